@@ -1,6 +1,8 @@
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"os"
@@ -10,6 +12,7 @@ import (
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 	"github.com/tinytelemetry/tiny-telemetry/internal/socketrpc"
+	"github.com/tinytelemetry/tiny-telemetry/internal/tui"
 	"github.com/spf13/viper"
 )
 
@@ -19,14 +22,25 @@ const (
 	defaultSkin           = model.DefaultSkin
 )
 
-// cliConfig holds only TUI-relevant configuration.
+// cliConfig holds only TUI-relevant configuration; config.yml beside this
+// file documents it.
 type cliConfig struct {
-	UpdateInterval     time.Duration `mapstructure:"update-interval"`
-	LogBuffer          int           `mapstructure:"log-buffer"`
-	Skin               string        `mapstructure:"skin"`
-	ReverseScrollWheel bool          `mapstructure:"reverse-scroll-wheel"`
-	UseLogTime         bool          `mapstructure:"use-log-time"`
-	SocketPath         string        `mapstructure:"socket-path"`
+	UpdateInterval     time.Duration     `mapstructure:"update-interval"`
+	LogBuffer          int               `mapstructure:"log-buffer"`
+	Skin               string            `mapstructure:"skin"`
+	Keymap             string            `mapstructure:"keymap"` // a preset or keymap file; "" = keymap.yml if present
+	ReverseScrollWheel bool              `mapstructure:"reverse-scroll-wheel"`
+	UseLogTime         bool              `mapstructure:"use-log-time"`
+	SocketPath         string            `mapstructure:"socket-path"`
+	RPCAddr            string            `mapstructure:"rpc-addr"`  // host:port of rpc-tcp-*; empty = the socket
+	RPCToken           string            `mapstructure:"rpc-token"` // the server's rpc-tcp-token
+	RPCTLS             bool              `mapstructure:"rpc-tls"`
+	RPCTLSCA           string            `mapstructure:"rpc-tls-ca"` // trust this CA instead of the system roots
+	RPCTLSCert         string            `mapstructure:"rpc-tls-cert"`
+	RPCTLSKey          string            `mapstructure:"rpc-tls-key"`
+	LogTemplates       map[string]string `mapstructure:"log-templates"`
+	WorkspaceDir       string            `mapstructure:"workspace-dir"`
+	Pages              []tui.PageConfig  `mapstructure:"pages"`
 }
 
 func loadCLIConfig(configPath string) (cliConfig, error) {
@@ -48,6 +62,7 @@ func loadCLIConfig(configPath string) (cliConfig, error) {
 	v.SetDefault("reverse-scroll-wheel", false)
 	v.SetDefault("use-log-time", false)
 	v.SetDefault("socket-path", socketrpc.DefaultSocketPath())
+	v.SetDefault("workspace-dir", filepath.Join(home, ".local", "share", "tiny-telemetry", "workspaces"))
 
 	if configPath != "" {
 		v.SetConfigFile(configPath)
@@ -65,6 +80,45 @@ func loadCLIConfig(configPath string) (cliConfig, error) {
 	if err := v.Unmarshal(&cfg); err != nil {
 		return cfg, err
 	}
+	for viewID, src := range cfg.LogTemplates {
+		if _, err := tui.ParseLogTemplate(src); err != nil {
+			return cfg, fmt.Errorf("invalid log-templates.%s: %w", viewID, err)
+		}
+	}
+	if _, err := tui.PageSpecsFromConfig(cfg.Pages); err != nil {
+		return cfg, fmt.Errorf("invalid %w", err)
+	}
 
 	return cfg, nil
 }
+
+// rpcTLS returns the TLS setup for dialing rpc-addr; nil when the
+// connection is plaintext.
+func rpcTLS(cfg cliConfig) (*tls.Config, error) {
+	if !cfg.RPCTLS && cfg.RPCTLSCA == "" && cfg.RPCTLSCert == "" && cfg.RPCTLSKey == "" {
+		return nil, nil
+	}
+	if (cfg.RPCTLSCert == "") != (cfg.RPCTLSKey == "") {
+		return nil, fmt.Errorf("rpc-tls-cert and rpc-tls-key must be set together")
+	}
+	conf := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.RPCTLSCA != "" {
+		pem, err := os.ReadFile(cfg.RPCTLSCA)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("rpc-tls-ca %s: no PEM certificates", cfg.RPCTLSCA)
+		}
+		conf.RootCAs = pool
+	}
+	if cfg.RPCTLSCert != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.RPCTLSCert, cfg.RPCTLSKey)
+		if err != nil {
+			return nil, err
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
+}
@@ -26,10 +26,14 @@ var (
 func main() {
 	var configPath string
 	var socketPath string
+	var addr string
+	var workspace string
 	var showVersion bool
 
 	flag.StringVar(&configPath, "config", "", "config file (default is $HOME/.config/tiny-telemetry/config.yml)")
 	flag.StringVar(&socketPath, "socket", "", "override socket path to connect to tiny-telemetry service")
+	flag.StringVar(&addr, "addr", "", "connect over TCP to host:port (the service's rpc-tcp-addr) instead of the socket")
+	flag.StringVar(&workspace, "workspace", "", "load a saved workspace (file path or name in workspace-dir)")
 	flag.BoolVar(&showVersion, "version", false, "print version information")
 	flag.Parse()
 
@@ -51,22 +55,25 @@ func main() {
 	if socketPath != "" {
 		cfg.SocketPath = socketPath
 	}
+	if addr != "" {
+		cfg.RPCAddr = addr
+	}
 
-	if err := runTUI(cfg); err != nil {
+	if err := runTUI(cfg, workspace); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func runTUI(cfg cliConfig) error {
+func runTUI(cfg cliConfig, workspace string) error {
 	configDir := os.Getenv("HOME") + "/.config/tiny-telemetry"
 	if err := tui.InitializeSkin(cfg.Skin, configDir); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: Failed to load skin '%s': %v (using default)\n", cfg.Skin, err)
 	}
 
-	client, err := socketrpc.Dial(cfg.SocketPath)
+	client, source, err := dialService(cfg)
 	if err != nil {
-		return fmt.Errorf("cannot connect to tiny-telemetry service at %s: %w\nIs the tiny-telemetry service running? Start it with: tiny-telemetry", cfg.SocketPath, err)
+		return err
 	}
 	defer func() {
 		done := make(chan struct{})
@@ -79,7 +86,25 @@ func runTUI(cfg cliConfig) error {
 		}
 	}()
 
-	dashboard := tui.NewDashboardModel(cfg.LogBuffer, cfg.UpdateInterval, cfg.ReverseScrollWheel, cfg.UseLogTime, client, "Socket")
+	dashboard := tui.NewDashboardModel(cfg.LogBuffer, cfg.UpdateInterval, cfg.ReverseScrollWheel, cfg.UseLogTime, client, source)
+	keys, err := tui.LoadKeyMap(cfg.Keymap, configDir)
+	if err != nil {
+		return fmt.Errorf("keymap: %w", err)
+	}
+	dashboard.SetKeyMap(keys)
+	if err := dashboard.SetLogTemplates(cfg.LogTemplates); err != nil {
+		return err
+	}
+	if err := dashboard.SetConfigPages(cfg.Pages); err != nil {
+		return fmt.Errorf("pages: %w", err)
+	}
+	dashboard.SetWorkspaceDir(cfg.WorkspaceDir)
+	dashboard.SetConfigDir(configDir)
+	if workspace != "" {
+		if err := dashboard.ApplyWorkspaceFile(tui.ResolveWorkspacePath(cfg.WorkspaceDir, workspace)); err != nil {
+			return fmt.Errorf("loading workspace: %w", err)
+		}
+	}
 	dashView := tui.NewDashboardView(dashboard)
 	app := tui.NewApp(dashView)
 
@@ -99,3 +124,38 @@ func runTUI(cfg cliConfig) error {
 
 	return nil
 }
+
+// dialService connects to the service's socket, or to its TCP listener
+// when rpc-addr is set, and names the connection for the status bar.
+func dialService(cfg cliConfig) (*socketrpc.Client, string, error) {
+	if cfg.RPCAddr == "" {
+		client, err := socketrpc.Dial(cfg.SocketPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("cannot connect to tiny-telemetry service at %s: %w\nIs the tiny-telemetry service running? Start it with: tiny-telemetry", cfg.SocketPath, err)
+		}
+		return client, "Socket" + serverTag(client), nil
+	}
+	conf, err := rpcTLS(cfg)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid rpc-tls: %w", err)
+	}
+	client, err := socketrpc.DialTCP(cfg.RPCAddr, socketrpc.TCPOptions{Token: cfg.RPCToken, TLS: conf})
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot connect to tiny-telemetry service at %s: %w\nIs rpc-tcp-enabled set on the service, with this rpc-token?", cfg.RPCAddr, err)
+	}
+	return client, "TCP" + serverTag(client), nil
+}
+
+// serverTag notes in the status bar a server built from another version,
+// which may lack features this TUI then leaves out.
+func serverTag(client *socketrpc.Client) string {
+	hello, ok := client.Hello()
+	switch {
+	case !ok:
+		return " (older server)"
+	case hello.Version != version:
+		return " (server " + hello.Version + ")"
+	default:
+		return ""
+	}
+}
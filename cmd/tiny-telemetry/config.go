@@ -1,8 +1,16 @@
 package main
 
 import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/memstore"
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 )
 
@@ -10,12 +18,24 @@ const (
 	defaultUpdateInterval      = model.DefaultUpdateInterval
 	defaultLogBuffer           = model.DefaultLogBuffer
 	defaultBindHost            = "127.0.0.1"
+	defaultTCPPort             = 4000
 	defaultGRPCPort            = 4317
+	defaultBeatsPort           = 5044
+	defaultCloudWatchPort      = 5080
+	defaultLogplexPort         = 5081
 	defaultMuxBufferSize       = DefaultMuxBuffer
 	defaultSkin                = model.DefaultSkin
 	defaultAPIPort             = 5000
+	defaultReadGRPCPort        = 4320
+	defaultRPCTCPPort          = 4321
 	defaultQueryTimeout        = 30 * time.Second
 	defaultMaxConcurrentReads  = 8
+	defaultQueryMaxScanRows    = 50_000_000
+	defaultQueryMaxRows        = 1000
+	defaultQueryCacheTTL       = model.DefaultUpdateInterval
+	defaultExportMaxRows       = 1_000_000
+	defaultExportTimeout       = 5 * time.Minute
+	defaultAPIRateBurst        = 10
 	defaultInsertBatchSize     = 2000
 	defaultInsertFlushInterval = 100 * time.Millisecond
 	defaultInsertFlushQueue    = 64
@@ -24,6 +44,14 @@ const (
 	defaultBackupInterval      = 6 * time.Hour
 	defaultBackupKeepLast      = 24
 	defaultBackupS3Region      = "us-east-1"
+	defaultStorageBackend      = storageBackendDuckDB
+	defaultMemoryMaxRecords    = memstore.DefaultMaxRecords
+)
+
+// Storage backends selectable with storage-backend.
+const (
+	storageBackendDuckDB = "duckdb"
+	storageBackendMemory = "memory"
 )
 
 // appConfig is internal runtime configuration.
@@ -33,27 +61,82 @@ type appConfig struct {
 	LogBuffer            int           `mapstructure:"log-buffer"`
 	TestMode             bool          `mapstructure:"test-mode"`
 	Host                 string        `mapstructure:"host"`
+	TCPEnabled           bool          `mapstructure:"tcp-enabled"`
+	TCPPort              int           `mapstructure:"tcp-port"`
+	TCPAddr              string        `mapstructure:"tcp-addr"`
+	TCPTLSCert           string        `mapstructure:"tcp-tls-cert"`
+	TCPTLSKey            string        `mapstructure:"tcp-tls-key"`
+	TCPTLSClientCA       string        `mapstructure:"tcp-tls-client-ca"`
+	TCPTLS               *tls.Config   `mapstructure:"-"` // loaded from the tcp-tls-* files
 	GRPCEnabled          bool          `mapstructure:"grpc-enabled"`
 	GRPCPort             int           `mapstructure:"grpc-port"`
 	GRPCAddr             string        `mapstructure:"grpc-addr"`
+	BeatsEnabled         bool          `mapstructure:"beats-enabled"`
+	BeatsPort            int           `mapstructure:"beats-port"`
+	BeatsAddr            string        `mapstructure:"beats-addr"`
+	CloudWatchEnabled    bool          `mapstructure:"cloudwatch-enabled"`
+	CloudWatchPort       int           `mapstructure:"cloudwatch-port"`
+	CloudWatchAddr       string        `mapstructure:"cloudwatch-addr"`
+	CloudWatchAccessKey  string        `mapstructure:"cloudwatch-access-key"`
+	LogplexEnabled       bool          `mapstructure:"logplex-enabled"`
+	LogplexPort          int           `mapstructure:"logplex-port"`
+	LogplexAddr          string        `mapstructure:"logplex-addr"`
+	LogplexDrainToken    string        `mapstructure:"logplex-drain-token"`
 	MuxBufferSize        int           `mapstructure:"mux-buffer-size"`
 	DBPath               string        `mapstructure:"db-path"`
 	Skin                 string        `mapstructure:"skin"`
 	DisableVersionCheck  bool          `mapstructure:"disable-version-check"`
+	VersionCheckEndpoint string        `mapstructure:"version-check-endpoint"`
+	VersionCheckAnonID   bool          `mapstructure:"version-check-anon-id"`
+	VersionCheckProxy    string        `mapstructure:"version-check-proxy"`
+	VersionCheckCacheTTL time.Duration `mapstructure:"version-check-cache-ttl"`
+	VersionCheckCache    string        `mapstructure:"version-check-cache-path"`
+	Offline              bool          `mapstructure:"offline"`
 	ReverseScrollWheel   bool          `mapstructure:"reverse-scroll-wheel"`
 	UseLogTime           bool          `mapstructure:"use-log-time"`
 	APIEnabled           bool          `mapstructure:"api-enabled"`
 	APIPort              int           `mapstructure:"api-port"`
 	APIAddr              string        `mapstructure:"api-addr"`
+	APIKeys              []apiKey      `mapstructure:"api-keys"`
+	APIRateLimit         float64       `mapstructure:"api-rate-limit"`
+	APIRateBurst         int           `mapstructure:"api-rate-burst"`
+	APIAccessLog         string        `mapstructure:"api-access-log"`
+	APITLSCert           string        `mapstructure:"api-tls-cert"`
+	APITLSKey            string        `mapstructure:"api-tls-key"`
+	APITLSClientCA       string        `mapstructure:"api-tls-client-ca"`
+	APITLS               *tls.Config   `mapstructure:"-"` // loaded from the api-tls-* files
+	ReadGRPCEnabled      bool          `mapstructure:"read-grpc-enabled"`
+	ReadGRPCPort         int           `mapstructure:"read-grpc-port"`
+	ReadGRPCAddr         string        `mapstructure:"read-grpc-addr"`
+	RPCTCPEnabled        bool          `mapstructure:"rpc-tcp-enabled"`
+	RPCTCPPort           int           `mapstructure:"rpc-tcp-port"`
+	RPCTCPAddr           string        `mapstructure:"rpc-tcp-addr"`
+	RPCTCPToken          string        `mapstructure:"rpc-tcp-token"`
 	QueryTimeout         time.Duration `mapstructure:"query-timeout"`
 	MaxConcurrentReads   int           `mapstructure:"max-concurrent-queries"`
+	QueryMaxScanRows     int64         `mapstructure:"query-max-scan-rows"`
+	QueryMaxRows         int           `mapstructure:"query-max-rows"`
+	QueryCacheTTL        time.Duration `mapstructure:"query-cache-ttl"`
+	ExportMaxRows        int64         `mapstructure:"export-max-rows"`
+	ExportTimeout        time.Duration `mapstructure:"export-timeout"`
 	InsertBatchSize      int           `mapstructure:"insert-batch-size"`
 	InsertFlushInterval  time.Duration `mapstructure:"insert-flush-interval"`
 	InsertFlushQueue     int           `mapstructure:"insert-flush-queue-size"`
 	JournalEnabled       bool          `mapstructure:"journal-enabled"`
 	JournalPath          string        `mapstructure:"journal-path"`
 	SocketPath           string        `mapstructure:"socket-path"`
+	SocketMode           string        `mapstructure:"socket-mode"`
+	SocketFileMode       os.FileMode   `mapstructure:"-"` // parsed from SocketMode
+	SocketOwner          string        `mapstructure:"socket-owner"`
+	SocketGroup          string        `mapstructure:"socket-group"`
+	SocketAllowUsers     []string      `mapstructure:"socket-allow-users"`
+	SocketAllowGroups    []string      `mapstructure:"socket-allow-groups"`
 	LogRetention         int           `mapstructure:"log-retention"`
+	MaxDBSize            string        `mapstructure:"max-db-size"`
+	MaxDBSizeBytes       int64         `mapstructure:"-"` // parsed from MaxDBSize
+	MaxRowCount          int64         `mapstructure:"max-row-count"`
+	MaintenanceInterval  time.Duration `mapstructure:"maintenance-interval"`
+	MaintenanceWindow    string        `mapstructure:"maintenance-window"`
 	BackupEnabled        bool          `mapstructure:"backup-enabled"`
 	BackupInterval       time.Duration `mapstructure:"backup-interval"`
 	BackupLocalDir       string        `mapstructure:"backup-local-dir"`
@@ -65,5 +148,195 @@ type appConfig struct {
 	BackupS3SecretKey    string        `mapstructure:"backup-s3-secret-key"`
 	BackupS3SessionToken string        `mapstructure:"backup-s3-session-token"`
 	BackupS3UseSSL       bool          `mapstructure:"backup-s3-use-ssl"`
+	StorageMinSeverity   []appSeverity `mapstructure:"storage-min-severity"`
+	StorageSampling      []appSample   `mapstructure:"storage-sampling"`
+	AlertRules           []alertRule   `mapstructure:"alert-rules"`
 	ConfigPath           string        `mapstructure:"-"` // not from config file
+	TCPListener          net.Listener  `mapstructure:"-"` // systemd socket "tcp"
+	APIListener          net.Listener  `mapstructure:"-"` // systemd socket "api"
+
+	// IngestAuth checks a key sent to the HTTP ingest listeners against
+	// api-keys for the ingest scope; nil when no api-keys are set.
+	IngestAuth func(key string) error `mapstructure:"-"`
+
+	// SourceParsers maps an input source ("tcp", "stdin") to a line parser name.
+	SourceParsers map[string]string `mapstructure:"source-parsers"`
+	// GrokPatterns are reusable %{NAME} patterns; GrokParsers are named grok
+	// expressions selectable in source-parsers.
+	GrokPatterns map[string]string `mapstructure:"grok-patterns"`
+	GrokParsers  map[string]string `mapstructure:"grok-parsers"`
+	// CSVParsers are named delimiter-separated parsers selectable in
+	// source-parsers.
+	CSVParsers map[string]csvParser `mapstructure:"csv-parsers"`
+	// SourceExtractors maps an input source to regular expressions with
+	// named groups, tried before the source's parser.
+	SourceExtractors map[string][]string `mapstructure:"source-extractors"`
+
+	// StorageBackend selects the log store: "duckdb" (default) or "memory".
+	StorageBackend   string `mapstructure:"storage-backend"`
+	MemoryMaxRecords int    `mapstructure:"memory-max-records"`
+	// MaintenanceWindowRange is parsed from MaintenanceWindow.
+	MaintenanceWindowRange duckdb.MaintenanceWindow `mapstructure:"-"`
+	// DuckDBMemoryLimit, DuckDBThreads, and DuckDBTempDir cap DuckDB's
+	// resources; unset keeps DuckDB's defaults.
+	DuckDBMemoryLimit      string `mapstructure:"duckdb-memory-limit"`
+	DuckDBMemoryLimitBytes int64  `mapstructure:"-"` // parsed from DuckDBMemoryLimit
+	DuckDBThreads          int    `mapstructure:"duckdb-threads"`
+	DuckDBTempDir          string `mapstructure:"duckdb-temp-dir"`
+	// SearchIndex maintains the DuckDB trigram index used by message search.
+	SearchIndex bool `mapstructure:"search-index"`
+	// PartitionByDay converts logs into one DuckDB table per UTC day.
+	PartitionByDay bool `mapstructure:"partition-by-day"`
+	// ArchiveAfter moves day partitions older than this many days into
+	// Parquet files under ArchiveDir; 0 disables.
+	ArchiveAfter int    `mapstructure:"archive-after"`
+	ArchiveDir   string `mapstructure:"archive-dir"`
+	// RotatePeriod ("day", "week", or "month") moves each finished period
+	// out of the database file into a read-only file under RotateDir; ""
+	// disables.
+	RotatePeriod string `mapstructure:"rotate-period"`
+	RotateDir    string `mapstructure:"rotate-dir"`
+	// PromoteAttributes lists attribute keys stored in their own typed
+	// DuckDB columns.
+	PromoteAttributes []promotedAttribute `mapstructure:"promote-attributes"`
+	// DedupWindow skips records whose event_id is already stored within
+	// this window; 0 disables. DedupKey picks where event_ids come from.
+	DedupWindow time.Duration `mapstructure:"dedup-window"`
+	DedupKey    string        `mapstructure:"dedup-key"`
+	// RawLine is how raw lines are stored: always, differs (only when not
+	// the message), never, or compress (zstd).
+	RawLine string `mapstructure:"raw-line"`
+	// PatternMining mines message templates from stored logs for
+	// /api/patterns.
+	PatternMining bool `mapstructure:"pattern-mining"`
+
+	// TCPAcks acknowledges TCP batches once they reach the ingest journal.
+	TCPAcks bool `mapstructure:"tcp-acks"`
+
+	// Multiline maps an input source to a rule folding continuation lines
+	// (stack traces) into the record before them.
+	Multiline map[string]multilineRule `mapstructure:"multiline"`
+
+	// TimestampFormats are extra strptime or Go layouts tried when parsing
+	// timestamp fields.
+	TimestampFormats []string `mapstructure:"timestamp-formats"`
+}
+
+// multilineRule selects continuation lines and bounds how many are joined.
+type multilineRule struct {
+	Pattern  string        `mapstructure:"pattern"`
+	MaxLines int           `mapstructure:"max-lines"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// csvParser maps the columns of a delimiter-separated format to fields.
+type csvParser struct {
+	Delimiter string   `mapstructure:"delimiter"`
+	Columns   []string `mapstructure:"columns"`
+}
+
+// promotedAttribute names an attribute key to copy into a typed column.
+type promotedAttribute struct {
+	Key  string `mapstructure:"key"`
+	Type string `mapstructure:"type"`
+}
+
+// promotedAttributeTypes are the types promote-attributes accepts.
+var promotedAttributeTypes = []string{"string", "integer", "float", "boolean"}
+
+// appSeverity sets the lowest severity stored for one app ("*" = all apps).
+type appSeverity struct {
+	App         string `mapstructure:"app"`
+	MinSeverity string `mapstructure:"min-severity"`
+}
+
+// appSample keeps a fraction of one app's records at one severity
+// ("*" = all apps; no level = all severities).
+type appSample struct {
+	App   string  `mapstructure:"app"`
+	Level string  `mapstructure:"level"`
+	Rate  float64 `mapstructure:"rate"`
+}
+
+// alertRule fires when more than threshold logs of an app and level arrive
+// within window, for at least for; see alerting.Rule.
+type alertRule struct {
+	Name      string        `mapstructure:"name"`
+	App       string        `mapstructure:"app"`
+	Level     string        `mapstructure:"level"`
+	Threshold float64       `mapstructure:"threshold"`
+	Window    time.Duration `mapstructure:"window"`
+	For       time.Duration `mapstructure:"for"`
+	Severity  string        `mapstructure:"severity"`
+	Summary   string        `mapstructure:"summary"`
+}
+
+// apiKey is a key accepted by the HTTP API, limited to scopes (read, query,
+// ingest, admin) when any are listed and to the logs of apps when any are.
+type apiKey struct {
+	Name   string   `mapstructure:"name"`
+	Key    string   `mapstructure:"key"`
+	Scopes []string `mapstructure:"scopes"`
+	Apps   []string `mapstructure:"apps"`
+}
+
+// byteUnits are the size suffixes parseByteSize accepts: decimal (KB, MB,
+// GB, TB) and binary (KiB, MiB, GiB, TiB).
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// parseByteSize parses a size such as "500MB", "1.5GiB", or "1048576".
+func parseByteSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(s)
+	}
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	unit, ok := byteUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit in %q", s)
+	}
+	return int64(n * unit), nil
+}
+
+// parseMaintenanceWindow parses a daily "HH:MM-HH:MM" local time range; a
+// range whose start is after its end wraps midnight.
+func parseMaintenanceWindow(s string) (duckdb.MaintenanceWindow, error) {
+	start, end, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return duckdb.MaintenanceWindow{}, fmt.Errorf("want HH:MM-HH:MM")
+	}
+	parse := func(clock string) (time.Duration, error) {
+		t, err := time.Parse("15:04", strings.TrimSpace(clock))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+	var w duckdb.MaintenanceWindow
+	var err error
+	if w.Start, err = parse(start); err != nil {
+		return w, err
+	}
+	if w.End, err = parse(end); err != nil {
+		return w, err
+	}
+	if w.Start == w.End {
+		return w, fmt.Errorf("window is empty")
+	}
+	return w, nil
 }
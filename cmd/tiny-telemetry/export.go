@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/httpserver"
+	"github.com/tinytelemetry/tiny-telemetry/pkg/lotus"
+)
+
+// runExport implements "tiny-telemetry export": it asks the running
+// server's HTTP API for the logs matching the flags and writes the file.
+func runExport(cfg appConfig, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "parquet", "output format: parquet, csv, or ndjson")
+	out := fs.String("o", "", "output file, or - for stdout (required)")
+	app := fs.String("app", "", "only export this app")
+	from := fs.String("from", "", "start time: RFC 3339 or a duration before now, e.g. 24h")
+	to := fs.String("to", "", "end time (exclusive): RFC 3339 or a duration before now")
+	certFile := fs.String("cert", "", "client certificate, when the API requires one (api-tls-client-ca)")
+	keyFile := fs.String("key", "", "client certificate key")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "parquet" && *format != "csv" && *format != "ndjson" {
+		return fmt.Errorf("unsupported export format: %q (want parquet, csv, or ndjson)", *format)
+	}
+	if *out == "" {
+		return fmt.Errorf("export: -o is required")
+	}
+	if !cfg.APIEnabled {
+		return fmt.Errorf("export needs the HTTP API (api-enabled: true)")
+	}
+
+	now := time.Now()
+	opts := lotus.QueryOpts{App: *app}
+	var err error
+	if opts.From, err = parseExportTime(*from, now); err != nil {
+		return fmt.Errorf("invalid -from: %w", err)
+	}
+	if opts.To, err = parseExportTime(*to, now); err != nil {
+		return fmt.Errorf("invalid -to: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	httpClient, scheme, err := apiHTTPClient(cfg, *certFile, *keyFile)
+	if err != nil {
+		return err
+	}
+	client := lotus.NewHTTPClient(scheme+"://"+dialAddr(cfg.APIAddr), lotus.HTTPConfig{HTTPClient: httpClient, APIKey: readKey(cfg)})
+	if *out == "-" {
+		_, err := client.Export(ctx, *format, opts, os.Stdout)
+		return err
+	}
+
+	// Write beside the target and rename, so a failed export leaves no
+	// partial file behind.
+	f, err := os.CreateTemp(filepath.Dir(*out), ".export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	rows, err := client.Export(ctx, *format, opts, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), *out); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d rows to %s\n", rows, *out)
+	return nil
+}
+
+// apiHTTPClient returns a client for the server's HTTP API and its URL
+// scheme. With api-tls-cert set it speaks HTTPS and trusts exactly that
+// certificate, whatever host name it was issued for, presenting
+// certFile/keyFile when given.
+func apiHTTPClient(cfg appConfig, certFile, keyFile string) (*http.Client, string, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, "", fmt.Errorf("export: -cert and -key must be set together")
+	}
+	if cfg.APITLS == nil {
+		return &http.Client{}, "http", nil
+	}
+	pinned := cfg.APITLS.Certificates[0].Certificate[0]
+	conf := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The pin below replaces chain and host name verification.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 || !bytes.Equal(cs.PeerCertificates[0].Raw, pinned) {
+				return fmt.Errorf("server certificate does not match api-tls-cert")
+			}
+			return nil
+		},
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("export: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: conf}}, "https", nil
+}
+
+// readKey returns the first configured API key granting the read scope on
+// every app, or "" when the API is open.
+func readKey(cfg appConfig) string {
+	for _, k := range cfg.APIKeys {
+		if len(k.Apps) > 0 {
+			continue
+		}
+		if len(k.Scopes) == 0 || slices.ContainsFunc(k.Scopes, func(scope string) bool {
+			scope = strings.ToLower(strings.TrimSpace(scope))
+			return scope == httpserver.ScopeRead || scope == httpserver.ScopeAdmin
+		}) {
+			return k.Key
+		}
+	}
+	return ""
+}
+
+// parseExportTime parses an RFC 3339 time or a duration before now; empty
+// means unbounded.
+func parseExportTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("%q is neither RFC 3339 nor a positive duration", value)
+	}
+	return now.Add(-d), nil
+}
+
+// dialAddr replaces a wildcard listen host with loopback so the CLI can
+// connect to it.
+func dialAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/httpserver"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestRunExport_Parquet(t *testing.T) {
+	store, err := duckdb.NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	now := time.Now()
+	err = store.InsertLogBatch([]*model.LogRecord{
+		{Timestamp: now.Add(-2 * time.Hour), Level: "INFO", Message: "old", App: "shop"},
+		{Timestamp: now.Add(-time.Minute), Level: "INFO", Message: "recent", App: "shop"},
+	})
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	srv := httpserver.NewServer("127.0.0.1:0", store)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("start http server: %v", err)
+	}
+	defer srv.Stop()
+
+	cfg := appConfig{APIEnabled: true, APIAddr: srv.Addr()}
+	out := filepath.Join(t.TempDir(), "logs.parquet")
+	if err := runExport(cfg, []string{"-o", out, "-from", "1h"}); err != nil {
+		t.Fatalf("runExport: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("PAR1")) {
+		t.Fatalf("export is not a Parquet file (%d bytes)", len(data))
+	}
+
+	csvOut := filepath.Join(t.TempDir(), "logs.csv")
+	if err := runExport(cfg, []string{"-o", csvOut, "-format", "csv", "-from", "1h"}); err != nil {
+		t.Fatalf("runExport csv: %v", err)
+	}
+	if data, err := os.ReadFile(csvOut); err != nil || bytes.Count(data, []byte("\n")) != 2 || !bytes.Contains(data, []byte("recent")) {
+		t.Fatalf("csv export = %q, %v; want a header and the recent log", data, err)
+	}
+	if err := runExport(cfg, []string{"-o", out, "-format", "xml"}); err == nil {
+		t.Fatal("xml format should be rejected")
+	}
+	if err := runExport(cfg, []string{"-o", out, "-from", "yesterday"}); err == nil {
+		t.Fatal("invalid -from should be rejected")
+	}
+}
+
+// writeTestCert writes a self-signed certificate for 127.0.0.1 and its key
+// as PEM files in dir.
+func writeTestCert(t *testing.T, dir, name string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestRunExport_MutualTLS(t *testing.T) {
+	store, err := duckdb.NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	if err := store.InsertLogBatch([]*model.LogRecord{{Timestamp: time.Now(), Level: "INFO", Message: "secret"}}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	dir := t.TempDir()
+	serverCert, serverKey := writeTestCert(t, dir, "server")
+	clientCert, clientKey := writeTestCert(t, dir, "client")
+	tlsConf, err := serverTLS(tlsFiles{Cert: serverCert, Key: serverKey, ClientCA: clientCert})
+	if err != nil {
+		t.Fatalf("serverTLS: %v", err)
+	}
+
+	srv := httpserver.NewServer("127.0.0.1:0", store)
+	srv.SetTLSConfig(tlsConf)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("start http server: %v", err)
+	}
+	defer srv.Stop()
+
+	cfg := appConfig{APIEnabled: true, APIAddr: srv.Addr(), APITLS: tlsConf}
+	out := filepath.Join(dir, "logs.ndjson")
+	if err := runExport(cfg, []string{"-o", out, "-format", "ndjson"}); err == nil {
+		t.Fatal("export without a client certificate succeeded")
+	}
+	if err := runExport(cfg, []string{"-o", out, "-format", "ndjson", "-cert", clientCert, "-key", clientKey}); err != nil {
+		t.Fatalf("runExport: %v", err)
+	}
+	if data, err := os.ReadFile(out); err != nil || !bytes.Contains(data, []byte("secret")) {
+		t.Fatalf("export = %q, %v", data, err)
+	}
+
+	// A server with another certificate is not trusted.
+	otherCert, otherKey := writeTestCert(t, dir, "other")
+	if cfg.APITLS, err = serverTLS(tlsFiles{Cert: otherCert, Key: otherKey}); err != nil {
+		t.Fatalf("serverTLS: %v", err)
+	}
+	if err := runExport(cfg, []string{"-o", out, "-format", "ndjson", "-cert", clientCert, "-key", clientKey}); err == nil {
+		t.Fatal("export trusted a server certificate other than api-tls-cert")
+	}
+}
+
+func TestDialAddr(t *testing.T) {
+	for in, want := range map[string]string{
+		"0.0.0.0:5000":   "127.0.0.1:5000",
+		"[::]:5000":      "127.0.0.1:5000",
+		":5000":          "127.0.0.1:5000",
+		"10.0.0.5:5000":  "10.0.0.5:5000",
+		"localhost:5000": "localhost:5000",
+	} {
+		if got := dialAddr(in); got != want {
+			t.Errorf("dialAddr(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
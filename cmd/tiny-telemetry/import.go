@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/timestamp"
+)
+
+// runImport implements "tiny-telemetry import": it loads Parquet or NDJSON
+// files into the DuckDB database. DuckDB allows one writer process, so the
+// server must not be running against the same db-path.
+func runImport(cfg appConfig, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	parser := fs.String("parser", "", "line parser for NDJSON files, e.g. app-json or otel (default: rows of logs columns)")
+	app := fs.String("app", "", "set app on records that have none")
+	batch := fs.Int("batch", duckdb.DefaultImportBatchSize, "records per insert batch")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("import: no files given")
+	}
+	if cfg.StorageBackend != storageBackendDuckDB {
+		return fmt.Errorf("import requires storage-backend: %s", storageBackendDuckDB)
+	}
+	if cfg.DBPath == "" {
+		return fmt.Errorf("import requires an on-disk db-path")
+	}
+
+	var parse func(string) []*model.LogRecord
+	if *parser != "" {
+		p, err := ingest.ParserByName(*parser)
+		if err != nil {
+			return fmt.Errorf("invalid -parser: %w", err)
+		}
+		layouts, err := timestamp.Layouts(cfg.TimestampFormats)
+		if err != nil {
+			return fmt.Errorf("invalid timestamp-formats: %w", err)
+		}
+		ingest.SetTimestampLayouts(layouts)
+		parse = p.Parse
+	}
+	store, err := duckdb.NewStoreWithConfig(cfg.DBPath, duckDBConfig(cfg))
+	if err != nil {
+		return fmt.Errorf("open %s (is the server running?): %w", cfg.DBPath, err)
+	}
+	defer store.Close()
+
+	var total int64
+	for _, path := range fs.Args() {
+		conf := duckdb.ImportConfig{
+			BatchSize:  *batch,
+			ParseLine:  parse,
+			DefaultApp: *app,
+			Progress: func(rows int64) {
+				fmt.Fprintf(os.Stderr, "\r%s: %d rows", path, rows)
+			},
+		}
+		n, err := store.ImportFile(path, conf)
+		if n > 0 {
+			fmt.Fprintln(os.Stderr)
+		}
+		total += n
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "imported %d rows into %s\n", total, cfg.DBPath)
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestRunImport_NDJSONWithParser(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "pino.ndjson")
+	err := os.WriteFile(file, []byte(`{"level":50,"time":1735732800000,"msg":"payment failed","order":42}
+{"level":30,"time":1735732801000,"msg":"retry ok"}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := appConfig{StorageBackend: storageBackendDuckDB, DBPath: filepath.Join(dir, "logs.duckdb")}
+	if err := runImport(cfg, []string{"-parser", "app-json", "-app", "billing", file}); err != nil {
+		t.Fatalf("runImport: %v", err)
+	}
+
+	store, err := duckdb.NewStore(cfg.DBPath)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+	logs, err := store.RecentLogsFiltered(10, model.QueryOpts{App: "billing"}, []string{"ERROR"}, nil, "")
+	if err != nil || len(logs) != 1 {
+		t.Fatalf("RecentLogsFiltered = %+v, %v", logs, err)
+	}
+	if logs[0].Message != "payment failed" || logs[0].Attributes["order"] != "42" || logs[0].Source != "import" {
+		t.Fatalf("record = %+v", logs[0])
+	}
+
+	if err := runImport(cfg, []string{"-parser", "nope", file}); err == nil {
+		t.Fatal("unknown parser should fail")
+	}
+	if err := runImport(cfg, nil); err == nil {
+		t.Fatal("missing files should fail")
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"net"
 	"os"
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/logsource"
@@ -17,8 +19,45 @@ type InputSourcePlugin interface {
 	Build(ctx context.Context) (NamedLogSource, error)
 }
 
-func buildInputPlugins() []InputSourcePlugin {
-	return []InputSourcePlugin{stdinInputPlugin{}}
+func buildInputPlugins(cfg appConfig) []InputSourcePlugin {
+	return []InputSourcePlugin{
+		tcpInputPlugin{enabled: cfg.TCPEnabled, addr: cfg.TCPAddr, listener: cfg.TCPListener, acks: cfg.TCPAcks, tls: cfg.TCPTLS},
+		stdinInputPlugin{},
+		logplexInputPlugin{enabled: cfg.LogplexEnabled, addr: cfg.LogplexAddr, drainToken: cfg.LogplexDrainToken, authorize: cfg.IngestAuth},
+	}
+}
+
+// tcpInputPlugin accepts newline-delimited logs over TCP. Each connection
+// may send plain text or a gzip/zstd compressed stream.
+type tcpInputPlugin struct {
+	enabled  bool
+	addr     string
+	listener net.Listener // socket-activated listener, if any
+	acks     bool         // per-batch acknowledgements (tcp-acks)
+	tls      *tls.Config  // tcp-tls-*, if set
+}
+
+func (p tcpInputPlugin) Name() string  { return "tcp" }
+func (p tcpInputPlugin) Enabled() bool { return p.enabled }
+
+func (p tcpInputPlugin) Build(ctx context.Context) (NamedLogSource, error) {
+	return logsource.NewTCPSource(ctx, p.addr, logsource.TCPConfig{Listener: p.listener, Acks: p.acks, TLS: p.tls})
+}
+
+// logplexInputPlugin is a Heroku Logplex HTTPS drain endpoint. Drain
+// bodies are octet-counted syslog messages, parsed by the syslog parser.
+type logplexInputPlugin struct {
+	enabled    bool
+	addr       string
+	drainToken string
+	authorize  func(key string) error // api-keys with the ingest scope, if set
+}
+
+func (p logplexInputPlugin) Name() string  { return "logplex" }
+func (p logplexInputPlugin) Enabled() bool { return p.enabled }
+
+func (p logplexInputPlugin) Build(ctx context.Context) (NamedLogSource, error) {
+	return logsource.NewLogplexSource(ctx, p.addr, logsource.LogplexConfig{DrainToken: p.drainToken, Authorize: p.authorize})
 }
 
 type stdinInputPlugin struct{}
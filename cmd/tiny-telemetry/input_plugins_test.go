@@ -1,22 +1,39 @@
 package main
 
 import (
+	"crypto/tls"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
 )
 
-func TestBuildInputPlugins_RegistersStdin(t *testing.T) {
+func TestBuildInputPlugins_RegistersTCPAndStdin(t *testing.T) {
 	t.Parallel()
 
-	plugins := buildInputPlugins()
+	plugins := buildInputPlugins(appConfig{TCPEnabled: true, TCPAddr: "127.0.0.1:4000"})
 
-	if len(plugins) != 1 {
-		t.Fatalf("expected 1 plugin, got %d", len(plugins))
+	if len(plugins) != 3 {
+		t.Fatalf("expected 3 plugins, got %d", len(plugins))
+	}
+	if plugins[0].Name() != "tcp" || !plugins[0].Enabled() {
+		t.Fatalf("plugins[0] = %q (enabled=%v), want enabled tcp", plugins[0].Name(), plugins[0].Enabled())
+	}
+	if plugins[1].Name() != "stdin" {
+		t.Fatalf("plugins[1] name = %q, want %q", plugins[1].Name(), "stdin")
 	}
-	if plugins[0].Name() != "stdin" {
-		t.Fatalf("plugins[0] name = %q, want %q", plugins[0].Name(), "stdin")
+	if plugins[2].Name() != "logplex" || plugins[2].Enabled() {
+		t.Fatalf("plugins[2] = %q (enabled=%v), want disabled logplex", plugins[2].Name(), plugins[2].Enabled())
+	}
+
+	disabled := buildInputPlugins(appConfig{})
+	if disabled[0].Enabled() {
+		t.Fatal("tcp plugin should be disabled when tcp-enabled is false")
 	}
 }
 
@@ -190,6 +207,30 @@ api-port: 3000
 			wantErr:      true,
 			errSubstring: "backup-s3-access-key and backup-s3-secret-key are required",
 		},
+		{
+			name: "offline rejects bucket upload",
+			configYAML: `
+offline: true
+backup-enabled: true
+backup-bucket-url: s3://my-bucket/tiny-telemetry
+backup-s3-access-key: key
+backup-s3-secret-key: secret
+grpc-port: 4317
+api-port: 3000
+`,
+			wantErr:      true,
+			errSubstring: "cannot be used with offline",
+		},
+		{
+			name: "invalid version check proxy rejected",
+			configYAML: `
+version-check-proxy: "not a url"
+grpc-port: 4317
+api-port: 3000
+`,
+			wantErr:      true,
+			errSubstring: "invalid version-check-proxy",
+		},
 	}
 
 	for _, tt := range tests {
@@ -216,6 +257,806 @@ api-port: 3000
 	}
 }
 
+func TestLoadConfig_StorageMinSeverity(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	configPath := writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+storage-min-severity:
+  - app: Payments
+    min-severity: INFO
+  - app: "*"
+    min-severity: debug
+`)
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if got := len(cfg.StorageMinSeverity); got != 2 {
+		t.Fatalf("storage-min-severity entries = %d, want 2", got)
+	}
+	if cfg.StorageMinSeverity[0].App != "Payments" || cfg.StorageMinSeverity[0].MinSeverity != "INFO" {
+		t.Fatalf("first entry = %+v, want app Payments at INFO", cfg.StorageMinSeverity[0])
+	}
+	if cfg.StorageMinSeverity[1].App != "*" {
+		t.Fatalf("second entry app = %q, want *", cfg.StorageMinSeverity[1].App)
+	}
+}
+
+func TestLoadConfig_StorageSampling(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+storage-sampling:
+  - app: payments
+    level: debug
+    rate: 0.1
+  - app: "*"
+    level: TRACE
+    rate: 0
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if got := len(cfg.StorageSampling); got != 2 {
+		t.Fatalf("storage-sampling entries = %d, want 2", got)
+	}
+	if cfg.StorageSampling[0] != (appSample{App: "payments", Level: "debug", Rate: 0.1}) {
+		t.Fatalf("first entry = %+v, want payments debug at 0.1", cfg.StorageSampling[0])
+	}
+
+	for _, tt := range []struct {
+		config string
+		want   string
+	}{
+		{"storage-sampling:\n  - app: payments\n    rate: 2\n", "invalid storage-sampling"},
+		{"storage-sampling:\n  - app: payments\n    level: loud\n    rate: 0.5\n", "invalid storage-sampling"},
+		{"storage-sampling:\n  - level: DEBUG\n    rate: 0.5\n", "invalid storage-sampling"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, tt.config))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("loadConfig(%q) error = %v, want %q", tt.config, err, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_ExportLimits(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.ExportMaxRows != defaultExportMaxRows || cfg.ExportTimeout != defaultExportTimeout {
+		t.Fatalf("export limits = %d, %s; want the defaults", cfg.ExportMaxRows, cfg.ExportTimeout)
+	}
+
+	for _, tt := range []struct {
+		config string
+		want   string
+	}{
+		{"export-max-rows: -1\n", "invalid export-max-rows"},
+		{"export-timeout: -1s\n", "invalid export-timeout"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, tt.config))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("loadConfig(%q) error = %v, want %q", tt.config, err, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_QueryMaxRows(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.QueryMaxRows != defaultQueryMaxRows {
+		t.Fatalf("QueryMaxRows = %d, want %d", cfg.QueryMaxRows, defaultQueryMaxRows)
+	}
+
+	for _, config := range []string{"query-max-rows: 0\n", "query-max-rows: -5\n"} {
+		_, err := loadConfig(writeTempConfig(t, config))
+		if err == nil || !strings.Contains(err.Error(), "invalid query-max-rows") {
+			t.Errorf("loadConfig(%q) error = %v, want invalid query-max-rows", config, err)
+		}
+	}
+}
+
+func TestLoadConfig_ReadGRPC(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.ReadGRPCEnabled || cfg.ReadGRPCAddr != "127.0.0.1:4320" {
+		t.Fatalf("read gRPC = %v on %q, want disabled on 127.0.0.1:4320", cfg.ReadGRPCEnabled, cfg.ReadGRPCAddr)
+	}
+
+	cfg, err = loadConfig(writeTempConfig(t, "read-grpc-enabled: true\nread-grpc-port: 9443\n"))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if !cfg.ReadGRPCEnabled || cfg.ReadGRPCAddr != "127.0.0.1:9443" {
+		t.Errorf("read gRPC = %v on %q, want enabled on 127.0.0.1:9443", cfg.ReadGRPCEnabled, cfg.ReadGRPCAddr)
+	}
+
+	_, err = loadConfig(writeTempConfig(t, "read-grpc-enabled: true\nread-grpc-port: 70000\n"))
+	if err == nil || !strings.Contains(err.Error(), "invalid read-grpc-port") {
+		t.Errorf("loadConfig error = %v, want invalid read-grpc-port", err)
+	}
+}
+
+func TestLoadConfig_RPCTCP(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\n"))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.RPCTCPEnabled || cfg.RPCTCPAddr != "127.0.0.1:4321" {
+		t.Fatalf("RPC TCP = %v on %q, want disabled on 127.0.0.1:4321", cfg.RPCTCPEnabled, cfg.RPCTCPAddr)
+	}
+
+	cfg, err = loadConfig(writeTempConfig(t, "host: 0.0.0.0\nrpc-tcp-enabled: true\nrpc-tcp-token: tcp-token-0123456789\n"))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if !cfg.RPCTCPEnabled || cfg.RPCTCPAddr != "0.0.0.0:4321" {
+		t.Errorf("RPC TCP = %v on %q, want enabled on 0.0.0.0:4321", cfg.RPCTCPEnabled, cfg.RPCTCPAddr)
+	}
+
+	for config, want := range map[string]string{
+		"rpc-tcp-enabled: true\n":                       "requires an rpc-tcp-token",
+		"rpc-tcp-enabled: true\nrpc-tcp-token: short\n": "requires an rpc-tcp-token",
+		"rpc-tcp-enabled: true\nrpc-tcp-port: 70000\nrpc-tcp-token: tcp-token-0123456789\n": "invalid rpc-tcp-port",
+	} {
+		if _, err := loadConfig(writeTempConfig(t, config)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("loadConfig(%q) error = %v, want %q", config, err, want)
+		}
+	}
+}
+
+func TestLoadConfig_SocketAccess(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	for config, want := range map[string]os.FileMode{
+		"grpc-port: 4317\n":       0,
+		"socket-mode: \"0660\"\n": 0660,
+		"socket-mode: 0640\n":     0640,
+		"socket-mode: \"600\"\n":  0600,
+	} {
+		cfg, err := loadConfig(writeTempConfig(t, config))
+		if err != nil {
+			t.Fatalf("loadConfig(%q) returned error: %v", config, err)
+		}
+		if cfg.SocketFileMode != want {
+			t.Errorf("loadConfig(%q) socket mode = %o, want %o", config, cfg.SocketFileMode, want)
+		}
+	}
+
+	cfg, err := loadConfig(writeTempConfig(t, "socket-group: telemetry\nsocket-allow-users: [alice, \"1001\"]\nsocket-allow-groups: [telemetry]\n"))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.SocketGroup != "telemetry" || strings.Join(cfg.SocketAllowUsers, ",") != "alice,1001" || strings.Join(cfg.SocketAllowGroups, ",") != "telemetry" {
+		t.Errorf("socket access = %q %v %v", cfg.SocketGroup, cfg.SocketAllowUsers, cfg.SocketAllowGroups)
+	}
+
+	for _, config := range []string{"socket-mode: \"rw\"\n", "socket-mode: \"01777\"\n", "socket-mode: 0\n"} {
+		if _, err := loadConfig(writeTempConfig(t, config)); err == nil || !strings.Contains(err.Error(), "invalid socket-mode") {
+			t.Errorf("loadConfig(%q) error = %v, want invalid socket-mode", config, err)
+		}
+	}
+}
+
+func TestLoadConfig_APIKeys(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+api-keys:
+  - name: grafana
+    key: 0123456789abcdef
+    scopes: [read]
+    apps: [shop]
+  - key: fedcba9876543210
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if len(cfg.APIKeys) != 2 || cfg.APIKeys[0].Name != "grafana" || len(cfg.APIKeys[0].Scopes) != 1 || len(cfg.APIKeys[0].Apps) != 1 {
+		t.Fatalf("api-keys = %+v, want grafana (read, shop) and an unscoped key", cfg.APIKeys)
+	}
+	if got := readKey(cfg); got != "fedcba9876543210" {
+		t.Fatalf("readKey = %q, want the key reading every app", got)
+	}
+	cfg.APIKeys[0].Apps = nil
+	if got := readKey(cfg); got != "0123456789abcdef" {
+		t.Fatalf("readKey = %q, want the grafana key", got)
+	}
+
+	for _, tt := range []struct {
+		config string
+		want   string
+	}{
+		{"api-keys:\n  - key: short\n", "invalid api-keys"},
+		{"api-keys:\n  - key: 0123456789abcdef\n    scopes: [write]\n", "invalid api-keys"},
+		{"api-keys:\n  - key: 0123456789abcdef\n    apps: [\"\"]\n", "invalid api-keys"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, tt.config))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("loadConfig(%q) error = %v, want %q", tt.config, err, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_TLS(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+	dir := t.TempDir()
+	cert, key := writeTestCert(t, dir, "server")
+
+	cfg, err := loadConfig(writeTempConfig(t, fmt.Sprintf("api-tls-cert: %s\napi-tls-key: %s\ntcp-tls-cert: %s\ntcp-tls-key: %s\ntcp-tls-client-ca: %s\n", cert, key, cert, key, cert)))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.APITLS == nil || cfg.APITLS.ClientAuth != tls.NoClientCert {
+		t.Fatalf("api TLS = %+v, want server TLS without client certificates", cfg.APITLS)
+	}
+	if cfg.TCPTLS == nil || cfg.TCPTLS.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Fatalf("tcp TLS = %+v, want client certificates required", cfg.TCPTLS)
+	}
+
+	for _, tt := range []struct {
+		config string
+		want   string
+	}{
+		{"api-tls-cert: " + cert + "\n", "invalid api-tls: cert and key must be set together"},
+		{"tcp-tls-client-ca: " + cert + "\n", "invalid tcp-tls: client-ca needs cert and key"},
+		{"api-tls-cert: " + filepath.Join(dir, "missing.crt") + "\napi-tls-key: " + key + "\n", "invalid api-tls"},
+		{"api-tls-cert: " + cert + "\napi-tls-key: " + key + "\napi-tls-client-ca: " + key + "\n", "no PEM certificates"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, tt.config))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("loadConfig(%q) error = %v, want %q", tt.config, err, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_APIRateLimit(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, "api-rate-limit: 0.5\n"))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.APIRateLimit != 0.5 || cfg.APIRateBurst != defaultAPIRateBurst {
+		t.Fatalf("rate limit = %g burst %d, want 0.5 burst %d", cfg.APIRateLimit, cfg.APIRateBurst, defaultAPIRateBurst)
+	}
+
+	for _, tt := range []struct {
+		config string
+		want   string
+	}{
+		{"api-rate-limit: -1\n", "invalid api-rate-limit"},
+		{"api-rate-limit: 1\napi-rate-burst: 0\n", "invalid api-rate-burst"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, tt.config))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("loadConfig(%q) error = %v, want %q", tt.config, err, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_APIAccessLog(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\n"))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.APIAccessLog != "warn" {
+		t.Fatalf("api-access-log = %q, want warn by default", cfg.APIAccessLog)
+	}
+	cfg, err = loadConfig(writeTempConfig(t, "api-access-log: INFO\n"))
+	if err != nil || cfg.APIAccessLog != "info" {
+		t.Fatalf("api-access-log = %q, %v; want info", cfg.APIAccessLog, err)
+	}
+	if _, err := loadConfig(writeTempConfig(t, "api-access-log: debug\n")); err == nil || !strings.Contains(err.Error(), "invalid api-access-log") {
+		t.Fatalf("api-access-log: debug error = %v", err)
+	}
+}
+
+func TestLoadConfig_SourceParsers(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+source-parsers:
+  tcp: access
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.SourceParsers["tcp"] != "access" {
+		t.Fatalf("source-parsers.tcp = %q, want access", cfg.SourceParsers["tcp"])
+	}
+
+	_, err = loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+source-parsers:
+  tcp: apache2
+`))
+	if err == nil || !strings.Contains(err.Error(), "invalid source-parsers") {
+		t.Fatalf("error = %v, want invalid source-parsers", err)
+	}
+}
+
+func TestLoadConfig_GrokParsers(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+grok-patterns:
+  ORDERID: 'ord-[0-9]+'
+grok-parsers:
+  legacy: '%{LOGLEVEL:level} %{ORDERID:order.id} %{GREEDYDATA:message}'
+source-parsers:
+  tcp: legacy
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	parsers, err := buildSourceParsers(cfg)
+	if err != nil {
+		t.Fatalf("buildSourceParsers: %v", err)
+	}
+	records := parsers["tcp"].Parse("INFO ord-7 shipped")
+	if len(records) != 1 || records[0].Attributes["order.id"] != "ord-7" {
+		t.Fatalf("records = %+v", records)
+	}
+
+	_, err = loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+grok-parsers:
+  legacy: '%{NOSUCHPATTERN:x}'
+`))
+	if err == nil || !strings.Contains(err.Error(), "invalid grok-parsers") {
+		t.Fatalf("error = %v, want invalid grok-parsers", err)
+	}
+}
+
+func TestLoadConfig_CSVParsers(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+csv-parsers:
+  export:
+    delimiter: tab
+    columns: [level, service, message]
+source-parsers:
+  stdin: export
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	parsers, err := buildSourceParsers(cfg)
+	if err != nil {
+		t.Fatalf("buildSourceParsers: %v", err)
+	}
+	records := parsers["stdin"].Parse("error\tbilling\tcard declined")
+	if len(records) != 1 || records[0].Level != "ERROR" || records[0].Attributes["service"] != "billing" {
+		t.Fatalf("records = %+v", records)
+	}
+
+	_, err = loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+grok-parsers:
+  export: '%{GREEDYDATA:message}'
+csv-parsers:
+  export:
+    columns: [message]
+`))
+	if err == nil || !strings.Contains(err.Error(), "invalid csv-parsers") {
+		t.Fatalf("error = %v, want invalid csv-parsers", err)
+	}
+}
+
+func TestLoadConfig_SourceExtractors(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+source-extractors:
+  stdin:
+    - '^\[(?P<severity>\w+)\] (?P<service>\S+): (?P<message>.*)$'
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	parsers, err := buildSourceParsers(cfg)
+	if err != nil {
+		t.Fatalf("buildSourceParsers: %v", err)
+	}
+	records := parsers["stdin"].Parse("[error] checkout: payment failed")
+	if len(records) != 1 || records[0].Level != "ERROR" || records[0].Attributes["service"] != "checkout" {
+		t.Fatalf("records = %+v", records)
+	}
+
+	_, err = loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+source-extractors:
+  stdin:
+    - '(unclosed'
+`))
+	if err == nil || !strings.Contains(err.Error(), "invalid source-extractors") {
+		t.Fatalf("error = %v, want invalid source-extractors", err)
+	}
+}
+
+func TestLoadConfig_TCPAcks(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+tcp-acks: true
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if !cfg.TCPAcks {
+		t.Fatal("tcp-acks = false, want true")
+	}
+	if p := buildInputPlugins(cfg)[0].(tcpInputPlugin); !p.acks {
+		t.Fatal("tcp plugin should have acks enabled")
+	}
+
+	_, err = loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+tcp-acks: true
+journal-enabled: false
+`))
+	if err == nil || !strings.Contains(err.Error(), "tcp-acks requires journal-enabled") {
+		t.Fatalf("error = %v, want tcp-acks requires journal-enabled", err)
+	}
+}
+
+func TestLoadConfig_StorageBackend(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.StorageBackend != storageBackendDuckDB {
+		t.Fatalf("storage-backend = %q, want duckdb", cfg.StorageBackend)
+	}
+
+	cfg, err = loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+storage-backend: Memory
+memory-max-records: 5000
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.StorageBackend != storageBackendMemory || cfg.MemoryMaxRecords != 5000 {
+		t.Fatalf("storage = %q/%d, want memory/5000", cfg.StorageBackend, cfg.MemoryMaxRecords)
+	}
+
+	for _, tc := range []struct{ config, want string }{
+		{"storage-backend: sqlite", "invalid storage-backend"},
+		{"storage-backend: memory\nmemory-max-records: 0", "invalid memory-max-records"},
+		{"storage-backend: memory\nbackup-enabled: true", "backup-enabled requires storage-backend"},
+		{"storage-backend: memory\nsearch-index: true", "search-index requires storage-backend"},
+		{"storage-backend: memory\npartition-by-day: true", "partition-by-day requires storage-backend"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"+tc.config))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("config %q: error = %v, want %q", tc.config, err, tc.want)
+		}
+	}
+}
+
+func TestLoadConfig_SizeRetention(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+max-db-size: 1.5GiB
+max-row-count: 1000000
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.MaxDBSizeBytes != 3<<29 || cfg.MaxRowCount != 1000000 {
+		t.Fatalf("size limits = %d/%d, want %d/1000000", cfg.MaxDBSizeBytes, cfg.MaxRowCount, int64(3<<29))
+	}
+
+	for _, tc := range []struct{ config, want string }{
+		{"max-db-size: lots", "invalid max-db-size"},
+		{"max-db-size: 10XB", "invalid max-db-size"},
+		{"max-row-count: -1", "invalid max-row-count"},
+		{"storage-backend: memory\nmax-row-count: 10", "max-db-size and max-row-count require storage-backend"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"+tc.config))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("config %q: error = %v, want %q", tc.config, err, tc.want)
+		}
+	}
+}
+
+func TestLoadConfig_DuckDBResources(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+duckdb-memory-limit: 512MiB
+duckdb-threads: 2
+duckdb-temp-dir: ~/spill
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.DuckDBMemoryLimitBytes != 512<<20 || cfg.DuckDBThreads != 2 || !strings.HasSuffix(cfg.DuckDBTempDir, "/spill") || strings.HasPrefix(cfg.DuckDBTempDir, "~") {
+		t.Fatalf("duckdb resources = %d/%d/%q", cfg.DuckDBMemoryLimitBytes, cfg.DuckDBThreads, cfg.DuckDBTempDir)
+	}
+
+	for _, tc := range []struct{ config, want string }{
+		{"duckdb-memory-limit: plenty", "invalid duckdb-memory-limit"},
+		{"duckdb-threads: -1", "invalid duckdb-threads"},
+		{"storage-backend: memory\nduckdb-threads: 2", "require storage-backend"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"+tc.config))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("config %q: error = %v, want %q", tc.config, err, tc.want)
+		}
+	}
+}
+
+func TestLoadConfig_Maintenance(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+maintenance-interval: 6h
+maintenance-window: "22:30-04:00"
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	want := duckdb.MaintenanceWindow{Start: 22*time.Hour + 30*time.Minute, End: 4 * time.Hour}
+	if cfg.MaintenanceInterval != 6*time.Hour || cfg.MaintenanceWindowRange != want {
+		t.Fatalf("maintenance = %s/%+v, want 6h/%+v", cfg.MaintenanceInterval, cfg.MaintenanceWindowRange, want)
+	}
+
+	for _, tc := range []struct{ config, want string }{
+		{"maintenance-interval: -1h", "invalid maintenance-interval"},
+		{"maintenance-interval: 1h\nmaintenance-window: nightly", "invalid maintenance-window"},
+		{"maintenance-interval: 1h\nmaintenance-window: \"03:00-03:00\"", "invalid maintenance-window"},
+		{"maintenance-window: \"02:00-05:00\"", "requires maintenance-interval"},
+		{"storage-backend: memory\nmaintenance-interval: 1h", "maintenance-interval requires storage-backend"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"+tc.config))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("config %q: error = %v, want %q", tc.config, err, tc.want)
+		}
+	}
+}
+
+func TestLoadConfig_PromoteAttributes(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+promote-attributes:
+  - key: http.status_code
+    type: Integer
+  - key: tenant.id
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	want := []promotedAttribute{{Key: "http.status_code", Type: "integer"}, {Key: "tenant.id", Type: "string"}}
+	if !reflect.DeepEqual(cfg.PromoteAttributes, want) {
+		t.Fatalf("promote-attributes = %+v, want %+v", cfg.PromoteAttributes, want)
+	}
+
+	for _, tc := range []struct{ config, want string }{
+		{"promote-attributes:\n  - type: integer", "key is required"},
+		{"promote-attributes:\n  - key: took\n    type: duration", "invalid promote-attributes[0] type"},
+		{"storage-backend: memory\npromote-attributes:\n  - key: tenant.id", "promote-attributes requires storage-backend"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"+tc.config))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("config %q: error = %v, want %q", tc.config, err, tc.want)
+		}
+	}
+}
+
+func TestLoadConfig_Dedup(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+dedup-window: 15m
+dedup-key: Content
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.DedupWindow != 15*time.Minute || cfg.DedupKey != "content" {
+		t.Fatalf("dedup = %s/%q, want 15m/content", cfg.DedupWindow, cfg.DedupKey)
+	}
+
+	for _, tc := range []struct{ config, want string }{
+		{"dedup-window: -1m", "invalid dedup-window"},
+		{"dedup-key: hash", "invalid dedup-key"},
+		{"storage-backend: memory\ndedup-window: 5m", "dedup-window requires storage-backend"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"+tc.config))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("config %q: error = %v, want %q", tc.config, err, tc.want)
+		}
+	}
+}
+
+func TestLoadConfig_Archive(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatalf("UserHomeDir: %v", err)
+	}
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+partition-by-day: true
+archive-after: 7
+archive-dir: ~/cold
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.ArchiveAfter != 7 || cfg.ArchiveDir != filepath.Join(home, "cold") {
+		t.Fatalf("archive = %d/%q, want 7/~/cold expanded", cfg.ArchiveAfter, cfg.ArchiveDir)
+	}
+
+	for _, tc := range []struct{ config, want string }{
+		{"archive-after: -1", "invalid archive-after"},
+		{"archive-after: 7", "archive-after requires partition-by-day"},
+		{"partition-by-day: true\narchive-after: 7\narchive-dir: \"\"", "archive-dir is required"},
+		{"partition-by-day: true\narchive-after: 30", "must be less than log-retention"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"+tc.config))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("config %q: error = %v, want %q", tc.config, err, tc.want)
+		}
+	}
+}
+
+func TestLoadConfig_Rotation(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatalf("UserHomeDir: %v", err)
+	}
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+partition-by-day: true
+rotate-period: Week
+rotate-dir: ~/rotated
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.RotatePeriod != "week" || cfg.RotateDir != filepath.Join(home, "rotated") {
+		t.Fatalf("rotation = %q/%q, want week/~/rotated expanded", cfg.RotatePeriod, cfg.RotateDir)
+	}
+
+	for _, tc := range []struct{ config, want string }{
+		{"partition-by-day: true\nrotate-period: hourly", "invalid rotate-period"},
+		{"rotate-period: week", "rotate-period requires partition-by-day"},
+		{"partition-by-day: true\nrotate-period: week\nrotate-dir: \"\"", "rotate-dir is required"},
+		{"partition-by-day: true\nrotate-period: week\narchive-after: 7", "cannot both be set"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"+tc.config))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("config %q: error = %v, want %q", tc.config, err, tc.want)
+		}
+	}
+}
+
+func TestLoadConfig_RawLine(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.RawLine != "always" {
+		t.Fatalf("default raw-line = %q, want always", cfg.RawLine)
+	}
+	cfg, err = loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\nraw-line: Compress\n"))
+	if err != nil || cfg.RawLine != "compress" {
+		t.Fatalf("raw-line = %q, %v; want compress", cfg.RawLine, err)
+	}
+
+	for _, tc := range []struct{ config, want string }{
+		{"raw-line: gzip", "invalid raw-line"},
+		{"storage-backend: memory\nraw-line: never", "raw-line requires storage-backend: duckdb"},
+	} {
+		_, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"+tc.config))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("config %q: error = %v, want %q", tc.config, err, tc.want)
+		}
+	}
+}
+
+func TestLoadConfig_QueryCacheTTL(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\n"))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.QueryCacheTTL != defaultQueryCacheTTL {
+		t.Fatalf("query-cache-ttl = %s, want %s", cfg.QueryCacheTTL, defaultQueryCacheTTL)
+	}
+
+	_, err = loadConfig(writeTempConfig(t, "grpc-port: 4317\napi-port: 3000\nquery-cache-ttl: -1s\n"))
+	if err == nil || !strings.Contains(err.Error(), "invalid query-cache-ttl") {
+		t.Fatalf("negative query-cache-ttl: error = %v", err)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	for in, want := range map[string]int64{
+		"1048576": 1 << 20,
+		"500MB":   500_000_000,
+		"10 gb":   10_000_000_000,
+		"2KiB":    2048,
+		"1.5GiB":  3 << 29,
+	} {
+		if got, err := parseByteSize(in); err != nil || got != want {
+			t.Errorf("parseByteSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "MB", "5 parsecs"} {
+		if _, err := parseByteSize(in); err == nil {
+			t.Errorf("parseByteSize(%q) succeeded, want error", in)
+		}
+	}
+}
+
 func writeTempConfig(t *testing.T, content string) string {
 	t.Helper()
 
@@ -258,3 +1099,96 @@ func resetTinyTelemetryEnv(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadConfig_Multiline(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+multiline:
+  stdin:
+    max-lines: 50
+    timeout: 250ms
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	rule := cfg.Multiline["stdin"]
+	if rule.MaxLines != 50 || rule.Timeout != 250*time.Millisecond || rule.Pattern != "" {
+		t.Fatalf("multiline = %+v", cfg.Multiline)
+	}
+	if rules, err := buildMultiline(cfg); err != nil || rules["stdin"] == nil {
+		t.Fatalf("buildMultiline = %v, %v", rules, err)
+	}
+
+	_, err = loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+multiline:
+  stdin:
+    pattern: '(unclosed'
+`))
+	if err == nil || !strings.Contains(err.Error(), "invalid multiline") {
+		t.Fatalf("error = %v, want invalid multiline", err)
+	}
+}
+
+func TestLoadConfig_Logplex(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+logplex-enabled: true
+logplex-drain-token: d.abc
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.LogplexAddr != "127.0.0.1:5081" || cfg.LogplexDrainToken != "d.abc" {
+		t.Fatalf("logplex addr/token = %q/%q", cfg.LogplexAddr, cfg.LogplexDrainToken)
+	}
+	plugins := buildInputPlugins(cfg)
+	if p := plugins[len(plugins)-1]; p.Name() != "logplex" || !p.Enabled() {
+		t.Fatalf("last plugin = %q (enabled=%v), want enabled logplex", p.Name(), p.Enabled())
+	}
+
+	_, err = loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+logplex-enabled: true
+logplex-port: 70000
+`))
+	if err == nil || !strings.Contains(err.Error(), "invalid logplex-port") {
+		t.Fatalf("error = %v, want invalid logplex-port", err)
+	}
+}
+
+func TestLoadConfig_TimestampFormats(t *testing.T) {
+	resetTinyTelemetryEnv(t)
+
+	cfg, err := loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+timestamp-formats:
+  - "%d/%m/%Y %H:%M:%S"
+  - "02.01.2006 15:04:05"
+`))
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if len(cfg.TimestampFormats) != 2 || cfg.TimestampFormats[0] != "%d/%m/%Y %H:%M:%S" {
+		t.Fatalf("timestamp-formats = %q", cfg.TimestampFormats)
+	}
+
+	_, err = loadConfig(writeTempConfig(t, `
+grpc-port: 4317
+api-port: 3000
+timestamp-formats:
+  - "%d/%m/%Y %Q"
+`))
+	if err == nil || !strings.Contains(err.Error(), "invalid timestamp-formats") {
+		t.Fatalf("error = %v, want invalid timestamp-formats", err)
+	}
+}
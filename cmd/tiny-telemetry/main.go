@@ -5,12 +5,20 @@ import (
 	"flag"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/tinytelemetry/tiny-telemetry/internal/alerting"
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/httpserver"
+	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
 	"github.com/tinytelemetry/tiny-telemetry/internal/socketrpc"
+	"github.com/tinytelemetry/tiny-telemetry/internal/timestamp"
+	versioncheck "github.com/tinytelemetry/tiny-telemetry/internal/version"
 
 	"github.com/spf13/viper"
 )
@@ -51,6 +59,19 @@ func main() {
 		os.Exit(1)
 	}
 
+	switch flag.Arg(0) {
+	case "export", "import":
+		run := runExport
+		if flag.Arg(0) == "import" {
+			run = runImport
+		}
+		if err := run(cfg, flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := runServer(cfg); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -67,7 +88,10 @@ func loadConfig(configPath string) (appConfig, error) {
 
 	defaultDBPath := filepath.Join(home, ".local", "share", "tiny-telemetry", "tiny-telemetry.duckdb")
 	defaultBackupDir := filepath.Join(home, ".local", "share", "tiny-telemetry", "backups")
+	defaultArchiveDir := filepath.Join(home, ".local", "share", "tiny-telemetry", "archive")
+	defaultRotateDir := filepath.Join(home, ".local", "share", "tiny-telemetry", "rotated")
 	defaultJournalPath := filepath.Join(home, ".local", "state", "tiny-telemetry", "ingest.journal")
+	defaultVersionCachePath := filepath.Join(home, ".cache", "tiny-telemetry", "version-check.json")
 
 	v := viper.New()
 	v.SetEnvPrefix("TINY_TELEMETRY")
@@ -78,18 +102,59 @@ func loadConfig(configPath string) (appConfig, error) {
 	v.SetDefault("log-buffer", defaultLogBuffer)
 	v.SetDefault("test-mode", false)
 	v.SetDefault("host", defaultBindHost)
+	v.SetDefault("tcp-enabled", true)
+	v.SetDefault("tcp-port", defaultTCPPort)
+	v.SetDefault("tcp-acks", false)
 	v.SetDefault("grpc-enabled", true)
 	v.SetDefault("grpc-port", defaultGRPCPort)
+	v.SetDefault("beats-enabled", false)
+	v.SetDefault("beats-port", defaultBeatsPort)
+	v.SetDefault("cloudwatch-enabled", false)
+	v.SetDefault("cloudwatch-port", defaultCloudWatchPort)
+	v.SetDefault("cloudwatch-access-key", "")
+	v.SetDefault("logplex-enabled", false)
+	v.SetDefault("logplex-port", defaultLogplexPort)
+	v.SetDefault("logplex-drain-token", "")
 	v.SetDefault("mux-buffer-size", defaultMuxBufferSize)
+	v.SetDefault("storage-backend", defaultStorageBackend)
+	v.SetDefault("memory-max-records", defaultMemoryMaxRecords)
+	v.SetDefault("search-index", false)
+	v.SetDefault("partition-by-day", false)
+	v.SetDefault("archive-after", 0)
+	v.SetDefault("archive-dir", defaultArchiveDir)
+	v.SetDefault("rotate-period", "")
+	v.SetDefault("rotate-dir", defaultRotateDir)
+	v.SetDefault("dedup-window", 0)
+	v.SetDefault("dedup-key", duckdb.DedupKeyEventID)
+	v.SetDefault("raw-line", string(duckdb.RawLineAlways))
+	v.SetDefault("pattern-mining", true)
 	v.SetDefault("db-path", defaultDBPath)
 	v.SetDefault("skin", defaultSkin)
 	v.SetDefault("disable-version-check", false)
+	v.SetDefault("version-check-endpoint", "")
+	v.SetDefault("version-check-anon-id", false)
+	v.SetDefault("version-check-proxy", "")
+	v.SetDefault("version-check-cache-ttl", versioncheck.DefaultCacheTTL)
+	v.SetDefault("version-check-cache-path", defaultVersionCachePath)
+	v.SetDefault("offline", false)
 	v.SetDefault("reverse-scroll-wheel", false)
 	v.SetDefault("use-log-time", false)
 	v.SetDefault("api-enabled", true)
 	v.SetDefault("api-port", defaultAPIPort)
 	v.SetDefault("query-timeout", defaultQueryTimeout)
 	v.SetDefault("max-concurrent-queries", defaultMaxConcurrentReads)
+	v.SetDefault("query-max-scan-rows", defaultQueryMaxScanRows)
+	v.SetDefault("query-max-rows", defaultQueryMaxRows)
+	v.SetDefault("query-cache-ttl", defaultQueryCacheTTL)
+	v.SetDefault("export-max-rows", defaultExportMaxRows)
+	v.SetDefault("export-timeout", defaultExportTimeout)
+	v.SetDefault("api-rate-limit", 0)
+	v.SetDefault("api-rate-burst", defaultAPIRateBurst)
+	v.SetDefault("api-access-log", string(httpserver.AccessLogWarn))
+	v.SetDefault("read-grpc-enabled", false)
+	v.SetDefault("read-grpc-port", defaultReadGRPCPort)
+	v.SetDefault("rpc-tcp-enabled", false)
+	v.SetDefault("rpc-tcp-port", defaultRPCTCPPort)
 	v.SetDefault("insert-batch-size", defaultInsertBatchSize)
 	v.SetDefault("insert-flush-interval", defaultInsertFlushInterval)
 	v.SetDefault("insert-flush-queue-size", defaultInsertFlushQueue)
@@ -97,6 +162,13 @@ func loadConfig(configPath string) (appConfig, error) {
 	v.SetDefault("journal-path", defaultJournalPath)
 	v.SetDefault("socket-path", socketrpc.DefaultSocketPath())
 	v.SetDefault("log-retention", defaultLogRetention)
+	v.SetDefault("max-db-size", "")
+	v.SetDefault("max-row-count", 0)
+	v.SetDefault("duckdb-memory-limit", "")
+	v.SetDefault("duckdb-threads", 0)
+	v.SetDefault("duckdb-temp-dir", "")
+	v.SetDefault("maintenance-interval", 0)
+	v.SetDefault("maintenance-window", "")
 	v.SetDefault("backup-enabled", false)
 	v.SetDefault("backup-interval", defaultBackupInterval)
 	v.SetDefault("backup-local-dir", defaultBackupDir)
@@ -127,12 +199,224 @@ func loadConfig(configPath string) (appConfig, error) {
 		return cfg, err
 	}
 	cfg.ConfigPath = v.ConfigFileUsed()
+	if cfg.TCPEnabled && (cfg.TCPPort <= 0 || cfg.TCPPort > 65535) {
+		return cfg, fmt.Errorf("invalid tcp-port: %d", cfg.TCPPort)
+	}
 	if cfg.GRPCPort <= 0 || cfg.GRPCPort > 65535 {
 		return cfg, fmt.Errorf("invalid grpc-port: %d", cfg.GRPCPort)
 	}
+	if cfg.BeatsEnabled && (cfg.BeatsPort <= 0 || cfg.BeatsPort > 65535) {
+		return cfg, fmt.Errorf("invalid beats-port: %d", cfg.BeatsPort)
+	}
+	if cfg.CloudWatchEnabled && (cfg.CloudWatchPort <= 0 || cfg.CloudWatchPort > 65535) {
+		return cfg, fmt.Errorf("invalid cloudwatch-port: %d", cfg.CloudWatchPort)
+	}
+	if cfg.LogplexEnabled && (cfg.LogplexPort <= 0 || cfg.LogplexPort > 65535) {
+		return cfg, fmt.Errorf("invalid logplex-port: %d", cfg.LogplexPort)
+	}
 	if cfg.APIPort <= 0 || cfg.APIPort > 65535 {
 		return cfg, fmt.Errorf("invalid api-port: %d", cfg.APIPort)
 	}
+	if cfg.ReadGRPCEnabled && (cfg.ReadGRPCPort <= 0 || cfg.ReadGRPCPort > 65535) {
+		return cfg, fmt.Errorf("invalid read-grpc-port: %d", cfg.ReadGRPCPort)
+	}
+	if cfg.RPCTCPEnabled && (cfg.RPCTCPPort <= 0 || cfg.RPCTCPPort > 65535) {
+		return cfg, fmt.Errorf("invalid rpc-tcp-port: %d", cfg.RPCTCPPort)
+	}
+	if cfg.RPCTCPEnabled && len(cfg.RPCTCPToken) < socketrpc.MinTokenLength {
+		return cfg, fmt.Errorf("rpc-tcp-enabled requires an rpc-tcp-token of at least %d characters", socketrpc.MinTokenLength)
+	}
+	if mode, ok := v.Get("socket-mode").(int); ok {
+		// YAML reads an unquoted 0660 as the number 432.
+		cfg.SocketMode = fmt.Sprintf("%#o", mode)
+	}
+	if cfg.SocketMode != "" {
+		mode, err := strconv.ParseUint(cfg.SocketMode, 8, 32)
+		if err != nil || mode == 0 || mode > 0777 {
+			return cfg, fmt.Errorf("invalid socket-mode: %q (want octal permissions, e.g. 0660)", cfg.SocketMode)
+		}
+		cfg.SocketFileMode = os.FileMode(mode)
+	}
+	if cfg.QueryMaxScanRows < 0 {
+		return cfg, fmt.Errorf("invalid query-max-scan-rows: %d", cfg.QueryMaxScanRows)
+	}
+	if cfg.QueryMaxRows <= 0 {
+		return cfg, fmt.Errorf("invalid query-max-rows: %d", cfg.QueryMaxRows)
+	}
+	if cfg.QueryCacheTTL < 0 {
+		return cfg, fmt.Errorf("invalid query-cache-ttl: %s", cfg.QueryCacheTTL)
+	}
+	if cfg.ExportMaxRows < 0 {
+		return cfg, fmt.Errorf("invalid export-max-rows: %d", cfg.ExportMaxRows)
+	}
+	if cfg.ExportTimeout < 0 {
+		return cfg, fmt.Errorf("invalid export-timeout: %s", cfg.ExportTimeout)
+	}
+	if cfg.APIRateLimit < 0 {
+		return cfg, fmt.Errorf("invalid api-rate-limit: %g", cfg.APIRateLimit)
+	}
+	if cfg.APIRateLimit > 0 && cfg.APIRateBurst < 1 {
+		return cfg, fmt.Errorf("invalid api-rate-burst: %d", cfg.APIRateBurst)
+	}
+	accessLog, err := httpserver.ParseAccessLogLevel(cfg.APIAccessLog)
+	if err != nil {
+		return cfg, fmt.Errorf("invalid api-access-log: %q (want %s, %s, %s, or %s)", cfg.APIAccessLog, httpserver.AccessLogOff, httpserver.AccessLogError, httpserver.AccessLogWarn, httpserver.AccessLogInfo)
+	}
+	cfg.APIAccessLog = string(accessLog)
+	if cfg.MaxDBSize != "" {
+		size, err := parseByteSize(cfg.MaxDBSize)
+		if err != nil || size <= 0 {
+			return cfg, fmt.Errorf("invalid max-db-size: %q (want e.g. 500MB or 10GiB)", cfg.MaxDBSize)
+		}
+		cfg.MaxDBSizeBytes = size
+	}
+	if cfg.MaxRowCount < 0 {
+		return cfg, fmt.Errorf("invalid max-row-count: %d", cfg.MaxRowCount)
+	}
+	if cfg.DuckDBMemoryLimit != "" {
+		size, err := parseByteSize(cfg.DuckDBMemoryLimit)
+		if err != nil || size <= 0 {
+			return cfg, fmt.Errorf("invalid duckdb-memory-limit: %q (want e.g. 512MB or 4GiB)", cfg.DuckDBMemoryLimit)
+		}
+		cfg.DuckDBMemoryLimitBytes = size
+	}
+	if cfg.DuckDBThreads < 0 {
+		return cfg, fmt.Errorf("invalid duckdb-threads: %d", cfg.DuckDBThreads)
+	}
+	if cfg.MaintenanceInterval < 0 {
+		return cfg, fmt.Errorf("invalid maintenance-interval: %s", cfg.MaintenanceInterval)
+	}
+	if cfg.MaintenanceWindow != "" {
+		if cfg.MaintenanceInterval == 0 {
+			return cfg, fmt.Errorf("maintenance-window requires maintenance-interval")
+		}
+		window, err := parseMaintenanceWindow(cfg.MaintenanceWindow)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid maintenance-window: %q: %v", cfg.MaintenanceWindow, err)
+		}
+		cfg.MaintenanceWindowRange = window
+	}
+	if cfg.ArchiveAfter < 0 {
+		return cfg, fmt.Errorf("invalid archive-after: %d", cfg.ArchiveAfter)
+	}
+	if cfg.ArchiveAfter > 0 {
+		if !cfg.PartitionByDay {
+			return cfg, fmt.Errorf("archive-after requires partition-by-day")
+		}
+		if strings.TrimSpace(cfg.ArchiveDir) == "" {
+			return cfg, fmt.Errorf("archive-dir is required when archive-after is set")
+		}
+		if cfg.LogRetention > 0 && cfg.ArchiveAfter >= cfg.LogRetention {
+			return cfg, fmt.Errorf("archive-after (%d days) must be less than log-retention (%d days)", cfg.ArchiveAfter, cfg.LogRetention)
+		}
+	}
+	cfg.RotatePeriod = strings.ToLower(strings.TrimSpace(cfg.RotatePeriod))
+	if cfg.RotatePeriod != "" {
+		if _, err := duckdb.ParseRotationPeriod(cfg.RotatePeriod); err != nil {
+			return cfg, fmt.Errorf("invalid rotate-period: %q (want %s, %s, or %s)", cfg.RotatePeriod, duckdb.RotateDaily, duckdb.RotateWeekly, duckdb.RotateMonthly)
+		}
+		if !cfg.PartitionByDay {
+			return cfg, fmt.Errorf("rotate-period requires partition-by-day")
+		}
+		if strings.TrimSpace(cfg.RotateDir) == "" {
+			return cfg, fmt.Errorf("rotate-dir is required when rotate-period is set")
+		}
+		if cfg.ArchiveAfter > 0 {
+			return cfg, fmt.Errorf("rotate-period and archive-after cannot both be set")
+		}
+	}
+	if cfg.DedupWindow < 0 {
+		return cfg, fmt.Errorf("invalid dedup-window: %s", cfg.DedupWindow)
+	}
+	cfg.DedupKey = strings.ToLower(strings.TrimSpace(cfg.DedupKey))
+	if cfg.DedupKey != duckdb.DedupKeyEventID && cfg.DedupKey != duckdb.DedupKeyContent {
+		return cfg, fmt.Errorf("invalid dedup-key: %q (want %s or %s)", cfg.DedupKey, duckdb.DedupKeyEventID, duckdb.DedupKeyContent)
+	}
+	rawLine, err := duckdb.ParseRawLinePolicy(cfg.RawLine)
+	if err != nil {
+		return cfg, fmt.Errorf("invalid raw-line: %q (want %s, %s, %s, or %s)", cfg.RawLine, duckdb.RawLineAlways, duckdb.RawLineDiffers, duckdb.RawLineNever, duckdb.RawLineCompress)
+	}
+	cfg.RawLine = string(rawLine)
+	cfg.StorageBackend = strings.ToLower(strings.TrimSpace(cfg.StorageBackend))
+	switch cfg.StorageBackend {
+	case storageBackendDuckDB:
+	case storageBackendMemory:
+		if cfg.MemoryMaxRecords <= 0 {
+			return cfg, fmt.Errorf("invalid memory-max-records: %d", cfg.MemoryMaxRecords)
+		}
+		if cfg.BackupEnabled {
+			return cfg, fmt.Errorf("backup-enabled requires storage-backend: %s", storageBackendDuckDB)
+		}
+		if cfg.SearchIndex {
+			return cfg, fmt.Errorf("search-index requires storage-backend: %s", storageBackendDuckDB)
+		}
+		if cfg.PartitionByDay {
+			return cfg, fmt.Errorf("partition-by-day requires storage-backend: %s", storageBackendDuckDB)
+		}
+		if len(cfg.PromoteAttributes) > 0 {
+			return cfg, fmt.Errorf("promote-attributes requires storage-backend: %s", storageBackendDuckDB)
+		}
+		if cfg.DedupWindow != 0 {
+			return cfg, fmt.Errorf("dedup-window requires storage-backend: %s", storageBackendDuckDB)
+		}
+		if cfg.RawLine != string(duckdb.RawLineAlways) {
+			return cfg, fmt.Errorf("raw-line requires storage-backend: %s", storageBackendDuckDB)
+		}
+		if cfg.MaxDBSize != "" || cfg.MaxRowCount != 0 {
+			return cfg, fmt.Errorf("max-db-size and max-row-count require storage-backend: %s (use memory-max-records)", storageBackendDuckDB)
+		}
+		if cfg.MaintenanceInterval != 0 {
+			return cfg, fmt.Errorf("maintenance-interval requires storage-backend: %s", storageBackendDuckDB)
+		}
+		if cfg.DuckDBMemoryLimit != "" || cfg.DuckDBThreads != 0 || cfg.DuckDBTempDir != "" {
+			return cfg, fmt.Errorf("duckdb-memory-limit, duckdb-threads, and duckdb-temp-dir require storage-backend: %s", storageBackendDuckDB)
+		}
+	default:
+		return cfg, fmt.Errorf("invalid storage-backend: %q (want %s or %s)", cfg.StorageBackend, storageBackendDuckDB, storageBackendMemory)
+	}
+	for i, attr := range cfg.PromoteAttributes {
+		attr.Key = strings.TrimSpace(attr.Key)
+		attr.Type = strings.ToLower(strings.TrimSpace(attr.Type))
+		if attr.Key == "" {
+			return cfg, fmt.Errorf("invalid promote-attributes[%d]: key is required", i)
+		}
+		if attr.Type == "" {
+			attr.Type = "string"
+		}
+		if !slices.Contains(promotedAttributeTypes, attr.Type) {
+			return cfg, fmt.Errorf("invalid promote-attributes[%d] type: %q (want %s)", i, attr.Type, strings.Join(promotedAttributeTypes, ", "))
+		}
+		cfg.PromoteAttributes[i] = attr
+	}
+	if _, err := buildSourceParsers(cfg); err != nil {
+		return cfg, err
+	}
+	if _, err := buildMultiline(cfg); err != nil {
+		return cfg, err
+	}
+	if _, err := httpserver.NewKeyring(apiKeys(cfg)); err != nil {
+		return cfg, fmt.Errorf("invalid api-keys: %w", err)
+	}
+	if _, err := ingest.NewSamplingSink(nil, sampleRules(cfg)); err != nil {
+		return cfg, fmt.Errorf("invalid storage-sampling: %w", err)
+	}
+	if _, err := alerting.NewEngine(nil, alertRules(cfg)); err != nil {
+		return cfg, fmt.Errorf("invalid alert-rules: %w", err)
+	}
+	if _, err := timestamp.Layouts(cfg.TimestampFormats); err != nil {
+		return cfg, fmt.Errorf("invalid timestamp-formats: %w", err)
+	}
+	if cfg.VersionCheckCacheTTL < 0 {
+		return cfg, fmt.Errorf("invalid version-check-cache-ttl: %s", cfg.VersionCheckCacheTTL)
+	}
+	if proxy := strings.TrimSpace(cfg.VersionCheckProxy); proxy != "" {
+		if u, err := url.Parse(proxy); err != nil || u.Scheme == "" || u.Host == "" {
+			return cfg, fmt.Errorf("invalid version-check-proxy: %q", cfg.VersionCheckProxy)
+		}
+	}
+	if cfg.Offline && cfg.BackupEnabled && strings.TrimSpace(cfg.BackupBucketURL) != "" {
+		return cfg, fmt.Errorf("backup-bucket-url cannot be used with offline: true")
+	}
 	if cfg.BackupEnabled && cfg.BackupInterval <= 0 {
 		return cfg, fmt.Errorf("invalid backup-interval: %s", cfg.BackupInterval)
 	}
@@ -152,24 +436,155 @@ func loadConfig(configPath string) (appConfig, error) {
 	if strings.HasPrefix(cfg.BackupLocalDir, "~/") {
 		cfg.BackupLocalDir = filepath.Join(home, cfg.BackupLocalDir[2:])
 	}
+	if strings.HasPrefix(cfg.ArchiveDir, "~/") {
+		cfg.ArchiveDir = filepath.Join(home, cfg.ArchiveDir[2:])
+	}
+	if strings.HasPrefix(cfg.RotateDir, "~/") {
+		cfg.RotateDir = filepath.Join(home, cfg.RotateDir[2:])
+	}
+	if strings.HasPrefix(cfg.DuckDBTempDir, "~/") {
+		cfg.DuckDBTempDir = filepath.Join(home, cfg.DuckDBTempDir[2:])
+	}
 	if strings.HasPrefix(cfg.JournalPath, "~/") {
 		cfg.JournalPath = filepath.Join(home, cfg.JournalPath[2:])
 	}
+	if strings.HasPrefix(cfg.VersionCheckCache, "~/") {
+		cfg.VersionCheckCache = filepath.Join(home, cfg.VersionCheckCache[2:])
+	}
+	for _, path := range []*string{&cfg.APITLSCert, &cfg.APITLSKey, &cfg.APITLSClientCA, &cfg.TCPTLSCert, &cfg.TCPTLSKey, &cfg.TCPTLSClientCA} {
+		if strings.HasPrefix(*path, "~/") {
+			*path = filepath.Join(home, (*path)[2:])
+		}
+	}
+	if cfg.APITLS, err = serverTLS(tlsFiles{Cert: cfg.APITLSCert, Key: cfg.APITLSKey, ClientCA: cfg.APITLSClientCA}); err != nil {
+		return cfg, fmt.Errorf("invalid api-tls: %w", err)
+	}
+	if cfg.TCPTLS, err = serverTLS(tlsFiles{Cert: cfg.TCPTLSCert, Key: cfg.TCPTLSKey, ClientCA: cfg.TCPTLSClientCA}); err != nil {
+		return cfg, fmt.Errorf("invalid tcp-tls: %w", err)
+	}
 	if cfg.BackupEnabled && cfg.DBPath == "" {
 		return cfg, fmt.Errorf("backup-enabled requires on-disk db-path")
 	}
+	if cfg.TCPAcks && !cfg.JournalEnabled {
+		return cfg, fmt.Errorf("tcp-acks requires journal-enabled")
+	}
 
 	host := cfg.Host
 	if host == "" {
 		host = defaultBindHost
 	}
 
+	if cfg.TCPAddr == "" {
+		cfg.TCPAddr = net.JoinHostPort(host, strconv.Itoa(cfg.TCPPort))
+	}
 	if cfg.GRPCAddr == "" {
 		cfg.GRPCAddr = net.JoinHostPort(host, strconv.Itoa(cfg.GRPCPort))
 	}
+	if cfg.BeatsAddr == "" {
+		cfg.BeatsAddr = net.JoinHostPort(host, strconv.Itoa(cfg.BeatsPort))
+	}
+	if cfg.CloudWatchAddr == "" {
+		cfg.CloudWatchAddr = net.JoinHostPort(host, strconv.Itoa(cfg.CloudWatchPort))
+	}
+	if cfg.LogplexAddr == "" {
+		cfg.LogplexAddr = net.JoinHostPort(host, strconv.Itoa(cfg.LogplexPort))
+	}
 	if cfg.APIAddr == "" {
 		cfg.APIAddr = net.JoinHostPort(host, strconv.Itoa(cfg.APIPort))
 	}
+	if cfg.ReadGRPCAddr == "" {
+		cfg.ReadGRPCAddr = net.JoinHostPort(host, strconv.Itoa(cfg.ReadGRPCPort))
+	}
+	if cfg.RPCTCPAddr == "" {
+		cfg.RPCTCPAddr = net.JoinHostPort(host, strconv.Itoa(cfg.RPCTCPPort))
+	}
 
 	return cfg, nil
 }
+
+// apiKeys converts the api-keys entries to HTTP API keys.
+func apiKeys(cfg appConfig) []httpserver.APIKey {
+	keys := make([]httpserver.APIKey, len(cfg.APIKeys))
+	for i, k := range cfg.APIKeys {
+		keys[i] = httpserver.APIKey{Name: k.Name, Key: k.Key, Scopes: k.Scopes, Apps: k.Apps}
+	}
+	return keys
+}
+
+// sampleRules converts the storage-sampling entries to ingest rules.
+func sampleRules(cfg appConfig) []ingest.SampleRule {
+	rules := make([]ingest.SampleRule, len(cfg.StorageSampling))
+	for i, r := range cfg.StorageSampling {
+		rules[i] = ingest.SampleRule{App: r.App, Level: r.Level, Rate: r.Rate}
+	}
+	return rules
+}
+
+// alertRules converts the alert-rules entries to alerting rules.
+func alertRules(cfg appConfig) []alerting.Rule {
+	rules := make([]alerting.Rule, len(cfg.AlertRules))
+	for i, r := range cfg.AlertRules {
+		rules[i] = alerting.Rule{
+			Name:      r.Name,
+			App:       r.App,
+			Level:     r.Level,
+			Threshold: r.Threshold,
+			Window:    r.Window,
+			For:       r.For,
+			Severity:  r.Severity,
+			Summary:   r.Summary,
+		}
+	}
+	return rules
+}
+
+// buildMultiline compiles the per-source multiline rules.
+func buildMultiline(cfg appConfig) (map[string]*ingest.Multiline, error) {
+	rules := make(map[string]ingest.MultilineRule, len(cfg.Multiline))
+	for source, r := range cfg.Multiline {
+		rules[source] = ingest.MultilineRule{Pattern: r.Pattern, MaxLines: r.MaxLines, Timeout: r.Timeout}
+	}
+	multiline, err := ingest.MultilineRules(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid multiline: %w", err)
+	}
+	return multiline, nil
+}
+
+// buildSourceParsers compiles user-defined parsers and resolves
+// source-parsers against them and the built-ins.
+func buildSourceParsers(cfg appConfig) (map[string]ingest.LineParser, error) {
+	custom, err := ingest.GrokParsers(ingest.GrokConfig{
+		Patterns: cfg.GrokPatterns,
+		Parsers:  cfg.GrokParsers,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid grok-parsers: %w", err)
+	}
+	csvConf := make(map[string]ingest.CSVConfig, len(cfg.CSVParsers))
+	for name, p := range cfg.CSVParsers {
+		csvConf[name] = ingest.CSVConfig{Delimiter: p.Delimiter, Columns: p.Columns}
+	}
+	csvParsers, err := ingest.CSVParsers(csvConf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv-parsers: %w", err)
+	}
+	for name, p := range csvParsers {
+		if _, ok := custom[name]; ok {
+			return nil, fmt.Errorf("invalid csv-parsers: %q is also a grok parser", name)
+		}
+		if custom == nil {
+			custom = make(map[string]ingest.LineParser, len(csvParsers))
+		}
+		custom[name] = p
+	}
+	parsers, err := ingest.SourceParsers(cfg.SourceParsers, custom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source-parsers: %w", err)
+	}
+	parsers, err = ingest.RegexExtractors(cfg.SourceExtractors, parsers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source-extractors: %w", err)
+	}
+	return parsers, nil
+}
@@ -4,37 +4,80 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tinytelemetry/tiny-telemetry/internal/alerting"
 	"github.com/tinytelemetry/tiny-telemetry/internal/backup"
+	"github.com/tinytelemetry/tiny-telemetry/internal/cloudwatch"
+	"github.com/tinytelemetry/tiny-telemetry/internal/drain3"
 	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/events"
+	"github.com/tinytelemetry/tiny-telemetry/internal/health"
 	"github.com/tinytelemetry/tiny-telemetry/internal/httpserver"
 	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
 	"github.com/tinytelemetry/tiny-telemetry/internal/journal"
+	"github.com/tinytelemetry/tiny-telemetry/internal/lumberjack"
+	"github.com/tinytelemetry/tiny-telemetry/internal/memstore"
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 	"github.com/tinytelemetry/tiny-telemetry/internal/otlpreceiver"
+	"github.com/tinytelemetry/tiny-telemetry/internal/querycache"
+	"github.com/tinytelemetry/tiny-telemetry/internal/readrpc"
 	"github.com/tinytelemetry/tiny-telemetry/internal/socketrpc"
+	"github.com/tinytelemetry/tiny-telemetry/internal/systemd"
+	"github.com/tinytelemetry/tiny-telemetry/internal/timestamp"
+	versioncheck "github.com/tinytelemetry/tiny-telemetry/internal/version"
 	"golang.org/x/sync/errgroup"
 )
 
+// maxReadyInsertLag is how long records may wait for the store before
+// /api/health/ready reports the server not ready.
+const maxReadyInsertLag = 30 * time.Second
+
+// storeErrorEventInterval spaces out store_error events while writes keep
+// failing, so a broken store does not flood subscribers.
+const storeErrorEventInterval = 10 * time.Second
+
+// storeErrorEvents returns an InsertBufferConfig.OnFlushError that
+// publishes failed writes to hub, at most one per storeErrorEventInterval.
+func storeErrorEvents(hub *events.Hub) func(error) {
+	var last atomic.Int64
+	return func(err error) {
+		now := time.Now().UnixNano()
+		prev := last.Load()
+		if now-prev < int64(storeErrorEventInterval) || !last.CompareAndSwap(prev, now) {
+			return
+		}
+		hub.Publish(model.ServerEvent{Kind: model.EventStoreError, Message: "store write failed: " + err.Error()})
+	}
+}
+
 // runServer starts headless log ingestion with the HTTP API.
 func runServer(cfg appConfig) error {
 	cleanupLogger := configureRuntimeLogger()
 	defer cleanupLogger()
 
-	// Initialize DuckDB store
-	store, err := duckdb.NewStore(cfg.DBPath, cfg.QueryTimeout)
+	// Adopt sockets passed by systemd before anything binds its own.
+	activated, err := systemd.Listeners()
+	if err != nil {
+		return fmt.Errorf("socket activation: %w", err)
+	}
+	applySocketActivation(&cfg, activated)
+
+	// Initialize the storage backend
+	store, err := openStorage(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to initialize DuckDB: %w", err)
+		return err
 	}
 	defer store.Close()
-	store.SetMaxConcurrentQueries(cfg.MaxConcurrentReads)
 
 	// Open local ingest journal for crash-safe replay and durable buffering.
 	var ingestJournal *journal.Journal
@@ -49,25 +92,107 @@ func runServer(cfg appConfig) error {
 		}
 	}
 
-	// Create insert buffer for batched DuckDB writes
-	insertBuffer := duckdb.NewInsertBuffer(store, duckdb.InsertBufferConfig{
+	// Server events (new apps, store errors, backups) go to socket
+	// subscribers such as the TUI status bar.
+	hub := events.NewHub()
+
+	// Create insert buffer for batched store writes
+	bufferConfig := duckdb.InsertBufferConfig{
 		BatchSize:      cfg.InsertBatchSize,
 		FlushInterval:  cfg.InsertFlushInterval,
 		FlushQueueSize: cfg.InsertFlushQueue,
 		Journal:        ingestJournal,
-	})
+		OnFlushError:   storeErrorEvents(hub),
+	}
+	if cfg.DedupWindow > 0 {
+		bufferConfig.DedupKey = cfg.DedupKey
+	}
+	insertBuffer := duckdb.NewInsertBuffer(store, bufferConfig)
 	defer insertBuffer.Stop()
 
+	// Sampled-out records and those below an app's minimum severity are
+	// counted but not stored.
+	// Live tails (socket Subscribe) see each record as it is buffered, and
+	// only the records that will be stored.
+	tailSink := ingest.NewTailSink(insertBuffer)
+	knownApps, err := store.ListApps()
+	if err != nil {
+		log.Printf("Warning: failed to list apps: %v", err)
+	}
+	var recordSink model.RecordSink = ingest.NewAppWatcher(tailSink, knownApps, func(app string) {
+		hub.Publish(model.ServerEvent{Kind: model.EventAppSeen, Message: "new app " + app, App: app})
+	})
+	var patterns *drain3.Miner
+	if cfg.PatternMining {
+		// Same tree shape as the TUI's patterns view.
+		patterns = drain3.NewMiner(&drain3.Config{Depth: 4, SimilarityTh: 0.5, MaxChildren: 50, MaxClusters: 100})
+		recordSink = ingest.NewPatternSink(recordSink, patterns)
+	}
+	var samplingSink *ingest.SamplingSink
+	if len(cfg.StorageSampling) > 0 {
+		samplingSink, err = ingest.NewSamplingSink(recordSink, sampleRules(cfg))
+		if err != nil {
+			return fmt.Errorf("invalid storage-sampling: %w", err)
+		}
+		defer func() {
+			if n := samplingSink.DroppedTotal(); n > 0 {
+				log.Printf("ingest: %d records sampled out were counted but not stored", n)
+			}
+		}()
+		recordSink = samplingSink
+	}
+	var thresholdSink *ingest.SeverityThresholdSink
+	if len(cfg.StorageMinSeverity) > 0 {
+		thresholds := make(map[string]string, len(cfg.StorageMinSeverity))
+		for _, entry := range cfg.StorageMinSeverity {
+			thresholds[entry.App] = entry.MinSeverity
+		}
+		thresholdSink, err = ingest.NewSeverityThresholdSink(recordSink, thresholds)
+		if err != nil {
+			return fmt.Errorf("invalid storage-min-severity: %w", err)
+		}
+		defer func() {
+			if n := thresholdSink.SuppressedTotal(); n > 0 {
+				log.Printf("ingest: %d records below app minimum severity were counted but not stored", n)
+			}
+		}()
+		// Severity counts include the suppressed records via the store's
+		// minute rollups, where the store keeps them.
+		if counter, ok := store.(model.SuppressedCounter); ok {
+			thresholdSink.PersistSuppressed(counter, time.Second)
+			defer thresholdSink.Stop()
+		}
+		recordSink = thresholdSink
+	}
+
 	// Start retention cleaner for automatic log expiry
 	retentionCleaner := duckdb.NewRetentionCleaner(store, duckdb.RetentionConfig{
 		RetentionDays: cfg.LogRetention,
+		MaxBytes:      cfg.MaxDBSizeBytes,
+		MaxRows:       cfg.MaxRowCount,
+		ArchiveDays:   cfg.ArchiveAfter,
+		Rotate:        cfg.RotatePeriod != "",
 	})
 	if retentionCleaner != nil {
 		defer retentionCleaner.Stop()
 	}
 
-	// Start periodic backups when enabled.
-	backupManager, err := backup.NewManager(store, backup.Config{
+	// Start periodic compaction; loadConfig only allows it for DuckDB.
+	var maintainer *duckdb.Maintainer
+	if compactor, ok := store.(duckdb.Compactor); ok {
+		maintainer = duckdb.NewMaintainer(compactor, duckdb.MaintenanceConfig{
+			Interval: cfg.MaintenanceInterval,
+			Window:   cfg.MaintenanceWindowRange,
+		})
+	}
+	if maintainer != nil {
+		defer maintainer.Stop()
+	}
+
+	// Start periodic backups when enabled. loadConfig only allows them for
+	// DuckDB, the one backend with an on-disk file to snapshot.
+	snapshotter, _ := store.(backup.Snapshotter)
+	backupManager, err := backup.NewManager(snapshotter, backup.Config{
 		Enabled:        cfg.BackupEnabled,
 		Interval:       cfg.BackupInterval,
 		LocalDir:       cfg.BackupLocalDir,
@@ -79,6 +204,13 @@ func runServer(cfg appConfig) error {
 		S3SecretKey:    cfg.BackupS3SecretKey,
 		S3SessionToken: cfg.BackupS3SessionToken,
 		S3UseSSL:       cfg.BackupS3UseSSL,
+		OnRun: func(snapshot string, err error) {
+			if err != nil {
+				hub.Publish(model.ServerEvent{Kind: model.EventBackupFailed, Message: "backup failed: " + err.Error()})
+				return
+			}
+			hub.Publish(model.ServerEvent{Kind: model.EventBackupDone, Message: "backup " + snapshot})
+		},
 	})
 	if err != nil {
 		return fmt.Errorf("failed to initialize backups: %w", err)
@@ -87,9 +219,104 @@ func runServer(cfg appConfig) error {
 		defer backupManager.Stop()
 	}
 
+	// Version check runs once in the background, and only against an
+	// endpoint configured explicitly; offline mode never dials out.
+	versionChecker := versioncheck.NewChecker(version, commit, versioncheck.Config{
+		Disabled:   cfg.DisableVersionCheck,
+		Offline:    cfg.Offline,
+		Endpoint:   cfg.VersionCheckEndpoint,
+		Proxy:      cfg.VersionCheckProxy,
+		CachePath:  cfg.VersionCheckCache,
+		CacheTTL:   cfg.VersionCheckCacheTTL,
+		SendAnonID: cfg.VersionCheckAnonID,
+	})
+	versionChecker.CheckInBackground()
+
+	// API keys guard the HTTP API and the gRPC read API alike.
+	var keyring *httpserver.Keyring
+	if len(cfg.APIKeys) > 0 {
+		if keyring, err = httpserver.NewKeyring(apiKeys(cfg)); err != nil {
+			return err
+		}
+		// The HTTP ingest listeners take keys with the ingest scope.
+		cfg.IngestAuth = func(key string) error {
+			_, _, err := keyring.Authorize(key, httpserver.ScopeIngest)
+			return err
+		}
+	}
+
+	// Component checks behind /api/health/ready and the TUI's Healthchecks
+	// page; sources are added once they run.
+	healthChecks := health.NewRegistry()
+	if pinger, ok := store.(health.Pinger); ok {
+		healthChecks.Add("store", true, pinger.Ping)
+	}
+	healthChecks.Add("insert_buffer", true, func() error {
+		if lag := insertBuffer.Lag(); lag > maxReadyInsertLag {
+			return fmt.Errorf("records waiting %s for the store", lag.Round(time.Second))
+		}
+		return nil
+	})
+	if ingestJournal != nil {
+		healthChecks.Add("journal", true, ingestJournal.Check)
+	}
+	if backupManager != nil {
+		healthChecks.Add("backup", false, func() error {
+			last, err := backupManager.LastRun()
+			switch {
+			case err == nil:
+				return nil
+			case last.IsZero():
+				return fmt.Errorf("no snapshot has succeeded: %w", err)
+			default:
+				return fmt.Errorf("last snapshot failed: %w (last success %s ago)", err, time.Since(last).Round(time.Second))
+			}
+		})
+	}
+	if retentionCleaner != nil {
+		healthChecks.Add("retention", false, retentionCleaner.Check)
+	}
+
 	// Start HTTP API server if enabled
 	if cfg.APIEnabled {
 		apiServer := httpserver.NewServer(cfg.APIAddr, store)
+		apiServer.SetMaxScanRows(cfg.QueryMaxScanRows)
+		apiServer.SetQueryLimits(cfg.QueryMaxRows, cfg.QueryTimeout)
+		if patterns != nil {
+			apiServer.SetPatternSource(patterns)
+		}
+		apiServer.SetExportLimits(cfg.ExportMaxRows, cfg.ExportTimeout)
+		apiServer.SetRateLimit(cfg.APIRateLimit, cfg.APIRateBurst)
+		apiServer.SetAccessLog(httpserver.AccessLogLevel(cfg.APIAccessLog))
+		if keyring != nil {
+			apiServer.SetKeyring(keyring)
+		}
+		apiServer.SetVersionReporter(versionChecker)
+		if retentionCleaner != nil {
+			apiServer.SetRetentionReporter(retentionCleaner)
+		}
+		if maintainer != nil {
+			apiServer.SetMaintenanceReporter(maintainer)
+		}
+		if dedup, ok := store.(httpserver.DedupReporter); ok && cfg.DedupWindow > 0 {
+			apiServer.SetDedupReporter(dedup)
+		}
+		if samplingSink != nil {
+			apiServer.SetSamplingReporter(samplingSink)
+		}
+		if thresholdSink != nil {
+			apiServer.SetThresholdReporter(thresholdSink)
+		}
+		if queries, ok := store.(httpserver.QueryStatsReporter); ok {
+			apiServer.SetQueryStatsReporter(queries)
+		}
+		apiServer.SetHealthRegistry(healthChecks)
+		if cfg.APITLS != nil {
+			apiServer.SetTLSConfig(cfg.APITLS)
+		}
+		if cfg.APIListener != nil {
+			apiServer.SetListener(cfg.APIListener)
+		}
 		if err := apiServer.Start(); err != nil {
 			return fmt.Errorf("failed to start API server: %w", err)
 		}
@@ -97,11 +324,83 @@ func runServer(cfg appConfig) error {
 	}
 
 	// Start socket RPC server for TUI IPC
-	sockServer := socketrpc.NewServer(cfg.SocketPath, store)
+	// Dashboards poll the same aggregates every tick; share them briefly.
+	var sockStore model.ReadAPI = store
+	if cfg.QueryCacheTTL > 0 {
+		sockStore = querycache.New(store, cfg.QueryCacheTTL)
+	}
+	sockServer := socketrpc.NewServer(cfg.SocketPath, sockStore)
+	if traces, ok := store.(model.TraceQuerier); ok {
+		sockServer.SetTraceQuerier(traces)
+	}
+	if ingest, ok := store.(model.IngestRater); ok {
+		sockServer.SetIngestRater(ingest)
+	}
+	if metrics, ok := store.(model.MetricQuerier); ok {
+		sockServer.SetMetricQuerier(metrics)
+	}
+	if saved, ok := store.(model.SavedQueryStore); ok {
+		sockServer.SetSavedQueries(saved)
+	}
+	sockServer.SetLogTailer(tailSink)
+	sockServer.SetEventSource(hub)
+	sockServer.SetHealthReporter(healthChecks)
+	sockServer.SetMaxScanRows(cfg.QueryMaxScanRows)
+	alertEngine, err := alerting.NewEngine(store, alertRules(cfg), alerting.Config{
+		OnFiring: func(a model.Alert) {
+			hub.Publish(model.ServerEvent{Kind: model.EventAlertFiring, Message: "alert " + a.Rule + ": " + a.Summary})
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("invalid alert-rules: %w", err)
+	}
+	if alertEngine != nil {
+		alertEngine.Start()
+		defer alertEngine.Stop()
+		sockServer.SetAlertManager(alertEngine)
+	}
+	if err := sockServer.SetSocketAccess(socketrpc.SocketAccess{
+		Mode:        cfg.SocketFileMode,
+		Owner:       cfg.SocketOwner,
+		Group:       cfg.SocketGroup,
+		AllowUsers:  cfg.SocketAllowUsers,
+		AllowGroups: cfg.SocketAllowGroups,
+	}); err != nil {
+		return fmt.Errorf("invalid socket access: %w", err)
+	}
+	var schemaRevision int
+	if versioned, ok := store.(model.SchemaVersioner); ok {
+		if schemaRevision, err = versioned.SchemaRevision(); err != nil {
+			log.Printf("Warning: failed to read schema revision: %v", err)
+		}
+	}
+	sockServer.SetVersion(version, schemaRevision)
 	if err := sockServer.Start(); err != nil {
 		log.Printf("Warning: failed to start socket server: %v", err)
-	} else {
-		defer sockServer.Stop()
+	}
+	defer sockServer.Stop()
+	if cfg.RPCTCPEnabled {
+		if err := sockServer.ListenTCP(cfg.RPCTCPAddr, cfg.APITLS, cfg.RPCTCPToken); err != nil {
+			return fmt.Errorf("failed to start RPC TCP listener: %w", err)
+		}
+	}
+
+	// Start the gRPC read API if enabled; it serves the socket's methods.
+	if cfg.ReadGRPCEnabled {
+		readServer := readrpc.NewServer(cfg.ReadGRPCAddr, sockStore)
+		if traces, ok := store.(model.TraceQuerier); ok {
+			readServer.SetTraceQuerier(traces)
+		}
+		if keyring != nil {
+			readServer.SetKeyring(keyring)
+		}
+		if cfg.APITLS != nil {
+			readServer.SetTLSConfig(cfg.APITLS)
+		}
+		if err := readServer.Start(); err != nil {
+			return fmt.Errorf("failed to start gRPC read API: %w", err)
+		}
+		defer readServer.Stop()
 	}
 
 	// Set up context and signal handling before errgroup
@@ -132,15 +431,42 @@ func runServer(cfg appConfig) error {
 
 	// Start OTLP/gRPC receiver if enabled
 	if cfg.GRPCEnabled {
-		otlpServer := otlpreceiver.NewServer(cfg.GRPCAddr, insertBuffer)
+		otlpServer := otlpreceiver.NewServer(cfg.GRPCAddr, recordSink)
+		if metrics, ok := store.(model.MetricWriter); ok {
+			otlpServer.SetMetricWriter(metrics)
+		}
+		if spans, ok := store.(model.SpanWriter); ok {
+			otlpServer.SetSpanWriter(spans)
+		}
 		if err := otlpServer.Start(); err != nil {
 			return fmt.Errorf("failed to start OTLP receiver: %w", err)
 		}
 		defer otlpServer.Stop()
 	}
 
+	// Start Beats (lumberjack v2) receiver if enabled
+	if cfg.BeatsEnabled {
+		beatsServer := lumberjack.NewServer(cfg.BeatsAddr, recordSink)
+		if err := beatsServer.Start(); err != nil {
+			return fmt.Errorf("failed to start Beats receiver: %w", err)
+		}
+		defer beatsServer.Stop()
+	}
+
+	// Start CloudWatch Logs (Firehose HTTP endpoint) receiver if enabled
+	if cfg.CloudWatchEnabled {
+		cloudWatchServer := cloudwatch.NewServer(cfg.CloudWatchAddr, recordSink, cloudwatch.Config{
+			AccessKey: cfg.CloudWatchAccessKey,
+			Authorize: cfg.IngestAuth,
+		})
+		if err := cloudWatchServer.Start(); err != nil {
+			return fmt.Errorf("failed to start CloudWatch receiver: %w", err)
+		}
+		defer cloudWatchServer.Stop()
+	}
+
 	// Build input plugins and source multiplexer
-	plugins := buildInputPlugins()
+	plugins := buildInputPlugins(cfg)
 
 	sources := make([]NamedLogSource, 0, len(plugins))
 	for _, plugin := range plugins {
@@ -167,9 +493,33 @@ func runServer(cfg appConfig) error {
 
 	mux := NewSourceMultiplexer(ctx, sources, cfg.MuxBufferSize)
 	mux.Start()
+	if mux.HasSources() {
+		healthChecks.Add("sources", false, func() error {
+			if stopped := mux.Stopped(); len(stopped) > 0 {
+				return fmt.Errorf("%d of %d stopped: %s", len(stopped), len(sources), strings.Join(stopped, ", "))
+			}
+			return nil
+		})
+	}
 
-	// OTEL is the single supported processing path.
-	processor := ingest.NewEnvelopeProcessor(insertBuffer, "")
+	// OTEL JSON (with logfmt fallback) unless a source selects another parser.
+	parsers, err := buildSourceParsers(cfg)
+	if err != nil {
+		return err
+	}
+	multiline, err := buildMultiline(cfg)
+	if err != nil {
+		return err
+	}
+	layouts, err := timestamp.Layouts(cfg.TimestampFormats)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp-formats: %w", err)
+	}
+	ingest.SetTimestampLayouts(layouts)
+	processor := ingest.NewEnvelopeProcessor(recordSink, "", ingest.Config{
+		SourceParsers: parsers,
+		Multiline:     multiline,
+	})
 
 	printStartupBanner(cfg, mux.HasSources(), processor.Name())
 
@@ -182,6 +532,7 @@ func runServer(cfg appConfig) error {
 			for env := range mux.Lines() {
 				processor.ProcessEnvelope(env)
 			}
+			processor.Flush()
 			return nil
 		})
 	}
@@ -241,7 +592,71 @@ func configureRuntimeLogger() func() {
 	}
 }
 
-func replayUncommittedJournal(j *journal.Journal, store *duckdb.Store, batchSize int) error {
+// openStorage creates the configured storage backend.
+func openStorage(cfg appConfig) (model.StorageBackend, error) {
+	switch cfg.StorageBackend {
+	case storageBackendMemory:
+		return memstore.NewStore(memstore.Config{MaxRecords: cfg.MemoryMaxRecords}), nil
+	default:
+		store, err := duckdb.NewStoreWithConfig(cfg.DBPath, duckDBConfig(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize DuckDB: %w", err)
+		}
+		store.SetMaxConcurrentQueries(cfg.MaxConcurrentReads)
+		store.EnableDedup(cfg.DedupWindow)
+		if err := store.SetRawLinePolicy(duckdb.RawLinePolicy(cfg.RawLine)); err != nil {
+			store.Close()
+			return nil, fmt.Errorf("failed to set raw line policy: %w", err)
+		}
+		if len(cfg.PromoteAttributes) > 0 {
+			attrs := make([]duckdb.PromotedAttribute, len(cfg.PromoteAttributes))
+			for i, a := range cfg.PromoteAttributes {
+				attrs[i] = duckdb.PromotedAttribute{Key: a.Key, Type: a.Type}
+			}
+			if err := store.PromoteAttributes(attrs); err != nil {
+				store.Close()
+				return nil, fmt.Errorf("failed to promote attributes: %w", err)
+			}
+		}
+		if cfg.PartitionByDay {
+			if err := store.EnableDayPartitions(); err != nil {
+				store.Close()
+				return nil, fmt.Errorf("failed to partition logs by day: %w", err)
+			}
+		}
+		if cfg.ArchiveAfter > 0 {
+			if err := store.EnableArchive(cfg.ArchiveDir); err != nil {
+				store.Close()
+				return nil, fmt.Errorf("failed to enable archive: %w", err)
+			}
+		}
+		if cfg.RotatePeriod != "" {
+			if err := store.EnableRotation(cfg.RotateDir, duckdb.RotationPeriod(cfg.RotatePeriod)); err != nil {
+				store.Close()
+				return nil, fmt.Errorf("failed to enable rotation: %w", err)
+			}
+		}
+		if cfg.SearchIndex {
+			if err := store.EnableSearchIndex(); err != nil {
+				store.Close()
+				return nil, fmt.Errorf("failed to enable search index: %w", err)
+			}
+		}
+		return store, nil
+	}
+}
+
+// duckDBConfig returns the DuckDB query timeout and resource limits.
+func duckDBConfig(cfg appConfig) duckdb.Config {
+	return duckdb.Config{
+		QueryTimeout:  cfg.QueryTimeout,
+		MemoryLimit:   cfg.DuckDBMemoryLimitBytes,
+		Threads:       cfg.DuckDBThreads,
+		TempDirectory: cfg.DuckDBTempDir,
+	}
+}
+
+func replayUncommittedJournal(j *journal.Journal, store model.LogWriter, batchSize int) error {
 	if j == nil {
 		return nil
 	}
@@ -329,25 +744,146 @@ func printStartupBanner(cfg appConfig, _ bool, processorName string) {
 	lines = append(lines, "")
 
 	if cfg.APIEnabled {
-		lines = append(lines, fmt.Sprintf("    %s  HTTP API       %s", check, cyan.Render(cfg.APIAddr)))
+		var authTag string
+		if n := len(cfg.APIKeys); n > 0 {
+			authTag = dim.Render(fmt.Sprintf(" (%d API keys)", n))
+		}
+		lines = append(lines, fmt.Sprintf("    %s  HTTP API       %s%s%s%s", check, cyan.Render(cfg.APIAddr), dim.Render(tlsTag(cfg.APITLS)), authTag, activatedTag(cfg.APIListener, dim)))
 	} else {
 		lines = append(lines, fmt.Sprintf("    %s  HTTP API       %s", dot, dim.Render("disabled")))
 	}
 
+	if cfg.ReadGRPCEnabled {
+		var authTag string
+		if n := len(cfg.APIKeys); n > 0 {
+			authTag = dim.Render(fmt.Sprintf(" (%d API keys)", n))
+		}
+		lines = append(lines, fmt.Sprintf("    %s  Read gRPC      %s%s%s", check, cyan.Render(cfg.ReadGRPCAddr), dim.Render(tlsTag(cfg.APITLS)), authTag))
+	}
+
+	if cfg.RPCTCPEnabled {
+		lines = append(lines, fmt.Sprintf("    %s  TUI RPC        %s%s%s", check, cyan.Render(cfg.RPCTCPAddr), dim.Render(tlsTag(cfg.APITLS)), dim.Render(" (token)")))
+	}
+
+	if cfg.TCPEnabled {
+		var acksTag string
+		if cfg.TCPAcks {
+			acksTag = dim.Render(" (acks)")
+		}
+		lines = append(lines, fmt.Sprintf("    %s  TCP            %s%s%s%s", check, cyan.Render(cfg.TCPAddr), dim.Render(tlsTag(cfg.TCPTLS)), activatedTag(cfg.TCPListener, dim), acksTag))
+	} else {
+		lines = append(lines, fmt.Sprintf("    %s  TCP            %s", dot, dim.Render("disabled")))
+	}
+
 	if cfg.GRPCEnabled {
-		lines = append(lines, fmt.Sprintf("    %s  OTLP/gRPC      %s", check, cyan.Render(cfg.GRPCAddr)))
+		signals := "logs"
+		if cfg.StorageBackend != storageBackendMemory {
+			signals = "logs, metrics, traces"
+		}
+		lines = append(lines, fmt.Sprintf("    %s  OTLP/gRPC      %s %s", check, cyan.Render(cfg.GRPCAddr), dim.Render("("+signals+")")))
 	} else {
 		lines = append(lines, fmt.Sprintf("    %s  OTLP/gRPC      %s", dot, dim.Render("disabled")))
 	}
 
+	if cfg.BeatsEnabled {
+		lines = append(lines, fmt.Sprintf("    %s  Beats          %s", check, cyan.Render(cfg.BeatsAddr)))
+	} else {
+		lines = append(lines, fmt.Sprintf("    %s  Beats          %s", dot, dim.Render("disabled")))
+	}
+
+	if cfg.CloudWatchEnabled {
+		lines = append(lines, fmt.Sprintf("    %s  CloudWatch     %s", check, cyan.Render(cfg.CloudWatchAddr)))
+	} else {
+		lines = append(lines, fmt.Sprintf("    %s  CloudWatch     %s", dot, dim.Render("disabled")))
+	}
+
+	if cfg.LogplexEnabled {
+		lines = append(lines, fmt.Sprintf("    %s  Logplex        %s", check, cyan.Render(cfg.LogplexAddr)))
+	} else {
+		lines = append(lines, fmt.Sprintf("    %s  Logplex        %s", dot, dim.Render("disabled")))
+	}
+
 	lines = append(lines, fmt.Sprintf("    %s  Unix Socket    %s", check, cyan.Render(shortenPath(cfg.SocketPath))))
+	if cfg.QueryCacheTTL > 0 {
+		lines = append(lines, fmt.Sprintf("    %s  Query cache    %s", check, dim.Render(fmt.Sprintf("%s (socket aggregates)", cfg.QueryCacheTTL))))
+	}
 	lines = append(lines, "")
 
 	// Storage
 	lines = append(lines, bold.Render("    Storage"))
 	lines = append(lines, "")
 
-	lines = append(lines, fmt.Sprintf("    %s  Storage        %s", check, dim.Render(shortenPath(cfg.DBPath))))
+	if cfg.StorageBackend == storageBackendMemory {
+		lines = append(lines, fmt.Sprintf("    %s  Storage        %s", check, dim.Render(fmt.Sprintf("memory (max %d records, SQL disabled)", cfg.MemoryMaxRecords))))
+	} else {
+		lines = append(lines, fmt.Sprintf("    %s  Storage        %s", check, dim.Render(shortenPath(cfg.DBPath))))
+		if cfg.DuckDBMemoryLimit != "" || cfg.DuckDBThreads > 0 || cfg.DuckDBTempDir != "" {
+			var limits []string
+			if cfg.DuckDBMemoryLimit != "" {
+				limits = append(limits, cfg.DuckDBMemoryLimit+" memory")
+			}
+			if cfg.DuckDBThreads > 0 {
+				limits = append(limits, fmt.Sprintf("%d threads", cfg.DuckDBThreads))
+			}
+			if cfg.DuckDBTempDir != "" {
+				limits = append(limits, "spill to "+shortenPath(cfg.DuckDBTempDir))
+			}
+			lines = append(lines, fmt.Sprintf("    %s  DuckDB         %s", check, dim.Render(strings.Join(limits, ", "))))
+		}
+		if cfg.MaintenanceInterval > 0 {
+			schedule := "every " + cfg.MaintenanceInterval.String()
+			if cfg.MaintenanceWindow != "" {
+				schedule += " (" + cfg.MaintenanceWindow + ")"
+			}
+			lines = append(lines, fmt.Sprintf("    %s  Maintenance    %s", check, dim.Render(schedule)))
+		}
+		if cfg.PartitionByDay {
+			lines = append(lines, fmt.Sprintf("    %s  Partitions     %s", check, dim.Render("one table per UTC day")))
+		}
+		if cfg.ArchiveAfter > 0 {
+			lines = append(lines, fmt.Sprintf("    %s  Archive        %s", check, dim.Render(fmt.Sprintf("after %d days to %s", cfg.ArchiveAfter, shortenPath(cfg.ArchiveDir)))))
+		}
+		if cfg.RotatePeriod != "" {
+			lines = append(lines, fmt.Sprintf("    %s  Rotation       %s", check, dim.Render(fmt.Sprintf("every %s to %s", cfg.RotatePeriod, shortenPath(cfg.RotateDir)))))
+		}
+		if cfg.SearchIndex {
+			lines = append(lines, fmt.Sprintf("    %s  Search index   %s", check, dim.Render("trigram (message)")))
+		}
+		if cfg.RawLine != string(duckdb.RawLineAlways) {
+			lines = append(lines, fmt.Sprintf("    %s  Raw lines      %s", check, dim.Render(cfg.RawLine)))
+		}
+		if cfg.DedupWindow > 0 {
+			lines = append(lines, fmt.Sprintf("    %s  Dedup          %s", check, dim.Render(fmt.Sprintf("%s window, by %s", cfg.DedupWindow, cfg.DedupKey))))
+		}
+		if len(cfg.PromoteAttributes) > 0 {
+			keys := make([]string, len(cfg.PromoteAttributes))
+			for i, a := range cfg.PromoteAttributes {
+				keys[i] = a.Key
+			}
+			lines = append(lines, fmt.Sprintf("    %s  Promoted       %s", check, dim.Render(strings.Join(keys, ", "))))
+		}
+	}
+	if cfg.MaxDBSizeBytes > 0 || cfg.MaxRowCount > 0 {
+		var limits []string
+		if cfg.MaxDBSizeBytes > 0 {
+			limits = append(limits, "max "+cfg.MaxDBSize)
+		}
+		if cfg.MaxRowCount > 0 {
+			limits = append(limits, fmt.Sprintf("max %d rows", cfg.MaxRowCount))
+		}
+		lines = append(lines, fmt.Sprintf("    %s  Size limit     %s", check, dim.Render(strings.Join(limits, ", "))))
+	}
+	if len(cfg.StorageSampling) > 0 {
+		rules := make([]string, len(cfg.StorageSampling))
+		for i, r := range cfg.StorageSampling {
+			level := r.Level
+			if level == "" {
+				level = "*"
+			}
+			rules[i] = fmt.Sprintf("%s/%s %g%%", r.App, strings.ToUpper(level), r.Rate*100)
+		}
+		lines = append(lines, fmt.Sprintf("    %s  Sampling       %s", check, dim.Render(strings.Join(rules, ", "))))
+	}
 	if cfg.BackupEnabled {
 		lines = append(lines, fmt.Sprintf("    %s  Snapshots      %s", check, dim.Render(shortenPath(cfg.BackupLocalDir))))
 	} else {
@@ -361,6 +897,44 @@ func printStartupBanner(cfg appConfig, _ bool, processorName string) {
 	lines = append(lines, "")
 
 	lines = append(lines, fmt.Sprintf("    %s  Processor      %s", check, dim.Render(processorName)))
+	if len(cfg.SourceParsers) > 0 {
+		sources := make([]string, 0, len(cfg.SourceParsers))
+		for source, parser := range cfg.SourceParsers {
+			sources = append(sources, source+"="+parser)
+		}
+		sort.Strings(sources)
+		lines = append(lines, fmt.Sprintf("    %s  Parsers        %s", check, dim.Render(strings.Join(sources, " "))))
+	}
+	if len(cfg.SourceExtractors) > 0 {
+		sources := make([]string, 0, len(cfg.SourceExtractors))
+		for source, exprs := range cfg.SourceExtractors {
+			sources = append(sources, fmt.Sprintf("%s(%d)", source, len(exprs)))
+		}
+		sort.Strings(sources)
+		lines = append(lines, fmt.Sprintf("    %s  Extractors     %s", check, dim.Render(strings.Join(sources, " "))))
+	}
+	if len(cfg.Multiline) > 0 {
+		sources := make([]string, 0, len(cfg.Multiline))
+		for source := range cfg.Multiline {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		lines = append(lines, fmt.Sprintf("    %s  Multiline      %s", check, dim.Render(strings.Join(sources, " "))))
+	}
+	if cfg.PatternMining {
+		lines = append(lines, fmt.Sprintf("    %s  Patterns       %s", check, dim.Render("drain3, per severity and service")))
+	}
+	if len(cfg.TimestampFormats) > 0 {
+		lines = append(lines, fmt.Sprintf("    %s  Timestamps     %s", check, dim.Render(strings.Join(cfg.TimestampFormats, ", "))))
+	}
+	switch {
+	case cfg.Offline:
+		lines = append(lines, fmt.Sprintf("    %s  Version Check  %s", dot, dim.Render("offline")))
+	case cfg.DisableVersionCheck || cfg.VersionCheckEndpoint == "":
+		lines = append(lines, fmt.Sprintf("    %s  Version Check  %s", dot, dim.Render("disabled")))
+	default:
+		lines = append(lines, fmt.Sprintf("    %s  Version Check  %s", check, dim.Render(cfg.VersionCheckEndpoint)))
+	}
 
 	lines = append(lines, "")
 	lines = append(lines, bold.Render("    Config"))
@@ -380,6 +954,14 @@ func printStartupBanner(cfg appConfig, _ bool, processorName string) {
 	fmt.Println(strings.Join(lines, "\n"))
 }
 
+// activatedTag marks banner addresses served from a systemd-provided socket.
+func activatedTag(ln net.Listener, style lipgloss.Style) string {
+	if ln == nil {
+		return ""
+	}
+	return style.Render(" (systemd)")
+}
+
 func shortenPath(path string) string {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"log"
+	"net"
+)
+
+// Socket names expected in FileDescriptorName= of the systemd .socket unit.
+const (
+	activationTCPName = "tcp"
+	activationAPIName = "api"
+)
+
+// applySocketActivation points the TCP ingest and HTTP API at listeners
+// passed in by systemd. An activated socket enables its surface and
+// replaces the configured address; unknown names are closed.
+func applySocketActivation(cfg *appConfig, listeners map[string]net.Listener) {
+	for name, ln := range listeners {
+		switch name {
+		case activationTCPName:
+			cfg.TCPEnabled = true
+			cfg.TCPListener = ln
+			cfg.TCPAddr = ln.Addr().String()
+		case activationAPIName:
+			cfg.APIEnabled = true
+			cfg.APIListener = ln
+			cfg.APIAddr = ln.Addr().String()
+		default:
+			log.Printf("socket activation: ignoring socket %q (expected %q or %q)", name, activationTCPName, activationAPIName)
+			ln.Close()
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestApplySocketActivation(t *testing.T) {
+	tcpLn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiLn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer apiLn.Close()
+	strayLn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := appConfig{TCPAddr: "127.0.0.1:4000", APIAddr: "127.0.0.1:3000"}
+	applySocketActivation(&cfg, map[string]net.Listener{
+		"tcp":   tcpLn,
+		"api":   apiLn,
+		"stray": strayLn,
+	})
+
+	if !cfg.TCPEnabled || cfg.TCPListener != tcpLn || cfg.TCPAddr != tcpLn.Addr().String() {
+		t.Fatalf("tcp not adopted: enabled=%v addr=%s", cfg.TCPEnabled, cfg.TCPAddr)
+	}
+	if !cfg.APIEnabled || cfg.APIListener != apiLn || cfg.APIAddr != apiLn.Addr().String() {
+		t.Fatalf("api not adopted: enabled=%v addr=%s", cfg.APIEnabled, cfg.APIAddr)
+	}
+	if _, err := strayLn.Accept(); err == nil {
+		t.Fatal("unknown socket should be closed")
+	}
+
+	// The TCP plugin serves the adopted listener rather than binding tcp-addr.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	src, err := buildInputPlugins(cfg)[0].Build(ctx)
+	if err != nil {
+		t.Fatalf("build tcp source: %v", err)
+	}
+	defer src.Stop()
+
+	conn, err := net.Dial("tcp", tcpLn.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	conn.Write([]byte("hello\n"))
+	conn.Close()
+
+	select {
+	case env := <-src.Lines():
+		if env.Line != "hello" {
+			t.Fatalf("line = %q", env.Line)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no line received on adopted listener")
+	}
+}
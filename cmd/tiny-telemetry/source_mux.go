@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"slices"
 	"sync"
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
@@ -22,6 +23,9 @@ type SourceMultiplexer struct {
 	stopOnce  sync.Once
 	closeOnce sync.Once
 	wg        sync.WaitGroup
+
+	mu      sync.Mutex
+	stopped []string // sources whose stream ended on its own
 }
 
 func NewSourceMultiplexer(parent context.Context, sources []NamedLogSource, buffer int) *SourceMultiplexer {
@@ -83,6 +87,14 @@ func (m *SourceMultiplexer) Lines() <-chan model.IngestEnvelope {
 	return m.lines
 }
 
+// Stopped returns the names of the sources whose streams ended before the
+// multiplexer was stopped, such as a file source that failed or stdin at EOF.
+func (m *SourceMultiplexer) Stopped() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.stopped)
+}
+
 func (m *SourceMultiplexer) forward(src NamedLogSource) {
 	defer m.wg.Done()
 
@@ -93,9 +105,14 @@ func (m *SourceMultiplexer) forward(src NamedLogSource) {
 			return
 		case line, ok := <-sourceLines:
 			if !ok {
+				if m.ctx.Err() == nil {
+					m.mu.Lock()
+					m.stopped = append(m.stopped, src.Name())
+					m.mu.Unlock()
+				}
 				return
 			}
-			if line.Line == "" {
+			if line.Empty() {
 				continue
 			}
 			select {
@@ -92,6 +92,29 @@ func TestSourceMultiplexer_StopInvokesSourceStop(t *testing.T) {
 	}
 }
 
+func TestSourceMultiplexer_Stopped(t *testing.T) {
+	t.Parallel()
+
+	a := newFakeSource("a", 1)
+	b := newFakeSource("b", 1)
+	mux := NewSourceMultiplexer(context.Background(), []NamedLogSource{a, b}, 8)
+	mux.Start()
+
+	a.Stop()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(mux.Stopped()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := mux.Stopped(); len(got) != 1 || got[0] != "a" {
+		t.Fatalf("Stopped = %v, want [a]", got)
+	}
+
+	mux.Stop()
+	if got := mux.Stopped(); len(got) != 1 {
+		t.Errorf("Stopped after Stop = %v, want only a", got)
+	}
+}
+
 type integrationSink struct {
 	records []*model.LogRecord
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// tlsFiles names the PEM files of a listener's TLS setup.
+type tlsFiles struct {
+	Cert     string
+	Key      string
+	ClientCA string // require client certificates signed by it
+}
+
+// serverTLS loads f into a server TLS config; nil when f sets no
+// certificate.
+func serverTLS(f tlsFiles) (*tls.Config, error) {
+	if f.Cert == "" && f.Key == "" {
+		if f.ClientCA != "" {
+			return nil, fmt.Errorf("client-ca needs cert and key")
+		}
+		return nil, nil
+	}
+	if f.Cert == "" || f.Key == "" {
+		return nil, fmt.Errorf("cert and key must be set together")
+	}
+	cert, err := tls.LoadX509KeyPair(f.Cert, f.Key)
+	if err != nil {
+		return nil, err
+	}
+	conf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if f.ClientCA != "" {
+		pem, err := os.ReadFile(f.ClientCA)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("client-ca %s: no PEM certificates", f.ClientCA)
+		}
+		conf.ClientCAs = pool
+		conf.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return conf, nil
+}
+
+// tlsTag labels a listener in the startup banner by its TLS setup.
+func tlsTag(conf *tls.Config) string {
+	switch {
+	case conf == nil:
+		return ""
+	case conf.ClientAuth == tls.RequireAndVerifyClientCert:
+		return " (mTLS)"
+	default:
+		return " (TLS)"
+	}
+}
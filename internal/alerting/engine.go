@@ -0,0 +1,279 @@
+// Package alerting evaluates alert rules over the store's severity counts
+// and keeps the state of each rule for the Alerts page.
+package alerting
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/logparse"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+const (
+	// DefaultInterval is how often the rules are evaluated.
+	DefaultInterval = 30 * time.Second
+	// DefaultWindow is the span of logs a rule counts.
+	DefaultWindow = 5 * time.Minute
+
+	// historyLen is how many evaluated values an alert keeps.
+	historyLen = 30
+	// resolvedFor is how long a resolved rule stays listed.
+	resolvedFor = time.Hour
+)
+
+// Rule fires when more than Threshold logs of its app and level arrive
+// within Window, and has done so for For.
+type Rule struct {
+	Name      string
+	App       string // empty counts every app
+	Level     string // empty counts every severity
+	Threshold float64
+	Window    time.Duration // DefaultWindow when 0
+	For       time.Duration // 0 fires on the first evaluation over Threshold
+	Severity  string        // reported on the alert, e.g. "critical"
+	Summary   string
+}
+
+// Config holds optional Engine settings.
+type Config struct {
+	Interval time.Duration // DefaultInterval when 0
+	// OnFiring, when set, is called when a rule starts firing and is not
+	// silenced. It runs on the evaluation goroutine and should not block.
+	OnFiring func(model.Alert)
+}
+
+// SeverityCounter is the part of the store the engine reads.
+type SeverityCounter interface {
+	SeverityCounts(opts model.QueryOpts) (map[string]int64, error)
+}
+
+// Engine evaluates rules on an interval and implements model.AlertManager.
+// All methods are safe for concurrent use.
+type Engine struct {
+	counter  SeverityCounter
+	rules    []Rule
+	interval time.Duration
+	onFiring func(model.Alert)
+
+	mu     sync.Mutex
+	alerts []model.Alert // one per rule, in rule order; State "" when inactive
+
+	done     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewEngine validates rules and returns an engine over counter. It returns
+// nil when there are no rules.
+func NewEngine(counter SeverityCounter, rules []Rule, conf ...Config) (*Engine, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	e := &Engine{
+		counter:  counter,
+		rules:    make([]Rule, len(rules)),
+		interval: DefaultInterval,
+		alerts:   make([]model.Alert, len(rules)),
+		done:     make(chan struct{}),
+	}
+	if len(conf) > 0 {
+		if conf[0].Interval > 0 {
+			e.interval = conf[0].Interval
+		}
+		e.onFiring = conf[0].OnFiring
+	}
+
+	seen := make(map[string]bool, len(rules))
+	for i, r := range rules {
+		r.Name = strings.TrimSpace(r.Name)
+		if r.Name == "" {
+			return nil, fmt.Errorf("rule %d: name is required", i+1)
+		}
+		if seen[r.Name] {
+			return nil, fmt.Errorf("rule %q: duplicate name", r.Name)
+		}
+		seen[r.Name] = true
+		if r.Threshold < 0 || r.Window < 0 || r.For < 0 {
+			return nil, fmt.Errorf("rule %q: threshold, window, and for must not be negative", r.Name)
+		}
+		if r.Level != "" {
+			r.Level = logparse.NormalizeSeverity(r.Level)
+		}
+		if r.Window == 0 {
+			r.Window = DefaultWindow
+		}
+		if r.Summary == "" {
+			r.Summary = summary(r)
+		}
+		e.rules[i] = r
+		e.alerts[i] = model.Alert{Rule: r.Name, Severity: r.Severity, Summary: r.Summary}
+	}
+	return e, nil
+}
+
+// summary describes a rule without one of its own.
+func summary(r Rule) string {
+	what := "logs"
+	if r.Level != "" {
+		what = r.Level + " logs"
+	}
+	if r.App != "" {
+		what += " from " + r.App
+	}
+	return fmt.Sprintf("more than %g %s in %s", r.Threshold, what, r.Window)
+}
+
+// Start evaluates the rules now and then every interval until Stop.
+func (e *Engine) Start() {
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		e.evaluate(time.Now())
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				e.evaluate(time.Now())
+			case <-e.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends evaluation and waits for a running one to finish.
+func (e *Engine) Stop() {
+	e.stopOnce.Do(func() {
+		close(e.done)
+		e.wg.Wait()
+	})
+}
+
+// evaluate counts each rule's logs up to now and moves its alert through
+// pending, firing, and resolved.
+func (e *Engine) evaluate(now time.Time) {
+	for i, r := range e.rules {
+		counts, err := e.counter.SeverityCounts(model.QueryOpts{App: r.App, From: now.Add(-r.Window), To: now})
+		if err != nil {
+			log.Printf("alerting: rule %q: %v", r.Name, err)
+			continue
+		}
+		var value float64
+		for level, n := range counts {
+			if r.Level == "" || level == r.Level {
+				value += float64(n)
+			}
+		}
+
+		e.mu.Lock()
+		a := &e.alerts[i]
+		a.Value = value
+		a.History = append(a.History, value)
+		if len(a.History) > historyLen {
+			a.History = a.History[len(a.History)-historyLen:]
+		}
+		fired := e.transition(a, r, value > r.Threshold, now)
+		alert := copyAlert(*a)
+		e.mu.Unlock()
+
+		if fired && e.onFiring != nil && !alert.Silenced(now) {
+			e.onFiring(alert)
+		}
+	}
+}
+
+// transition updates a's state for whether its condition holds at now,
+// reporting whether it started firing. Caller must hold e.mu.
+func (e *Engine) transition(a *model.Alert, r Rule, holds bool, now time.Time) bool {
+	set := func(state string) {
+		a.State, a.Since = state, now
+	}
+	switch {
+	case holds && (a.State == "" || a.State == model.AlertResolved):
+		a.Acknowledged = false
+		if r.For > 0 {
+			set(model.AlertPending)
+			return false
+		}
+		set(model.AlertFiring)
+		return true
+	case holds && a.State == model.AlertPending && now.Sub(a.Since) >= r.For:
+		set(model.AlertFiring)
+		return true
+	case !holds && a.State == model.AlertPending:
+		set("")
+	case !holds && a.State == model.AlertFiring:
+		a.Acknowledged = false
+		set(model.AlertResolved)
+	case !holds && a.State == model.AlertResolved && now.Sub(a.Since) > resolvedFor:
+		set("")
+	}
+	return false
+}
+
+// Alerts returns the rules that are pending, firing, or recently resolved,
+// in configuration order.
+func (e *Engine) Alerts() ([]model.Alert, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	out := make([]model.Alert, 0, len(e.alerts))
+	for _, a := range e.alerts {
+		if a.State != "" {
+			out = append(out, copyAlert(a))
+		}
+	}
+	return out, nil
+}
+
+// SilenceAlert keeps OnFiring from being called for rule for d; d <= 0
+// lifts the silence.
+func (e *Engine) SilenceAlert(rule string, d time.Duration) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	a, err := e.alert(rule)
+	if err != nil {
+		return err
+	}
+	a.SilencedUntil = time.Time{}
+	if d > 0 {
+		a.SilencedUntil = time.Now().Add(d)
+	}
+	return nil
+}
+
+// AcknowledgeAlert marks rule's current firing as seen; it is cleared when
+// the rule resolves.
+func (e *Engine) AcknowledgeAlert(rule string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	a, err := e.alert(rule)
+	if err != nil {
+		return err
+	}
+	a.Acknowledged = true
+	return nil
+}
+
+// alert returns the alert of rule. Caller must hold e.mu.
+func (e *Engine) alert(rule string) (*model.Alert, error) {
+	for i := range e.alerts {
+		if e.alerts[i].Rule == rule {
+			return &e.alerts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", model.ErrUnknownAlert, rule)
+}
+
+// copyAlert returns a with its own history slice.
+func copyAlert(a model.Alert) model.Alert {
+	a.History = append([]float64(nil), a.History...)
+	return a
+}
@@ -0,0 +1,124 @@
+package alerting
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+type stubCounter struct {
+	counts map[string]int64
+	opts   model.QueryOpts
+}
+
+func (s *stubCounter) SeverityCounts(opts model.QueryOpts) (map[string]int64, error) {
+	s.opts = opts
+	return s.counts, nil
+}
+
+func TestEngine_PendingFiringResolved(t *testing.T) {
+	t.Parallel()
+
+	counter := &stubCounter{}
+	var fired []model.Alert
+	e, err := NewEngine(counter, []Rule{
+		{Name: "checkout-errors", App: "checkout", Level: "error", Threshold: 10, Window: time.Minute, For: time.Minute},
+	}, Config{OnFiring: func(a model.Alert) { fired = append(fired, a) }})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	state := func() string {
+		t.Helper()
+		alerts, _ := e.Alerts()
+		if len(alerts) == 0 {
+			return ""
+		}
+		return alerts[0].State
+	}
+
+	t0 := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	counter.counts = map[string]int64{"ERROR": 5, "INFO": 100}
+	e.evaluate(t0)
+	if got := state(); got != "" {
+		t.Fatalf("below threshold: state = %q, want no alert", got)
+	}
+	if counter.opts.App != "checkout" || !counter.opts.From.Equal(t0.Add(-time.Minute)) || !counter.opts.To.Equal(t0) {
+		t.Fatalf("counted %+v, want checkout over the last minute", counter.opts)
+	}
+
+	counter.counts = map[string]int64{"ERROR": 11}
+	e.evaluate(t0.Add(30 * time.Second))
+	if got := state(); got != model.AlertPending {
+		t.Fatalf("over threshold: state = %q, want pending", got)
+	}
+	e.evaluate(t0.Add(90 * time.Second))
+	if got := state(); got != model.AlertFiring || len(fired) != 1 {
+		t.Fatalf("after for: state = %q, fired %d; want firing once", got, len(fired))
+	}
+
+	if err := e.AcknowledgeAlert("checkout-errors"); err != nil {
+		t.Fatalf("AcknowledgeAlert: %v", err)
+	}
+	alerts, _ := e.Alerts()
+	if !alerts[0].Acknowledged || alerts[0].Value != 11 || len(alerts[0].History) != 3 {
+		t.Fatalf("alert = %+v, want acknowledged with value 11 and three values of history", alerts[0])
+	}
+
+	counter.counts = nil
+	e.evaluate(t0.Add(2 * time.Minute))
+	alerts, _ = e.Alerts()
+	if alerts[0].State != model.AlertResolved || alerts[0].Acknowledged {
+		t.Fatalf("alert = %+v, want resolved and no longer acknowledged", alerts[0])
+	}
+	e.evaluate(t0.Add(2*time.Minute + resolvedFor + time.Second))
+	if got := state(); got != "" {
+		t.Fatalf("long resolved: state = %q, want no alert", got)
+	}
+}
+
+func TestEngine_SilenceSuppressesOnFiring(t *testing.T) {
+	t.Parallel()
+
+	counter := &stubCounter{counts: map[string]int64{"WARN": 3}}
+	fired := 0
+	e, err := NewEngine(counter, []Rule{{Name: "any-logs", Threshold: 1}}, Config{OnFiring: func(model.Alert) { fired++ }})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := e.SilenceAlert("any-logs", time.Hour); err != nil {
+		t.Fatalf("SilenceAlert: %v", err)
+	}
+	e.evaluate(time.Now())
+	alerts, _ := e.Alerts()
+	if len(alerts) != 1 || alerts[0].State != model.AlertFiring || !alerts[0].Silenced(time.Now()) || fired != 0 {
+		t.Fatalf("alerts = %+v, fired %d; want a silenced firing alert and no notification", alerts, fired)
+	}
+
+	if err := e.SilenceAlert("nope", time.Hour); !errors.Is(err, model.ErrUnknownAlert) {
+		t.Fatalf("SilenceAlert(unknown) = %v, want ErrUnknownAlert", err)
+	}
+	if err := e.AcknowledgeAlert("nope"); !errors.Is(err, model.ErrUnknownAlert) {
+		t.Fatalf("AcknowledgeAlert(unknown) = %v, want ErrUnknownAlert", err)
+	}
+}
+
+func TestNewEngine_Validates(t *testing.T) {
+	t.Parallel()
+
+	if e, err := NewEngine(nil, nil); e != nil || err != nil {
+		t.Fatalf("NewEngine(no rules) = %v, %v; want nil, nil", e, err)
+	}
+	for _, rules := range [][]Rule{
+		{{Threshold: 1}},
+		{{Name: "a"}, {Name: "a"}},
+		{{Name: "a", Threshold: -1}},
+		{{Name: "a", For: -time.Second}},
+	} {
+		if _, err := NewEngine(nil, rules); err == nil {
+			t.Errorf("NewEngine(%+v) accepted invalid rules", rules)
+		}
+	}
+}
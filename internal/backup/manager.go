@@ -30,6 +30,10 @@ type Manager struct {
 	done     chan struct{}
 	wg       sync.WaitGroup
 	stopOnce sync.Once
+
+	mu          sync.Mutex
+	lastSuccess time.Time
+	lastErr     error
 }
 
 // NewManager initializes backup manager. It returns nil when backups are disabled.
@@ -115,10 +119,29 @@ func (m *Manager) loop() {
 	}
 }
 
+// LastRun returns when a snapshot last succeeded (zero if none has) and
+// the error of the latest run, nil if it succeeded.
+func (m *Manager) LastRun() (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastSuccess, m.lastErr
+}
+
 // RunOnce creates one local snapshot, uploads it when configured, and prunes old local copies.
-func (m *Manager) RunOnce(ctx context.Context) error {
+func (m *Manager) RunOnce(ctx context.Context) (err error) {
 	timestamp := strings.ReplaceAll(time.Now().UTC().Format("20060102-150405.000000000"), ".", "-")
 	fileName := fmt.Sprintf("tiny-telemetry-%s.duckdb", timestamp)
+	defer func() {
+		m.mu.Lock()
+		m.lastErr = err
+		if err == nil {
+			m.lastSuccess = time.Now()
+		}
+		m.mu.Unlock()
+		if m.cfg.OnRun != nil {
+			m.cfg.OnRun(fileName, err)
+		}
+	}()
 	localPath := filepath.Join(m.cfg.LocalDir, fileName)
 
 	if err := m.store.SnapshotTo(localPath); err != nil {
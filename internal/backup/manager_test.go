@@ -2,8 +2,10 @@ package backup
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -82,6 +84,20 @@ func TestRunOnce_CreatesAndPrunesLocalBackups(t *testing.T) {
 	if len(files) != 2 {
 		t.Fatalf("backup files = %d, want 2", len(files))
 	}
+	if last, err := m.LastRun(); last.IsZero() || err != nil {
+		t.Errorf("LastRun = %v, %v; want a success", last, err)
+	}
+
+	m.cfg.LocalDir = filepath.Join(localDir, "missing", "dir")
+	if err := os.WriteFile(filepath.Join(localDir, "missing"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.RunOnce(context.Background()); err == nil {
+		t.Fatal("RunOnce into a file path succeeded")
+	}
+	if last, err := m.LastRun(); last.IsZero() || err == nil {
+		t.Errorf("LastRun after a failure = %v, %v; want the earlier success and the error", last, err)
+	}
 }
 
 type blockingUploader struct {
@@ -137,3 +153,44 @@ func TestStop_CancelsInFlightUpload(t *testing.T) {
 		t.Fatal("Stop did not return; upload likely not canceled")
 	}
 }
+
+type failingUploader struct{ err error }
+
+func (u failingUploader) UploadFile(context.Context, string) error { return u.err }
+
+func TestRunOnce_ReportsEachRun(t *testing.T) {
+	t.Parallel()
+
+	type run struct {
+		snapshot string
+		err      error
+	}
+	var runs []run
+	m := &Manager{
+		store: &fakeSnapshotter{dbPath: "/tmp/tiny-telemetry.duckdb", data: []byte("snapshot")},
+		cfg: Config{
+			Enabled:  true,
+			LocalDir: t.TempDir(),
+			OnRun:    func(snapshot string, err error) { runs = append(runs, run{snapshot, err}) },
+		},
+	}
+
+	if err := m.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	uploadErr := errors.New("bucket gone")
+	m.uploader = failingUploader{err: uploadErr}
+	if err := m.RunOnce(context.Background()); !errors.Is(err, uploadErr) {
+		t.Fatalf("RunOnce with a failing upload = %v", err)
+	}
+
+	if len(runs) != 2 {
+		t.Fatalf("OnRun called %d times, want 2", len(runs))
+	}
+	if runs[0].err != nil || !strings.HasPrefix(runs[0].snapshot, "tiny-telemetry-") {
+		t.Fatalf("first run reported %+v", runs[0])
+	}
+	if !errors.Is(runs[1].err, uploadErr) {
+		t.Fatalf("second run reported %+v", runs[1])
+	}
+}
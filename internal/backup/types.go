@@ -19,6 +19,10 @@ type Config struct {
 	S3SecretKey    string
 	S3SessionToken string
 	S3UseSSL       bool
+
+	// OnRun, when set, is called after each backup run with the snapshot's
+	// file name and the run's error.
+	OnRun func(snapshot string, err error)
 }
 
 // Snapshotter is the minimal DB snapshot contract used by BackupManager.
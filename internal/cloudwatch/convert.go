@@ -0,0 +1,133 @@
+package cloudwatch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
+	"github.com/tinytelemetry/tiny-telemetry/internal/logparse"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// maxInflatedSize bounds a single decompressed subscription payload.
+const maxInflatedSize = 16 << 20
+
+// firehoseRequest is the Kinesis Data Firehose HTTP endpoint delivery body.
+type firehoseRequest struct {
+	RequestID string           `json:"requestId"`
+	Timestamp int64            `json:"timestamp"`
+	Records   []firehoseRecord `json:"records"`
+}
+
+type firehoseRecord struct {
+	Data string `json:"data"` // base64(gzip(subscriptionMessage))
+}
+
+// subscriptionMessage is the payload CloudWatch Logs writes for a subscription filter.
+type subscriptionMessage struct {
+	MessageType         string     `json:"messageType"`
+	Owner               string     `json:"owner"`
+	LogGroup            string     `json:"logGroup"`
+	LogStream           string     `json:"logStream"`
+	SubscriptionFilters []string   `json:"subscriptionFilters"`
+	LogEvents           []logEvent `json:"logEvents"`
+}
+
+type logEvent struct {
+	ID        string `json:"id"`
+	Timestamp int64  `json:"timestamp"` // Unix milliseconds
+	Message   string `json:"message"`
+}
+
+// decodeRecord unwraps one Firehose record into a subscription message.
+// Records may be gzip-compressed (the CloudWatch default) or plain JSON.
+func decodeRecord(data string) (*subscriptionMessage, error) {
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode base64: %w", err)
+	}
+	if len(raw) >= 2 && raw[0] == 0x1f && raw[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		defer zr.Close()
+		raw, err = io.ReadAll(io.LimitReader(zr, maxInflatedSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		if len(raw) > maxInflatedSize {
+			return nil, fmt.Errorf("payload inflates past %d bytes", maxInflatedSize)
+		}
+	}
+
+	var msg subscriptionMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, fmt.Errorf("decode subscription message: %w", err)
+	}
+	return &msg, nil
+}
+
+// convertMessage converts the log events of a DATA_MESSAGE into LogRecords.
+// CONTROL_MESSAGE deliveries (sent when a subscription is created) yield nothing.
+func convertMessage(msg *subscriptionMessage) []*model.LogRecord {
+	if msg == nil || msg.MessageType != "DATA_MESSAGE" {
+		return nil
+	}
+
+	app := appFromLogGroup(msg.LogGroup)
+	records := make([]*model.LogRecord, 0, len(msg.LogEvents))
+	for _, ev := range msg.LogEvents {
+		attributes := map[string]string{
+			"logGroup":  msg.LogGroup,
+			"logStream": msg.LogStream,
+		}
+		if msg.Owner != "" {
+			attributes["owner"] = msg.Owner
+		}
+		if ev.ID != "" {
+			attributes["eventId"] = ev.ID
+		}
+
+		message := ingest.SanitizeMessage(strings.TrimRight(ev.Message, "\n"))
+		level := logparse.NormalizeSeverity(logparse.ExtractSeverityFromText(message))
+
+		var origTimestamp time.Time
+		if ev.Timestamp > 0 {
+			origTimestamp = time.UnixMilli(ev.Timestamp)
+		}
+
+		records = append(records, &model.LogRecord{
+			Timestamp:     time.Now(),
+			OrigTimestamp: origTimestamp,
+			Level:         level,
+			LevelNum:      ingest.DefaultSeverityNumber(level),
+			Message:       message,
+			RawLine:       ev.Message,
+			Attributes:    attributes,
+			Source:        "cloudwatch",
+			App:           app,
+			Service:       msg.LogGroup,
+		})
+	}
+	return records
+}
+
+// appFromLogGroup uses the last path segment of a log group as the app name,
+// e.g. "/aws/lambda/checkout" -> "checkout".
+func appFromLogGroup(logGroup string) string {
+	trimmed := strings.Trim(logGroup, "/")
+	if i := strings.LastIndex(trimmed, "/"); i >= 0 {
+		trimmed = trimmed[i+1:]
+	}
+	if trimmed == "" {
+		return "default"
+	}
+	return trimmed
+}
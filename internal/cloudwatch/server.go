@@ -0,0 +1,180 @@
+// Package cloudwatch receives CloudWatch Logs subscription deliveries sent
+// through a Kinesis Data Firehose HTTP endpoint destination.
+package cloudwatch
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// maxBodySize bounds a single Firehose delivery request.
+const maxBodySize = 64 << 20
+
+// Config holds optional receiver settings.
+type Config struct {
+	// AccessKey, when set, must match the X-Amz-Firehose-Access-Key header.
+	AccessKey string
+	// Authorize, when set, checks the X-Amz-Firehose-Access-Key header as
+	// an API key; a header matching AccessKey passes without it.
+	Authorize func(key string) error
+}
+
+// Server is a Firehose HTTP endpoint that unwraps CloudWatch Logs events.
+type Server struct {
+	addr      string
+	sink      model.RecordSink
+	accessKey string
+	authorize func(key string) error
+	server    *http.Server
+	listener  net.Listener
+	stopOnce  sync.Once
+}
+
+// NewServer creates a new CloudWatch Logs subscription receiver.
+func NewServer(addr string, sink model.RecordSink, conf ...Config) *Server {
+	s := &Server{
+		addr: addr,
+		sink: sink,
+	}
+	if len(conf) > 0 {
+		s.accessKey = conf[0].AccessKey
+		s.authorize = conf[0].Authorize
+	}
+	return s
+}
+
+// Start begins listening and serving HTTP in a background goroutine.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.listener = ln
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleDelivery)
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       60 * time.Second,
+	}
+
+	go func() {
+		if err := s.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			log.Printf("cloudwatch: Serve exited: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop gracefully shuts down the HTTP server.
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() {
+		if s.server == nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		s.server.Shutdown(ctx)
+	})
+}
+
+// Addr returns the actual listen address (useful when port 0 is used).
+func (s *Server) Addr() string {
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+	return s.addr
+}
+
+// accessAllowed reports whether a delivery's access key matches AccessKey
+// or is an API key Authorize accepts; without either configured, any key.
+func (s *Server) accessAllowed(key string) bool {
+	if s.accessKey == "" && s.authorize == nil {
+		return true
+	}
+	if s.accessKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(s.accessKey)) == 1 {
+		return true
+	}
+	return s.authorize != nil && key != "" && s.authorize(key) == nil
+}
+
+// handleDelivery accepts one Firehose delivery. Firehose retries any request
+// that does not get a 200, so records are only acknowledged after every
+// event has been handed to the sink.
+func (s *Server) handleDelivery(w http.ResponseWriter, r *http.Request) {
+	requestID := r.Header.Get("X-Amz-Firehose-Request-Id")
+
+	if r.Method != http.MethodPost {
+		writeResponse(w, http.StatusMethodNotAllowed, requestID, "method not allowed")
+		return
+	}
+	if !s.accessAllowed(r.Header.Get("X-Amz-Firehose-Access-Key")) {
+		writeResponse(w, http.StatusUnauthorized, requestID, "invalid access key")
+		return
+	}
+
+	var body io.Reader = http.MaxBytesReader(w, r.Body, maxBodySize)
+	if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+		zr, err := gzip.NewReader(body)
+		if err != nil {
+			writeResponse(w, http.StatusBadRequest, requestID, "invalid gzip body")
+			return
+		}
+		defer zr.Close()
+		body = io.LimitReader(zr, maxBodySize)
+	}
+
+	var req firehoseRequest
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		writeResponse(w, http.StatusBadRequest, requestID, "invalid Firehose request body")
+		return
+	}
+	if requestID == "" {
+		requestID = req.RequestID
+	}
+
+	// Decode everything before writing so a bad record rejects the whole
+	// delivery instead of partially storing it and then being retried.
+	var records []*model.LogRecord
+	for _, rec := range req.Records {
+		msg, err := decodeRecord(rec.Data)
+		if err != nil {
+			writeResponse(w, http.StatusBadRequest, requestID, err.Error())
+			return
+		}
+		records = append(records, convertMessage(msg)...)
+	}
+	for _, record := range records {
+		s.sink.Add(record)
+	}
+
+	writeResponse(w, http.StatusOK, requestID, "")
+}
+
+// writeResponse writes the JSON response body Firehose expects.
+func writeResponse(w http.ResponseWriter, status int, requestID, errMsg string) {
+	resp := struct {
+		RequestID    string `json:"requestId"`
+		Timestamp    int64  `json:"timestamp"`
+		ErrorMessage string `json:"errorMessage,omitempty"`
+	}{
+		RequestID:    requestID,
+		Timestamp:    time.Now().UnixMilli(),
+		ErrorMessage: errMsg,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,157 @@
+package cloudwatch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+type mockSink struct {
+	mu      sync.Mutex
+	records []*model.LogRecord
+}
+
+func (m *mockSink) Add(r *model.LogRecord) {
+	m.mu.Lock()
+	m.records = append(m.records, r)
+	m.mu.Unlock()
+}
+
+func encodeRecord(t *testing.T, msg subscriptionMessage) string {
+	t.Helper()
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(raw)
+	zw.Close()
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func deliver(t *testing.T, srv *Server, records []firehoseRecord, accessKey string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(firehoseRequest{RequestID: "req-1", Timestamp: 1700000000000, Records: records})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("X-Amz-Firehose-Request-Id", "req-1")
+	if accessKey != "" {
+		req.Header.Set("X-Amz-Firehose-Access-Key", accessKey)
+	}
+	w := httptest.NewRecorder()
+	srv.handleDelivery(w, req)
+	return w
+}
+
+func TestHandleDelivery_UnwrapsLogEvents(t *testing.T) {
+	t.Parallel()
+
+	sink := &mockSink{}
+	srv := NewServer("", sink)
+
+	data := encodeRecord(t, subscriptionMessage{
+		MessageType: "DATA_MESSAGE",
+		Owner:       "123456789012",
+		LogGroup:    "/aws/lambda/checkout",
+		LogStream:   "2024/01/15/[$LATEST]abc",
+		LogEvents: []logEvent{
+			{ID: "1", Timestamp: 1705314600000, Message: "ERROR payment declined\n"},
+			{ID: "2", Timestamp: 1705314601000, Message: "request complete"},
+		},
+	})
+	control := encodeRecord(t, subscriptionMessage{MessageType: "CONTROL_MESSAGE"})
+
+	w := deliver(t, srv, []firehoseRecord{{Data: data}, {Data: control}}, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp["requestId"] != "req-1" {
+		t.Errorf("requestId = %v, want req-1", resp["requestId"])
+	}
+
+	if len(sink.records) != 2 {
+		t.Fatalf("records = %d, want 2", len(sink.records))
+	}
+	first := sink.records[0]
+	if first.Message != "ERROR payment declined" || first.Level != "ERROR" {
+		t.Errorf("unexpected first record: %+v", first)
+	}
+	if first.Attributes["logGroup"] != "/aws/lambda/checkout" || first.Attributes["logStream"] != "2024/01/15/[$LATEST]abc" {
+		t.Errorf("missing logGroup/logStream attributes: %v", first.Attributes)
+	}
+	if first.App != "checkout" || first.Source != "cloudwatch" {
+		t.Errorf("app/source = %q/%q, want checkout/cloudwatch", first.App, first.Source)
+	}
+	if first.OrigTimestamp.UnixMilli() != 1705314600000 {
+		t.Errorf("OrigTimestamp = %v", first.OrigTimestamp)
+	}
+}
+
+func TestHandleDelivery_RejectsBadRecordWithoutStoring(t *testing.T) {
+	t.Parallel()
+
+	sink := &mockSink{}
+	srv := NewServer("", sink)
+
+	good := encodeRecord(t, subscriptionMessage{
+		MessageType: "DATA_MESSAGE",
+		LogGroup:    "app",
+		LogEvents:   []logEvent{{Message: "ok"}},
+	})
+	w := deliver(t, srv, []firehoseRecord{{Data: good}, {Data: "not base64!"}}, "")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	if len(sink.records) != 0 {
+		t.Fatalf("records = %d, want 0", len(sink.records))
+	}
+}
+
+func TestHandleDelivery_AccessKey(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer("", &mockSink{}, Config{AccessKey: "secret"})
+
+	if w := deliver(t, srv, nil, "wrong"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("wrong key status = %d, want 401", w.Code)
+	}
+	if w := deliver(t, srv, nil, "secret"); w.Code != http.StatusOK {
+		t.Fatalf("valid key status = %d, want 200", w.Code)
+	}
+}
+
+func TestHandleDelivery_APIKey(t *testing.T) {
+	t.Parallel()
+
+	authorize := func(key string) error {
+		if key != "ingest-key-0123456789" {
+			return errors.New("invalid API key")
+		}
+		return nil
+	}
+	srv := NewServer("", &mockSink{}, Config{Authorize: authorize})
+
+	for _, key := range []string{"", "read-key-0123456789"} {
+		if w := deliver(t, srv, nil, key); w.Code != http.StatusUnauthorized {
+			t.Fatalf("key %q status = %d, want 401", key, w.Code)
+		}
+	}
+	if w := deliver(t, srv, nil, "ingest-key-0123456789"); w.Code != http.StatusOK {
+		t.Fatalf("ingest key status = %d, want 200", w.Code)
+	}
+}
@@ -0,0 +1,141 @@
+package drain3
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// maxMinerGroups caps the severity/service pairs a Miner keeps a parse tree
+// for; services seen after that are mined under an empty service name.
+const maxMinerGroups = 512
+
+// Miner mines message templates from logs as they arrive, separately for
+// each severity and service, and remembers when each template was first
+// and last seen. All methods are safe for concurrent use.
+type Miner struct {
+	config *Config
+
+	mu     sync.Mutex
+	groups map[minerKey]*minerGroup
+}
+
+type minerKey struct {
+	level   string
+	service string
+}
+
+// minerGroup is the parse tree of one severity/service pair.
+type minerGroup struct {
+	drain *Drain
+	seen  map[int64]*seenRange // cluster ID -> first/last message time
+}
+
+type seenRange struct {
+	first, last time.Time
+}
+
+// NewMiner returns a Miner whose parse trees use config; nil uses
+// DefaultConfig.
+func NewMiner(config *Config) *Miner {
+	if config == nil {
+		config = DefaultConfig
+	}
+	return &Miner{config: config, groups: make(map[minerKey]*minerGroup)}
+}
+
+// Add mines the message of record.
+func (m *Miner) Add(record *model.LogRecord) {
+	if record == nil || strings.TrimSpace(record.Message) == "" {
+		return
+	}
+	at := record.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	key := minerKey{level: record.Level, service: record.Service}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, ok := m.groups[key]
+	if !ok && len(m.groups) >= maxMinerGroups {
+		key.service = ""
+		g, ok = m.groups[key]
+	}
+	if !ok {
+		d := New(m.config)
+		if d == nil {
+			return
+		}
+		g = &minerGroup{drain: d, seen: make(map[int64]*seenRange)}
+		m.groups[key] = g
+	}
+
+	cluster, _, err := g.drain.Drain.AddLogMessage(record.Message)
+	if err != nil {
+		return
+	}
+	r, ok := g.seen[cluster.ClusterId]
+	if !ok {
+		g.seen[cluster.ClusterId] = &seenRange{first: at, last: at}
+		if len(g.seen) > 2*m.config.MaxClusters {
+			g.forgetEvicted()
+		}
+		return
+	}
+	if at.Before(r.first) {
+		r.first = at
+	}
+	if at.After(r.last) {
+		r.last = at
+	}
+}
+
+// forgetEvicted drops the seen times of clusters the parse tree evicted.
+func (g *minerGroup) forgetEvicted() {
+	for id := range g.seen {
+		if !g.drain.IdToCluster.Contains(id) {
+			delete(g.seen, id)
+		}
+	}
+}
+
+// Patterns returns the templates of the given severity and service (empty
+// matches any), most frequent first, up to limit (0 = all).
+func (m *Miner) Patterns(level, service string, limit int) []model.LogPattern {
+	m.mu.Lock()
+	var patterns []model.LogPattern
+	for key, g := range m.groups {
+		if (level != "" && !strings.EqualFold(key.level, level)) || (service != "" && key.service != service) {
+			continue
+		}
+		for _, cluster := range g.drain.GetClusters() {
+			p := model.LogPattern{
+				Template: cluster.GetTemplate(),
+				Level:    key.level,
+				Service:  key.service,
+				Count:    cluster.Size,
+			}
+			if r, ok := g.seen[cluster.ClusterId]; ok {
+				p.FirstSeen, p.LastSeen = r.first, r.last
+			}
+			patterns = append(patterns, p)
+		}
+	}
+	m.mu.Unlock()
+
+	sort.Slice(patterns, func(i, j int) bool {
+		if patterns[i].Count != patterns[j].Count {
+			return patterns[i].Count > patterns[j].Count
+		}
+		return patterns[i].Template < patterns[j].Template
+	})
+	if limit > 0 && len(patterns) > limit {
+		patterns = patterns[:limit]
+	}
+	return patterns
+}
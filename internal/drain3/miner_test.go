@@ -0,0 +1,47 @@
+package drain3
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestMiner_PatternsPerSeverityAndService(t *testing.T) {
+	m := NewMiner(&Config{Depth: 4, SimilarityTh: 0.5, MaxChildren: 50, MaxClusters: 100})
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		m.Add(&model.LogRecord{
+			Timestamp: start.Add(time.Duration(i) * time.Minute),
+			Level:     "ERROR",
+			Service:   "api",
+			Message:   fmt.Sprintf("connection to db-%d refused", i),
+		})
+	}
+	m.Add(&model.LogRecord{Timestamp: start, Level: "INFO", Service: "api", Message: "request served"})
+	m.Add(&model.LogRecord{Timestamp: start, Level: "ERROR", Service: "worker", Message: "job failed"})
+	m.Add(&model.LogRecord{Timestamp: start, Level: "ERROR", Service: "worker", Message: "   "})
+
+	all := m.Patterns("", "", 0)
+	if len(all) != 3 {
+		t.Fatalf("patterns = %+v, want 3", all)
+	}
+	top := all[0]
+	if top.Template != "connection to <*> refused" || top.Count != 5 || top.Level != "ERROR" || top.Service != "api" {
+		t.Errorf("top pattern = %+v", top)
+	}
+	if !top.FirstSeen.Equal(start) || !top.LastSeen.Equal(start.Add(4*time.Minute)) {
+		t.Errorf("seen = %s..%s, want %s..%s", top.FirstSeen, top.LastSeen, start, start.Add(4*time.Minute))
+	}
+
+	if got := m.Patterns("error", "", 0); len(got) != 2 {
+		t.Errorf("ERROR patterns = %+v, want 2", got)
+	}
+	if got := m.Patterns("", "worker", 0); len(got) != 1 || got[0].Template != "job failed" {
+		t.Errorf("worker patterns = %+v", got)
+	}
+	if got := m.Patterns("", "", 1); len(got) != 1 {
+		t.Errorf("limited patterns = %d, want 1", len(got))
+	}
+}
@@ -0,0 +1,210 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// archivePattern matches the Parquet files ArchiveBefore writes, one per
+// archived day: logs-<yyyymmdd>-<unix nanos>.parquet.
+const archivePattern = "logs-*.parquet"
+
+// EnableArchive lets ArchiveBefore move whole day partitions into Parquet
+// files under dir, which the logs view reads alongside the partitions, so
+// archived days stay visible to every query. The directory is recorded in
+// the database; a store opened on it later keeps reading the archive whether
+// or not this is called. Requires day partitions.
+func (s *Store) EnableArchive(dir string) error {
+	ctx := context.Background()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.partitions == nil {
+		return errors.New("archive: logs are not partitioned by day")
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("archive: %w", err)
+	}
+	if err := os.MkdirAll(abs, 0o755); err != nil {
+		return fmt.Errorf("archive: %w", err)
+	}
+
+	prev := s.archiveDir
+	s.archiveDir = abs
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.archiveDir = prev
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM log_archive`); err != nil {
+		s.archiveDir = prev
+		return fmt.Errorf("archive: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO log_archive VALUES (?)`, abs); err != nil {
+		s.archiveDir = prev
+		return fmt.Errorf("archive: %w", err)
+	}
+	if err := s.replaceLogsView(ctx, tx, s.partitions); err != nil {
+		s.archiveDir = prev
+		return fmt.Errorf("archive: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		s.archiveDir = prev
+		return err
+	}
+	return nil
+}
+
+// loadArchive reads the archive directory recorded by EnableArchive.
+func (s *Store) loadArchive(ctx context.Context) error {
+	var dir string
+	err := s.db.QueryRowContext(ctx, `SELECT dir FROM log_archive LIMIT 1`).Scan(&dir)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.archiveDir = dir
+	return nil
+}
+
+// ArchiveBefore moves the day partitions wholly before cutoff's UTC day into
+// Parquet files in the archive directory and returns how many rows moved.
+// Rollups and the search index are left alone: the rows are still in logs.
+// It does nothing unless EnableArchive was called.
+func (s *Store) ArchiveBefore(cutoff time.Time) (int64, error) {
+	ctx := context.Background()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.archiveDir == "" || s.partitions == nil {
+		return 0, nil
+	}
+	cutoffDay := partitionFor(cutoff)
+	var archived int64
+	for _, name := range sortedPartitions(s.partitions) {
+		if name >= cutoffDay {
+			break
+		}
+		n, err := s.archivePartition(ctx, name)
+		if err != nil {
+			return archived, fmt.Errorf("archive %s: %w", name, err)
+		}
+		archived += n
+	}
+	return archived, nil
+}
+
+// archivePartition writes one partition to Parquet, then drops it and
+// repoints the logs view in one transaction. The file is written under a
+// temporary name and renamed into place, so the view never reads a partial
+// file; it is removed again if the drop fails.
+func (s *Store) archivePartition(ctx context.Context, name string) (int64, error) {
+	var count int64
+	if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM `+partitionTable(name)).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	var path string
+	if count > 0 {
+		path = filepath.Join(s.archiveDir, fmt.Sprintf("logs-%s-%d.parquet", strings.TrimPrefix(name, "d"), time.Now().UnixNano()))
+		tmp := path + ".tmp"
+		if _, err := s.db.ExecContext(ctx, `COPY (SELECT * FROM `+partitionTable(name)+` ORDER BY timestamp, id)
+			TO `+quoteSQLString(tmp)+` (FORMAT parquet, COMPRESSION zstd)`); err != nil {
+			os.Remove(tmp)
+			return 0, err
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			os.Remove(tmp)
+			return 0, err
+		}
+	}
+
+	err := func() error {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+		if _, err := dropPartition(ctx, tx, name); err != nil {
+			return err
+		}
+		if err := s.afterDrop(ctx, tx, []string{name}); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}()
+	if err != nil {
+		if path != "" {
+			os.Remove(path)
+		}
+		return 0, err
+	}
+	delete(s.partitions, name)
+	return count, nil
+}
+
+// archiveFiles returns the archived files, oldest day first.
+func (s *Store) archiveFiles() []string {
+	if s.archiveDir == "" {
+		return nil
+	}
+	files, _ := filepath.Glob(filepath.Join(s.archiveDir, archivePattern))
+	sort.Strings(files)
+	return files
+}
+
+// archiveFileDay returns the partition name of the day an archived file
+// holds, or "" when the name does not parse.
+func archiveFileDay(path string) string {
+	day, _, ok := strings.Cut(strings.TrimPrefix(filepath.Base(path), "logs-"), "-")
+	if !ok || len(day) != len(partitionDayLayout) {
+		return ""
+	}
+	return "d" + day
+}
+
+// archiveSelect returns the SELECT the logs view reads the archive with, or
+// "" when there is nothing archived: read_parquet fails on a glob that
+// matches no files.
+func (s *Store) archiveSelect() string {
+	if len(s.archiveFiles()) == 0 {
+		return ""
+	}
+	glob := filepath.Join(s.archiveDir, archivePattern)
+	return `SELECT * FROM read_parquet(` + quoteSQLString(glob) + `, union_by_name = true)`
+}
+
+// deleteArchiveBefore removes the archived days wholly before cutoffDay and
+// returns how many rows they held. Files are removed before tx commits;
+// they are expired either way. The caller repoints the logs view.
+func (s *Store) deleteArchiveBefore(ctx context.Context, tx *sql.Tx, cutoffDay string) (int64, error) {
+	var deleted int64
+	for _, path := range s.archiveFiles() {
+		day := archiveFileDay(path)
+		if day == "" || day >= cutoffDay {
+			continue
+		}
+		var count int64
+		if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM read_parquet(`+quoteSQLString(path)+`)`).Scan(&count); err != nil {
+			return 0, fmt.Errorf("count %s: %w", filepath.Base(path), err)
+		}
+		if err := os.Remove(path); err != nil {
+			return 0, err
+		}
+		deleted += count
+	}
+	return deleted, nil
+}
@@ -0,0 +1,123 @@
+package duckdb
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArchiveBefore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.duckdb")
+	archiveDir := filepath.Join(t.TempDir(), "archive")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := store.EnableDayPartitions(); err != nil {
+		t.Fatalf("EnableDayPartitions: %v", err)
+	}
+	if err := store.EnableArchive(archiveDir); err != nil {
+		t.Fatalf("EnableArchive: %v", err)
+	}
+
+	day := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: day.AddDate(0, 0, -2), Level: "INFO", Message: "two days ago", Attributes: map[string]string{"user": "ann"}},
+		{Timestamp: day.AddDate(0, 0, -1), Level: "WARN", Message: "yesterday"},
+		{Timestamp: day, Level: "ERROR", Message: "today"},
+	})
+
+	archived, err := store.ArchiveBefore(day)
+	if err != nil || archived != 2 {
+		t.Fatalf("ArchiveBefore = %d, %v; want 2", archived, err)
+	}
+	if got, want := partitionNames(t, store), []string{"d20260310"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("partitions = %v, want %v", got, want)
+	}
+	if files := store.archiveFiles(); len(files) != 2 {
+		t.Fatalf("archive files = %v, want 2", files)
+	}
+
+	// Archived days stay visible, including to columns promoted afterwards.
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 3 {
+		t.Fatalf("TotalLogCount = %d, %v; want 3", count, err)
+	}
+	logs, err := store.RecentLogsFiltered(10, QueryOpts{}, nil, nil, "yesterday")
+	if err != nil || len(logs) != 1 || logs[0].Level != "WARN" {
+		t.Fatalf("search archived day = %+v, %v", logs, err)
+	}
+	if err := store.PromoteAttributes([]PromotedAttribute{{Key: "user", Type: "string"}}); err != nil {
+		t.Fatalf("PromoteAttributes: %v", err)
+	}
+	var users int
+	if err := store.db.QueryRow(`SELECT count(attr_user) FROM logs`).Scan(&users); err != nil || users != 0 {
+		t.Fatalf("promoted column over archive = %d, %v; want 0", users, err)
+	}
+	insertTestRecords(t, store, []*LogRecord{{Timestamp: day.AddDate(0, 0, 1), Level: "INFO", Message: "tomorrow"}})
+	store.Close()
+
+	// A reopened store reads the archive without EnableArchive.
+	store, err = NewStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer store.Close()
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 4 {
+		t.Fatalf("TotalLogCount after reopen = %d, %v; want 4", count, err)
+	}
+
+	// Retention removes archived days whole, then the archive itself.
+	deleted, err := store.DeleteBefore(day.AddDate(0, 0, -1))
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeleteBefore = %d, %v; want 1", deleted, err)
+	}
+	if files := store.archiveFiles(); len(files) != 1 {
+		t.Fatalf("archive files after retention = %v, want 1", files)
+	}
+	deleted, err = store.DeleteBefore(day.Add(-time.Hour))
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeleteBefore = %d, %v; want 1", deleted, err)
+	}
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 2 {
+		t.Fatalf("TotalLogCount after retention = %d, %v; want 2", count, err)
+	}
+	if got := mustSeverityCounts(t, store); !reflect.DeepEqual(got, map[string]int64{"ERROR": 1, "INFO": 1}) {
+		t.Errorf("SeverityCounts after retention = %v", got)
+	}
+}
+
+func TestEnableArchive_RequiresPartitions(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.EnableArchive(t.TempDir()); err == nil {
+		t.Fatal("EnableArchive on an unpartitioned store succeeded")
+	}
+}
+
+func TestRetentionCleaner_Archive(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.EnableDayPartitions(); err != nil {
+		t.Fatalf("EnableDayPartitions: %v", err)
+	}
+	if err := store.EnableArchive(t.TempDir()); err != nil {
+		t.Fatalf("EnableArchive: %v", err)
+	}
+	now := time.Now()
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: now.AddDate(0, 0, -3), Level: "INFO", Message: "old"},
+		{Timestamp: now, Level: "INFO", Message: "new"},
+	})
+
+	cleaner := NewRetentionCleaner(store, RetentionConfig{ArchiveDays: 2})
+	if cleaner == nil {
+		t.Fatal("expected a cleaner with archiving enabled")
+	}
+	defer cleaner.Stop()
+
+	if stats := cleaner.RetentionStats(); stats.Archived != 1 || stats.Expired != 0 {
+		t.Errorf("stats = %+v, want 1 archived", stats)
+	}
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 2 {
+		t.Errorf("TotalLogCount = %d, %v; want 2", count, err)
+	}
+}
@@ -0,0 +1,51 @@
+package duckdb
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// Catalog returns the values of dimension (app, service, or host) with
+// their log counts and latest log timestamp, most logs first.
+func (s *Store) Catalog(dimension string, limit int, opts QueryOpts) ([]CatalogEntry, error) {
+	if !slices.Contains(model.CatalogDimensions, dimension) {
+		return nil, fmt.Errorf("invalid dimension %q (want one of %v)", dimension, model.CatalogDimensions)
+	}
+
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	where, wArgs := scopeFilter(opts)
+	query := fmt.Sprintf(`
+		SELECT COALESCE(NULLIF(%s, ''), 'unknown') AS value, COUNT(*) AS count, MAX(timestamp) AS last_seen
+		FROM logs %s
+		GROUP BY value
+		ORDER BY count DESC, value ASC
+		LIMIT ?`, rateColumns[dimension], where)
+
+	rows, err := s.db.QueryContext(ctx, query, append(wArgs, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []CatalogEntry
+	for rows.Next() {
+		var item CatalogEntry
+		if err := rows.Scan(&item.Value, &item.Count, &item.LastSeen); err != nil {
+			log.Printf("duckdb scan error (Catalog): %v", err)
+			continue
+		}
+		results = append(results, item)
+	}
+	return results, rows.Err()
+}
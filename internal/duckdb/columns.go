@@ -0,0 +1,101 @@
+package duckdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// logColumn is a logs column added after the migrations stopped being able
+// to alter logs: a partitioned store's logs is a view, so NewStore adds the
+// columns missing from each logs table instead.
+type logColumn struct {
+	name     string
+	dataType string
+	backfill string // SQL filling rows stored before the column existed; "" leaves NULL
+}
+
+// addedLogColumns are added in order; partitionColumns lists them too.
+var addedLogColumns = []logColumn{
+	{name: "trace_id", dataType: "VARCHAR", backfill: traceBackfill(model.TraceIDKeys)},
+	{name: "span_id", dataType: "VARCHAR", backfill: traceBackfill(model.SpanIDKeys)},
+	{name: "body_json", dataType: "JSON"},
+	{name: "raw_line_zstd", dataType: "BLOB"},
+}
+
+// ensureLogColumns adds the addedLogColumns missing from the logs tables
+// and backfills them. It is a no-op once every table has them.
+func (s *Store) ensureLogColumns(ctx context.Context) error {
+	missing := make(map[string][]logColumn)
+	var tables []string
+	for _, table := range s.logTables() {
+		schema, name := "main", table
+		if s.partitions != nil {
+			schema, name, _ = strings.Cut(table, ".")
+		}
+		for _, c := range addedLogColumns {
+			var n int
+			if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM duckdb_columns()
+				WHERE schema_name = ? AND table_name = ? AND column_name = ?`, schema, name, c.name).Scan(&n); err != nil {
+				return err
+			}
+			if n > 0 {
+				continue
+			}
+			if len(missing[table]) == 0 {
+				tables = append(tables, table)
+			}
+			missing[table] = append(missing[table], c)
+		}
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	// As in PromoteAttributes, columns are added and filled in separate
+	// transactions.
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, table := range tables {
+		for _, c := range missing[table] {
+			if _, err := tx.ExecContext(ctx, `ALTER TABLE `+table+` ADD COLUMN `+c.name+` `+c.dataType); err != nil {
+				return fmt.Errorf("add %s to %s: %w", c.name, table, err)
+			}
+		}
+	}
+	if s.partitions != nil {
+		if err := s.replaceLogsView(ctx, tx, s.partitions); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	backfill, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer backfill.Rollback()
+	for _, table := range tables {
+		var sets []string
+		for _, c := range missing[table] {
+			if c.backfill != "" {
+				sets = append(sets, c.name+` = `+c.backfill)
+			}
+		}
+		if len(sets) == 0 {
+			continue
+		}
+		if _, err := backfill.ExecContext(ctx, `UPDATE `+table+` SET `+strings.Join(sets, ", ")+
+			` WHERE attributes IS NOT NULL`); err != nil {
+			return fmt.Errorf("backfill %s: %w", table, err)
+		}
+	}
+	return backfill.Commit()
+}
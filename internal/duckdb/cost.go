@@ -0,0 +1,80 @@
+package duckdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// explainNode is one operator in DuckDB's EXPLAIN (FORMAT JSON) plan.
+type explainNode struct {
+	Name      string                 `json:"name"`
+	Children  []explainNode          `json:"children"`
+	ExtraInfo map[string]interface{} `json:"extra_info"`
+}
+
+// EstimateQueryScanRows returns the number of rows DuckDB's planner expects
+// the query's scan operators to produce, without executing it. The query is
+// validated with the same read-only rules as ExecuteQuery, and args are
+// bound the same way.
+func (s *Store) EstimateQueryScanRows(query string, args ...any) (int64, error) {
+	trimmed, err := validateReadOnlyQuery(query)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel, err := s.queryCtx(priorityBulk)
+	if err != nil {
+		return 0, err
+	}
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, "EXPLAIN (FORMAT JSON) "+trimmed, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var total int64
+	for rows.Next() {
+		var key, plan string
+		if err := rows.Scan(&key, &plan); err != nil {
+			return 0, err
+		}
+		var nodes []explainNode
+		if err := json.Unmarshal([]byte(plan), &nodes); err != nil {
+			return 0, fmt.Errorf("parse query plan: %w", err)
+		}
+		for _, node := range nodes {
+			total += scanCardinality(node)
+		}
+	}
+	return total, rows.Err()
+}
+
+// scanCardinality sums the estimated cardinality of every scan operator in the plan tree.
+func scanCardinality(node explainNode) int64 {
+	var total int64
+	if strings.Contains(strings.ToUpper(node.Name), "SCAN") {
+		total += estimatedCardinality(node.ExtraInfo)
+	}
+	for _, child := range node.Children {
+		total += scanCardinality(child)
+	}
+	return total
+}
+
+func estimatedCardinality(info map[string]interface{}) int64 {
+	switch v := info["Estimated Cardinality"].(type) {
+	case string:
+		n, _ := strconv.ParseInt(strings.TrimPrefix(v, "~"), 10, 64)
+		return n
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+)
+
+// logTotals is the number of logs and the length of the lines they store
+// (see storedLength), counted in characters like the rollups.
+type logTotals struct {
+	count int64
+	bytes int64
+}
+
+// Per-app totals of every stored log are kept in memory, so TotalLogCount
+// and TotalLogBytes without a time range answer without a query every TUI
+// tick. The insert path adds each committed batch; deletes reread them from
+// the rollups they just trimmed. RecountTotals recounts from logs.
+
+// addTotals adds records, just committed, to the running totals. The
+// caller holds s.mu.
+func (s *Store) addTotals(records []*LogRecord) {
+	if s.totals == nil {
+		return
+	}
+	for _, r := range records {
+		app := r.App
+		if app == "" {
+			app = "default"
+		}
+		t := s.totals[app]
+		t.count++
+		t.bytes += storedLength(s.rawLine, r)
+		s.totals[app] = t
+	}
+}
+
+// loadTotals reads the per-app totals from the rollups. The caller holds
+// s.mu or has the store to itself.
+func (s *Store) loadTotals(ctx context.Context, q queryer) error {
+	totals, err := scanTotals(ctx, q, `SELECT app, SUM(count)::BIGINT, SUM(bytes)::BIGINT FROM log_minute_rollups GROUP BY app`)
+	if err != nil {
+		return err
+	}
+	s.totals = totals
+	return nil
+}
+
+// RecountTotals recounts the per-app totals from logs itself rather than
+// the rollups, for logs written around InsertLogBatch.
+func (s *Store) RecountTotals() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	totals, err := scanTotals(context.Background(), s.db,
+		`SELECT coalesce(app, 'default'), count(*), coalesce(sum(length(coalesce(raw_line, message))), 0)::BIGINT FROM logs GROUP BY ALL`)
+	if err != nil {
+		return err
+	}
+	s.totals = totals
+	return nil
+}
+
+// cachedTotals returns the running totals for opts when they answer it: all
+// time, for one app or all. The caller holds s.mu.
+func (s *Store) cachedTotals(opts QueryOpts) (logTotals, bool) {
+	if s.totals == nil || !opts.From.IsZero() || !opts.To.IsZero() {
+		return logTotals{}, false
+	}
+	if opts.App != "" {
+		return s.totals[opts.App], true
+	}
+	var sum logTotals
+	for _, t := range s.totals {
+		sum.count += t.count
+		sum.bytes += t.bytes
+	}
+	return sum, true
+}
+
+// queryer is the query method shared by *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// scanTotals runs query, which returns (app, count, bytes) rows.
+func scanTotals(ctx context.Context, q queryer, query string) (map[string]logTotals, error) {
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make(map[string]logTotals)
+	for rows.Next() {
+		var app string
+		var t logTotals
+		if err := rows.Scan(&app, &t.count, &t.bytes); err != nil {
+			return nil, err
+		}
+		totals[app] = t
+	}
+	return totals, rows.Err()
+}
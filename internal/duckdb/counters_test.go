@@ -0,0 +1,99 @@
+package duckdb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunningTotals(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: now.Add(-2 * time.Hour), Level: "INFO", Message: "old", RawLine: "old", App: "web"},
+		{Timestamp: now, Level: "INFO", Message: "a", RawLine: "abcd", App: "web"},
+		{Timestamp: now, Level: "INFO", Message: "b", RawLine: "héllo", App: "worker"},
+	})
+
+	totals := func() (count, bytes, web int64) {
+		t.Helper()
+		var err error
+		if count, err = store.TotalLogCount(QueryOpts{}); err != nil {
+			t.Fatalf("TotalLogCount: %v", err)
+		}
+		if bytes, err = store.TotalLogBytes(QueryOpts{}); err != nil {
+			t.Fatalf("TotalLogBytes: %v", err)
+		}
+		if web, err = store.TotalLogCount(QueryOpts{App: "web"}); err != nil {
+			t.Fatalf("TotalLogCount(web): %v", err)
+		}
+		return count, bytes, web
+	}
+	if count, bytes, web := totals(); count != 3 || bytes != 12 || web != 2 {
+		t.Fatalf("after insert: count=%d bytes=%d web=%d, want 3, 12, 2", count, bytes, web)
+	}
+
+	// Retention corrects the totals.
+	if _, err := store.DeleteBefore(now.Add(-time.Hour)); err != nil {
+		t.Fatalf("DeleteBefore: %v", err)
+	}
+	if count, bytes, web := totals(); count != 2 || bytes != 9 || web != 1 {
+		t.Fatalf("after delete: count=%d bytes=%d web=%d, want 2, 9, 1", count, bytes, web)
+	}
+
+	// Rows written around InsertLogBatch show up after a recount.
+	if _, err := store.db.Exec(`INSERT INTO logs (timestamp, level, message, raw_line, app) VALUES (?, 'INFO', 'side', 'xy', 'web')`, now); err != nil {
+		t.Fatalf("insert around the store: %v", err)
+	}
+	if count, _, _ := totals(); count != 2 {
+		t.Fatalf("count before recount = %d, want the running total 2", count)
+	}
+	if err := store.RecountTotals(); err != nil {
+		t.Fatalf("RecountTotals: %v", err)
+	}
+	if count, bytes, web := totals(); count != 3 || bytes != 11 || web != 2 {
+		t.Fatalf("after recount: count=%d bytes=%d web=%d, want 3, 11, 2", count, bytes, web)
+	}
+}
+
+func TestRunningTotalsMatchRecountUnderRawLinePolicy(t *testing.T) {
+	now := time.Now()
+	for _, policy := range []RawLinePolicy{RawLineAlways, RawLineDiffers, RawLineNever, RawLineCompress} {
+		t.Run(string(policy), func(t *testing.T) {
+			store := newTestStore(t)
+			if err := store.SetRawLinePolicy(policy); err != nil {
+				t.Fatalf("SetRawLinePolicy: %v", err)
+			}
+			insertTestRecords(t, store, []*LogRecord{
+				{Timestamp: now, Level: "INFO", Message: "plain", RawLine: "plain", App: "web"},
+				{Timestamp: now, Level: "INFO", Message: "parsed", RawLine: `{"msg":"parsed","user":"jürgen"}`, App: "web"},
+				{Timestamp: now, Level: "WARN", Message: "héllo", RawLine: "level=warn msg=héllo", App: "worker"},
+			})
+
+			liveBytes, err := store.TotalLogBytes(QueryOpts{})
+			if err != nil {
+				t.Fatalf("TotalLogBytes: %v", err)
+			}
+			var rollupBytes int64
+			if err := store.db.QueryRow(`SELECT sum(bytes)::BIGINT FROM log_minute_rollups`).Scan(&rollupBytes); err != nil {
+				t.Fatalf("sum rollup bytes: %v", err)
+			}
+
+			if err := store.RecountTotals(); err != nil {
+				t.Fatalf("RecountTotals: %v", err)
+			}
+			if recounted, err := store.TotalLogBytes(QueryOpts{}); err != nil || recounted != liveBytes {
+				t.Fatalf("TotalLogBytes after RecountTotals = %d, %v; live total was %d", recounted, err, liveBytes)
+			}
+			if err := store.RebuildRollups(); err != nil {
+				t.Fatalf("RebuildRollups: %v", err)
+			}
+			var rebuilt int64
+			if err := store.db.QueryRow(`SELECT sum(bytes)::BIGINT FROM log_minute_rollups`).Scan(&rebuilt); err != nil || rebuilt != rollupBytes {
+				t.Fatalf("rollup bytes after RebuildRollups = %d, %v; live rollups had %d", rebuilt, err, rollupBytes)
+			}
+			if rollupBytes != liveBytes {
+				t.Fatalf("rollup bytes = %d, running total = %d", rollupBytes, liveBytes)
+			}
+		})
+	}
+}
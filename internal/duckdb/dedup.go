@@ -0,0 +1,133 @@
+package duckdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Dedup keys select where InsertBuffer takes the event_id of a record that
+// has none, so that redelivered copies of a record share one.
+const (
+	// DedupKeyEventID uses the record's "event_id" attribute when present.
+	DedupKeyEventID = "event_id"
+	// DedupKeyContent hashes the record's timestamp, origin, and content.
+	DedupKeyContent = "content"
+)
+
+// EnableDedup makes inserts skip records whose event_id is already stored
+// with a timestamp within window of the batch, instead of failing the batch
+// on the unique index and retrying it record by record. Duplicates older
+// than the window still hit the index. Zero disables.
+func (s *Store) EnableDedup(window time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dedupWindow = max(window, 0)
+}
+
+// DuplicatesDropped returns how many records dedup has skipped since the
+// store was opened.
+func (s *Store) DuplicatesDropped() int64 {
+	return s.duplicates.Load()
+}
+
+// dropDuplicates returns records without those whose event_id is already
+// stored within the dedup window or repeats an earlier record of the batch,
+// and how many were dropped.
+func (s *Store) dropDuplicates(ctx context.Context, tx *sql.Tx, records []*LogRecord) ([]*LogRecord, int64, error) {
+	var ids []string
+	var first, last time.Time
+	for _, r := range records {
+		if r.EventID == "" {
+			continue
+		}
+		ids = append(ids, r.EventID)
+		if first.IsZero() || r.Timestamp.Before(first) {
+			first = r.Timestamp
+		}
+		if r.Timestamp.After(last) {
+			last = r.Timestamp
+		}
+	}
+	if len(ids) == 0 {
+		return records, 0, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT event_id FROM logs
+		WHERE timestamp >= ? AND timestamp <= ? AND event_id IN (SELECT unnest(?::VARCHAR[]))`,
+		first.Add(-s.dedupWindow), last.Add(s.dedupWindow), ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, 0, err
+		}
+		seen[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	kept := make([]*LogRecord, 0, len(records))
+	for _, r := range records {
+		if r.EventID != "" {
+			if seen[r.EventID] {
+				continue
+			}
+			seen[r.EventID] = true
+		}
+		kept = append(kept, r)
+	}
+	return kept, int64(len(records) - len(kept)), nil
+}
+
+// dedupEventID returns the event_id key derives for r, or "" when it
+// yields none.
+func dedupEventID(key string, r *LogRecord) string {
+	switch key {
+	case DedupKeyEventID:
+		return r.Attributes["event_id"]
+	case DedupKeyContent:
+		return contentEventID(r)
+	}
+	return ""
+}
+
+// contentEventID hashes the fields that identify a record's content. Two
+// deliveries of the same line get the same id only if the source or its
+// parser stamped the same timestamp on both.
+func contentEventID(r *LogRecord) string {
+	h := sha256.New()
+	write := func(s string) {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	write(strconv.FormatInt(r.Timestamp.UnixNano(), 10))
+	write(r.App)
+	write(r.Source)
+	write(r.Service)
+	write(r.Hostname)
+	write(strconv.Itoa(r.PID))
+	write(r.Level)
+	write(r.Message)
+	write(r.RawLine)
+	keys := make([]string, 0, len(r.Attributes))
+	for k := range r.Attributes {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		write(k)
+		write(r.Attributes[k])
+	}
+	return "c-" + hex.EncodeToString(h.Sum(nil)[:16])
+}
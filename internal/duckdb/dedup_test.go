@@ -0,0 +1,81 @@
+package duckdb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDedupSkipsStoredEventIDs(t *testing.T) {
+	store := newTestStore(t)
+	store.EnableDedup(10 * time.Minute)
+
+	now := time.Now()
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: now, Level: "INFO", Message: "a", EventID: "e1"},
+		{Timestamp: now.Add(-time.Hour), Level: "INFO", Message: "old", EventID: "e-old"},
+	})
+	// A redelivered record, a repeat within the batch, and a new record.
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: now, Level: "INFO", Message: "a", EventID: "e1"},
+		{Timestamp: now, Level: "INFO", Message: "b", EventID: "e2"},
+		{Timestamp: now, Level: "INFO", Message: "b", EventID: "e2"},
+	})
+
+	if got := store.DuplicatesDropped(); got != 2 {
+		t.Errorf("DuplicatesDropped = %d, want 2", got)
+	}
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 3 {
+		t.Errorf("TotalLogCount = %d, %v; want 3", count, err)
+	}
+
+	// A duplicate outside the window is still rejected by the unique index.
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: now, Level: "INFO", Message: "old again", EventID: "e-old"},
+	})
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 3 {
+		t.Errorf("TotalLogCount after stale duplicate = %d, %v; want 3", count, err)
+	}
+	if got := store.DuplicatesDropped(); got != 2 {
+		t.Errorf("DuplicatesDropped after stale duplicate = %d, want 2", got)
+	}
+}
+
+func TestInsertBuffer_DedupKey(t *testing.T) {
+	store := newTestStore(t)
+	store.EnableDedup(time.Hour)
+
+	ts := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	buf := NewInsertBuffer(store, InsertBufferConfig{DedupKey: DedupKeyContent})
+	for range 3 {
+		buf.Add(&LogRecord{Timestamp: ts, Level: "INFO", Message: "retried", Source: "tcp"})
+	}
+	buf.Add(&LogRecord{Timestamp: ts, Level: "INFO", Message: "different", Source: "tcp"})
+	buf.Stop()
+
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 2 {
+		t.Errorf("content dedup: TotalLogCount = %d, %v; want 2", count, err)
+	}
+
+	buf = NewInsertBuffer(store, InsertBufferConfig{DedupKey: DedupKeyEventID})
+	for _, msg := range []string{"first delivery", "second delivery"} {
+		buf.Add(&LogRecord{Timestamp: ts, Level: "INFO", Message: msg, Attributes: map[string]string{"event_id": "client-42"}})
+	}
+	buf.Stop()
+
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 3 {
+		t.Errorf("event_id dedup: TotalLogCount = %d, %v; want 3", count, err)
+	}
+}
+
+func TestContentEventID(t *testing.T) {
+	ts := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	a := &LogRecord{Timestamp: ts, Level: "INFO", Message: "x", Attributes: map[string]string{"k": "v", "j": "w"}}
+	b := &LogRecord{Timestamp: ts, Level: "INFO", Message: "x", Attributes: map[string]string{"j": "w", "k": "v"}}
+	if contentEventID(a) != contentEventID(b) {
+		t.Error("contentEventID differs for identical records")
+	}
+	b.Timestamp = ts.Add(time.Nanosecond)
+	if contentEventID(a) == contentEventID(b) {
+		t.Error("contentEventID ignores the timestamp")
+	}
+}
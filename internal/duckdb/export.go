@@ -0,0 +1,167 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// exportChunkRows is how many rows StreamLogs reads per query.
+const exportChunkRows = 5000
+
+// ExportParquet writes the logs matching opts to a Parquet file at path,
+// oldest first, and returns the number of rows written. It uses DuckDB's
+// COPY internally; the statement is built here, so no caller SQL reaches
+// COPY. The file is written beside path and renamed into place. The
+// export stops after maxRows rows and fails after timeout (0 = no limit
+// for either); the query timeout does not apply. Raw lines stored under
+// the compress policy stay compressed in raw_line_zstd, which ImportFile
+// decompresses.
+func (s *Store) ExportParquet(path string, opts QueryOpts, maxRows int64, timeout time.Duration) (int64, error) {
+	if path == "" {
+		return 0, fmt.Errorf("export: empty path")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, fmt.Errorf("create export dir: %w", err)
+	}
+	tmp := path + ".tmp"
+
+	where, args := scopeFilter(opts)
+	limit := ""
+	if maxRows > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", maxRows)
+	}
+	query := fmt.Sprintf(`COPY (SELECT * FROM logs %s ORDER BY timestamp%s) TO %s (FORMAT parquet, COMPRESSION zstd)`,
+		where, limit, quoteSQLString(tmp))
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	s.mu.RLock()
+	res, err := s.db.ExecContext(ctx, query, args...)
+	s.mu.RUnlock()
+	if err != nil {
+		_ = os.Remove(tmp)
+		return 0, fmt.Errorf("export parquet: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return 0, fmt.Errorf("export parquet: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	return rows, nil
+}
+
+// StreamLogs hands the logs matching opts to fn, oldest first, a chunk at a
+// time, until maxRows (0 = no limit) have been handed over, fn fails, or
+// ctx ends, and returns how many it handed over. Each chunk is its own
+// query resuming after the last row of the one before, and the read lock
+// is released while fn runs, so a slow consumer does not hold up writes.
+func (s *Store) StreamLogs(ctx context.Context, opts QueryOpts, maxRows int64, fn func([]LogRecord) error) (int64, error) {
+	var total int64
+	var after struct {
+		ts  time.Time
+		id  int64
+		set bool
+	}
+	for maxRows <= 0 || total < maxRows {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		chunk := int64(exportChunkRows)
+		if maxRows > 0 {
+			chunk = min(chunk, maxRows-total)
+		}
+
+		conditions, args := scopeConditions(opts)
+		if after.set {
+			conditions = append(conditions, "(timestamp > ? OR (timestamp = ? AND id > ?))")
+			args = append(args, after.ts, after.ts, after.id)
+		}
+		query := "SELECT id, timestamp, orig_timestamp, level, level_num, message, coalesce(raw_line, message) AS raw_line, raw_line_zstd, service, hostname, pid, CAST(attributes AS VARCHAR) AS attributes, source, app, CAST(body_json AS VARCHAR) AS body_json FROM logs"
+		if len(conditions) > 0 {
+			query += " WHERE " + strings.Join(conditions, " AND ")
+		}
+		query += " ORDER BY timestamp, id LIMIT ?"
+		args = append(args, chunk)
+
+		records, lastID, err := s.exportChunk(ctx, query, args)
+		if err != nil {
+			return total, err
+		}
+		if len(records) == 0 {
+			return total, nil
+		}
+		if err := fn(records); err != nil {
+			return total, err
+		}
+		total += int64(len(records))
+		if int64(len(records)) < chunk {
+			return total, nil
+		}
+		after.ts, after.id, after.set = records[len(records)-1].Timestamp, lastID, true
+	}
+	return total, nil
+}
+
+// exportChunk runs one StreamLogs query and returns its records and the id
+// of the last.
+func (s *Store) exportChunk(ctx context.Context, query string, args []interface{}) ([]LogRecord, int64, error) {
+	queryCtx, cancel, err := s.queryCtx(priorityBulk)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.QueryContext(queryCtx, query, args...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, 0, ctx.Err()
+		}
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []LogRecord
+	var id int64
+	for rows.Next() {
+		var r LogRecord
+		var origTS sql.NullTime
+		var attrsJSON string
+		var body sql.NullString
+		var rawZstd []byte
+		if err := rows.Scan(&id, &r.Timestamp, &origTS, &r.Level, &r.LevelNum, &r.Message, &r.RawLine, &rawZstd, &r.Service, &r.Hostname, &r.PID, &attrsJSON, &r.Source, &r.App, &body); err != nil {
+			log.Printf("duckdb scan error (StreamLogs): %v", err)
+			continue
+		}
+		if origTS.Valid {
+			r.OrigTimestamp = origTS.Time
+		}
+		r.BodyJSON = body.String
+		decodeRawLine(&r, rawZstd)
+		r.Attributes = make(map[string]string)
+		if attrsJSON != "" && attrsJSON != "{}" {
+			parseJSONMap(attrsJSON, r.Attributes)
+		}
+		results = append(results, r)
+	}
+	return results, id, rows.Err()
+}
+
+// quoteSQLString quotes s as a SQL string literal.
+func quoteSQLString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,103 @@
+package duckdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExportParquet(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	err = store.InsertLogBatch([]*LogRecord{
+		{Timestamp: base, Level: "INFO", Message: "first", App: "shop"},
+		{Timestamp: base.Add(time.Minute), Level: "ERROR", Message: "second", App: "shop"},
+		{Timestamp: base.Add(2 * time.Minute), Level: "INFO", Message: "other app", App: "billing"},
+	})
+	if err != nil {
+		t.Fatalf("InsertLogBatch: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "it's", "logs.parquet")
+	n, err := store.ExportParquet(path, QueryOpts{App: "shop", From: base.Add(30 * time.Second)}, 0, 0)
+	if err != nil {
+		t.Fatalf("ExportParquet: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("rows = %d, want 1", n)
+	}
+
+	var message, app string
+	err = store.DB().QueryRow("SELECT message, app FROM read_parquet(?)", path).Scan(&message, &app)
+	if err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	if message != "second" || app != "shop" {
+		t.Fatalf("exported row = %q/%q", message, app)
+	}
+
+	if _, err := store.ExportParquet("", QueryOpts{}, 0, 0); err == nil {
+		t.Fatal("empty path should fail")
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	// Two and a half chunks sharing a handful of timestamps, so chunks end
+	// in the middle of a timestamp.
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	total := 2*exportChunkRows + exportChunkRows/2
+	records := make([]*LogRecord, total)
+	for i := range records {
+		records[i] = &LogRecord{Timestamp: base.Add(time.Duration(i/3000) * time.Second), Level: "INFO", Message: "m", PID: i}
+	}
+	if err := store.InsertLogBatch(records); err != nil {
+		t.Fatalf("InsertLogBatch: %v", err)
+	}
+
+	var chunks int
+	next := 0
+	n, err := store.StreamLogs(context.Background(), QueryOpts{}, 0, func(chunk []LogRecord) error {
+		chunks++
+		for _, r := range chunk {
+			if r.PID != next {
+				return fmt.Errorf("got pid %d, want %d", r.PID, next)
+			}
+			next++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamLogs: %v", err)
+	}
+	if n != int64(total) || chunks != 3 {
+		t.Fatalf("streamed %d rows in %d chunks, want %d in 3", n, chunks, total)
+	}
+
+	n, err = store.StreamLogs(context.Background(), QueryOpts{}, 7, func([]LogRecord) error { return nil })
+	if err != nil || n != 7 {
+		t.Fatalf("StreamLogs with maxRows 7 = %d, %v; want 7", n, err)
+	}
+
+	stop := errors.New("stop")
+	if _, err := store.StreamLogs(context.Background(), QueryOpts{}, 0, func([]LogRecord) error { return stop }); err != stop {
+		t.Fatalf("StreamLogs error = %v, want the callback's", err)
+	}
+}
@@ -0,0 +1,138 @@
+package duckdb
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// appendAttributeCondition adds the predicate matching attribute key =
+// value, on its column when the key is promoted as a string.
+func (s *Store) appendAttributeCondition(conditions []string, args []interface{}, key, value string) ([]string, []interface{}) {
+	for _, p := range s.promoted {
+		if p.key == key && p.dataType == "VARCHAR" {
+			return append(conditions, p.column+" = ?"), append(args, value)
+		}
+	}
+	return append(conditions, "(attributes->>?) = ?"), append(args, key, value)
+}
+
+// appendFacetConditions adds the predicates matching every facet (see
+// model.MatchesFacets).
+func (s *Store) appendFacetConditions(conditions []string, args []interface{}, facets map[string]string) ([]string, []interface{}) {
+	for key, value := range facets {
+		switch key {
+		case model.FacetService:
+			conditions, args = append(conditions, "service = ?"), append(args, value)
+		case model.FacetHost:
+			conditions, args = append(conditions, "hostname = ?"), append(args, value)
+		default:
+			conditions, args = s.appendAttributeCondition(conditions, args, key, value)
+		}
+	}
+	return conditions, args
+}
+
+// FindLogs returns a page of the logs matching filter, newest first (ties
+// by insertion, latest first), and the cursor of the next page. Attribute
+// keys promoted as strings are compared on their column.
+func (s *Store) FindLogs(filter LogFilter) (LogPage, error) {
+	if filter.Limit <= 0 || filter.Limit > model.MaxLogPage {
+		return LogPage{}, fmt.Errorf("limit must be between 1 and %d", model.MaxLogPage)
+	}
+	var cursor model.LogCursor
+	if filter.Cursor != "" {
+		var err error
+		if cursor, err = model.ParseLogCursor(filter.Cursor); err != nil {
+			return LogPage{}, err
+		}
+	}
+
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return LogPage{}, err
+	}
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conditions, args := scopeConditions(filter.QueryOpts)
+	if filter.Cursor != "" {
+		conditions = append(conditions, "timestamp <= ?")
+		args = append(args, cursor.Timestamp)
+	}
+	if len(filter.Levels) > 0 {
+		placeholders := make([]string, len(filter.Levels))
+		for i, lvl := range filter.Levels {
+			placeholders[i] = "?"
+			args = append(args, lvl)
+		}
+		conditions = append(conditions, "level IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	for key, value := range filter.Attributes {
+		conditions, args = s.appendAttributeCondition(conditions, args, key, value)
+	}
+
+	source := "logs"
+	if filter.Pattern != "" {
+		if literalPattern(filter.Pattern) {
+			if source, err = s.searchSource(ctx, filter.Pattern); err != nil {
+				return LogPage{}, err
+			}
+			conditions = append(conditions, "contains(message, ?)")
+		} else {
+			conditions = append(conditions, "regexp_matches(message, ?)")
+		}
+		args = append(args, filter.Pattern)
+	}
+
+	query := "SELECT timestamp, orig_timestamp, level, level_num, message, coalesce(raw_line, message) AS raw_line, raw_line_zstd, service, hostname, pid, CAST(attributes AS VARCHAR) AS attributes, source, app, CAST(body_json AS VARCHAR) AS body_json FROM " + source
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	// The logs at the cursor's timestamp sort first; skip those already sent.
+	query += " ORDER BY timestamp DESC, id DESC LIMIT ? OFFSET ?"
+	args = append(args, filter.Limit, cursor.Skip)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return LogPage{}, err
+	}
+	defer rows.Close()
+
+	var results []LogRecord
+	for rows.Next() {
+		var r LogRecord
+		var origTS sql.NullTime
+		var attrsJSON string
+		var body sql.NullString
+		var rawZstd []byte
+		if err := rows.Scan(&r.Timestamp, &origTS, &r.Level, &r.LevelNum, &r.Message, &r.RawLine, &rawZstd, &r.Service, &r.Hostname, &r.PID, &attrsJSON, &r.Source, &r.App, &body); err != nil {
+			log.Printf("duckdb scan error (FindLogs): %v", err)
+			continue
+		}
+		if origTS.Valid {
+			r.OrigTimestamp = origTS.Time
+		}
+		r.BodyJSON = body.String
+		decodeRawLine(&r, rawZstd)
+		r.Attributes = make(map[string]string)
+		if attrsJSON != "" && attrsJSON != "{}" {
+			parseJSONMap(attrsJSON, r.Attributes)
+		}
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return LogPage{}, err
+	}
+
+	page := LogPage{Logs: results}
+	if len(results) == filter.Limit {
+		page.NextCursor = model.NextLogCursor(cursor, results).String()
+	}
+	return page, nil
+}
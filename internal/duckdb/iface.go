@@ -6,7 +6,16 @@ import "github.com/tinytelemetry/tiny-telemetry/internal/model"
 // consumers that import duckdb for these continue to compile.
 type QueryOpts = model.QueryOpts
 type LogQuerier = model.LogQuerier
+type LogFinder = model.LogFinder
+type Cataloger = model.Cataloger
 type SchemaQuerier = model.SchemaQuerier
 type LogWriter = model.LogWriter
 type LogReader = model.LogReader
 type ReadAPI = model.ReadAPI
+type LogPruner = model.LogPruner
+type LogDeleter = model.LogDeleter
+type CapacityPruner = model.CapacityPruner
+type LogArchiver = model.LogArchiver
+type LogRotator = model.LogRotator
+type Compactor = model.Compactor
+type StorageBackend = model.StorageBackend
@@ -0,0 +1,414 @@
+package duckdb
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	duckdb "github.com/duckdb/duckdb-go/v2"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// DefaultImportBatchSize is the number of records ImportFile inserts per batch.
+const DefaultImportBatchSize = 10_000
+
+// maxImportLineSize bounds one NDJSON line.
+const maxImportLineSize = 16 << 20
+
+// ImportConfig holds optional settings for ImportFile.
+type ImportConfig struct {
+	BatchSize int
+	// Progress is called after each batch with the rows imported so far.
+	Progress func(rows int64)
+	// ParseLine, when set, turns each NDJSON line into records instead of
+	// reading it as a row of logs columns (e.g. an ingest line parser for
+	// logger JSON). It is not used for Parquet.
+	ParseLine func(line string) []*LogRecord
+	// DefaultApp is set on records without an app.
+	DefaultApp string
+}
+
+// ImportFile loads a Parquet (.parquet) or NDJSON (.ndjson, .jsonl, .json,
+// optionally .gz) file into the logs table in batches and returns the number
+// of rows imported. Row fields named like logs columns (timestamp, level,
+// message, attributes, ...) fill the record; any other field becomes an
+// attribute, so files exported by ExportParquet round-trip. Records without
+// a source are tagged "import".
+func (s *Store) ImportFile(path string, conf ...ImportConfig) (int64, error) {
+	var cfg ImportConfig
+	if len(conf) > 0 {
+		cfg = conf[0]
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultImportBatchSize
+	}
+
+	imp := &importer{store: s, conf: cfg}
+	name := strings.ToLower(path)
+	gzipped := strings.HasSuffix(name, ".gz")
+	name = strings.TrimSuffix(name, ".gz")
+
+	var err error
+	switch filepath.Ext(name) {
+	case ".parquet":
+		if gzipped {
+			return 0, fmt.Errorf("import %s: gzip-compressed parquet is not supported", path)
+		}
+		err = imp.parquet(path)
+	case ".ndjson", ".jsonl", ".json":
+		err = imp.ndjson(path, gzipped)
+	default:
+		return 0, fmt.Errorf("import %s: unknown format (want .parquet, .ndjson, .jsonl, or .json, optionally .gz)", path)
+	}
+	if err == nil {
+		err = imp.flush()
+	}
+	if err != nil {
+		return imp.rows, fmt.Errorf("import %s: %w", path, err)
+	}
+	return imp.rows, nil
+}
+
+// importer batches records for ImportFile.
+type importer struct {
+	store *Store
+	conf  ImportConfig
+	batch []*LogRecord
+	rows  int64
+}
+
+func (imp *importer) add(r *LogRecord) error {
+	if r.Source == "" {
+		r.Source = "import"
+	}
+	if imp.conf.DefaultApp != "" && (r.App == "" || r.App == "default") {
+		r.App = imp.conf.DefaultApp
+	}
+	imp.batch = append(imp.batch, r)
+	if len(imp.batch) >= imp.conf.BatchSize {
+		return imp.flush()
+	}
+	return nil
+}
+
+func (imp *importer) flush() error {
+	if len(imp.batch) == 0 {
+		return nil
+	}
+	if err := imp.store.InsertLogBatch(imp.batch); err != nil {
+		return err
+	}
+	imp.rows += int64(len(imp.batch))
+	imp.batch = imp.batch[:0]
+	if imp.conf.Progress != nil {
+		imp.conf.Progress(imp.rows)
+	}
+	return nil
+}
+
+// parquet streams rows out of the file with read_parquet. The read does not
+// touch the logs table, so it runs outside the store lock while batches
+// are inserted.
+func (imp *importer) parquet(path string) error {
+	rows, err := imp.store.db.QueryContext(context.Background(), `SELECT * FROM read_parquet(?)`, path)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	values := make([]any, len(columns))
+	ptrs := make([]any, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		row := make(map[string]any, len(columns))
+		for i, col := range columns {
+			row[col] = values[i]
+		}
+		if err := imp.add(recordFromRow(row)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ndjson reads one JSON object per line.
+func (imp *importer) ndjson(path string, gzipped bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if gzipped {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLineSize)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if imp.conf.ParseLine != nil {
+			for _, record := range imp.conf.ParseLine(line) {
+				if err := imp.add(record); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		var row map[string]any
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			return fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		if err := imp.add(recordFromRow(row)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// recordFromRow maps a row keyed by logs column names to a record. A raw
+// line stored compressed (the "compress" raw line policy) is decompressed.
+// Columns the store derives on insert are not carried over as attributes:
+// id, the promoted attr_* columns of a row that has attributes (they are
+// copies of its attributes), and trace_id and span_id when the attributes
+// already hold the trace context they were taken from.
+func recordFromRow(row map[string]any) *LogRecord {
+	r := &LogRecord{Attributes: make(map[string]string)}
+	_, hasAttributes := row["attributes"]
+	derived := make(map[string]string)
+	var compressed []byte
+	for key, value := range row {
+		if value == nil {
+			continue
+		}
+		switch key {
+		case "id":
+		case "trace_id", "span_id":
+			derived[key] = importString(value)
+		case "timestamp":
+			r.Timestamp, _ = importTime(value)
+		case "orig_timestamp":
+			r.OrigTimestamp, _ = importTime(value)
+		case "level":
+			r.Level = importString(value)
+		case "level_num":
+			r.LevelNum = int(importInt(value))
+		case "message":
+			r.Message = importString(value)
+		case "raw_line":
+			r.RawLine = importString(value)
+		case "raw_line_zstd":
+			if b, ok := value.([]byte); ok {
+				compressed = b
+			}
+		case "service":
+			r.Service = importString(value)
+		case "hostname":
+			r.Hostname = importString(value)
+		case "pid":
+			r.PID = int(importInt(value))
+		case "source":
+			r.Source = importString(value)
+		case "app":
+			r.App = importString(value)
+		case "event_id":
+			r.EventID = importString(value)
+		case "body_json":
+			r.BodyJSON = importString(value)
+		case "attributes":
+			mergeImportAttributes(r.Attributes, value)
+		default:
+			if hasAttributes && strings.HasPrefix(key, "attr_") {
+				continue
+			}
+			r.Attributes[key] = importString(value)
+		}
+	}
+	traceID, spanID := model.TraceContext(r.Attributes)
+	if v := derived["trace_id"]; v != "" && traceID == "" {
+		r.Attributes["trace_id"] = v
+	}
+	if v := derived["span_id"]; v != "" && spanID == "" {
+		r.Attributes["span_id"] = v
+	}
+	if r.Timestamp.IsZero() {
+		r.Timestamp = time.Now()
+	}
+	if r.RawLine == "" {
+		decodeRawLine(r, compressed)
+	}
+	if r.RawLine == "" {
+		r.RawLine = r.Message
+	}
+	return r
+}
+
+// mergeImportAttributes adds an attributes value: a map (Parquet MAP or
+// JSON object) or a JSON object string (DuckDB JSON columns).
+func mergeImportAttributes(dst map[string]string, value any) {
+	switch v := value.(type) {
+	case string:
+		var m map[string]any
+		if json.Unmarshal([]byte(v), &m) == nil {
+			mergeImportAttributes(dst, m)
+		}
+	case []byte:
+		mergeImportAttributes(dst, string(v))
+	case map[string]any:
+		for k, val := range v {
+			if val != nil {
+				dst[k] = importString(val)
+			}
+		}
+	case duckdb.Map:
+		mergeImportAttributes(dst, map[any]any(v))
+	case map[any]any:
+		for k, val := range v {
+			if val != nil {
+				dst[importString(k)] = importString(val)
+			}
+		}
+	}
+}
+
+// importString formats a scalar as text; objects and arrays become JSON.
+func importString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]any, map[any]any, duckdb.Map, []any:
+		if data, err := json.Marshal(jsonSafe(v)); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+// jsonSafe converts map[any]any (from DuckDB) into JSON-encodable maps.
+func jsonSafe(value any) any {
+	switch v := value.(type) {
+	case duckdb.Map:
+		return jsonSafe(map[any]any(v))
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			m[fmt.Sprint(k)] = jsonSafe(val)
+		}
+		return m
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			m[k] = jsonSafe(val)
+		}
+		return m
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = jsonSafe(val)
+		}
+		return out
+	}
+	return value
+}
+
+// importInt reads an integer from a numeric or string value.
+func importInt(value any) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int32:
+		return int64(v)
+	case int:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case string:
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n
+	}
+	return 0
+}
+
+// importTimeLayouts are the textual timestamps ImportFile accepts: RFC 3339
+// and DuckDB's own TIMESTAMP text form.
+var importTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// importTime reads a timestamp from a time, text, or epoch value (seconds,
+// milliseconds, microseconds, or nanoseconds, by magnitude).
+func importTime(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range importTimeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return importTime(f)
+		}
+	case float64:
+		return epochTime(v), true
+	case int64, int32, int, uint64, uint32:
+		return epochTime(float64(importInt(v))), true
+	}
+	return time.Time{}, false
+}
+
+// epochTime converts an epoch value whose unit is inferred from magnitude.
+func epochTime(v float64) time.Time {
+	switch {
+	case v > 1e17:
+		return time.Unix(0, int64(v))
+	case v > 1e14:
+		return time.UnixMicro(int64(v))
+	case v > 1e11:
+		return time.UnixMilli(int64(v))
+	default:
+		sec := int64(v)
+		return time.Unix(sec, int64((v-float64(sec))*1e9))
+	}
+}
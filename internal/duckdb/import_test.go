@@ -0,0 +1,201 @@
+package duckdb
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestImportFile_ParquetRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	src, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = src.Close() })
+	if err := src.PromoteAttributes([]PromotedAttribute{{Key: "order", Type: "integer"}}); err != nil {
+		t.Fatalf("PromoteAttributes: %v", err)
+	}
+	ts := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	err = src.InsertLogBatch([]*LogRecord{
+		{Timestamp: ts, Level: "ERROR", LevelNum: 17, Message: "payment failed", App: "shop", Hostname: "web-1", Attributes: map[string]string{"order": "42", "trace.id": "abc"}},
+		{Timestamp: ts.Add(time.Second), Level: "INFO", Message: "retry ok", App: "shop"},
+		{Timestamp: ts.Add(2 * time.Second), Level: "INFO", Message: "third", App: "shop"},
+	})
+	if err != nil {
+		t.Fatalf("InsertLogBatch: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "logs.parquet")
+	if _, err := src.ExportParquet(path, QueryOpts{}, 0, 0); err != nil {
+		t.Fatalf("ExportParquet: %v", err)
+	}
+
+	dst, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = dst.Close() })
+	var progress []int64
+	n, err := dst.ImportFile(path, ImportConfig{BatchSize: 2, Progress: func(rows int64) { progress = append(progress, rows) }})
+	if err != nil {
+		t.Fatalf("ImportFile: %v", err)
+	}
+	if n != 3 || len(progress) != 2 || progress[1] != 3 {
+		t.Fatalf("rows = %d, progress = %v", n, progress)
+	}
+
+	logs, err := dst.RecentLogsFiltered(10, QueryOpts{App: "shop"}, []string{"ERROR"}, nil, "")
+	if err != nil || len(logs) != 1 {
+		t.Fatalf("RecentLogsFiltered = %+v, %v", logs, err)
+	}
+	got := logs[0]
+	if got.Message != "payment failed" || got.Hostname != "web-1" || got.Attributes["order"] != "42" || !got.Timestamp.Equal(ts) {
+		t.Fatalf("imported record = %+v", got)
+	}
+	// id, trace_id, and the promoted attr_order column are derived on
+	// insert and must not come back as attributes.
+	if len(got.Attributes) != 2 || got.Attributes["trace.id"] != "abc" {
+		t.Fatalf("imported attributes = %v, want only order and trace.id", got.Attributes)
+	}
+}
+
+func TestImportFile_ParquetCompressedRawLine(t *testing.T) {
+	t.Parallel()
+
+	src, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = src.Close() })
+	if err := src.SetRawLinePolicy(RawLineCompress); err != nil {
+		t.Fatal(err)
+	}
+	raw := `{"level":"error","msg":"payment failed"}`
+	ts := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := src.InsertLogBatch([]*LogRecord{{Timestamp: ts, Level: "ERROR", Message: "payment failed", RawLine: raw, App: "shop"}}); err != nil {
+		t.Fatalf("InsertLogBatch: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "logs.parquet")
+	if _, err := src.ExportParquet(path, QueryOpts{}, 0, 0); err != nil {
+		t.Fatalf("ExportParquet: %v", err)
+	}
+
+	dst, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = dst.Close() })
+	if n, err := dst.ImportFile(path); err != nil || n != 1 {
+		t.Fatalf("ImportFile = %d, %v", n, err)
+	}
+	logs, err := dst.RecentLogsFiltered(10, QueryOpts{App: "shop"}, nil, nil, "")
+	if err != nil || len(logs) != 1 {
+		t.Fatalf("RecentLogsFiltered = %+v, %v", logs, err)
+	}
+	if got := logs[0]; got.RawLine != raw || len(got.Attributes) != 0 {
+		t.Fatalf("imported raw line %q, attributes %v; want the original raw line and no attributes", got.RawLine, got.Attributes)
+	}
+}
+
+func TestImportFile_NDJSONGzip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.ndjson.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	gz.Write([]byte(`{"timestamp":"2025-01-01T12:00:00Z","level":"WARN","message":"disk 91%","app":"ops","region":"eu","attributes":{"disk":"/dev/sda"}}
+{"timestamp":1735732800000,"level":"INFO","message":"epoch ms"}
+
+`))
+	gz.Close()
+	f.Close()
+
+	store, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	n, err := store.ImportFile(path)
+	if err != nil || n != 2 {
+		t.Fatalf("ImportFile = %d, %v", n, err)
+	}
+
+	logs, err := store.RecentLogsFiltered(10, QueryOpts{}, nil, nil, "")
+	if err != nil || len(logs) != 2 {
+		t.Fatalf("RecentLogsFiltered = %+v, %v", logs, err)
+	}
+	for _, r := range logs {
+		if r.Source != "import" {
+			t.Errorf("source = %q, want import", r.Source)
+		}
+		if !r.Timestamp.Equal(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)) {
+			t.Errorf("timestamp = %s", r.Timestamp)
+		}
+	}
+	warn := logs[0]
+	if warn.Level != "WARN" {
+		warn = logs[1]
+	}
+	if warn.App != "ops" || warn.Attributes["region"] != "eu" || warn.Attributes["disk"] != "/dev/sda" {
+		t.Fatalf("record = %+v", warn)
+	}
+
+	if _, err := store.ImportFile(filepath.Join(t.TempDir(), "logs.csv")); err == nil {
+		t.Fatal("unknown extension should fail")
+	}
+}
+
+func TestImportFile_ParseLine(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.jsonl")
+	if err := os.WriteFile(path, []byte("a\nb\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	n, err := store.ImportFile(path, ImportConfig{ParseLine: func(line string) []*LogRecord {
+		return []*LogRecord{{Timestamp: time.Now(), Level: "INFO", Message: "parsed " + line}}
+	}})
+	if err != nil || n != 2 {
+		t.Fatalf("ImportFile = %d, %v", n, err)
+	}
+}
+
+func TestImportFile_ParquetMapAttributes(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	// A foreign file: MAP attributes, an extra column, no level_num.
+	path := filepath.Join(t.TempDir(), "foreign.parquet")
+	_, err = store.DB().Exec(`COPY (SELECT TIMESTAMP '2025-01-01 12:00:00' AS timestamp, 'hello' AS message,
+		MAP {'k8s.pod': 'api-0'} AS attributes, 'eu' AS region) TO ` + quoteSQLString(path) + ` (FORMAT parquet)`)
+	if err != nil {
+		t.Fatalf("write parquet: %v", err)
+	}
+	if n, err := store.ImportFile(path, ImportConfig{DefaultApp: "legacy"}); err != nil || n != 1 {
+		t.Fatalf("ImportFile = %d, %v", n, err)
+	}
+	logs, err := store.RecentLogsFiltered(1, QueryOpts{App: "legacy"}, nil, nil, "")
+	if err != nil || len(logs) != 1 {
+		t.Fatalf("RecentLogsFiltered = %+v, %v", logs, err)
+	}
+	if logs[0].Attributes["k8s.pod"] != "api-0" || logs[0].Attributes["region"] != "eu" {
+		t.Fatalf("attributes = %v", logs[0].Attributes)
+	}
+}
@@ -2,6 +2,7 @@ package duckdb
 
 import (
 	"context"
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -9,6 +10,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	duckdb "github.com/duckdb/duckdb-go/v2"
 	"github.com/tinytelemetry/tiny-telemetry/internal/journal"
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 )
@@ -42,11 +44,18 @@ type InsertBuffer struct {
 	wg            sync.WaitGroup
 	tickWg        sync.WaitGroup // separate WaitGroup for tickLoop
 	journal       durableJournal
+	dedupKey      string // see InsertBufferConfig.DedupKey
+	onFlushError  func(error)
 
 	// backpressureCount tracks inline flushes for throttled logging.
 	backpressureCount atomic.Int64
 	lastBPLog         atomic.Int64 // unix timestamp of last backpressure log
 	stopOnce          sync.Once
+
+	// unwritten counts records added but not yet flushed; see Lag.
+	unwritten    atomic.Int64
+	waitingSince atomic.Int64 // unix nanos when unwritten last rose from zero
+	lastWrite    atomic.Int64 // unix nanos of the last successful flush
 }
 
 // InsertBufferConfig holds tunable parameters for the insert buffer.
@@ -55,6 +64,12 @@ type InsertBufferConfig struct {
 	FlushInterval  time.Duration
 	FlushQueueSize int
 	Journal        *journal.Journal
+	// DedupKey derives the event_id of records that have none
+	// (DedupKeyEventID or DedupKeyContent); empty assigns a unique id.
+	DedupKey string
+	// OnFlushError, when set, is called with each failed flush after it is
+	// logged. It runs on the flushing goroutine and should not block.
+	OnFlushError func(error)
 }
 
 // NewInsertBuffer creates a new insert buffer that flushes to the store.
@@ -86,6 +101,10 @@ func NewInsertBuffer(writer model.LogWriter, conf ...InsertBufferConfig) *Insert
 	if len(conf) > 0 && conf[0].Journal != nil {
 		b.journal = conf[0].Journal
 	}
+	if len(conf) > 0 {
+		b.dedupKey = conf[0].DedupKey
+		b.onFlushError = conf[0].OnFlushError
+	}
 
 	b.wg.Add(1)
 	go b.flushWorker()
@@ -126,6 +145,14 @@ func (b *InsertBuffer) logBackpressure() {
 	}
 }
 
+// flushFailed logs a failed flush and reports it to OnFlushError.
+func (b *InsertBuffer) flushFailed(what string, err error) {
+	log.Printf("%s: %v", what, err)
+	if b.onFlushError != nil {
+		b.onFlushError(err)
+	}
+}
+
 // drainPending moves pending records to the flush channel without blocking on DuckDB.
 func (b *InsertBuffer) drainPending() {
 	b.mu.Lock()
@@ -144,7 +171,7 @@ func (b *InsertBuffer) drainPending() {
 	default:
 		b.logBackpressure()
 		if err := b.flushBatch(batch); err != nil {
-			log.Printf("duckdb flush error (inline): %v", err)
+			b.flushFailed("duckdb flush error (inline)", err)
 		}
 	}
 }
@@ -154,13 +181,16 @@ func (b *InsertBuffer) flushWorker() {
 	defer b.wg.Done()
 	for batch := range b.flushChan {
 		if err := b.flushBatch(batch); err != nil {
-			log.Printf("duckdb flush error: %v", err)
+			b.flushFailed("duckdb flush error", err)
 		}
 	}
 }
 
 // Add queues a record for batch insertion. This never blocks on DuckDB IO.
 func (b *InsertBuffer) Add(record *LogRecord) {
+	if record.EventID == "" {
+		record.EventID = dedupEventID(b.dedupKey, record)
+	}
 	if record.EventID == "" {
 		record.EventID = nextEventID()
 	}
@@ -182,6 +212,9 @@ func (b *InsertBuffer) Add(record *LogRecord) {
 		}
 	}
 
+	if b.unwritten.Add(1) == 1 {
+		b.waitingSince.Store(time.Now().UnixNano())
+	}
 	b.mu.Lock()
 	b.pending = append(b.pending, journaledRecord{
 		seq:    seq,
@@ -203,7 +236,7 @@ func (b *InsertBuffer) Add(record *LogRecord) {
 			// unbounded goroutines under sustained overload.
 			b.logBackpressure()
 			if err := b.flushBatch(batch); err != nil {
-				log.Printf("duckdb flush error (overflow-inline): %v", err)
+				b.flushFailed("duckdb flush error (overflow-inline)", err)
 			}
 		}
 	}
@@ -226,10 +259,22 @@ func (b *InsertBuffer) Stop() {
 	})
 }
 
+// Lag returns how long records have been waiting without the buffer
+// writing any: zero when every added record is written, and growing while
+// the store does not keep up or its writes fail.
+func (b *InsertBuffer) Lag() time.Duration {
+	if b.unwritten.Load() <= 0 {
+		return 0
+	}
+	since := max(b.waitingSince.Load(), b.lastWrite.Load())
+	return time.Since(time.Unix(0, since))
+}
+
 func (b *InsertBuffer) flushBatch(batch []journaledRecord) error {
 	if len(batch) == 0 {
 		return nil
 	}
+	defer b.unwritten.Add(-int64(len(batch)))
 
 	records := make([]*LogRecord, 0, len(batch))
 	for _, item := range batch {
@@ -239,6 +284,7 @@ func (b *InsertBuffer) flushBatch(batch []journaledRecord) error {
 	if err := b.writer.InsertLogBatch(records); err != nil {
 		return err
 	}
+	b.lastWrite.Store(time.Now().UnixNano())
 
 	if b.journal != nil {
 		maxSeq := uint64(0)
@@ -289,9 +335,26 @@ func (s *Store) InsertLogBatch(records []*LogRecord) error {
 	return nil
 }
 
-// insertBatchTx inserts records in a single transaction.
+// appendColumns are the logs columns insertBatchTx fills, before the
+// promoted attribute columns; id takes its sequence default.
+var appendColumns = []string{
+	"timestamp", "orig_timestamp", "level", "level_num", "message", "raw_line",
+	"service", "hostname", "pid", "attributes", "source", "app", "event_id",
+	"trace_id", "span_id", "body_json", "raw_line_zstd",
+}
+
+// insertBatchTx inserts records in a single transaction. Rows go through
+// DuckDB's appender on the transaction's connection, one table at a time,
+// which is much cheaper than executing an INSERT per record. A constraint
+// violation fails the whole batch, as an INSERT would.
 func (s *Store) insertBatchTx(ctx context.Context, records []*LogRecord) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -302,12 +365,86 @@ func (s *Store) insertBatchTx(ctx context.Context, records []*LogRecord) error {
 		}
 	}()
 
-	logStmt, err := tx.PrepareContext(ctx, `INSERT INTO logs (timestamp, orig_timestamp, level, level_num, message, raw_line, service, hostname, pid, attributes, source, app, event_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	var duplicates int64
+	if s.dedupWindow > 0 {
+		if records, duplicates, err = s.dropDuplicates(ctx, tx, records); err != nil {
+			return fmt.Errorf("dedup: %w", err)
+		}
+		if len(records) == 0 {
+			s.duplicates.Add(duplicates)
+			return nil
+		}
+	}
+
+	// A partitioned store inserts each record into its day's table.
+	var created []string
+	if s.partitions != nil {
+		if created, err = s.ensurePartitions(ctx, tx, records); err != nil {
+			return err
+		}
+	}
+	var tables []string
+	groups := make(map[string][]*LogRecord)
+	for _, r := range records {
+		table := ""
+		if s.partitions != nil {
+			table = partitionFor(r.Timestamp)
+		}
+		if _, ok := groups[table]; !ok {
+			tables = append(tables, table)
+		}
+		groups[table] = append(groups[table], r)
+	}
+
+	// Ids are assigned in append order, table by table.
+	appended := make([]*LogRecord, 0, len(records))
+	err = conn.Raw(func(driverConn any) error {
+		for _, table := range tables {
+			schema, name := "", "logs"
+			if table != "" {
+				schema, name = partitionSchema, table
+			}
+			if err := appendLogs(driverConn.(driver.Conn), schema, name, s.promoted, s.rawLine, groups[table]); err != nil {
+				return err
+			}
+			appended = append(appended, groups[table]...)
+		}
+		return nil
+	})
 	if err != nil {
+		return fmt.Errorf("record insert: %w", err)
+	}
+
+	if err := updateRollups(ctx, tx, s.rawLine, appended); err != nil {
+		return fmt.Errorf("rollups: %w", err)
+	}
+	if s.searchIndex {
+		if err := s.indexInsertedLogs(ctx, tx, appended); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
 		return err
 	}
-	defer logStmt.Close()
+	committed = true
+	s.duplicates.Add(duplicates)
+	s.addTotals(appended)
+	for _, name := range created {
+		s.partitions[name] = true
+	}
+	return nil
+}
 
+// appendLogs appends records to schema.table through a DuckDB appender,
+// storing their raw lines as policy says.
+func appendLogs(driverConn driver.Conn, schema, table string, promoted []promotedColumn, policy RawLinePolicy, records []*LogRecord) error {
+	columns := append(append([]string(nil), appendColumns...), promotedNames(promoted)...)
+	appender, err := duckdb.NewAppenderWithColumns(driverConn, "", schema, table, columns)
+	if err != nil {
+		return err
+	}
+	row := make([]driver.Value, len(columns))
 	for _, r := range records {
 		attrsJSON := []byte("{}")
 		if len(r.Attributes) > 0 {
@@ -318,7 +455,7 @@ func (s *Store) insertBatchTx(ctx context.Context, records []*LogRecord) error {
 			}
 		}
 
-		var origTS any
+		var origTS driver.Value
 		if !r.OrigTimestamp.IsZero() {
 			origTS = r.OrigTimestamp
 		}
@@ -331,22 +468,28 @@ func (s *Store) insertBatchTx(ctx context.Context, records []*LogRecord) error {
 		if eventID == "" {
 			eventID = nextEventID()
 		}
-
-		if _, err := logStmt.ExecContext(
-			ctx,
-			r.Timestamp, origTS, r.Level, r.LevelNum,
-			r.Message, r.RawLine, r.Service, r.Hostname,
-			r.PID, string(attrsJSON), r.Source, app, eventID,
-		); err != nil {
-			return fmt.Errorf("record insert: %w", err)
+		traceID, spanID := model.TraceContext(r.Attributes)
+		raw, compressed := storedRawLine(policy, r)
+		var body driver.Value
+		if r.BodyJSON != "" {
+			body = json.RawMessage(r.BodyJSON)
 		}
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
+		row = append(row[:0],
+			r.Timestamp, origTS, r.Level, int32(r.LevelNum),
+			r.Message, raw, r.Service, r.Hostname,
+			int32(r.PID), json.RawMessage(attrsJSON), r.Source, app, eventID,
+			nullString(traceID), nullString(spanID), body, compressed,
+		)
+		for _, p := range promoted {
+			row = append(row, promotedValue(p, r.Attributes))
+		}
+		if err := appender.AppendRow(row...); err != nil {
+			appender.Close()
+			return err
+		}
 	}
-	committed = true
-	return nil
+	return appender.Close()
 }
 
 func nextEventID() string {
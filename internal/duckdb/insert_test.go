@@ -1,6 +1,7 @@
 package duckdb
 
 import (
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -33,6 +34,64 @@ func TestInsertBuffer_AddAndStop(t *testing.T) {
 	}
 }
 
+// blockedWriter holds every batch until release is closed.
+type blockedWriter struct {
+	release chan struct{}
+}
+
+func (w *blockedWriter) InsertLogBatch([]*LogRecord) error {
+	<-w.release
+	return nil
+}
+
+func TestInsertBuffer_Lag(t *testing.T) {
+	w := &blockedWriter{release: make(chan struct{})}
+	buf := NewInsertBuffer(w, InsertBufferConfig{FlushInterval: 10 * time.Millisecond})
+	defer buf.Stop()
+
+	if lag := buf.Lag(); lag != 0 {
+		t.Fatalf("Lag of an empty buffer = %s, want 0", lag)
+	}
+	buf.Add(&LogRecord{Timestamp: time.Now(), Message: "stuck"})
+	time.Sleep(50 * time.Millisecond)
+	if lag := buf.Lag(); lag < 40*time.Millisecond {
+		t.Errorf("Lag while the writer is blocked = %s, want at least 40ms", lag)
+	}
+
+	close(w.release)
+	deadline := time.Now().Add(2 * time.Second)
+	for buf.Lag() != 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if lag := buf.Lag(); lag != 0 {
+		t.Errorf("Lag after the write = %s, want 0", lag)
+	}
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) InsertLogBatch([]*LogRecord) error { return w.err }
+
+func TestInsertBuffer_OnFlushError(t *testing.T) {
+	want := errors.New("disk full")
+	failed := make(chan error, 1)
+	buf := NewInsertBuffer(failingWriter{err: want}, InsertBufferConfig{
+		FlushInterval: 10 * time.Millisecond,
+		OnFlushError:  func(err error) { failed <- err },
+	})
+	defer buf.Stop()
+
+	buf.Add(&LogRecord{Timestamp: time.Now(), Message: "lost"})
+	select {
+	case err := <-failed:
+		if !errors.Is(err, want) {
+			t.Fatalf("OnFlushError got %v, want %v", err, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnFlushError not called")
+	}
+}
+
 func TestInsertBuffer_BatchThreshold(t *testing.T) {
 	store := newTestStore(t)
 	buf := NewInsertBuffer(store)
@@ -119,3 +178,63 @@ func TestInsertBuffer_StopIsIdempotent(t *testing.T) {
 		t.Errorf("after double Stop, TotalLogCount = %d, want 1", count)
 	}
 }
+
+func TestInsertLogBatch_DuplicateEventIDKeepsRest(t *testing.T) {
+	store := newTestStore(t)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: time.Now(), Level: "INFO", Message: "first", EventID: "dup"},
+	})
+
+	// The appender rejects the whole batch; the record-by-record retry keeps
+	// everything but the duplicate.
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: time.Now(), Level: "INFO", Message: "second", EventID: "a"},
+		{Timestamp: time.Now(), Level: "INFO", Message: "again", EventID: "dup"},
+		{Timestamp: time.Now(), Level: "INFO", Message: "third", EventID: "b", Attributes: map[string]string{"k": "v"}},
+	})
+
+	logs, err := store.RecentLogsFiltered(10, QueryOpts{}, nil, nil, "")
+	if err != nil {
+		t.Fatalf("RecentLogsFiltered: %v", err)
+	}
+	if len(logs) != 3 {
+		t.Fatalf("stored %d logs, want 3", len(logs))
+	}
+	for _, r := range logs {
+		if r.Message == "again" {
+			t.Errorf("duplicate event_id was stored")
+		}
+		if r.Message == "third" && r.Attributes["k"] != "v" {
+			t.Errorf("attributes = %v, want k=v", r.Attributes)
+		}
+	}
+}
+
+func TestInsertLogBatch_InterleavedDaysIndexed(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.EnableDayPartitions(); err != nil {
+		t.Fatalf("EnableDayPartitions: %v", err)
+	}
+	if err := store.EnableSearchIndex(); err != nil {
+		t.Fatalf("EnableSearchIndex: %v", err)
+	}
+
+	// Records are appended day by day, so ids no longer follow batch order;
+	// with the batch straddling an index block, the index must still file
+	// each message under its own id.
+	if _, err := store.db.Exec(`SELECT nextval('logs_id_seq') FROM range(?)`, searchBlockSize-3); err != nil {
+		t.Fatalf("advance id sequence: %v", err)
+	}
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: day.Add(-23 * time.Hour), Level: "INFO", Message: "alpha yesterday"},
+		{Timestamp: day.Add(time.Hour), Level: "INFO", Message: "bravo today"},
+		{Timestamp: day.Add(-22 * time.Hour), Level: "INFO", Message: "charlie yesterday"},
+	})
+
+	for _, term := range []string{"alpha", "bravo", "charlie"} {
+		if got := searchMessages(t, store, term); len(got) != 1 {
+			t.Errorf("SearchLogs(%q) = %q, want one match", term, got)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package duckdb
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// maintenanceWindowCheck is how often a maintainer with a window looks for
+// the window to open once a compaction is due.
+const maintenanceWindowCheck = time.Minute
+
+// Compact checkpoints the database, writing the WAL into the file and
+// truncating free blocks at its end, and returns how many bytes the file
+// and WAL shrank by. DuckDB reclaims space only through checkpoints;
+// VACUUM does not shrink the file. An in-memory database reports 0.
+func (s *Store) Compact() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := s.fileBytes()
+	if _, err := s.db.Exec("CHECKPOINT"); err != nil {
+		return 0, fmt.Errorf("checkpoint: %w", err)
+	}
+	return max(before-s.fileBytes(), 0), nil
+}
+
+// fileBytes returns the size of the database file plus its WAL.
+func (s *Store) fileBytes() int64 {
+	if s.dbPath == "" {
+		return 0
+	}
+	var size int64
+	for _, path := range []string{s.dbPath, s.dbPath + ".wal"} {
+		if info, err := os.Stat(path); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+// MaintenanceWindow is a daily local time range, as offsets from midnight.
+// A window whose Start is after its End wraps midnight (22:00-04:00). The
+// zero window allows any time.
+type MaintenanceWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Contains reports whether t's local time of day falls in the window.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	if w.Start == w.End {
+		return true
+	}
+	h, m, s := t.Clock()
+	tod := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	if w.Start < w.End {
+		return tod >= w.Start && tod < w.End
+	}
+	return tod >= w.Start || tod < w.End
+}
+
+// MaintenanceConfig holds configuration for the maintainer.
+type MaintenanceConfig struct {
+	// Interval is the time between compactions; 0 disables.
+	Interval time.Duration
+	// Window restricts compactions to off-hours; a due compaction waits
+	// for the window to open.
+	Window MaintenanceWindow
+}
+
+// Maintainer periodically compacts the store so deletes from retention do
+// not leave the WAL and file growing unchecked.
+type Maintainer struct {
+	store    Compactor
+	interval time.Duration
+	window   MaintenanceWindow
+	now      func() time.Time
+	done     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+
+	statsMu sync.Mutex
+	stats   MaintenanceStats
+	lastRun time.Time // when the last due compaction ran, or the start
+}
+
+// NewMaintainer starts compacting store every cfg.Interval. Returns nil
+// when the interval is not positive.
+func NewMaintainer(store Compactor, cfg MaintenanceConfig) *Maintainer {
+	if cfg.Interval <= 0 {
+		return nil
+	}
+	m := &Maintainer{
+		store:    store,
+		interval: cfg.Interval,
+		window:   cfg.Window,
+		now:      time.Now,
+		done:     make(chan struct{}),
+	}
+	m.lastRun = m.now()
+
+	tick := m.interval
+	if m.window != (MaintenanceWindow{}) {
+		tick = min(tick, maintenanceWindowCheck)
+	}
+	m.wg.Add(1)
+	go m.tickLoop(tick)
+	return m
+}
+
+func (m *Maintainer) tickLoop(tick time.Duration) {
+	defer m.wg.Done()
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.maybeCompact()
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// maybeCompact compacts when a compaction is due and the window is open.
+func (m *Maintainer) maybeCompact() {
+	now := m.now()
+	if now.Sub(m.lastRun) < m.interval || !m.window.Contains(now) {
+		return
+	}
+	m.lastRun = now
+	m.compact()
+}
+
+func (m *Maintainer) compact() {
+	reclaimed, err := m.store.Compact()
+	if err != nil {
+		log.Printf("duckdb: maintenance checkpoint error: %v", err)
+		return
+	}
+	m.statsMu.Lock()
+	m.stats.Runs++
+	m.stats.Reclaimed += reclaimed
+	m.stats.LastReclaimed = reclaimed
+	m.stats.LastRun = m.now()
+	m.statsMu.Unlock()
+	if reclaimed > 0 {
+		log.Printf("duckdb: maintenance checkpoint reclaimed %d bytes", reclaimed)
+	}
+}
+
+// MaintenanceStats returns what compaction has done since startup.
+func (m *Maintainer) MaintenanceStats() MaintenanceStats {
+	m.statsMu.Lock()
+	defer m.statsMu.Unlock()
+	return m.stats
+}
+
+// Stop signals the maintainer to stop and waits for it to finish.
+func (m *Maintainer) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.done)
+		m.wg.Wait()
+	})
+}
+
+// Store compacts itself.
+var _ Compactor = (*Store)(nil)
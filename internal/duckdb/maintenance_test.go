@@ -0,0 +1,110 @@
+package duckdb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindow_Contains(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 3, 10, h, m, 0, 0, time.Local) }
+	night := MaintenanceWindow{Start: 22 * time.Hour, End: 4 * time.Hour}
+	early := MaintenanceWindow{Start: 2 * time.Hour, End: 5 * time.Hour}
+
+	tests := []struct {
+		window MaintenanceWindow
+		t      time.Time
+		want   bool
+	}{
+		{MaintenanceWindow{}, at(13, 0), true},
+		{early, at(2, 0), true},
+		{early, at(4, 59), true},
+		{early, at(5, 0), false},
+		{early, at(1, 59), false},
+		{night, at(23, 30), true},
+		{night, at(3, 0), true},
+		{night, at(12, 0), false},
+	}
+	for _, tt := range tests {
+		if got := tt.window.Contains(tt.t); got != tt.want {
+			t.Errorf("%+v.Contains(%s) = %v, want %v", tt.window, tt.t.Format("15:04"), got, tt.want)
+		}
+	}
+}
+
+// fakeCompactor reclaims a fixed number of bytes per call.
+type fakeCompactor struct{ calls int }
+
+func (f *fakeCompactor) Compact() (int64, error) {
+	f.calls++
+	return 1024, nil
+}
+
+func TestMaintainer_WaitsForIntervalAndWindow(t *testing.T) {
+	store := &fakeCompactor{}
+	m := NewMaintainer(store, MaintenanceConfig{
+		Interval: time.Hour,
+		Window:   MaintenanceWindow{Start: 2 * time.Hour, End: 5 * time.Hour},
+	})
+	m.Stop() // drive maybeCompact by hand
+
+	start := time.Date(2026, 3, 10, 0, 30, 0, 0, time.Local)
+	now := start
+	m.now = func() time.Time { return now }
+	m.lastRun = start
+
+	now = start.Add(30 * time.Minute) // not due
+	m.maybeCompact()
+	now = start.Add(time.Hour) // due at 01:30, before the window
+	m.maybeCompact()
+	if store.calls != 0 {
+		t.Fatalf("compacted %d times before the window opened", store.calls)
+	}
+	now = start.Add(2 * time.Hour) // 02:30
+	m.maybeCompact()
+	m.maybeCompact()
+	if store.calls != 1 {
+		t.Fatalf("compacted %d times in the window, want 1 per interval", store.calls)
+	}
+	if stats := m.MaintenanceStats(); stats.Runs != 1 || stats.Reclaimed != 1024 || !stats.LastRun.Equal(now) {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestNewMaintainer_DisabledReturnsNil(t *testing.T) {
+	if m := NewMaintainer(&fakeCompactor{}, MaintenanceConfig{}); m != nil {
+		m.Stop()
+		t.Fatal("expected nil maintainer without an interval")
+	}
+}
+
+func TestStoreCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.duckdb")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	var records []*LogRecord
+	for i := range 2000 {
+		records = append(records, &LogRecord{Timestamp: now, Level: "INFO", Message: fmt.Sprintf("log line %d with some padding to take up space", i)})
+	}
+	insertTestRecords(t, store, records)
+	if _, err := store.DeleteBefore(now.Add(time.Second)); err != nil {
+		t.Fatalf("DeleteBefore: %v", err)
+	}
+
+	if _, err := store.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if info, err := os.Stat(path + ".wal"); err == nil && info.Size() > 0 {
+		t.Errorf("WAL is %d bytes after Compact, want it checkpointed", info.Size())
+	}
+	if reclaimed, err := newTestStore(t).Compact(); err != nil || reclaimed != 0 {
+		t.Errorf("in-memory Compact = %d, %v; want 0", reclaimed, err)
+	}
+}
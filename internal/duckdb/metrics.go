@@ -0,0 +1,194 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	duckdb "github.com/duckdb/duckdb-go/v2"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// metricColumns are the metrics columns InsertMetricBatch fills.
+var metricColumns = []string{"timestamp", "name", "kind", "unit", "value", "labels", "source", "app", "service"}
+
+// InsertMetricBatch appends samples to the metrics table through a DuckDB
+// appender. A batch is written whole or not at all.
+func (s *Store) InsertMetricBatch(samples []*model.MetricSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.QueryTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(driverConn any) error {
+		appender, err := duckdb.NewAppenderWithColumns(driverConn.(driver.Conn), "", "", "metrics", metricColumns)
+		if err != nil {
+			return err
+		}
+		for _, m := range samples {
+			labelsJSON := []byte("{}")
+			if len(m.Labels) > 0 {
+				if data, merr := json.Marshal(m.Labels); merr != nil {
+					log.Printf("duckdb: failed to marshal metric labels, using empty: %v", merr)
+				} else {
+					labelsJSON = data
+				}
+			}
+			app := m.App
+			if app == "" {
+				app = "default"
+			}
+			source := m.Source
+			if source == "" {
+				source = "otlp"
+			}
+			if err := appender.AppendRow(m.Timestamp, m.Name, m.Kind, nullString(m.Unit), m.Value,
+				json.RawMessage(labelsJSON), source, app, nullString(m.Service)); err != nil {
+				appender.Close()
+				return err
+			}
+		}
+		return appender.Close()
+	})
+	if err != nil {
+		return fmt.Errorf("metric insert: %w", err)
+	}
+	return nil
+}
+
+// nullString returns nil for an empty string, so the appender stores NULL.
+func nullString(s string) driver.Value {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// MetricSeries lists the series whose name contains filter, case
+// insensitively (all when empty), ordered by name and labels. Series are
+// told apart by their full label set.
+func (s *Store) MetricSeries(filter string, limit int, opts QueryOpts) ([]model.MetricSeries, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conditions, args := scopeConditions(opts)
+	if filter != "" {
+		conditions = append(conditions, "contains(lower(name), lower(?))")
+		args = append(args, filter)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	query := fmt.Sprintf(`
+		SELECT name, any_value(kind), coalesce(any_value(unit), ''), labels::VARCHAR AS series_labels,
+			count(*), max(timestamp), arg_max(value, timestamp)
+		FROM metrics %s
+		GROUP BY name, series_labels
+		ORDER BY name, series_labels
+		LIMIT ?`, where)
+
+	rows, err := s.db.QueryContext(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var series []model.MetricSeries
+	for rows.Next() {
+		var ms model.MetricSeries
+		var labels string
+		if err := rows.Scan(&ms.Name, &ms.Kind, &ms.Unit, &labels, &ms.Points, &ms.LastSeen, &ms.LastValue); err != nil {
+			log.Printf("duckdb scan error (MetricSeries): %v", err)
+			continue
+		}
+		ms.Labels = make(map[string]string)
+		if err := parseJSONMap(labels, ms.Labels); err != nil {
+			log.Printf("duckdb: bad metric labels (MetricSeries): %v", err)
+		}
+		series = append(series, ms)
+	}
+	return series, rows.Err()
+}
+
+// MetricRange buckets the samples of name whose labels include every pair
+// in labels into steps of step (a minute when step is not positive),
+// oldest first. Samples of all matching series in a step are aggregated
+// together.
+func (s *Store) MetricRange(name string, labels map[string]string, step time.Duration, opts QueryOpts) ([]model.MetricPoint, error) {
+	if step <= 0 {
+		step = time.Minute
+	}
+
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conditions, scopeArgs := scopeConditions(opts)
+	conditions = append([]string{"name = ?"}, conditions...)
+	args := append([]interface{}{step.Microseconds(), name}, scopeArgs...)
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		conditions = append(conditions, "json_extract_string(labels, ?) = ?")
+		args = append(args, k, labels[k])
+	}
+	query := `
+		SELECT time_bucket(to_microseconds(?), timestamp) AS bucket,
+			avg(value), min(value), max(value), count(*)
+		FROM metrics
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		GROUP BY bucket
+		ORDER BY bucket`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []model.MetricPoint
+	for rows.Next() {
+		var p model.MetricPoint
+		if err := rows.Scan(&p.Time, &p.Avg, &p.Min, &p.Max, &p.Samples); err != nil {
+			log.Printf("duckdb scan error (MetricRange): %v", err)
+			continue
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// Store stores and queries metrics.
+var _ model.MetricWriter = (*Store)(nil)
+var _ model.MetricQuerier = (*Store)(nil)
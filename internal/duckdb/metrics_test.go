@@ -0,0 +1,63 @@
+package duckdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestMetricSeriesAndRange(t *testing.T) {
+	store := newTestStore(t)
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	var samples []*model.MetricSample
+	for i := range 6 {
+		ts := base.Add(time.Duration(i) * 20 * time.Second)
+		samples = append(samples,
+			&model.MetricSample{Timestamp: ts, Name: "queue.depth", Kind: "gauge", Value: float64(i), Labels: map[string]string{"queue": "mail"}, App: "shop"},
+			&model.MetricSample{Timestamp: ts, Name: "queue.depth", Kind: "gauge", Value: 100, Labels: map[string]string{"queue": "jobs"}, App: "shop"},
+		)
+	}
+	samples = append(samples, &model.MetricSample{Timestamp: base, Name: "http.requests", Kind: "counter", Unit: "1", Value: 42})
+	if err := store.InsertMetricBatch(samples); err != nil {
+		t.Fatalf("InsertMetricBatch: %v", err)
+	}
+
+	series, err := store.MetricSeries("QUEUE", 10, QueryOpts{})
+	if err != nil {
+		t.Fatalf("MetricSeries: %v", err)
+	}
+	if len(series) != 2 || series[0].Labels["queue"] != "jobs" || series[1].Labels["queue"] != "mail" {
+		t.Fatalf("MetricSeries = %+v, want the jobs and mail series", series)
+	}
+	if s := series[1]; s.Points != 6 || s.LastValue != 5 || !s.LastSeen.Equal(base.Add(100*time.Second)) || s.Kind != "gauge" {
+		t.Errorf("mail series = %+v", s)
+	}
+	if all, err := store.MetricSeries("", 10, QueryOpts{App: "default"}); err != nil || len(all) != 1 || all[0].Unit != "1" {
+		t.Errorf("MetricSeries(app default) = %+v, %v", all, err)
+	}
+
+	points, err := store.MetricRange("queue.depth", map[string]string{"queue": "mail"}, time.Minute, QueryOpts{})
+	if err != nil {
+		t.Fatalf("MetricRange: %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("MetricRange = %+v, want 2 minutes", points)
+	}
+	if p := points[0]; !p.Time.Equal(base) || p.Avg != 1 || p.Min != 0 || p.Max != 2 || p.Samples != 3 {
+		t.Errorf("first minute = %+v", p)
+	}
+	both, err := store.MetricRange("queue.depth", nil, time.Minute, QueryOpts{From: base.Add(time.Minute)})
+	if err != nil || len(both) != 1 || both[0].Samples != 6 || both[0].Max != 100 {
+		t.Errorf("MetricRange(all series, from) = %+v, %v", both, err)
+	}
+
+	// Metrics expire with the logs' retention period.
+	if _, err := store.DeleteBefore(base.Add(time.Minute)); err != nil {
+		t.Fatalf("DeleteBefore: %v", err)
+	}
+	if series, err := store.MetricSeries("", 10, QueryOpts{}); err != nil || len(series) != 2 || series[0].Points != 3 {
+		t.Errorf("MetricSeries after retention = %+v, %v", series, err)
+	}
+}
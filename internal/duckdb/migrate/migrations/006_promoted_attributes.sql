@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS promoted_attributes (
+    key         VARCHAR PRIMARY KEY,
+    column_name VARCHAR NOT NULL UNIQUE,
+    data_type   VARCHAR NOT NULL,
+    position    INTEGER NOT NULL
+);
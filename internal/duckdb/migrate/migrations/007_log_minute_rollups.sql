@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS log_minute_rollups (
+    minute  TIMESTAMP NOT NULL,
+    app     VARCHAR NOT NULL,
+    service VARCHAR NOT NULL,
+    level   VARCHAR NOT NULL,
+    count   BIGINT NOT NULL,
+    bytes   BIGINT NOT NULL,
+    PRIMARY KEY (minute, app, service, level)
+);
+
+INSERT INTO log_minute_rollups
+SELECT date_trunc('minute', timestamp), coalesce(app, 'default'), coalesce(service, ''), level,
+       count(*), coalesce(sum(length(raw_line)), 0)
+FROM logs
+GROUP BY ALL;
@@ -0,0 +1,3 @@
+CREATE TABLE IF NOT EXISTS log_archive (
+    dir VARCHAR NOT NULL
+);
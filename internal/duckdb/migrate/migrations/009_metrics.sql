@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS metrics (
+    timestamp   TIMESTAMP NOT NULL,
+    name        VARCHAR NOT NULL,
+    kind        VARCHAR NOT NULL,
+    unit        VARCHAR,
+    value       DOUBLE NOT NULL,
+    labels      JSON,
+    source      VARCHAR DEFAULT 'otlp',
+    app         VARCHAR DEFAULT 'default',
+    service     VARCHAR
+);
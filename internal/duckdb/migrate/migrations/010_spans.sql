@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS spans (
+    trace_id        VARCHAR NOT NULL,
+    span_id         VARCHAR NOT NULL,
+    parent_span_id  VARCHAR,
+    name            VARCHAR NOT NULL,
+    kind            VARCHAR,
+    start_time      TIMESTAMP NOT NULL,
+    end_time        TIMESTAMP,
+    status_code     VARCHAR,
+    status_message  VARCHAR,
+    attributes      JSON,
+    service         VARCHAR,
+    app             VARCHAR DEFAULT 'default'
+);
+
+CREATE INDEX IF NOT EXISTS idx_spans_trace_id ON spans(trace_id);
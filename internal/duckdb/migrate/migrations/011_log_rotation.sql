@@ -0,0 +1,3 @@
+CREATE TABLE IF NOT EXISTS log_rotation (
+    dir VARCHAR NOT NULL
+);
@@ -0,0 +1,10 @@
+CREATE SEQUENCE IF NOT EXISTS saved_queries_id_seq;
+
+CREATE TABLE IF NOT EXISTS saved_queries (
+    id          BIGINT PRIMARY KEY DEFAULT nextval('saved_queries_id_seq'),
+    name        VARCHAR NOT NULL UNIQUE,
+    description VARCHAR NOT NULL DEFAULT '',
+    sql         VARCHAR NOT NULL,
+    created_at  TIMESTAMP NOT NULL,
+    updated_at  TIMESTAMP NOT NULL
+);
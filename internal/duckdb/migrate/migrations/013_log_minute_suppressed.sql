@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS log_minute_suppressed (
+    minute  TIMESTAMP NOT NULL,
+    app     VARCHAR NOT NULL,
+    service VARCHAR NOT NULL,
+    level   VARCHAR NOT NULL,
+    count   BIGINT NOT NULL,
+    PRIMARY KEY (minute, app, service, level)
+);
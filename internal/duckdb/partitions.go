@@ -0,0 +1,370 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// partitionSchema holds the day partitions of a partitioned store.
+const partitionSchema = "log_days"
+
+// partitionBase is an always-empty partition that keeps the logs view valid
+// when no day has been written yet.
+const partitionBase = "base"
+
+// partitionDayLayout formats the UTC day of a partition name ("d20260131").
+const partitionDayLayout = "20060102"
+
+// partitionColumns mirrors the logs table created by the migrations and
+// addedLogColumns; partitionDDL adds the promoted attribute columns.
+const partitionColumns = `
+	id              BIGINT DEFAULT nextval('logs_id_seq'),
+	timestamp       TIMESTAMP NOT NULL,
+	orig_timestamp  TIMESTAMP,
+	level           VARCHAR NOT NULL,
+	level_num       INTEGER,
+	message         VARCHAR NOT NULL,
+	raw_line        VARCHAR,
+	service         VARCHAR DEFAULT 'unknown',
+	hostname        VARCHAR,
+	pid             INTEGER,
+	attributes      JSON,
+	source          VARCHAR DEFAULT 'tcp',
+	app             VARCHAR DEFAULT 'default',
+	event_id        VARCHAR,
+	trace_id        VARCHAR,
+	span_id         VARCHAR,
+	body_json       JSON,
+	raw_line_zstd   BLOB`
+
+// EnableDayPartitions converts the logs table into one table per UTC day
+// under the log_days schema, with logs recreated as a view over them. Reads
+// and ad-hoc SQL keep using logs; time-bounded queries skip the days outside
+// their range, and retention drops whole days instead of deleting rows.
+// Existing rows are copied into their days once, in one transaction. The
+// conversion cannot be undone; a store opened on a partitioned database
+// uses the partitions whether or not this is called.
+func (s *Store) EnableDayPartitions() error {
+	ctx := context.Background()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.partitions != nil {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `CREATE SCHEMA IF NOT EXISTS `+partitionSchema); err != nil {
+		return fmt.Errorf("partition logs: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE `+partitionTable(partitionBase)+` `+s.partitionDDL()); err != nil {
+		return fmt.Errorf("partition logs: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT DISTINCT CAST(timestamp AS DATE) FROM logs`)
+	if err != nil {
+		return fmt.Errorf("partition logs: %w", err)
+	}
+	var days []time.Time
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			rows.Close()
+			return fmt.Errorf("partition logs: %w", err)
+		}
+		days = append(days, day)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("partition logs: %w", err)
+	}
+
+	partitions := make(map[string]bool, len(days))
+	for _, day := range days {
+		name := partitionName(day)
+		if err := s.createPartition(ctx, tx, name); err != nil {
+			return fmt.Errorf("partition logs: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO `+partitionTable(name)+` BY NAME
+			SELECT * FROM logs WHERE timestamp >= ? AND timestamp < ?`, day, day.AddDate(0, 0, 1)); err != nil {
+			return fmt.Errorf("partition logs: copy %s: %w", name, err)
+		}
+		partitions[name] = true
+	}
+
+	if _, err := tx.ExecContext(ctx, `DROP TABLE logs`); err != nil {
+		return fmt.Errorf("partition logs: %w", err)
+	}
+	if err := s.replaceLogsView(ctx, tx, partitions); err != nil {
+		return fmt.Errorf("partition logs: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.partitions = partitions
+	return nil
+}
+
+// Partitioned reports whether logs is stored as day partitions.
+func (s *Store) Partitioned() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.partitions != nil
+}
+
+// loadPartitions reads the day partitions of a partitioned database; it
+// leaves s.partitions nil when logs is a plain table.
+func (s *Store) loadPartitions(ctx context.Context) error {
+	var views int
+	if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM duckdb_views()
+		WHERE schema_name = 'main' AND view_name = 'logs'`).Scan(&views); err != nil {
+		return err
+	}
+	if views == 0 {
+		s.partitions = nil
+		return nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT table_name FROM duckdb_tables()
+		WHERE schema_name = ? AND table_name <> ?`, partitionSchema, partitionBase)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	partitions := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		partitions[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	s.partitions = partitions
+	return nil
+}
+
+// partitionFor returns the partition a record with timestamp ts belongs to.
+func partitionFor(ts time.Time) string {
+	return partitionName(ts.UTC())
+}
+
+func partitionName(day time.Time) string {
+	return "d" + day.Format(partitionDayLayout)
+}
+
+func partitionTable(name string) string {
+	return partitionSchema + "." + name
+}
+
+// sortedPartitions returns partition names oldest first.
+func sortedPartitions(partitions map[string]bool) []string {
+	names := make([]string, 0, len(partitions))
+	for name := range partitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// logTables returns the tables holding logs rows: the logs table, or the
+// base and day partitions of a partitioned store.
+func (s *Store) logTables() []string {
+	if s.partitions == nil {
+		return []string{"logs"}
+	}
+	tables := []string{partitionTable(partitionBase)}
+	for _, name := range sortedPartitions(s.partitions) {
+		tables = append(tables, partitionTable(name))
+	}
+	return tables
+}
+
+// partitionDDL returns the column list of a new partition table.
+func (s *Store) partitionDDL() string {
+	return "(" + partitionColumns + s.promotedColumnsDDL() + "\n)"
+}
+
+// createPartition creates an empty day partition with the event_id index
+// the logs table has.
+func (s *Store) createPartition(ctx context.Context, tx *sql.Tx, name string) error {
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE `+partitionTable(name)+` `+s.partitionDDL()); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `CREATE UNIQUE INDEX idx_`+name+`_event_id ON `+partitionTable(name)+`(event_id)`)
+	return err
+}
+
+// replaceLogsView points the logs view at the given partitions and the
+// rotated files and archived days, if any.
+func (s *Store) replaceLogsView(ctx context.Context, tx *sql.Tx, partitions map[string]bool) error {
+	selects := []string{`SELECT * FROM ` + partitionTable(partitionBase)}
+	for _, name := range sortedPartitions(partitions) {
+		selects = append(selects, `SELECT * FROM `+partitionTable(name))
+	}
+	// Rotated files, like archived days, lack columns promoted later.
+	selects = append(selects, s.coldSelects()...)
+	// By name: columns added to older partitions by ALTER follow the
+	// promoted columns, while newer partitions list them first.
+	view := strings.Join(selects, " UNION ALL BY NAME ")
+	if archive := s.archiveSelect(); archive != "" {
+		// Archived days written before a column was promoted lack it.
+		view += " UNION ALL BY NAME " + archive
+	}
+	_, err := tx.ExecContext(ctx, `CREATE OR REPLACE VIEW logs AS `+view)
+	return err
+}
+
+// ensurePartitions creates the partitions records need that do not exist
+// yet and returns their names; the caller adds them to s.partitions once
+// tx commits.
+func (s *Store) ensurePartitions(ctx context.Context, tx *sql.Tx, records []*LogRecord) ([]string, error) {
+	var created []string
+	seen := make(map[string]bool)
+	for _, r := range records {
+		name := partitionFor(r.Timestamp)
+		if s.partitions[name] || seen[name] {
+			continue
+		}
+		if err := s.createPartition(ctx, tx, name); err != nil {
+			return nil, fmt.Errorf("create partition %s: %w", name, err)
+		}
+		seen[name] = true
+		created = append(created, name)
+	}
+	if len(created) == 0 {
+		return nil, nil
+	}
+	all := make(map[string]bool, len(s.partitions)+len(created))
+	for name := range s.partitions {
+		all[name] = true
+	}
+	for _, name := range created {
+		all[name] = true
+	}
+	if err := s.replaceLogsView(ctx, tx, all); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// deletePartitionsBefore drops the partitions, rotated files, and archived
+// days wholly before cutoff and deletes the older rows of the day cutoff
+// falls in. A rotated file goes once its last day is past.
+func (s *Store) deletePartitionsBefore(ctx context.Context, tx *sql.Tx, cutoff time.Time) (int64, error) {
+	cutoffDay := partitionFor(cutoff)
+	deleted, err := s.deleteArchiveBefore(ctx, tx, cutoffDay)
+	if err != nil {
+		return 0, err
+	}
+	expired, err := s.deleteColdBefore(ctx, tx, cutoffDay)
+	if err != nil {
+		return 0, err
+	}
+	deleted += expired
+	if s.archiveDir != "" {
+		// Repoint the view before anything reads logs: it must not name
+		// an archive glob that no longer matches any file.
+		if err := s.afterDrop(ctx, tx, nil); err != nil {
+			return 0, err
+		}
+	}
+	var dropped []string
+	for _, name := range sortedPartitions(s.partitions) {
+		if name > cutoffDay {
+			break
+		}
+		if name < cutoffDay {
+			n, err := dropPartition(ctx, tx, name)
+			if err != nil {
+				return 0, err
+			}
+			deleted += n
+			dropped = append(dropped, name)
+			continue
+		}
+		result, err := tx.ExecContext(ctx, `DELETE FROM `+partitionTable(name)+` WHERE timestamp < ?`, cutoff)
+		if err != nil {
+			return 0, err
+		}
+		n, _ := result.RowsAffected()
+		deleted += n
+	}
+	return deleted, s.afterDrop(ctx, tx, dropped)
+}
+
+// deleteOldestPartitioned drops whole rotated files, then whole partitions,
+// oldest first, while they fit in n, then deletes the oldest rows of the
+// next partition. It stops short of n at a rotated file that does not fit.
+func (s *Store) deleteOldestPartitioned(ctx context.Context, tx *sql.Tx, n int64) (int64, error) {
+	deleted, all, err := s.deleteOldestCold(ctx, tx, n)
+	if err != nil || !all {
+		return deleted, err
+	}
+	var dropped []string
+	for _, name := range sortedPartitions(s.partitions) {
+		if deleted >= n {
+			break
+		}
+		var count int64
+		if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM `+partitionTable(name)).Scan(&count); err != nil {
+			return 0, err
+		}
+		if count <= n-deleted {
+			if _, err := dropPartition(ctx, tx, name); err != nil {
+				return 0, err
+			}
+			deleted += count
+			dropped = append(dropped, name)
+			continue
+		}
+		table := partitionTable(name)
+		result, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE id IN (SELECT id FROM `+table+` ORDER BY timestamp, id LIMIT ?)`, n-deleted)
+		if err != nil {
+			return 0, err
+		}
+		rows, _ := result.RowsAffected()
+		deleted += rows
+	}
+	return deleted, s.afterDrop(ctx, tx, dropped)
+}
+
+// dropPartition drops a day partition and returns how many rows it held.
+func dropPartition(ctx context.Context, tx *sql.Tx, name string) (int64, error) {
+	var count int64
+	if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM `+partitionTable(name)).Scan(&count); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DROP TABLE `+partitionTable(name)); err != nil {
+		return 0, fmt.Errorf("drop partition %s: %w", name, err)
+	}
+	return count, nil
+}
+
+// afterDrop repoints the logs view past dropped partitions. With an
+// archive, it always repoints, since archived files may have changed.
+func (s *Store) afterDrop(ctx context.Context, tx *sql.Tx, dropped []string) error {
+	if len(dropped) == 0 && s.archiveDir == "" {
+		return nil
+	}
+	remaining := make(map[string]bool, len(s.partitions))
+	for name := range s.partitions {
+		remaining[name] = true
+	}
+	for _, name := range dropped {
+		delete(remaining, name)
+	}
+	return s.replaceLogsView(ctx, tx, remaining)
+}
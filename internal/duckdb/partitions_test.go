@@ -0,0 +1,122 @@
+package duckdb
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func partitionNames(t *testing.T, store *Store) []string {
+	t.Helper()
+	rows, err := store.db.Query(`SELECT table_name FROM duckdb_tables() WHERE schema_name = ? AND table_name <> ? ORDER BY table_name`, partitionSchema, partitionBase)
+	if err != nil {
+		t.Fatalf("list partitions: %v", err)
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("scan partition: %v", err)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestEnableDayPartitions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.duckdb")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	day := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: day.AddDate(0, 0, -2), Level: "INFO", Message: "two days ago", EventID: "a"},
+		{Timestamp: day.AddDate(0, 0, -1), Level: "WARN", Message: "yesterday", EventID: "b"},
+		{Timestamp: day, Level: "ERROR", Message: "today", EventID: "c"},
+	})
+
+	if err := store.EnableDayPartitions(); err != nil {
+		t.Fatalf("EnableDayPartitions: %v", err)
+	}
+	if !store.Partitioned() {
+		t.Fatal("Partitioned() = false after EnableDayPartitions")
+	}
+	if got, want := partitionNames(t, store), []string{"d20260308", "d20260309", "d20260310"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("partitions = %v, want %v", got, want)
+	}
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 3 {
+		t.Fatalf("TotalLogCount = %d, %v; want 3", count, err)
+	}
+	if count, err := store.TotalLogCount(QueryOpts{From: day.Add(-12 * time.Hour)}); err != nil || count != 1 {
+		t.Fatalf("TotalLogCount(from today) = %d, %v; want 1", count, err)
+	}
+
+	// New days get a partition; event_id stays unique within a day.
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: day.AddDate(0, 0, 1), Level: "INFO", Message: "tomorrow", EventID: "d"},
+		{Timestamp: day, Level: "ERROR", Message: "today again", EventID: "c"},
+	})
+	if got := partitionNames(t, store); len(got) != 4 || got[3] != "d20260311" {
+		t.Fatalf("partitions after insert = %v", got)
+	}
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 4 {
+		t.Fatalf("TotalLogCount after insert = %d, %v; want 4 (duplicate event_id dropped)", count, err)
+	}
+	store.Close()
+
+	// Reopening finds the partitions without converting again.
+	store, err = NewStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer store.Close()
+	if !store.Partitioned() {
+		t.Fatal("reopened store is not partitioned")
+	}
+	logs, err := store.SearchLogs("tomorrow", 10, QueryOpts{})
+	if err != nil || len(logs) != 1 {
+		t.Fatalf("SearchLogs = %d, %v; want 1", len(logs), err)
+	}
+}
+
+func TestDayPartitionsRetention(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.EnableDayPartitions(); err != nil {
+		t.Fatalf("EnableDayPartitions: %v", err)
+	}
+
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: day.Add(1 * time.Hour), Level: "INFO", Message: "day 1 early"},
+		{Timestamp: day.Add(2 * time.Hour), Level: "INFO", Message: "day 1 late"},
+		{Timestamp: day.Add(25 * time.Hour), Level: "INFO", Message: "day 2 early"},
+		{Timestamp: day.Add(30 * time.Hour), Level: "INFO", Message: "day 2 late"},
+		{Timestamp: day.Add(49 * time.Hour), Level: "INFO", Message: "day 3"},
+	})
+
+	// Day 1 is dropped whole; day 2 loses its early row.
+	deleted, err := store.DeleteBefore(day.Add(26 * time.Hour))
+	if err != nil || deleted != 3 {
+		t.Fatalf("DeleteBefore = %d, %v; want 3", deleted, err)
+	}
+	if got, want := partitionNames(t, store), []string{"d20260311", "d20260312"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("partitions = %v, want %v", got, want)
+	}
+
+	// Day 2 fits in the limit and is dropped; day 3 is untouched.
+	deleted, err = store.DeleteOldest(1)
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeleteOldest = %d, %v; want 1", deleted, err)
+	}
+	if got, want := partitionNames(t, store), []string{"d20260312"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("partitions = %v, want %v", got, want)
+	}
+	logs, err := store.RecentLogsFiltered(10, QueryOpts{}, nil, nil, "")
+	if err != nil || len(logs) != 1 || logs[0].Message != "day 3" {
+		t.Fatalf("remaining logs = %+v, %v", logs, err)
+	}
+}
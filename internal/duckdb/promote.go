@@ -0,0 +1,218 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Promoted attribute types and the column types they are stored as.
+var promotedTypes = map[string]string{
+	"string":  "VARCHAR",
+	"integer": "BIGINT",
+	"float":   "DOUBLE",
+	"boolean": "BOOLEAN",
+}
+
+// PromotedAttribute is an attribute key copied into its own typed column
+// of logs at insert time.
+type PromotedAttribute struct {
+	Key string
+	// Type is "string" (default), "integer", "float", or "boolean".
+	Type string
+}
+
+// promotedColumn is a promoted attribute as stored in promoted_attributes.
+type promotedColumn struct {
+	key      string
+	column   string
+	dataType string // DuckDB column type
+}
+
+// PromotedColumn returns the logs column an attribute key is promoted to:
+// "attr_" followed by the key lowercased, with every character other than
+// a letter or digit replaced by "_" (http.status_code → attr_http_status_code).
+func PromotedColumn(key string) string {
+	var b strings.Builder
+	b.WriteString("attr_")
+	for _, r := range strings.ToLower(key) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// PromoteAttributes adds a typed column to logs for each attribute key not
+// yet promoted and fills it from the attributes of existing rows. From then
+// on every insert sets the column too, so queries can filter and aggregate
+// on it without casting the attributes JSON. Values that do not parse as
+// the column's type are stored as NULL; attributes keeps every value.
+// Promoted columns are never dropped; keys promoted earlier keep being
+// filled even when they are no longer passed here. Promoting a key again
+// with a different type is an error.
+func (s *Store) PromoteAttributes(attrs []PromotedAttribute) error {
+	ctx := context.Background()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing := make(map[string]promotedColumn, len(s.promoted))
+	columns := make(map[string]string, len(s.promoted))
+	for _, p := range s.promoted {
+		existing[p.key] = p
+		columns[p.column] = p.key
+	}
+
+	var added []promotedColumn
+	for _, a := range attrs {
+		typ := strings.ToLower(strings.TrimSpace(a.Type))
+		if typ == "" {
+			typ = "string"
+		}
+		dataType, ok := promotedTypes[typ]
+		if !ok {
+			return fmt.Errorf("promote attribute %q: unknown type %q (want string, integer, float, or boolean)", a.Key, a.Type)
+		}
+		if a.Key == "" {
+			return fmt.Errorf("promote attribute: empty key")
+		}
+		if p, ok := existing[a.Key]; ok {
+			if p.dataType != dataType {
+				return fmt.Errorf("promote attribute %q: already promoted to %s %s", a.Key, p.column, p.dataType)
+			}
+			continue
+		}
+		column := PromotedColumn(a.Key)
+		if other, ok := columns[column]; ok {
+			return fmt.Errorf("promote attribute %q: column %s is already used by %q", a.Key, column, other)
+		}
+		p := promotedColumn{key: a.Key, column: column, dataType: dataType}
+		existing[a.Key] = p
+		columns[column] = a.Key
+		added = append(added, p)
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	tables := s.logTables()
+
+	// DuckDB cannot update a table in the transaction that altered it, so
+	// the columns are added first and filled in a second transaction.
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for i, p := range added {
+		for _, table := range tables {
+			if _, err := tx.ExecContext(ctx, `ALTER TABLE `+table+` ADD COLUMN `+p.column+` `+p.dataType); err != nil {
+				return fmt.Errorf("promote attribute %q: %w", p.key, err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO promoted_attributes (key, column_name, data_type, position) VALUES (?, ?, ?, ?)`,
+			p.key, p.column, p.dataType, len(s.promoted)+i); err != nil {
+			return fmt.Errorf("promote attribute %q: %w", p.key, err)
+		}
+	}
+	if s.partitions != nil {
+		if err := s.replaceLogsView(ctx, tx, s.partitions); err != nil {
+			return fmt.Errorf("promote attributes: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.promoted = append(s.promoted, added...)
+
+	sets := make([]string, len(added))
+	args := make([]interface{}, len(added))
+	for i, p := range added {
+		sets[i] = p.column + ` = TRY_CAST(json_extract_string(attributes, ?) AS ` + p.dataType + `)`
+		args[i] = p.key
+	}
+	backfill, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer backfill.Rollback()
+	for _, table := range tables {
+		if _, err := backfill.ExecContext(ctx, `UPDATE `+table+` SET `+strings.Join(sets, ", ")+` WHERE attributes IS NOT NULL`, args...); err != nil {
+			return fmt.Errorf("promote attributes: backfill %s: %w", table, err)
+		}
+	}
+	return backfill.Commit()
+}
+
+// loadPromoted reads the promoted attributes, in column order.
+func (s *Store) loadPromoted(ctx context.Context) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT key, column_name, data_type FROM promoted_attributes ORDER BY position`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var promoted []promotedColumn
+	for rows.Next() {
+		var p promotedColumn
+		if err := rows.Scan(&p.key, &p.column, &p.dataType); err != nil {
+			return err
+		}
+		promoted = append(promoted, p)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	s.promoted = promoted
+	return nil
+}
+
+// promotedColumnsDDL returns the column definitions of the promoted
+// attributes, for tables created after they were promoted.
+func (s *Store) promotedColumnsDDL() string {
+	var b strings.Builder
+	for _, p := range s.promoted {
+		b.WriteString(",\n\t" + p.column + " " + p.dataType)
+	}
+	return b.String()
+}
+
+// promotedValue converts an attribute value to its column's type; a
+// missing or unparsable value is NULL.
+func promotedValue(p promotedColumn, attrs map[string]string) driver.Value {
+	v, ok := attrs[p.key]
+	if !ok {
+		return nil
+	}
+	v = strings.TrimSpace(v)
+	switch p.dataType {
+	case "BIGINT":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "DOUBLE":
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	case "BOOLEAN":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	default:
+		return attrs[p.key]
+	}
+	return nil
+}
+
+// promotedNames returns the column names of the promoted attributes.
+func promotedNames(promoted []promotedColumn) []string {
+	names := make([]string, len(promoted))
+	for i, p := range promoted {
+		names[i] = p.column
+	}
+	return names
+}
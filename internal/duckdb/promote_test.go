@@ -0,0 +1,152 @@
+package duckdb
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPromoteAttributes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.duckdb")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	now := time.Now()
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: now, Level: "INFO", Message: "ok", Attributes: map[string]string{"http.status_code": "200", "tenant.id": "acme"}},
+		{Timestamp: now, Level: "WARN", Message: "odd", Attributes: map[string]string{"http.status_code": "n/a"}},
+	})
+
+	attrs := []PromotedAttribute{
+		{Key: "http.status_code", Type: "integer"},
+		{Key: "tenant.id"},
+	}
+	if err := store.PromoteAttributes(attrs); err != nil {
+		t.Fatalf("PromoteAttributes: %v", err)
+	}
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: now, Level: "ERROR", Message: "boom", Attributes: map[string]string{"http.status_code": "503", "tenant.id": "acme"}},
+	})
+
+	// Existing rows are backfilled, new rows filled on insert; values that
+	// do not parse are NULL.
+	var total, sum int64
+	var tenants int64
+	if err := store.db.QueryRow(`SELECT count(attr_http_status_code), sum(attr_http_status_code), count(*) FILTER (WHERE attr_tenant_id = 'acme') FROM logs`).Scan(&total, &sum, &tenants); err != nil {
+		t.Fatalf("query promoted columns: %v", err)
+	}
+	if total != 2 || sum != 703 || tenants != 2 {
+		t.Errorf("promoted columns: count=%d sum=%d tenants=%d, want 2, 703, 2", total, sum, tenants)
+	}
+	if desc := store.GetSchemaDescription(); !strings.Contains(desc, `attr_http_status_code (BIGINT, attribute "http.status_code")`) {
+		t.Errorf("schema description does not list promoted column: %s", desc)
+	}
+
+	// Promoting again is a no-op; changing the type is an error.
+	if err := store.PromoteAttributes(attrs); err != nil {
+		t.Errorf("PromoteAttributes again: %v", err)
+	}
+	if err := store.PromoteAttributes([]PromotedAttribute{{Key: "http.status_code", Type: "string"}}); err == nil {
+		t.Error("PromoteAttributes with a new type succeeded")
+	}
+	if err := store.PromoteAttributes([]PromotedAttribute{{Key: "http_status.code"}}); err == nil {
+		t.Error("PromoteAttributes with a colliding column succeeded")
+	}
+	if err := store.PromoteAttributes([]PromotedAttribute{{Key: "x", Type: "date"}}); err == nil {
+		t.Error("PromoteAttributes with an unknown type succeeded")
+	}
+	store.Close()
+
+	// Promoted columns are filled after reopening without promoting again.
+	store, err = NewStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer store.Close()
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: now, Level: "INFO", Message: "later", Attributes: map[string]string{"http.status_code": "404"}},
+	})
+	var status int64
+	if err := store.db.QueryRow(`SELECT attr_http_status_code FROM logs WHERE message = 'later'`).Scan(&status); err != nil || status != 404 {
+		t.Errorf("attr_http_status_code after reopen = %d, %v; want 404", status, err)
+	}
+
+	// Facets read a string column when promoted, the attributes otherwise.
+	if logs, err := store.RecentLogsFiltered(10, QueryOpts{}, nil, map[string]string{"tenant.id": "acme"}, ""); err != nil || len(logs) != 2 {
+		t.Errorf("RecentLogsFiltered(tenant.id=acme) = %d logs, %v; want 2", len(logs), err)
+	}
+	if logs, err := store.RecentLogsFiltered(10, QueryOpts{}, nil, map[string]string{"http.status_code": "404"}, ""); err != nil || len(logs) != 1 || logs[0].Message != "later" {
+		t.Errorf("RecentLogsFiltered(http.status_code=404) = %+v, %v; want the later log", logs, err)
+	}
+}
+
+func TestPromoteAttributesPartitioned(t *testing.T) {
+	store := newTestStore(t)
+	day := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: day, Level: "INFO", Message: "before", Attributes: map[string]string{"latency": "1.5"}},
+	})
+	if err := store.EnableDayPartitions(); err != nil {
+		t.Fatalf("EnableDayPartitions: %v", err)
+	}
+	if err := store.PromoteAttributes([]PromotedAttribute{{Key: "latency", Type: "float"}}); err != nil {
+		t.Fatalf("PromoteAttributes: %v", err)
+	}
+
+	// A day created after promotion gets the column too.
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: day.AddDate(0, 0, 1), Level: "INFO", Message: "after", Attributes: map[string]string{"latency": "2.5"}},
+	})
+	var sum float64
+	if err := store.db.QueryRow(`SELECT sum(attr_latency) FROM logs`).Scan(&sum); err != nil || sum != 4 {
+		t.Errorf("sum(attr_latency) = %v, %v; want 4", sum, err)
+	}
+}
+
+func TestPromotedColumn(t *testing.T) {
+	for key, want := range map[string]string{
+		"http.status_code": "attr_http_status_code",
+		"Tenant-ID":        "attr_tenant_id",
+		"k8s/pod name":     "attr_k8s_pod_name",
+	} {
+		if got := PromotedColumn(key); got != want {
+			t.Errorf("PromotedColumn(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestDistinctAttributeValues(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+	var records []*LogRecord
+	for i, method := range []string{"GET", "GET", "get", "POST", "PUT", "PATCH", ""} {
+		records = append(records, &LogRecord{Timestamp: now.Add(time.Duration(i) * time.Second), Level: "INFO", Message: "req",
+			Attributes: map[string]string{"http.method": method}})
+	}
+	insertTestRecords(t, store, records)
+
+	check := func(stage string) {
+		t.Helper()
+		got, err := store.DistinctAttributeValues("http.method", "p", 10, QueryOpts{})
+		if err != nil {
+			t.Fatalf("%s: DistinctAttributeValues: %v", stage, err)
+		}
+		want := []DimensionCount{{Value: "PATCH", Count: 1}, {Value: "POST", Count: 1}, {Value: "PUT", Count: 1}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: values = %+v, want %+v", stage, got, want)
+		}
+		got, err = store.DistinctAttributeValues("http.method", "", 1, QueryOpts{})
+		if err != nil || len(got) != 1 || got[0] != (DimensionCount{Value: "GET", Count: 2}) {
+			t.Errorf("%s: top value = %+v, %v; want GET x2", stage, got, err)
+		}
+	}
+	check("attributes JSON")
+	if err := store.PromoteAttributes([]PromotedAttribute{{Key: "http.method"}}); err != nil {
+		t.Fatalf("PromoteAttributes: %v", err)
+	}
+	check("promoted column")
+}
@@ -4,15 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log"
 	"regexp"
 	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb/migrate"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 )
 
-// ErrTooManyConcurrentQueries is returned when the query concurrency gate is full.
-var ErrTooManyConcurrentQueries = errors.New("too many concurrent queries")
+// ErrOverloaded is returned when the query scheduler sheds a read query.
+var ErrOverloaded = model.ErrOverloaded
 
 // dangerousKeywordPattern matches dangerous SQL keywords at word boundaries.
 // This avoids false positives like "RESET" matching "SET".
@@ -40,55 +43,76 @@ func stripSQLComments(query string) string {
 	return result.String()
 }
 
-// queryCtx returns a context with the store's configured query timeout.
-func (s *Store) queryCtx() (context.Context, context.CancelFunc) {
+// queryCtx returns a context with the store's configured query timeout,
+// once the query scheduler admits a query of priority p; time spent queued
+// counts against the timeout. It returns ErrOverloaded when the query is
+// shed. Callers take s.mu after it, so queued readers do not delay writes.
+func (s *Store) queryCtx(p queryPriority) (context.Context, context.CancelFunc, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.QueryTimeout)
 
-	// Fast-fail when read concurrency is saturated.
-	// This avoids piling up waiting readers that could delay writes under load.
-	if s.querySlots == nil {
-		return ctx, cancel
+	sched := s.queries.Load()
+	if sched == nil {
+		return ctx, cancel, nil
 	}
-	select {
-	case s.querySlots <- struct{}{}:
-		return ctx, func() {
-			<-s.querySlots
-			cancel()
-		}
-	default:
+	if err := sched.acquire(ctx, p); err != nil {
 		cancel()
-		deniedCtx, deniedCancel := context.WithCancel(context.Background())
-		deniedCancel()
-		return deniedCtx, func() {}
+		return nil, nil, err
 	}
+	return ctx, func() {
+		sched.release(p)
+		cancel()
+	}, nil
 }
 
-// appFilter returns a WHERE clause and args when opts.App is non-empty.
-func appFilter(opts QueryOpts) (clause string, args []interface{}) {
+// scopeConditions returns the predicates and args for the app and time
+// range in opts.
+func scopeConditions(opts QueryOpts) (conditions []string, args []interface{}) {
 	if opts.App != "" {
-		return "WHERE app = ?", []interface{}{opts.App}
+		conditions = append(conditions, "app = ?")
+		args = append(args, opts.App)
+	}
+	if !opts.From.IsZero() {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, opts.From)
+	}
+	if !opts.To.IsZero() {
+		conditions = append(conditions, "timestamp < ?")
+		args = append(args, opts.To)
+	}
+	return conditions, args
+}
+
+// scopeFilter returns a WHERE clause and args when opts narrows the query.
+func scopeFilter(opts QueryOpts) (clause string, args []interface{}) {
+	conditions, args := scopeConditions(opts)
+	if len(conditions) == 0 {
+		return "", nil
 	}
-	return "", nil
+	return "WHERE " + strings.Join(conditions, " AND "), args
 }
 
-// appAnd returns an "AND app = ?" fragment and args when opts.App is non-empty.
+// scopeAnd returns an "AND ..." fragment and args when opts narrows the query.
 // Use this when there is already a WHERE clause.
-func appAnd(opts QueryOpts) (clause string, args []interface{}) {
-	if opts.App != "" {
-		return " AND app = ?", []interface{}{opts.App}
+func scopeAnd(opts QueryOpts) (clause string, args []interface{}) {
+	conditions, args := scopeConditions(opts)
+	if len(conditions) == 0 {
+		return "", nil
 	}
-	return "", nil
+	return " AND " + strings.Join(conditions, " AND "), args
 }
 
 // TopWords returns the most frequent words.
 func (s *Store) TopWords(limit int, opts QueryOpts) ([]WordCount, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
-
-	where, wArgs := appFilter(opts)
+	where, wArgs := scopeFilter(opts)
 	query := fmt.Sprintf(`
 		WITH words AS (
 			SELECT regexp_replace(
@@ -126,13 +150,16 @@ func (s *Store) TopWords(limit int, opts QueryOpts) ([]WordCount, error) {
 
 // TopAttributes returns the most frequent attribute key-value pairs.
 func (s *Store) TopAttributes(limit int, opts QueryOpts) ([]AttributeStat, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
-
-	where, wArgs := appFilter(opts)
+	where, wArgs := scopeFilter(opts)
 	query := fmt.Sprintf(`
 		WITH attrs AS (
 			SELECT
@@ -168,13 +195,16 @@ func (s *Store) TopAttributes(limit int, opts QueryOpts) ([]AttributeStat, error
 
 // TopAttributeKeys returns attribute keys sorted by number of unique values.
 func (s *Store) TopAttributeKeys(limit int, opts QueryOpts) ([]AttributeKeyStat, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
-
-	where, wArgs := appFilter(opts)
+	where, wArgs := scopeFilter(opts)
 	query := fmt.Sprintf(`
 		WITH attrs AS (
 			SELECT
@@ -209,25 +239,33 @@ func (s *Store) TopAttributeKeys(limit int, opts QueryOpts) ([]AttributeKeyStat,
 }
 
 // AttributeKeyValues returns value counts for a specific attribute key.
-func (s *Store) AttributeKeyValues(key string, limit int) (map[string]int64, error) {
+func (s *Store) AttributeKeyValues(key string, limit int, opts QueryOpts) (map[string]int64, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
+	where, args := scopeFilter(opts)
+	args = append(args, key, limit)
+
 	rows, err := s.db.QueryContext(ctx, `
 		WITH attrs AS (
 			SELECT
 				unnest(map_keys(CAST(attributes AS MAP(VARCHAR, VARCHAR)))) AS attr_key,
 				unnest(map_values(CAST(attributes AS MAP(VARCHAR, VARCHAR)))) AS attr_value
 			FROM logs
+			`+where+`
 		)
 		SELECT attr_value, COUNT(*) AS count
 		FROM attrs
 		WHERE attr_key = ?
 		GROUP BY attr_value
 		ORDER BY count DESC, attr_value ASC
-		LIMIT ?`, key, limit)
+		LIMIT ?`, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -246,16 +284,67 @@ func (s *Store) AttributeKeyValues(key string, limit int) (map[string]int64, err
 	return result, rows.Err()
 }
 
-// SeverityCounts returns the total count per severity level.
-func (s *Store) SeverityCounts(opts QueryOpts) (map[string]int64, error) {
+// DistinctAttributeValues returns the most frequent values of attribute key
+// that start with prefix, ignoring case, for autocompletion. Empty values are
+// skipped. A key promoted as a string reads its column instead of parsing
+// the attributes JSON; typed columns would drop values that did not parse.
+func (s *Store) DistinctAttributeValues(key, prefix string, limit int, opts QueryOpts) ([]DimensionCount, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
+	value, args := "attributes->>?", []interface{}{key}
+	for _, p := range s.promoted {
+		if p.key == key && p.dataType == "VARCHAR" {
+			value, args = p.column, nil
+			break
+		}
+	}
+	where, wArgs := scopeFilter(opts)
+	args = append(append(args, wArgs...), prefix, limit)
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT value, COUNT(*) AS count
+		FROM (SELECT %s AS value FROM logs %s)
+		WHERE value != '' AND starts_with(lower(value), lower(?))
+		GROUP BY value
+		ORDER BY count DESC, value ASC
+		LIMIT ?`, value, where), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []DimensionCount
+	for rows.Next() {
+		var item DimensionCount
+		if err := rows.Scan(&item.Value, &item.Count); err != nil {
+			log.Printf("duckdb scan error (DistinctAttributeValues): %v", err)
+			continue
+		}
+		results = append(results, item)
+	}
+	return results, rows.Err()
+}
+
+// SeverityCounts returns the total count per severity level.
+func (s *Store) SeverityCounts(opts QueryOpts) (map[string]int64, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
 	defer cancel()
 
-	where, wArgs := appFilter(opts)
-	query := fmt.Sprintf(`SELECT level, COUNT(*) FROM logs %s GROUP BY level`, where)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	source, wArgs := severitySource(opts)
+	query := fmt.Sprintf(`SELECT level, SUM(count)::BIGINT FROM %s GROUP BY level`, source)
 
 	rows, err := s.db.QueryContext(ctx, query, wArgs...)
 	if err != nil {
@@ -278,24 +367,27 @@ func (s *Store) SeverityCounts(opts QueryOpts) (map[string]int64, error) {
 
 // SeverityCountsByMinute returns per-minute severity breakdowns for all logs.
 func (s *Store) SeverityCountsByMinute(opts QueryOpts) ([]MinuteCounts, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
-
-	where, wArgs := appFilter(opts)
+	source, wArgs := severitySource(opts)
 	query := fmt.Sprintf(`
-		SELECT date_trunc('minute', timestamp) as minute,
-			SUM(CASE WHEN level='TRACE' THEN 1 ELSE 0 END) as trace,
-			SUM(CASE WHEN level='DEBUG' THEN 1 ELSE 0 END) as debug,
-			SUM(CASE WHEN level='INFO' THEN 1 ELSE 0 END) as info,
-			SUM(CASE WHEN level='WARN' THEN 1 ELSE 0 END) as warn,
-			SUM(CASE WHEN level='ERROR' THEN 1 ELSE 0 END) as error,
-			SUM(CASE WHEN level='FATAL' THEN 1 ELSE 0 END) as fatal,
-			COUNT(*) as total
-		FROM logs %s
-		GROUP BY minute ORDER BY minute`, where)
+		SELECT minute,
+			SUM(CASE WHEN level='TRACE' THEN count ELSE 0 END)::BIGINT as trace,
+			SUM(CASE WHEN level='DEBUG' THEN count ELSE 0 END)::BIGINT as debug,
+			SUM(CASE WHEN level='INFO' THEN count ELSE 0 END)::BIGINT as info,
+			SUM(CASE WHEN level='WARN' THEN count ELSE 0 END)::BIGINT as warn,
+			SUM(CASE WHEN level='ERROR' THEN count ELSE 0 END)::BIGINT as error,
+			SUM(CASE WHEN level='FATAL' THEN count ELSE 0 END)::BIGINT as fatal,
+			SUM(count)::BIGINT as total
+		FROM %s
+		GROUP BY minute ORDER BY minute`, source)
 
 	rows, err := s.db.QueryContext(ctx, query, wArgs...)
 	if err != nil {
@@ -315,47 +407,72 @@ func (s *Store) SeverityCountsByMinute(opts QueryOpts) ([]MinuteCounts, error) {
 	return results, rows.Err()
 }
 
-// TotalLogCount returns the total number of logs in the database.
+// TotalLogCount returns the total number of logs in the database. Without
+// a time range it answers from the running totals.
 func (s *Store) TotalLogCount(opts QueryOpts) (int64, error) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
+	t, ok := s.cachedTotals(opts)
+	s.mu.RUnlock()
+	if ok {
+		return t.count, nil
+	}
 
-	ctx, cancel := s.queryCtx()
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return 0, err
+	}
 	defer cancel()
 
-	where, wArgs := appFilter(opts)
-	query := fmt.Sprintf(`SELECT COUNT(*) FROM logs %s`, where)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	source, wArgs := rollupSource(opts)
+	query := fmt.Sprintf(`SELECT COALESCE(SUM(count), 0)::BIGINT FROM %s`, source)
 
 	var count int64
-	err := s.db.QueryRowContext(ctx, query, wArgs...).Scan(&count)
+	err = s.db.QueryRowContext(ctx, query, wArgs...).Scan(&count)
 	return count, err
 }
 
-// TotalLogBytes returns the total raw-line bytes persisted in logs.
+// TotalLogBytes returns the total raw-line bytes persisted in logs. Without
+// a time range it answers from the running totals.
 func (s *Store) TotalLogBytes(opts QueryOpts) (int64, error) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
+	t, ok := s.cachedTotals(opts)
+	s.mu.RUnlock()
+	if ok {
+		return t.bytes, nil
+	}
 
-	ctx, cancel := s.queryCtx()
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return 0, err
+	}
 	defer cancel()
 
-	where, wArgs := appFilter(opts)
-	query := fmt.Sprintf(`SELECT COALESCE(SUM(length(raw_line)), 0) FROM logs %s`, where)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	source, wArgs := rollupSource(opts)
+	query := fmt.Sprintf(`SELECT COALESCE(SUM(bytes), 0)::BIGINT FROM %s`, source)
 
 	var total int64
-	err := s.db.QueryRowContext(ctx, query, wArgs...).Scan(&total)
+	err = s.db.QueryRowContext(ctx, query, wArgs...).Scan(&total)
 	return total, err
 }
 
 // TopHosts returns hostnames by descending log count.
 func (s *Store) TopHosts(limit int, opts QueryOpts) ([]DimensionCount, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
-
-	where, wArgs := appFilter(opts)
+	where, wArgs := scopeFilter(opts)
 	query := fmt.Sprintf(`
 		SELECT COALESCE(NULLIF(hostname, ''), 'unknown') AS host, COUNT(*) AS count
 		FROM logs %s
@@ -384,19 +501,22 @@ func (s *Store) TopHosts(limit int, opts QueryOpts) ([]DimensionCount, error) {
 
 // TopServices returns services by descending log count.
 func (s *Store) TopServices(limit int, opts QueryOpts) ([]DimensionCount, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
-
-	where, wArgs := appFilter(opts)
+	source, wArgs := rollupSource(opts)
 	query := fmt.Sprintf(`
-		SELECT COALESCE(NULLIF(service, ''), 'unknown') AS service, COUNT(*) AS count
-		FROM logs %s
-		GROUP BY service
+		SELECT COALESCE(NULLIF(service, ''), 'unknown') AS service, SUM(count)::BIGINT AS count
+		FROM %s
+		GROUP BY 1
 		ORDER BY count DESC, service ASC
-		LIMIT ?`, where)
+		LIMIT ?`, source)
 
 	args := append(wArgs, limit)
 	rows, err := s.db.QueryContext(ctx, query, args...)
@@ -419,23 +539,25 @@ func (s *Store) TopServices(limit int, opts QueryOpts) ([]DimensionCount, error)
 
 // TopServicesBySeverity returns the top services for a given severity level.
 func (s *Store) TopServicesBySeverity(severity string, limit int, opts QueryOpts) ([]DimensionCount, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
-
-	andApp, aArgs := appAnd(opts)
+	source, args := rollupSource(opts)
 	query := fmt.Sprintf(`
-		SELECT COALESCE(NULLIF(service, ''), 'unknown') AS svc, COUNT(*) AS count
-		FROM logs
-		WHERE level = ?%s
+		SELECT COALESCE(NULLIF(service, ''), 'unknown') AS svc, SUM(count)::BIGINT AS count
+		FROM %s
+		WHERE level = ?
 		GROUP BY svc
 		ORDER BY count DESC, svc ASC
-		LIMIT ?`, andApp)
+		LIMIT ?`, source)
 
-	args := append([]interface{}{severity}, aArgs...)
-	args = append(args, limit)
+	args = append(args, severity, limit)
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
@@ -454,13 +576,153 @@ func (s *Store) TopServicesBySeverity(severity string, limit int, opts QueryOpts
 	return results, rows.Err()
 }
 
+// rateColumns maps RateByDimension dimensions to logs columns.
+var rateColumns = map[string]string{"service": "service", "host": "hostname", "app": "app", "level": "level"}
+
+// RateByDimension returns log counts per step-wide bucket for each value of
+// dimension over the last window, optionally limited to severity levels.
+// Buckets are aligned to multiples of step since the Unix epoch; buckets
+// without logs are omitted. Whole-minute steps read the minute rollups,
+// except for host, which the rollups do not keep.
+func (s *Store) RateByDimension(dimension string, window, step time.Duration, severityLevels []string, opts QueryOpts) ([]DimensionRate, error) {
+	opts, err := model.RateScope(dimension, window, step, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	args := []interface{}{step.Microseconds()}
+	var source, timeColumn, count string
+	var conditions []string
+	if dimension != "host" && step%time.Minute == 0 {
+		var sourceArgs []interface{}
+		source, sourceArgs = rollupSource(opts)
+		args = append(args, sourceArgs...)
+		timeColumn, count = "minute", "SUM(count)"
+	} else {
+		var scopeArgs []interface{}
+		conditions, scopeArgs = scopeConditions(opts)
+		args = append(args, scopeArgs...)
+		source, timeColumn, count = "logs", "timestamp", "COUNT(*)"
+	}
+	if len(severityLevels) > 0 {
+		placeholders := make([]string, len(severityLevels))
+		for i, lvl := range severityLevels {
+			placeholders[i] = "?"
+			args = append(args, lvl)
+		}
+		conditions = append(conditions, "level IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT time_bucket(to_microseconds(?), %s, TIMESTAMP '1970-01-01') AS bucket,
+			COALESCE(NULLIF(%s, ''), 'unknown') AS value, %s::BIGINT AS count
+		FROM %s %s
+		GROUP BY bucket, value
+		ORDER BY bucket ASC, count DESC, value ASC`, timeColumn, rateColumns[dimension], count, source, where)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []DimensionRate
+	for rows.Next() {
+		var item DimensionRate
+		if err := rows.Scan(&item.Bucket, &item.Value, &item.Count); err != nil {
+			log.Printf("duckdb scan error (RateByDimension): %v", err)
+			continue
+		}
+		results = append(results, item)
+	}
+	return results, rows.Err()
+}
+
+// IngestRate returns the logs, and their raw-line length, per step-wide
+// bucket over the last window. Buckets are aligned to multiples of step
+// since the Unix epoch; buckets without logs are omitted. Whole-minute
+// steps read the minute rollups.
+func (s *Store) IngestRate(window, step time.Duration, opts QueryOpts) ([]model.IngestPoint, error) {
+	opts, err := model.IngestScope(window, step, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	args := []interface{}{step.Microseconds()}
+	var source, timeColumn, count, bytes string
+	if step%time.Minute == 0 {
+		var sourceArgs []interface{}
+		source, sourceArgs = rollupSource(opts)
+		args = append(args, sourceArgs...)
+		timeColumn, count, bytes = "minute", "SUM(count)", "SUM(bytes)"
+	} else {
+		conditions, scopeArgs := scopeConditions(opts)
+		args = append(args, scopeArgs...)
+		source = "logs"
+		if len(conditions) > 0 {
+			source += " WHERE " + strings.Join(conditions, " AND ")
+		}
+		timeColumn, count, bytes = "timestamp", "COUNT(*)", "COALESCE(SUM(length(COALESCE(raw_line, message))), 0)"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT time_bucket(to_microseconds(?), %s, TIMESTAMP '1970-01-01') AS bucket,
+			%s::BIGINT AS count, %s::BIGINT AS bytes
+		FROM %s
+		GROUP BY bucket
+		ORDER BY bucket ASC`, timeColumn, count, bytes, source)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.IngestPoint
+	for rows.Next() {
+		var item model.IngestPoint
+		if err := rows.Scan(&item.Bucket, &item.Count, &item.Bytes); err != nil {
+			log.Printf("duckdb scan error (IngestRate): %v", err)
+			continue
+		}
+		results = append(results, item)
+	}
+	return results, rows.Err()
+}
+
 // ListApps returns all distinct app names from the logs table.
 func (s *Store) ListApps() ([]string, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
 	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT app FROM logs ORDER BY app`)
 	if err != nil {
 		return nil, err
@@ -479,49 +741,56 @@ func (s *Store) ListApps() ([]string, error) {
 	return apps, rows.Err()
 }
 
-// ExecuteQuery runs a read-only SQL query and returns results as maps.
-// Only SELECT/WITH read queries are allowed; DDL/DML is rejected.
-func (s *Store) ExecuteQuery(query string) ([]map[string]interface{}, error) {
-	trimmed := strings.TrimSpace(query)
+// DefaultQueryMaxRows is how many rows ExecuteQuery returns at most.
+const DefaultQueryMaxRows = 1000
 
-	// Reject semicolons to prevent statement chaining.
-	if strings.Contains(trimmed, ";") {
-		return nil, fmt.Errorf("query must not contain semicolons")
-	}
-
-	// Strip SQL comments so keywords hidden in comments are still caught.
-	stripped := strings.TrimSpace(stripSQLComments(trimmed))
-	upper := strings.ToUpper(stripped)
+// ExecuteQuery runs a read-only SQL query and returns results as maps.
+// Only SELECT/WITH read queries are allowed; DDL/DML is rejected. args are
+// bound to the query's ? placeholders. Results stop at
+// DefaultQueryMaxRows rows.
+func (s *Store) ExecuteQuery(query string, args ...any) ([]map[string]interface{}, error) {
+	results, _, err := s.QueryRows(context.Background(), query, DefaultQueryMaxRows, args...)
+	return results, err
+}
 
-	if !strings.HasPrefix(upper, "SELECT") && !strings.HasPrefix(upper, "WITH") {
-		return nil, fmt.Errorf("only SELECT/WITH queries are allowed")
+// QueryRows runs a read-only query like ExecuteQuery, returning at most
+// maxRows rows and whether there were more. ctx can end the query before
+// the store's query timeout does.
+func (s *Store) QueryRows(ctx context.Context, query string, maxRows int, args ...any) ([]map[string]interface{}, bool, error) {
+	trimmed, err := validateReadOnlyQuery(query)
+	if err != nil {
+		return nil, false, err
 	}
 
-	// Defense-in-depth: reject dangerous keywords after comment stripping.
-	if match := dangerousKeywordPattern.FindString(stripped); match != "" {
-		return nil, fmt.Errorf("query contains disallowed keyword: %s", strings.ToUpper(match))
+	queryCtx, cancel, err := s.queryCtx(priorityBulk)
+	if err != nil {
+		return nil, false, err
 	}
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
 
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
-	rows, err := s.db.QueryContext(ctx, trimmed)
+	rows, err := s.db.QueryContext(queryCtx, trimmed, args...)
 	if err != nil {
-		return nil, err
+		return nil, false, contextErr(ctx, err)
 	}
 	defer rows.Close()
 
 	columns, err := rows.Columns()
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	var results []map[string]interface{}
-	maxRows := 1000
-
-	for rows.Next() && len(results) < maxRows {
+	truncated := false
+	for rows.Next() {
+		if len(results) == maxRows {
+			truncated = true
+			break
+		}
 		values := make([]interface{}, len(columns))
 		valuePtrs := make([]interface{}, len(columns))
 		for i := range values {
@@ -529,7 +798,7 @@ func (s *Store) ExecuteQuery(query string) ([]map[string]interface{}, error) {
 		}
 
 		if err := rows.Scan(valuePtrs...); err != nil {
-			log.Printf("duckdb scan error (ExecuteQuery): %v", err)
+			log.Printf("duckdb scan error (QueryRows): %v", err)
 			continue
 		}
 
@@ -540,26 +809,90 @@ func (s *Store) ExecuteQuery(query string) ([]map[string]interface{}, error) {
 		results = append(results, row)
 	}
 
-	return results, rows.Err()
+	return results, truncated, contextErr(ctx, rows.Err())
+}
+
+// contextErr reports a query stopped by the caller's ctx as ctx's error,
+// rather than the driver's.
+func contextErr(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// validateReadOnlyQuery checks that query is a single SELECT/WITH statement
+// and returns it trimmed.
+func validateReadOnlyQuery(query string) (string, error) {
+	trimmed := strings.TrimSpace(query)
+
+	// Reject semicolons to prevent statement chaining.
+	if strings.Contains(trimmed, ";") {
+		return "", fmt.Errorf("query must not contain semicolons")
+	}
+
+	// Strip SQL comments so keywords hidden in comments are still caught.
+	stripped := strings.TrimSpace(stripSQLComments(trimmed))
+	upper := strings.ToUpper(stripped)
+
+	if !strings.HasPrefix(upper, "SELECT") && !strings.HasPrefix(upper, "WITH") {
+		return "", fmt.Errorf("only SELECT/WITH queries are allowed")
+	}
+
+	// Defense-in-depth: reject dangerous keywords after comment stripping.
+	if match := dangerousKeywordPattern.FindString(stripped); match != "" {
+		return "", fmt.Errorf("query contains disallowed keyword: %s", strings.ToUpper(match))
+	}
+	return trimmed, nil
 }
 
 // GetSchemaDescription returns a human-readable schema description for AI prompts.
 func (s *Store) GetSchemaDescription() string {
-	return `Table 'logs': id (BIGINT), timestamp (TIMESTAMP), orig_timestamp (TIMESTAMP), ` +
+	desc := `Table 'logs': id (BIGINT), timestamp (TIMESTAMP), orig_timestamp (TIMESTAMP), ` +
 		`level (VARCHAR: TRACE/DEBUG/INFO/WARN/ERROR/FATAL), level_num (INTEGER), ` +
-		`message (VARCHAR), raw_line (VARCHAR), service (VARCHAR), hostname (VARCHAR), ` +
+		`message (VARCHAR), raw_line (VARCHAR; NULL when not stored, so read coalesce(raw_line, message)), ` +
+		`service (VARCHAR), hostname (VARCHAR), ` +
 		`pid (INTEGER), attributes (JSON), source (VARCHAR: tcp/stdin/file), app (VARCHAR), ` +
-		`event_id (VARCHAR, replay-stable id for dedupe).`
+		`event_id (VARCHAR, replay-stable id for dedupe), trace_id (VARCHAR), span_id (VARCHAR), ` +
+		`body_json (JSON, structured OTLP body; NULL for plain messages), ` +
+		`raw_line_zstd (BLOB, zstd-compressed raw line stored in place of raw_line). ` +
+		`Table 'metrics': timestamp (TIMESTAMP), name (VARCHAR), ` +
+		`kind (VARCHAR: gauge/sum/counter/histogram/summary), unit (VARCHAR), value (DOUBLE), ` +
+		`labels (JSON), source (VARCHAR), app (VARCHAR), service (VARCHAR). ` +
+		`Table 'spans': trace_id (VARCHAR), span_id (VARCHAR), parent_span_id (VARCHAR), ` +
+		`name (VARCHAR), kind (VARCHAR), start_time (TIMESTAMP), end_time (TIMESTAMP), ` +
+		`status_code (VARCHAR: unset/ok/error), status_message (VARCHAR), attributes (JSON), ` +
+		`service (VARCHAR), app (VARCHAR).`
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.promoted) == 0 {
+		return desc
+	}
+	columns := make([]string, len(s.promoted))
+	for i, p := range s.promoted {
+		columns[i] = fmt.Sprintf("%s (%s, attribute %q)", p.column, p.dataType, p.key)
+	}
+	return desc + ` Promoted attribute columns: ` + strings.Join(columns, ", ") + `.`
+}
+
+// SchemaRevision returns the version of the last migration applied.
+func (s *Store) SchemaRevision() (int, error) {
+	current, _, err := migrate.NewRunner(s.db).Status()
+	return current, err
 }
 
 // TableRowCounts returns the row count for each known table using a hardcoded allowlist.
 func (s *Store) TableRowCounts() (map[string]int64, error) {
+	ctx, cancel, err := s.queryCtx(priorityBulk)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
-
 	allowedTables := []string{"logs"}
 	counts := make(map[string]int64, len(allowedTables))
 
@@ -576,21 +909,18 @@ func (s *Store) TableRowCounts() (map[string]int64, error) {
 }
 
 // RecentLogsFiltered returns recent log records with optional filtering by app,
-// severity levels, and message pattern (regex).
-func (s *Store) RecentLogsFiltered(limit int, app string, severityLevels []string, messagePattern string) ([]LogRecord, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	ctx, cancel := s.queryCtx()
+// time range, severity levels, facets, and message pattern (regex).
+func (s *Store) RecentLogsFiltered(limit int, opts QueryOpts, severityLevels []string, facets map[string]string, messagePattern string) ([]LogRecord, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
 	defer cancel()
 
-	var conditions []string
-	var args []interface{}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	if app != "" {
-		conditions = append(conditions, "app = ?")
-		args = append(args, app)
-	}
+	conditions, args := scopeConditions(opts)
 
 	if len(severityLevels) > 0 {
 		placeholders := make([]string, len(severityLevels))
@@ -600,13 +930,23 @@ func (s *Store) RecentLogsFiltered(limit int, app string, severityLevels []strin
 		}
 		conditions = append(conditions, "level IN ("+strings.Join(placeholders, ", ")+")")
 	}
+	conditions, args = s.appendFacetConditions(conditions, args, facets)
 
+	source := "logs"
 	if messagePattern != "" {
-		conditions = append(conditions, "regexp_matches(message, ?)")
+		if literalPattern(messagePattern) {
+			var err error
+			if source, err = s.searchSource(ctx, messagePattern); err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, "contains(message, ?)")
+		} else {
+			conditions = append(conditions, "regexp_matches(message, ?)")
+		}
 		args = append(args, messagePattern)
 	}
 
-	innerQuery := "SELECT timestamp, orig_timestamp, level, level_num, message, raw_line, service, hostname, pid, CAST(attributes AS VARCHAR) AS attributes, source, app FROM logs"
+	innerQuery := "SELECT timestamp, orig_timestamp, level, level_num, message, coalesce(raw_line, message) AS raw_line, raw_line_zstd, service, hostname, pid, CAST(attributes AS VARCHAR) AS attributes, source, app, CAST(body_json AS VARCHAR) AS body_json FROM " + source
 	if len(conditions) > 0 {
 		innerQuery += " WHERE " + strings.Join(conditions, " AND ")
 	}
@@ -627,13 +967,17 @@ func (s *Store) RecentLogsFiltered(limit int, app string, severityLevels []strin
 		var r LogRecord
 		var origTS sql.NullTime
 		var attrsJSON string
-		if err := rows.Scan(&r.Timestamp, &origTS, &r.Level, &r.LevelNum, &r.Message, &r.RawLine, &r.Service, &r.Hostname, &r.PID, &attrsJSON, &r.Source, &r.App); err != nil {
+		var body sql.NullString
+		var rawZstd []byte
+		if err := rows.Scan(&r.Timestamp, &origTS, &r.Level, &r.LevelNum, &r.Message, &r.RawLine, &rawZstd, &r.Service, &r.Hostname, &r.PID, &attrsJSON, &r.Source, &r.App, &body); err != nil {
 			log.Printf("duckdb scan error (RecentLogsFiltered): %v", err)
 			continue
 		}
 		if origTS.Valid {
 			r.OrigTimestamp = origTS.Time
 		}
+		r.BodyJSON = body.String
+		decodeRawLine(&r, rawZstd)
 		// Parse attributes JSON back to map; always initialize to non-nil.
 		r.Attributes = make(map[string]string)
 		if attrsJSON != "" && attrsJSON != "{}" {
@@ -646,18 +990,25 @@ func (s *Store) RecentLogsFiltered(limit int, app string, severityLevels []strin
 
 // SearchLogs performs a case-insensitive substring search on log messages.
 func (s *Store) SearchLogs(term string, limit int, opts QueryOpts) ([]LogRecord, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ctx, cancel := s.queryCtx()
-	defer cancel()
-
-	andApp, aArgs := appAnd(opts)
-	query := fmt.Sprintf(`SELECT timestamp, orig_timestamp, level, level_num, message, raw_line, service, hostname, pid, CAST(attributes AS VARCHAR) AS attributes, source, app
-		FROM logs
+	source, err := s.searchSource(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	andApp, aArgs := scopeAnd(opts)
+	query := fmt.Sprintf(`SELECT timestamp, orig_timestamp, level, level_num, message, coalesce(raw_line, message) AS raw_line, raw_line_zstd, service, hostname, pid, CAST(attributes AS VARCHAR) AS attributes, source, app, CAST(body_json AS VARCHAR) AS body_json
+		FROM %s
 		WHERE contains(lower(message), lower(?))%s
 		ORDER BY timestamp DESC
-		LIMIT ?`, andApp)
+		LIMIT ?`, source, andApp)
 
 	args := append([]interface{}{term}, aArgs...)
 	args = append(args, limit)
@@ -673,13 +1024,17 @@ func (s *Store) SearchLogs(term string, limit int, opts QueryOpts) ([]LogRecord,
 		var r LogRecord
 		var origTS sql.NullTime
 		var attrsJSON string
-		if err := rows.Scan(&r.Timestamp, &origTS, &r.Level, &r.LevelNum, &r.Message, &r.RawLine, &r.Service, &r.Hostname, &r.PID, &attrsJSON, &r.Source, &r.App); err != nil {
+		var body sql.NullString
+		var rawZstd []byte
+		if err := rows.Scan(&r.Timestamp, &origTS, &r.Level, &r.LevelNum, &r.Message, &r.RawLine, &rawZstd, &r.Service, &r.Hostname, &r.PID, &attrsJSON, &r.Source, &r.App, &body); err != nil {
 			log.Printf("duckdb scan error (SearchLogs): %v", err)
 			continue
 		}
 		if origTS.Valid {
 			r.OrigTimestamp = origTS.Time
 		}
+		r.BodyJSON = body.String
+		decodeRawLine(&r, rawZstd)
 		r.Attributes = make(map[string]string)
 		if attrsJSON != "" && attrsJSON != "{}" {
 			parseJSONMap(attrsJSON, r.Attributes)
@@ -0,0 +1,100 @@
+package duckdb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// RawLinePolicy selects how raw_line is stored. For JSON-heavy workloads
+// the raw line roughly doubles the size of a log.
+type RawLinePolicy string
+
+const (
+	// RawLineAlways stores every raw line as received.
+	RawLineAlways RawLinePolicy = "always"
+	// RawLineDiffers stores a raw line only when it differs from the message.
+	RawLineDiffers RawLinePolicy = "differs"
+	// RawLineNever stores no raw lines.
+	RawLineNever RawLinePolicy = "never"
+	// RawLineCompress stores a raw line that differs from the message
+	// zstd-compressed in raw_line_zstd instead of raw_line.
+	RawLineCompress RawLinePolicy = "compress"
+)
+
+// ParseRawLinePolicy parses "always", "differs", "never", or "compress".
+func ParseRawLinePolicy(s string) (RawLinePolicy, error) {
+	switch p := RawLinePolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case RawLineAlways, RawLineDiffers, RawLineNever, RawLineCompress:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown raw line policy %q (want %s, %s, %s, or %s)", s, RawLineAlways, RawLineDiffers, RawLineNever, RawLineCompress)
+}
+
+// SetRawLinePolicy sets how the raw lines of records inserted from now on
+// are stored. Rows already stored keep theirs. A raw line that is not
+// stored is NULL, and reads return the message in its place; a compressed
+// one is decompressed by the Store's reads, while ad-hoc SQL sees it only
+// in raw_line_zstd.
+func (s *Store) SetRawLinePolicy(policy RawLinePolicy) error {
+	if _, err := ParseRawLinePolicy(string(policy)); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rawLine = policy
+	return nil
+}
+
+// Raw lines are compressed one at a time, so the codecs need no state
+// between calls and are shared.
+var (
+	rawLineEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+	rawLineDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+)
+
+// storedRawLine returns the raw_line and raw_line_zstd values appended for
+// r under policy.
+func storedRawLine(policy RawLinePolicy, r *LogRecord) (raw, compressed driver.Value) {
+	switch policy {
+	case RawLineNever:
+		return nil, nil
+	case RawLineDiffers, RawLineCompress:
+		if r.RawLine == r.Message {
+			return nil, nil
+		}
+		if policy == RawLineCompress {
+			return nil, rawLineEncoder.EncodeAll([]byte(r.RawLine), nil)
+		}
+	}
+	return r.RawLine, nil
+}
+
+// storedLength returns the length, in characters, of the line logs keeps
+// for r under policy: its raw line when stored, otherwise the message.
+// The rollups and totals count it as length(coalesce(raw_line, message)).
+func storedLength(policy RawLinePolicy, r *LogRecord) int64 {
+	if raw, _ := storedRawLine(policy, r); raw != nil {
+		return int64(utf8.RuneCountInString(r.RawLine))
+	}
+	return int64(utf8.RuneCountInString(r.Message))
+}
+
+// decodeRawLine sets r.RawLine from a raw_line_zstd value read with it,
+// if any.
+func decodeRawLine(r *LogRecord, compressed []byte) {
+	if len(compressed) == 0 {
+		return
+	}
+	raw, err := rawLineDecoder.DecodeAll(compressed, nil)
+	if err != nil {
+		log.Printf("duckdb: failed to decompress raw line, using message: %v", err)
+		return
+	}
+	r.RawLine = string(raw)
+}
@@ -0,0 +1,81 @@
+package duckdb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRawLinePolicy(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	records := func() []*LogRecord {
+		return []*LogRecord{
+			{Timestamp: now, Level: "INFO", Message: "plain", RawLine: "plain"},
+			{Timestamp: now.Add(time.Second), Level: "INFO", Message: "parsed", RawLine: `{"msg":"parsed"}`},
+		}
+	}
+
+	for _, tc := range []struct {
+		policy RawLinePolicy
+		stored int64 // non-NULL raw lines
+	}{
+		{RawLineAlways, 2},
+		{RawLineDiffers, 1},
+		{RawLineNever, 0},
+	} {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			store := newTestStore(t)
+			if err := store.SetRawLinePolicy(tc.policy); err != nil {
+				t.Fatalf("SetRawLinePolicy: %v", err)
+			}
+			insertTestRecords(t, store, records())
+
+			var stored int64
+			if err := store.db.QueryRow(`SELECT count(raw_line) FROM logs`).Scan(&stored); err != nil || stored != tc.stored {
+				t.Fatalf("stored raw lines = %d, %v; want %d", stored, err, tc.stored)
+			}
+			// Reads fall back to the message.
+			logs, err := store.RecentLogsFiltered(10, QueryOpts{}, nil, nil, "")
+			if err != nil || len(logs) != 2 {
+				t.Fatalf("RecentLogsFiltered = %+v, %v", logs, err)
+			}
+			for _, r := range logs {
+				want := r.Message
+				if r.Message == "parsed" && tc.policy != RawLineNever {
+					want = `{"msg":"parsed"}`
+				}
+				if r.RawLine != want {
+					t.Errorf("raw line of %q = %q, want %q", r.Message, r.RawLine, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRawLinePolicy_Compress(t *testing.T) {
+	if _, err := ParseRawLinePolicy("gzip"); err == nil {
+		t.Fatal("ParseRawLinePolicy accepted an unknown policy")
+	}
+
+	store := newTestStore(t)
+	if err := store.SetRawLinePolicy(RawLineCompress); err != nil {
+		t.Fatalf("SetRawLinePolicy: %v", err)
+	}
+	raw := `{"msg":"parsed","user":"ann","request":{"method":"GET","path":"/api/logs"}}`
+	now := time.Now()
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: now, Level: "INFO", Message: "plain", RawLine: "plain"},
+		{Timestamp: now.Add(time.Second), Level: "INFO", Message: "parsed", RawLine: raw},
+	})
+
+	var stored, compressed int64
+	if err := store.db.QueryRow(`SELECT count(raw_line), count(raw_line_zstd) FROM logs`).Scan(&stored, &compressed); err != nil {
+		t.Fatalf("count raw lines: %v", err)
+	}
+	if stored != 0 || compressed != 1 {
+		t.Fatalf("raw_line/raw_line_zstd = %d/%d, want 0/1", stored, compressed)
+	}
+	logs, err := store.SearchLogs("parsed", 10, QueryOpts{})
+	if err != nil || len(logs) != 1 || logs[0].RawLine != raw {
+		t.Fatalf("SearchLogs = %+v, %v; want the raw line decompressed", logs, err)
+	}
+}
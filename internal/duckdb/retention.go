@@ -1,42 +1,106 @@
 package duckdb
 
 import (
+	"errors"
 	"log"
+	"math"
 	"sync"
 	"time"
 )
 
+// capacityCheckInterval is how often the cleaner runs when a size or row
+// limit is set; age-only retention runs hourly.
+const capacityCheckInterval = 5 * time.Minute
+
+// capacityTarget is the share of MaxBytes the size policy deletes down to,
+// so a store hovering at the limit is not trimmed on every run.
+const capacityTarget = 0.9
+
 // RetentionConfig holds configuration for the retention cleaner.
 type RetentionConfig struct {
 	RetentionDays int
+	// MaxBytes deletes the oldest logs when the store's StorageBytes exceed
+	// it, down to about 90% of the limit. 0 disables.
+	MaxBytes int64
+	// MaxRows deletes the oldest logs beyond this many. 0 disables.
+	MaxRows int64
+	// ArchiveDays archives logs older than this many days on stores that
+	// implement LogArchiver. 0 disables.
+	ArchiveDays int
+	// Rotate moves finished periods out of the active database file on
+	// stores that implement LogRotator.
+	Rotate bool
 }
 
-// RetentionCleaner periodically deletes logs older than the configured retention period.
+// RetentionCleaner periodically deletes logs older than the configured retention
+// period, and the oldest logs once the store exceeds its size or row limit.
+// It also archives logs past the archive age and rotates the database file.
 type RetentionCleaner struct {
-	store         *Store
+	store         LogPruner
+	capacity      CapacityPruner // nil unless a size or row limit applies
+	archiver      LogArchiver    // nil unless archiving applies
+	rotator       LogRotator     // nil unless rotation applies
 	retentionDays int
+	archiveDays   int
+	maxBytes      int64
+	maxRows       int64
+	interval      time.Duration
 	done          chan struct{}
 	wg            sync.WaitGroup
 	tickWg        sync.WaitGroup
 	stopOnce      sync.Once
+	runMu         sync.Mutex // serializes runs from the ticker and RunNow
+
+	statsMu sync.Mutex
+	stats   RetentionStats
+	lastErr error // of the latest run, nil if it succeeded
 }
 
 // NewRetentionCleaner creates a retention cleaner that deletes expired logs.
-// Returns nil when retention is 0 (disabled).
-func NewRetentionCleaner(store *Store, conf ...RetentionConfig) *RetentionCleaner {
-	days := 30
+// Size and row limits need a store implementing CapacityPruner, archiving
+// one implementing LogArchiver, and rotation one implementing LogRotator;
+// they are ignored otherwise.
+// Returns nil when every policy is disabled.
+func NewRetentionCleaner(store LogPruner, conf ...RetentionConfig) *RetentionCleaner {
+	cfg := RetentionConfig{RetentionDays: 30}
 	if len(conf) > 0 {
-		days = conf[0].RetentionDays
-	}
-	if days <= 0 {
-		return nil
+		cfg = conf[0]
 	}
 
 	rc := &RetentionCleaner{
 		store:         store,
-		retentionDays: days,
+		retentionDays: max(cfg.RetentionDays, 0),
+		interval:      time.Hour,
 		done:          make(chan struct{}),
 	}
+	if cfg.MaxBytes > 0 || cfg.MaxRows > 0 {
+		if capacity, ok := store.(CapacityPruner); ok {
+			rc.capacity = capacity
+			rc.maxBytes = max(cfg.MaxBytes, 0)
+			rc.maxRows = max(cfg.MaxRows, 0)
+			rc.interval = capacityCheckInterval
+		} else {
+			log.Printf("duckdb: storage backend does not support size-based retention; ignoring size and row limits")
+		}
+	}
+	if cfg.ArchiveDays > 0 {
+		if archiver, ok := store.(LogArchiver); ok {
+			rc.archiver = archiver
+			rc.archiveDays = cfg.ArchiveDays
+		} else {
+			log.Printf("duckdb: storage backend does not support archiving; ignoring archive age")
+		}
+	}
+	if cfg.Rotate {
+		if rotator, ok := store.(LogRotator); ok {
+			rc.rotator = rotator
+		} else {
+			log.Printf("duckdb: storage backend does not support file rotation; ignoring rotate-period")
+		}
+	}
+	if rc.retentionDays == 0 && rc.capacity == nil && rc.archiver == nil && rc.rotator == nil {
+		return nil
+	}
 
 	// Startup cleanup to catch up after downtime.
 	rc.cleanup()
@@ -51,7 +115,7 @@ func NewRetentionCleaner(store *Store, conf ...RetentionConfig) *RetentionCleane
 func (rc *RetentionCleaner) tickLoop() {
 	defer rc.wg.Done()
 	defer rc.tickWg.Done()
-	ticker := time.NewTicker(1 * time.Hour)
+	ticker := time.NewTicker(rc.interval)
 	defer ticker.Stop()
 
 	for {
@@ -65,16 +129,172 @@ func (rc *RetentionCleaner) tickLoop() {
 }
 
 func (rc *RetentionCleaner) cleanup() {
+	rc.runMu.Lock()
+	defer rc.runMu.Unlock()
+
+	var errs []error
+	if rc.archiver != nil {
+		errs = append(errs, rc.archive())
+	}
+	if rc.rotator != nil {
+		errs = append(errs, rc.rotate())
+	}
+	if rc.retentionDays > 0 {
+		errs = append(errs, rc.expire())
+	}
+	if rc.capacity != nil {
+		errs = append(errs, rc.enforceCapacity())
+	}
+	rc.statsMu.Lock()
+	rc.stats.LastRun = time.Now()
+	rc.lastErr = errors.Join(errs...)
+	rc.statsMu.Unlock()
+}
+
+func (rc *RetentionCleaner) expire() error {
 	cutoff := time.Now().Add(-time.Duration(rc.retentionDays) * 24 * time.Hour)
 
 	rows, err := rc.store.DeleteBefore(cutoff)
 	if err != nil {
 		log.Printf("duckdb: retention cleanup error: %v", err)
-		return
+		return err
 	}
 	if rows > 0 {
+		rc.record(func(s *RetentionStats) { s.Expired += rows })
 		log.Printf("duckdb: retention cleanup deleted %d expired logs (older than %d days)", rows, rc.retentionDays)
 	}
+	return nil
+}
+
+func (rc *RetentionCleaner) archive() error {
+	cutoff := time.Now().Add(-time.Duration(rc.archiveDays) * 24 * time.Hour)
+
+	rows, err := rc.archiver.ArchiveBefore(cutoff)
+	if rows > 0 {
+		rc.record(func(s *RetentionStats) { s.Archived += rows })
+		log.Printf("duckdb: retention archived %d logs (older than %d days)", rows, rc.archiveDays)
+	}
+	if err != nil {
+		log.Printf("duckdb: retention archive error: %v", err)
+	}
+	return err
+}
+
+func (rc *RetentionCleaner) rotate() error {
+	rows, err := rc.rotator.Rotate(time.Now())
+	if rows > 0 {
+		rc.record(func(s *RetentionStats) { s.Rotated += rows })
+		log.Printf("duckdb: retention rotated %d logs out of the active database file", rows)
+	}
+	if err != nil {
+		log.Printf("duckdb: retention rotation error: %v", err)
+	}
+	return err
+}
+
+// enforceCapacity applies the row limit, then the size limit. Rows are
+// assumed to be of similar size, so the size policy deletes the oldest
+// share of rows that brings the store to capacityTarget of the limit; if
+// that is not enough the next run deletes more.
+func (rc *RetentionCleaner) enforceCapacity() error {
+	count, err := rc.capacity.TotalLogCount(QueryOpts{})
+	if err != nil {
+		log.Printf("duckdb: retention size check error: %v", err)
+		return err
+	}
+
+	if rc.maxRows > 0 && count > rc.maxRows {
+		rows, err := rc.capacity.DeleteOldest(count - rc.maxRows)
+		if err != nil {
+			log.Printf("duckdb: retention row limit error: %v", err)
+			return err
+		}
+		count -= rows
+		rc.record(func(s *RetentionStats) { s.RowsEvicted += rows })
+		log.Printf("duckdb: retention evicted %d oldest logs (over max-row-count %d)", rows, rc.maxRows)
+	}
+
+	if rc.maxBytes == 0 {
+		return nil
+	}
+	size, err := rc.capacity.StorageBytes()
+	if err != nil {
+		log.Printf("duckdb: retention size check error: %v", err)
+		return err
+	}
+	if size > rc.maxBytes && count > 0 {
+		share := 1 - float64(rc.maxBytes)*capacityTarget/float64(size)
+		rows, err := rc.capacity.DeleteOldest(int64(math.Ceil(float64(count) * share)))
+		if err != nil {
+			log.Printf("duckdb: retention size limit error: %v", err)
+			return err
+		}
+		rc.record(func(s *RetentionStats) { s.SizeEvicted += rows })
+		log.Printf("duckdb: retention evicted %d oldest logs (storage %d bytes over max-db-size %d)", rows, size, rc.maxBytes)
+		if size, err = rc.capacity.StorageBytes(); err != nil {
+			size = 0
+		}
+	}
+	rc.record(func(s *RetentionStats) { s.StorageBytes = size })
+	return nil
+}
+
+// RunNow applies every policy now instead of at the next tick and returns
+// the stats after the run.
+func (rc *RetentionCleaner) RunNow() RetentionStats {
+	rc.cleanup()
+	return rc.RetentionStats()
+}
+
+// Preview counts the logs RunNow would expire by age and evict over the row
+// limit, without deleting them. Size-based eviction, archiving, and
+// rotation are not estimated.
+func (rc *RetentionCleaner) Preview() (RetentionStats, error) {
+	var preview RetentionStats
+	counter, ok := rc.store.(interface {
+		TotalLogCount(opts QueryOpts) (int64, error)
+	})
+	if !ok {
+		return preview, nil
+	}
+	if rc.retentionDays > 0 {
+		cutoff := time.Now().Add(-time.Duration(rc.retentionDays) * 24 * time.Hour)
+		expired, err := counter.TotalLogCount(QueryOpts{To: cutoff})
+		if err != nil {
+			return preview, err
+		}
+		preview.Expired = expired
+	}
+	if rc.maxRows > 0 {
+		count, err := counter.TotalLogCount(QueryOpts{})
+		if err != nil {
+			return preview, err
+		}
+		preview.RowsEvicted = max(count-preview.Expired-rc.maxRows, 0)
+	}
+	return preview, nil
+}
+
+func (rc *RetentionCleaner) record(update func(*RetentionStats)) {
+	rc.statsMu.Lock()
+	update(&rc.stats)
+	rc.statsMu.Unlock()
+}
+
+// RetentionStats returns how many logs each policy has deleted since the
+// cleaner started.
+func (rc *RetentionCleaner) RetentionStats() RetentionStats {
+	rc.statsMu.Lock()
+	defer rc.statsMu.Unlock()
+	return rc.stats
+}
+
+// Check returns the error of the cleaner's latest run, nil if it
+// succeeded.
+func (rc *RetentionCleaner) Check() error {
+	rc.statsMu.Lock()
+	defer rc.statsMu.Unlock()
+	return rc.lastErr
 }
 
 // Stop signals the cleaner to stop and waits for it to finish.
@@ -1,6 +1,12 @@
 package duckdb
 
-import "testing"
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestRetentionCleaner_StopIsIdempotent(t *testing.T) {
 	store := newTestStore(t)
@@ -12,3 +18,72 @@ func TestRetentionCleaner_StopIsIdempotent(t *testing.T) {
 	cleaner.Stop()
 	cleaner.Stop()
 }
+
+func TestRetentionCleaner_DisabledReturnsNil(t *testing.T) {
+	store := newTestStore(t)
+	if cleaner := NewRetentionCleaner(store, RetentionConfig{}); cleaner != nil {
+		cleaner.Stop()
+		t.Fatal("expected nil cleaner with every policy disabled")
+	}
+}
+
+func TestRetentionCleaner_RowLimit(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+	var records []*LogRecord
+	for i := range 10 {
+		records = append(records, &LogRecord{Timestamp: now.Add(time.Duration(i) * time.Second), Level: "INFO", Message: fmt.Sprintf("log %d", i)})
+	}
+	insertTestRecords(t, store, records)
+
+	cleaner := NewRetentionCleaner(store, RetentionConfig{MaxRows: 4})
+	defer cleaner.Stop()
+
+	if stats := cleaner.RetentionStats(); stats.RowsEvicted != 6 || stats.LastRun.IsZero() {
+		t.Errorf("stats = %+v, want 6 rows evicted", stats)
+	}
+	logs, err := store.RecentLogsFiltered(10, QueryOpts{}, nil, nil, "")
+	if err != nil {
+		t.Fatalf("RecentLogsFiltered: %v", err)
+	}
+	if len(logs) != 4 || logs[0].Message != "log 6" {
+		t.Errorf("remaining logs = %d starting %q, want the newest 4", len(logs), logs[0].Message)
+	}
+}
+
+func TestRetentionCleaner_SizeLimit(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "logs.duckdb"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	var records []*LogRecord
+	for i := range 2000 {
+		records = append(records, &LogRecord{Timestamp: now.Add(time.Duration(i) * time.Millisecond), Level: "INFO", Message: strings.Repeat(fmt.Sprintf("payload %d ", i), 20)})
+	}
+	insertTestRecords(t, store, records)
+	if _, err := store.DB().Exec("CHECKPOINT"); err != nil {
+		t.Fatalf("checkpoint: %v", err)
+	}
+	size, err := store.StorageBytes()
+	if err != nil || size == 0 {
+		t.Fatalf("StorageBytes = %d, %v", size, err)
+	}
+
+	cleaner := NewRetentionCleaner(store, RetentionConfig{MaxBytes: size / 2})
+	defer cleaner.Stop()
+
+	stats := cleaner.RetentionStats()
+	if stats.SizeEvicted == 0 || stats.StorageBytes == 0 {
+		t.Fatalf("stats = %+v, want size evictions", stats)
+	}
+	count, err := store.TotalLogCount(QueryOpts{})
+	if err != nil {
+		t.Fatalf("TotalLogCount: %v", err)
+	}
+	if count != 2000-stats.SizeEvicted {
+		t.Errorf("count = %d, want %d", count, 2000-stats.SizeEvicted)
+	}
+}
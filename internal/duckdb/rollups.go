@@ -0,0 +1,253 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// rollupSelect aggregates logs the way log_minute_rollups stores them: one
+// row per minute, app, service, and level, with the count and the length
+// of the stored line. Callers append a WHERE clause and GROUP BY ALL.
+const rollupSelect = `SELECT date_trunc('minute', timestamp) AS minute, coalesce(app, 'default') AS app,
+	coalesce(service, '') AS service, level, count(*) AS count, coalesce(sum(length(coalesce(raw_line, message))), 0) AS bytes
+	FROM logs`
+
+// rollupKey identifies one row of log_minute_rollups.
+type rollupKey struct {
+	minute  time.Time
+	app     string
+	service string
+	level   string
+}
+
+// updateRollups adds records, just inserted in tx with policy, to
+// log_minute_rollups.
+func updateRollups(ctx context.Context, tx *sql.Tx, policy RawLinePolicy, records []*LogRecord) error {
+	type totals struct{ count, bytes int64 }
+	var keys []rollupKey
+	sums := make(map[rollupKey]*totals)
+	for _, r := range records {
+		app := r.App
+		if app == "" {
+			app = "default"
+		}
+		key := rollupKey{minute: r.Timestamp.UTC().Truncate(time.Minute), app: app, service: r.Service, level: r.Level}
+		t := sums[key]
+		if t == nil {
+			t = &totals{}
+			sums[key] = t
+			keys = append(keys, key)
+		}
+		t.count++
+		t.bytes += storedLength(policy, r)
+	}
+
+	values := make([]string, len(keys))
+	args := make([]interface{}, 0, len(keys)*6)
+	for i, key := range keys {
+		values[i] = "(?, ?, ?, ?, ?, ?)"
+		args = append(args, key.minute, key.app, key.service, key.level, sums[key].count, sums[key].bytes)
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO log_minute_rollups VALUES `+strings.Join(values, ", ")+`
+		ON CONFLICT (minute, app, service, level) DO UPDATE SET count = count + excluded.count, bytes = bytes + excluded.bytes`, args...)
+	return err
+}
+
+// trimRollups drops the rollups of deleted logs. Retention deletes the
+// oldest logs by timestamp, so the minutes before the oldest remaining log
+// are gone and the oldest remaining minute is recounted.
+func trimRollups(ctx context.Context, tx *sql.Tx) error {
+	var oldest sql.NullTime
+	if err := tx.QueryRowContext(ctx, `SELECT date_trunc('minute', min(timestamp)) FROM logs`).Scan(&oldest); err != nil {
+		return err
+	}
+	if !oldest.Valid {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM log_minute_suppressed`); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM log_minute_rollups`)
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM log_minute_suppressed WHERE minute < ?`, oldest.Time); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM log_minute_rollups WHERE minute <= ?`, oldest.Time); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO log_minute_rollups `+rollupSelect+`
+		WHERE timestamp < ? GROUP BY ALL`, oldest.Time.Add(time.Minute))
+	return err
+}
+
+// recountRollups recounts the rollups of opts's app and the minutes its
+// time range touches from logs, after a delete inside them.
+func recountRollups(ctx context.Context, tx *sql.Tx, opts QueryOpts) error {
+	var conditions []string
+	var args []interface{}
+	if opts.App != "" {
+		conditions = append(conditions, "app = ?")
+		args = append(args, opts.App)
+	}
+	if !opts.From.IsZero() {
+		conditions = append(conditions, "minute >= ?")
+		args = append(args, opts.From.UTC().Truncate(time.Minute))
+	}
+	if !opts.To.IsZero() {
+		conditions = append(conditions, "minute < ?")
+		args = append(args, opts.To.UTC())
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM log_minute_suppressed`+where, args...); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM log_minute_rollups`+where, args...); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO log_minute_rollups SELECT * FROM (`+rollupSelect+` GROUP BY ALL)`+where, args...)
+	return err
+}
+
+// rollupSource returns a row source of (minute, app, service, level, count,
+// bytes) for the logs in opts: whole minutes come from log_minute_rollups,
+// and the partial minutes at either end of the range are aggregated from
+// logs, so results match a scan of logs exactly.
+func rollupSource(opts QueryOpts) (string, []interface{}) {
+	var appCond []string
+	var appArgs []interface{}
+	if opts.App != "" {
+		appCond = []string{"app = ?"}
+		appArgs = []interface{}{opts.App}
+	}
+
+	// [lo, hi) is the run of whole minutes inside the range.
+	lo, hi := opts.From, opts.To
+	if !lo.IsZero() && !lo.Equal(lo.Truncate(time.Minute)) {
+		lo = lo.Truncate(time.Minute).Add(time.Minute)
+	}
+	if !hi.IsZero() {
+		hi = hi.Truncate(time.Minute)
+	}
+	if !lo.IsZero() && !hi.IsZero() && !lo.Before(hi) {
+		// Less than a whole minute: aggregate logs directly.
+		where, args := scopeFilter(opts)
+		return "(" + rollupSelect + " " + where + " GROUP BY ALL)", args
+	}
+
+	var parts []string
+	var args []interface{}
+	conds := append([]string(nil), appCond...)
+	args = append(args, appArgs...)
+	if !lo.IsZero() {
+		conds = append(conds, "minute >= ?")
+		args = append(args, lo)
+	}
+	if !hi.IsZero() {
+		conds = append(conds, "minute < ?")
+		args = append(args, hi)
+	}
+	rollups := `SELECT minute, app, service, level, count, bytes FROM log_minute_rollups`
+	if len(conds) > 0 {
+		rollups += " WHERE " + strings.Join(conds, " AND ")
+	}
+	parts = append(parts, rollups)
+
+	edge := func(from, to time.Time) {
+		conds := append(append([]string(nil), appCond...), "timestamp >= ?", "timestamp < ?")
+		parts = append(parts, rollupSelect+" WHERE "+strings.Join(conds, " AND ")+" GROUP BY ALL")
+		args = append(args, appArgs...)
+		args = append(args, from, to)
+	}
+	if !opts.From.IsZero() && opts.From.Before(lo) {
+		edge(opts.From, lo)
+	}
+	if !opts.To.IsZero() && hi.Before(opts.To) {
+		edge(hi, opts.To)
+	}
+	return "(" + strings.Join(parts, " UNION ALL ") + ")", args
+}
+
+// severitySource is rollupSource plus the records ingest counted but did
+// not store, from log_minute_suppressed. Those are kept per minute only, so
+// a partial minute at either end of opts counts in full.
+func severitySource(opts QueryOpts) (string, []interface{}) {
+	source, args := rollupSource(opts)
+	var conds []string
+	if opts.App != "" {
+		conds = append(conds, "app = ?")
+		args = append(args, opts.App)
+	}
+	if !opts.From.IsZero() {
+		conds = append(conds, "minute >= ?")
+		args = append(args, opts.From.UTC().Truncate(time.Minute))
+	}
+	if !opts.To.IsZero() {
+		conds = append(conds, "minute < ?")
+		args = append(args, opts.To.UTC())
+	}
+	suppressed := `SELECT minute, app, service, level, count, 0 AS bytes FROM log_minute_suppressed`
+	if len(conds) > 0 {
+		suppressed += " WHERE " + strings.Join(conds, " AND ")
+	}
+	return "(SELECT * FROM " + source + " UNION ALL " + suppressed + ")", args
+}
+
+// AddSuppressedCounts adds counts of records kept out of storage to
+// log_minute_suppressed, which severity counts include.
+func (s *Store) AddSuppressedCounts(counts []model.SuppressedCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.QueryTimeout)
+	defer cancel()
+
+	values := make([]string, len(counts))
+	args := make([]interface{}, 0, len(counts)*5)
+	for i, c := range counts {
+		app := c.App
+		if app == "" {
+			app = "default"
+		}
+		values[i] = "(?, ?, ?, ?, ?)"
+		args = append(args, c.Minute.UTC().Truncate(time.Minute), app, c.Service, c.Level, c.Count)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO log_minute_suppressed VALUES `+strings.Join(values, ", ")+`
+		ON CONFLICT (minute, app, service, level) DO UPDATE SET count = count + excluded.count`, args...)
+	return err
+}
+
+// RebuildRollups recomputes log_minute_rollups, and the running totals, from
+// logs. The insert path keeps them current; this is for logs written around
+// it.
+func (s *Store) RebuildRollups() error {
+	ctx := context.Background()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM log_minute_rollups`); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO log_minute_rollups `+rollupSelect+` GROUP BY ALL`); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return s.loadTotals(ctx, s.db)
+}
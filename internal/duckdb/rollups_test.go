@@ -0,0 +1,344 @@
+package duckdb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// scanSeverityCounts counts levels with a scan of logs, bypassing the rollups.
+func scanSeverityCounts(t *testing.T, store *Store, opts QueryOpts) map[string]int64 {
+	t.Helper()
+	where, args := scopeFilter(opts)
+	rows, err := store.db.Query(`SELECT level, count(*) FROM logs `+where+` GROUP BY level`, args...)
+	if err != nil {
+		t.Fatalf("scan severity counts: %v", err)
+	}
+	defer rows.Close()
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var level string
+		var n int64
+		if err := rows.Scan(&level, &n); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		counts[level] = n
+	}
+	return counts
+}
+
+func seedRollupLogs(t *testing.T, store *Store, base time.Time) {
+	t.Helper()
+	var records []*LogRecord
+	for i := 0; i < 120; i++ {
+		level := "INFO"
+		if i%4 == 0 {
+			level = "ERROR"
+		}
+		app := "web"
+		if i%3 == 0 {
+			app = "worker"
+		}
+		records = append(records, &LogRecord{
+			Timestamp: base.Add(time.Duration(i) * 7 * time.Second),
+			Level:     level,
+			Message:   "m",
+			RawLine:   "raw line",
+			Service:   []string{"api", "", "db"}[i%3],
+			App:       app,
+		})
+	}
+	insertTestRecords(t, store, records)
+}
+
+func TestRollupsMatchScan(t *testing.T) {
+	store := newTestStore(t)
+	zone := time.FixedZone("UTC+5:30", 5*3600+1800)
+	base := time.Date(2026, 3, 10, 12, 0, 13, 0, zone)
+	seedRollupLogs(t, store, base)
+
+	for _, opts := range []QueryOpts{
+		{},
+		{App: "web"},
+		{From: base.Add(95 * time.Second)},
+		{To: base.Add(400 * time.Second)},
+		{From: base.Add(95 * time.Second), To: base.Add(400 * time.Second), App: "worker"},
+		{From: base.Add(130 * time.Second), To: base.Add(150 * time.Second)},
+		{From: base.Add(-13 * time.Second), To: base.Add(47 * time.Second)},
+	} {
+		got, err := store.SeverityCounts(opts)
+		if err != nil {
+			t.Fatalf("SeverityCounts(%+v): %v", opts, err)
+		}
+		if want := scanSeverityCounts(t, store, opts); !reflect.DeepEqual(got, want) {
+			t.Errorf("SeverityCounts(%+v) = %v, want %v", opts, got, want)
+		}
+
+		var total int64
+		for _, n := range got {
+			total += n
+		}
+		if count, err := store.TotalLogCount(opts); err != nil || count != total {
+			t.Errorf("TotalLogCount(%+v) = %d, %v; want %d", opts, count, err, total)
+		}
+		if bytes, err := store.TotalLogBytes(opts); err != nil || bytes != total*int64(len("raw line")) {
+			t.Errorf("TotalLogBytes(%+v) = %d, %v; want %d", opts, bytes, err, total*8)
+		}
+
+		minutes, err := store.SeverityCountsByMinute(opts)
+		if err != nil {
+			t.Fatalf("SeverityCountsByMinute(%+v): %v", opts, err)
+		}
+		var byMinute int64
+		for _, mc := range minutes {
+			byMinute += mc.Total
+		}
+		if byMinute != total {
+			t.Errorf("SeverityCountsByMinute(%+v) totals %d, want %d", opts, byMinute, total)
+		}
+	}
+
+	services, err := store.TopServicesBySeverity("ERROR", 10, QueryOpts{})
+	if err != nil {
+		t.Fatalf("TopServicesBySeverity: %v", err)
+	}
+	// Every fourth record is an ERROR; services cycle every third.
+	want := []DimensionCount{{Value: "api", Count: 10}, {Value: "db", Count: 10}, {Value: "unknown", Count: 10}}
+	if !reflect.DeepEqual(services, want) {
+		t.Errorf("TopServicesBySeverity = %+v, want %+v", services, want)
+	}
+}
+
+func TestRollupsTrimmedWithRetention(t *testing.T) {
+	store := newTestStore(t)
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	seedRollupLogs(t, store, base)
+
+	if _, err := store.DeleteBefore(base.Add(100 * time.Second)); err != nil {
+		t.Fatalf("DeleteBefore: %v", err)
+	}
+	if got, want := mustSeverityCounts(t, store), scanSeverityCounts(t, store, QueryOpts{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("after DeleteBefore: SeverityCounts = %v, want %v", got, want)
+	}
+
+	if _, err := store.DeleteOldest(25); err != nil {
+		t.Fatalf("DeleteOldest: %v", err)
+	}
+	if got, want := mustSeverityCounts(t, store), scanSeverityCounts(t, store, QueryOpts{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("after DeleteOldest: SeverityCounts = %v, want %v", got, want)
+	}
+
+	if _, err := store.DeleteBefore(base.Add(time.Hour)); err != nil {
+		t.Fatalf("DeleteBefore: %v", err)
+	}
+	var rows int
+	if err := store.db.QueryRow(`SELECT count(*) FROM log_minute_rollups`).Scan(&rows); err != nil || rows != 0 {
+		t.Errorf("rollup rows after deleting everything = %d, %v; want 0", rows, err)
+	}
+}
+
+func TestSeverityCountsIncludeSuppressed(t *testing.T) {
+	store := newTestStore(t)
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: base, Level: "ERROR", Message: "kept", App: "payments"},
+	})
+
+	sink, err := ingest.NewSeverityThresholdSink(nil, map[string]string{"payments": "INFO"})
+	if err != nil {
+		t.Fatalf("NewSeverityThresholdSink: %v", err)
+	}
+	sink.PersistSuppressed(store, time.Hour)
+	sink.Add(&LogRecord{Timestamp: base.Add(5 * time.Second), Level: "DEBUG", Message: "noisy", App: "payments"})
+	sink.Add(&LogRecord{Timestamp: base.Add(65 * time.Second), Level: "DEBUG", Message: "noisy", App: "payments"})
+	sink.Add(&LogRecord{Timestamp: base.Add(70 * time.Second), Level: "TRACE", Message: "noisy", App: "payments"})
+	sink.Stop()
+
+	if got, want := mustSeverityCounts(t, store), map[string]int64{"ERROR": 1, "DEBUG": 2, "TRACE": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SeverityCounts = %v, want %v", got, want)
+	}
+	minutes, err := store.SeverityCountsByMinute(QueryOpts{App: "payments", From: base, To: base.Add(2 * time.Minute)})
+	if err != nil {
+		t.Fatalf("SeverityCountsByMinute: %v", err)
+	}
+	if len(minutes) != 2 || minutes[0].Debug != 1 || minutes[0].Error != 1 || minutes[1].Debug != 1 || minutes[1].Trace != 1 {
+		t.Errorf("SeverityCountsByMinute = %+v, want the suppressed records in their minutes", minutes)
+	}
+	// Suppressed records were never stored, so they don't count as logs.
+	if total, err := store.TotalLogCount(QueryOpts{}); err != nil || total != 1 {
+		t.Errorf("TotalLogCount = %d, %v; want 1", total, err)
+	}
+
+	if _, err := store.DeleteBefore(base.Add(time.Hour)); err != nil {
+		t.Fatalf("DeleteBefore: %v", err)
+	}
+	if got := mustSeverityCounts(t, store); len(got) != 0 {
+		t.Errorf("SeverityCounts after deleting every log = %v, want none", got)
+	}
+}
+
+func TestDeleteLogs(t *testing.T) {
+	for _, partitioned := range []bool{false, true} {
+		store := newTestStore(t)
+		if partitioned {
+			if err := store.EnableDayPartitions(); err != nil {
+				t.Fatalf("EnableDayPartitions: %v", err)
+			}
+		}
+		base := time.Date(2026, 3, 10, 23, 50, 3, 0, time.UTC) // spans midnight
+		seedRollupLogs(t, store, base)
+
+		for _, opts := range []QueryOpts{
+			{App: "worker"},
+			{From: base.Add(100 * time.Second), To: base.Add(400 * time.Second)},
+			{App: "web", To: base.Add(11 * time.Minute)},
+		} {
+			want, _ := store.TotalLogCount(QueryOpts{})
+			matched := scanSeverityCounts(t, store, opts)
+			deleted, err := store.DeleteLogs(opts)
+			if err != nil {
+				t.Fatalf("DeleteLogs(%+v): %v", opts, err)
+			}
+			var n int64
+			for _, c := range matched {
+				n += c
+			}
+			if deleted != n || n == 0 {
+				t.Errorf("partitioned=%v DeleteLogs(%+v) = %d, want %d (non-zero)", partitioned, opts, deleted, n)
+			}
+			if left, _ := store.TotalLogCount(QueryOpts{}); left != want-deleted {
+				t.Errorf("partitioned=%v TotalLogCount after delete = %d, want %d", partitioned, left, want-deleted)
+			}
+			if got, want := mustSeverityCounts(t, store), scanSeverityCounts(t, store, QueryOpts{}); !reflect.DeepEqual(got, want) {
+				t.Errorf("partitioned=%v after DeleteLogs(%+v): SeverityCounts = %v, want %v", partitioned, opts, got, want)
+			}
+			if n, _ := store.TotalLogCount(opts); n != 0 {
+				t.Errorf("partitioned=%v %d logs left in %+v", partitioned, n, opts)
+			}
+		}
+	}
+}
+
+func TestRebuildRollups(t *testing.T) {
+	store := newTestStore(t)
+	if _, err := store.db.Exec(`INSERT INTO logs (timestamp, level, message, service, app) VALUES (now(), 'WARN', 'direct', 'api', 'default')`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if counts := mustSeverityCounts(t, store); len(counts) != 0 {
+		t.Fatalf("SeverityCounts before rebuild = %v, want none", counts)
+	}
+	if err := store.RebuildRollups(); err != nil {
+		t.Fatalf("RebuildRollups: %v", err)
+	}
+	if counts := mustSeverityCounts(t, store); counts["WARN"] != 1 {
+		t.Errorf("SeverityCounts after rebuild = %v, want WARN:1", counts)
+	}
+}
+
+func mustSeverityCounts(t *testing.T, store *Store) map[string]int64 {
+	t.Helper()
+	counts, err := store.SeverityCounts(QueryOpts{})
+	if err != nil {
+		t.Fatalf("SeverityCounts: %v", err)
+	}
+	return counts
+}
+
+func TestRateByDimension(t *testing.T) {
+	store := newTestStore(t)
+	base := time.Date(2026, 3, 10, 12, 0, 13, 0, time.UTC)
+	seedRollupLogs(t, store, base)
+	opts := QueryOpts{To: base.Add(20 * time.Minute)}
+
+	// Whole-minute steps read the rollups; sub-minute steps scan logs. Summed
+	// into the same buckets, both must agree.
+	fromRollups, err := store.RateByDimension("service", time.Hour, 5*time.Minute, nil, opts)
+	if err != nil {
+		t.Fatalf("RateByDimension (rollups): %v", err)
+	}
+	fromScan, err := store.RateByDimension("service", time.Hour, 30*time.Second, nil, opts)
+	if err != nil {
+		t.Fatalf("RateByDimension (scan): %v", err)
+	}
+	sum := func(rates []DimensionRate) map[string]int64 {
+		out := make(map[string]int64)
+		for _, r := range rates {
+			out[r.Bucket.Truncate(5*time.Minute).Format("15:04")+" "+r.Value] += r.Count
+		}
+		return out
+	}
+	for _, r := range fromRollups {
+		if r.Bucket.Unix()%300 != 0 {
+			t.Errorf("bucket %s is not aligned to the 5m step", r.Bucket)
+		}
+	}
+	want := sum(fromRollups)
+	if got := sum(fromScan); !reflect.DeepEqual(got, want) {
+		t.Errorf("scan buckets = %v, rollup buckets = %v", got, want)
+	}
+	// 12:00:13 to 12:04:59 holds records 0-40, every third one per service.
+	if want["12:00 api"] != 14 || want["12:00 unknown"] != 14 || want["12:00 db"] != 13 {
+		t.Errorf("12:00 buckets = %v", want)
+	}
+
+	errorRates, err := store.RateByDimension("host", time.Hour, time.Minute, []string{"ERROR"}, opts)
+	if err != nil {
+		t.Fatalf("RateByDimension (host): %v", err)
+	}
+	var total int64
+	for _, r := range errorRates {
+		total += r.Count
+	}
+	if total != 30 || errorRates[0].Value != "unknown" {
+		t.Errorf("host ERROR rates = %v, want 30 records from unknown hosts", errorRates)
+	}
+
+	for _, bad := range []struct {
+		dimension    string
+		window, step time.Duration
+	}{{"pid", time.Hour, time.Minute}, {"service", 0, time.Minute}, {"service", 48 * time.Hour, time.Second}} {
+		if _, err := store.RateByDimension(bad.dimension, bad.window, bad.step, nil, opts); err == nil {
+			t.Errorf("RateByDimension(%q, %s, %s) succeeded", bad.dimension, bad.window, bad.step)
+		}
+	}
+}
+
+func TestIngestRate(t *testing.T) {
+	store := newTestStore(t)
+	base := time.Date(2026, 3, 10, 12, 0, 13, 0, time.UTC)
+	seedRollupLogs(t, store, base)
+	opts := QueryOpts{App: "web", To: base.Add(20 * time.Minute)}
+
+	// As with RateByDimension, the rollups and a scan of logs must agree.
+	fromRollups, err := store.IngestRate(time.Hour, 5*time.Minute, opts)
+	if err != nil {
+		t.Fatalf("IngestRate (rollups): %v", err)
+	}
+	fromScan, err := store.IngestRate(time.Hour, 30*time.Second, opts)
+	if err != nil {
+		t.Fatalf("IngestRate (scan): %v", err)
+	}
+	sum := func(points []model.IngestPoint) map[string][2]int64 {
+		out := make(map[string][2]int64)
+		for _, p := range points {
+			key := p.Bucket.Truncate(5 * time.Minute).Format("15:04")
+			out[key] = [2]int64{out[key][0] + p.Count, out[key][1] + p.Bytes}
+		}
+		return out
+	}
+	want := sum(fromRollups)
+	if got := sum(fromScan); !reflect.DeepEqual(got, want) {
+		t.Errorf("scan buckets = %v, rollup buckets = %v", got, want)
+	}
+	// 12:00:13 to 12:04:59 holds records 0-40; the 27 web ones carry 8 bytes each.
+	if want["12:00"] != [2]int64{27, 216} {
+		t.Errorf("12:00 bucket = %v, want 27 logs of 216 bytes", want["12:00"])
+	}
+
+	if _, err := store.IngestRate(48*time.Hour, time.Second, opts); err == nil {
+		t.Error("IngestRate over too many steps succeeded")
+	}
+}
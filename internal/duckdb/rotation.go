@@ -0,0 +1,450 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RotationPeriod is how much time each cold database file holds.
+type RotationPeriod string
+
+const (
+	RotateDaily   RotationPeriod = "day"
+	RotateWeekly  RotationPeriod = "week" // Monday to Sunday
+	RotateMonthly RotationPeriod = "month"
+)
+
+// ParseRotationPeriod parses "day", "week", or "month".
+func ParseRotationPeriod(s string) (RotationPeriod, error) {
+	switch p := RotationPeriod(strings.ToLower(strings.TrimSpace(s))); p {
+	case RotateDaily, RotateWeekly, RotateMonthly:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown rotation period %q (want %s, %s, or %s)", s, RotateDaily, RotateWeekly, RotateMonthly)
+}
+
+// start returns the UTC start of the period day falls in.
+func (p RotationPeriod) start(day time.Time) time.Time {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	switch p {
+	case RotateWeekly:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case RotateMonthly:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+// coldPattern matches the database files Rotate writes, one per rotated
+// period: logs-<first yyyymmdd>-<last yyyymmdd>-<unix nanos>.duckdb.
+const coldPattern = "logs-*.duckdb"
+
+// coldFile is a rotated database file attached read-only. It holds one
+// table per day partition, named like the partition.
+type coldFile struct {
+	path   string
+	alias  string // catalog name it is attached as
+	first  string // partition name of its first day
+	last   string // partition name of its last day
+	tables []string
+}
+
+// EnableRotation lets Rotate move the day partitions of each finished
+// period out of the database file into a database file of its own under
+// dir, attached read-only and read by the logs view alongside the
+// partitions. The active file then holds only the current period, so it
+// stays small and snapshots copy only recent data. The directory is
+// recorded in the database; a store opened on it later keeps reading the
+// rotated files whether or not this is called. Requires day partitions.
+func (s *Store) EnableRotation(dir string, period RotationPeriod) error {
+	ctx := context.Background()
+
+	if _, err := ParseRotationPeriod(string(period)); err != nil {
+		return fmt.Errorf("rotation: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.partitions == nil {
+		return errors.New("rotation: logs are not partitioned by day")
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("rotation: %w", err)
+	}
+	if err := os.MkdirAll(abs, 0o755); err != nil {
+		return fmt.Errorf("rotation: %w", err)
+	}
+	if abs == s.coldDir {
+		s.rotatePeriod = period
+		return nil
+	}
+
+	// Switch to the files of the new directory.
+	prev := s.cold
+	files, err := s.attachColdDir(ctx, abs)
+	if err != nil {
+		return fmt.Errorf("rotation: %w", err)
+	}
+	s.cold = files
+	err = func() error {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+		if _, err := tx.ExecContext(ctx, `DELETE FROM log_rotation`); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO log_rotation VALUES (?)`, abs); err != nil {
+			return err
+		}
+		if err := s.replaceLogsView(ctx, tx, s.partitions); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}()
+	if err != nil {
+		s.cold = prev
+		s.detachCold(ctx, files)
+		return fmt.Errorf("rotation: %w", err)
+	}
+	s.detachCold(ctx, prev)
+	s.coldDir = abs
+	s.rotatePeriod = period
+	return nil
+}
+
+// loadRotation attaches the rotated files in the directory recorded by
+// EnableRotation. It runs before anything reads logs, whose view names
+// them.
+func (s *Store) loadRotation(ctx context.Context) error {
+	var dir string
+	err := s.db.QueryRowContext(ctx, `SELECT dir FROM log_rotation LIMIT 1`).Scan(&dir)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	files, err := s.attachColdDir(ctx, dir)
+	if err != nil {
+		return fmt.Errorf("rotation: %w", err)
+	}
+	s.coldDir = dir
+	s.cold = files
+	return nil
+}
+
+// Rotate moves the day partitions of every period that ended before now
+// into one database file per period and returns how many rows moved.
+// Rollups and the search index are left alone: the rows are still in logs.
+// It does nothing unless EnableRotation was called.
+func (s *Store) Rotate(now time.Time) (int64, error) {
+	ctx := context.Background()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rotatePeriod == "" || s.partitions == nil {
+		return 0, nil
+	}
+	current := partitionName(s.rotatePeriod.start(now.UTC()))
+	var periods [][]string
+	var periodStart string
+	for _, name := range sortedPartitions(s.partitions) {
+		if name >= current {
+			break
+		}
+		day, err := time.Parse(partitionDayLayout, strings.TrimPrefix(name, "d"))
+		if err != nil {
+			continue
+		}
+		if start := partitionName(s.rotatePeriod.start(day)); start != periodStart || len(periods) == 0 {
+			periodStart = start
+			periods = append(periods, nil)
+		}
+		periods[len(periods)-1] = append(periods[len(periods)-1], name)
+	}
+
+	var rotated int64
+	for _, names := range periods {
+		n, err := s.rotatePartitions(ctx, names)
+		if err != nil {
+			return rotated, fmt.Errorf("rotate %s: %w", names[0], err)
+		}
+		rotated += n
+	}
+	return rotated, nil
+}
+
+// rotatePartitions copies partitions into a new database file, attaches it
+// read-only, then drops them and repoints the logs view in one
+// transaction. The file is written under a temporary name and renamed into
+// place, so a store opening the directory never attaches a partial file;
+// it is removed again if the drop fails.
+func (s *Store) rotatePartitions(ctx context.Context, names []string) (int64, error) {
+	base := fmt.Sprintf("logs-%s-%s-%d.duckdb",
+		strings.TrimPrefix(names[0], "d"), strings.TrimPrefix(names[len(names)-1], "d"), time.Now().UnixNano())
+	path := filepath.Join(s.coldDir, base)
+	if err := s.writeColdFile(ctx, path, names); err != nil {
+		return 0, err
+	}
+
+	file, err := s.attachCold(ctx, path)
+	if err != nil {
+		os.Remove(path)
+		return 0, err
+	}
+	prev := s.cold
+	s.cold = append(s.cold[:len(s.cold):len(s.cold)], file)
+	sortColdFiles(s.cold)
+
+	var rotated int64
+	err = func() error {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+		for _, name := range names {
+			n, err := dropPartition(ctx, tx, name)
+			if err != nil {
+				return err
+			}
+			rotated += n
+		}
+		if err := s.afterDrop(ctx, tx, names); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}()
+	if err != nil {
+		s.cold = prev
+		s.detachCold(ctx, []coldFile{file})
+		os.Remove(path)
+		return 0, err
+	}
+	for _, name := range names {
+		delete(s.partitions, name)
+	}
+	return rotated, nil
+}
+
+// writeColdFile creates the database file at path holding a copy of each
+// partition.
+func (s *Store) writeColdFile(ctx context.Context, path string, names []string) error {
+	tmp := path + ".tmp"
+	alias := coldAlias(path) + "_w"
+	if _, err := s.db.ExecContext(ctx, `ATTACH `+quoteSQLString(tmp)+` AS `+alias); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, err := s.db.ExecContext(ctx, `CREATE TABLE `+alias+`.main.`+name+` AS
+			SELECT * FROM `+partitionTable(name)+` ORDER BY timestamp, id`); err != nil {
+			s.db.ExecContext(ctx, `DETACH `+alias)
+			removeDatabaseFile(tmp)
+			return err
+		}
+	}
+	if _, err := s.db.ExecContext(ctx, `DETACH `+alias); err != nil {
+		removeDatabaseFile(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		removeDatabaseFile(tmp)
+		return err
+	}
+	return nil
+}
+
+// attachColdDir attaches every rotated file in dir, oldest first. On error
+// the files attached so far are detached again.
+func (s *Store) attachColdDir(ctx context.Context, dir string) ([]coldFile, error) {
+	paths, _ := filepath.Glob(filepath.Join(dir, coldPattern))
+	var files []coldFile
+	for _, path := range paths {
+		file, err := s.attachCold(ctx, path)
+		if err != nil {
+			s.detachCold(ctx, files)
+			return nil, fmt.Errorf("attach %s: %w", filepath.Base(path), err)
+		}
+		files = append(files, file)
+	}
+	sortColdFiles(files)
+	return files, nil
+}
+
+// attachCold attaches the rotated file at path read-only and lists its day
+// tables.
+func (s *Store) attachCold(ctx context.Context, path string) (coldFile, error) {
+	file := coldFile{path: path, alias: coldAlias(path)}
+	file.first, file.last = coldFileDays(path)
+	if file.first == "" {
+		return coldFile{}, errors.New("not a rotated log file name")
+	}
+	if _, err := s.db.ExecContext(ctx, `ATTACH `+quoteSQLString(path)+` AS `+file.alias+` (READ_ONLY)`); err != nil {
+		return coldFile{}, err
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT table_name FROM duckdb_tables()
+		WHERE database_name = ? AND schema_name = 'main' ORDER BY table_name`, file.alias)
+	if err != nil {
+		s.db.ExecContext(ctx, `DETACH `+file.alias)
+		return coldFile{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			s.db.ExecContext(ctx, `DETACH `+file.alias)
+			return coldFile{}, err
+		}
+		file.tables = append(file.tables, name)
+	}
+	if err := rows.Err(); err != nil {
+		s.db.ExecContext(ctx, `DETACH `+file.alias)
+		return coldFile{}, err
+	}
+	return file, nil
+}
+
+// detachCold detaches files, which the logs view must no longer name.
+func (s *Store) detachCold(ctx context.Context, files []coldFile) {
+	for _, file := range files {
+		s.db.ExecContext(ctx, `DETACH DATABASE IF EXISTS `+file.alias)
+	}
+}
+
+// coldSelects returns the SELECTs the logs view reads the rotated files
+// with.
+func (s *Store) coldSelects() []string {
+	var selects []string
+	for _, file := range s.cold {
+		for _, table := range file.tables {
+			selects = append(selects, `SELECT * FROM `+file.alias+`.main.`+table)
+		}
+	}
+	return selects
+}
+
+// expireCold drops files from the logs view and returns how many rows they
+// held. They are detached and deleted by deleteLogs once tx commits.
+func (s *Store) expireCold(ctx context.Context, tx *sql.Tx, files []coldFile) (int64, error) {
+	if len(files) == 0 {
+		return 0, nil
+	}
+	var count int64
+	for _, file := range files {
+		for _, table := range file.tables {
+			var n int64
+			if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM `+file.alias+`.main.`+table).Scan(&n); err != nil {
+				return 0, fmt.Errorf("count %s: %w", filepath.Base(file.path), err)
+			}
+			count += n
+		}
+	}
+	expired := make(map[string]bool, len(files))
+	for _, file := range files {
+		expired[file.path] = true
+	}
+	var kept []coldFile
+	for _, file := range s.cold {
+		if !expired[file.path] {
+			kept = append(kept, file)
+		}
+	}
+	s.cold = kept
+	s.coldExpired = append(s.coldExpired, files...)
+	return count, s.replaceLogsView(ctx, tx, s.partitions)
+}
+
+// deleteColdBefore expires the rotated files wholly before cutoffDay.
+func (s *Store) deleteColdBefore(ctx context.Context, tx *sql.Tx, cutoffDay string) (int64, error) {
+	var files []coldFile
+	for _, file := range s.cold {
+		if file.last < cutoffDay {
+			files = append(files, file)
+		}
+	}
+	return s.expireCold(ctx, tx, files)
+}
+
+// deleteOldestCold expires the oldest rotated files while they fit in n. A
+// file is only deleted whole, so it reports whether every file went and
+// the partitions may be trimmed next.
+func (s *Store) deleteOldestCold(ctx context.Context, tx *sql.Tx, n int64) (int64, bool, error) {
+	var deleted int64
+	for _, file := range s.cold {
+		var count int64
+		for _, table := range file.tables {
+			var rows int64
+			if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM `+file.alias+`.main.`+table).Scan(&rows); err != nil {
+				return 0, false, fmt.Errorf("count %s: %w", filepath.Base(file.path), err)
+			}
+			count += rows
+		}
+		if count > n-deleted {
+			return deleted, false, nil
+		}
+		if _, err := s.expireCold(ctx, tx, []coldFile{file}); err != nil {
+			return 0, false, err
+		}
+		deleted += count
+	}
+	return deleted, true, nil
+}
+
+// removeExpiredCold detaches and deletes the files expireCold dropped from
+// the view. The caller holds s.mu.
+func (s *Store) removeExpiredCold(ctx context.Context) error {
+	var errs []error
+	for _, file := range s.coldExpired {
+		if _, err := s.db.ExecContext(ctx, `DETACH DATABASE IF EXISTS `+file.alias); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := removeDatabaseFile(file.path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	s.coldExpired = nil
+	return errors.Join(errs...)
+}
+
+// coldFileDays returns the partition names of the first and last day a
+// rotated file holds, or "" when the name does not parse.
+func coldFileDays(path string) (first, last string) {
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "logs-"), ".duckdb"), "-")
+	if len(parts) != 3 || len(parts[0]) != len(partitionDayLayout) || len(parts[1]) != len(partitionDayLayout) {
+		return "", ""
+	}
+	return "d" + parts[0], "d" + parts[1]
+}
+
+// coldAlias returns the catalog name a rotated file is attached as.
+func coldAlias(path string) string {
+	name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "logs-"), ".duckdb")
+	return "cold_" + strings.NewReplacer("-", "_", ".", "_").Replace(name)
+}
+
+// sortColdFiles orders files oldest first.
+func sortColdFiles(files []coldFile) {
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+}
+
+// removeDatabaseFile removes a database file and its write-ahead log.
+func removeDatabaseFile(path string) error {
+	os.Remove(path + ".wal")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
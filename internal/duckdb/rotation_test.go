@@ -0,0 +1,155 @@
+package duckdb
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRotationPeriodStart(t *testing.T) {
+	day := time.Date(2026, 3, 15, 18, 30, 0, 0, time.UTC) // a Sunday
+	for period, want := range map[RotationPeriod]time.Time{
+		RotateDaily:   time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		RotateWeekly:  time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+		RotateMonthly: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		if got := period.start(day); !got.Equal(want) {
+			t.Errorf("%s start = %v, want %v", period, got, want)
+		}
+	}
+	if _, err := ParseRotationPeriod("fortnight"); err == nil {
+		t.Error("ParseRotationPeriod accepted an unknown period")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.duckdb")
+	coldDir := filepath.Join(t.TempDir(), "cold")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := store.EnableDayPartitions(); err != nil {
+		t.Fatalf("EnableDayPartitions: %v", err)
+	}
+	if err := store.EnableRotation(coldDir, RotateWeekly); err != nil {
+		t.Fatalf("EnableRotation: %v", err)
+	}
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC) // a Tuesday
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), Level: "INFO", Message: "two weeks ago", Attributes: map[string]string{"user": "ann"}},
+		{Timestamp: time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC), Level: "WARN", Message: "last tuesday"},
+		{Timestamp: time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC), Level: "WARN", Message: "last thursday"},
+		{Timestamp: now, Level: "ERROR", Message: "today"},
+	})
+
+	rotated, err := store.Rotate(now)
+	if err != nil || rotated != 3 {
+		t.Fatalf("Rotate = %d, %v; want 3", rotated, err)
+	}
+	if got, want := partitionNames(t, store), []string{"d20260310"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("partitions = %v, want %v", got, want)
+	}
+	files, _ := filepath.Glob(filepath.Join(coldDir, coldPattern))
+	if len(files) != 2 {
+		t.Fatalf("rotated files = %v, want one per week", files)
+	}
+	if rotated, err := store.Rotate(now); err != nil || rotated != 0 {
+		t.Fatalf("second Rotate = %d, %v; want 0", rotated, err)
+	}
+
+	// Rotated days stay visible, including to columns promoted afterwards.
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 4 {
+		t.Fatalf("TotalLogCount = %d, %v; want 4", count, err)
+	}
+	logs, err := store.RecentLogsFiltered(10, QueryOpts{}, nil, nil, "last thursday")
+	if err != nil || len(logs) != 1 || logs[0].Level != "WARN" {
+		t.Fatalf("search rotated day = %+v, %v", logs, err)
+	}
+	if err := store.PromoteAttributes([]PromotedAttribute{{Key: "user", Type: "string"}}); err != nil {
+		t.Fatalf("PromoteAttributes: %v", err)
+	}
+	var users int
+	if err := store.db.QueryRow(`SELECT count(attr_user) FROM logs`).Scan(&users); err != nil || users != 0 {
+		t.Fatalf("promoted column over rotated files = %d, %v; want 0", users, err)
+	}
+	store.Close()
+
+	// A reopened store attaches the rotated files without EnableRotation.
+	store, err = NewStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer store.Close()
+	if count, err := store.TotalLogCount(QueryOpts{From: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)}); err != nil || count != 3 {
+		t.Fatalf("TotalLogCount after reopen = %d, %v; want 3", count, err)
+	}
+
+	// Retention deletes a rotated file once its last day is past.
+	deleted, err := store.DeleteBefore(time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC))
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeleteBefore = %d, %v; want 1", deleted, err)
+	}
+	files, _ = filepath.Glob(filepath.Join(coldDir, coldPattern))
+	if len(files) != 1 {
+		t.Fatalf("rotated files after retention = %v, want 1", files)
+	}
+	if _, err := os.Stat(files[0]); err != nil {
+		t.Fatalf("remaining rotated file: %v", err)
+	}
+	if got := mustSeverityCounts(t, store); !reflect.DeepEqual(got, map[string]int64{"ERROR": 1, "WARN": 2}) {
+		t.Errorf("SeverityCounts after retention = %v", got)
+	}
+
+	// The row limit only deletes a rotated file whole.
+	if deleted, err := store.DeleteOldest(1); err != nil || deleted != 0 {
+		t.Fatalf("DeleteOldest(1) = %d, %v; want 0", deleted, err)
+	}
+	if deleted, err := store.DeleteOldest(3); err != nil || deleted != 3 {
+		t.Fatalf("DeleteOldest(3) = %d, %v; want 3", deleted, err)
+	}
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 0 {
+		t.Fatalf("TotalLogCount after DeleteOldest = %d, %v; want 0", count, err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(coldDir, coldPattern)); len(files) != 0 {
+		t.Fatalf("rotated files after DeleteOldest = %v, want none", files)
+	}
+}
+
+func TestEnableRotation_RequiresPartitions(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.EnableRotation(t.TempDir(), RotateWeekly); err == nil {
+		t.Fatal("EnableRotation on an unpartitioned store succeeded")
+	}
+}
+
+func TestRetentionCleaner_Rotate(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.EnableDayPartitions(); err != nil {
+		t.Fatalf("EnableDayPartitions: %v", err)
+	}
+	if err := store.EnableRotation(t.TempDir(), RotateDaily); err != nil {
+		t.Fatalf("EnableRotation: %v", err)
+	}
+	now := time.Now()
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: now.AddDate(0, 0, -3), Level: "INFO", Message: "old"},
+		{Timestamp: now, Level: "INFO", Message: "new"},
+	})
+
+	cleaner := NewRetentionCleaner(store, RetentionConfig{Rotate: true})
+	if cleaner == nil {
+		t.Fatal("expected a cleaner with rotation enabled")
+	}
+	defer cleaner.Stop()
+
+	if stats := cleaner.RetentionStats(); stats.Rotated != 1 || stats.Expired != 0 {
+		t.Errorf("stats = %+v, want 1 rotated", stats)
+	}
+	if count, err := store.TotalLogCount(QueryOpts{}); err != nil || count != 2 {
+		t.Errorf("TotalLogCount = %d, %v; want 2", count, err)
+	}
+}
@@ -0,0 +1,140 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// SaveQuery creates q when its ID is zero and replaces the saved query with
+// its ID otherwise. The name must be unique and the SQL a read-only query
+// ExecuteQuery accepts.
+func (s *Store) SaveQuery(q model.SavedQuery) (model.SavedQuery, error) {
+	q.Name = strings.TrimSpace(q.Name)
+	q.Description = strings.TrimSpace(q.Description)
+	if q.Name == "" {
+		return q, fmt.Errorf("%w: name is required", model.ErrSavedQueryInvalid)
+	}
+	trimmed, err := validateReadOnlyQuery(q.SQL)
+	if err != nil {
+		return q, fmt.Errorf("%w: %v", model.ErrSavedQueryInvalid, err)
+	}
+	q.SQL = trimmed
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.QueryTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return q, err
+	}
+	defer tx.Rollback()
+
+	var taken int64
+	err = tx.QueryRowContext(ctx, `SELECT id FROM saved_queries WHERE name = ? AND id <> ?`, q.Name, q.ID).Scan(&taken)
+	if err == nil {
+		return q, fmt.Errorf("%w: %q", model.ErrSavedQueryExists, q.Name)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return q, err
+	}
+
+	if q.ID == 0 {
+		q.CreatedAt, q.UpdatedAt = now, now
+		err = tx.QueryRowContext(ctx, `INSERT INTO saved_queries (name, description, sql, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?) RETURNING id`,
+			q.Name, q.Description, q.SQL, q.CreatedAt, q.UpdatedAt).Scan(&q.ID)
+	} else {
+		q.UpdatedAt = now
+		err = tx.QueryRowContext(ctx, `UPDATE saved_queries SET name = ?, description = ?, sql = ?, updated_at = ?
+			WHERE id = ? RETURNING created_at`,
+			q.Name, q.Description, q.SQL, q.UpdatedAt, q.ID).Scan(&q.CreatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return q, model.ErrSavedQueryNotFound
+		}
+	}
+	if err != nil {
+		return q, err
+	}
+	return q, tx.Commit()
+}
+
+// SavedQueries lists the saved queries by name.
+func (s *Store) SavedQueries() ([]model.SavedQuery, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name, description, sql, created_at, updated_at
+		FROM saved_queries ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var queries []model.SavedQuery
+	for rows.Next() {
+		var q model.SavedQuery
+		if err := rows.Scan(&q.ID, &q.Name, &q.Description, &q.SQL, &q.CreatedAt, &q.UpdatedAt); err != nil {
+			log.Printf("duckdb scan error (SavedQueries): %v", err)
+			continue
+		}
+		queries = append(queries, q)
+	}
+	return queries, rows.Err()
+}
+
+// SavedQuery returns the saved query with id, or
+// model.ErrSavedQueryNotFound.
+func (s *Store) SavedQuery(id int64) (model.SavedQuery, error) {
+	q := model.SavedQuery{ID: id}
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return q, err
+	}
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	err = s.db.QueryRowContext(ctx, `SELECT name, description, sql, created_at, updated_at
+		FROM saved_queries WHERE id = ?`, id).Scan(&q.Name, &q.Description, &q.SQL, &q.CreatedAt, &q.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return q, model.ErrSavedQueryNotFound
+	}
+	return q, err
+}
+
+// DeleteSavedQuery deletes the saved query with id, or returns
+// model.ErrSavedQueryNotFound.
+func (s *Store) DeleteSavedQuery(id int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.QueryTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM saved_queries WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return model.ErrSavedQueryNotFound
+	}
+	return nil
+}
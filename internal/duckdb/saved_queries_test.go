@@ -0,0 +1,66 @@
+package duckdb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestSavedQueries(t *testing.T) {
+	store := newTestStore(t)
+
+	errorsByService, err := store.SaveQuery(model.SavedQuery{Name: " errors by service ", SQL: " SELECT service, count(*) FROM logs WHERE level = 'ERROR' GROUP BY 1 "})
+	if err != nil {
+		t.Fatalf("SaveQuery: %v", err)
+	}
+	if errorsByService.ID == 0 || errorsByService.Name != "errors by service" || errorsByService.CreatedAt.IsZero() {
+		t.Fatalf("saved = %+v, want an id, a trimmed name, and a creation time", errorsByService)
+	}
+	if _, err := store.SaveQuery(model.SavedQuery{Name: "apps", SQL: "SELECT DISTINCT app FROM logs"}); err != nil {
+		t.Fatalf("SaveQuery(apps): %v", err)
+	}
+
+	for _, q := range []model.SavedQuery{
+		{Name: "", SQL: "SELECT 1"},
+		{Name: "wipe", SQL: "DELETE FROM logs"},
+	} {
+		if _, err := store.SaveQuery(q); !errors.Is(err, model.ErrSavedQueryInvalid) {
+			t.Errorf("SaveQuery(%+v) error = %v, want ErrSavedQueryInvalid", q, err)
+		}
+	}
+	if _, err := store.SaveQuery(model.SavedQuery{Name: "apps", SQL: "SELECT 1"}); !errors.Is(err, model.ErrSavedQueryExists) {
+		t.Errorf("duplicate name error = %v, want ErrSavedQueryExists", err)
+	}
+
+	errorsByService.Description = "for the on-call"
+	updated, err := store.SaveQuery(errorsByService)
+	if err != nil {
+		t.Fatalf("SaveQuery(update): %v", err)
+	}
+	if !updated.CreatedAt.Equal(errorsByService.CreatedAt) || updated.UpdatedAt.Before(errorsByService.UpdatedAt) {
+		t.Errorf("updated times = %v, %v", updated.CreatedAt, updated.UpdatedAt)
+	}
+	if _, err := store.SaveQuery(model.SavedQuery{ID: 999, Name: "gone", SQL: "SELECT 1"}); !errors.Is(err, model.ErrSavedQueryNotFound) {
+		t.Errorf("update of a missing query error = %v, want ErrSavedQueryNotFound", err)
+	}
+
+	got, err := store.SavedQuery(errorsByService.ID)
+	if err != nil || got.Description != "for the on-call" || got.SQL != "SELECT service, count(*) FROM logs WHERE level = 'ERROR' GROUP BY 1" {
+		t.Errorf("SavedQuery = %+v, %v", got, err)
+	}
+	all, err := store.SavedQueries()
+	if err != nil || len(all) != 2 || all[0].Name != "apps" {
+		t.Errorf("SavedQueries = %+v, %v; want apps first", all, err)
+	}
+
+	if err := store.DeleteSavedQuery(errorsByService.ID); err != nil {
+		t.Fatalf("DeleteSavedQuery: %v", err)
+	}
+	if err := store.DeleteSavedQuery(errorsByService.ID); !errors.Is(err, model.ErrSavedQueryNotFound) {
+		t.Errorf("second delete error = %v, want ErrSavedQueryNotFound", err)
+	}
+	if _, err := store.SavedQuery(errorsByService.ID); !errors.Is(err, model.ErrSavedQueryNotFound) {
+		t.Errorf("SavedQuery after delete error = %v, want ErrSavedQueryNotFound", err)
+	}
+}
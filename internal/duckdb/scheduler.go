@@ -0,0 +1,132 @@
+package duckdb
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// queryPriority orders queries waiting for a slot.
+type queryPriority int
+
+const (
+	// priorityInteractive is the dashboard reads behind the TUI, the socket,
+	// and the API's bounded endpoints.
+	priorityInteractive queryPriority = iota
+	// priorityBulk is ad-hoc SQL, whose cost has no bound.
+	priorityBulk
+)
+
+// queryScheduler admits read queries to a fixed number of slots. A query
+// that finds none free waits in its priority's queue; freed slots go to
+// interactive queries first, and bulk queries never hold more than
+// bulkSlots, so a heavy /api/query cannot starve the dashboard. A query is
+// shed with ErrOverloaded when its queue is full or its timeout passes
+// while it waits.
+type queryScheduler struct {
+	slots     int
+	bulkSlots int // at most this many slots run bulk queries
+	maxQueued int // per priority
+
+	mu      sync.Mutex
+	running [2]int
+	queues  [2][]chan struct{}
+
+	shed [2]atomic.Int64
+}
+
+// newQueryScheduler returns a scheduler with slots slots, up to half of
+// them (at least one) for bulk queries, and queues as long as slots.
+func newQueryScheduler(slots int) *queryScheduler {
+	return &queryScheduler{
+		slots:     slots,
+		bulkSlots: max(slots/2, 1),
+		maxQueued: slots,
+	}
+}
+
+// acquire takes a slot for a query of priority p, waiting until ctx is done
+// at most. The caller calls release(p) once the query finishes.
+func (q *queryScheduler) acquire(ctx context.Context, p queryPriority) error {
+	q.mu.Lock()
+	if q.admits(p) && len(q.queues[p]) == 0 && (p == priorityInteractive || len(q.queues[priorityInteractive]) == 0) {
+		q.running[p]++
+		q.mu.Unlock()
+		return nil
+	}
+	if len(q.queues[p]) >= q.maxQueued {
+		q.mu.Unlock()
+		q.shed[p].Add(1)
+		return ErrOverloaded
+	}
+	ready := make(chan struct{})
+	q.queues[p] = append(q.queues[p], ready)
+	q.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+	}
+	q.mu.Lock()
+	if i := slices.Index(q.queues[p], ready); i >= 0 {
+		q.queues[p] = slices.Delete(q.queues[p], i, i+1)
+		q.mu.Unlock()
+	} else {
+		// Granted a slot as the wait ended; hand it on.
+		q.mu.Unlock()
+		q.release(p)
+	}
+	q.shed[p].Add(1)
+	return ErrOverloaded
+}
+
+// release frees a slot of priority p and grants the freed capacity to the
+// waiting queries, interactive ones first.
+func (q *queryScheduler) release(p queryPriority) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.running[p]--
+	for _, next := range []queryPriority{priorityInteractive, priorityBulk} {
+		for len(q.queues[next]) > 0 && q.admits(next) {
+			close(q.queues[next][0])
+			q.queues[next] = q.queues[next][1:]
+			q.running[next]++
+		}
+	}
+}
+
+// admits reports whether a query of priority p may run now. The caller
+// holds q.mu.
+func (q *queryScheduler) admits(p queryPriority) bool {
+	if q.running[priorityInteractive]+q.running[priorityBulk] >= q.slots {
+		return false
+	}
+	return p == priorityInteractive || q.running[priorityBulk] < q.bulkSlots
+}
+
+// stats returns the scheduler's current load and how many queries it has
+// shed.
+func (q *queryScheduler) stats() QueryStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return QueryStats{
+		Slots:           q.slots,
+		Running:         q.running[priorityInteractive] + q.running[priorityBulk],
+		Queued:          len(q.queues[priorityInteractive]) + len(q.queues[priorityBulk]),
+		ShedInteractive: q.shed[priorityInteractive].Load(),
+		ShedBulk:        q.shed[priorityBulk].Load(),
+	}
+}
+
+// QueryStats reports the read query scheduler's load; it is zero when
+// concurrency is unlimited.
+func (s *Store) QueryStats() QueryStats {
+	sched := s.queries.Load()
+	if sched == nil {
+		return QueryStats{}
+	}
+	return sched.stats()
+}
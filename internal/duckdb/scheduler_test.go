@@ -0,0 +1,114 @@
+package duckdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQueryScheduler_InteractiveFirst(t *testing.T) {
+	q := newQueryScheduler(2)
+	ctx := context.Background()
+
+	// Bulk queries get at most half the slots.
+	if err := q.acquire(ctx, priorityBulk); err != nil {
+		t.Fatalf("first bulk acquire: %v", err)
+	}
+	bulk := make(chan error, 1)
+	go func() { bulk <- q.acquire(ctx, priorityBulk) }()
+	waitQueued(t, q, 1)
+
+	// An interactive query still runs beside it, filling the slots.
+	if err := q.acquire(ctx, priorityInteractive); err != nil {
+		t.Fatalf("interactive acquire: %v", err)
+	}
+	interactive := make(chan error, 1)
+	go func() { interactive <- q.acquire(ctx, priorityInteractive) }()
+	waitQueued(t, q, 2)
+
+	// The freed bulk slot goes to the interactive query queued after the
+	// bulk one.
+	q.release(priorityBulk)
+	if err := <-interactive; err != nil {
+		t.Fatalf("queued interactive acquire: %v", err)
+	}
+	select {
+	case err := <-bulk:
+		t.Fatalf("bulk query admitted over an interactive one: %v", err)
+	default:
+	}
+
+	q.release(priorityInteractive)
+	if err := <-bulk; err != nil {
+		t.Fatalf("queued bulk acquire: %v", err)
+	}
+	if stats := q.stats(); stats.Running != 2 || stats.Queued != 0 {
+		t.Fatalf("stats = %+v, want 2 running", stats)
+	}
+}
+
+func TestQueryScheduler_Sheds(t *testing.T) {
+	q := newQueryScheduler(1)
+	if err := q.acquire(context.Background(), priorityInteractive); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	// A queued query is shed when its timeout passes.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := q.acquire(ctx, priorityInteractive); !errors.Is(err, ErrOverloaded) {
+		t.Fatalf("acquire past timeout = %v, want ErrOverloaded", err)
+	}
+
+	// A query finding its queue full is shed at once.
+	waiting := make(chan error, 1)
+	go func() { waiting <- q.acquire(context.Background(), priorityBulk) }()
+	waitQueued(t, q, 1)
+	if err := q.acquire(context.Background(), priorityBulk); !errors.Is(err, ErrOverloaded) {
+		t.Fatalf("acquire on a full queue = %v, want ErrOverloaded", err)
+	}
+
+	stats := q.stats()
+	if stats.ShedInteractive != 1 || stats.ShedBulk != 1 {
+		t.Fatalf("stats = %+v, want one shed of each", stats)
+	}
+	q.release(priorityInteractive)
+	if err := <-waiting; err != nil {
+		t.Fatalf("queued acquire: %v", err)
+	}
+}
+
+func TestStore_QueryOverloaded(t *testing.T) {
+	store := newTestStore(t)
+	store.QueryTimeout = 20 * time.Millisecond
+	store.SetMaxConcurrentQueries(1)
+
+	// Hold the only slot as a running query would.
+	sched := store.queries.Load()
+	if err := sched.acquire(context.Background(), priorityInteractive); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	if _, err := store.TopServices(5, QueryOpts{}); !errors.Is(err, ErrOverloaded) {
+		t.Fatalf("TopServices = %v, want ErrOverloaded", err)
+	}
+	sched.release(priorityInteractive)
+	if _, err := store.TopServices(5, QueryOpts{}); err != nil {
+		t.Fatalf("TopServices after release: %v", err)
+	}
+	if stats := store.QueryStats(); stats.Slots != 1 || stats.ShedInteractive != 1 {
+		t.Fatalf("QueryStats = %+v", stats)
+	}
+}
+
+// waitQueued waits until n queries are queued.
+func waitQueued(t *testing.T, q *queryScheduler, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for q.stats().Queued != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("queued = %d, want %d", q.stats().Queued, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,229 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// searchBlockSize is the number of consecutive log ids one search index
+// entry covers. The index records which trigrams occur in each block, not
+// in each row, so it stays small and cheap to maintain.
+const searchBlockSize = 8192
+
+// maxSearchRanges bounds the id ranges a search reads through the index.
+// A term whose candidate blocks need more ranges is too common for the index
+// to help, and the query scans instead.
+const maxSearchRanges = 64
+
+// EnableSearchIndex turns on the trigram search index over message. Once
+// enabled, every insert records the lowercase trigrams of each message per
+// block of log ids, and SearchLogs and literal RecentLogsFiltered patterns
+// narrow the scan to the blocks that contain every trigram of the term.
+// Results are unchanged; the index only skips blocks that cannot match.
+// Logs written while the index was off are indexed here.
+func (s *Store) EnableSearchIndex() error {
+	ctx := context.Background()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS log_search_blocks (
+		trigram VARCHAR NOT NULL,
+		block BIGINT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create search index: %w", err)
+	}
+
+	// Resume from the last indexed block; it may have been partial.
+	var from sql.NullInt64
+	if err := s.db.QueryRowContext(ctx, `SELECT max(block) * ? FROM log_search_blocks`, searchBlockSize).Scan(&from); err != nil {
+		return fmt.Errorf("search index backfill: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT id, message FROM logs WHERE id >= ? ORDER BY id`, from.Int64)
+	if err != nil {
+		return fmt.Errorf("search index backfill: %w", err)
+	}
+	blocks := make(map[int64]map[string]struct{})
+	for rows.Next() {
+		var id int64
+		var message string
+		if err := rows.Scan(&id, &message); err != nil {
+			rows.Close()
+			return fmt.Errorf("search index backfill: %w", err)
+		}
+		addTrigrams(blocks, id, message)
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("search index backfill: %w", err)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("search index backfill: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := writeSearchBlocks(ctx, tx, blocks); err != nil {
+		return fmt.Errorf("search index backfill: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.searchIndex = true
+	return nil
+}
+
+// indexInsertedLogs records the trigrams of records just inserted in tx,
+// which were assigned the consecutive ids ending at the sequence's current
+// value.
+func (s *Store) indexInsertedLogs(ctx context.Context, tx *sql.Tx, records []*LogRecord) error {
+	var lastID int64
+	if err := tx.QueryRowContext(ctx, `SELECT currval('logs_id_seq')`).Scan(&lastID); err != nil {
+		return fmt.Errorf("search index: %w", err)
+	}
+	firstID := lastID - int64(len(records)) + 1
+	blocks := make(map[int64]map[string]struct{})
+	for i, r := range records {
+		addTrigrams(blocks, firstID+int64(i), r.Message)
+	}
+	if err := writeSearchBlocks(ctx, tx, blocks); err != nil {
+		return fmt.Errorf("search index: %w", err)
+	}
+	return nil
+}
+
+// pruneSearchIndex drops index blocks that lie wholly before the oldest
+// remaining log.
+func (s *Store) pruneSearchIndex(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM log_search_blocks
+		WHERE NOT EXISTS (SELECT 1 FROM logs) OR block < (SELECT min(id) FROM logs) // ?`, searchBlockSize)
+	return err
+}
+
+// searchSource returns the row source to read in place of the logs table
+// when searching for term, case-insensitively: the blocks whose messages may
+// contain it, or "logs" when the index is off or cannot narrow the search.
+// Callers still apply their own match condition.
+func (s *Store) searchSource(ctx context.Context, term string) (string, error) {
+	if !s.searchIndex {
+		return "logs", nil
+	}
+	grams := trigrams(term)
+	if len(grams) == 0 {
+		return "logs", nil
+	}
+
+	placeholders := make([]string, len(grams))
+	args := make([]interface{}, 0, len(grams)+1)
+	for i, g := range grams {
+		placeholders[i] = "?"
+		args = append(args, g)
+	}
+	args = append(args, len(grams))
+	rows, err := s.db.QueryContext(ctx, `SELECT block, (SELECT max(block) - min(block) + 1 FROM log_search_blocks)
+		FROM log_search_blocks
+		WHERE trigram IN (`+strings.Join(placeholders, ", ")+`)
+		GROUP BY block HAVING count(DISTINCT trigram) = ?
+		ORDER BY block`, args...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var ranges [][2]int64
+	var candidates int64
+	for rows.Next() {
+		var block, span int64
+		if err := rows.Scan(&block, &span); err != nil {
+			return "", err
+		}
+		// Skipping fewer than half the blocks does not pay for the lookup.
+		if candidates++; candidates > span/2 {
+			return "logs", nil
+		}
+		if n := len(ranges); n > 0 && ranges[n-1][1] == block-1 {
+			ranges[n-1][1] = block
+			continue
+		}
+		if len(ranges) == maxSearchRanges {
+			return "logs", nil
+		}
+		ranges = append(ranges, [2]int64{block, block})
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	if len(ranges) == 0 {
+		return "(SELECT * FROM logs LIMIT 0) AS logs", nil
+	}
+
+	// One simple range scan per run of blocks lets DuckDB skip the row
+	// groups in between; an OR of the same ranges in a WHERE clause does
+	// not. The bounds are computed here, not taken from the caller.
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		parts[i] = fmt.Sprintf("SELECT * FROM logs WHERE id BETWEEN %d AND %d", r[0]*searchBlockSize, (r[1]+1)*searchBlockSize-1)
+	}
+	return "(" + strings.Join(parts, " UNION ALL ") + ") AS logs", nil
+}
+
+// literalPattern reports whether a regular expression matches only itself,
+// so the search index can serve it.
+func literalPattern(pattern string) bool {
+	return regexp.QuoteMeta(pattern) == pattern
+}
+
+// trigrams returns the distinct three-character substrings of s lowercased,
+// in sorted order. Strings shorter than three characters have none.
+func trigrams(s string) []string {
+	set := make(map[string]struct{})
+	collectTrigrams(set, s)
+	out := make([]string, 0, len(set))
+	for g := range set {
+		out = append(out, g)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func addTrigrams(blocks map[int64]map[string]struct{}, id int64, message string) {
+	block := id / searchBlockSize
+	set := blocks[block]
+	if set == nil {
+		set = make(map[string]struct{})
+		blocks[block] = set
+	}
+	collectTrigrams(set, message)
+}
+
+func collectTrigrams(set map[string]struct{}, s string) {
+	runes := []rune(strings.ToLower(s))
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = struct{}{}
+	}
+}
+
+// writeSearchBlocks adds the trigram sets to the index, one statement per
+// block. The table has no key, so a block written by several batches holds
+// repeated trigrams; lookups count distinct ones.
+func writeSearchBlocks(ctx context.Context, tx *sql.Tx, blocks map[int64]map[string]struct{}) error {
+	for block, set := range blocks {
+		if len(set) == 0 {
+			continue
+		}
+		grams := make([]string, 0, len(set))
+		for g := range set {
+			grams = append(grams, g)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO log_search_blocks SELECT unnest(?::VARCHAR[]), ?`, grams, block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
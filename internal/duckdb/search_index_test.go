@@ -0,0 +1,133 @@
+package duckdb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// skipLogIDs advances the id sequence so the next insert starts a new search
+// index block.
+func skipLogIDs(t *testing.T, store *Store) {
+	t.Helper()
+	if _, err := store.db.Exec(`SELECT nextval('logs_id_seq') FROM range(?)`, searchBlockSize); err != nil {
+		t.Fatalf("advance id sequence: %v", err)
+	}
+}
+
+// seedSearchBlocks inserts three blocks of logs; only the middle one
+// mentions a deadlock.
+func seedSearchBlocks(t *testing.T, store *Store, base time.Time) {
+	t.Helper()
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: base, Level: "INFO", Message: "request handled status=200"},
+		{Timestamp: base.Add(time.Second), Level: "INFO", Message: "cache warmed"},
+	})
+	skipLogIDs(t, store)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: base.Add(2 * time.Second), Level: "ERROR", Message: "panic: Deadlock detected in worker 3"},
+		{Timestamp: base.Add(3 * time.Second), Level: "INFO", Message: "request handled status=500"},
+	})
+	skipLogIDs(t, store)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: base.Add(4 * time.Second), Level: "INFO", Message: "request handled status=200"},
+	})
+}
+
+func searchMessages(t *testing.T, store *Store, term string) []string {
+	t.Helper()
+	logs, err := store.SearchLogs(term, 100, QueryOpts{})
+	if err != nil {
+		t.Fatalf("SearchLogs(%q): %v", term, err)
+	}
+	var messages []string
+	for _, r := range logs {
+		messages = append(messages, r.Message)
+	}
+	return messages
+}
+
+func TestSearchIndexMatchesScan(t *testing.T) {
+	store := newTestStore(t)
+	seedSearchBlocks(t, store, time.Now().Add(-time.Minute))
+
+	terms := []string{"deadlock", "DEADLOCK", "status=200", "status=5", "handled", "nothing here", "ok"}
+	want := make(map[string][]string)
+	for _, term := range terms {
+		want[term] = searchMessages(t, store, term)
+	}
+
+	if err := store.EnableSearchIndex(); err != nil {
+		t.Fatalf("EnableSearchIndex: %v", err)
+	}
+	for _, term := range terms {
+		if got := searchMessages(t, store, term); !reflect.DeepEqual(got, want[term]) {
+			t.Errorf("SearchLogs(%q) with index = %q, want %q", term, got, want[term])
+		}
+	}
+
+	// The index narrows a rare term to its block.
+	source, err := store.searchSource(t.Context(), "deadlock")
+	if err != nil {
+		t.Fatalf("searchSource: %v", err)
+	}
+	if source == "logs" {
+		t.Error("searchSource(deadlock) did not narrow the scan")
+	}
+
+	// Logs inserted after enabling are indexed too.
+	skipLogIDs(t, store)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: time.Now(), Level: "ERROR", Message: "another deadlock"},
+	})
+	if got := searchMessages(t, store, "deadlock"); len(got) != 2 || got[0] != "another deadlock" {
+		t.Errorf("SearchLogs(deadlock) after insert = %q", got)
+	}
+
+	filtered, err := store.RecentLogsFiltered(100, QueryOpts{}, nil, nil, "Deadlock")
+	if err != nil {
+		t.Fatalf("RecentLogsFiltered: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].Message != "panic: Deadlock detected in worker 3" {
+		t.Errorf("RecentLogsFiltered(Deadlock) = %+v, want the case-sensitive match only", filtered)
+	}
+}
+
+func TestSearchIndexPrunedWithRetention(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.EnableSearchIndex(); err != nil {
+		t.Fatalf("EnableSearchIndex: %v", err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	seedSearchBlocks(t, store, old)
+	skipLogIDs(t, store)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: time.Now(), Level: "INFO", Message: "fresh deadlock report"},
+	})
+
+	if _, err := store.DeleteBefore(time.Now().Add(-24 * time.Hour)); err != nil {
+		t.Fatalf("DeleteBefore: %v", err)
+	}
+	var blocks int
+	if err := store.db.QueryRow(`SELECT count(DISTINCT block) FROM log_search_blocks`).Scan(&blocks); err != nil {
+		t.Fatalf("count blocks: %v", err)
+	}
+	if blocks != 1 {
+		t.Errorf("index blocks after retention = %d, want 1", blocks)
+	}
+	if got := searchMessages(t, store, "deadlock"); len(got) != 1 || got[0] != "fresh deadlock report" {
+		t.Errorf("SearchLogs(deadlock) after retention = %q", got)
+	}
+}
+
+func TestTrigrams(t *testing.T) {
+	if got, want := trigrams("AbcD"), []string{"abc", "bcd"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("trigrams(AbcD) = %q, want %q", got, want)
+	}
+	if got, want := trigrams("héllo"), []string{"hél", "llo", "éll"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("trigrams(héllo) = %q, want %q", got, want)
+	}
+	if got := trigrams("ab"); len(got) != 0 {
+		t.Errorf("trigrams(ab) = %q, want none", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb/migrate"
@@ -20,13 +21,50 @@ type Store struct {
 	mu           sync.RWMutex
 	dbPath       string
 	QueryTimeout time.Duration
-	querySlots   chan struct{}
+	// queries admits read queries; nil when their concurrency is unlimited.
+	queries      atomic.Pointer[queryScheduler]
+	searchIndex  bool                 // see EnableSearchIndex
+	partitions   map[string]bool      // day partitions; nil unless logs is partitioned
+	archiveDir   string               // see EnableArchive
+	coldDir      string               // see EnableRotation
+	rotatePeriod RotationPeriod       // "" unless EnableRotation was called
+	cold         []coldFile           // rotated files, oldest first
+	coldExpired  []coldFile           // dropped from the logs view; see expireCold
+	promoted     []promotedColumn     // see PromoteAttributes
+	dedupWindow  time.Duration        // see EnableDedup
+	rawLine      RawLinePolicy        // see SetRawLinePolicy; "" stores every raw line
+	duplicates   atomic.Int64         // records skipped by dedup
+	totals       map[string]logTotals // per-app totals of stored logs; see counters.go
+}
+
+// Config tunes a Store. Zero values keep the defaults.
+type Config struct {
+	// QueryTimeout bounds each read query; it defaults to 30s.
+	QueryTimeout time.Duration
+	// MemoryLimit caps DuckDB's buffer memory in bytes; 0 keeps DuckDB's
+	// default of 80% of RAM. Larger operations spill to TempDirectory.
+	MemoryLimit int64
+	// Threads caps DuckDB's worker threads; 0 uses one per core.
+	Threads int
+	// TempDirectory is where DuckDB spills; "" keeps DuckDB's default
+	// (<db-path>.tmp, or none for an in-memory database).
+	TempDirectory string
 }
 
 // NewStore opens or creates a DuckDB database.
 // If dbPath is empty, an in-memory database is used.
 // An optional queryTimeout can be passed; it defaults to 30s.
 func NewStore(dbPath string, queryTimeout ...time.Duration) (*Store, error) {
+	var cfg Config
+	if len(queryTimeout) > 0 {
+		cfg.QueryTimeout = queryTimeout[0]
+	}
+	return NewStoreWithConfig(dbPath, cfg)
+}
+
+// NewStoreWithConfig is NewStore with DuckDB resource limits, which apply
+// to every connection of the store.
+func NewStoreWithConfig(dbPath string, cfg Config) (*Store, error) {
 	dsn := ""
 	if dbPath != "" {
 		// Ensure parent directory exists
@@ -36,12 +74,26 @@ func NewStore(dbPath string, queryTimeout ...time.Duration) (*Store, error) {
 		}
 		dsn = dbPath
 	}
+	if cfg.TempDirectory != "" {
+		if err := os.MkdirAll(cfg.TempDirectory, 0755); err != nil {
+			return nil, err
+		}
+	}
 
+	bootQueries := []string{
+		`SET schema = 'main'`,
+		`SET search_path = 'main'`,
+	}
+	if cfg.MemoryLimit > 0 {
+		bootQueries = append(bootQueries, fmt.Sprintf(`SET memory_limit = '%dB'`, cfg.MemoryLimit))
+	}
+	if cfg.Threads > 0 {
+		bootQueries = append(bootQueries, fmt.Sprintf(`SET threads = %d`, cfg.Threads))
+	}
+	if cfg.TempDirectory != "" {
+		bootQueries = append(bootQueries, `SET temp_directory = `+quoteSQLString(cfg.TempDirectory))
+	}
 	connector, err := duckdb.NewConnector(dsn, func(execer driver.ExecerContext) error {
-		bootQueries := []string{
-			`SET schema = 'main'`,
-			`SET search_path = 'main'`,
-		}
 		for _, query := range bootQueries {
 			if _, err := execer.ExecContext(context.Background(), query, nil); err != nil {
 				return fmt.Errorf("duckdb connector init query %q failed: %w", query, err)
@@ -69,16 +121,41 @@ func NewStore(dbPath string, queryTimeout ...time.Duration) (*Store, error) {
 	}
 
 	qt := 30 * time.Second
-	if len(queryTimeout) > 0 && queryTimeout[0] > 0 {
-		qt = queryTimeout[0]
+	if cfg.QueryTimeout > 0 {
+		qt = cfg.QueryTimeout
 	}
 
-	return &Store{
+	store := &Store{
 		db:           db,
 		dbPath:       dbPath,
 		QueryTimeout: qt,
-		querySlots:   make(chan struct{}, 8),
-	}, nil
+	}
+	store.queries.Store(newQueryScheduler(8))
+	if err := store.loadPartitions(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := store.loadArchive(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := store.loadRotation(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := store.ensureLogColumns(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := store.loadPromoted(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := store.loadTotals(context.Background(), db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
 }
 
 // Close closes the database connection.
@@ -86,22 +163,30 @@ func (s *Store) Close() error {
 	return s.db.Close()
 }
 
+// pingTimeout bounds Ping, which health probes call.
+const pingTimeout = 2 * time.Second
+
+// Ping checks that the database is open and answering.
+func (s *Store) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	return s.db.PingContext(ctx)
+}
+
 // DB returns the underlying *sql.DB for direct query access (e.g., AI queries).
 func (s *Store) DB() *sql.DB {
 	return s.db
 }
 
-// SetMaxConcurrentQueries configures global read-query concurrency.
-// Values <= 0 disable the limit.
+// SetMaxConcurrentQueries configures global read-query concurrency; see
+// queryScheduler. Values <= 0 disable the limit. Queries already admitted
+// finish under the previous scheduler.
 func (s *Store) SetMaxConcurrentQueries(n int) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if n <= 0 {
-		s.querySlots = nil
+		s.queries.Store(nil)
 		return
 	}
-	s.querySlots = make(chan struct{}, n)
+	s.queries.Store(newQueryScheduler(n))
 }
 
 // DeleteBefore deletes all log records with a timestamp before the given cutoff.
@@ -110,9 +195,156 @@ func (s *Store) DeleteBefore(cutoff time.Time) (int64, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	result, err := s.db.Exec("DELETE FROM logs WHERE timestamp < ?", cutoff)
+	return s.deleteLogs(func(ctx context.Context, tx *sql.Tx) (int64, error) {
+		// Metric samples and spans share the retention period; they are
+		// not counted.
+		if _, err := tx.ExecContext(ctx, "DELETE FROM metrics WHERE timestamp < ?", cutoff); err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM spans WHERE start_time < ?", cutoff); err != nil {
+			return 0, err
+		}
+		if s.partitions != nil {
+			return s.deletePartitionsBefore(ctx, tx, cutoff)
+		}
+		result, err := tx.ExecContext(ctx, "DELETE FROM logs WHERE timestamp < ?", cutoff)
+		if err != nil {
+			return 0, err
+		}
+		return result.RowsAffected()
+	})
+}
+
+// DeleteLogs deletes the logs of opts's app and time range from the active
+// database; archived and rotated files are read-only and keep theirs. The
+// rollups of the minutes it touched are recounted. Returns the number of
+// logs deleted.
+func (s *Store) DeleteLogs(opts QueryOpts) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	where, args := scopeFilter(opts)
+	return s.deleteLogs(func(ctx context.Context, tx *sql.Tx) (int64, error) {
+		tables := []string{"logs"}
+		if s.partitions != nil {
+			tables = tables[:0]
+			for _, name := range sortedPartitions(s.partitions) {
+				tables = append(tables, partitionTable(name))
+			}
+		}
+		var deleted int64
+		for _, table := range tables {
+			result, err := tx.ExecContext(ctx, "DELETE FROM "+table+" "+where, args...)
+			if err != nil {
+				return 0, err
+			}
+			n, _ := result.RowsAffected()
+			deleted += n
+		}
+		return deleted, recountRollups(ctx, tx, opts)
+	})
+}
+
+// DeleteOldest deletes the n oldest log records by timestamp and
+// checkpoints, so the freed blocks show up in StorageBytes.
+// Returns the number of rows deleted.
+func (s *Store) DeleteOldest(n int64) (int64, error) {
+	if n <= 0 {
+		return 0, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted, err := s.deleteLogs(func(ctx context.Context, tx *sql.Tx) (int64, error) {
+		if s.partitions != nil {
+			return s.deleteOldestPartitioned(ctx, tx, n)
+		}
+		result, err := tx.ExecContext(ctx, "DELETE FROM logs WHERE id IN (SELECT id FROM logs ORDER BY timestamp, id LIMIT ?)", n)
+		if err != nil {
+			return 0, err
+		}
+		return result.RowsAffected()
+	})
 	if err != nil {
 		return 0, err
 	}
-	return result.RowsAffected()
+	if _, err := s.db.Exec("CHECKPOINT"); err != nil {
+		return deleted, fmt.Errorf("checkpoint: %w", err)
+	}
+	return deleted, nil
 }
+
+// StorageBytes returns the bytes the database occupies: the blocks in use
+// plus the write-ahead log. Deleted rows are not reclaimed from the file,
+// so this, not the file size, is what falls after deletes. An in-memory
+// database reports 0.
+func (s *Store) StorageBytes() (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var used int64
+	if err := s.db.QueryRow("SELECT coalesce(sum(used_blocks * block_size), 0) FROM pragma_database_size()").Scan(&used); err != nil {
+		return 0, err
+	}
+	if s.dbPath != "" {
+		if info, err := os.Stat(s.dbPath + ".wal"); err == nil {
+			used += info.Size()
+		}
+	}
+	return used, nil
+}
+
+// deleteLogs runs del in a transaction, trimming the rollups and pruning the
+// search index with it, and rereads the running totals and the partitions
+// del may have dropped. Rotated files del expired are deleted once it
+// commits.
+// The caller holds s.mu.
+func (s *Store) deleteLogs(del func(ctx context.Context, tx *sql.Tx) (int64, error)) (int64, error) {
+	ctx := context.Background()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	cold := s.cold
+	defer func() {
+		if s.coldExpired != nil {
+			// Not committed: the view still names them.
+			s.cold, s.coldExpired = cold, nil
+		}
+	}()
+	deleted, err := del(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	if err := trimRollups(ctx, tx); err != nil {
+		return 0, err
+	}
+	totals, err := scanTotals(ctx, tx, `SELECT app, SUM(count)::BIGINT, SUM(bytes)::BIGINT FROM log_minute_rollups GROUP BY app`)
+	if err != nil {
+		return 0, err
+	}
+	if s.searchIndex {
+		if err := s.pruneSearchIndex(ctx, tx); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	s.totals = totals
+	if err := s.removeExpiredCold(ctx); err != nil {
+		return deleted, err
+	}
+	if s.partitions != nil {
+		if err := s.loadPartitions(ctx); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// Store is the default StorageBackend.
+var _ StorageBackend = (*Store)(nil)
+var _ CapacityPruner = (*Store)(nil)
+var _ LogDeleter = (*Store)(nil)
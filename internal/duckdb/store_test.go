@@ -1,6 +1,8 @@
 package duckdb
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -277,3 +279,34 @@ func TestTableRowCounts(t *testing.T) {
 		}
 	}
 }
+
+func TestNewStoreWithConfig_ResourceLimits(t *testing.T) {
+	tempDir := filepath.Join(t.TempDir(), "spill")
+	store, err := NewStoreWithConfig("", Config{MemoryLimit: 256 << 20, Threads: 2, TempDirectory: tempDir})
+	if err != nil {
+		t.Fatalf("NewStoreWithConfig: %v", err)
+	}
+	defer store.Close()
+
+	var memory, temp string
+	var threads int64
+	if err := store.db.QueryRow(`SELECT current_setting('memory_limit'), current_setting('threads'), current_setting('temp_directory')`).Scan(&memory, &threads, &temp); err != nil {
+		t.Fatalf("current_setting: %v", err)
+	}
+	if memory != "256.0 MiB" || threads != 2 || temp != tempDir {
+		t.Errorf("settings = %q/%d/%q, want 256.0 MiB/2/%q", memory, threads, temp, tempDir)
+	}
+	if _, err := os.Stat(tempDir); err != nil {
+		t.Errorf("temp directory not created: %v", err)
+	}
+}
+
+func TestSchemaRevision(t *testing.T) {
+	store := newTestStore(t)
+
+	// The embedded migrations run on open; the last is 013.
+	rev, err := store.SchemaRevision()
+	if err != nil || rev < 13 {
+		t.Fatalf("SchemaRevision = %d, %v, want at least 13", rev, err)
+	}
+}
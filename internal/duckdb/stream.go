@@ -0,0 +1,73 @@
+package duckdb
+
+import (
+	"context"
+	"log"
+)
+
+// StreamQuery runs a read-only SELECT/WITH query like ExecuteQuery, args
+// bound to its placeholders, but without its row cap, and hands the results to fn batchSize rows at a time
+// as they are read; a query without rows hands over the columns in one
+// empty batch. fn returning an error, or ctx ending, stops the query.
+// The store's read lock is held until the last batch is handed over, so a
+// slow fn delays writes; the query timeout bounds the whole stream.
+func (s *Store) StreamQuery(ctx context.Context, query string, batchSize int, fn func(columns []string, rows [][]interface{}) error, args ...any) error {
+	trimmed, err := validateReadOnlyQuery(query)
+	if err != nil {
+		return err
+	}
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	queryCtx, cancel, err := s.queryCtx(priorityBulk)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.QueryContext(queryCtx, trimmed, args...)
+	if err != nil {
+		return contextErr(ctx, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	sent := false
+	batch := make([][]interface{}, 0, batchSize)
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		valuePtrs := make([]interface{}, len(columns))
+		for i := range values {
+			valuePtrs[i] = &values[i]
+		}
+		if err := rows.Scan(valuePtrs...); err != nil {
+			log.Printf("duckdb scan error (StreamQuery): %v", err)
+			continue
+		}
+		batch = append(batch, values)
+		if len(batch) == batchSize {
+			if err := fn(columns, batch); err != nil {
+				return err
+			}
+			sent = true
+			batch = make([][]interface{}, 0, batchSize)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return contextErr(ctx, err)
+	}
+	if len(batch) > 0 || !sent {
+		return fn(columns, batch)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	duckdb "github.com/duckdb/duckdb-go/v2"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// traceBackfill returns the SQL expression filling a trace column added
+// to an existing logs table from the first of keys in its attributes.
+func traceBackfill(keys []string) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = `nullif(json_extract_string(attributes, ` + quoteSQLString(k) + `), '')`
+	}
+	return `lower(coalesce(` + strings.Join(parts, ", ") + `))`
+}
+
+// spanColumns are the spans columns InsertSpanBatch fills.
+var spanColumns = []string{
+	"trace_id", "span_id", "parent_span_id", "name", "kind", "start_time", "end_time",
+	"status_code", "status_message", "attributes", "service", "app",
+}
+
+// InsertSpanBatch appends spans to the spans table through a DuckDB
+// appender. A batch is written whole or not at all.
+func (s *Store) InsertSpanBatch(spans []*model.Span) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.QueryTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(driverConn any) error {
+		appender, err := duckdb.NewAppenderWithColumns(driverConn.(driver.Conn), "", "", "spans", spanColumns)
+		if err != nil {
+			return err
+		}
+		for _, sp := range spans {
+			attrsJSON := []byte("{}")
+			if len(sp.Attributes) > 0 {
+				if data, merr := json.Marshal(sp.Attributes); merr != nil {
+					log.Printf("duckdb: failed to marshal span attributes, using empty: %v", merr)
+				} else {
+					attrsJSON = data
+				}
+			}
+			app := sp.App
+			if app == "" {
+				app = "default"
+			}
+			var end driver.Value
+			if !sp.EndTime.IsZero() {
+				end = sp.EndTime
+			}
+			if err := appender.AppendRow(strings.ToLower(sp.TraceID), strings.ToLower(sp.SpanID), nullString(strings.ToLower(sp.ParentSpanID)),
+				sp.Name, nullString(sp.Kind), sp.StartTime, end, nullString(sp.StatusCode), nullString(sp.StatusMessage),
+				json.RawMessage(attrsJSON), nullString(sp.Service), app); err != nil {
+				appender.Close()
+				return err
+			}
+		}
+		return appender.Close()
+	})
+	if err != nil {
+		return fmt.Errorf("span insert: %w", err)
+	}
+	return nil
+}
+
+// TraceLogs returns up to limit logs carrying traceID, oldest first.
+func (s *Store) TraceLogs(traceID string, limit int) ([]LogRecord, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT timestamp, orig_timestamp, level, level_num, message, coalesce(raw_line, message) AS raw_line, raw_line_zstd, service, hostname, pid, CAST(attributes AS VARCHAR) AS attributes, source, app, CAST(body_json AS VARCHAR) AS body_json
+		FROM logs
+		WHERE trace_id = ?
+		ORDER BY timestamp
+		LIMIT ?`, strings.ToLower(traceID), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []LogRecord
+	for rows.Next() {
+		var r LogRecord
+		var origTS sql.NullTime
+		var attrsJSON string
+		var body sql.NullString
+		var rawZstd []byte
+		if err := rows.Scan(&r.Timestamp, &origTS, &r.Level, &r.LevelNum, &r.Message, &r.RawLine, &rawZstd, &r.Service, &r.Hostname, &r.PID, &attrsJSON, &r.Source, &r.App, &body); err != nil {
+			log.Printf("duckdb scan error (TraceLogs): %v", err)
+			continue
+		}
+		if origTS.Valid {
+			r.OrigTimestamp = origTS.Time
+		}
+		r.BodyJSON = body.String
+		decodeRawLine(&r, rawZstd)
+		r.Attributes = make(map[string]string)
+		if attrsJSON != "" && attrsJSON != "{}" {
+			parseJSONMap(attrsJSON, r.Attributes)
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// TraceSpans returns the spans of traceID by start time.
+func (s *Store) TraceSpans(traceID string) ([]model.Span, error) {
+	ctx, cancel, err := s.queryCtx(priorityInteractive)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT trace_id, span_id, coalesce(parent_span_id, ''), name, coalesce(kind, ''),
+			start_time, end_time, coalesce(status_code, ''), coalesce(status_message, ''),
+			CAST(attributes AS VARCHAR), coalesce(service, ''), app
+		FROM spans
+		WHERE trace_id = ?
+		ORDER BY start_time, span_id`, strings.ToLower(traceID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var spans []model.Span
+	for rows.Next() {
+		var sp model.Span
+		var end sql.NullTime
+		var attrsJSON string
+		if err := rows.Scan(&sp.TraceID, &sp.SpanID, &sp.ParentSpanID, &sp.Name, &sp.Kind, &sp.StartTime, &end,
+			&sp.StatusCode, &sp.StatusMessage, &attrsJSON, &sp.Service, &sp.App); err != nil {
+			log.Printf("duckdb scan error (TraceSpans): %v", err)
+			continue
+		}
+		if end.Valid {
+			sp.EndTime = end.Time
+		}
+		sp.Attributes = make(map[string]string)
+		if attrsJSON != "" && attrsJSON != "{}" {
+			parseJSONMap(attrsJSON, sp.Attributes)
+		}
+		spans = append(spans, sp)
+	}
+	return spans, rows.Err()
+}
+
+// Store stores spans and correlates them with logs.
+var _ model.SpanWriter = (*Store)(nil)
+var _ model.TraceQuerier = (*Store)(nil)
@@ -0,0 +1,61 @@
+package duckdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestTraceLogsAndSpans(t *testing.T) {
+	store := newTestStore(t)
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	insertTestRecords(t, store, []*LogRecord{
+		{Timestamp: base.Add(2 * time.Second), Level: "ERROR", Message: "payment failed", Attributes: map[string]string{"trace.id": "ABC123", "span.id": "02"}},
+		{Timestamp: base, Level: "INFO", Message: "checkout started", Attributes: map[string]string{"traceId": "abc123", "spanId": "01"}},
+		{Timestamp: base.Add(time.Second), Level: "INFO", Message: "other trace", Attributes: map[string]string{"trace_id": "def456"}},
+		{Timestamp: base, Level: "INFO", Message: "no trace"},
+	})
+
+	logs, err := store.TraceLogs("abc123", 10)
+	if err != nil {
+		t.Fatalf("TraceLogs: %v", err)
+	}
+	if len(logs) != 2 || logs[0].Message != "checkout started" || logs[1].Message != "payment failed" {
+		t.Fatalf("TraceLogs = %+v, want the two abc123 logs oldest first", logs)
+	}
+	var spanID string
+	if err := store.db.QueryRow(`SELECT span_id FROM logs WHERE message = 'payment failed'`).Scan(&spanID); err != nil || spanID != "02" {
+		t.Errorf("span_id = %q, %v; want 02", spanID, err)
+	}
+
+	spans := []*model.Span{
+		{TraceID: "ABC123", SpanID: "02", ParentSpanID: "01", Name: "charge", StartTime: base.Add(time.Second), EndTime: base.Add(2 * time.Second), StatusCode: "error", Service: "payments"},
+		{TraceID: "abc123", SpanID: "01", Name: "POST /checkout", Kind: "server", StartTime: base, Attributes: map[string]string{"http.route": "/checkout"}},
+		{TraceID: "def456", SpanID: "01", Name: "GET /", StartTime: base},
+	}
+	if err := store.InsertSpanBatch(spans); err != nil {
+		t.Fatalf("InsertSpanBatch: %v", err)
+	}
+	got, err := store.TraceSpans("ABC123")
+	if err != nil {
+		t.Fatalf("TraceSpans: %v", err)
+	}
+	if len(got) != 2 || got[0].Name != "POST /checkout" || got[1].ParentSpanID != "01" {
+		t.Fatalf("TraceSpans = %+v, want the root then its child", got)
+	}
+	if got[0].App != "default" || got[0].Attributes["http.route"] != "/checkout" || !got[0].EndTime.IsZero() {
+		t.Errorf("root span = %+v", got[0])
+	}
+	if got[1].StatusCode != "error" || got[1].EndTime.Sub(got[1].StartTime) != time.Second {
+		t.Errorf("child span = %+v", got[1])
+	}
+
+	// Spans expire with the logs' retention period.
+	if _, err := store.DeleteBefore(base.Add(time.Second)); err != nil {
+		t.Fatalf("DeleteBefore: %v", err)
+	}
+	if got, err := store.TraceSpans("abc123"); err != nil || len(got) != 1 || got[0].SpanID != "02" {
+		t.Errorf("TraceSpans after DeleteBefore = %+v, %v", got, err)
+	}
+}
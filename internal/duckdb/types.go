@@ -10,3 +10,10 @@ type AttributeStat = model.AttributeStat
 type AttributeKeyStat = model.AttributeKeyStat
 type DimensionCount = model.DimensionCount
 type MinuteCounts = model.MinuteCounts
+type RetentionStats = model.RetentionStats
+type MaintenanceStats = model.MaintenanceStats
+type QueryStats = model.QueryStats
+type DimensionRate = model.DimensionRate
+type CatalogEntry = model.CatalogEntry
+type LogFilter = model.LogFilter
+type LogPage = model.LogPage
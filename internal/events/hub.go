@@ -0,0 +1,82 @@
+// Package events fans server events out to the clients subscribed to them.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// defaultBuffer is how many events a subscription holds for a subscriber
+// that asked for no particular buffer.
+const defaultBuffer = 64
+
+// Hub delivers published events to every open subscription. A subscriber
+// that falls behind misses events rather than slowing the publisher.
+// All methods are safe for concurrent use.
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[*subscription]struct{}
+}
+
+// NewHub returns a hub with no subscribers.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[*subscription]struct{})}
+}
+
+// Publish sends ev to every subscriber with room for it, stamping it with
+// the current time when it has none.
+func (h *Hub) Publish(ev model.ServerEvent) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for s := range h.subs {
+		select {
+		case s.events <- ev:
+		default:
+		}
+	}
+}
+
+// SubscribeEvents opens a subscription to the events published from now
+// on. A buffer of zero or less holds defaultBuffer events.
+func (h *Hub) SubscribeEvents(buffer int) (model.EventSubscription, error) {
+	if buffer <= 0 {
+		buffer = defaultBuffer
+	}
+	s := &subscription{hub: h, events: make(chan model.ServerEvent, buffer)}
+	h.mu.Lock()
+	h.subs[s] = struct{}{}
+	h.mu.Unlock()
+	return s, nil
+}
+
+// Subscribers returns how many subscriptions are open.
+func (h *Hub) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs)
+}
+
+// subscription is a model.EventSubscription fed by a Hub.
+type subscription struct {
+	hub       *Hub
+	events    chan model.ServerEvent
+	closeOnce sync.Once
+}
+
+func (s *subscription) Events() <-chan model.ServerEvent { return s.events }
+
+// Close removes the subscription from its hub and closes its channel.
+// Events are only sent under the hub's read lock, so none can follow.
+func (s *subscription) Close() {
+	s.closeOnce.Do(func() {
+		s.hub.mu.Lock()
+		delete(s.hub.subs, s)
+		s.hub.mu.Unlock()
+		close(s.events)
+	})
+}
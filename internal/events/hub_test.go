@@ -0,0 +1,37 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestHub_Publish(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub()
+	hub.Publish(model.ServerEvent{Kind: model.EventBackupDone}) // no subscribers yet
+
+	sub, _ := hub.SubscribeEvents(2)
+	for _, app := range []string{"shop", "blog", "wiki"} {
+		hub.Publish(model.ServerEvent{Kind: model.EventAppSeen, App: app})
+	}
+	first := <-sub.Events()
+	if first.App != "shop" || first.Time.IsZero() {
+		t.Fatalf("first event = %+v, want shop stamped with a time", first)
+	}
+	// The third did not fit and was dropped rather than blocking.
+	if second := <-sub.Events(); second.App != "blog" || len(sub.Events()) != 0 {
+		t.Fatalf("second event = %+v with %d more, want blog and none", second, len(sub.Events()))
+	}
+
+	sub.Close()
+	sub.Close()
+	if hub.Subscribers() != 0 {
+		t.Fatalf("Subscribers = %d after Close, want 0", hub.Subscribers())
+	}
+	if _, ok := <-sub.Events(); ok {
+		t.Fatal("events channel open after Close")
+	}
+	hub.Publish(model.ServerEvent{Kind: model.EventBackupDone})
+}
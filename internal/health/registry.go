@@ -0,0 +1,98 @@
+// Package health keeps the server's component checks and remembers, for
+// each component, its latest failure and how often it recovered, so the
+// readiness endpoint and the TUI report the same state.
+package health
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// Pinger is implemented by stores that can check their connection; its
+// Ping is the store's check.
+type Pinger interface {
+	Ping() error
+}
+
+// component is one registered check and what earlier runs of it found.
+type component struct {
+	name     string
+	required bool
+	check    func() error
+	state    model.ComponentHealth
+}
+
+// Registry runs the registered component checks. It is safe for
+// concurrent use.
+type Registry struct {
+	mu         sync.Mutex
+	components []*component
+	now        func() time.Time
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{now: time.Now}
+}
+
+// Add registers a component: check returns nil when it is healthy, or why
+// not. A failing required component makes the server not ready. Adding a
+// name again replaces its check and keeps its history. It may be called
+// while the registry is in use.
+func (r *Registry) Add(name string, required bool, check func() error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range r.components {
+		if c.name == name {
+			c.required, c.check = required, check
+			return
+		}
+	}
+	r.components = append(r.components, &component{name: name, required: required, check: check})
+}
+
+// Health runs every check, in the order they were added, and returns each
+// component's state. The error is always nil; Registry is a
+// model.HealthReporter.
+func (r *Registry) Health() ([]model.ComponentHealth, error) {
+	r.mu.Lock()
+	components := append([]*component(nil), r.components...)
+	checks := make([]func() error, len(components))
+	for i, c := range components {
+		checks[i] = c.check
+	}
+	r.mu.Unlock()
+
+	out := make([]model.ComponentHealth, len(components))
+	for i, c := range components {
+		// Checks run unlocked: a slow one must not hold up Add.
+		err := checks[i]()
+		r.mu.Lock()
+		out[i] = r.update(c, err)
+		r.mu.Unlock()
+	}
+	return out, nil
+}
+
+// update records the outcome of one run of c's check. r.mu must be held.
+func (r *Registry) update(c *component, err error) model.ComponentHealth {
+	now := r.now()
+	s := &c.state
+	status := model.HealthOK
+	s.Error = ""
+	if err != nil {
+		status = model.HealthFailing
+		s.Error = err.Error()
+		s.LastError, s.LastErrorAt = s.Error, now
+	}
+	if status != s.Status {
+		if s.Status == model.HealthFailing {
+			s.Restarts++
+		}
+		s.Status, s.Since = status, now
+	}
+	s.Name, s.Required = c.name, c.required
+	return *s
+}
@@ -0,0 +1,56 @@
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestRegistry_TracksFailuresAndRestarts(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	r := NewRegistry()
+	r.now = func() time.Time { return clock }
+
+	var sourceErr error
+	r.Add("store", true, func() error { return nil })
+	r.Add("sources", false, func() error { return sourceErr })
+
+	got := health(t, r)
+	if len(got) != 2 || got[0].Name != "store" || got[1].Status != model.HealthOK || !got[1].LastErrorAt.IsZero() {
+		t.Fatalf("first check = %+v", got)
+	}
+
+	clock = clock.Add(time.Minute)
+	sourceErr = errors.New("1 of 1 stopped: stdin")
+	src := health(t, r)[1]
+	if src.Status != model.HealthFailing || src.Error != sourceErr.Error() || !src.Since.Equal(clock) || src.Restarts != 0 {
+		t.Fatalf("failing source = %+v", src)
+	}
+
+	failedAt := clock
+	clock = clock.Add(time.Minute)
+	sourceErr = nil
+	src = health(t, r)[1]
+	if src.Status != model.HealthOK || src.Error != "" || src.Restarts != 1 || !src.LastErrorAt.Equal(failedAt) || src.LastError == "" {
+		t.Fatalf("recovered source = %+v, want one restart and the last error kept", src)
+	}
+
+	// Registering a name again replaces its check and keeps its history.
+	r.Add("sources", true, func() error { return nil })
+	if src = health(t, r)[1]; !src.Required || src.Restarts != 1 {
+		t.Fatalf("replaced source = %+v", src)
+	}
+}
+
+func health(t *testing.T, r *Registry) []model.ComponentHealth {
+	t.Helper()
+	states, err := r.Health()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return states
+}
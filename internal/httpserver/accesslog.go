@@ -0,0 +1,81 @@
+package httpserver
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLogLevel selects which API requests are written to the runtime log.
+type AccessLogLevel string
+
+const (
+	// AccessLogOff logs no requests.
+	AccessLogOff AccessLogLevel = "off"
+	// AccessLogError logs requests answered with a 5xx status.
+	AccessLogError AccessLogLevel = "error"
+	// AccessLogWarn also logs requests answered with a 4xx status.
+	AccessLogWarn AccessLogLevel = "warn"
+	// AccessLogInfo logs every request.
+	AccessLogInfo AccessLogLevel = "info"
+)
+
+// accessLogRank orders the levels; a request is logged when the level of
+// its status ranks at or above the configured one.
+var accessLogRank = map[AccessLogLevel]int{
+	AccessLogInfo:  1,
+	AccessLogWarn:  2,
+	AccessLogError: 3,
+	AccessLogOff:   4,
+}
+
+// ParseAccessLogLevel parses "off", "error", "warn", or "info".
+func ParseAccessLogLevel(s string) (AccessLogLevel, error) {
+	level := AccessLogLevel(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := accessLogRank[level]; !ok {
+		return "", fmt.Errorf("unknown access log level %q (want %s, %s, %s, or %s)", s, AccessLogOff, AccessLogError, AccessLogWarn, AccessLogInfo)
+	}
+	return level, nil
+}
+
+// SetAccessLog logs the API requests whose status is at level or worse:
+// 5xx is error, 4xx warn, and the rest info. Requests are not logged by
+// default. Call before Start.
+func (s *Server) SetAccessLog(level AccessLogLevel) {
+	s.accessLog = level
+}
+
+// statusLevel returns the access log level of a response status.
+func statusLevel(status int) AccessLogLevel {
+	switch {
+	case status >= 500:
+		return AccessLogError
+	case status >= 400:
+		return AccessLogWarn
+	}
+	return AccessLogInfo
+}
+
+// logAccess writes one line per request at or above the access log level:
+// method, path, status, latency, client address, and the name of the API
+// key that authorized it. The query string is left out, since WebSocket
+// clients may carry their key in it.
+func (s *Server) logAccess(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	status := c.Writer.Status()
+	level := statusLevel(status)
+	if accessLogRank[level] < accessLogRank[s.accessLog] {
+		return
+	}
+	key := "-"
+	if v, ok := c.Get(grantKey); ok {
+		key = v.(*apiGrant).name
+	}
+	log.Printf("httpserver: access level=%s method=%s path=%s status=%d latency=%s client=%s key=%q",
+		level, c.Request.Method, c.Request.URL.Path, status, time.Since(start).Round(time.Microsecond), c.RemoteIP(), key)
+}
@@ -0,0 +1,70 @@
+package httpserver
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseAccessLogLevel(t *testing.T) {
+	if level, err := ParseAccessLogLevel(" WARN "); err != nil || level != AccessLogWarn {
+		t.Errorf("ParseAccessLogLevel(WARN) = %q, %v", level, err)
+	}
+	if _, err := ParseAccessLogLevel("debug"); err == nil {
+		t.Error("ParseAccessLogLevel(debug) succeeded, want an error")
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	keyring, err := NewKeyring([]APIKey{{Name: "ci", Key: "ci-key-0123456789abcdef", Scopes: []string{ScopeRead}}})
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	srv.SetKeyring(keyring)
+
+	var buf bytes.Buffer
+	prev := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(prev) })
+
+	serve := func(level AccessLogLevel, path, key string) string {
+		srv.SetAccessLog(level)
+		r := gin.New()
+		r.Use(srv.logAccess, gin.Recovery())
+		srv.registerRoutes(r)
+
+		buf.Reset()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		r.ServeHTTP(httptest.NewRecorder(), req)
+		return buf.String()
+	}
+
+	line := serve(AccessLogInfo, "/api/apps?api_key=secret", "ci-key-0123456789abcdef")
+	for _, want := range []string{"level=info", "method=GET", "path=/api/apps ", "status=200", "latency=", "client=", `key="ci"`} {
+		if !strings.Contains(line, want) {
+			t.Errorf("access line %q lacks %q", line, want)
+		}
+	}
+	if strings.Contains(line, "secret") {
+		t.Errorf("access line %q logs the query string", line)
+	}
+
+	if line := serve(AccessLogWarn, "/api/apps", "ci-key-0123456789abcdef"); line != "" {
+		t.Errorf("warn level logged a 200: %q", line)
+	}
+	if line := serve(AccessLogWarn, "/api/apps", ""); !strings.Contains(line, "level=warn") || !strings.Contains(line, "status=401") || !strings.Contains(line, `key="-"`) {
+		t.Errorf("warn level line for a 401 = %q", line)
+	}
+	if line := serve(AccessLogError, "/api/apps", ""); line != "" {
+		t.Errorf("error level logged a 401: %q", line)
+	}
+}
@@ -0,0 +1,123 @@
+package httpserver
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// RetentionRunner is implemented by retention reporters that can apply
+// their policies on demand, or report what that would delete.
+type RetentionRunner interface {
+	RunNow() model.RetentionStats
+	Preview() (model.RetentionStats, error)
+}
+
+// storageSizer is implemented by stores that report their footprint.
+type storageSizer interface {
+	StorageBytes() (int64, error)
+}
+
+// audit logs an admin action, who asked for it, and its outcome.
+func audit(c *gin.Context, action string, dryRun bool, outcome string) {
+	who := "anonymous"
+	if v, ok := c.Get(grantKey); ok {
+		who = v.(*apiGrant).name
+	}
+	if dryRun {
+		action += " (dry run)"
+	}
+	log.Printf("httpserver: audit: %s by %q from %s: %s", action, who, c.RemoteIP(), outcome)
+}
+
+// handlePurge deletes the logs of an app, a time range, or both. With
+// dry_run=true it only counts them.
+func (s *Server) handlePurge(c *gin.Context) {
+	deleter, ok := s.store.(model.LogDeleter)
+	if !ok {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "deleting logs is not supported by this storage backend"})
+		return
+	}
+	opts, err := queryOpts(c)
+	if err != nil {
+		optsInvalid(c, err)
+		return
+	}
+	if opts == (model.QueryOpts{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "set app, from, or to; purging everything is not allowed"})
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+	action := fmt.Sprintf("purge app=%q from=%s to=%s", opts.App, c.Query("from"), c.Query("to"))
+
+	var n int64
+	if dryRun {
+		n, err = s.store.TotalLogCount(opts)
+	} else {
+		n, err = deleter.DeleteLogs(opts)
+	}
+	if err != nil {
+		audit(c, action, dryRun, "failed: "+err.Error())
+		if queryUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "purge failed: " + err.Error()})
+		return
+	}
+	audit(c, action, dryRun, fmt.Sprintf("%d logs", n))
+	c.JSON(http.StatusOK, gin.H{"deleted": n, "dry_run": dryRun})
+}
+
+// handleRunRetention applies the retention policies now. With dry_run=true
+// it reports what they would expire and evict instead.
+func (s *Server) handleRunRetention(c *gin.Context) {
+	runner, ok := s.retention.(RetentionRunner)
+	if !ok {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "no retention policy is configured"})
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+	if dryRun {
+		preview, err := runner.Preview()
+		if err != nil {
+			audit(c, "retention", true, "failed: "+err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "retention preview failed: " + err.Error()})
+			return
+		}
+		audit(c, "retention", true, fmt.Sprintf("%d expired, %d over the row limit", preview.Expired, preview.RowsEvicted))
+		c.JSON(http.StatusOK, gin.H{"retention": preview, "dry_run": true})
+		return
+	}
+	stats := runner.RunNow()
+	audit(c, "retention", false, fmt.Sprintf("totals since startup: %d expired, %d size-evicted, %d row-evicted, %d archived, %d rotated",
+		stats.Expired, stats.SizeEvicted, stats.RowsEvicted, stats.Archived, stats.Rotated))
+	c.JSON(http.StatusOK, gin.H{"retention": stats, "dry_run": false})
+}
+
+// handleCompact checkpoints the store and returns the bytes freed. With
+// dry_run=true it only reports the store's size.
+func (s *Server) handleCompact(c *gin.Context) {
+	compactor, ok := s.store.(model.Compactor)
+	if !ok {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "compaction is not supported by this storage backend"})
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+	var reclaimed, size int64
+	if !dryRun {
+		var err error
+		if reclaimed, err = compactor.Compact(); err != nil {
+			audit(c, "compact", false, "failed: "+err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "compaction failed: " + err.Error()})
+			return
+		}
+	}
+	if sizer, ok := s.store.(storageSizer); ok {
+		size, _ = sizer.StorageBytes()
+	}
+	audit(c, "compact", dryRun, fmt.Sprintf("reclaimed %d bytes, %d in use", reclaimed, size))
+	c.JSON(http.StatusOK, gin.H{"reclaimed_bytes": reclaimed, "storage_bytes": size, "dry_run": dryRun})
+}
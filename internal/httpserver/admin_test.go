@@ -0,0 +1,105 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+type stubRetention struct {
+	runs int
+}
+
+func (r *stubRetention) RetentionStats() model.RetentionStats {
+	return model.RetentionStats{Expired: int64(r.runs)}
+}
+
+func (r *stubRetention) RunNow() model.RetentionStats {
+	r.runs++
+	return r.RetentionStats()
+}
+
+func (r *stubRetention) Preview() (model.RetentionStats, error) {
+	return model.RetentionStats{Expired: 7}, nil
+}
+
+func TestAdminEndpoints(t *testing.T) {
+	srv, store, r := newTestServer(t)
+	base := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	if err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: base, App: "shop", Level: "INFO", Message: "a"},
+		{Timestamp: base.Add(time.Minute), App: "shop", Level: "INFO", Message: "b"},
+		{Timestamp: base.Add(2 * time.Minute), App: "billing", Level: "INFO", Message: "c"},
+	}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	post := func(path, key string) (int, map[string]any) {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var body map[string]any
+		json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body
+	}
+
+	if code, _ := post("/api/admin/purge?app=shop", ""); code != http.StatusForbidden {
+		t.Fatalf("purge without a keyring = %d, want 403", code)
+	}
+
+	keyring, err := NewKeyring([]APIKey{
+		{Name: "dashboard", Key: "read-only-key-0001", Scopes: []string{ScopeRead}},
+		{Name: "ops", Key: "admin-key-00000001", Scopes: []string{ScopeAdmin}},
+	})
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	srv.SetKeyring(keyring)
+	const admin = "admin-key-00000001"
+
+	if code, _ := post("/api/admin/purge?app=shop", "read-only-key-0001"); code != http.StatusForbidden {
+		t.Errorf("purge with a read key = %d, want 403", code)
+	}
+	if code, _ := post("/api/admin/purge", admin); code != http.StatusBadRequest {
+		t.Errorf("unscoped purge = %d, want 400", code)
+	}
+
+	code, body := post("/api/admin/purge?app=shop&dry_run=true", admin)
+	if code != http.StatusOK || body["deleted"] != 2.0 || body["dry_run"] != true {
+		t.Errorf("dry-run purge = %d %v, want 2 counted", code, body)
+	}
+	if n, _ := store.TotalLogCount(model.QueryOpts{}); n != 3 {
+		t.Fatalf("dry run deleted logs: %d left, want 3", n)
+	}
+	code, body = post("/api/admin/purge?app=shop&to="+base.Add(30*time.Second).Format(time.RFC3339), admin)
+	if code != http.StatusOK || body["deleted"] != 1.0 {
+		t.Errorf("purge = %d %v, want 1 deleted", code, body)
+	}
+	if n, _ := store.TotalLogCount(model.QueryOpts{}); n != 2 {
+		t.Errorf("%d logs left after purge, want 2", n)
+	}
+
+	if code, _ := post("/api/admin/retention", admin); code != http.StatusNotImplemented {
+		t.Errorf("retention without a runner = %d, want 501", code)
+	}
+	runner := &stubRetention{}
+	srv.SetRetentionReporter(runner)
+	if _, body := post("/api/admin/retention?dry_run=true", admin); runner.runs != 0 || body["retention"].(map[string]any)["expired"] != 7.0 {
+		t.Errorf("retention dry run = %v after %d runs, want the preview and none", body, runner.runs)
+	}
+	if code, _ := post("/api/admin/retention", admin); code != http.StatusOK || runner.runs != 1 {
+		t.Errorf("retention = %d after %d runs, want 200 and one run", code, runner.runs)
+	}
+
+	if code, body := post("/api/admin/compact", admin); code != http.StatusOK || body["dry_run"] != false {
+		t.Errorf("compact = %d %v", code, body)
+	}
+}
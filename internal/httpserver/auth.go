@@ -0,0 +1,233 @@
+package httpserver
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Scopes an API key can be limited to.
+const (
+	ScopeRead   = "read"   // health, logs, stats, and exports
+	ScopeQuery  = "query"  // ad-hoc SQL on /api/query and /api/stream
+	ScopeIngest = "ingest" // the CloudWatch and Logplex receivers, via Authorize
+	ScopeAdmin  = "admin"  // server administration; implies every other scope
+)
+
+// minAPIKeyLength rejects keys short enough to guess.
+const minAPIKeyLength = 16
+
+// grantKey is the gin context key of the caller's *apiGrant.
+const grantKey = "httpserver.grant"
+
+// APIKey is a key the HTTP API accepts and the scopes it grants.
+type APIKey struct {
+	Name   string   // identifies the key in logs; defaults to "key N"
+	Key    string   // sent as "Authorization: Bearer KEY" or "X-API-Key: KEY"
+	Scopes []string // empty grants every scope
+	// Apps limits the key to the logs of these apps, for one server shared
+	// by several teams; empty reads every app.
+	Apps []string
+}
+
+// Keyring holds the API keys the HTTP API accepts. Keys are kept as SHA-256
+// digests, so a lookup does not compare the secret byte by byte.
+type Keyring struct {
+	grants map[[sha256.Size]byte]*apiGrant
+}
+
+// apiGrant is what a presented key may do.
+type apiGrant struct {
+	name   string
+	scopes []string // nil = every scope
+	apps   []string // nil = every app
+}
+
+// NewKeyring validates keys and returns a Keyring accepting them.
+func NewKeyring(keys []APIKey) (*Keyring, error) {
+	k := &Keyring{grants: make(map[[sha256.Size]byte]*apiGrant, len(keys))}
+	for i, key := range keys {
+		name := key.Name
+		if name == "" {
+			name = fmt.Sprintf("key %d", i+1)
+		}
+		if len(key.Key) < minAPIKeyLength {
+			return nil, fmt.Errorf("%s: key must be at least %d characters", name, minAPIKeyLength)
+		}
+		digest := sha256.Sum256([]byte(key.Key))
+		if _, ok := k.grants[digest]; ok {
+			return nil, fmt.Errorf("%s: duplicate key", name)
+		}
+		grant := &apiGrant{name: name}
+		for _, scope := range key.Scopes {
+			scope = strings.ToLower(strings.TrimSpace(scope))
+			switch scope {
+			case ScopeRead, ScopeQuery, ScopeIngest, ScopeAdmin:
+				grant.scopes = append(grant.scopes, scope)
+			default:
+				return nil, fmt.Errorf("%s: unknown scope %q (want read, query, ingest, or admin)", name, scope)
+			}
+		}
+		for _, app := range key.Apps {
+			app = strings.TrimSpace(app)
+			if app == "" {
+				return nil, fmt.Errorf("%s: empty app name", name)
+			}
+			if !slices.Contains(grant.apps, app) {
+				grant.apps = append(grant.apps, app)
+			}
+		}
+		k.grants[digest] = grant
+	}
+	return k, nil
+}
+
+// Len returns the number of keys.
+func (k *Keyring) Len() int {
+	return len(k.grants)
+}
+
+// lookup returns the grant of a presented key.
+func (k *Keyring) lookup(token string) (*apiGrant, bool) {
+	grant, ok := k.grants[sha256.Sum256([]byte(token))]
+	return grant, ok
+}
+
+// Errors of Keyring.Authorize and RestrictApp.
+var (
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	ErrScopeDenied   = errors.New("API key lacks the scope")
+	ErrAppRequired   = errors.New("API key is limited to several apps; set app")
+	ErrAppDenied     = errors.New("API key is not allowed to read the app")
+)
+
+// Authorize returns the name of the key token and the apps it is limited
+// to (nil for every app) when it grants scope, for other listeners sharing
+// the HTTP API's keys. It fails with ErrInvalidAPIKey for an unknown key
+// and ErrScopeDenied without the scope.
+func (k *Keyring) Authorize(token, scope string) (name string, apps []string, err error) {
+	grant, ok := k.lookup(token)
+	if !ok {
+		return "", nil, ErrInvalidAPIKey
+	}
+	if !grant.allows(scope) {
+		return grant.name, grant.apps, fmt.Errorf("%w: %q lacks %s", ErrScopeDenied, grant.name, scope)
+	}
+	return grant.name, grant.apps, nil
+}
+
+// RestrictApp returns the app a query asking for app may read under a key
+// limited to apps: app itself when allowed, or the key's only app when app
+// is empty. It fails with ErrAppDenied for another app and ErrAppRequired
+// when app is empty and the key has several. Nil apps allows any app.
+func RestrictApp(app string, apps []string) (string, error) {
+	switch {
+	case apps == nil || slices.Contains(apps, app):
+		return app, nil
+	case app != "":
+		return "", fmt.Errorf("%w %q (allowed: %s)", ErrAppDenied, app, strings.Join(apps, ", "))
+	case len(apps) == 1:
+		return apps[0], nil
+	default:
+		return "", fmt.Errorf("%w (allowed: %s)", ErrAppRequired, strings.Join(apps, ", "))
+	}
+}
+
+// allows reports whether the grant covers scope.
+func (g *apiGrant) allows(scope string) bool {
+	return g.scopes == nil || slices.Contains(g.scopes, scope) || slices.Contains(g.scopes, ScopeAdmin)
+}
+
+// SetKeyring requires every API request to carry one of k's keys with the
+// scope its route needs. Nil leaves the API open, as it is by default.
+func (s *Server) SetKeyring(k *Keyring) {
+	s.keyring = k
+}
+
+// requireScope rejects requests without a key granting scope when a
+// keyring is set: 401 without a known key, 403 without the scope. Admin
+// routes are refused outright without a keyring.
+func (s *Server) requireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.keyring == nil {
+			if scope == ScopeAdmin {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin endpoints need api-keys configured"})
+			}
+			return
+		}
+		token, err := presentedKey(c.Request)
+		if err == nil && token == "" {
+			err = errors.New("API key required")
+		}
+		var grant *apiGrant
+		if err == nil {
+			var ok bool
+			if grant, ok = s.keyring.lookup(token); !ok {
+				err = errors.New("invalid API key")
+			}
+		}
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="tiny-telemetry"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		if !grant.allows(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("API key %q lacks the %s scope", grant.name, scope)})
+			return
+		}
+		c.Set(grantKey, grant)
+	}
+}
+
+// presentedKey returns the key of r: a bearer token, an X-API-Key header,
+// or, for WebSocket upgrades (browsers cannot set their headers), an
+// api_key query parameter.
+func presentedKey(r *http.Request) (string, error) {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		scheme, token, ok := strings.Cut(auth, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			return "", errors.New("unsupported Authorization scheme (want Bearer)")
+		}
+		return strings.TrimSpace(token), nil
+	}
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key, nil
+	}
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return r.URL.Query().Get("api_key"), nil
+	}
+	return "", nil
+}
+
+// granted reports whether the request's key covers scope; always true when
+// the API is open.
+func granted(c *gin.Context, scope string) bool {
+	v, ok := c.Get(grantKey)
+	if !ok {
+		return true
+	}
+	return v.(*apiGrant).allows(scope)
+}
+
+// grantedApps returns the apps the request's key is limited to; nil when
+// it reads every app or the API is open.
+func grantedApps(c *gin.Context) []string {
+	v, ok := c.Get(grantKey)
+	if !ok {
+		return nil
+	}
+	return v.(*apiGrant).apps
+}
+
+// refuseAppLimited rejects keys limited to apps with 403, for routes that
+// read across apps.
+func refuseAppLimited(c *gin.Context) {
+	if apps := grantedApps(c); apps != nil {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API key is limited to apps " + strings.Join(apps, ", ") + "; this endpoint reads across apps"})
+	}
+}
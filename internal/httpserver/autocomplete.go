@@ -0,0 +1,49 @@
+package httpserver
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// maxAutocompleteLimit caps the limit parameter of /api/attribute-values.
+const maxAutocompleteLimit = 100
+
+// handleAttributeValues returns the most frequent values of an attribute
+// key starting with prefix (case-insensitive), for clients offering
+// autocompletion while an attribute filter is typed. app, from, and to scope
+// the values; limit defaults to 10.
+func (s *Server) handleAttributeValues(c *gin.Context) {
+	key := c.Query("key")
+	if key == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "key is required"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 || limit > maxAutocompleteLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit: want 1-" + strconv.Itoa(maxAutocompleteLimit)})
+		return
+	}
+	opts, err := queryOpts(c)
+	if err != nil {
+		optsInvalid(c, err)
+		return
+	}
+
+	values, err := s.store.DistinctAttributeValues(key, c.Query("prefix"), limit, opts)
+	if err != nil {
+		if queryUnavailable(c, err) {
+			return
+		}
+		log.Printf("httpserver: attribute values: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "attribute values query failed"})
+		return
+	}
+	if values == nil {
+		values = []model.DimensionCount{}
+	}
+	c.JSON(http.StatusOK, gin.H{"key": key, "values": dimensionCounts(values)})
+}
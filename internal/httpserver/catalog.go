@@ -0,0 +1,55 @@
+package httpserver
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// handleCatalog returns a handler listing the values of dimension under
+// key, with their log counts and latest log, for /api/apps, /api/services,
+// and /api/hosts. limit defaults to 100.
+func (s *Server) handleCatalog(dimension, key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cataloger, ok := s.store.(model.Cataloger)
+		if !ok {
+			c.JSON(http.StatusNotImplemented, gin.H{"error": "catalog listing is not supported by this storage backend"})
+			return
+		}
+		opts, err := queryOpts(c)
+		// A key limited to several apps lists those of them without
+		// naming one.
+		allowed := dimension == "app" && errors.Is(err, ErrAppRequired)
+		if err != nil && !allowed {
+			optsInvalid(c, err)
+			return
+		}
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+		if err != nil || limit <= 0 || limit > maxStatsLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit: want 1-" + strconv.Itoa(maxStatsLimit)})
+			return
+		}
+		fetch := limit
+		if allowed {
+			fetch = maxStatsLimit
+		}
+		entries, err := cataloger.Catalog(dimension, fetch, opts)
+		if err != nil {
+			statsFailed(c, key, err)
+			return
+		}
+		if allowed {
+			apps := grantedApps(c)
+			entries = slices.DeleteFunc(entries, func(e model.CatalogEntry) bool { return !slices.Contains(apps, e.Value) })
+			entries = entries[:min(limit, len(entries))]
+		}
+		if entries == nil {
+			entries = []model.CatalogEntry{}
+		}
+		c.JSON(http.StatusOK, gin.H{key: entries})
+	}
+}
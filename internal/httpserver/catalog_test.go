@@ -0,0 +1,47 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestCatalogEndpoints(t *testing.T) {
+	_, store, r := newTestServer(t)
+	base := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	if err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: base, App: "shop", Service: "api", Hostname: "web-1", Level: "INFO", Message: "a"},
+		{Timestamp: base.Add(time.Minute), App: "shop", Service: "api", Hostname: "web-2", Level: "INFO", Message: "b"},
+		{Timestamp: base.Add(2 * time.Minute), App: "billing", Service: "", Hostname: "web-1", Level: "INFO", Message: "c"},
+	}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	get := func(path string) (int, map[string][]model.CatalogEntry) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var body map[string][]model.CatalogEntry
+		json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body
+	}
+
+	code, body := get("/api/apps")
+	apps := body["apps"]
+	if code != http.StatusOK || len(apps) != 2 || apps[0].Value != "shop" || apps[0].Count != 2 || !apps[0].LastSeen.Equal(base.Add(time.Minute)) {
+		t.Fatalf("apps = %d %+v", code, apps)
+	}
+	if _, body := get("/api/services"); len(body["services"]) != 2 || body["services"][1].Value != "unknown" {
+		t.Errorf("services = %+v, want api and unknown", body["services"])
+	}
+	if _, body := get("/api/hosts?app=shop&limit=1"); len(body["hosts"]) != 1 {
+		t.Errorf("hosts = %+v, want one", body["hosts"])
+	}
+	if code, _ := get("/api/hosts?limit=0"); code != http.StatusBadRequest {
+		t.Errorf("limit=0 status = %d, want 400", code)
+	}
+}
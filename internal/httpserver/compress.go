@@ -0,0 +1,141 @@
+package httpserver
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	gzipWriters  = sync.Pool{New: func() any { w, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression); return w }}
+	flateWriters = sync.Pool{New: func() any { w, _ := flate.NewWriter(nil, flate.DefaultCompression); return w }}
+)
+
+// compressResponse gzip- or deflate-encodes the response when the client
+// accepts it. Responses that set their own Content-Encoding or are already
+// compressed (Parquet) pass through unchanged.
+func compressResponse(c *gin.Context) {
+	encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+	c.Header("Vary", "Accept-Encoding")
+	if encoding == "" {
+		return
+	}
+	w := &compressWriter{ResponseWriter: c.Writer, encoding: encoding}
+	c.Writer = w
+	defer w.close()
+	c.Next()
+}
+
+// negotiateEncoding picks gzip, then deflate, from an Accept-Encoding
+// header, or "" for neither.
+func negotiateEncoding(header string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(k) == "q" {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = parsed
+			}
+		}
+		accepted[name] = q > 0
+	}
+	for _, encoding := range []string{"gzip", "deflate"} {
+		if ok, listed := accepted[encoding]; ok || (!listed && accepted["*"]) {
+			return encoding
+		}
+	}
+	return ""
+}
+
+// compressWriter encodes the body once the handler starts writing it, when
+// the response headers allow.
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	decided  bool
+	enc      interface {
+		io.WriteCloser
+		Flush() error
+	}
+}
+
+// decide sets up the encoder if the response should be encoded. It runs
+// before the first byte, while the headers can still change.
+func (w *compressWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	h := w.Header()
+	status := w.Status()
+	if h.Get("Content-Encoding") != "" || status == http.StatusNoContent || status == http.StatusNotModified || status == http.StatusPartialContent {
+		return
+	}
+	switch strings.TrimSpace(strings.Split(h.Get("Content-Type"), ";")[0]) {
+	case "application/octet-stream", "application/vnd.apache.parquet":
+		return
+	}
+	h.Set("Content-Encoding", w.encoding)
+	h.Del("Content-Length")
+	if w.encoding == "gzip" {
+		gz := gzipWriters.Get().(*gzip.Writer)
+		gz.Reset(w.ResponseWriter)
+		w.enc = gz
+	} else {
+		fl := flateWriters.Get().(*flate.Writer)
+		fl.Reset(w.ResponseWriter)
+		w.enc = fl
+	}
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if w.enc == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+	return w.enc.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) WriteHeaderNow() {
+	w.decide()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Flush sends what has been encoded so far, for streamed responses.
+func (w *compressWriter) Flush() {
+	w.decide()
+	if w.enc != nil {
+		w.enc.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// close ends the encoded stream and returns the encoder to its pool.
+func (w *compressWriter) close() {
+	if w.enc == nil {
+		return
+	}
+	w.enc.Close()
+	switch enc := w.enc.(type) {
+	case *gzip.Writer:
+		enc.Reset(io.Discard)
+		gzipWriters.Put(enc)
+	case *flate.Writer:
+		enc.Reset(io.Discard)
+		flateWriters.Put(enc)
+	}
+	w.enc = nil
+}
@@ -0,0 +1,90 @@
+package httpserver
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	for header, want := range map[string]string{
+		"":                      "",
+		"gzip":                  "gzip",
+		"deflate, gzip;q=1.0":   "gzip",
+		"gzip;q=0, deflate":     "deflate",
+		"br":                    "",
+		"*":                     "gzip",
+		"*, gzip;q=0":           "deflate",
+		"identity, Deflate;q=1": "deflate",
+	} {
+		if got := negotiateEncoding(header); got != want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestCompressedResponses(t *testing.T) {
+	_, store, r := newTestServer(t)
+	now := time.Now()
+	if err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now.Add(-time.Minute), Level: "INFO", Message: strings.Repeat("compressible ", 100)},
+	}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	get := func(method, path, body, encoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if encoding != "" {
+			req.Header.Set("Accept-Encoding", encoding)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get(http.MethodPost, "/api/query", `{"sql": "SELECT message FROM logs"}`, "gzip")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("query = %d, Content-Encoding %q; want gzip", w.Code, w.Header().Get("Content-Encoding"))
+	}
+	zr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	var result struct {
+		RowCount int `json:"row_count"`
+	}
+	if err := json.NewDecoder(zr).Decode(&result); err != nil || result.RowCount != 1 {
+		t.Fatalf("decoded query = %+v, %v", result, err)
+	}
+
+	w = get(http.MethodGet, "/api/logs", "", "deflate")
+	if w.Header().Get("Content-Encoding") != "deflate" {
+		t.Fatalf("logs Content-Encoding = %q, want deflate", w.Header().Get("Content-Encoding"))
+	}
+	data, err := io.ReadAll(flate.NewReader(w.Body))
+	if err != nil || !strings.Contains(string(data), "compressible") {
+		t.Fatalf("inflated logs = %.100q, %v", data, err)
+	}
+
+	// Streamed exports keep their trailers.
+	w = get(http.MethodGet, "/api/export?format=ndjson", "", "gzip")
+	if w.Header().Get("Content-Encoding") != "gzip" || w.Result().Trailer.Get("X-Row-Count") != "1" {
+		t.Fatalf("ndjson export Content-Encoding %q, X-Row-Count %q; want gzip and 1", w.Header().Get("Content-Encoding"), w.Result().Trailer.Get("X-Row-Count"))
+	}
+
+	// Parquet is compressed already.
+	if w := get(http.MethodGet, "/api/export", "", "gzip"); w.Code != http.StatusOK || w.Header().Get("Content-Encoding") != "" {
+		t.Fatalf("parquet export = %d, Content-Encoding %q; want 200 without encoding", w.Code, w.Header().Get("Content-Encoding"))
+	}
+	if w := get(http.MethodGet, "/api/logs", "", ""); w.Header().Get("Content-Encoding") != "" {
+		t.Fatalf("logs without Accept-Encoding are encoded as %q", w.Header().Get("Content-Encoding"))
+	}
+}
@@ -0,0 +1,91 @@
+package httpserver
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// docsPage renders the registered routes with a form per endpoint that
+// sends the request and shows the response. It is self-contained so it
+// works offline.
+var docsPage = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Tiny Telemetry API</title>
+<style>
+body { font: 14px/1.4 system-ui, sans-serif; margin: 2em auto; max-width: 960px; padding: 0 1em; }
+section { border: 1px solid #ccc; border-radius: 4px; margin: 1em 0; padding: 0.5em 1em; }
+h2 { font: bold 15px monospace; }
+.method { background: #246; border-radius: 3px; color: #fff; padding: 0 0.4em; }
+label { display: block; margin: 0.3em 0; }
+label span { display: inline-block; font-family: monospace; width: 9em; }
+input, textarea { font-family: monospace; width: 28em; }
+small { color: #666; }
+pre { background: #f4f4f4; max-height: 24em; overflow: auto; padding: 0.5em; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>Tiny Telemetry API</h1>
+<p>The machine-readable description is at <a href="openapi.json">/api/openapi.json</a>.</p>
+<label><span>API key</span> <input id="api-key" type="password" autocomplete="off"> <small>sent as a bearer token when set</small></label>
+{{range .}}
+<section>
+<h2><span class="method">{{.Method}}</span> {{.Path}}</h2>
+<p>{{.Summary}}</p>
+<form data-method="{{.Method}}" data-path="{{.Path}}">
+{{range .Params}}<label><span>{{.Name}}{{if .Required}}*{{end}}</span> <input name="{{.Name}}"> <small>{{.Description}}</small></label>
+{{end}}{{if .Body}}<label><span>body</span> <textarea name="-body" rows="3">{"sql": "SELECT count(*) FROM logs"}</textarea></label>
+{{end}}{{if ne .Path "/api/stream"}}<button>Send</button>{{else}}<small>WebSocket endpoint; see the read-surface docs.</small>{{end}}
+<pre hidden></pre>
+</form>
+</section>
+{{end}}
+<script>
+for (const form of document.querySelectorAll("form")) {
+  form.addEventListener("submit", async (e) => {
+    e.preventDefault();
+    const query = new URLSearchParams();
+    let path = form.dataset.path;
+    let body;
+    for (const [name, value] of new FormData(form)) {
+      if (name === "-body") body = value;
+      else if (path.includes("/:" + name)) path = path.replace("/:" + name, "/" + encodeURIComponent(value));
+      else if (value !== "") query.append(name, value);
+    }
+    const headers = body ? {"Content-Type": "application/json"} : {};
+    const key = document.getElementById("api-key").value;
+    if (key) headers["Authorization"] = "Bearer " + key;
+    const out = form.querySelector("pre");
+    out.hidden = false;
+    out.textContent = "…";
+    try {
+      const resp = await fetch(path + (query.size ? "?" + query : ""), {
+        method: form.dataset.method,
+        headers,
+        body,
+      });
+      const type = resp.headers.get("Content-Type") || "";
+      let text = type.includes("json") ? JSON.stringify(await resp.json(), null, 2) : await resp.text();
+      if (text.length > 100000) text = text.slice(0, 100000) + "\n…";
+      out.textContent = resp.status + " " + resp.statusText + "\n\n" + text;
+    } catch (err) {
+      out.textContent = String(err);
+    }
+  });
+}
+</script>
+</body>
+</html>
+`))
+
+// handleDocs serves the interactive API documentation.
+func (s *Server) handleDocs(c *gin.Context) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := docsPage.Execute(c.Writer, s.api); err != nil {
+		c.Error(err)
+	}
+}
@@ -0,0 +1,220 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// ParquetExporter is implemented by stores that can write logs to Parquet
+// (the DuckDB backend).
+type ParquetExporter interface {
+	ExportParquet(path string, opts model.QueryOpts, maxRows int64, timeout time.Duration) (int64, error)
+}
+
+// LogStreamer is implemented by stores that can hand over the logs matching
+// opts oldest first, a chunk at a time (both backends).
+type LogStreamer interface {
+	StreamLogs(ctx context.Context, opts model.QueryOpts, maxRows int64, fn func([]model.LogRecord) error) (int64, error)
+}
+
+// errExportLimit stops a stream that reached the export row limit.
+var errExportLimit = errors.New("export row limit reached")
+
+// csvHeader is the column row of a CSV export.
+var csvHeader = []string{"timestamp", "orig_timestamp", "level", "level_num", "message", "raw_line", "service", "hostname", "pid", "source", "app", "attributes", "body_json"}
+
+// SetExportLimits bounds every /api/export to maxRows rows and timeout;
+// zero disables either bound.
+func (s *Server) SetExportLimits(maxRows int64, timeout time.Duration) {
+	s.exportMaxRows = max(maxRows, 0)
+	s.exportTimeout = max(timeout, 0)
+}
+
+// handleExport writes the logs matching app/from/to, oldest first, as
+// format=parquet (the default), csv, or ndjson. CSV and NDJSON are
+// streamed as they are read; Parquet is staged in a temporary file.
+// X-Row-Count carries the rows written, and X-Export-Truncated is set when
+// the export stopped at the row or time limit.
+func (s *Server) handleExport(c *gin.Context) {
+	format := c.DefaultQuery("format", "parquet")
+	if format != "parquet" && format != "csv" && format != "ndjson" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported format: " + format + " (want parquet, csv, or ndjson)"})
+		return
+	}
+	opts, err := queryOpts(c)
+	if err != nil {
+		optsInvalid(c, err)
+		return
+	}
+	if format == "parquet" {
+		s.exportParquet(c, opts)
+		return
+	}
+	s.exportStream(c, format, opts)
+}
+
+func (s *Server) exportParquet(c *gin.Context, opts model.QueryOpts) {
+	exporter, ok := s.store.(ParquetExporter)
+	if !ok {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "parquet export requires the duckdb storage backend"})
+		return
+	}
+
+	dir, err := os.MkdirTemp("", "tiny-telemetry-export-")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to stage export"})
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "logs.parquet")
+	rows, err := exporter.ExportParquet(path, opts, s.exportMaxRows, s.exportTimeout)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "export timed out; narrow the time range"})
+			return
+		}
+		log.Printf("httpserver: export: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "export failed"})
+		return
+	}
+	c.Header("X-Row-Count", strconv.FormatInt(rows, 10))
+	if s.exportMaxRows > 0 && rows == s.exportMaxRows {
+		c.Header("X-Export-Truncated", "rows")
+	}
+	c.FileAttachment(path, "logs.parquet")
+}
+
+// exportStream writes CSV or NDJSON as the store hands the logs over. The
+// status is sent with the first chunk, so the row count and truncation are
+// HTTP trailers.
+func (s *Server) exportStream(c *gin.Context, format string, opts model.QueryOpts) {
+	streamer, ok := s.store.(LogStreamer)
+	if !ok {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": format + " export is not supported by this storage backend"})
+		return
+	}
+	ctx := c.Request.Context()
+	if s.exportTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.exportTimeout)
+		defer cancel()
+	}
+
+	c.Header("Trailer", "X-Row-Count, X-Export-Truncated")
+	var csvw *csv.Writer
+	var enc *json.Encoder
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="logs.csv"`)
+		csvw = csv.NewWriter(c.Writer)
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", `attachment; filename="logs.ndjson"`)
+		enc = json.NewEncoder(c.Writer)
+	}
+
+	// Ask for one row past the limit to tell a full export from a cut one.
+	limit := s.exportMaxRows
+	if limit > 0 {
+		limit++
+	}
+	var written int64
+	started := false
+	_, err := streamer.StreamLogs(ctx, opts, limit, func(records []model.LogRecord) error {
+		if !started {
+			started = true
+			c.Status(http.StatusOK)
+			if csvw != nil {
+				if err := csvw.Write(csvHeader); err != nil {
+					return err
+				}
+			}
+		}
+		for _, l := range toAPILogs(records) {
+			if s.exportMaxRows > 0 && written == s.exportMaxRows {
+				return errExportLimit
+			}
+			var err error
+			if csvw != nil {
+				err = csvw.Write(csvRecord(l))
+			} else {
+				err = enc.Encode(l)
+			}
+			if err != nil {
+				return err
+			}
+			written++
+		}
+		if csvw != nil {
+			csvw.Flush()
+			if err := csvw.Error(); err != nil {
+				return err
+			}
+		}
+		c.Writer.Flush()
+		return nil
+	})
+
+	truncated := ""
+	switch {
+	case errors.Is(err, errExportLimit):
+		truncated = "rows"
+	case err != nil && !started:
+		if queryUnavailable(c, err) {
+			return
+		}
+		log.Printf("httpserver: export: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "export failed"})
+		return
+	case err != nil && ctx.Err() != nil && c.Request.Context().Err() == nil:
+		truncated = "timeout"
+	case err != nil:
+		// The status has gone out; a cut body is all the client will see.
+		log.Printf("httpserver: export: %v", err)
+		return
+	}
+	if !started && csvw != nil {
+		csvw.Write(csvHeader)
+		csvw.Flush()
+	}
+	c.Writer.Header().Set("X-Row-Count", strconv.FormatInt(written, 10))
+	if truncated != "" {
+		c.Writer.Header().Set("X-Export-Truncated", truncated)
+	}
+}
+
+// csvRecord is the CSV row of l, matching csvHeader.
+func csvRecord(l apiLog) []string {
+	orig := ""
+	if l.OrigTimestamp != nil {
+		orig = l.OrigTimestamp.Format(time.RFC3339Nano)
+	}
+	attrs, _ := json.Marshal(l.Attributes)
+	return []string{
+		l.Timestamp.Format(time.RFC3339Nano),
+		orig,
+		l.Level,
+		strconv.Itoa(l.LevelNum),
+		l.Message,
+		l.RawLine,
+		l.Service,
+		l.Hostname,
+		strconv.Itoa(l.PID),
+		l.Source,
+		l.App,
+		string(attrs),
+		l.BodyJSON,
+	}
+}
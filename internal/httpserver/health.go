@@ -0,0 +1,69 @@
+package httpserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/health"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// storePinger is implemented by stores that can check their connection.
+type storePinger interface {
+	Ping() error
+}
+
+// componentStatus is a component's entry on /api/health/ready.
+type componentStatus struct {
+	Status      string     `json:"status"` // ok or failing
+	Required    bool       `json:"required"`
+	Error       string     `json:"error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"` // latest failure, kept after recovery
+	Restarts    int        `json:"restarts,omitempty"`      // times it recovered after failing
+}
+
+// AddReadinessCheck reports a component on /api/health/ready: check
+// returns nil when it is healthy, or why not. A failing required component
+// makes the server not ready (503); any other is reported as degraded. It
+// may be called after Start.
+func (s *Server) AddReadinessCheck(name string, required bool, check func() error) {
+	s.health.Add(name, required, check)
+}
+
+// SetHealthRegistry reports the components of r on /api/health/ready, in
+// place of the server's own checks, so other read surfaces can share them.
+// r should check the store. Call before Start.
+func (s *Server) SetHealthRegistry(r *health.Registry) {
+	s.health = r
+}
+
+// handleLive answers as long as the server is serving requests, without
+// touching the store.
+func (s *Server) handleLive(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "uptime": time.Since(s.startTime).String()})
+}
+
+// handleReady runs the readiness checks, the store's first: 200 when every
+// required component is healthy ("ready", or "degraded" when an optional
+// one is failing), 503 otherwise.
+func (s *Server) handleReady(c *gin.Context) {
+	status, code := "ready", http.StatusOK
+	states, _ := s.health.Health() // a registry never fails
+	components := make(map[string]componentStatus, len(states))
+	for _, ch := range states {
+		cs := componentStatus{Status: ch.Status, Required: ch.Required, Error: ch.Error, Restarts: ch.Restarts}
+		if !ch.LastErrorAt.IsZero() {
+			cs.LastErrorAt = &ch.LastErrorAt
+		}
+		if ch.Status == model.HealthFailing {
+			if ch.Required {
+				status, code = "not ready", http.StatusServiceUnavailable
+			} else if code == http.StatusOK {
+				status = "degraded"
+			}
+		}
+		components[ch.Name] = cs
+	}
+	c.JSON(code, gin.H{"status": status, "components": components})
+}
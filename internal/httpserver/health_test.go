@@ -0,0 +1,49 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadiness(t *testing.T) {
+	srv, store, r := newTestServer(t)
+
+	get := func(path string) (int, map[string]any) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var body map[string]any
+		json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body
+	}
+
+	if code, body := get("/api/health/ready"); code != http.StatusOK || body["status"] != "ready" {
+		t.Fatalf("ready = %d %v, want 200 ready", code, body)
+	}
+
+	var backupErr error
+	srv.AddReadinessCheck("backup", false, func() error { return backupErr })
+	backupErr = errors.New("bucket unreachable")
+	code, body := get("/api/health/ready")
+	backup := body["components"].(map[string]any)["backup"].(map[string]any)
+	if code != http.StatusOK || body["status"] != "degraded" || backup["error"] != "bucket unreachable" {
+		t.Errorf("ready with a failing optional check = %d %v, want 200 degraded", code, body)
+	}
+	backupErr = nil
+	code, body = get("/api/health/ready")
+	backup = body["components"].(map[string]any)["backup"].(map[string]any)
+	if code != http.StatusOK || body["status"] != "ready" || backup["restarts"] != 1.0 || backup["last_error_at"] == nil {
+		t.Errorf("ready after the check recovered = %d %v, want a restart and the last error time", code, body)
+	}
+
+	store.Close()
+	code, body = get("/api/health/ready")
+	if code != http.StatusServiceUnavailable || body["status"] != "not ready" {
+		t.Errorf("ready with the store closed = %d %v, want 503 not ready", code, body)
+	}
+	if code, _ := get("/api/health/live"); code != http.StatusOK {
+		t.Errorf("live with the store closed = %d, want 200", code)
+	}
+}
@@ -0,0 +1,131 @@
+package httpserver
+
+import (
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// apiLog is a log record as the API returns it.
+type apiLog struct {
+	Timestamp     time.Time         `json:"timestamp"`
+	OrigTimestamp *time.Time        `json:"orig_timestamp,omitempty"`
+	Level         string            `json:"level"`
+	LevelNum      int               `json:"level_num"`
+	Message       string            `json:"message"`
+	RawLine       string            `json:"raw_line"`
+	Service       string            `json:"service"`
+	Hostname      string            `json:"hostname"`
+	PID           int               `json:"pid"`
+	Attributes    map[string]string `json:"attributes"`
+	Source        string            `json:"source"`
+	App           string            `json:"app"`
+	BodyJSON      string            `json:"body_json,omitempty"`
+	TraceID       string            `json:"trace_id,omitempty"`
+	SpanID        string            `json:"span_id,omitempty"`
+}
+
+// toAPILogs converts records to their API form.
+func toAPILogs(records []model.LogRecord) []apiLog {
+	out := make([]apiLog, len(records))
+	for i, r := range records {
+		out[i] = apiLog{
+			Timestamp:  r.Timestamp,
+			Level:      r.Level,
+			LevelNum:   r.LevelNum,
+			Message:    r.Message,
+			RawLine:    r.RawLine,
+			Service:    r.Service,
+			Hostname:   r.Hostname,
+			PID:        r.PID,
+			Attributes: r.Attributes,
+			Source:     r.Source,
+			App:        r.App,
+			BodyJSON:   r.BodyJSON,
+		}
+		out[i].TraceID, out[i].SpanID = model.TraceContext(r.Attributes)
+		if !r.OrigTimestamp.IsZero() {
+			out[i].OrigTimestamp = &r.OrigTimestamp
+		}
+		if out[i].Attributes == nil {
+			out[i].Attributes = map[string]string{}
+		}
+	}
+	return out
+}
+
+// handleLogs returns a page of logs, newest first, matching structured
+// filters: app, from, to, level (comma-separated severities), attr
+// (key=value, repeatable), and pattern (a message regular expression).
+// limit defaults to 100; next_cursor, passed back as cursor, continues
+// after the page.
+func (s *Server) handleLogs(c *gin.Context) {
+	finder, ok := s.store.(model.LogFinder)
+	if !ok {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "log listing is not supported by this storage backend"})
+		return
+	}
+	opts, err := queryOpts(c)
+	if err != nil {
+		optsInvalid(c, err)
+		return
+	}
+	filter := model.LogFilter{
+		QueryOpts: opts,
+		Pattern:   c.Query("pattern"),
+		Cursor:    c.Query("cursor"),
+	}
+	filter.Limit, err = strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || filter.Limit <= 0 || filter.Limit > model.MaxLogPage {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit: want 1-" + strconv.Itoa(model.MaxLogPage)})
+		return
+	}
+	if value := c.Query("level"); value != "" {
+		for _, level := range strings.Split(value, ",") {
+			filter.Levels = append(filter.Levels, strings.ToUpper(strings.TrimSpace(level)))
+		}
+	}
+	for _, pair := range c.QueryArray("attr") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid attr: " + pair + " (want key=value)"})
+			return
+		}
+		if filter.Attributes == nil {
+			filter.Attributes = make(map[string]string)
+		}
+		filter.Attributes[key] = value
+	}
+	if filter.Pattern != "" {
+		if _, err := regexp.Compile(filter.Pattern); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pattern: " + err.Error()})
+			return
+		}
+	}
+	if filter.Cursor != "" {
+		if _, err := model.ParseLogCursor(filter.Cursor); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	page, err := finder.FindLogs(filter)
+	if err != nil {
+		if queryUnavailable(c, err) {
+			return
+		}
+		log.Printf("httpserver: logs: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "logs query failed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"logs":        toAPILogs(page.Logs),
+		"next_cursor": page.NextCursor,
+	})
+}
@@ -0,0 +1,84 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+)
+
+func TestLogsEndpoint_FiltersAndPages(t *testing.T) {
+	_, store, r := newTestServer(t)
+	now := time.Now().Truncate(time.Second)
+	if err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now.Add(-time.Minute), Level: "ERROR", Message: "old failure", App: "shop", Attributes: map[string]string{"region": "eu"}},
+		{Timestamp: now, Level: "ERROR", Message: "failure a", App: "shop", Attributes: map[string]string{"region": "eu", "trace.id": "4BF92F35", "span.id": "00F067AA"}},
+		{Timestamp: now, Level: "ERROR", Message: "failure b", App: "shop", Attributes: map[string]string{"region": "eu"}},
+		{Timestamp: now, Level: "ERROR", Message: "failure c", App: "shop", Attributes: map[string]string{"region": "us"}},
+		{Timestamp: now, Level: "INFO", Message: "fine", App: "shop", Attributes: map[string]string{"region": "eu"}},
+		{Timestamp: now, Level: "ERROR", Message: "failure elsewhere", App: "jobs", Attributes: map[string]string{"region": "eu"}},
+	}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	// Two logs per page, through ties on the newest timestamp.
+	query := url.Values{"app": {"shop"}, "level": {"error"}, "attr": {"region=eu"}, "pattern": {"^fail|old"}, "limit": {"2"}}
+	var got []string
+	for range 5 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/logs?"+query.Encode(), nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+		}
+		var body struct {
+			Logs []struct {
+				Timestamp  time.Time         `json:"timestamp"`
+				Level      string            `json:"level"`
+				Message    string            `json:"message"`
+				Attributes map[string]string `json:"attributes"`
+				TraceID    *string           `json:"trace_id"`
+				SpanID     *string           `json:"span_id"`
+			} `json:"logs"`
+			NextCursor string `json:"next_cursor"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		for _, l := range body.Logs {
+			if l.Level != "ERROR" || l.Attributes["region"] != "eu" || l.Timestamp.IsZero() {
+				t.Fatalf("log %+v does not match the filters", l)
+			}
+			if l.Message == "failure a" {
+				if l.TraceID == nil || *l.TraceID != "4bf92f35" || l.SpanID == nil || *l.SpanID != "00f067aa" {
+					t.Fatalf("log %q trace_id = %v, span_id = %v; want its trace context", l.Message, l.TraceID, l.SpanID)
+				}
+			} else if l.TraceID != nil || l.SpanID != nil {
+				t.Fatalf("log %q has a trace context, want trace_id and span_id left out", l.Message)
+			}
+			got = append(got, l.Message)
+		}
+		if body.NextCursor == "" {
+			break
+		}
+		query.Set("cursor", body.NextCursor)
+	}
+	if strings.Join(got, ",") != "failure b,failure a,old failure" {
+		t.Fatalf("paged logs = %v, want newest first", got)
+	}
+}
+
+func TestLogsEndpoint_BadParams(t *testing.T) {
+	_, _, r := newTestServer(t)
+	for _, q := range []string{"limit=0", "limit=5000", "attr=region", "pattern=(", "cursor=nope", "from=yesterday"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/logs?"+q, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", q, w.Code)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package httpserver
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiRoute is a registered endpoint and the description of it served on
+// /api/openapi.json and /api/docs.
+type apiRoute struct {
+	Method  string
+	Path    string
+	Summary string
+	// Scope is the API key scope the route needs when a keyring is set;
+	// empty leaves it open.
+	Scope string
+	// Limited counts requests against the per-client rate limit.
+	Limited bool
+	// Compressed gzip- or deflate-encodes the response when accepted.
+	Compressed bool
+	// AllApps marks a route reading across apps, refused to keys limited
+	// to apps.
+	AllApps bool
+	Params  []apiParam
+	// Body and Response are zero values of the request body and the 200
+	// response; the spec describes their types. Nil means none.
+	Body     any
+	Response any
+	// Produces lists the 200 response content types other than JSON, sent
+	// instead of Response when it is nil or when the client asks for them.
+	Produces []string
+}
+
+// apiParam is a parameter of an apiRoute.
+type apiParam struct {
+	Name        string
+	Type        string // string, integer, or boolean
+	Description string
+	Required    bool
+	Repeated    bool
+	// In is "path" for a :name segment of the route's path; empty for the
+	// query string.
+	In string
+}
+
+// scopeParams are the app/from/to parameters read by queryOpts.
+var scopeParams = []apiParam{
+	{Name: "app", Type: "string", Description: "only logs from this app"},
+	{Name: "from", Type: "string", Description: "start time: RFC 3339 or a duration before now, e.g. 15m"},
+	{Name: "to", Type: "string", Description: "end time (exclusive): RFC 3339 or a duration before now"},
+}
+
+// scoped returns scopeParams followed by params.
+func scoped(params ...apiParam) []apiParam {
+	return append(append([]apiParam{}, scopeParams...), params...)
+}
+
+// errorBody is the body of every non-2xx JSON response.
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// handle registers h for route, behind the key check of its scope and the
+// rate limit, and records route for the spec.
+func (s *Server) handle(r gin.IRoutes, route apiRoute, h gin.HandlerFunc) {
+	var chain []gin.HandlerFunc
+	if route.Scope != "" {
+		chain = append(chain, s.requireScope(route.Scope))
+		if route.AllApps {
+			chain = append(chain, refuseAppLimited)
+		}
+	}
+	if route.Limited {
+		chain = append(chain, s.rateLimit)
+	}
+	if route.Compressed {
+		chain = append(chain, compressResponse)
+	}
+	r.Handle(route.Method, route.Path, append(chain, h)...)
+	s.api = append(s.api, route)
+}
+
+// handleOpenAPI returns the OpenAPI 3 description of the registered routes.
+func (s *Server) handleOpenAPI(c *gin.Context) {
+	c.JSON(http.StatusOK, s.openAPISpec())
+}
+
+// openAPISpec builds the OpenAPI document from the registered routes.
+func (s *Server) openAPISpec() gin.H {
+	paths := gin.H{}
+	for _, route := range s.api {
+		op := gin.H{
+			"summary":     route.Summary,
+			"operationId": operationID(route),
+		}
+		var notes []string
+		if route.Scope != "" && s.keyring != nil {
+			notes = append(notes, "Needs an API key with the "+route.Scope+" scope.")
+			op["security"] = []gin.H{{"bearerKey": []string{}}, {"headerKey": []string{}}}
+			if route.AllApps {
+				notes = append(notes, "Refused to API keys limited to apps.")
+			}
+		}
+		if route.Limited && s.limiter != nil {
+			notes = append(notes, "Rate limited per client; over the limit it answers 429 with Retry-After.")
+		}
+		if len(notes) > 0 {
+			op["description"] = strings.Join(notes, " ")
+		}
+		if len(route.Params) > 0 {
+			params := make([]gin.H, len(route.Params))
+			for i, p := range route.Params {
+				schema := gin.H{"type": p.Type}
+				if p.Repeated {
+					schema = gin.H{"type": "array", "items": schema}
+				}
+				in := "query"
+				if p.In != "" {
+					in = p.In
+				}
+				params[i] = gin.H{
+					"name":        p.Name,
+					"in":          in,
+					"description": p.Description,
+					"required":    p.Required,
+					"schema":      schema,
+				}
+			}
+			op["parameters"] = params
+		}
+		if route.Body != nil {
+			op["requestBody"] = gin.H{
+				"required": true,
+				"content":  gin.H{"application/json": gin.H{"schema": jsonSchema(reflect.TypeOf(route.Body))}},
+			}
+		}
+		ok := gin.H{"description": "OK"}
+		content := gin.H{}
+		if route.Response != nil {
+			content["application/json"] = gin.H{"schema": jsonSchema(reflect.TypeOf(route.Response))}
+		}
+		for _, typ := range route.Produces {
+			content[typ] = gin.H{"schema": gin.H{"type": "string", "format": "binary"}}
+		}
+		if len(content) > 0 {
+			ok["content"] = content
+		}
+		op["responses"] = gin.H{
+			"200": ok,
+			"default": gin.H{
+				"description": "Error",
+				"content":     gin.H{"application/json": gin.H{"schema": jsonSchema(reflect.TypeOf(errorBody{}))}},
+			},
+		}
+
+		path := specPath(route.Path)
+		item, _ := paths[path].(gin.H)
+		if item == nil {
+			item = gin.H{}
+			paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = op
+	}
+	spec := gin.H{
+		"openapi": "3.0.3",
+		"info": gin.H{
+			"title":       "Tiny Telemetry API",
+			"description": "Read-only access to the logs Tiny Telemetry has stored.",
+			"version":     "1",
+		},
+		"paths": paths,
+	}
+	if s.keyring != nil {
+		spec["components"] = gin.H{"securitySchemes": gin.H{
+			"bearerKey": gin.H{"type": "http", "scheme": "bearer"},
+			"headerKey": gin.H{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+		}}
+	}
+	return spec
+}
+
+// operationID derives an operation id from the route, e.g. getStatsHosts.
+func operationID(route apiRoute) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(route.Method))
+	for _, part := range strings.FieldsFunc(strings.TrimPrefix(route.Path, "/api"), func(r rune) bool {
+		return r == '/' || r == '-' || r == ':'
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// specPath writes the :name segments of a gin path as OpenAPI's {name}.
+func specPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if name, ok := strings.CutPrefix(part, ":"); ok {
+			parts[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// jsonSchema describes how encoding/json renders values of t.
+func jsonSchema(t reflect.Type) gin.H {
+	if t == timeType {
+		return gin.H{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return jsonSchema(t.Elem())
+	case reflect.Bool:
+		return gin.H{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return gin.H{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return gin.H{"type": "number"}
+	case reflect.String:
+		return gin.H{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return gin.H{"type": "array", "items": jsonSchema(t.Elem())}
+	case reflect.Map:
+		return gin.H{"type": "object", "additionalProperties": jsonSchema(t.Elem())}
+	case reflect.Struct:
+		props := gin.H{}
+		var required []string
+		addStructFields(t, props, &required)
+		schema := gin.H{"type": "object", "properties": props}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		// interface{} and friends: any JSON value.
+		return gin.H{}
+	}
+}
+
+// addStructFields adds the JSON fields of struct type t to props, flattening
+// embedded structs the way encoding/json does. Fields without omitempty are
+// always present, so they are listed in required.
+func addStructFields(t reflect.Type, props gin.H, required *[]string) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			addStructFields(f.Type, props, required)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = jsonSchema(f.Type)
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAPIEndpoint(t *testing.T) {
+	_, _, r := newTestServer(t)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("openapi status = %d; body: %s", w.Code, w.Body.String())
+	}
+	var spec struct {
+		OpenAPI string                               `json:"openapi"`
+		Paths   map[string]map[string]map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Fatalf("openapi = %q, want 3.x", spec.OpenAPI)
+	}
+
+	// Every route on the router is described.
+	for _, route := range r.Routes() {
+		if _, ok := spec.Paths[specPath(route.Path)][strings.ToLower(route.Method)]; !ok {
+			t.Errorf("%s %s is missing from the spec", route.Method, route.Path)
+		}
+	}
+
+	// Path parameters are in the path.
+	run := spec.Paths["/api/saved-queries/{id}/run"]["post"]
+	if params, _ := run["parameters"].([]any); len(params) != 1 || params[0].(map[string]any)["in"] != "path" || run["operationId"] != "postSavedQueriesIdRun" {
+		t.Errorf("saved query run = %v, want an id path parameter", run)
+	}
+
+	// Response shapes come from the handlers' types.
+	logs := spec.Paths["/api/logs"]["get"]
+	content := logs["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)
+	schema := content["application/json"].(map[string]any)["schema"].(map[string]any)
+	items := schema["properties"].(map[string]any)["logs"].(map[string]any)["items"].(map[string]any)
+	props := items["properties"].(map[string]any)
+	for _, field := range []string{"timestamp", "level", "message", "attributes"} {
+		if _, ok := props[field]; !ok {
+			t.Errorf("/api/logs log schema has no %q: %v", field, props)
+		}
+	}
+	if ts := props["timestamp"].(map[string]any); ts["format"] != "date-time" {
+		t.Errorf("timestamp schema = %v, want a date-time string", ts)
+	}
+	if _, ok := spec.Paths["/api/query"]["post"]["requestBody"]; !ok {
+		t.Error("POST /api/query has no request body")
+	}
+}
+
+func TestDocsEndpoint(t *testing.T) {
+	_, _, r := newTestServer(t)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("docs status = %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Content-Type = %q, want text/html", ct)
+	}
+	if body := w.Body.String(); !strings.Contains(body, `data-path="/api/logs"`) || !strings.Contains(body, `name="cursor"`) {
+		t.Error("docs page does not describe /api/logs and its parameters")
+	}
+}
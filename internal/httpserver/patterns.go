@@ -0,0 +1,38 @@
+package httpserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// PatternSource exposes the message templates mined from ingested logs.
+type PatternSource interface {
+	Patterns(level, service string, limit int) []model.LogPattern
+}
+
+// SetPatternSource serves p on /api/patterns.
+func (s *Server) SetPatternSource(p PatternSource) {
+	s.patterns = p
+}
+
+// handlePatterns returns the mined message templates, most frequent first,
+// optionally for one severity and service.
+func (s *Server) handlePatterns(c *gin.Context) {
+	if s.patterns == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "pattern mining is disabled on this server"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > maxStatsLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit: want 1-" + strconv.Itoa(maxStatsLimit)})
+		return
+	}
+	patterns := s.patterns.Patterns(c.Query("level"), c.Query("service"), limit)
+	if patterns == nil {
+		patterns = []model.LogPattern{}
+	}
+	c.JSON(http.StatusOK, gin.H{"patterns": patterns})
+}
@@ -0,0 +1,58 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// patternStub records the filters it was asked for.
+type patternStub struct {
+	level, service string
+	limit          int
+}
+
+func (p *patternStub) Patterns(level, service string, limit int) []model.LogPattern {
+	p.level, p.service, p.limit = level, service, limit
+	return []model.LogPattern{{Template: "user <*> logged in", Level: "INFO", Service: "auth", Count: 7}}
+}
+
+func TestPatternsEndpoint(t *testing.T) {
+	srv, _, r := newTestServer(t)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	if w := get("/api/patterns"); w.Code != http.StatusNotImplemented {
+		t.Fatalf("patterns without a source = %d, want 501", w.Code)
+	}
+
+	stub := &patternStub{}
+	srv.SetPatternSource(stub)
+	w := get("/api/patterns?level=INFO&service=auth&limit=5")
+	if w.Code != http.StatusOK {
+		t.Fatalf("patterns status = %d; body: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Patterns []model.LogPattern `json:"patterns"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(body.Patterns) != 1 || body.Patterns[0].Count != 7 {
+		t.Errorf("patterns = %+v", body.Patterns)
+	}
+	if stub.level != "INFO" || stub.service != "auth" || stub.limit != 5 {
+		t.Errorf("source asked for %+v, want INFO/auth/5", *stub)
+	}
+
+	if w := get("/api/patterns?limit=0"); w.Code != http.StatusBadRequest {
+		t.Errorf("limit=0 status = %d, want 400", w.Code)
+	}
+}
@@ -0,0 +1,67 @@
+package httpserver
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// handleRate returns log counts per step for each value of a dimension
+// (service, host, app, or level) over the last window, so clients can chart
+// a series such as errors per service without writing SQL. level takes a
+// comma-separated list of severities; app, from, and to scope the query.
+func (s *Server) handleRate(c *gin.Context) {
+	opts, err := queryOpts(c)
+	if err != nil {
+		optsInvalid(c, err)
+		return
+	}
+	dimension := c.DefaultQuery("dimension", "service")
+	var window, step time.Duration
+	for _, p := range []struct {
+		name string
+		def  string
+		dst  *time.Duration
+	}{{"window", "1h", &window}, {"step", "1m", &step}} {
+		value := c.DefaultQuery(p.name, p.def)
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + p.name + ": " + value})
+			return
+		}
+		*p.dst = d
+	}
+	if _, err := model.RateScope(dimension, window, step, opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var levels []string
+	if value := c.Query("level"); value != "" {
+		for _, level := range strings.Split(value, ",") {
+			levels = append(levels, strings.ToUpper(strings.TrimSpace(level)))
+		}
+	}
+
+	rates, err := s.store.RateByDimension(dimension, window, step, levels, opts)
+	if err != nil {
+		if queryUnavailable(c, err) {
+			return
+		}
+		log.Printf("httpserver: rate: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "rate query failed"})
+		return
+	}
+	if rates == nil {
+		rates = []model.DimensionRate{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"dimension": dimension,
+		"window":    window.String(),
+		"step":      step.String(),
+		"rates":     rates,
+	})
+}
@@ -0,0 +1,107 @@
+package httpserver
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateSweepInterval is how often idle buckets are dropped.
+const rateSweepInterval = time.Minute
+
+// rateLimiter is a token bucket per client: each holds up to burst tokens,
+// refilled at rate per second, and a request takes one.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(max(burst, 1)),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// allow takes a token from client's bucket, or reports how long until one
+// is available.
+func (l *rateLimiter) allow(client string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= rateSweepInterval {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// sweep drops the buckets that have refilled completely; they are
+// recreated full on the client's next request.
+func (l *rateLimiter) sweep(now time.Time) {
+	full := time.Duration(l.burst / l.rate * float64(time.Second))
+	for client, b := range l.buckets {
+		if now.Sub(b.last) >= full {
+			delete(l.buckets, client)
+		}
+	}
+	l.lastSweep = now
+}
+
+// SetRateLimit limits each client to perSecond requests to the expensive
+// endpoints (/api/query, /api/export, and query streams), with bursts of
+// up to burst. Clients are told apart by API key, or by remote address
+// when keys are not required. Zero disables the limit.
+func (s *Server) SetRateLimit(perSecond float64, burst int) {
+	if perSecond <= 0 {
+		s.limiter = nil
+		return
+	}
+	s.limiter = newRateLimiter(perSecond, burst)
+}
+
+// rateClient identifies the caller of c for rate limiting. The remote
+// address is used rather than X-Forwarded-For, which any client can set.
+func rateClient(c *gin.Context) string {
+	if v, ok := c.Get(grantKey); ok {
+		return "key:" + v.(*apiGrant).name
+	}
+	return "ip:" + c.RemoteIP()
+}
+
+// rateLimit answers 429 with Retry-After once the caller has used up its
+// requests.
+func (s *Server) rateLimit(c *gin.Context) {
+	if s.limiter == nil {
+		return
+	}
+	if ok, wait := s.limiter.allow(rateClient(c), time.Now()); !ok {
+		seconds := int(math.Ceil(wait.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(seconds))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded; retry after " + strconv.Itoa(seconds) + "s"})
+	}
+}
@@ -0,0 +1,82 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(2, 3)
+	now := time.Now()
+
+	for i := range 3 {
+		if ok, _ := l.allow("a", now); !ok {
+			t.Fatalf("request %d within the burst was limited", i+1)
+		}
+	}
+	ok, wait := l.allow("a", now)
+	if ok || wait != 500*time.Millisecond {
+		t.Fatalf("4th request = %v, wait %s; want limited for 500ms", ok, wait)
+	}
+	if ok, _ := l.allow("b", now); !ok {
+		t.Fatal("another client shares the first one's bucket")
+	}
+	if ok, _ := l.allow("a", now.Add(500*time.Millisecond)); !ok {
+		t.Fatal("request after the refill was limited")
+	}
+
+	// Idle, refilled buckets are dropped.
+	l.allow("a", now.Add(2*rateSweepInterval))
+	if len(l.buckets) != 1 {
+		t.Fatalf("%d buckets after the sweep, want 1", len(l.buckets))
+	}
+}
+
+func TestRateLimit_Endpoint(t *testing.T) {
+	srv, _, r := newTestServer(t)
+	srv.SetRateLimit(0.001, 2)
+
+	query := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/query", strings.NewReader(`{"sql": "SELECT 1"}`))
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	for range 2 {
+		if w := query(""); w.Code != http.StatusOK {
+			t.Fatalf("query within the burst = %d; body: %s", w.Code, w.Body.String())
+		}
+	}
+	w := query("")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Fatalf("query over the limit = %d, Retry-After %q; want 429 with Retry-After", w.Code, w.Header().Get("Retry-After"))
+	}
+	// Cheap endpoints are not limited.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/health", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("health = %d, want 200", w.Code)
+	}
+
+	// With keys, each key has its own bucket.
+	keyring, err := NewKeyring([]APIKey{{Name: "a", Key: "first-key-000000001"}, {Name: "b", Key: "second-key-00000001"}})
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	srv.SetKeyring(keyring)
+	for range 2 {
+		query("first-key-000000001")
+	}
+	if w := query("first-key-000000001"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("key a over the limit = %d, want 429", w.Code)
+	}
+	if w := query("second-key-00000001"); w.Code != http.StatusOK {
+		t.Fatalf("key b = %d, want 200", w.Code)
+	}
+}
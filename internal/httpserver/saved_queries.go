@@ -0,0 +1,166 @@
+package httpserver
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// savedQueryBody is the request body creating or replacing a saved query.
+type savedQueryBody struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	SQL         string `json:"sql"`
+}
+
+// savedQueryStore returns the store's saved queries, answering 501 when it
+// keeps none.
+func (s *Server) savedQueryStore(c *gin.Context) (model.SavedQueryStore, bool) {
+	saved, ok := s.store.(model.SavedQueryStore)
+	if !ok {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "saved queries require the duckdb storage backend"})
+	}
+	return saved, ok
+}
+
+// savedQueryID reads the :id path parameter, answering 400 when it is not
+// an id.
+func savedQueryID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid saved query id " + strconv.Quote(c.Param("id"))})
+		return 0, false
+	}
+	return id, true
+}
+
+// savedQueryFailed answers a SavedQueryStore error: 404 for an unknown id,
+// 409 for a taken name, 400 for an invalid query.
+func savedQueryFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, model.ErrSavedQueryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrSavedQueryExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrSavedQueryInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		if queryUnavailable(c, err) {
+			return
+		}
+		log.Printf("httpserver: saved queries: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to access saved queries"})
+	}
+}
+
+// handleListSavedQueries returns the saved queries by name.
+func (s *Server) handleListSavedQueries(c *gin.Context) {
+	saved, ok := s.savedQueryStore(c)
+	if !ok {
+		return
+	}
+	queries, err := saved.SavedQueries()
+	if err != nil {
+		savedQueryFailed(c, err)
+		return
+	}
+	if queries == nil {
+		queries = []model.SavedQuery{}
+	}
+	c.JSON(http.StatusOK, gin.H{"saved_queries": queries})
+}
+
+// handleGetSavedQuery returns one saved query.
+func (s *Server) handleGetSavedQuery(c *gin.Context) {
+	saved, ok := s.savedQueryStore(c)
+	if !ok {
+		return
+	}
+	id, ok := savedQueryID(c)
+	if !ok {
+		return
+	}
+	q, err := saved.SavedQuery(id)
+	if err != nil {
+		savedQueryFailed(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, q)
+}
+
+// handleSaveQuery creates a saved query (POST, 201) or replaces the one
+// with the :id path parameter (PUT).
+func (s *Server) handleSaveQuery(c *gin.Context) {
+	saved, ok := s.savedQueryStore(c)
+	if !ok {
+		return
+	}
+	var q model.SavedQuery
+	status := http.StatusCreated
+	if c.Param("id") != "" {
+		if q.ID, ok = savedQueryID(c); !ok {
+			return
+		}
+		status = http.StatusOK
+	}
+	var body savedQueryBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON body"})
+		return
+	}
+	q.Name, q.Description, q.SQL = body.Name, body.Description, body.SQL
+	q, err := saved.SaveQuery(q)
+	if err != nil {
+		savedQueryFailed(c, err)
+		return
+	}
+	c.JSON(status, q)
+}
+
+// handleDeleteSavedQuery deletes a saved query.
+func (s *Server) handleDeleteSavedQuery(c *gin.Context) {
+	saved, ok := s.savedQueryStore(c)
+	if !ok {
+		return
+	}
+	id, ok := savedQueryID(c)
+	if !ok {
+		return
+	}
+	if err := saved.DeleteSavedQuery(id); err != nil {
+		savedQueryFailed(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// handleRunSavedQuery runs a saved query as /api/query would. The body is
+// optional and takes the params, force, max_rows, and timeout of a query
+// request; its sql is ignored.
+func (s *Server) handleRunSavedQuery(c *gin.Context) {
+	saved, ok := s.savedQueryStore(c)
+	if !ok {
+		return
+	}
+	id, ok := savedQueryID(c)
+	if !ok {
+		return
+	}
+	var req queryRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON body"})
+		return
+	}
+	q, err := saved.SavedQuery(id)
+	if err != nil {
+		savedQueryFailed(c, err)
+		return
+	}
+	req.SQL = q.SQL
+	s.runQuery(c, req)
+}
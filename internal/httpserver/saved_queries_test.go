@@ -0,0 +1,95 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestSavedQueryEndpoints(t *testing.T) {
+	_, store, r := newTestServer(t)
+	now := time.Now()
+	if err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now, App: "shop", Level: "ERROR", Message: "a"},
+		{Timestamp: now, App: "shop", Level: "INFO", Message: "b"},
+		{Timestamp: now, App: "billing", Level: "ERROR", Message: "c"},
+	}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/saved-queries", `{"name": "errors", "sql": "SELECT count(*) AS n FROM logs WHERE level = 'ERROR' AND app LIKE ?"}`)
+	var created model.SavedQuery
+	json.Unmarshal(w.Body.Bytes(), &created)
+	if w.Code != http.StatusCreated || created.ID == 0 {
+		t.Fatalf("create = %d %s", w.Code, w.Body.String())
+	}
+	id := "/api/saved-queries/" + strconv.FormatInt(created.ID, 10)
+
+	for _, tt := range []struct {
+		method, path, body string
+		want               int
+	}{
+		{http.MethodPost, "/api/saved-queries", `{"name": "errors", "sql": "SELECT 1"}`, http.StatusConflict},
+		{http.MethodPost, "/api/saved-queries", `{"name": "wipe", "sql": "DELETE FROM logs"}`, http.StatusBadRequest},
+		{http.MethodGet, "/api/saved-queries/abc", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/saved-queries/999", "", http.StatusNotFound},
+		{http.MethodPost, "/api/saved-queries/999/run", "", http.StatusNotFound},
+		{http.MethodGet, id, "", http.StatusOK},
+	} {
+		if w := do(tt.method, tt.path, tt.body); w.Code != tt.want {
+			t.Errorf("%s %s = %d, want %d; body: %s", tt.method, tt.path, w.Code, tt.want, w.Body.String())
+		}
+	}
+
+	w = do(http.MethodPost, id+"/run", `{"params": ["%"]}`)
+	var result struct {
+		Rows []map[string]any `json:"rows"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &result)
+	if w.Code != http.StatusOK || len(result.Rows) != 1 || result.Rows[0]["n"] != float64(2) {
+		t.Fatalf("run = %d %s, want n=2", w.Code, w.Body.String())
+	}
+
+	w = do(http.MethodPut, id, `{"name": "shop errors", "sql": "SELECT count(*) AS n FROM logs WHERE level = 'ERROR' AND app = 'shop'"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("update = %d %s", w.Code, w.Body.String())
+	}
+	w = do(http.MethodPost, id+"/run", "")
+	json.Unmarshal(w.Body.Bytes(), &result)
+	if w.Code != http.StatusOK || result.Rows[0]["n"] != float64(1) {
+		t.Fatalf("run without a body = %d %s, want n=1", w.Code, w.Body.String())
+	}
+
+	w = do(http.MethodGet, "/api/saved-queries", "")
+	var list struct {
+		SavedQueries []model.SavedQuery `json:"saved_queries"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &list)
+	if w.Code != http.StatusOK || len(list.SavedQueries) != 1 || list.SavedQueries[0].Name != "shop errors" {
+		t.Fatalf("list = %d %s", w.Code, w.Body.String())
+	}
+
+	if w := do(http.MethodDelete, id, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("delete = %d %s", w.Code, w.Body.String())
+	}
+	if w := do(http.MethodGet, id, ""); w.Code != http.StatusNotFound {
+		t.Errorf("get after delete = %d, want 404", w.Code)
+	}
+}
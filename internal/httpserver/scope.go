@@ -0,0 +1,62 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// queryOpts reads the app and time-range query parameters shared by the
+// read endpoints. from and to take RFC 3339 times or a duration before now,
+// so ?from=15m covers the last 15 minutes. Under a key limited to apps,
+// app must name one of them; it defaults to the key's only app.
+func queryOpts(c *gin.Context) (model.QueryOpts, error) {
+	var opts model.QueryOpts
+	now := time.Now()
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &opts.From}, {"to", &opts.To}} {
+		value := c.Query(p.name)
+		if value == "" {
+			continue
+		}
+		t, err := parseTimeParam(value, now)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s: %q (want RFC 3339 or a duration such as 15m)", p.name, value)
+		}
+		*p.dst = t
+	}
+	if !opts.From.IsZero() && !opts.To.IsZero() && !opts.From.Before(opts.To) {
+		return opts, fmt.Errorf("invalid range: from must be before to")
+	}
+	app, err := RestrictApp(c.Query("app"), grantedApps(c))
+	opts.App = app
+	return opts, err
+}
+
+// optsInvalid answers a queryOpts error: 403 for an app the key may not
+// read, 400 otherwise.
+func optsInvalid(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, ErrAppDenied) {
+		status = http.StatusForbidden
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
+
+// parseTimeParam parses an RFC 3339 time or a positive duration before now.
+func parseTimeParam(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid time %q", value)
+	}
+	return now.Add(-d), nil
+}
@@ -2,13 +2,17 @@ package httpserver
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"time"
 
+	"github.com/tinytelemetry/tiny-telemetry/internal/health"
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/version"
 	"github.com/gin-gonic/gin"
 )
 
@@ -17,6 +21,56 @@ type QueryStore interface {
 	model.ReadAPI
 }
 
+// RowQuerier is implemented by stores that run ad-hoc queries under a
+// row cap and deadline set per request, reporting whether rows were cut
+// (the DuckDB backend).
+type RowQuerier interface {
+	QueryRows(ctx context.Context, query string, maxRows int, args ...any) ([]map[string]interface{}, bool, error)
+}
+
+// VersionReporter exposes the result of the background version check.
+type VersionReporter interface {
+	Status() version.Status
+}
+
+// RetentionReporter exposes what retention has deleted.
+type RetentionReporter interface {
+	RetentionStats() model.RetentionStats
+}
+
+// MaintenanceReporter exposes what periodic compaction has reclaimed.
+type MaintenanceReporter interface {
+	MaintenanceStats() model.MaintenanceStats
+}
+
+// TotalsRecounter is implemented by stores that answer TotalLogCount from
+// running counters (the DuckDB backend) and can recount them exactly.
+type TotalsRecounter interface {
+	RecountTotals() error
+}
+
+// DedupReporter exposes how many duplicate records dedup has skipped.
+type DedupReporter interface {
+	DuplicatesDropped() int64
+}
+
+// SamplingReporter exposes how many records sampling has kept out of
+// storage, by app and level.
+type SamplingReporter interface {
+	Dropped() map[string]map[string]int64
+}
+
+// ThresholdReporter exposes how many records fell below their app's
+// minimum stored severity, by app and level.
+type ThresholdReporter interface {
+	Suppressed() map[string]map[string]int64
+}
+
+// QueryStatsReporter exposes the load on the store's query scheduler.
+type QueryStatsReporter interface {
+	QueryStats() model.QueryStats
+}
+
 // Server provides an HTTP API for querying Tiny Telemetry analytics.
 type Server struct {
 	addr      string
@@ -26,6 +80,30 @@ type Server struct {
 	ctx       context.Context
 	cancel    context.CancelFunc
 	startTime time.Time
+
+	maxScanRows   int64         // 0 = no cost guardrail
+	queryMaxRows  int           // /api/query row cap; 0 = defaultQueryMaxRows
+	queryTimeout  time.Duration // longest /api/query timeout a request may ask for; 0 = the store's
+	exportMaxRows int64         // 0 = unbounded exports
+	exportTimeout time.Duration // 0 = unbounded exports
+	tailPoll      time.Duration // /api/stream tail poll interval; 0 = 1s
+	versions      VersionReporter
+	retention     RetentionReporter
+	maintenance   MaintenanceReporter
+	dedup         DedupReporter
+	sampling      SamplingReporter
+	thresholds    ThresholdReporter
+	queries       QueryStatsReporter
+	patterns      PatternSource
+
+	health *health.Registry // see AddReadinessCheck
+
+	accessLog AccessLogLevel // "" or off = requests not logged
+
+	keyring *Keyring     // nil = no API key required
+	limiter *rateLimiter // nil = no rate limit
+	tls     *tls.Config  // nil = plain HTTP
+	api     []apiRoute   // registered routes, for /api/openapi.json
 }
 
 // NewServer creates a new HTTP API server.
@@ -34,11 +112,16 @@ func NewServer(addr string, store QueryStore) *Server {
 		addr = "0.0.0.0:5000"
 	}
 	ctx, cancel := context.WithCancel(context.Background())
+	checks := health.NewRegistry()
+	if pinger, ok := store.(storePinger); ok {
+		checks.Add("store", true, pinger.Ping)
+	}
 	return &Server{
 		addr:   addr,
 		store:  store,
 		ctx:    ctx,
 		cancel: cancel,
+		health: checks,
 	}
 }
 
@@ -46,11 +129,12 @@ func NewServer(addr string, store QueryStore) *Server {
 func (s *Server) Start() error {
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
+	if s.accessLog != "" && s.accessLog != AccessLogOff {
+		r.Use(s.logAccess)
+	}
 	r.Use(gin.Recovery())
 
-	r.GET("/api/health", s.handleHealth)
-	r.GET("/api/schema", s.handleSchema)
-	r.POST("/api/query", s.handleQuery)
+	s.registerRoutes(r)
 
 	s.server = &http.Server{
 		Handler:           r,
@@ -60,18 +144,373 @@ func (s *Server) Start() error {
 		WriteTimeout:      60 * time.Second,
 	}
 
-	listener, err := net.Listen("tcp", s.addr)
-	if err != nil {
-		return err
+	if s.listener == nil {
+		listener, err := net.Listen("tcp", s.addr)
+		if err != nil {
+			return err
+		}
+		s.listener = listener
 	}
-	s.listener = listener
 
 	s.startTime = time.Now()
 
-	go s.server.Serve(listener)
+	if s.tls != nil {
+		s.server.TLSConfig = s.tls
+		go s.server.ServeTLS(s.listener, "", "")
+		return nil
+	}
+	go s.server.Serve(s.listener)
 	return nil
 }
 
+// registerRoutes adds the API endpoints to r. Each is described for
+// /api/openapi.json and /api/docs as it is registered.
+func (s *Server) registerRoutes(r gin.IRoutes) {
+	limit := func(def, max int) apiParam {
+		return apiParam{Name: "limit", Type: "integer", Description: fmt.Sprintf("maximum results, 1-%d (default %d)", max, def)}
+	}
+	level := apiParam{Name: "level", Type: "string", Description: "comma-separated severities, e.g. error,warn"}
+
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/health",
+		Summary: "Server status, uptime, and the stored log count.",
+		Scope:   ScopeRead,
+		Params:  scoped(apiParam{Name: "exact", Type: "boolean", Description: "recount the running totals first"}),
+		Response: struct {
+			Status            string                      `json:"status"`
+			Uptime            string                      `json:"uptime"`
+			LogCount          int64                       `json:"log_count"`
+			Retention         *model.RetentionStats       `json:"retention,omitempty"`
+			Maintenance       *model.MaintenanceStats     `json:"maintenance,omitempty"`
+			DuplicatesDropped int64                       `json:"duplicates_dropped,omitempty"`
+			SampledOut        map[string]map[string]int64 `json:"sampled_out,omitempty"`
+			BelowMinSeverity  map[string]map[string]int64 `json:"below_min_severity,omitempty"`
+			Queries           *model.QueryStats           `json:"queries,omitempty"`
+		}{},
+	}, s.handleHealth)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/health/live",
+		Summary: "Liveness: 200 while the server is serving requests.",
+		Response: struct {
+			Status string `json:"status"`
+			Uptime string `json:"uptime"`
+		}{},
+	}, s.handleLive)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/health/ready",
+		Summary: "Readiness: the status of each component; 503 when a required one is failing.",
+		Response: struct {
+			Status     string                     `json:"status"`
+			Components map[string]componentStatus `json:"components"`
+		}{},
+	}, s.handleReady)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/schema",
+		Summary: "The tables and columns available to /api/query, with row counts.",
+		Scope:   ScopeRead,
+		AllApps: true,
+		Response: struct {
+			Description string                         `json:"description"`
+			Tables      map[string][]map[string]string `json:"tables"`
+			RowCounts   map[string]int64               `json:"row_counts"`
+		}{},
+	}, s.handleSchema)
+	s.handle(r, apiRoute{
+		Method:     http.MethodPost,
+		Path:       "/api/query",
+		Summary:    "Run a read-only SELECT or WITH query, binding params to its ? placeholders. With Accept: application/x-ndjson the rows stream as they are read.",
+		Scope:      ScopeQuery,
+		AllApps:    true,
+		Limited:    true,
+		Compressed: true,
+		Params:     []apiParam{{Name: "force", Type: "boolean", Description: "skip the scanned-rows estimate check"}},
+		Body: struct {
+			SQL     string `json:"sql"`
+			Params  []any  `json:"params,omitempty"`
+			Force   bool   `json:"force,omitempty"`
+			MaxRows int    `json:"max_rows,omitempty"`
+			Timeout string `json:"timeout,omitempty"`
+		}{},
+		Response: struct {
+			Columns   []string                 `json:"columns"`
+			Rows      []map[string]interface{} `json:"rows"`
+			RowCount  int                      `json:"row_count"`
+			Truncated bool                     `json:"truncated"`
+			MaxRows   int                      `json:"max_rows"`
+		}{},
+		Produces: []string{ndjsonMIME},
+	}, s.handleQuery)
+	savedID := apiParam{Name: "id", Type: "integer", Description: "saved query id", Required: true, In: "path"}
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/saved-queries",
+		Summary: "The saved queries, by name.",
+		Scope:   ScopeRead,
+		AllApps: true,
+		Response: struct {
+			SavedQueries []model.SavedQuery `json:"saved_queries"`
+		}{},
+	}, s.handleListSavedQueries)
+	s.handle(r, apiRoute{
+		Method:   http.MethodPost,
+		Path:     "/api/saved-queries",
+		Summary:  "Save a named read-only query; names are unique. Answers 201.",
+		Scope:    ScopeQuery,
+		AllApps:  true,
+		Body:     savedQueryBody{},
+		Response: model.SavedQuery{},
+	}, s.handleSaveQuery)
+	s.handle(r, apiRoute{
+		Method:   http.MethodGet,
+		Path:     "/api/saved-queries/:id",
+		Summary:  "One saved query.",
+		Scope:    ScopeRead,
+		AllApps:  true,
+		Params:   []apiParam{savedID},
+		Response: model.SavedQuery{},
+	}, s.handleGetSavedQuery)
+	s.handle(r, apiRoute{
+		Method:   http.MethodPut,
+		Path:     "/api/saved-queries/:id",
+		Summary:  "Replace the name, description, and SQL of a saved query.",
+		Scope:    ScopeQuery,
+		AllApps:  true,
+		Params:   []apiParam{savedID},
+		Body:     savedQueryBody{},
+		Response: model.SavedQuery{},
+	}, s.handleSaveQuery)
+	s.handle(r, apiRoute{
+		Method:  http.MethodDelete,
+		Path:    "/api/saved-queries/:id",
+		Summary: "Delete a saved query. Answers 204.",
+		Scope:   ScopeQuery,
+		AllApps: true,
+		Params:  []apiParam{savedID},
+	}, s.handleDeleteSavedQuery)
+	s.handle(r, apiRoute{
+		Method:     http.MethodPost,
+		Path:       "/api/saved-queries/:id/run",
+		Summary:    "Run a saved query as /api/query does. The optional body takes its params, force, max_rows, and timeout.",
+		Scope:      ScopeQuery,
+		AllApps:    true,
+		Limited:    true,
+		Compressed: true,
+		Params:     []apiParam{savedID},
+		Produces:   []string{ndjsonMIME},
+	}, s.handleRunSavedQuery)
+	s.handle(r, apiRoute{
+		Method:     http.MethodGet,
+		Path:       "/api/export",
+		Summary:    "Download the matching logs, oldest first. X-Row-Count carries the row count; X-Export-Truncated is set when a limit cut the export.",
+		Scope:      ScopeRead,
+		Limited:    true,
+		Compressed: true,
+		Params:     scoped(apiParam{Name: "format", Type: "string", Description: "parquet (default), csv, or ndjson"}),
+		Produces:   []string{"application/vnd.apache.parquet", "text/csv", "application/x-ndjson"},
+	}, s.handleExport)
+	s.handle(r, apiRoute{
+		Method:     http.MethodGet,
+		Path:       "/api/logs",
+		Summary:    "A page of matching logs, newest first.",
+		Scope:      ScopeRead,
+		Compressed: true,
+		Params: scoped(level,
+			apiParam{Name: "attr", Type: "string", Description: "key=value attribute match", Repeated: true},
+			apiParam{Name: "pattern", Type: "string", Description: "message regular expression"},
+			limit(100, model.MaxLogPage),
+			apiParam{Name: "cursor", Type: "string", Description: "next_cursor of the previous page"}),
+		Response: struct {
+			Logs       []apiLog `json:"logs"`
+			NextCursor string   `json:"next_cursor"`
+		}{},
+	}, s.handleLogs)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/stats/severity",
+		Summary: "Log counts per severity, or per minute with by=minute.",
+		Scope:   ScopeRead,
+		Params:  scoped(apiParam{Name: "by", Type: "string", Description: "minute for per-minute counts"}),
+		Response: struct {
+			Counts  map[string]int64 `json:"counts,omitempty"`
+			Minutes []apiMinute      `json:"minutes,omitempty"`
+		}{},
+	}, s.handleStatsSeverity)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/stats/services",
+		Summary: "The services with the most logs.",
+		Scope:   ScopeRead,
+		Params:  scoped(apiParam{Name: "level", Type: "string", Description: "count only this severity"}, limit(10, maxStatsLimit)),
+		Response: struct {
+			Services []apiCount `json:"services"`
+		}{},
+	}, s.handleStatsServices)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/stats/hosts",
+		Summary: "The hosts with the most logs.",
+		Scope:   ScopeRead,
+		Params:  scoped(limit(10, maxStatsLimit)),
+		Response: struct {
+			Hosts []apiCount `json:"hosts"`
+		}{},
+	}, s.handleStatsHosts)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/stats/attributes",
+		Summary: "The most used attribute keys, or with key set, that key's most frequent values.",
+		Scope:   ScopeRead,
+		Params:  scoped(apiParam{Name: "key", Type: "string", Description: "attribute key to list values of"}, limit(10, maxStatsLimit)),
+		Response: struct {
+			Attributes []apiAttributeKey `json:"attributes,omitempty"`
+			Key        string            `json:"key,omitempty"`
+			Values     map[string]int64  `json:"values,omitempty"`
+		}{},
+	}, s.handleStatsAttributes)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/rate",
+		Summary: "Log counts per step-wide bucket for each value of a dimension.",
+		Scope:   ScopeRead,
+		Params: scoped(
+			apiParam{Name: "dimension", Type: "string", Description: "service (default), host, app, or level"},
+			apiParam{Name: "window", Type: "string", Description: "how far back to count (default 1h)"},
+			apiParam{Name: "step", Type: "string", Description: "bucket width (default 1m)"},
+			level),
+		Response: struct {
+			Dimension string                `json:"dimension"`
+			Window    string                `json:"window"`
+			Step      string                `json:"step"`
+			Rates     []model.DimensionRate `json:"rates"`
+		}{},
+	}, s.handleRate)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/attribute-values",
+		Summary: "Values of an attribute key starting with prefix, most frequent first.",
+		Scope:   ScopeRead,
+		Params: append([]apiParam{
+			{Name: "key", Type: "string", Description: "attribute key", Required: true},
+			{Name: "prefix", Type: "string", Description: "value prefix"},
+			limit(10, maxAutocompleteLimit),
+		}, scopeParams...),
+		Response: struct {
+			Key    string     `json:"key"`
+			Values []apiCount `json:"values"`
+		}{},
+	}, s.handleAttributeValues)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/apps",
+		Summary: "The apps with logs, most logs first, with when each last logged.",
+		Scope:   ScopeRead,
+		Params:  scoped(limit(100, maxStatsLimit)),
+		Response: struct {
+			Apps []model.CatalogEntry `json:"apps"`
+		}{},
+	}, s.handleCatalog("app", "apps"))
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/services",
+		Summary: "The services with logs, most logs first, with when each last logged.",
+		Scope:   ScopeRead,
+		Params:  scoped(limit(100, maxStatsLimit)),
+		Response: struct {
+			Services []model.CatalogEntry `json:"services"`
+		}{},
+	}, s.handleCatalog("service", "services"))
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/hosts",
+		Summary: "The hosts with logs, most logs first, with when each last logged.",
+		Scope:   ScopeRead,
+		Params:  scoped(limit(100, maxStatsLimit)),
+		Response: struct {
+			Hosts []model.CatalogEntry `json:"hosts"`
+		}{},
+	}, s.handleCatalog("host", "hosts"))
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/patterns",
+		Summary: "Message templates mined from the ingested logs, most frequent first, with when each was first and last seen.",
+		Scope:   ScopeRead,
+		AllApps: true,
+		Params: []apiParam{
+			{Name: "level", Type: "string", Description: "only this severity, e.g. ERROR"},
+			{Name: "service", Type: "string", Description: "only this service"},
+			limit(50, maxStatsLimit),
+		},
+		Response: struct {
+			Patterns []model.LogPattern `json:"patterns"`
+		}{},
+	}, s.handlePatterns)
+	dryRun := apiParam{Name: "dry_run", Type: "boolean", Description: "report what would happen without doing it"}
+	s.handle(r, apiRoute{
+		Method:  http.MethodPost,
+		Path:    "/api/admin/purge",
+		Summary: "Delete the logs of an app, a time range, or both. Archived and rotated files keep theirs.",
+		Scope:   ScopeAdmin,
+		Params:  scoped(dryRun),
+		Response: struct {
+			Deleted int64 `json:"deleted"`
+			DryRun  bool  `json:"dry_run"`
+		}{},
+	}, s.handlePurge)
+	s.handle(r, apiRoute{
+		Method:  http.MethodPost,
+		Path:    "/api/admin/retention",
+		Summary: "Apply the retention policies now instead of at the next hourly run.",
+		Scope:   ScopeAdmin,
+		AllApps: true,
+		Params:  []apiParam{dryRun},
+		Response: struct {
+			Retention model.RetentionStats `json:"retention"`
+			DryRun    bool                 `json:"dry_run"`
+		}{},
+	}, s.handleRunRetention)
+	s.handle(r, apiRoute{
+		Method:  http.MethodPost,
+		Path:    "/api/admin/compact",
+		Summary: "Checkpoint the database and return the space freed to the file system.",
+		Scope:   ScopeAdmin,
+		AllApps: true,
+		Params:  []apiParam{dryRun},
+		Response: struct {
+			Reclaimed int64 `json:"reclaimed_bytes"`
+			Storage   int64 `json:"storage_bytes"`
+			DryRun    bool  `json:"dry_run"`
+		}{},
+	}, s.handleCompact)
+	s.handle(r, apiRoute{
+		Method:   http.MethodGet,
+		Path:     "/api/version",
+		Summary:  "The result of the latest version check.",
+		Scope:    ScopeRead,
+		Response: version.Status{},
+	}, s.handleVersion)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/stream",
+		Summary: "WebSocket for live tails and incremental query results.",
+		Scope:   ScopeRead,
+	}, s.handleStream)
+	s.handle(r, apiRoute{
+		Method:  http.MethodGet,
+		Path:    "/api/openapi.json",
+		Summary: "This OpenAPI description.",
+	}, s.handleOpenAPI)
+	s.handle(r, apiRoute{
+		Method:   http.MethodGet,
+		Path:     "/api/docs",
+		Summary:  "Interactive API documentation.",
+		Produces: []string{"text/html"},
+	}, s.handleDocs)
+}
+
 // Stop gracefully shuts down the HTTP server.
 func (s *Server) Stop() error {
 	s.cancel()
@@ -80,6 +519,75 @@ func (s *Server) Stop() error {
 	return s.server.Shutdown(ctx)
 }
 
+// defaultQueryMaxRows caps /api/query results when SetQueryLimits is not
+// called.
+const defaultQueryMaxRows = 1000
+
+// SetQueryLimits caps /api/query results at maxRows rows, and the timeout
+// a request may ask for at timeout. Requests can lower either. Zero keeps
+// the defaults: 1000 rows, and the store's query timeout.
+func (s *Server) SetQueryLimits(maxRows int, timeout time.Duration) {
+	s.queryMaxRows = max(maxRows, 0)
+	s.queryTimeout = max(timeout, 0)
+}
+
+// SetMaxScanRows rejects ad-hoc queries that the planner estimates will scan
+// more than n rows unless the request sets force=true. Zero disables the check.
+func (s *Server) SetMaxScanRows(n int64) {
+	if n < 0 {
+		n = 0
+	}
+	s.maxScanRows = n
+}
+
+// SetVersionReporter exposes version check results on /api/version.
+func (s *Server) SetVersionReporter(r VersionReporter) {
+	s.versions = r
+}
+
+// SetRetentionReporter adds retention counters to /api/health.
+func (s *Server) SetRetentionReporter(r RetentionReporter) {
+	s.retention = r
+}
+
+// SetMaintenanceReporter adds compaction counters to /api/health.
+func (s *Server) SetMaintenanceReporter(r MaintenanceReporter) {
+	s.maintenance = r
+}
+
+// SetDedupReporter adds the dedup counter to /api/health.
+func (s *Server) SetDedupReporter(r DedupReporter) {
+	s.dedup = r
+}
+
+// SetSamplingReporter adds the sampled-out counts to /api/health.
+func (s *Server) SetSamplingReporter(r SamplingReporter) {
+	s.sampling = r
+}
+
+// SetThresholdReporter adds the below-minimum-severity counts to /api/health.
+func (s *Server) SetThresholdReporter(r ThresholdReporter) {
+	s.thresholds = r
+}
+
+// SetQueryStatsReporter adds the query scheduler's load to /api/health.
+func (s *Server) SetQueryStatsReporter(r QueryStatsReporter) {
+	s.queries = r
+}
+
+// SetTLSConfig serves HTTPS with c, which must carry the certificate; set
+// ClientCAs and ClientAuth on it to require client certificates. Call
+// before Start.
+func (s *Server) SetTLSConfig(c *tls.Config) {
+	s.tls = c
+}
+
+// SetListener serves on a pre-opened listener (e.g. one passed in by
+// systemd) instead of listening on the configured address. Call before Start.
+func (s *Server) SetListener(ln net.Listener) {
+	s.listener = ln
+}
+
 // Addr returns the active listen address.
 // Before Start, it returns the configured address.
 func (s *Server) Addr() string {
@@ -90,17 +598,54 @@ func (s *Server) Addr() string {
 }
 
 func (s *Server) handleHealth(c *gin.Context) {
-	logCount, err := s.store.TotalLogCount(model.QueryOpts{})
+	opts, err := queryOpts(c)
+	if err != nil {
+		optsInvalid(c, err)
+		return
+	}
+	// ?exact=true recounts the running totals from the stored logs first.
+	if recounter, ok := s.store.(TotalsRecounter); ok && c.Query("exact") == "true" {
+		if err := recounter.RecountTotals(); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to recount logs"})
+			return
+		}
+	}
+	logCount, err := s.store.TotalLogCount(opts)
 	if err != nil {
+		if queryUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read health metrics"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	health := gin.H{
 		"status":    "ok",
 		"uptime":    time.Since(s.startTime).String(),
 		"log_count": logCount,
-	})
+	}
+	// Server-wide figures are left out for keys limited to apps.
+	if grantedApps(c) == nil {
+		if s.retention != nil {
+			health["retention"] = s.retention.RetentionStats()
+		}
+		if s.maintenance != nil {
+			health["maintenance"] = s.maintenance.MaintenanceStats()
+		}
+		if s.dedup != nil {
+			health["duplicates_dropped"] = s.dedup.DuplicatesDropped()
+		}
+		if s.sampling != nil {
+			health["sampled_out"] = s.sampling.Dropped()
+		}
+		if s.thresholds != nil {
+			health["below_min_severity"] = s.thresholds.Suppressed()
+		}
+		if s.queries != nil {
+			health["queries"] = s.queries.QueryStats()
+		}
+	}
+	c.JSON(http.StatusOK, health)
 }
 
 func (s *Server) handleSchema(c *gin.Context) {
@@ -110,6 +655,9 @@ func (s *Server) handleSchema(c *gin.Context) {
 		"SELECT table_name, column_name, data_type FROM information_schema.columns WHERE table_schema = 'main' ORDER BY table_name, ordinal_position",
 	)
 	if err != nil {
+		if queryUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read schema metadata"})
 		return
 	}
@@ -125,6 +673,9 @@ func (s *Server) handleSchema(c *gin.Context) {
 
 	counts, err := s.store.TableRowCounts()
 	if err != nil {
+		if queryUnavailable(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read table row counts"})
 		return
 	}
@@ -136,19 +687,96 @@ func (s *Server) handleSchema(c *gin.Context) {
 	})
 }
 
+// queryRequest is the body of /api/query, and of a saved query's run
+// without its SQL.
+type queryRequest struct {
+	SQL     string            `json:"sql"`
+	Params  []json.RawMessage `json:"params"`
+	Force   bool              `json:"force"`
+	MaxRows int               `json:"max_rows"`
+	Timeout string            `json:"timeout"`
+}
+
 func (s *Server) handleQuery(c *gin.Context) {
-	var req struct {
-		SQL string `json:"sql" binding:"required"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
+	var req queryRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.SQL == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON body or missing sql field"})
 		return
 	}
+	s.runQuery(c, req)
+}
 
-	results, err := s.store.ExecuteQuery(req.SQL)
+// runQuery runs req under the server's guardrails and row and time limits
+// and answers with its results.
+func (s *Server) runQuery(c *gin.Context, req queryRequest) {
+	args, err := model.QueryArgs(req.Params)
 	if err != nil {
-		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "query overloaded or timed out; retry"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	force := req.Force || c.Query("force") == "true"
+
+	maxRows := s.queryMaxRows
+	if maxRows == 0 {
+		maxRows = defaultQueryMaxRows
+	}
+	if req.MaxRows < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_rows must not be negative"})
+		return
+	}
+	if req.MaxRows > 0 {
+		maxRows = min(req.MaxRows, maxRows)
+	}
+	ctx := c.Request.Context()
+	if req.Timeout != "" {
+		timeout, err := time.ParseDuration(req.Timeout)
+		if err != nil || timeout <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid timeout %q (want a duration such as 5s)", req.Timeout)})
+			return
+		}
+		if s.queryTimeout > 0 {
+			timeout = min(timeout, s.queryTimeout)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if s.maxScanRows > 0 && !force {
+		estimated, err := s.store.EstimateQueryScanRows(req.SQL, args...)
+		if err != nil {
+			if queryUnavailable(c, err) {
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if estimated > s.maxScanRows {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":          fmt.Sprintf("query is estimated to scan %d rows (limit %d); narrow it or resend with force=true", estimated, s.maxScanRows),
+				"estimated_rows": estimated,
+				"max_scan_rows":  s.maxScanRows,
+			})
+			return
+		}
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, ndjsonMIME) == ndjsonMIME {
+		s.streamQuery(ctx, c, req.SQL, args, maxRows)
+		return
+	}
+
+	var (
+		results   []map[string]interface{}
+		truncated bool
+	)
+	if rq, ok := s.store.(RowQuerier); ok {
+		results, truncated, err = rq.QueryRows(ctx, req.SQL, maxRows, args...)
+	} else if results, err = s.store.ExecuteQuery(req.SQL, args...); len(results) > maxRows {
+		results, truncated = results[:maxRows], true
+	}
+	if err != nil {
+		if queryUnavailable(c, err) {
 			return
 		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -166,5 +794,87 @@ func (s *Server) handleQuery(c *gin.Context) {
 		"columns":   columns,
 		"rows":      results,
 		"row_count": len(results),
+		"truncated": truncated,
+		"max_rows":  maxRows,
 	})
 }
+
+// ndjsonMIME is the content type of newline-delimited JSON.
+const ndjsonMIME = "application/x-ndjson"
+
+// errQueryRowLimit stops a streamed query that reached its row cap.
+var errQueryRowLimit = errors.New("query row limit reached")
+
+// streamQuery writes the results of an ad-hoc query as NDJSON while the
+// store reads them, so a large result is never held whole: a {"columns"}
+// line, one JSON array per row in column order, then a {"row_count",
+// "truncated", "max_rows"} line. The status is sent with the first line, so
+// a query failing later ends with an {"error"} line instead.
+func (s *Server) streamQuery(ctx context.Context, c *gin.Context, sql string, args []any, maxRows int) {
+	streamer, ok := s.store.(QueryStreamer)
+	if !ok {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "streamed query results require the duckdb storage backend"})
+		return
+	}
+	enc := json.NewEncoder(c.Writer)
+	written, started := 0, false
+	err := streamer.StreamQuery(ctx, sql, 0, func(columns []string, rows [][]interface{}) error {
+		if !started {
+			started = true
+			c.Header("Content-Type", ndjsonMIME)
+			c.Status(http.StatusOK)
+			if err := enc.Encode(gin.H{"columns": columns}); err != nil {
+				return err
+			}
+		}
+		for _, row := range rows {
+			if written == maxRows {
+				return errQueryRowLimit
+			}
+			if err := enc.Encode(row); err != nil {
+				return err
+			}
+			written++
+		}
+		c.Writer.Flush()
+		return nil
+	}, args...)
+
+	truncated := errors.Is(err, errQueryRowLimit)
+	switch {
+	case truncated:
+	case err != nil && !started:
+		if queryUnavailable(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		enc.Encode(gin.H{"error": streamError(err)})
+		return
+	}
+	enc.Encode(gin.H{"row_count": written, "truncated": truncated, "max_rows": maxRows})
+}
+
+// queryUnavailable answers 503 when the store shed the query, with
+// Retry-After, or it timed out, and reports whether it did.
+func queryUnavailable(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, model.ErrOverloaded):
+		c.Header("Retry-After", "1")
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "query overloaded; retry"})
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "query timed out; retry"})
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleVersion(c *gin.Context) {
+	status := version.Status{State: version.StateDisabled}
+	if s.versions != nil {
+		status = s.versions.Status()
+	}
+	c.JSON(http.StatusOK, status)
+}
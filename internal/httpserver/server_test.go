@@ -2,13 +2,19 @@ package httpserver
 
 import (
 	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/version"
 	"github.com/gin-gonic/gin"
 )
 
@@ -29,9 +35,7 @@ func newTestServer(t *testing.T) (*Server, *duckdb.Store, *gin.Engine) {
 
 	r := gin.New()
 	r.Use(gin.Recovery())
-	r.GET("/api/health", srv.handleHealth)
-	r.GET("/api/schema", srv.handleSchema)
-	r.POST("/api/query", srv.handleQuery)
+	srv.registerRoutes(r)
 
 	return srv, store, r
 }
@@ -56,6 +60,212 @@ func TestHealthEndpoint(t *testing.T) {
 	}
 }
 
+func TestHealthEndpoint_TimeRange(t *testing.T) {
+	_, store, r := newTestServer(t)
+
+	now := time.Now()
+	err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now.Add(-time.Hour), Level: "INFO", Message: "old"},
+		{Timestamp: now.Add(-time.Minute), Level: "INFO", Message: "recent"},
+	})
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	count := func(query string) (int, float64) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/health"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var body map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("unmarshal health: %v", err)
+		}
+		n, _ := body["log_count"].(float64)
+		return w.Code, n
+	}
+
+	if code, n := count(""); code != http.StatusOK || n != 2 {
+		t.Fatalf("all-time = %d/%v, want 200/2", code, n)
+	}
+	if code, n := count("?from=15m"); code != http.StatusOK || n != 1 {
+		t.Fatalf("from=15m = %d/%v, want 200/1", code, n)
+	}
+	to := now.Add(-30 * time.Minute).UTC().Format(time.RFC3339)
+	if code, n := count("?to=" + to); code != http.StatusOK || n != 1 {
+		t.Fatalf("to=%s = %d/%v, want 200/1", to, code, n)
+	}
+	for _, q := range []string{"?from=yesterday", "?from=-5m", "?from=5m&to=10m"} {
+		if code, _ := count(q); code != http.StatusBadRequest {
+			t.Errorf("%s status = %d, want 400", q, code)
+		}
+	}
+}
+
+func TestHealthEndpoint_ExactRecount(t *testing.T) {
+	_, store, r := newTestServer(t)
+
+	// A row written around InsertLogBatch is missing from the running
+	// totals until an exact recount.
+	if _, err := store.DB().Exec(`INSERT INTO logs (timestamp, level, message) VALUES (now(), 'INFO', 'side')`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	logCount := func(query string) int64 {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/health"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var body struct {
+			LogCount int64 `json:"log_count"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("unmarshal health: %v", err)
+		}
+		return body.LogCount
+	}
+	if n := logCount(""); n != 0 {
+		t.Fatalf("log_count = %d before recount, want 0", n)
+	}
+	if n := logCount("?exact=true"); n != 1 {
+		t.Fatalf("log_count = %d with exact=true, want 1", n)
+	}
+}
+
+func TestHealthEndpoint_Retention(t *testing.T) {
+	srv, store, r := newTestServer(t)
+
+	now := time.Now()
+	err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now.Add(-2 * time.Minute), Level: "INFO", Message: "first"},
+		{Timestamp: now.Add(-time.Minute), Level: "INFO", Message: "second"},
+		{Timestamp: now, Level: "INFO", Message: "third"},
+	})
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	cleaner := duckdb.NewRetentionCleaner(store, duckdb.RetentionConfig{MaxRows: 1})
+	t.Cleanup(cleaner.Stop)
+	srv.SetRetentionReporter(cleaner)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var body struct {
+		LogCount  int64 `json:"log_count"`
+		Retention struct {
+			RowsEvicted int64 `json:"rows_evicted"`
+		} `json:"retention"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal health: %v", err)
+	}
+	if body.LogCount != 1 || body.Retention.RowsEvicted != 2 {
+		t.Errorf("health = %s, want log_count 1 and rows_evicted 2", w.Body.String())
+	}
+}
+
+// fakeMaintenance reports fixed maintenance stats.
+type fakeMaintenance struct{ stats duckdb.MaintenanceStats }
+
+func (f fakeMaintenance) MaintenanceStats() duckdb.MaintenanceStats { return f.stats }
+
+func TestHealthEndpoint_Maintenance(t *testing.T) {
+	srv, _, r := newTestServer(t)
+	srv.SetMaintenanceReporter(fakeMaintenance{duckdb.MaintenanceStats{Runs: 3, Reclaimed: 4096}})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var body struct {
+		Maintenance struct {
+			Runs      int64 `json:"runs"`
+			Reclaimed int64 `json:"reclaimed_bytes"`
+		} `json:"maintenance"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal health: %v", err)
+	}
+	if body.Maintenance.Runs != 3 || body.Maintenance.Reclaimed != 4096 {
+		t.Errorf("health = %s, want 3 runs and 4096 reclaimed bytes", w.Body.String())
+	}
+}
+
+func TestHealthEndpoint_Dedup(t *testing.T) {
+	srv, store, r := newTestServer(t)
+
+	store.EnableDedup(time.Hour)
+	now := time.Now()
+	for range 2 {
+		if err := store.InsertLogBatch([]*duckdb.LogRecord{{Timestamp: now, Level: "INFO", Message: "once", EventID: "e1"}}); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+	srv.SetDedupReporter(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var body struct {
+		LogCount          int64 `json:"log_count"`
+		DuplicatesDropped int64 `json:"duplicates_dropped"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal health: %v", err)
+	}
+	if body.LogCount != 1 || body.DuplicatesDropped != 1 {
+		t.Errorf("health = %s, want log_count 1 and duplicates_dropped 1", w.Body.String())
+	}
+}
+
+type sampledOut map[string]map[string]int64
+
+func (s sampledOut) Dropped() map[string]map[string]int64 { return s }
+
+func TestHealthEndpoint_Sampling(t *testing.T) {
+	srv, _, r := newTestServer(t)
+	srv.SetSamplingReporter(sampledOut{"payments": {"DEBUG": 90}})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var body struct {
+		SampledOut map[string]map[string]int64 `json:"sampled_out"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal health: %v", err)
+	}
+	if body.SampledOut["payments"]["DEBUG"] != 90 {
+		t.Errorf("health = %s, want sampled_out payments/DEBUG 90", w.Body.String())
+	}
+}
+
+type belowMinSeverity map[string]map[string]int64
+
+func (s belowMinSeverity) Suppressed() map[string]map[string]int64 { return s }
+
+func TestHealthEndpoint_MinSeverity(t *testing.T) {
+	srv, _, r := newTestServer(t)
+	srv.SetThresholdReporter(belowMinSeverity{"payments": {"DEBUG": 12}})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var body struct {
+		BelowMinSeverity map[string]map[string]int64 `json:"below_min_severity"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal health: %v", err)
+	}
+	if body.BelowMinSeverity["payments"]["DEBUG"] != 12 {
+		t.Errorf("health = %s, want below_min_severity payments/DEBUG 12", w.Body.String())
+	}
+}
+
 func TestHealthEndpoint_WrongMethod(t *testing.T) {
 	_, _, r := newTestServer(t)
 
@@ -90,6 +300,51 @@ func TestQueryEndpoint_ValidSelect(t *testing.T) {
 	}
 }
 
+// overloadedStore sheds every ad-hoc query.
+type overloadedStore struct{ *duckdb.Store }
+
+func (overloadedStore) ExecuteQuery(string, ...any) ([]map[string]interface{}, error) {
+	return nil, model.ErrOverloaded
+}
+
+func (overloadedStore) QueryRows(context.Context, string, int, ...any) ([]map[string]interface{}, bool, error) {
+	return nil, false, model.ErrOverloaded
+}
+
+func TestQueryEndpoint_Overloaded(t *testing.T) {
+	_, store, _ := newTestServer(t)
+	srv := NewServer("", overloadedStore{store})
+	srv.SetQueryStatsReporter(store)
+	r := gin.New()
+	r.POST("/api/query", srv.handleQuery)
+	r.GET("/api/health", srv.handleHealth)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/query", bytes.NewBufferString(`{"sql": "SELECT 1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") == "" {
+		t.Fatalf("query status = %d, Retry-After %q; want 503 with Retry-After", w.Code, w.Header().Get("Retry-After"))
+	}
+	if !strings.Contains(w.Body.String(), "overloaded") {
+		t.Errorf("query body = %s, want an overloaded error", w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var body struct {
+		Queries *model.QueryStats `json:"queries"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal health: %v", err)
+	}
+	if body.Queries == nil || body.Queries.Slots != 8 {
+		t.Errorf("health = %s, want the query scheduler's stats", w.Body.String())
+	}
+}
+
 func TestQueryEndpoint_ValidWith(t *testing.T) {
 	_, store, r := newTestServer(t)
 
@@ -111,6 +366,217 @@ func TestQueryEndpoint_ValidWith(t *testing.T) {
 	}
 }
 
+func TestQueryEndpoint_ScanGuardrail(t *testing.T) {
+	srv, store, r := newTestServer(t)
+	srv.SetMaxScanRows(2)
+
+	now := time.Now()
+	err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now, Level: "INFO", Message: "a"},
+		{Timestamp: now, Level: "INFO", Message: "b"},
+		{Timestamp: now, Level: "INFO", Message: "c"},
+	})
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	body := `{"sql": "SELECT message FROM logs"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/query", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("guarded query status = %d, want %d; body: %s", w.Code, http.StatusUnprocessableEntity, w.Body.String())
+	}
+	var rejected map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &rejected); err != nil {
+		t.Fatalf("unmarshal rejection: %v", err)
+	}
+	if rejected["estimated_rows"] != float64(3) {
+		t.Errorf("estimated_rows = %v, want 3", rejected["estimated_rows"])
+	}
+
+	body = `{"sql": "SELECT message FROM logs", "force": true}`
+	req = httptest.NewRequest(http.MethodPost, "/api/query", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("forced query status = %d, want %d; body: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+}
+
+func TestQueryEndpoint_Params(t *testing.T) {
+	srv, store, r := newTestServer(t)
+	srv.SetMaxScanRows(1000) // the estimate binds the params too
+
+	now := time.Now()
+	err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now, App: "o'brien", Level: "ERROR", Message: "a"},
+		{Timestamp: now, App: "o'brien", Level: "INFO", Message: "b"},
+		{Timestamp: now, App: "other", Level: "ERROR", Message: "c"},
+	})
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/query", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"sql": "SELECT message FROM logs WHERE app = ? AND level = ? LIMIT ?", "params": ["o'brien", "ERROR", 10]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("query status = %d; body: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Rows []map[string]interface{} `json:"rows"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(resp.Rows) != 1 || resp.Rows[0]["message"] != "a" {
+		t.Errorf("rows = %v, want the one ERROR log of o'brien", resp.Rows)
+	}
+
+	for _, body := range []string{
+		`{"sql": "SELECT 1 WHERE 1 = ?", "params": [{"x": 1}]}`,
+		`{"sql": "SELECT 1 WHERE 1 = ? AND 2 = ?", "params": [1]}`,
+	} {
+		if w := post(body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400; body: %s", body, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestQueryEndpoint_Limits(t *testing.T) {
+	srv, store, r := newTestServer(t)
+	srv.SetQueryLimits(3, time.Second)
+
+	now := time.Now()
+	var records []*duckdb.LogRecord
+	for i := 0; i < 5; i++ {
+		records = append(records, &duckdb.LogRecord{Timestamp: now, Level: "INFO", Message: fmt.Sprint(i)})
+	}
+	if err := store.InsertLogBatch(records); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	post := func(body string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodPost, "/api/query", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var resp map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return w.Code, resp
+	}
+
+	for _, tt := range []struct {
+		body      string
+		rows      float64
+		truncated bool
+	}{
+		{`{"sql": "SELECT message FROM logs"}`, 3, true},
+		{`{"sql": "SELECT message FROM logs", "max_rows": 2}`, 2, true},
+		{`{"sql": "SELECT message FROM logs", "max_rows": 100}`, 3, true}, // bounded by the server
+		{`{"sql": "SELECT message FROM logs LIMIT 3"}`, 3, false},
+		{`{"sql": "SELECT message FROM logs", "max_rows": 5, "timeout": "1h"}`, 3, true},
+	} {
+		code, resp := post(tt.body)
+		if code != http.StatusOK || resp["row_count"] != tt.rows || resp["truncated"] != tt.truncated {
+			t.Errorf("%s: %d row_count=%v truncated=%v; want %v rows, truncated %v", tt.body, code, resp["row_count"], resp["truncated"], tt.rows, tt.truncated)
+		}
+	}
+
+	for _, body := range []string{
+		`{"sql": "SELECT 1", "max_rows": -1}`,
+		`{"sql": "SELECT 1", "timeout": "soon"}`,
+		`{"sql": "SELECT 1", "timeout": "0s"}`,
+	} {
+		if code, _ := post(body); code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", body, code)
+		}
+	}
+
+	code, resp := post(`{"sql": "SELECT COUNT(*) FROM range(10000000000) a, range(1000) b", "timeout": "50ms", "force": true}`)
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("slow query with a 50ms timeout = %d %v, want 503", code, resp)
+	}
+}
+
+func TestQueryEndpoint_NDJSON(t *testing.T) {
+	srv, store, r := newTestServer(t)
+	srv.SetQueryLimits(3, time.Second)
+
+	now := time.Now()
+	var records []*duckdb.LogRecord
+	for i := 0; i < 5; i++ {
+		records = append(records, &duckdb.LogRecord{Timestamp: now, Level: "INFO", Message: fmt.Sprint(i)})
+	}
+	if err := store.InsertLogBatch(records); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	post := func(body string) (*httptest.ResponseRecorder, []string) {
+		req := httptest.NewRequest(http.MethodPost, "/api/query", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/x-ndjson")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w, strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	}
+
+	w, lines := post(`{"sql": "SELECT level, message FROM logs WHERE message = ?", "params": ["2"]}`)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("status = %d, Content-Type %q; body: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+	want := []string{`{"columns":["level","message"]}`, `["INFO","2"]`, `{"max_rows":3,"row_count":1,"truncated":false}`}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+
+	if _, lines := post(`{"sql": "SELECT message FROM logs"}`); len(lines) != 5 || lines[4] != `{"max_rows":3,"row_count":3,"truncated":true}` {
+		t.Errorf("capped lines = %q, want 3 rows and a truncated summary", lines)
+	}
+	if w, _ := post(`{"sql": "DELETE FROM logs"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("DELETE status = %d, want 400", w.Code)
+	}
+}
+
+func TestVersionEndpoint(t *testing.T) {
+	srv, _, r := newTestServer(t)
+
+	get := func() map[string]interface{} {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/version", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("version status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("unmarshal version: %v", err)
+		}
+		return body
+	}
+
+	if body := get(); body["state"] != version.StateDisabled {
+		t.Errorf("state without reporter = %v, want %s", body["state"], version.StateDisabled)
+	}
+
+	srv.SetVersionReporter(version.NewChecker("1.2.3", "abc", version.Config{Offline: true}))
+	if body := get(); body["state"] != version.StateOffline {
+		t.Errorf("state = %v, want %s", body["state"], version.StateOffline)
+	}
+}
+
 func TestSchemaEndpoint(t *testing.T) {
 	_, _, r := newTestServer(t)
 
@@ -206,6 +672,186 @@ func TestQueryEndpoint_EmptySQL(t *testing.T) {
 	}
 }
 
+func TestExportEndpoint_Parquet(t *testing.T) {
+	_, store, r := newTestServer(t)
+
+	now := time.Now()
+	err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now.Add(-time.Hour), Level: "INFO", Message: "old"},
+		{Timestamp: now.Add(-time.Minute), Level: "INFO", Message: "recent"},
+	})
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/export?format=parquet&from=15m", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("export status = %d; body: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("X-Row-Count"); got != "1" {
+		t.Errorf("X-Row-Count = %q, want 1", got)
+	}
+	if body := w.Body.Bytes(); len(body) < 8 || string(body[:4]) != "PAR1" {
+		t.Errorf("body is not a Parquet file (%d bytes)", len(body))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/export?format=xml", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("format=xml status = %d, want 400", w.Code)
+	}
+}
+
+func TestExportEndpoint_Stream(t *testing.T) {
+	srv, store, r := newTestServer(t)
+
+	now := time.Now()
+	var records []*duckdb.LogRecord
+	for i := range 5 {
+		records = append(records, &duckdb.LogRecord{Timestamp: now.Add(time.Duration(i-10) * time.Second), Level: "INFO", Message: fmt.Sprintf("m%d", i), Attributes: map[string]string{"k": "v"}})
+	}
+	if err := store.InsertLogBatch(records); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/export?format=csv", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("csv status = %d; body: %s", w.Code, w.Body.String())
+	}
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(rows) != 6 || rows[0][0] != "timestamp" || rows[1][4] != "m0" || rows[1][11] != `{"k":"v"}` {
+		t.Fatalf("csv rows = %v; want a header and m0..m4, oldest first", rows)
+	}
+	res := w.Result()
+	if got := res.Trailer.Get("X-Row-Count"); got != "5" {
+		t.Errorf("X-Row-Count trailer = %q, want 5", got)
+	}
+
+	// The row limit cuts the export and says so.
+	srv.SetExportLimits(3, 0)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/export?format=ndjson", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("ndjson status = %d; body: %s", w.Code, w.Body.String())
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("ndjson lines = %d, want 3", len(lines))
+	}
+	var first apiLog
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil || first.Message != "m0" {
+		t.Fatalf("first line = %s, %v; want m0", lines[0], err)
+	}
+	res = w.Result()
+	if got := res.Trailer.Get("X-Export-Truncated"); got != "rows" {
+		t.Errorf("X-Export-Truncated trailer = %q, want rows", got)
+	}
+
+	// Exactly at the limit is not truncated.
+	srv.SetExportLimits(5, 0)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/export?format=ndjson", nil))
+	if got := w.Result().Trailer.Get("X-Export-Truncated"); got != "" {
+		t.Errorf("X-Export-Truncated at the limit = %q, want none", got)
+	}
+}
+
+func TestRateEndpoint(t *testing.T) {
+	_, store, r := newTestServer(t)
+
+	now := time.Now()
+	err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now.Add(-3 * time.Hour), Level: "ERROR", Service: "api", Message: "outside the window"},
+		{Timestamp: now.Add(-10 * time.Minute), Level: "ERROR", Service: "api", Message: "a"},
+		{Timestamp: now.Add(-10 * time.Minute), Level: "ERROR", Service: "api", Message: "b"},
+		{Timestamp: now.Add(-5 * time.Minute), Level: "ERROR", Service: "db", Message: "c"},
+		{Timestamp: now.Add(-5 * time.Minute), Level: "INFO", Service: "db", Message: "d"},
+	})
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/rate?dimension=service&window=1h&step=1h&level=error", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("rate status = %d; body: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Rates []duckdb.DimensionRate `json:"rates"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal rate: %v", err)
+	}
+	counts := make(map[string]int64)
+	for _, rate := range body.Rates {
+		counts[rate.Value] += rate.Count
+	}
+	if counts["api"] != 2 || counts["db"] != 1 || len(counts) != 2 {
+		t.Errorf("error counts by service = %v, want api 2 and db 1", counts)
+	}
+
+	for _, q := range []string{"?dimension=pid", "?window=forever", "?window=1h&step=1s"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/rate"+q, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s status = %d, want 400", q, w.Code)
+		}
+	}
+}
+
+func TestAttributeValuesEndpoint(t *testing.T) {
+	_, store, r := newTestServer(t)
+
+	now := time.Now()
+	err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now, Level: "INFO", Message: "a", Attributes: map[string]string{"env": "prod"}},
+		{Timestamp: now, Level: "INFO", Message: "b", Attributes: map[string]string{"env": "prod"}},
+		{Timestamp: now, Level: "INFO", Message: "c", Attributes: map[string]string{"env": "preview"}},
+		{Timestamp: now, Level: "INFO", Message: "d", Attributes: map[string]string{"env": "dev"}},
+	})
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/attribute-values?key=env&prefix=PR", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d; body: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Values []struct {
+			Value string `json:"value"`
+			Count int64  `json:"count"`
+		} `json:"values"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(body.Values) != 2 || body.Values[0].Value != "prod" || body.Values[0].Count != 2 || body.Values[1].Value != "preview" {
+		t.Errorf("values = %s, want prod (2) then preview", w.Body.String())
+	}
+
+	for _, q := range []string{"?prefix=p", "?key=env&limit=0", "?key=env&limit=1000"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/attribute-values"+q, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s status = %d, want 400", q, w.Code)
+		}
+	}
+}
+
 func TestGinRecovery(t *testing.T) {
 	r := gin.New()
 	r.Use(gin.Recovery())
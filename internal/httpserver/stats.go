@@ -0,0 +1,175 @@
+package httpserver
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// maxStatsLimit caps the limit parameter of the /api/stats endpoints.
+const maxStatsLimit = 1000
+
+// statsParams reads the app/from/to scope and the limit (default 10) of a
+// /api/stats request, answering 400 itself when one is invalid.
+func statsParams(c *gin.Context) (model.QueryOpts, int, bool) {
+	opts, err := queryOpts(c)
+	if err != nil {
+		optsInvalid(c, err)
+		return opts, 0, false
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 || limit > maxStatsLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit: want 1-" + strconv.Itoa(maxStatsLimit)})
+		return opts, 0, false
+	}
+	return opts, limit, true
+}
+
+// statsFailed answers a failed aggregation query.
+func statsFailed(c *gin.Context, name string, err error) {
+	if queryUnavailable(c, err) {
+		return
+	}
+	log.Printf("httpserver: stats %s: %v", name, err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": name + " query failed"})
+}
+
+// apiCount is a value and how many logs have it.
+type apiCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// apiMinute is one minute of severity counts.
+type apiMinute struct {
+	Minute time.Time `json:"minute"`
+	Trace  int64     `json:"trace"`
+	Debug  int64     `json:"debug"`
+	Info   int64     `json:"info"`
+	Warn   int64     `json:"warn"`
+	Error  int64     `json:"error"`
+	Fatal  int64     `json:"fatal"`
+	Total  int64     `json:"total"`
+}
+
+// apiAttributeKey is an attribute key with its distinct value count.
+type apiAttributeKey struct {
+	Key          string `json:"key"`
+	UniqueValues int    `json:"unique_values"`
+	Count        int64  `json:"count"`
+}
+
+// dimensionCounts converts top-N results to their API form.
+func dimensionCounts(values []model.DimensionCount) []apiCount {
+	out := make([]apiCount, len(values))
+	for i, v := range values {
+		out[i] = apiCount{Value: v.Value, Count: v.Count}
+	}
+	return out
+}
+
+// handleStatsSeverity returns log counts per severity. With by=minute it
+// returns them per minute instead.
+func (s *Server) handleStatsSeverity(c *gin.Context) {
+	opts, _, ok := statsParams(c)
+	if !ok {
+		return
+	}
+	switch c.Query("by") {
+	case "":
+		counts, err := s.store.SeverityCounts(opts)
+		if err != nil {
+			statsFailed(c, "severity", err)
+			return
+		}
+		if counts == nil {
+			counts = map[string]int64{}
+		}
+		c.JSON(http.StatusOK, gin.H{"counts": counts})
+	case "minute":
+		minutes, err := s.store.SeverityCountsByMinute(opts)
+		if err != nil {
+			statsFailed(c, "severity", err)
+			return
+		}
+		out := make([]apiMinute, len(minutes))
+		for i, m := range minutes {
+			out[i] = apiMinute(m)
+		}
+		c.JSON(http.StatusOK, gin.H{"minutes": out})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid by: " + c.Query("by") + " (want minute)"})
+	}
+}
+
+// handleStatsServices returns the services with the most logs, counting
+// only one severity when level is set.
+func (s *Server) handleStatsServices(c *gin.Context) {
+	opts, limit, ok := statsParams(c)
+	if !ok {
+		return
+	}
+	var services []model.DimensionCount
+	var err error
+	if level := c.Query("level"); level != "" {
+		services, err = s.store.TopServicesBySeverity(strings.ToUpper(strings.TrimSpace(level)), limit, opts)
+	} else {
+		services, err = s.store.TopServices(limit, opts)
+	}
+	if err != nil {
+		statsFailed(c, "services", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"services": dimensionCounts(services)})
+}
+
+// handleStatsHosts returns the hosts with the most logs.
+func (s *Server) handleStatsHosts(c *gin.Context) {
+	opts, limit, ok := statsParams(c)
+	if !ok {
+		return
+	}
+	hosts, err := s.store.TopHosts(limit, opts)
+	if err != nil {
+		statsFailed(c, "hosts", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"hosts": dimensionCounts(hosts)})
+}
+
+// handleStatsAttributes returns the most used attribute keys with their
+// distinct value counts, or, with key set, that key's most frequent values.
+func (s *Server) handleStatsAttributes(c *gin.Context) {
+	opts, limit, ok := statsParams(c)
+	if !ok {
+		return
+	}
+	if key := c.Query("key"); key != "" {
+		values, err := s.store.AttributeKeyValues(key, limit, opts)
+		if err != nil {
+			statsFailed(c, "attributes", err)
+			return
+		}
+		if values == nil {
+			values = map[string]int64{}
+		}
+		c.JSON(http.StatusOK, gin.H{"key": key, "values": values})
+		return
+	}
+
+	keys, err := s.store.TopAttributeKeys(limit, opts)
+	if err != nil {
+		statsFailed(c, "attributes", err)
+		return
+	}
+	out := make([]apiAttributeKey, len(keys))
+	for i, k := range keys {
+		out[i] = apiAttributeKey{Key: k.Key, UniqueValues: k.UniqueValues, Count: k.TotalCount}
+	}
+	c.JSON(http.StatusOK, gin.H{"attributes": out})
+}
@@ -0,0 +1,93 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+)
+
+func TestStatsEndpoints(t *testing.T) {
+	_, store, r := newTestServer(t)
+	now := time.Now()
+	if err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now, Level: "ERROR", Message: "a", Service: "api", Hostname: "web1", App: "shop", Attributes: map[string]string{"region": "eu"}},
+		{Timestamp: now, Level: "INFO", Message: "b", Service: "api", Hostname: "web1", App: "shop", Attributes: map[string]string{"region": "us"}},
+		{Timestamp: now, Level: "INFO", Message: "c", Service: "worker", Hostname: "web2", App: "shop", Attributes: map[string]string{"region": "eu"}},
+		{Timestamp: now, Level: "ERROR", Message: "d", Service: "cron", Hostname: "web3", App: "jobs"},
+	}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	get := func(path string, dst any) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d: %s", path, w.Code, w.Body.String())
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), dst); err != nil {
+			t.Fatalf("%s: unmarshal: %v", path, err)
+		}
+	}
+
+	var severity struct{ Counts map[string]int64 }
+	get("/api/stats/severity?app=shop", &severity)
+	if severity.Counts["ERROR"] != 1 || severity.Counts["INFO"] != 2 {
+		t.Errorf("severity counts = %v", severity.Counts)
+	}
+	var minutes struct {
+		Minutes []struct{ Error, Total int64 }
+	}
+	get("/api/stats/severity?by=minute", &minutes)
+	if len(minutes.Minutes) == 0 || minutes.Minutes[len(minutes.Minutes)-1].Total != 4 {
+		t.Errorf("severity by minute = %+v", minutes.Minutes)
+	}
+
+	type dimension struct {
+		Value string
+		Count int64
+	}
+	var services struct{ Services []dimension }
+	get("/api/stats/services?app=shop&limit=1", &services)
+	if len(services.Services) != 1 || services.Services[0] != (dimension{"api", 2}) {
+		t.Errorf("services = %+v, want api first", services.Services)
+	}
+	get("/api/stats/services?level=error", &services)
+	if len(services.Services) != 2 {
+		t.Errorf("error services = %+v, want api and cron", services.Services)
+	}
+	var hosts struct{ Hosts []dimension }
+	get("/api/stats/hosts", &hosts)
+	if len(hosts.Hosts) != 3 || hosts.Hosts[0] != (dimension{"web1", 2}) {
+		t.Errorf("hosts = %+v", hosts.Hosts)
+	}
+
+	var keys struct {
+		Attributes []struct {
+			Key          string
+			UniqueValues int `json:"unique_values"`
+			Count        int64
+		}
+	}
+	get("/api/stats/attributes", &keys)
+	if len(keys.Attributes) != 1 || keys.Attributes[0].Key != "region" || keys.Attributes[0].UniqueValues != 2 || keys.Attributes[0].Count != 3 {
+		t.Errorf("attribute keys = %+v", keys.Attributes)
+	}
+	var values struct{ Values map[string]int64 }
+	get("/api/stats/attributes?key=region", &values)
+	if values.Values["eu"] != 2 || values.Values["us"] != 1 {
+		t.Errorf("region values = %v", values.Values)
+	}
+
+	for _, path := range []string{"/api/stats/hosts?limit=0", "/api/stats/severity?by=hour", "/api/stats/services?from=soon"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", path, w.Code)
+		}
+	}
+}
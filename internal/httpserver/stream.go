@@ -0,0 +1,355 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"golang.org/x/net/websocket"
+)
+
+// QueryStreamer is implemented by stores that can hand over the results of
+// a read-only query in batches as they are read (the DuckDB backend).
+type QueryStreamer interface {
+	StreamQuery(ctx context.Context, query string, batchSize int, fn func(columns []string, rows [][]interface{}) error, args ...any) error
+}
+
+const (
+	// streamBuffer is how many messages may wait for a slow client. A tail
+	// that finds the buffer full drops its batch and reports the count in
+	// the next one; a query waits for room, up to the query timeout.
+	streamBuffer = 64
+	// maxStreams is how many tails and queries one connection may run.
+	maxStreams = 16
+	// maxStreamRequest bounds a client message.
+	maxStreamRequest = 64 << 10
+	// defaultTailLimit is how many new logs a tail sends per poll by default.
+	defaultTailLimit = 500
+)
+
+// tailInterval is how often a tail polls for new logs.
+func (s *Server) tailInterval() time.Duration {
+	if s.tailPoll > 0 {
+		return s.tailPoll
+	}
+	return time.Second
+}
+
+// streamRequest is a client message on /api/stream. Op is "tail", "query",
+// or "cancel"; ID names the stream the server's messages answer.
+type streamRequest struct {
+	ID string `json:"id"`
+	Op string `json:"op"`
+
+	// tail
+	App     string   `json:"app"`
+	Levels  []string `json:"levels"`
+	Pattern string   `json:"pattern"`
+	Limit   int      `json:"limit"`
+
+	// query
+	SQL   string `json:"sql"`
+	Batch int    `json:"batch"`
+}
+
+// streamMessage is a server message on /api/stream. Type is "logs" (new
+// logs of a tail), "rows" (a batch of query results), "done" (a query
+// finished), or "error".
+type streamMessage struct {
+	ID       string          `json:"id"`
+	Type     string          `json:"type"`
+	Logs     []apiLog        `json:"logs,omitempty"`
+	Dropped  int             `json:"dropped,omitempty"`
+	Columns  []string        `json:"columns,omitempty"`
+	Rows     [][]interface{} `json:"rows,omitempty"`
+	RowCount int64           `json:"row_count,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// streamConn is one /api/stream client: its streams and the buffer of
+// messages waiting to be written to it.
+type streamConn struct {
+	server   *Server
+	ctx      context.Context
+	out      chan streamMessage
+	canQuery bool     // the API key grants the query scope
+	apps     []string // the apps the API key is limited to; nil = all
+	client   string   // rateClient of the connection
+
+	mu      sync.Mutex
+	streams map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// handleStream upgrades /api/stream to a WebSocket on which a client can
+// run live tails and stream query results, for a web UI.
+func (s *Server) handleStream(c *gin.Context) {
+	canQuery, apps, client := granted(c, ScopeQuery), grantedApps(c), rateClient(c)
+	websocket.Server{
+		Handshake: checkStreamOrigin,
+		Handler:   func(ws *websocket.Conn) { s.serveStream(ws, canQuery, apps, client) },
+	}.ServeHTTP(c.Writer, c.Request)
+}
+
+// checkStreamOrigin accepts clients that send no Origin (not browsers) and
+// pages served from the API's own host, so another site open in a browser
+// cannot read the logs.
+func checkStreamOrigin(config *websocket.Config, req *http.Request) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host != req.Host {
+		return fmt.Errorf("origin %q not allowed", origin)
+	}
+	config.Origin = u
+	return nil
+}
+
+func (s *Server) serveStream(ws *websocket.Conn, canQuery bool, apps []string, client string) {
+	ws.MaxPayloadBytes = maxStreamRequest
+	ctx, cancel := context.WithCancel(ws.Request().Context())
+	defer cancel()
+	// Hijacked connections outlive Shutdown; close this one with the server.
+	stop := context.AfterFunc(ctx, func() { ws.Close() })
+	defer stop()
+
+	conn := &streamConn{
+		server:   s,
+		ctx:      ctx,
+		out:      make(chan streamMessage, streamBuffer),
+		canQuery: canQuery,
+		apps:     apps,
+		client:   client,
+		streams:  make(map[string]context.CancelFunc),
+	}
+	go conn.write(ws, cancel)
+
+	for {
+		var req streamRequest
+		if err := websocket.JSON.Receive(ws, &req); err != nil {
+			break
+		}
+		conn.handle(req)
+	}
+	cancel()
+	conn.wg.Wait()
+}
+
+// write sends buffered messages to the client until the connection ends.
+func (conn *streamConn) write(ws *websocket.Conn, cancel context.CancelFunc) {
+	for {
+		select {
+		case <-conn.ctx.Done():
+			return
+		case msg := <-conn.out:
+			if err := websocket.JSON.Send(ws, msg); err != nil {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// send queues msg for the client, waiting for room until ctx ends.
+func (conn *streamConn) send(ctx context.Context, msg streamMessage) bool {
+	select {
+	case conn.out <- msg:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// trySend queues msg for the client unless its buffer is full.
+func (conn *streamConn) trySend(msg streamMessage) bool {
+	select {
+	case conn.out <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+func (conn *streamConn) handle(req streamRequest) {
+	if req.Op == "cancel" {
+		conn.mu.Lock()
+		if cancel, ok := conn.streams[req.ID]; ok {
+			cancel()
+		}
+		conn.mu.Unlock()
+		return
+	}
+
+	var run func(ctx context.Context, req streamRequest) error
+	switch req.Op {
+	case "tail":
+		app, err := RestrictApp(req.App, conn.apps)
+		if err != nil {
+			conn.send(conn.ctx, streamMessage{ID: req.ID, Type: "error", Error: err.Error()})
+			return
+		}
+		req.App = app
+		run = conn.tail
+	case "query":
+		if !conn.canQuery {
+			conn.send(conn.ctx, streamMessage{ID: req.ID, Type: "error", Error: "API key lacks the query scope"})
+			return
+		}
+		if conn.apps != nil {
+			conn.send(conn.ctx, streamMessage{ID: req.ID, Type: "error", Error: "API key is limited to apps; SQL queries read across apps"})
+			return
+		}
+		if limiter := conn.server.limiter; limiter != nil {
+			if ok, wait := limiter.allow(conn.client, time.Now()); !ok {
+				conn.send(conn.ctx, streamMessage{ID: req.ID, Type: "error", Error: fmt.Sprintf("rate limit exceeded; retry after %s", wait.Round(time.Millisecond))})
+				return
+			}
+		}
+		if strings.TrimSpace(req.SQL) == "" {
+			conn.send(conn.ctx, streamMessage{ID: req.ID, Type: "error", Error: "sql is required"})
+			return
+		}
+		run = conn.query
+	default:
+		conn.send(conn.ctx, streamMessage{ID: req.ID, Type: "error", Error: fmt.Sprintf("unknown op %q (want tail, query, or cancel)", req.Op)})
+		return
+	}
+
+	conn.mu.Lock()
+	if _, ok := conn.streams[req.ID]; ok {
+		conn.mu.Unlock()
+		conn.send(conn.ctx, streamMessage{ID: req.ID, Type: "error", Error: "stream id already in use"})
+		return
+	}
+	if len(conn.streams) >= maxStreams {
+		conn.mu.Unlock()
+		conn.send(conn.ctx, streamMessage{ID: req.ID, Type: "error", Error: fmt.Sprintf("too many streams (max %d)", maxStreams)})
+		return
+	}
+	ctx, cancel := context.WithCancel(conn.ctx)
+	conn.streams[req.ID] = cancel
+	conn.mu.Unlock()
+
+	conn.wg.Add(1)
+	go func() {
+		defer conn.wg.Done()
+		err := run(ctx, req)
+		conn.mu.Lock()
+		delete(conn.streams, req.ID)
+		conn.mu.Unlock()
+		cancel()
+		if err != nil && conn.ctx.Err() == nil {
+			conn.send(conn.ctx, streamMessage{ID: req.ID, Type: "error", Error: streamError(err)})
+		}
+	}()
+}
+
+// tail polls for logs newer than the last it sent and sends them, up to
+// req.Limit per poll, until ctx ends.
+func (conn *streamConn) tail(ctx context.Context, req streamRequest) error {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultTailLimit
+	}
+	levels := make([]string, len(req.Levels))
+	for i, level := range req.Levels {
+		levels[i] = strings.ToUpper(strings.TrimSpace(level))
+	}
+
+	// Logs sharing the newest timestamp sent are read again by the next
+	// poll; atSince counts those already sent.
+	since := time.Now()
+	atSince := 0
+	dropped := 0
+	ticker := time.NewTicker(conn.server.tailInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		logs, err := conn.server.store.RecentLogsFiltered(limit, model.QueryOpts{App: req.App, From: since}, levels, nil, req.Pattern)
+		if errors.Is(err, model.ErrOverloaded) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		skip := 0
+		for skip < len(logs) && skip < atSince && logs[skip].Timestamp.Equal(since) {
+			skip++
+		}
+		logs = logs[skip:]
+		if len(logs) == 0 {
+			continue
+		}
+
+		newest := logs[len(logs)-1].Timestamp
+		if newest.Equal(since) {
+			atSince += len(logs)
+		} else {
+			since, atSince = newest, 0
+			for _, r := range logs {
+				if r.Timestamp.Equal(newest) {
+					atSince++
+				}
+			}
+		}
+		if conn.trySend(streamMessage{ID: req.ID, Type: "logs", Logs: toAPILogs(logs), Dropped: dropped}) {
+			dropped = 0
+		} else {
+			dropped += len(logs)
+		}
+	}
+}
+
+// query streams the results of req.SQL in batches, then a "done" message.
+func (conn *streamConn) query(ctx context.Context, req streamRequest) error {
+	streamer, ok := conn.server.store.(QueryStreamer)
+	if !ok {
+		return errors.New("query streaming requires the duckdb storage backend")
+	}
+	var total int64
+	err := streamer.StreamQuery(ctx, req.SQL, req.Batch, func(columns []string, rows [][]interface{}) error {
+		msg := streamMessage{ID: req.ID, Type: "rows", Rows: rows}
+		if total == 0 {
+			msg.Columns = columns
+		}
+		if !conn.send(ctx, msg) {
+			return ctx.Err()
+		}
+		total += int64(len(rows))
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			// Cancelled by the client, or the connection closed.
+			return nil
+		}
+		return err
+	}
+	conn.send(ctx, streamMessage{ID: req.ID, Type: "done", RowCount: total})
+	return nil
+}
+
+// streamError is the message sent for a failed stream.
+func streamError(err error) string {
+	switch {
+	case errors.Is(err, model.ErrOverloaded):
+		return "query overloaded; retry"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "query timed out; retry"
+	}
+	return err.Error()
+}
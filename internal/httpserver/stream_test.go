@@ -0,0 +1,189 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"golang.org/x/net/websocket"
+)
+
+// dialStream opens /api/stream on a test server for r, from a page at
+// origin, or at the server itself when origin is empty.
+func dialStream(t *testing.T, r http.Handler, origin string) (*websocket.Conn, error) {
+	t.Helper()
+	ts := httptest.NewServer(r)
+	t.Cleanup(ts.Close)
+
+	if origin == "" {
+		origin = ts.URL
+	}
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/api/stream", "", origin)
+	if err == nil {
+		t.Cleanup(func() { ws.Close() })
+		ws.SetDeadline(time.Now().Add(10 * time.Second))
+	}
+	return ws, err
+}
+
+func TestStream_Query(t *testing.T) {
+	_, store, r := newTestServer(t)
+	now := time.Now()
+	var records []*duckdb.LogRecord
+	for i := range 5 {
+		records = append(records, &duckdb.LogRecord{Timestamp: now.Add(time.Duration(i) * time.Second), Level: "INFO", Message: "m"})
+	}
+	if err := store.InsertLogBatch(records); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	ws, err := dialStream(t, r, "")
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	if err := websocket.JSON.Send(ws, streamRequest{ID: "q", Op: "query", SQL: "SELECT message FROM logs", Batch: 2}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	var batches, rows int
+	for {
+		var msg streamMessage
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			t.Fatalf("receive: %v", err)
+		}
+		if msg.ID != "q" {
+			t.Fatalf("message id = %q, want q", msg.ID)
+		}
+		switch msg.Type {
+		case "rows":
+			if batches == 0 && (len(msg.Columns) != 1 || msg.Columns[0] != "message") {
+				t.Fatalf("first batch columns = %v, want [message]", msg.Columns)
+			}
+			batches++
+			rows += len(msg.Rows)
+			continue
+		case "done":
+			if batches != 3 || rows != 5 || msg.RowCount != 5 {
+				t.Fatalf("got %d batches, %d rows, row_count %d; want 3, 5, 5", batches, rows, msg.RowCount)
+			}
+		default:
+			t.Fatalf("unexpected message %+v", msg)
+		}
+		break
+	}
+
+	// Writes are rejected like on /api/query.
+	websocket.JSON.Send(ws, streamRequest{ID: "w", Op: "query", SQL: "DELETE FROM logs"})
+	var msg streamMessage
+	if err := websocket.JSON.Receive(ws, &msg); err != nil || msg.Type != "error" {
+		t.Fatalf("DELETE = %+v, %v; want an error message", msg, err)
+	}
+}
+
+func TestStream_Tail(t *testing.T) {
+	srv, store, r := newTestServer(t)
+	srv.tailPoll = 10 * time.Millisecond
+
+	ws, err := dialStream(t, r, "")
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	if err := websocket.JSON.Send(ws, streamRequest{ID: "t", Op: "tail", Levels: []string{"error"}}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	// Let the tail start before writing, so the logs are new to it.
+	time.Sleep(50 * time.Millisecond)
+	now := time.Now()
+	if err := store.InsertLogBatch([]*duckdb.LogRecord{
+		{Timestamp: now, Level: "INFO", Message: "skipped"},
+		{Timestamp: now, Level: "ERROR", Message: "first"},
+		{Timestamp: now.Add(time.Millisecond), Level: "ERROR", Message: "second"},
+	}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	var got []string
+	for len(got) < 2 {
+		var msg streamMessage
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			t.Fatalf("receive: %v", err)
+		}
+		if msg.Type != "logs" {
+			t.Fatalf("unexpected message %+v", msg)
+		}
+		for _, l := range msg.Logs {
+			got = append(got, l.Message)
+		}
+	}
+	if strings.Join(got, ",") != "first,second" {
+		t.Fatalf("tailed %v, want [first second]", got)
+	}
+}
+
+func TestStream_RejectsForeignOrigin(t *testing.T) {
+	_, _, r := newTestServer(t)
+	if _, err := dialStream(t, r, "http://evil.example"); err == nil {
+		t.Fatal("dial from a foreign origin succeeded")
+	}
+}
+
+func TestStream_APIKey(t *testing.T) {
+	srv, _, r := newTestServer(t)
+	keyring, err := NewKeyring([]APIKey{{Key: "read-only-key-0001", Scopes: []string{ScopeRead}}})
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	srv.SetKeyring(keyring)
+	ts := httptest.NewServer(r)
+	t.Cleanup(ts.Close)
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/api/stream"
+
+	if _, err := websocket.Dial(url, "", ts.URL); err == nil {
+		t.Fatal("dial without a key succeeded")
+	}
+	// Browsers cannot set headers on a WebSocket, so the key may be a
+	// query parameter.
+	ws, err := websocket.Dial(url+"?api_key=read-only-key-0001", "", ts.URL)
+	if err != nil {
+		t.Fatalf("dial with a key: %v", err)
+	}
+	defer ws.Close()
+	ws.SetDeadline(time.Now().Add(10 * time.Second))
+
+	websocket.JSON.Send(ws, streamRequest{ID: "q", Op: "query", SQL: "SELECT 1"})
+	var msg streamMessage
+	if err := websocket.JSON.Receive(ws, &msg); err != nil || msg.Type != "error" || !strings.Contains(msg.Error, "query scope") {
+		t.Fatalf("query with a read key = %+v, %v; want a scope error", msg, err)
+	}
+}
+
+func TestStream_AppLimitedKey(t *testing.T) {
+	srv, _, r := newTestServer(t)
+	keyring, err := NewKeyring([]APIKey{{Key: "shop-team-key-0001", Apps: []string{"shop"}}})
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	srv.SetKeyring(keyring)
+	ts := httptest.NewServer(r)
+	t.Cleanup(ts.Close)
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/api/stream?api_key=shop-team-key-0001", "", ts.URL)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer ws.Close()
+	ws.SetDeadline(time.Now().Add(10 * time.Second))
+
+	for _, req := range []streamRequest{
+		{ID: "t", Op: "tail", App: "billing"},
+		{ID: "q", Op: "query", SQL: "SELECT 1"},
+	} {
+		websocket.JSON.Send(ws, req)
+		var msg streamMessage
+		if err := websocket.JSON.Receive(ws, &msg); err != nil || msg.ID != req.ID || msg.Type != "error" {
+			t.Errorf("%s with a key limited to shop = %+v, %v; want an error", req.Op, msg, err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package ingest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// accessLogTimeLayout is the [10/Oct/2000:13:55:36 -0700] timestamp used by
+// Apache and nginx.
+const accessLogTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// accessLogRegex matches Common Log Format with the optional Combined
+// referer and user-agent fields. Anything after that is returned in the
+// last group for nginx/Apache extensions (request time, forwarded-for).
+var accessLogRegex = regexp.MustCompile(
+	`^(\S+) (\S+) (\S+) \[([^\]]+)\] "((?:[^"\\]|\\.)*)" (\d{3}) (\d+|-)` +
+		`(?: "((?:[^"\\]|\\.)*)" "((?:[^"\\]|\\.)*)")?(.*)$`)
+
+// Attribute keys written by the access log parser (OTEL semantic conventions
+// where one exists).
+const (
+	attrClientAddress = "client.address"
+	attrUserName      = "user.name"
+	attrHTTPMethod    = "http.request.method"
+	attrURLPath       = "url.path"
+	attrURLQuery      = "url.query"
+	attrHTTPProtocol  = "network.protocol.version"
+	attrHTTPStatus    = "http.response.status_code"
+	attrHTTPBodySize  = "http.response.body.size"
+	attrHTTPReferer   = "http.request.header.referer"
+	attrUserAgent     = "user_agent.original"
+	attrForwardedFor  = "http.request.header.x-forwarded-for"
+	attrDurationMS    = "http.server.duration_ms"
+)
+
+// ParseAccessLogLine parses an Apache/nginx access log line in Common or
+// Combined Log Format, including nginx's default "combined" format.
+//
+// A trailing $request_time (seconds, with a decimal point), Apache %D
+// (integer microseconds), or rt=/request_time= pair fills in the latency.
+// 5xx responses are stored as ERROR; everything else is INFO.
+// Returns nil when the line is not an access log line.
+func ParseAccessLogLine(line string) *model.LogRecord {
+	m := accessLogRegex.FindStringSubmatch(strings.TrimRight(line, "\r"))
+	if m == nil {
+		return nil
+	}
+
+	attributes := map[string]string{
+		attrClientAddress: m[1],
+		attrHTTPStatus:    m[6],
+	}
+	if m[3] != "-" {
+		attributes[attrUserName] = m[3]
+	}
+	if m[7] != "-" {
+		attributes[attrHTTPBodySize] = m[7]
+	}
+	if m[8] != "" && m[8] != "-" {
+		attributes[attrHTTPReferer] = m[8]
+	}
+	if m[9] != "" && m[9] != "-" {
+		attributes[attrUserAgent] = m[9]
+	}
+
+	request := m[5]
+	method, target, protocol := splitRequestLine(request)
+	if method != "" {
+		attributes[attrHTTPMethod] = method
+		path, query, _ := strings.Cut(target, "?")
+		attributes[attrURLPath] = path
+		if query != "" {
+			attributes[attrURLQuery] = query
+		}
+		if v, ok := strings.CutPrefix(protocol, "HTTP/"); ok {
+			attributes[attrHTTPProtocol] = v
+		}
+	}
+	parseAccessLogExtras(m[10], attributes)
+
+	var origTimestamp time.Time
+	if ts, err := time.Parse(accessLogTimeLayout, m[4]); err == nil {
+		origTimestamp = ts
+	}
+
+	status, _ := strconv.Atoi(m[6])
+	level := "INFO"
+	if status >= 500 {
+		level = "ERROR"
+	}
+
+	message := request
+	if method != "" {
+		message = method + " " + attributes[attrURLPath]
+	}
+	message = SanitizeMessage(message + " " + m[6])
+
+	return &model.LogRecord{
+		Timestamp:     time.Now(),
+		OrigTimestamp: origTimestamp,
+		Level:         level,
+		LevelNum:      DefaultSeverityNumber(level),
+		Message:       message,
+		RawLine:       line,
+		Attributes:    attributes,
+		App:           "default",
+	}
+}
+
+// splitRequestLine splits "GET /path HTTP/1.1". Malformed request lines
+// (e.g. "-" or raw TLS bytes) return empty strings.
+func splitRequestLine(request string) (method, target, protocol string) {
+	parts := strings.Fields(request)
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", "", ""
+	}
+	for _, r := range parts[0] {
+		if r < 'A' || r > 'Z' {
+			return "", "", ""
+		}
+	}
+	if len(parts) == 3 {
+		protocol = parts[2]
+	}
+	return parts[0], parts[1], protocol
+}
+
+// parseAccessLogExtras reads the common fields appended after Combined
+// format: a quoted X-Forwarded-For, the request time, and rt=/urt= pairs.
+func parseAccessLogExtras(rest string, attributes map[string]string) {
+	rest = strings.TrimSpace(rest)
+	for rest != "" {
+		var tok string
+		if rest[0] == '"' {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return
+			}
+			tok, rest = rest[1:end+1], strings.TrimSpace(rest[end+2:])
+			if tok != "" && tok != "-" {
+				if _, ok := attributes[attrForwardedFor]; !ok {
+					attributes[attrForwardedFor] = tok
+				}
+			}
+			continue
+		}
+		tok, rest, _ = strings.Cut(rest, " ")
+		rest = strings.TrimSpace(rest)
+
+		if k, v, ok := strings.Cut(tok, "="); ok {
+			if k == "rt" || k == "request_time" {
+				setAccessDuration(attributes, v, true)
+			}
+			continue
+		}
+		setAccessDuration(attributes, tok, strings.Contains(tok, "."))
+	}
+}
+
+// setAccessDuration records the first latency value found. Seconds come
+// from nginx ($request_time); integers are Apache %D microseconds.
+func setAccessDuration(attributes map[string]string, value string, seconds bool) {
+	if _, ok := attributes[attrDurationMS]; ok {
+		return
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return
+	}
+	ms := n / 1000
+	if seconds {
+		ms = n * 1000
+	}
+	attributes[attrDurationMS] = strconv.FormatFloat(ms, 'f', -1, 64)
+}
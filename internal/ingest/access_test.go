@@ -0,0 +1,131 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestParseAccessLogLine_Common(t *testing.T) {
+	t.Parallel()
+
+	entry := ParseAccessLogLine(`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif?x=1 HTTP/1.0" 200 2326`)
+	if entry == nil {
+		t.Fatal("ParseAccessLogLine returned nil")
+	}
+	want := map[string]string{
+		"client.address":            "127.0.0.1",
+		"user.name":                 "frank",
+		"http.request.method":       "GET",
+		"url.path":                  "/apache_pb.gif",
+		"url.query":                 "x=1",
+		"network.protocol.version":  "1.0",
+		"http.response.status_code": "200",
+		"http.response.body.size":   "2326",
+	}
+	for k, v := range want {
+		if entry.Attributes[k] != v {
+			t.Errorf("%s = %q, want %q", k, entry.Attributes[k], v)
+		}
+	}
+	if entry.Level != "INFO" {
+		t.Fatalf("Level = %q, want INFO", entry.Level)
+	}
+	if entry.Message != "GET /apache_pb.gif 200" {
+		t.Fatalf("Message = %q", entry.Message)
+	}
+	wantTS := time.Date(2000, 10, 10, 20, 55, 36, 0, time.UTC)
+	if !entry.OrigTimestamp.Equal(wantTS) {
+		t.Fatalf("OrigTimestamp = %s, want %s", entry.OrigTimestamp, wantTS)
+	}
+}
+
+func TestParseAccessLogLine_NginxCombinedWithTiming(t *testing.T) {
+	t.Parallel()
+
+	line := `10.1.2.3 - - [18/Feb/2026:10:22:23 +0000] "POST /api/pay HTTP/1.1" 502 157 "https://shop.example/cart" "Mozilla/5.0 (X11)" "203.0.113.9" 0.250`
+	entry := ParseAccessLogLine(line)
+	if entry == nil {
+		t.Fatal("ParseAccessLogLine returned nil")
+	}
+	if entry.Level != "ERROR" || entry.LevelNum != 17 {
+		t.Fatalf("Level = %q (%d), want ERROR for 5xx", entry.Level, entry.LevelNum)
+	}
+	if got := entry.Attributes["http.server.duration_ms"]; got != "250" {
+		t.Fatalf("duration_ms = %q, want 250", got)
+	}
+	if got := entry.Attributes["user_agent.original"]; got != "Mozilla/5.0 (X11)" {
+		t.Fatalf("user agent = %q", got)
+	}
+	if got := entry.Attributes["http.request.header.referer"]; got != "https://shop.example/cart" {
+		t.Fatalf("referer = %q", got)
+	}
+	if got := entry.Attributes["http.request.header.x-forwarded-for"]; got != "203.0.113.9" {
+		t.Fatalf("forwarded-for = %q", got)
+	}
+	if _, ok := entry.Attributes["user.name"]; ok {
+		t.Fatal("user.name should be omitted for -")
+	}
+}
+
+func TestParseAccessLogLine_ApacheMicroseconds(t *testing.T) {
+	t.Parallel()
+
+	entry := ParseAccessLogLine(`::1 - - [18/Feb/2026:10:22:23 +0000] "GET / HTTP/2.0" 404 - "-" "curl/8.0" 1500`)
+	if entry == nil {
+		t.Fatal("ParseAccessLogLine returned nil")
+	}
+	if got := entry.Attributes["http.server.duration_ms"]; got != "1.5" {
+		t.Fatalf("duration_ms = %q, want 1.5", got)
+	}
+	if _, ok := entry.Attributes["http.response.body.size"]; ok {
+		t.Fatal("body size should be omitted for -")
+	}
+	if entry.Level != "INFO" {
+		t.Fatalf("Level = %q, want INFO for 4xx", entry.Level)
+	}
+}
+
+func TestParseAccessLogLine_Rejects(t *testing.T) {
+	t.Parallel()
+
+	for _, line := range []string{
+		"plain text",
+		`level=info msg="not access"`,
+		`127.0.0.1 - - [bad] "GET /" abc 12`,
+	} {
+		if entry := ParseAccessLogLine(line); entry != nil {
+			t.Errorf("ParseAccessLogLine(%q) = %+v, want nil", line, entry)
+		}
+	}
+}
+
+func TestProcessor_SourceParsers(t *testing.T) {
+	t.Parallel()
+
+	parsers, err := SourceParsers(map[string]string{"tcp": "access"}, nil)
+	if err != nil {
+		t.Fatalf("SourceParsers: %v", err)
+	}
+	sink := &recordingSink{}
+	p := NewProcessor(sink, "stdin", Config{SourceParsers: parsers})
+
+	access := `10.0.0.1 - - [18/Feb/2026:10:22:23 +0000] "GET /healthz HTTP/1.1" 200 2`
+	if p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", Line: access}) == nil {
+		t.Fatal("tcp access line should be parsed")
+	}
+	if p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", Line: `level=info msg=skipped`}) != nil {
+		t.Fatal("tcp is access-only; logfmt should be dropped")
+	}
+	if p.ProcessEnvelope(model.IngestEnvelope{Source: "stdin", Line: access}) != nil {
+		t.Fatal("stdin uses auto and should not parse access lines")
+	}
+	if len(sink.records) != 1 || sink.records[0].Attributes["url.path"] != "/healthz" {
+		t.Fatalf("sink records = %+v", sink.records)
+	}
+
+	if _, err := SourceParsers(map[string]string{"tcp": "nope"}, nil); err == nil {
+		t.Fatal("expected error for unknown parser")
+	}
+}
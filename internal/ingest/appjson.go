@@ -0,0 +1,139 @@
+package ingest
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/logparse"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// Keys the app-json parser lifts out of application logger JSON (pino,
+// winston, bunyan, zap, logrus, structlog, Serilog CLEF, ECS, GELF). The
+// first key present wins; everything else becomes an attribute.
+var (
+	appJSONMessageKeys   = []string{"msg", "message", "@message", "@m", "short_message", "event"}
+	appJSONLevelKeys     = []string{"level", "severity", "lvl", "loglevel", "levelname", "@level", "@l", "log.level"}
+	appJSONTimestampKeys = []string{"time", "timestamp", "ts", "@timestamp", "@t"}
+)
+
+// ParseAppJSONLine parses a JSON object from an application logger. OTEL
+// JSON is parsed as such; any other object maps its message, level, and
+// time keys onto the record and flattens the remaining fields into
+// attributes (nested objects as dotted keys). Objects without a message key
+// keep the whole line as the message.
+// Returns nil when the line is not a JSON object.
+func ParseAppJSONLine(line string) []*model.LogRecord {
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &raw); err != nil || raw == nil {
+		return nil
+	}
+	if records, ok := parseOTELJSONLogEntries(raw, line); ok {
+		return records
+	}
+
+	attributes := make(map[string]string, len(raw))
+	FlattenJSONAttributes("", raw, attributes)
+
+	message := takeAttribute(attributes, appJSONMessageKeys...)
+	if message == "" {
+		message = line
+	}
+	message = SanitizeMessage(message)
+
+	level := appJSONLevel(takeAttribute(attributes, appJSONLevelKeys...))
+	if level == "" {
+		level = logparse.ExtractSeverityFromText(message)
+	}
+	level = logparse.NormalizeSeverity(level)
+
+	var origTimestamp time.Time
+	for _, key := range appJSONTimestampKeys {
+		if ts, ok := parseAppJSONTimestamp(attributes[key]); ok {
+			origTimestamp = ts
+			delete(attributes, key)
+			break
+		}
+	}
+
+	app := ExtractApp(attributes)
+	if app == "" {
+		app = "default"
+	}
+	return []*model.LogRecord{{
+		Timestamp:     time.Now(),
+		OrigTimestamp: origTimestamp,
+		Level:         level,
+		LevelNum:      DefaultSeverityNumber(level),
+		Message:       message,
+		RawLine:       line,
+		Attributes:    attributes,
+		App:           app,
+	}}
+}
+
+// appJSONLevel maps numeric levels to names: pino/bunyan use 10-60 (trace
+// to fatal) and GELF uses syslog 0-7. Named levels pass through, with
+// Serilog's Verbose as TRACE.
+func appJSONLevel(level string) string {
+	n, err := strconv.Atoi(level)
+	if err != nil {
+		if strings.EqualFold(level, "verbose") {
+			return "TRACE"
+		}
+		return level
+	}
+	switch {
+	case n >= 60:
+		return "FATAL"
+	case n >= 50:
+		return "ERROR"
+	case n >= 40:
+		return "WARN"
+	case n >= 30:
+		return "INFO"
+	case n >= 20:
+		return "DEBUG"
+	case n >= 10:
+		return "TRACE"
+	case n <= 2:
+		return "FATAL"
+	case n == 3:
+		return "ERROR"
+	case n == 4:
+		return "WARN"
+	case n <= 6:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
+
+// parseAppJSONTimestamp parses a logger timestamp. Numbers are epoch time
+// in seconds (zap, logrus), milliseconds (pino), microseconds, or
+// nanoseconds, told apart by magnitude.
+func parseAppJSONTimestamp(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return parseFieldTimestamp(value)
+	}
+	if n <= 0 {
+		return time.Time{}, false
+	}
+	switch {
+	case n >= 1e17:
+		return time.Unix(0, int64(n)), true
+	case n >= 1e14:
+		return time.UnixMicro(int64(n)), true
+	case n >= 1e11:
+		return time.UnixMilli(int64(n)), true
+	}
+	secs, frac := math.Modf(n)
+	return time.Unix(int64(secs), int64(frac*1e9)), true
+}
@@ -0,0 +1,125 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestParseAppJSONLine_LoggerShapes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		line    string
+		level   string
+		message string
+		ts      time.Time
+		attrs   map[string]string
+	}{
+		{
+			name:    "pino",
+			line:    `{"level":50,"time":1739876543210,"pid":42,"hostname":"web-1","name":"checkout","msg":"payment failed","err":{"type":"Error","message":"timeout"}}`,
+			level:   "ERROR",
+			message: "payment failed",
+			ts:      time.UnixMilli(1739876543210),
+			attrs:   map[string]string{"hostname": "web-1", "err.message": "timeout", "name": "checkout"},
+		},
+		{
+			name:    "winston",
+			line:    `{"level":"warn","message":"disk nearly full","timestamp":"2025-02-18T10:22:23.000Z","service":"storage"}`,
+			level:   "WARN",
+			message: "disk nearly full",
+			ts:      time.Date(2025, 2, 18, 10, 22, 23, 0, time.UTC),
+			attrs:   map[string]string{"service": "storage"},
+		},
+		{
+			name:    "bunyan",
+			line:    `{"name":"api","hostname":"h","pid":1,"level":30,"msg":"listening","time":"2025-02-18T10:22:23.500Z","v":0}`,
+			level:   "INFO",
+			message: "listening",
+			ts:      time.Date(2025, 2, 18, 10, 22, 23, 500e6, time.UTC),
+			attrs:   map[string]string{"v": "0"},
+		},
+		{
+			name:    "zap",
+			line:    `{"level":"debug","ts":1739876543.5,"caller":"main.go:12","msg":"cache warm","keys":128}`,
+			level:   "DEBUG",
+			message: "cache warm",
+			ts:      time.Unix(1739876543, 5e8),
+			attrs:   map[string]string{"caller": "main.go:12", "keys": "128"},
+		},
+		{
+			name:    "gelf",
+			line:    `{"version":"1.1","host":"db-2","short_message":"replication lag","level":4}`,
+			level:   "WARN",
+			message: "replication lag",
+			attrs:   map[string]string{"host": "db-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := ParseAppJSONLine(tt.line)
+			if len(r) != 1 {
+				t.Fatalf("records = %+v", r)
+			}
+			if r[0].Level != tt.level || r[0].Message != tt.message {
+				t.Fatalf("level/message = %q/%q, want %q/%q", r[0].Level, r[0].Message, tt.level, tt.message)
+			}
+			if !r[0].OrigTimestamp.Equal(tt.ts) {
+				t.Fatalf("OrigTimestamp = %s, want %s", r[0].OrigTimestamp, tt.ts)
+			}
+			for k, v := range tt.attrs {
+				if r[0].Attributes[k] != v {
+					t.Errorf("%s = %q, want %q", k, r[0].Attributes[k], v)
+				}
+			}
+			for _, k := range []string{"msg", "message", "level", "time", "ts", "timestamp"} {
+				if _, ok := r[0].Attributes[k]; ok {
+					t.Errorf("mapped key %q left in attributes", k)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAppJSONLine_OTELAndInvalid(t *testing.T) {
+	t.Parallel()
+
+	if r := ParseAppJSONLine(`{"severityText":"Error","body":{"stringValue":"otel"},"attributes":[]}`); len(r) != 1 || r[0].Message != "otel" {
+		t.Fatalf("OTEL records = %+v", r)
+	}
+	if r := ParseAppJSONLine(`{"user":"alice"}`); len(r) != 1 || r[0].Message != `{"user":"alice"}` || r[0].Attributes["user"] != "alice" {
+		t.Fatalf("message-less records = %+v", r)
+	}
+	for _, line := range []string{"not json", "[1,2]", "null"} {
+		if ParseAppJSONLine(line) != nil {
+			t.Errorf("%q should not parse", line)
+		}
+	}
+}
+
+func TestProcessor_AppJSONSourceStoresLoggerJSON(t *testing.T) {
+	t.Parallel()
+
+	parsers, err := SourceParsers(map[string]string{"tcp": ParserAppJSON}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &recordingSink{}
+	p := NewProcessor(sink, "stdin", Config{SourceParsers: parsers})
+
+	p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", Line: "{"})
+	p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", Line: `  "level": "info", "msg": "multi-line"`})
+	p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", Line: "}"})
+	if len(sink.records) != 1 || sink.records[0].Message != "multi-line" || sink.records[0].Source != "tcp" {
+		t.Fatalf("records = %+v", sink.records)
+	}
+
+	// The default auto parser still drops non-OTEL JSON.
+	if p.ProcessEnvelope(model.IngestEnvelope{Source: "stdin", Line: `{"level":"info","msg":"legacy"}`}) != nil {
+		t.Fatal("auto source should drop non-OTEL JSON")
+	}
+}
@@ -0,0 +1,57 @@
+package ingest
+
+import (
+	"sync"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// AppWatcher forwards records to the next sink and reports each app the
+// first time a record of it arrives. Records without an app count as
+// "default", as the stores name them.
+// All methods are safe for concurrent use.
+type AppWatcher struct {
+	next model.RecordSink
+	seen func(app string)
+
+	mu   sync.RWMutex
+	apps map[string]struct{}
+}
+
+// NewAppWatcher wraps next, calling seen for apps not in known. seen runs
+// on the ingesting goroutine and should not block.
+func NewAppWatcher(next model.RecordSink, known []string, seen func(app string)) *AppWatcher {
+	w := &AppWatcher{next: next, seen: seen, apps: make(map[string]struct{}, len(known))}
+	for _, app := range known {
+		w.apps[app] = struct{}{}
+	}
+	return w
+}
+
+// Add forwards record to the next sink, then reports its app if new.
+func (w *AppWatcher) Add(record *model.LogRecord) {
+	if record == nil {
+		return
+	}
+	if w.next != nil {
+		w.next.Add(record)
+	}
+
+	app := record.App
+	if app == "" {
+		app = "default"
+	}
+	w.mu.RLock()
+	_, known := w.apps[app]
+	w.mu.RUnlock()
+	if known {
+		return
+	}
+	w.mu.Lock()
+	_, known = w.apps[app]
+	w.apps[app] = struct{}{}
+	w.mu.Unlock()
+	if !known {
+		w.seen(app)
+	}
+}
@@ -0,0 +1,27 @@
+package ingest
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestAppWatcher_ReportsNewApps(t *testing.T) {
+	t.Parallel()
+
+	next := &recordingSink{}
+	var seen []string
+	w := NewAppWatcher(next, []string{"shop"}, func(app string) { seen = append(seen, app) })
+	for _, app := range []string{"shop", "blog", "", "blog", "default"} {
+		w.Add(&model.LogRecord{App: app, Message: "m"})
+	}
+	w.Add(nil)
+
+	if len(next.records) != 5 {
+		t.Fatalf("forwarded %d records, want 5", len(next.records))
+	}
+	if !slices.Equal(seen, []string{"blog", "default"}) {
+		t.Fatalf("seen = %v, want blog then default", seen)
+	}
+}
@@ -0,0 +1,302 @@
+package ingest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/logparse"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// Attribute keys written by the CEF and LEEF parsers for header fields.
+// Extension keys (src, dst, act, ...) are stored as-is.
+const (
+	attrCEFVersion       = "cef.version"
+	attrCEFDeviceVendor  = "cef.device.vendor"
+	attrCEFDeviceProduct = "cef.device.product"
+	attrCEFDeviceVersion = "cef.device.version"
+	attrCEFSignatureID   = "cef.signature_id"
+	attrCEFSeverity      = "cef.severity"
+
+	attrLEEFVersion       = "leef.version"
+	attrLEEFDeviceVendor  = "leef.device.vendor"
+	attrLEEFDeviceProduct = "leef.device.product"
+	attrLEEFDeviceVersion = "leef.device.version"
+	attrLEEFEventID       = "leef.event_id"
+)
+
+// securityTimeLayouts are the non-RFC 3339 forms CEF rt/end and LEEF
+// devTime commonly take ("MMM dd yyyy HH:mm:ss", optionally with millis
+// and a zone).
+var securityTimeLayouts = []string{
+	"Jan 02 2006 15:04:05",
+	"Jan 02 2006 15:04:05.000",
+	"Jan 02 2006 15:04:05 MST",
+	"Jan 02 2006 15:04:05.000 MST",
+	"Jan 02 2006 15:04:05 -0700",
+	"Jan 02 2006 15:04:05.000 -0700",
+}
+
+// ParseCEFLine parses an ArcSight Common Event Format line:
+//
+//	CEF:Version|Vendor|Product|Version|SignatureID|Name|Severity|Extension
+//
+// A syslog header before "CEF:" is skipped. Header fields become cef.*
+// attributes and the event name the message; extension key=value pairs are
+// stored under their own keys. Severity 0-10 (or Low/Medium/High/Very-High)
+// maps to INFO, WARN, ERROR, and FATAL; rt or end sets the timestamp and
+// dvchost the host.
+// Returns nil when the line is not a CEF line.
+func ParseCEFLine(line string) *model.LogRecord {
+	body, ok := cutSecurityPrefix(strings.TrimRight(line, "\r"), "CEF:")
+	if !ok {
+		return nil
+	}
+	header, extension, ok := splitCEFHeader(body)
+	if !ok {
+		return nil
+	}
+
+	attributes := parseCEFExtension(extension)
+	attributes[attrCEFVersion] = header[0]
+	attributes[attrCEFDeviceVendor] = header[1]
+	attributes[attrCEFDeviceProduct] = header[2]
+	attributes[attrCEFDeviceVersion] = header[3]
+	attributes[attrCEFSignatureID] = header[4]
+	attributes[attrCEFSeverity] = header[6]
+
+	var origTimestamp time.Time
+	for _, key := range []string{"rt", "end"} {
+		if ts, ok := parseSecurityTimestamp(attributes[key]); ok {
+			origTimestamp = ts
+			break
+		}
+	}
+	message := header[5]
+	if message == "" {
+		message = attributes["msg"]
+	}
+	return securityRecord(line, message, securityLevel(header[6]), origTimestamp, attributes, attributes["dvchost"])
+}
+
+// ParseLEEFLine parses an IBM QRadar Log Event Extended Format line:
+//
+//	LEEF:1.0|Vendor|Product|Version|EventID|key=value<tab>key=value
+//	LEEF:2.0|Vendor|Product|Version|EventID|Delimiter|key=value...
+//
+// A syslog header before "LEEF:" is skipped. LEEF 2.0 names its attribute
+// delimiter as a character or hex code (^, x5E, 0x5E); 1.0 uses a tab.
+// Header fields become leef.* attributes; the msg attribute (or the event
+// id) becomes the message. sev 1-10 sets the level, devTime the timestamp,
+// and identHostName the host.
+// Returns nil when the line is not a LEEF line.
+func ParseLEEFLine(line string) *model.LogRecord {
+	body, ok := cutSecurityPrefix(strings.TrimRight(line, "\r"), "LEEF:")
+	if !ok {
+		return nil
+	}
+	fields := strings.SplitN(body, "|", 6)
+	if len(fields) < 6 {
+		return nil
+	}
+	version := fields[0]
+	rest := fields[5]
+	delimiter := "\t"
+	if strings.HasPrefix(version, "2") {
+		d, attrs, ok := strings.Cut(rest, "|")
+		if !ok {
+			return nil
+		}
+		if decoded := leefDelimiter(d); decoded != "" {
+			delimiter = decoded
+		}
+		rest = attrs
+	}
+
+	attributes := make(map[string]string)
+	for _, pair := range strings.Split(rest, delimiter) {
+		key, value, ok := strings.Cut(pair, "=")
+		if key = strings.TrimSpace(key); !ok || key == "" || value == "" {
+			continue
+		}
+		attributes[key] = value
+	}
+	attributes[attrLEEFVersion] = version
+	attributes[attrLEEFDeviceVendor] = fields[1]
+	attributes[attrLEEFDeviceProduct] = fields[2]
+	attributes[attrLEEFDeviceVersion] = fields[3]
+	attributes[attrLEEFEventID] = fields[4]
+
+	origTimestamp, _ := parseSecurityTimestamp(attributes["devTime"])
+	message := attributes["msg"]
+	if message == "" {
+		message = fields[4]
+	}
+	return securityRecord(line, message, securityLevel(attributes["sev"]), origTimestamp, attributes, attributes["identHostName"])
+}
+
+// securityRecord builds the record shared by the CEF and LEEF parsers.
+func securityRecord(line, message, level string, origTimestamp time.Time, attributes map[string]string, host string) *model.LogRecord {
+	if host != "" && ExtractHostname(attributes) == "" {
+		attributes["host.name"] = host
+	}
+	app := ExtractApp(attributes)
+	if app == "" {
+		app = "default"
+	}
+	return &model.LogRecord{
+		Timestamp:     time.Now(),
+		OrigTimestamp: origTimestamp,
+		Level:         level,
+		LevelNum:      DefaultSeverityNumber(level),
+		Message:       SanitizeMessage(message),
+		RawLine:       line,
+		Attributes:    attributes,
+		App:           app,
+	}
+}
+
+// cutSecurityPrefix returns line from marker on. The marker must start the
+// line or follow whitespace (the end of a syslog header).
+func cutSecurityPrefix(line, marker string) (string, bool) {
+	i := strings.Index(line, marker)
+	if i < 0 || (i > 0 && line[i-1] != ' ' && line[i-1] != '\t') {
+		return "", false
+	}
+	return line[i+len(marker):], true
+}
+
+// splitCEFHeader splits the seven pipe-delimited header fields, unescaping
+// \| and \\, and returns the extension that follows.
+func splitCEFHeader(body string) ([]string, string, bool) {
+	header := make([]string, 0, 7)
+	var field strings.Builder
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case c == '\\' && i+1 < len(body) && (body[i+1] == '|' || body[i+1] == '\\'):
+			field.WriteByte(body[i+1])
+			i++
+		case c == '|':
+			header = append(header, field.String())
+			field.Reset()
+			if len(header) == 7 {
+				return header, body[i+1:], true
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	// Some senders omit the final pipe when there is no extension.
+	if len(header) == 6 {
+		return append(header, field.String()), "", true
+	}
+	return nil, "", false
+}
+
+// parseCEFExtension splits space-separated key=value pairs. Values may
+// contain spaces; a value runs until the space before the next key.
+// \= \\ \n \r are unescaped.
+func parseCEFExtension(extension string) map[string]string {
+	attributes := make(map[string]string)
+	var key string
+	valueStart := -1
+	flush := func(end int) {
+		if value := unescapeCEFValue(strings.TrimSpace(extension[valueStart:end])); value != "" {
+			attributes[key] = value
+		}
+	}
+	for i := 0; i < len(extension); i++ {
+		switch extension[i] {
+		case '\\':
+			i++
+		case '=':
+			// The key is the word before '='.
+			start := strings.LastIndexAny(extension[:i], " \t") + 1
+			if start >= i || (valueStart >= 0 && start <= valueStart) {
+				continue
+			}
+			if valueStart >= 0 {
+				flush(start)
+			}
+			key = extension[start:i]
+			valueStart = i + 1
+		}
+	}
+	if valueStart >= 0 {
+		flush(len(extension))
+	}
+	return attributes
+}
+
+var cefValueReplacer = strings.NewReplacer(`\=`, "=", `\\`, `\`, `\n`, " ", `\r`, " ")
+
+func unescapeCEFValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	return cefValueReplacer.Replace(value)
+}
+
+// leefDelimiter decodes a LEEF 2.0 delimiter: a single character or a hex
+// code such as x09 or 0x5E.
+func leefDelimiter(d string) string {
+	if len(d) == 1 {
+		return d
+	}
+	lower := strings.ToLower(d)
+	hex := strings.TrimPrefix(strings.TrimPrefix(lower, "0x"), "x")
+	if hex == lower {
+		return ""
+	}
+	n, err := strconv.ParseUint(hex, 16, 8)
+	if err != nil || n == 0 {
+		return ""
+	}
+	return string(rune(n))
+}
+
+// securityLevel maps a CEF/LEEF severity (0-10, or a CEF name) to a level.
+func securityLevel(severity string) string {
+	if n, err := strconv.Atoi(strings.TrimSpace(severity)); err == nil {
+		switch {
+		case n >= 9:
+			return "FATAL"
+		case n >= 7:
+			return "ERROR"
+		case n >= 4:
+			return "WARN"
+		case n >= 0:
+			return "INFO"
+		}
+	}
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "low":
+		return "INFO"
+	case "medium":
+		return "WARN"
+	case "high":
+		return "ERROR"
+	case "very-high", "very high":
+		return "FATAL"
+	}
+	return logparse.NormalizeSeverity(severity)
+}
+
+// parseSecurityTimestamp parses epoch milliseconds, the "MMM dd yyyy
+// HH:mm:ss" forms in securityTimeLayouts, or anything parseFieldTimestamp
+// accepts.
+func parseSecurityTimestamp(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil && ms > 1e11 {
+		return time.UnixMilli(ms), true
+	}
+	for _, layout := range securityTimeLayouts {
+		if ts, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return ts, true
+		}
+	}
+	return parseFieldTimestamp(value)
+}
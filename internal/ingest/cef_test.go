@@ -0,0 +1,119 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCEFLine(t *testing.T) {
+	t.Parallel()
+
+	line := `<134>Feb 18 10:22:23 fw01 CEF:0|Palo Alto Networks|PAN-OS|10.1|threat\|vuln|Brute force attempt|8|rt=1771410143000 src=10.0.0.5 dst=10.0.0.9 dpt=22 msg=login failed for user=root suser=root cs1Label=Rule cs1=deny ssh dvchost=fw01.example.com`
+	r := ParseCEFLine(line)
+	if r == nil {
+		t.Fatal("CEF line should parse")
+	}
+	if r.Level != "ERROR" || r.Message != "Brute force attempt" {
+		t.Fatalf("level/message = %q/%q", r.Level, r.Message)
+	}
+	want := map[string]string{
+		"cef.version":        "0",
+		"cef.device.vendor":  "Palo Alto Networks",
+		"cef.device.product": "PAN-OS",
+		"cef.device.version": "10.1",
+		"cef.signature_id":   "threat|vuln",
+		"cef.severity":       "8",
+		"src":                "10.0.0.5",
+		"dpt":                "22",
+		"msg":                "login failed for",
+		"user":               "root",
+		"cs1":                "deny ssh",
+		"host.name":          "fw01.example.com",
+	}
+	for k, v := range want {
+		if r.Attributes[k] != v {
+			t.Errorf("%s = %q, want %q", k, r.Attributes[k], v)
+		}
+	}
+	if !r.OrigTimestamp.Equal(time.UnixMilli(1771410143000)) {
+		t.Fatalf("OrigTimestamp = %s", r.OrigTimestamp)
+	}
+	if r.RawLine != line {
+		t.Fatalf("raw line = %q", r.RawLine)
+	}
+}
+
+func TestParseCEFLine_EscapesAndNamedSeverity(t *testing.T) {
+	t.Parallel()
+
+	r := ParseCEFLine(`CEF:1|Vendor|IDS|2.0|100|Query matched|Very-High|request=https://x.test/?a\=b&c\=d rt=Feb 18 2026 10:22:23`)
+	if r == nil || r.Level != "FATAL" {
+		t.Fatalf("record = %+v", r)
+	}
+	if got := r.Attributes["request"]; got != "https://x.test/?a=b&c=d" {
+		t.Fatalf("request = %q", got)
+	}
+	if ts := r.OrigTimestamp; ts.Year() != 2026 || ts.Month() != time.February || ts.Hour() != 10 {
+		t.Fatalf("OrigTimestamp = %s", ts)
+	}
+
+	for _, line := range []string{
+		"level=info msg=CEF:0",
+		"CEF:0|too|few|fields",
+		"notCEF:0|a|b|c|d|e|5|",
+	} {
+		if ParseCEFLine(line) != nil {
+			t.Errorf("%q should not parse as CEF", line)
+		}
+	}
+}
+
+func TestParseLEEFLine(t *testing.T) {
+	t.Parallel()
+
+	r := ParseLEEFLine("LEEF:1.0|Microsoft|MSExchange|2016|15345|src=10.50.1.1\tdst=2.10.20.20\tsev=5\tmsg=mailbox quota exceeded\tidentHostName=mx1")
+	if r == nil {
+		t.Fatal("LEEF 1.0 line should parse")
+	}
+	if r.Level != "WARN" || r.Message != "mailbox quota exceeded" {
+		t.Fatalf("level/message = %q/%q", r.Level, r.Message)
+	}
+	want := map[string]string{
+		"leef.version":        "1.0",
+		"leef.device.vendor":  "Microsoft",
+		"leef.device.product": "MSExchange",
+		"leef.device.version": "2016",
+		"leef.event_id":       "15345",
+		"src":                 "10.50.1.1",
+		"host.name":           "mx1",
+	}
+	for k, v := range want {
+		if r.Attributes[k] != v {
+			t.Errorf("%s = %q, want %q", k, r.Attributes[k], v)
+		}
+	}
+
+	r = ParseLEEFLine("<13>Feb 18 10:22:23 qradar LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=9^devTime=Feb 18 2026 10:22:23")
+	if r == nil || r.Level != "FATAL" || r.Message != "41" || r.Attributes["dst"] != "10.0.0.5" {
+		t.Fatalf("LEEF 2.0 record = %+v", r)
+	}
+	if r.OrigTimestamp.IsZero() {
+		t.Fatal("devTime should set OrigTimestamp")
+	}
+
+	r = ParseLEEFLine("LEEF:2.0|Vendor|Product|1|evt|x7C|a=1|b=2")
+	if r == nil || r.Attributes["a"] != "1" || r.Attributes["b"] != "2" {
+		t.Fatalf("hex delimiter record = %+v", r)
+	}
+}
+
+func TestParseAuto_SecurityFormats(t *testing.T) {
+	t.Parallel()
+
+	if r := parseAuto(`CEF:0|Vendor|Product|1|sig|Name|3|src=1.2.3.4`); len(r) != 1 || r[0].Attributes["cef.device.vendor"] != "Vendor" {
+		t.Fatalf("auto CEF = %+v", r)
+	}
+	if r := parseAuto("LEEF:1.0|Vendor|Product|1|evt|src=1.2.3.4"); len(r) != 1 || r[0].Attributes["leef.event_id"] != "evt" {
+		t.Fatalf("auto LEEF = %+v", r)
+	}
+}
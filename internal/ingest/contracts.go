@@ -11,9 +11,11 @@ const (
 type EnvelopeProcessor interface {
 	Name() string
 	ProcessEnvelope(model.IngestEnvelope) *ProcessResult
+	// Flush stores records still held for multiline joining.
+	Flush()
 }
 
 // NewEnvelopeProcessor creates the OTEL processor implementation.
-func NewEnvelopeProcessor(sink model.RecordSink, sourceName string) EnvelopeProcessor {
-	return NewProcessor(sink, sourceName)
+func NewEnvelopeProcessor(sink model.RecordSink, sourceName string, conf ...Config) EnvelopeProcessor {
+	return NewProcessor(sink, sourceName, conf...)
 }
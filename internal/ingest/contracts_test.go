@@ -3,6 +3,11 @@ package ingest
 import (
 	"testing"
 
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 )
 
@@ -89,3 +94,58 @@ func TestProcessor_ProcessEnvelope_NonOTELDropped(t *testing.T) {
 		t.Fatalf("expected zero sink records, got %d", len(sink.records))
 	}
 }
+
+func TestProcessor_ProcessEnvelope_BinaryOTLP(t *testing.T) {
+	t.Parallel()
+
+	sink := &recordingSink{}
+	p := NewProcessor(sink, "stdin")
+
+	str := func(s string) *commonpb.AnyValue {
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: s}}
+	}
+	data, err := proto.Marshal(&logspb.LogsData{ResourceLogs: []*logspb.ResourceLogs{{
+		Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{{Key: "service.name", Value: str("api")}}},
+		ScopeLogs: []*logspb.ScopeLogs{{LogRecords: []*logspb.LogRecord{
+			{SeverityText: "ERROR", Body: str("binary one")},
+			{SeverityNumber: logspb.SeverityNumber_SEVERITY_NUMBER_WARN, Body: str("binary two")},
+		}}},
+	}}})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	result := p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", OTLP: data})
+	if result == nil || result.Record.Message != "binary one" {
+		t.Fatalf("result = %+v", result)
+	}
+	if got := len(sink.records); got != 2 {
+		t.Fatalf("sink records = %d, want 2", got)
+	}
+	if r := sink.records[1]; r.Level != "WARN" || r.Service != "api" || r.Source != "tcp" {
+		t.Fatalf("second record = %+v", r)
+	}
+
+	if result := p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", OTLP: []byte{0xff, 0xff}}); result != nil {
+		t.Fatal("expected nil result for malformed frame")
+	}
+	if got := len(sink.records); got != 2 {
+		t.Fatalf("malformed frame should store nothing, sink records = %d", got)
+	}
+}
+
+func TestProcessor_ProcessEnvelope_CallsDoneAfterSink(t *testing.T) {
+	t.Parallel()
+
+	sink := &recordingSink{}
+	p := NewProcessor(sink, "tcp")
+
+	var storedAtDone []int
+	done := func() { storedAtDone = append(storedAtDone, len(sink.records)) }
+	p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", Line: "level=info msg=stored", Done: done})
+	p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", Line: "{", Done: done}) // held for JSON accumulation
+
+	if len(storedAtDone) != 2 || storedAtDone[0] != 1 {
+		t.Fatalf("done calls saw stored counts %v, want [1 1]", storedAtDone)
+	}
+}
@@ -0,0 +1,153 @@
+package ingest
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// attrLogIOStream records which stream (stdout, stderr) a CRI line came from.
+const attrLogIOStream = "log.iostream"
+
+// criLine is one line of the CRI container log format written by
+// containerd and CRI-O under /var/log/containers:
+//
+//	2024-01-01T00:00:00.000000000Z stdout F message
+type criLine struct {
+	timestamp string
+	stream    string
+	partial   bool // tag P: the content continues on the next line
+	content   string
+}
+
+// splitCRILine splits the CRI prefix from line.
+func splitCRILine(line string) (criLine, bool) {
+	ts, rest, ok := strings.Cut(strings.TrimRight(line, "\r"), " ")
+	if !ok {
+		return criLine{}, false
+	}
+	stream, rest, ok := strings.Cut(rest, " ")
+	if !ok || (stream != "stdout" && stream != "stderr") {
+		return criLine{}, false
+	}
+	tags, content, _ := strings.Cut(rest, " ")
+	tag, _, _ := strings.Cut(tags, ":")
+	if tag != "P" && tag != "F" {
+		return criLine{}, false
+	}
+	if _, err := time.Parse(time.RFC3339Nano, ts); err != nil {
+		return criLine{}, false
+	}
+	return criLine{timestamp: ts, stream: stream, partial: tag == "P", content: content}, true
+}
+
+// ParseCRILine unwraps a full (tag F) CRI line and parses its content with
+// the auto parser; content no parser accepts becomes the message. The CRI
+// timestamp is used unless the content carries its own, and the stream is
+// stored as log.iostream. Partial lines are joined by the Processor before
+// they reach the parser.
+// Returns nil when the line is not a CRI line.
+func ParseCRILine(line string) []*model.LogRecord {
+	entry, ok := splitCRILine(line)
+	if !ok || entry.content == "" {
+		return nil
+	}
+
+	records := parseAuto(entry.content)
+	if len(records) == 0 {
+		records = []*model.LogRecord{recordFromFields(entry.content, map[string]string{})}
+	}
+	ts, _ := time.Parse(time.RFC3339Nano, entry.timestamp)
+	for _, record := range records {
+		record.RawLine = line
+		if record.OrigTimestamp.IsZero() {
+			record.OrigTimestamp = ts
+		}
+		if record.Attributes == nil {
+			record.Attributes = make(map[string]string)
+		}
+		record.Attributes[attrLogIOStream] = entry.stream
+	}
+	return records
+}
+
+// joinsCRI reports whether lines for p are CRI lines whose partial (tag P)
+// lines need joining before parsing.
+func joinsCRI(p LineParser) bool {
+	if e, ok := p.(*RegexExtractor); ok {
+		return joinsCRI(e.fallback)
+	}
+	return p.Name() == ParserCRI
+}
+
+// criPartialTimeout is how long partial CRI lines wait for their final
+// part before what arrived is processed as a full line.
+const criPartialTimeout = 5 * time.Second
+
+// criPartial buffers the partial lines of one source.
+type criPartial struct {
+	prefix  string // timestamp and stream of the first partial line
+	content strings.Builder
+	done    acks // Done callbacks of the buffered lines
+	timer   *time.Timer
+}
+
+// joinCRI buffers partial CRI lines for source. It returns false while a
+// line is incomplete, and the joined line once its final part arrives.
+// Lines that are not CRI lines are returned unchanged for the parser to
+// reject. A buffered line takes its callbacks from done, and the joined
+// line gets back those of every part. Caller must hold p.mu.
+func (p *Processor) joinCRI(source, line string, done *acks) (string, bool) {
+	entry, ok := splitCRILine(line)
+	if !ok {
+		return line, true
+	}
+	buf := p.criPartials[source]
+	if entry.partial {
+		if buf == nil {
+			buf = &criPartial{prefix: entry.timestamp + " " + entry.stream + " "}
+			buf.timer = time.AfterFunc(criPartialTimeout, func() { p.expireCRI(source, buf) })
+			if p.criPartials == nil {
+				p.criPartials = make(map[string]*criPartial)
+			}
+			p.criPartials[source] = buf
+		}
+		buf.content.WriteString(entry.content)
+		buf.done = append(buf.done, *done...)
+		*done = nil
+		if buf.content.Len() > maxJSONBufferSize {
+			log.Printf("ingest: partial CRI line from %s exceeded %d bytes, resetting", source, maxJSONBufferSize)
+			buf.timer.Stop()
+			delete(p.criPartials, source)
+			*done = buf.done // dropped, so handled
+		}
+		return "", false
+	}
+	if buf == nil {
+		return line, true
+	}
+	buf.timer.Stop()
+	delete(p.criPartials, source)
+	*done = append(buf.done, *done...)
+	return buf.prefix + "F " + buf.content.String() + entry.content, true
+}
+
+// expireCRI processes the partial lines buffered in buf as a full line
+// when their final part has not arrived in time.
+func (p *Processor) expireCRI(source string, buf *criPartial) {
+	p.mu.Lock()
+	if p.criPartials[source] != buf {
+		p.mu.Unlock()
+		return
+	}
+	delete(p.criPartials, source)
+	p.mu.Unlock()
+
+	p.ProcessEnvelope(model.IngestEnvelope{
+		Source: source,
+		Line:   buf.prefix + "F " + buf.content.String(),
+		Done:   buf.done.run,
+	})
+}
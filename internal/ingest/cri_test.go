@@ -0,0 +1,81 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestParseCRILine(t *testing.T) {
+	t.Parallel()
+
+	line := `2024-01-01T00:00:00.123456789Z stderr F level=error msg="db down" service=api`
+	r := ParseCRILine(line)
+	if len(r) != 1 || r[0].Level != "ERROR" || r[0].Message != "db down" {
+		t.Fatalf("records = %+v", r)
+	}
+	if r[0].Attributes["log.iostream"] != "stderr" || r[0].Attributes["service"] != "api" {
+		t.Fatalf("attributes = %v", r[0].Attributes)
+	}
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 123456789, time.UTC); !r[0].OrigTimestamp.Equal(want) {
+		t.Fatalf("OrigTimestamp = %s, want %s", r[0].OrigTimestamp, want)
+	}
+	if r[0].RawLine != line {
+		t.Fatalf("raw line = %q", r[0].RawLine)
+	}
+
+	r = ParseCRILine("2024-01-01T00:00:00Z stdout F WARNING: cache miss rate high")
+	if len(r) != 1 || r[0].Message != "WARNING: cache miss rate high" || r[0].Level != "WARN" {
+		t.Fatalf("plain text records = %+v", r)
+	}
+
+	for _, line := range []string{
+		"level=info msg=hello",
+		"2024-01-01T00:00:00Z stdlog F nope",
+		"not-a-time stdout F nope",
+		"2024-01-01T00:00:00Z stdout X nope",
+	} {
+		if ParseCRILine(line) != nil {
+			t.Errorf("%q should not parse as CRI", line)
+		}
+	}
+}
+
+func TestProcessor_JoinsPartialCRILines(t *testing.T) {
+	t.Parallel()
+
+	parsers, err := SourceParsers(map[string]string{"stdin": ParserCRI}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &recordingSink{}
+	p := NewProcessor(sink, "stdin", Config{SourceParsers: parsers})
+
+	lines := []string{
+		`2024-01-01T00:00:00Z stdout P {"severityText":"INFO",`,
+		`2024-01-01T00:00:00Z stdout P "body":"joined`,
+		`2024-01-01T00:00:01Z stdout F  record"}`,
+		`2024-01-01T00:00:02Z stdout F second`,
+	}
+	acked := 0
+	for i, line := range lines {
+		result := p.ProcessEnvelope(model.IngestEnvelope{Source: "stdin", Line: line, Done: func() { acked++ }})
+		if partial := i < 2; partial != (result == nil) {
+			t.Fatalf("line %d result = %+v", i, result)
+		}
+		// Partial lines are acknowledged with the record they end up in.
+		if want := map[int]int{0: 0, 1: 0, 2: 3, 3: 4}[i]; acked != want {
+			t.Fatalf("after line %d acked = %d, want %d", i, acked, want)
+		}
+	}
+	if len(sink.records) != 2 {
+		t.Fatalf("stored %d records, want 2", len(sink.records))
+	}
+	if got := sink.records[0].Message; got != "joined record" {
+		t.Fatalf("joined message = %q", got)
+	}
+	if got := sink.records[1].Message; got != "second" {
+		t.Fatalf("second message = %q", got)
+	}
+}
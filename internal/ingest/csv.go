@@ -0,0 +1,128 @@
+package ingest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// CSVConfig declares a delimiter-separated line parser.
+type CSVConfig struct {
+	// Delimiter is a single character; "tab" (or "\t") selects TSV.
+	// Defaults to a comma.
+	Delimiter string
+	// Columns names each column in order. message, level, and timestamp
+	// fill the record; app sets the app; other names become attributes.
+	// An empty name or "-" skips the column.
+	Columns []string
+}
+
+// CSVParser splits lines on a delimiter and maps columns to fields.
+// Quoted fields follow RFC 4180. A line whose fields all equal their
+// column names is treated as a header row and skipped.
+type CSVParser struct {
+	name    string
+	comma   rune
+	columns []string
+}
+
+// NewCSVParser validates conf and returns a parser named name.
+func NewCSVParser(name string, conf CSVConfig) (*CSVParser, error) {
+	comma := ','
+	switch d := conf.Delimiter; d {
+	case "":
+	case "tab", `\t`:
+		comma = '\t'
+	default:
+		r, size := utf8.DecodeRuneInString(d)
+		if size != len(d) || r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+			return nil, fmt.Errorf("csv parser %q: invalid delimiter %q", name, d)
+		}
+		comma = r
+	}
+
+	named := false
+	for _, column := range conf.Columns {
+		if column != "" && column != "-" {
+			named = true
+			break
+		}
+	}
+	if !named {
+		return nil, fmt.Errorf("csv parser %q: no columns", name)
+	}
+	return &CSVParser{name: name, comma: comma, columns: conf.Columns}, nil
+}
+
+// Name implements LineParser.
+func (p *CSVParser) Name() string { return p.name }
+
+// Parse implements LineParser. Fields beyond the last column are joined
+// back into it, so an unquoted trailing message may contain the delimiter.
+func (p *CSVParser) Parse(line string) []*model.LogRecord {
+	r := csv.NewReader(strings.NewReader(line))
+	r.Comma = p.comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	fields, err := r.Read()
+	if err != nil || p.isHeader(fields) {
+		return nil
+	}
+	if n := len(p.columns); len(fields) > n {
+		fields = append(fields[:n-1], strings.Join(fields[n-1:], string(p.comma)))
+	}
+
+	attributes := make(map[string]string, len(fields))
+	for i, value := range fields {
+		column := p.columns[i]
+		if column == "" || column == "-" || value == "" {
+			continue
+		}
+		attributes[column] = value
+	}
+	return []*model.LogRecord{recordFromFields(line, attributes)}
+}
+
+// isHeader reports whether fields repeat the configured column names.
+func (p *CSVParser) isHeader(fields []string) bool {
+	if len(fields) != len(p.columns) {
+		return false
+	}
+	for i, field := range fields {
+		if column := p.columns[i]; column != "" && column != "-" && !strings.EqualFold(strings.TrimSpace(field), column) {
+			return false
+		}
+	}
+	return true
+}
+
+// CSVParsers builds every parser in conf. Names are lowercased to match
+// ParserByName and may not shadow a built-in parser.
+func CSVParsers(conf map[string]CSVConfig) (map[string]LineParser, error) {
+	if len(conf) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(conf))
+	for name := range conf {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make(map[string]LineParser, len(names))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, ok := builtinParsers[key]; ok {
+			return nil, fmt.Errorf("csv parser %q shadows a built-in parser", name)
+		}
+		p, err := NewCSVParser(key, conf[name])
+		if err != nil {
+			return nil, err
+		}
+		out[key] = p
+	}
+	return out, nil
+}
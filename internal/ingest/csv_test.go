@@ -0,0 +1,70 @@
+package ingest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVParser_MapsColumns(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewCSVParser("export", CSVConfig{Columns: []string{"timestamp", "level", "service", "-", "message"}})
+	if err != nil {
+		t.Fatalf("NewCSVParser: %v", err)
+	}
+
+	if p.Parse("Timestamp,Level,Service,ignored,Message") != nil {
+		t.Fatal("header row should be skipped")
+	}
+
+	r := p.Parse(`2026-02-18T10:22:23Z,error,checkout,x,"payment failed, retrying"`)
+	if len(r) != 1 {
+		t.Fatalf("Parse returned %d records, want 1", len(r))
+	}
+	if r[0].Level != "ERROR" || r[0].Message != "payment failed, retrying" || r[0].Attributes["service"] != "checkout" {
+		t.Fatalf("record = %+v", r[0])
+	}
+	if want := time.Date(2026, 2, 18, 10, 22, 23, 0, time.UTC); !r[0].OrigTimestamp.Equal(want) {
+		t.Fatalf("OrigTimestamp = %s, want %s", r[0].OrigTimestamp, want)
+	}
+	if _, ok := r[0].Attributes["-"]; ok {
+		t.Fatal("skipped column should not become an attribute")
+	}
+
+	// Unquoted delimiters in the last column stay in the message.
+	r = p.Parse("2026-02-18T10:22:24Z,info,api,x,a, b, c")
+	if r[0].Message != "a, b, c" {
+		t.Fatalf("Message = %q, want the joined tail", r[0].Message)
+	}
+}
+
+func TestCSVParser_TSV(t *testing.T) {
+	t.Parallel()
+
+	parsers, err := CSVParsers(map[string]CSVConfig{"Batch": {Delimiter: "tab", Columns: []string{"level", "message", "user.id"}}})
+	if err != nil {
+		t.Fatalf("CSVParsers: %v", err)
+	}
+	r := parsers["batch"].Parse("WARN\tquota near limit\t42")
+	if len(r) != 1 || r[0].Level != "WARN" || r[0].Message != "quota near limit" || r[0].Attributes["user.id"] != "42" {
+		t.Fatalf("record = %+v", r)
+	}
+}
+
+func TestCSVParsers_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]CSVConfig{
+		"invalid delimiter": {Delimiter: ";;", Columns: []string{"message"}},
+		"no columns":        {Columns: []string{"-", ""}},
+	}
+	for want, conf := range tests {
+		if _, err := CSVParsers(map[string]CSVConfig{"x": conf}); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("CSVParsers(%+v) error = %v, want %q", conf, err, want)
+		}
+	}
+	if _, err := CSVParsers(map[string]CSVConfig{"access": {Columns: []string{"message"}}}); err == nil {
+		t.Fatal("expected error for csv parser shadowing a built-in")
+	}
+}
@@ -102,3 +102,22 @@ func MergeAttributes(dst, src map[string]string) {
 		dst[k] = v
 	}
 }
+
+// FlattenJSONAttributes copies a decoded JSON object into dst using dotted keys
+// for nested objects (e.g. {"host":{"name":"a"}} -> "host.name"). Arrays and
+// other non-object values are stored as their string form; empty values are skipped.
+func FlattenJSONAttributes(prefix string, src map[string]interface{}, dst map[string]string) {
+	for k, v := range src {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			FlattenJSONAttributes(key, nested, dst)
+			continue
+		}
+		if str := stringifyJSONValue(v); str != "" {
+			dst[key] = str
+		}
+	}
+}
@@ -181,6 +181,10 @@ func parseOTELLogRecord(raw map[string]interface{}, inherited map[string]string,
 	}
 
 	message := extractOTELBody(raw["body"])
+	var bodyJSON string
+	if fields, ok := otelJSONAnyValue(raw["body"]).(map[string]interface{}); ok {
+		message, bodyJSON = structuredBody(fields)
+	}
 
 	rawLine := line
 	if encoded, err := json.Marshal(raw); err == nil {
@@ -218,6 +222,7 @@ func parseOTELLogRecord(raw map[string]interface{}, inherited map[string]string,
 		LevelNum:      severityNumber,
 		Message:       message,
 		RawLine:       rawLine,
+		BodyJSON:      bodyJSON,
 		Attributes:    attributes,
 		App:           app,
 	}
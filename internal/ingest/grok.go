@@ -0,0 +1,218 @@
+package ingest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// grokMaxDepth bounds pattern nesting, so self-referencing patterns fail
+// at startup instead of recursing forever.
+const grokMaxDepth = 16
+
+// grokReferenceRegex matches %{NAME}, %{NAME:field}, and %{NAME:field:type}.
+var grokReferenceRegex = regexp.MustCompile(`%\{(\w+)(?::([^:{}]+))?(?::(\w+))?\}`)
+
+// grokBuiltinPatterns is a subset of the Logstash pattern library, rewritten
+// for RE2 (no lookaround or possessive quantifiers).
+var grokBuiltinPatterns = map[string]string{
+	"USERNAME":   `[a-zA-Z0-9._-]+`,
+	"USER":       `%{USERNAME}`,
+	"INT":        `[+-]?[0-9]+`,
+	"BASE10NUM":  `[+-]?(?:[0-9]+(?:\.[0-9]+)?|\.[0-9]+)`,
+	"NUMBER":     `%{BASE10NUM}`,
+	"BASE16NUM":  `[+-]?(?:0x)?[0-9A-Fa-f]+`,
+	"POSINT":     `[1-9][0-9]*`,
+	"NONNEGINT":  `[0-9]+`,
+	"WORD":       `\b\w+\b`,
+	"NOTSPACE":   `\S+`,
+	"SPACE":      `\s*`,
+	"DATA":       `.*?`,
+	"GREEDYDATA": `.*`,
+
+	"QUOTEDSTRING": `"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`,
+	"UUID":         `[A-Fa-f0-9]{8}-(?:[A-Fa-f0-9]{4}-){3}[A-Fa-f0-9]{12}`,
+	"MAC":          `(?:[A-Fa-f0-9]{2}[:-]){5}[A-Fa-f0-9]{2}`,
+
+	"IPV4":     `(?:(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])`,
+	"IPV6":     `(?:[A-Fa-f0-9]{0,4}:){2,7}[A-Fa-f0-9]{0,4}(?:%\w+)?`,
+	"IP":       `(?:%{IPV6}|%{IPV4})`,
+	"HOSTNAME": `\b[0-9A-Za-z][0-9A-Za-z-]{0,62}(?:\.[0-9A-Za-z][0-9A-Za-z-]{0,62})*\.?`,
+	"IPORHOST": `(?:%{IP}|%{HOSTNAME})`,
+	"HOSTPORT": `%{IPORHOST}:%{POSINT}`,
+
+	"UNIXPATH":     `(?:/[\w%!$@:.,+~-]*)+`,
+	"PATH":         `%{UNIXPATH}`,
+	"URIPROTO":     `[A-Za-z][A-Za-z0-9+.-]*`,
+	"URIHOST":      `%{IPORHOST}(?::%{POSINT})?`,
+	"URIPATH":      `(?:/[A-Za-z0-9$.+!*'(){},~:;=@#%&_\-]*)+`,
+	"URIPARAM":     `\?[A-Za-z0-9$.+!*'|(){},~@#%&/=:;_?\-\[\]<>]*`,
+	"URIPATHPARAM": `%{URIPATH}(?:%{URIPARAM})?`,
+	"URI":          `%{URIPROTO}://(?:%{USER}(?::[^@]*)?@)?(?:%{URIHOST})?(?:%{URIPATHPARAM})?`,
+
+	"MONTH":             `\b(?:[Jj]an(?:uary)?|[Ff]eb(?:ruary)?|[Mm]ar(?:ch)?|[Aa]pr(?:il)?|[Mm]ay|[Jj]un(?:e)?|[Jj]ul(?:y)?|[Aa]ug(?:ust)?|[Ss]ep(?:tember)?|[Oo]ct(?:ober)?|[Nn]ov(?:ember)?|[Dd]ec(?:ember)?)\b`,
+	"MONTHNUM":          `(?:0?[1-9]|1[0-2])`,
+	"MONTHDAY":          `(?:0[1-9]|[12][0-9]|3[01]|[1-9])`,
+	"DAY":               `(?:Mon(?:day)?|Tue(?:sday)?|Wed(?:nesday)?|Thu(?:rsday)?|Fri(?:day)?|Sat(?:urday)?|Sun(?:day)?)`,
+	"YEAR":              `(?:\d\d){1,2}`,
+	"HOUR":              `(?:2[0123]|[01]?[0-9])`,
+	"MINUTE":            `(?:[0-5][0-9])`,
+	"SECOND":            `(?:(?:[0-5]?[0-9]|60)(?:[:.,][0-9]+)?)`,
+	"TIME":              `%{HOUR}:%{MINUTE}(?::%{SECOND})?`,
+	"DATE_US":           `%{MONTHNUM}[/-]%{MONTHDAY}[/-]%{YEAR}`,
+	"DATE_EU":           `%{MONTHDAY}[./-]%{MONTHNUM}[./-]%{YEAR}`,
+	"ISO8601_TIMEZONE":  `(?:Z|[+-]%{HOUR}(?::?%{MINUTE}))`,
+	"TIMESTAMP_ISO8601": `%{YEAR}-%{MONTHNUM}-%{MONTHDAY}[T ]%{HOUR}:?%{MINUTE}(?::?%{SECOND})?%{ISO8601_TIMEZONE}?`,
+	"HTTPDATE":          `%{MONTHDAY}/%{MONTH}/%{YEAR}:%{TIME} %{INT}`,
+	"SYSLOGTIMESTAMP":   `%{MONTH} +%{MONTHDAY} %{TIME}`,
+
+	"LOGLEVEL":     `(?i:trace|debug|info|notice|warn(?:ing)?|err(?:or)?|crit(?:ical)?|fatal|severe|emerg(?:ency)?|alert)`,
+	"PROG":         `[\x21-\x5a\x5c\x5e-\x7e]+`,
+	"SYSLOGPROG":   `%{PROG:program}(?:\[%{POSINT:pid}\])?`,
+	"EMAILADDRESS": `[a-zA-Z0-9!#$%&'*+\-/=?^_{|}~.]+@%{HOSTNAME}`,
+	"JAVACLASS":    `(?:[a-zA-Z$_][a-zA-Z$_0-9]*\.)*[a-zA-Z$_][a-zA-Z$_0-9]*`,
+}
+
+// GrokConfig declares user-defined grok patterns and parsers.
+type GrokConfig struct {
+	// Patterns are reusable sub-patterns referenced as %{NAME}. They may
+	// override built-ins. Names are case-insensitive.
+	Patterns map[string]string
+	// Parsers maps a parser name (for source-parsers) to a match expression.
+	Parsers map[string]string
+}
+
+// GrokParser matches lines against one compiled grok expression.
+type GrokParser struct {
+	name   string
+	regex  *regexp.Regexp
+	fields []string // capture field per submatch index; "" = unnamed
+}
+
+// NewGrokParser compiles expr, resolving %{NAME} references against
+// patterns and then the built-in library.
+//
+// %{NAME:field} captures into field. The capture names message, level, and
+// timestamp fill the record; app sets the app; everything else (including
+// service and host, picked up by the processor) is stored as an attribute.
+// A third :type part (%{INT:bytes:int}) is accepted for Logstash
+// compatibility and ignored, since attributes are strings. Plain RE2 named
+// groups, (?P<field>...), capture the same way.
+func NewGrokParser(name, expr string, patterns map[string]string) (*GrokParser, error) {
+	lookup := make(map[string]string, len(patterns))
+	for k, v := range patterns {
+		lookup[strings.ToUpper(k)] = v
+	}
+
+	var fields []string
+	expanded, err := expandGrok(expr, lookup, &fields, 0)
+	if err != nil {
+		return nil, fmt.Errorf("grok parser %q: %w", name, err)
+	}
+	re, err := regexp.Compile(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("grok parser %q: %w", name, err)
+	}
+
+	byIndex := make([]string, len(re.SubexpNames()))
+	for i, group := range re.SubexpNames() {
+		if n, ok := strings.CutPrefix(group, "grok"); ok {
+			if idx, err := strconv.Atoi(n); err == nil && idx < len(fields) {
+				byIndex[i] = fields[idx]
+				continue
+			}
+		}
+		byIndex[i] = group
+	}
+	return &GrokParser{name: name, regex: re, fields: byIndex}, nil
+}
+
+// expandGrok replaces %{...} references with regex source, recording one
+// field name per generated capture group.
+func expandGrok(expr string, lookup map[string]string, fields *[]string, depth int) (string, error) {
+	if depth > grokMaxDepth {
+		return "", fmt.Errorf("patterns nested deeper than %d (recursive reference?)", grokMaxDepth)
+	}
+	var firstErr error
+	out := grokReferenceRegex.ReplaceAllStringFunc(expr, func(ref string) string {
+		if firstErr != nil {
+			return ""
+		}
+		m := grokReferenceRegex.FindStringSubmatch(ref)
+		patternName, field := strings.ToUpper(m[1]), m[2]
+		def, ok := lookup[patternName]
+		if !ok {
+			def, ok = grokBuiltinPatterns[patternName]
+		}
+		if !ok {
+			firstErr = fmt.Errorf("unknown pattern %%{%s}", m[1])
+			return ""
+		}
+		sub, err := expandGrok(def, lookup, fields, depth+1)
+		if err != nil {
+			firstErr = err
+			return ""
+		}
+		if field == "" {
+			return "(?:" + sub + ")"
+		}
+		group := fmt.Sprintf("grok%d", len(*fields))
+		*fields = append(*fields, field)
+		return "(?P<" + group + ">" + sub + ")"
+	})
+	return out, firstErr
+}
+
+// Name implements LineParser.
+func (p *GrokParser) Name() string { return p.name }
+
+// Parse implements LineParser. Lines that do not match return nil.
+func (p *GrokParser) Parse(line string) []*model.LogRecord {
+	m := p.regex.FindStringSubmatch(strings.TrimRight(line, "\r"))
+	if m == nil {
+		return nil
+	}
+
+	attributes := make(map[string]string)
+	for i, field := range p.fields {
+		if field == "" || m[i] == "" {
+			continue
+		}
+		// With alternations the same field can appear twice; first wins.
+		if _, ok := attributes[field]; !ok {
+			attributes[field] = m[i]
+		}
+	}
+	return []*model.LogRecord{recordFromFields(line, attributes)}
+}
+
+// GrokParsers compiles every parser in conf. Names are lowercased to match
+// ParserByName and may not shadow a built-in parser.
+func GrokParsers(conf GrokConfig) (map[string]LineParser, error) {
+	if len(conf.Parsers) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(conf.Parsers))
+	for name := range conf.Parsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make(map[string]LineParser, len(names))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, ok := builtinParsers[key]; ok {
+			return nil, fmt.Errorf("grok parser %q shadows a built-in parser", name)
+		}
+		p, err := NewGrokParser(key, conf.Parsers[name], conf.Patterns)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = p
+	}
+	return out, nil
+}
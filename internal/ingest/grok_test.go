@@ -0,0 +1,118 @@
+package ingest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestGrokParser_FieldsAndAttributes(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewGrokParser("legacy",
+		`^%{TIMESTAMP_ISO8601:timestamp} \[%{ORDERID:order.id}\] %{LOGLEVEL:level} %{WORD:service} %{IP:client.address}: %{GREEDYDATA:message}$`,
+		map[string]string{"orderid": `ord-[0-9]+`})
+	if err != nil {
+		t.Fatalf("NewGrokParser: %v", err)
+	}
+
+	records := p.Parse(`2026-02-18T10:22:23Z [ord-42] warning checkout 10.1.2.3: card declined`)
+	if len(records) != 1 {
+		t.Fatalf("Parse returned %d records, want 1", len(records))
+	}
+	r := records[0]
+	if r.Message != "card declined" || r.Level != "WARN" {
+		t.Fatalf("message/level = %q/%q", r.Message, r.Level)
+	}
+	if want := time.Date(2026, 2, 18, 10, 22, 23, 0, time.UTC); !r.OrigTimestamp.Equal(want) {
+		t.Fatalf("OrigTimestamp = %s, want %s", r.OrigTimestamp, want)
+	}
+	want := map[string]string{"order.id": "ord-42", "service": "checkout", "client.address": "10.1.2.3"}
+	for k, v := range want {
+		if r.Attributes[k] != v {
+			t.Errorf("%s = %q, want %q", k, r.Attributes[k], v)
+		}
+	}
+	for _, k := range []string{"timestamp", "level", "message"} {
+		if _, ok := r.Attributes[k]; ok {
+			t.Errorf("%s should be promoted, not kept as an attribute", k)
+		}
+	}
+
+	if p.Parse("free text that does not match") != nil {
+		t.Fatal("non-matching line should return nil")
+	}
+}
+
+func TestGrokParser_NestedCapturesAndNamedGroups(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewGrokParser("syslog",
+		`%{SYSLOGTIMESTAMP:timestamp} %{HOSTNAME:host} %{SYSLOGPROG}: (?P<message>.*)`, nil)
+	if err != nil {
+		t.Fatalf("NewGrokParser: %v", err)
+	}
+	r := p.Parse(`Feb  3 04:05:06 web-1 sshd[812]: Accepted publickey for deploy`)
+	if r == nil {
+		t.Fatal("syslog line should match")
+	}
+	attrs := r[0].Attributes
+	if attrs["host"] != "web-1" || attrs["program"] != "sshd" || attrs["pid"] != "812" {
+		t.Fatalf("attributes = %+v", attrs)
+	}
+	if r[0].Message != "Accepted publickey for deploy" {
+		t.Fatalf("Message = %q", r[0].Message)
+	}
+	if ts := r[0].OrigTimestamp; ts.Year() != time.Now().Year() || ts.Month() != time.February || ts.Day() != 3 {
+		t.Fatalf("OrigTimestamp = %s, want Feb 3 of the current year", ts)
+	}
+}
+
+func TestGrokParser_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expr     string
+		patterns map[string]string
+		want     string
+	}{
+		{`%{NOPE:x}`, nil, "unknown pattern"},
+		{`%{LOOP}`, map[string]string{"LOOP": `a%{LOOP}`}, "nested deeper"},
+		{`%{WORD:x}(`, nil, "missing closing"},
+	}
+	for _, tt := range tests {
+		if _, err := NewGrokParser("bad", tt.expr, tt.patterns); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("NewGrokParser(%q) error = %v, want %q", tt.expr, err, tt.want)
+		}
+	}
+
+	if _, err := GrokParsers(GrokConfig{Parsers: map[string]string{"Logfmt": `%{WORD:x}`}}); err == nil {
+		t.Fatal("expected error for grok parser shadowing a built-in")
+	}
+}
+
+func TestProcessor_GrokSourceParser(t *testing.T) {
+	t.Parallel()
+
+	custom, err := GrokParsers(GrokConfig{
+		Parsers: map[string]string{"Legacy": `^%{LOGLEVEL:level}: %{GREEDYDATA:message}`},
+	})
+	if err != nil {
+		t.Fatalf("GrokParsers: %v", err)
+	}
+	parsers, err := SourceParsers(map[string]string{"stdin": "legacy"}, custom)
+	if err != nil {
+		t.Fatalf("SourceParsers: %v", err)
+	}
+
+	sink := &recordingSink{}
+	p := NewProcessor(sink, "stdin", Config{SourceParsers: parsers})
+	if p.ProcessEnvelope(model.IngestEnvelope{Line: "ERROR: disk full"}) == nil {
+		t.Fatal("grok line should be parsed")
+	}
+	if len(sink.records) != 1 || sink.records[0].Level != "ERROR" || sink.records[0].Message != "disk full" {
+		t.Fatalf("sink records = %+v", sink.records)
+	}
+}
@@ -0,0 +1,78 @@
+package ingest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// klogRegex matches the klog/glog header:
+//
+//	Lmmdd hh:mm:ss.uuuuuu threadid file:line] msg
+//
+// where L is one of I, W, E, F.
+var klogRegex = regexp.MustCompile(`^([IWEF])(\d{2})(\d{2}) (\d{2}:\d{2}:\d{2}(?:\.\d+)?)\s+(\d+) ([^\s:\]]+):(\d+)\] ?(.*)$`)
+
+// Attribute keys written by the klog parser (OTEL semantic conventions).
+const (
+	attrProcessPID   = "process.pid"
+	attrCodeFilepath = "code.filepath"
+	attrCodeLineno   = "code.lineno"
+)
+
+var klogSeverities = map[string]string{"I": "INFO", "W": "WARN", "E": "ERROR", "F": "FATAL"}
+
+// ParseKlogLine parses a Kubernetes klog (or glog) line. The thread id and
+// source location become attributes. Structured klog messages
+// (`"msg" key="value" ...`) have their key/value pairs split out as
+// attributes. klog omits the year and zone, so the timestamp is taken in
+// local time in the current year.
+// Returns nil when the line is not a klog line.
+func ParseKlogLine(line string) *model.LogRecord {
+	m := klogRegex.FindStringSubmatch(strings.TrimRight(line, "\r"))
+	if m == nil {
+		return nil
+	}
+
+	attributes := map[string]string{
+		attrProcessPID:   m[5],
+		attrCodeFilepath: m[6],
+		attrCodeLineno:   m[7],
+	}
+	message := m[8]
+	if quoted, err := strconv.QuotedPrefix(message); err == nil {
+		if pairs, _, ok := tokenizeLogfmt(message[len(quoted):]); ok {
+			message, _ = strconv.Unquote(quoted)
+			for _, p := range pairs {
+				if _, reserved := attributes[p.key]; !reserved {
+					attributes[p.key] = p.value
+				}
+			}
+		}
+	}
+
+	var origTimestamp time.Time
+	if ts, err := time.ParseInLocation("0102 15:04:05.999999999", m[2]+m[3]+" "+m[4], time.Local); err == nil {
+		origTimestamp = ts.AddDate(time.Now().Year(), 0, 0)
+	}
+
+	level := klogSeverities[m[1]]
+	app := ExtractApp(attributes)
+	if app == "" {
+		app = "default"
+	}
+
+	return &model.LogRecord{
+		Timestamp:     time.Now(),
+		OrigTimestamp: origTimestamp,
+		Level:         level,
+		LevelNum:      DefaultSeverityNumber(level),
+		Message:       SanitizeMessage(message),
+		RawLine:       line,
+		Attributes:    attributes,
+		App:           app,
+	}
+}
@@ -0,0 +1,53 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseKlogLine(t *testing.T) {
+	t.Parallel()
+
+	r := ParseKlogLine("E0102 15:04:05.123456    4711 reflector.go:138] k8s.io/client-go: failed to list *v1.Pod: timeout")
+	if r == nil {
+		t.Fatal("klog line should parse")
+	}
+	if r.Level != "ERROR" || r.Message != "k8s.io/client-go: failed to list *v1.Pod: timeout" {
+		t.Fatalf("level/message = %q/%q", r.Level, r.Message)
+	}
+	want := map[string]string{"process.pid": "4711", "code.filepath": "reflector.go", "code.lineno": "138"}
+	for k, v := range want {
+		if r.Attributes[k] != v {
+			t.Errorf("%s = %q, want %q", k, r.Attributes[k], v)
+		}
+	}
+	ts := r.OrigTimestamp
+	if ts.Year() != time.Now().Year() || ts.Month() != time.January || ts.Day() != 2 || ts.Hour() != 15 || ts.Nanosecond() != 123456000 {
+		t.Fatalf("OrigTimestamp = %s", ts)
+	}
+
+	if ParseKlogLine("level=info msg=hello user=1") != nil {
+		t.Fatal("logfmt line should not parse as klog")
+	}
+}
+
+func TestParseKlogLine_Structured(t *testing.T) {
+	t.Parallel()
+
+	r := ParseKlogLine(`I0215 08:00:01.000001       1 controller.go:42] "Successfully synced" key="kube-system/coredns" attempt=3`)
+	if r == nil || r.Level != "INFO" || r.Message != "Successfully synced" {
+		t.Fatalf("record = %+v", r)
+	}
+	if r.Attributes["key"] != "kube-system/coredns" || r.Attributes["attempt"] != "3" {
+		t.Fatalf("attributes = %+v", r.Attributes)
+	}
+}
+
+func TestParseAuto_Klog(t *testing.T) {
+	t.Parallel()
+
+	records := parseAuto(`W0301 12:00:00.000000 9 server.go:7] "Deprecated flag" flag="--insecure-port" replacement="none"`)
+	if len(records) != 1 || records[0].Level != "WARN" || records[0].Attributes["code.filepath"] != "server.go" {
+		t.Fatalf("records = %+v", records)
+	}
+}
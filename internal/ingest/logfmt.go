@@ -0,0 +1,182 @@
+package ingest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/logparse"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// logfmtMinPairs is how many key=value pairs a line needs before it is
+// treated as logfmt rather than free text that happens to contain "=".
+const logfmtMinPairs = 2
+
+var (
+	logfmtMessageKeys   = []string{"msg", "message"}
+	logfmtLevelKeys     = []string{"level", "lvl", "severity"}
+	logfmtTimestampKeys = []string{"ts", "time", "timestamp"}
+)
+
+// logfmtPair is one decoded key=value token. Bare keys have hasValue=false.
+type logfmtPair struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+// ParseLogfmtLine parses a logfmt line (key=value pairs, as written by
+// go-kit/log, zap's logfmt encoder, logrus, and Heroku). The level, ts, and
+// msg keys populate the record; every other pair becomes an attribute.
+// Returns nil when the line does not look like logfmt.
+func ParseLogfmtLine(line string) *model.LogRecord {
+	pairs, ok := decodeLogfmt(line)
+	if !ok {
+		return nil
+	}
+
+	attributes := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		if p.hasValue {
+			attributes[p.key] = p.value
+		} else {
+			attributes[p.key] = "true"
+		}
+	}
+
+	message := takeAttribute(attributes, logfmtMessageKeys...)
+	level := takeAttribute(attributes, logfmtLevelKeys...)
+	var origTimestamp time.Time
+	for _, key := range logfmtTimestampKeys {
+		if ts, ok := parseLogfmtTimestamp(attributes[key]); ok {
+			origTimestamp = ts
+			delete(attributes, key)
+			break
+		}
+	}
+
+	if message == "" {
+		message = line
+	}
+	message = SanitizeMessage(message)
+	if level == "" {
+		level = logparse.ExtractSeverityFromText(message)
+	}
+	normalizedSeverity := logparse.NormalizeSeverity(level)
+
+	app := ExtractApp(attributes)
+	if app == "" {
+		app = "default"
+	}
+
+	return &model.LogRecord{
+		Timestamp:     time.Now(),
+		OrigTimestamp: origTimestamp,
+		Level:         normalizedSeverity,
+		LevelNum:      DefaultSeverityNumber(normalizedSeverity),
+		Message:       message,
+		RawLine:       line,
+		Attributes:    attributes,
+		App:           app,
+	}
+}
+
+// takeAttribute removes and returns the first non-empty value among keys.
+func takeAttribute(attributes map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if v := attributes[key]; v != "" {
+			delete(attributes, key)
+			return v
+		}
+	}
+	return ""
+}
+
+// decodeLogfmt tokenizes a logfmt line. It reports false for anything that
+// is not well-formed logfmt or has fewer than logfmtMinPairs key=value pairs.
+func decodeLogfmt(line string) ([]logfmtPair, bool) {
+	pairs, withValue, ok := tokenizeLogfmt(line)
+	if !ok || withValue < logfmtMinPairs || withValue <= len(pairs)-withValue {
+		return nil, false
+	}
+	return pairs, true
+}
+
+// tokenizeLogfmt splits line into pairs and counts those with a value. It
+// reports false for anything that is not well-formed logfmt.
+func tokenizeLogfmt(line string) ([]logfmtPair, int, bool) {
+	var pairs []logfmtPair
+	withValue := 0
+	i, n := 0, len(line)
+	for {
+		for i < n && (line[i] == ' ' || line[i] == '\t') {
+			i++
+		}
+		if i >= n {
+			break
+		}
+
+		start := i
+		for i < n && line[i] > ' ' && line[i] != '=' && line[i] != '"' {
+			i++
+		}
+		if i == start {
+			return nil, 0, false // key cannot start with '=' or '"'
+		}
+		p := logfmtPair{key: line[start:i]}
+
+		if i < n && line[i] == '=' {
+			i++
+			p.hasValue = true
+			if i < n && line[i] == '"' {
+				end := i + 1
+				for end < n && line[end] != '"' {
+					if line[end] == '\\' {
+						end++
+					}
+					end++
+				}
+				if end >= n {
+					return nil, 0, false // unterminated quote
+				}
+				v, err := strconv.Unquote(line[i : end+1])
+				if err != nil {
+					return nil, 0, false
+				}
+				p.value = v
+				i = end + 1
+			} else {
+				vs := i
+				for i < n && line[i] != ' ' && line[i] != '\t' {
+					if line[i] == '"' {
+						return nil, 0, false
+					}
+					i++
+				}
+				p.value = line[vs:i]
+			}
+			withValue++
+		}
+		if i < n && line[i] != ' ' && line[i] != '\t' {
+			return nil, 0, false // junk directly after a value or key
+		}
+		pairs = append(pairs, p)
+	}
+	return pairs, withValue, true
+}
+
+// parseLogfmtTimestamp accepts RFC 3339 timestamps and Unix epoch seconds
+// (optionally fractional), the two forms logfmt emitters use.
+func parseLogfmtTimestamp(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return ts, true
+	}
+	if secs, err := strconv.ParseFloat(value, 64); err == nil && secs > 0 {
+		whole := int64(secs)
+		return time.Unix(whole, int64((secs-float64(whole))*1e9)), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,99 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestParseLogfmtLine_GoKit(t *testing.T) {
+	t.Parallel()
+
+	line := `ts=2026-02-18T10:22:23.123Z level=warn caller=worker.go:42 msg="queue depth high" depth=812 service.name=payments`
+	entry := ParseLogfmtLine(line)
+	if entry == nil {
+		t.Fatal("ParseLogfmtLine returned nil")
+	}
+	if entry.Level != "WARN" || entry.LevelNum != 13 {
+		t.Fatalf("Level = %q (%d), want WARN (13)", entry.Level, entry.LevelNum)
+	}
+	if entry.Message != "queue depth high" {
+		t.Fatalf("Message = %q", entry.Message)
+	}
+	want := time.Date(2026, 2, 18, 10, 22, 23, 123000000, time.UTC)
+	if !entry.OrigTimestamp.Equal(want) {
+		t.Fatalf("OrigTimestamp = %s, want %s", entry.OrigTimestamp, want)
+	}
+	if entry.Attributes["depth"] != "812" || entry.Attributes["caller"] != "worker.go:42" {
+		t.Fatalf("attributes = %v", entry.Attributes)
+	}
+	for _, key := range []string{"ts", "level", "msg"} {
+		if _, ok := entry.Attributes[key]; ok {
+			t.Fatalf("attribute %q should be promoted, not kept", key)
+		}
+	}
+	if entry.App != "payments" {
+		t.Fatalf("App = %q, want payments", entry.App)
+	}
+	if entry.RawLine != line {
+		t.Fatalf("RawLine = %q", entry.RawLine)
+	}
+}
+
+func TestParseLogfmtLine_EpochAndEscapes(t *testing.T) {
+	t.Parallel()
+
+	entry := ParseLogfmtLine(`ts=1739876543.5 lvl=error msg="bad \"input\"" retry`)
+	if entry == nil {
+		t.Fatal("ParseLogfmtLine returned nil")
+	}
+	if entry.Level != "ERROR" {
+		t.Fatalf("Level = %q, want ERROR", entry.Level)
+	}
+	if entry.Message != `bad "input"` {
+		t.Fatalf("Message = %q", entry.Message)
+	}
+	if entry.OrigTimestamp.Unix() != 1739876543 || entry.OrigTimestamp.Nanosecond() != 500000000 {
+		t.Fatalf("OrigTimestamp = %s", entry.OrigTimestamp)
+	}
+	if entry.Attributes["retry"] != "true" {
+		t.Fatalf("bare key should become true, got %q", entry.Attributes["retry"])
+	}
+}
+
+func TestParseLogfmtLine_RejectsNonLogfmt(t *testing.T) {
+	t.Parallel()
+
+	for _, line := range []string{
+		"plain text message",
+		"GET /health 200 took=5ms",
+		`msg="unterminated level=info`,
+		`{"level":"info","msg":"json"}`,
+		"a=1 b c d",
+		"",
+	} {
+		if entry := ParseLogfmtLine(line); entry != nil {
+			t.Errorf("ParseLogfmtLine(%q) = %+v, want nil", line, entry)
+		}
+	}
+}
+
+func TestProcessor_ProcessEnvelope_Logfmt(t *testing.T) {
+	t.Parallel()
+
+	sink := &recordingSink{}
+	p := NewProcessor(sink, "stdin")
+
+	result := p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", Line: `level=info msg="user login" service=auth host=node-7`})
+	if result == nil || result.Record == nil {
+		t.Fatal("expected a record for a logfmt line")
+	}
+	if len(sink.records) != 1 {
+		t.Fatalf("sink records = %d, want 1", len(sink.records))
+	}
+	rec := sink.records[0]
+	if rec.Source != "tcp" || rec.Service != "auth" || rec.Hostname != "node-7" {
+		t.Fatalf("record = source %q service %q host %q", rec.Source, rec.Service, rec.Hostname)
+	}
+}
@@ -0,0 +1,205 @@
+package ingest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+const (
+	// DefaultMultilinePattern matches indented lines and the usual stack
+	// trace markers (Java "Caused by:" and "... N more", Python tracebacks).
+	DefaultMultilinePattern = `^(?:[ \t]+\S|Caused by:|Traceback \(most recent call last\):|\.\.\. \d+ (?:more|common frames omitted))`
+	// DefaultMultilineMaxLines caps how many lines are folded into one record.
+	DefaultMultilineMaxLines = 500
+	// DefaultMultilineTimeout is how long a record waits for continuations.
+	DefaultMultilineTimeout = time.Second
+)
+
+// MultilineRule configures continuation-line joining for a source.
+type MultilineRule struct {
+	Pattern  string        // continuation lines; DefaultMultilinePattern when empty
+	MaxLines int           // continuation lines per record; DefaultMultilineMaxLines when 0
+	Timeout  time.Duration // wait for more continuations; DefaultMultilineTimeout when 0
+}
+
+// Multiline is a compiled MultilineRule.
+type Multiline struct {
+	continuation *regexp.Regexp
+	maxLines     int
+	timeout      time.Duration
+}
+
+// NewMultiline compiles rule, filling in defaults.
+func NewMultiline(rule MultilineRule) (*Multiline, error) {
+	pattern := rule.Pattern
+	if pattern == "" {
+		pattern = DefaultMultilinePattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("pattern: %w", err)
+	}
+	if rule.MaxLines < 0 {
+		return nil, fmt.Errorf("max-lines must not be negative")
+	}
+	if rule.Timeout < 0 {
+		return nil, fmt.Errorf("timeout must not be negative")
+	}
+	m := &Multiline{continuation: re, maxLines: rule.MaxLines, timeout: rule.Timeout}
+	if m.maxLines == 0 {
+		m.maxLines = DefaultMultilineMaxLines
+	}
+	if m.timeout == 0 {
+		m.timeout = DefaultMultilineTimeout
+	}
+	return m, nil
+}
+
+// MultilineRules compiles a source name -> rule map.
+func MultilineRules(rules map[string]MultilineRule) (map[string]*Multiline, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	sources := make([]string, 0, len(rules))
+	for source := range rules {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	out := make(map[string]*Multiline, len(rules))
+	for _, source := range sources {
+		m, err := NewMultiline(rules[source])
+		if err != nil {
+			return nil, fmt.Errorf("source %q: %w", source, err)
+		}
+		out[source] = m
+	}
+	return out, nil
+}
+
+// heldRecord is a parsed record waiting for continuation lines.
+type heldRecord struct {
+	record *model.LogRecord
+	raw    []string
+	lines  int  // continuation lines folded so far
+	done   acks // Done callbacks of the lines in the record
+	timer  *time.Timer
+}
+
+// fold appends a continuation line to the held record; text is the part
+// of line that goes into the message.
+func (h *heldRecord) fold(text, line string) {
+	h.record.Message += "\n" + SanitizeMessage(strings.TrimRight(text, "\r"))
+	h.raw = append(h.raw, strings.TrimRight(line, "\r"))
+	h.lines++
+}
+
+// continuationText returns the part of line continuation patterns see:
+// the content of a CRI line, or the whole line.
+func (p *Processor) continuationText(source, line string) string {
+	if joinsCRI(p.parserFor(source)) {
+		if entry, ok := splitCRILine(line); ok {
+			return entry.content
+		}
+	}
+	return line
+}
+
+// continues reports whether line should be folded into the record held
+// for source. Caller must hold p.mu.
+func (p *Processor) continues(source, line string) bool {
+	rule, h := p.multiline[source], p.held[source]
+	return rule != nil && h != nil && h.lines < rule.maxLines &&
+		rule.continuation.MatchString(p.continuationText(source, line))
+}
+
+// foldHeld appends line to the record held for source, which takes its
+// callbacks from done, and restarts its timeout. Caller must hold p.mu.
+func (p *Processor) foldHeld(source, line string, done *acks) {
+	h := p.held[source]
+	h.fold(p.continuationText(source, line), line)
+	h.done = append(h.done, *done...)
+	*done = nil
+	h.timer.Reset(p.multiline[source].timeout)
+}
+
+// hold keeps record back for continuation lines until the next record
+// from source, the rule's timeout, or Flush. It takes the callbacks in
+// done, which run once the record is stored.
+// Caller must hold p.mu.
+func (p *Processor) hold(source string, record *model.LogRecord, done *acks) {
+	h := &heldRecord{record: record, raw: []string{record.RawLine}, done: *done}
+	*done = nil
+	h.timer = time.AfterFunc(p.multiline[source].timeout, func() {
+		p.mu.Lock()
+		if p.held[source] != h {
+			p.mu.Unlock()
+			return
+		}
+		delete(p.held, source)
+		sink := p.sink
+		p.mu.Unlock()
+		h.store(sink)
+	})
+	if p.held == nil {
+		p.held = make(map[string]*heldRecord)
+	}
+	p.held[source] = h
+}
+
+// releaseHeld removes and returns the record held for source, if any.
+// Caller must hold p.mu.
+func (p *Processor) releaseHeld(source string) *heldRecord {
+	h := p.held[source]
+	if h == nil {
+		return nil
+	}
+	h.timer.Stop()
+	delete(p.held, source)
+	return h
+}
+
+// storeHeld stores the record held for source, if any. Caller must hold
+// p.mu; like storeRecords, the lock is released around sink.Add().
+func (p *Processor) storeHeld(source string) {
+	h := p.releaseHeld(source)
+	if h == nil {
+		return
+	}
+	sink := p.sink
+	p.mu.Unlock()
+	h.store(sink)
+	p.mu.Lock()
+}
+
+// store hands the finished record to sink, then acknowledges its lines.
+func (h *heldRecord) store(sink model.RecordSink) {
+	if len(h.raw) > 1 {
+		h.record.RawLine = strings.Join(h.raw, "\n")
+	}
+	if sink != nil {
+		sink.Add(h.record)
+	}
+	h.done.run()
+}
+
+// Flush stores every record still held for continuation lines. Call it
+// when the input ends. Safe for concurrent use.
+func (p *Processor) Flush() {
+	p.mu.Lock()
+	var held []*heldRecord
+	for source := range p.held {
+		held = append(held, p.releaseHeld(source))
+	}
+	sink := p.sink
+	p.mu.Unlock()
+
+	for _, h := range held {
+		h.store(sink)
+	}
+}
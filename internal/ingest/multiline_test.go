@@ -0,0 +1,134 @@
+package ingest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func newMultilineProcessor(t *testing.T, sink model.RecordSink, rule MultilineRule) *Processor {
+	t.Helper()
+	rules, err := MultilineRules(map[string]MultilineRule{"stdin": rule})
+	if err != nil {
+		t.Fatalf("MultilineRules: %v", err)
+	}
+	return NewProcessor(sink, "stdin", Config{Multiline: rules})
+}
+
+func TestProcessor_FoldsStackTraceIntoParent(t *testing.T) {
+	t.Parallel()
+
+	sink := &recordingSink{}
+	p := newMultilineProcessor(t, sink, MultilineRule{Timeout: time.Hour})
+
+	lines := []string{
+		`level=error msg="request failed"`,
+		`java.lang.IllegalStateException: boom`,
+		"\tat com.example.Handler.run(Handler.java:42)",
+		`Caused by: java.io.IOException: broken pipe`,
+		"\t... 12 more",
+		`level=info msg=next`,
+	}
+	if p.ProcessLine(lines[0]) == nil {
+		t.Fatal("held line should still return its record")
+	}
+	// The exception header does not parse, so it is folded in as well.
+	for _, line := range lines[1:] {
+		p.ProcessLine(line)
+	}
+	p.Flush()
+
+	if len(sink.records) != 2 {
+		t.Fatalf("stored %d records, want 2: %+v", len(sink.records), sink.records)
+	}
+	parent := sink.records[0]
+	want := "request failed\njava.lang.IllegalStateException: boom\n at com.example.Handler.run(Handler.java:42)\nCaused by: java.io.IOException: broken pipe\n ... 12 more"
+	if parent.Message != want {
+		t.Fatalf("message = %q, want %q", parent.Message, want)
+	}
+	if parent.RawLine != strings.Join(lines[:5], "\n") {
+		t.Fatalf("raw line = %q", parent.RawLine)
+	}
+	if parent.Level != "ERROR" || parent.Source != "stdin" {
+		t.Fatalf("parent = %+v", parent)
+	}
+	if sink.records[1].Message != "next" {
+		t.Fatalf("records = %+v", sink.records)
+	}
+}
+
+func TestProcessor_MultilineMaxLines(t *testing.T) {
+	t.Parallel()
+
+	sink := &recordingSink{}
+	p := newMultilineProcessor(t, sink, MultilineRule{MaxLines: 2, Timeout: time.Hour})
+
+	p.ProcessLine(`level=error msg=panic`)
+	for range 3 {
+		p.ProcessLine("  level=debug msg=frame")
+	}
+	p.Flush()
+
+	if len(sink.records) != 2 {
+		t.Fatalf("stored %d records, want 2", len(sink.records))
+	}
+	if got := sink.records[0].Message; got != "panic\n  level=debug msg=frame\n  level=debug msg=frame" {
+		t.Fatalf("message = %q", got)
+	}
+}
+
+func TestProcessor_MultilineOnlyConfiguredSources(t *testing.T) {
+	t.Parallel()
+
+	sink := &recordingSink{}
+	p := newMultilineProcessor(t, sink, MultilineRule{Timeout: time.Hour})
+
+	p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", Line: `level=error msg=boom`})
+	p.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", Line: "  level=info msg=indented"})
+	if len(sink.records) != 2 || sink.records[0].Message != "boom" {
+		t.Fatalf("tcp records = %+v, want 2 unjoined", sink.records)
+	}
+}
+
+type chanSink chan *model.LogRecord
+
+func (s chanSink) Add(record *model.LogRecord) { s <- record }
+
+func TestProcessor_MultilineTimeoutStoresHeldRecord(t *testing.T) {
+	t.Parallel()
+
+	sink := make(chanSink, 1)
+	p := newMultilineProcessor(t, sink, MultilineRule{Timeout: 10 * time.Millisecond})
+
+	p.ProcessLine(`level=error msg=boom`)
+	p.ProcessLine("  at main.go:12")
+
+	select {
+	case r := <-sink:
+		if r.Message != "boom\n  at main.go:12" {
+			t.Fatalf("message = %q", r.Message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("held record was not stored after the timeout")
+	}
+	p.Flush()
+	if len(sink) != 0 {
+		t.Fatal("Flush stored the record twice")
+	}
+}
+
+func TestNewMultiline_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, rule := range []MultilineRule{
+		{Pattern: "(unclosed"},
+		{MaxLines: -1},
+		{Timeout: -time.Second},
+	} {
+		if _, err := NewMultiline(rule); err == nil {
+			t.Fatalf("NewMultiline(%+v) should fail", rule)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package ingest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/logparse"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// DecodeOTLPLogsData parses a binary OTLP LogsData protobuf message into records.
+func DecodeOTLPLogsData(data []byte) ([]*model.LogRecord, error) {
+	var logsData logspb.LogsData
+	if err := proto.Unmarshal(data, &logsData); err != nil {
+		return nil, fmt.Errorf("decode OTLP LogsData: %w", err)
+	}
+	return RecordsFromResourceLogs(logsData.GetResourceLogs()), nil
+}
+
+// RecordsFromResourceLogs flattens OTLP resource/scope/log nesting into records,
+// with log attributes overriding scope attributes overriding resource attributes.
+func RecordsFromResourceLogs(resourceLogs []*logspb.ResourceLogs) []*model.LogRecord {
+	var records []*model.LogRecord
+	for _, rl := range resourceLogs {
+		resourceAttrs := OTLPResourceAttributes(rl.GetResource())
+		for _, sl := range rl.GetScopeLogs() {
+			scopeAttrs := OTLPScopeAttributes(resourceAttrs, sl.GetScope())
+			for _, lr := range sl.GetLogRecords() {
+				records = append(records, ConvertOTLPLogRecord(lr, scopeAttrs))
+			}
+		}
+	}
+	return records
+}
+
+// OTLPScopeAttributes layers instrumentation scope name, version, and
+// attributes over a copy of the resource attributes.
+func OTLPScopeAttributes(resourceAttrs map[string]string, scope *commonpb.InstrumentationScope) map[string]string {
+	scopeAttrs := CloneAttributes(resourceAttrs)
+	if scope != nil {
+		if scope.Name != "" {
+			scopeAttrs["otel.scope.name"] = scope.Name
+		}
+		if scope.Version != "" {
+			scopeAttrs["otel.scope.version"] = scope.Version
+		}
+		MergeOTLPKeyValues(scopeAttrs, scope.Attributes)
+	}
+	return scopeAttrs
+}
+
+// ConvertOTLPLogRecord converts an OTLP proto LogRecord into a model.LogRecord.
+// inherited contains merged resource + scope attributes (resource < scope priority).
+func ConvertOTLPLogRecord(lr *logspb.LogRecord, inherited map[string]string) *model.LogRecord {
+	receiveTime := time.Now()
+
+	attributes := CloneAttributes(inherited)
+	MergeOTLPKeyValues(attributes, lr.GetAttributes())
+
+	// Trace/span context
+	if len(lr.TraceId) > 0 {
+		attributes["trace.id"] = hex.EncodeToString(lr.TraceId)
+	}
+	if len(lr.SpanId) > 0 {
+		attributes["span.id"] = hex.EncodeToString(lr.SpanId)
+	}
+	if lr.Flags != 0 {
+		attributes["trace.flags"] = fmt.Sprintf("%d", lr.Flags)
+	}
+	if lr.DroppedAttributesCount > 0 {
+		attributes["otel.dropped_attributes_count"] = fmt.Sprintf("%d", lr.DroppedAttributesCount)
+	}
+
+	message := OTLPAnyValueString(lr.GetBody())
+	var bodyJSON string
+	if lr.GetBody().GetKvlistValue() != nil {
+		fields, _ := OTLPAnyValueJSON(lr.GetBody()).(map[string]any)
+		message, bodyJSON = structuredBody(fields)
+	}
+
+	rawLine := ""
+	if b, err := protojson.Marshal(lr); err == nil {
+		rawLine = string(b)
+	}
+	if message == "" {
+		message = rawLine
+	}
+	message = SanitizeMessage(message)
+
+	severityNumber := int(lr.SeverityNumber)
+	severity := lr.SeverityText
+	if severity == "" && severityNumber > 0 {
+		severity = SeverityFromNumber(severityNumber)
+	}
+	if severity == "" {
+		severity = "INFO"
+	}
+	normalizedSeverity := logparse.NormalizeSeverity(severity)
+	if severityNumber == 0 {
+		severityNumber = DefaultSeverityNumber(normalizedSeverity)
+	}
+
+	var origTimestamp time.Time
+	if lr.TimeUnixNano > 0 {
+		origTimestamp = time.Unix(0, int64(lr.TimeUnixNano))
+	} else if lr.ObservedTimeUnixNano > 0 {
+		origTimestamp = time.Unix(0, int64(lr.ObservedTimeUnixNano))
+	}
+
+	app := ExtractApp(attributes)
+	if app == "" {
+		app = "default"
+	}
+
+	return &model.LogRecord{
+		Timestamp:     receiveTime,
+		OrigTimestamp: origTimestamp,
+		Level:         normalizedSeverity,
+		LevelNum:      severityNumber,
+		Message:       message,
+		RawLine:       rawLine,
+		BodyJSON:      bodyJSON,
+		Attributes:    attributes,
+		Source:        "otlp",
+		App:           app,
+		Service:       ExtractService(attributes),
+		Hostname:      ExtractHostname(attributes),
+	}
+}
+
+// OTLPResourceAttributes extracts attributes from a Resource proto.
+func OTLPResourceAttributes(resource *resourcepb.Resource) map[string]string {
+	if resource == nil {
+		return map[string]string{}
+	}
+	attrs := make(map[string]string, len(resource.Attributes))
+	for _, kv := range resource.Attributes {
+		if kv.Key == "" {
+			continue
+		}
+		if v := OTLPAnyValueString(kv.Value); v != "" {
+			attrs[kv.Key] = v
+		}
+	}
+	return attrs
+}
+
+// MergeOTLPKeyValues merges proto KeyValue pairs into the dst map.
+func MergeOTLPKeyValues(dst map[string]string, kvs []*commonpb.KeyValue) {
+	for _, kv := range kvs {
+		if kv.Key == "" {
+			continue
+		}
+		if v := OTLPAnyValueString(kv.Value); v != "" {
+			dst[kv.Key] = v
+		}
+	}
+}
+
+// OTLPAnyValueString converts an OTLP AnyValue to a string representation.
+func OTLPAnyValueString(av *commonpb.AnyValue) string {
+	if av == nil {
+		return ""
+	}
+	switch v := av.Value.(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return fmt.Sprintf("%v", v.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return fmt.Sprintf("%d", v.IntValue)
+	case *commonpb.AnyValue_DoubleValue:
+		return fmt.Sprintf("%v", v.DoubleValue)
+	case *commonpb.AnyValue_BytesValue:
+		return hex.EncodeToString(v.BytesValue)
+	case *commonpb.AnyValue_ArrayValue:
+		if v.ArrayValue == nil {
+			return ""
+		}
+		parts := make([]string, 0, len(v.ArrayValue.Values))
+		for _, val := range v.ArrayValue.Values {
+			if s := OTLPAnyValueString(val); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ",")
+	case *commonpb.AnyValue_KvlistValue:
+		if v.KvlistValue == nil {
+			return ""
+		}
+		if b, err := protojson.Marshal(v.KvlistValue); err == nil {
+			return string(b)
+		}
+		return ""
+	default:
+		return ""
+	}
+}
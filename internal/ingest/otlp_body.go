@@ -0,0 +1,118 @@
+package ingest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+)
+
+// bodyMessageKeys are the structured body fields used as a record's
+// message, in order.
+var bodyMessageKeys = []string{"message", "msg", "body"}
+
+// structuredBody encodes a kvlist body as plain JSON and picks the record's
+// message: the first string field named in bodyMessageKeys, or the JSON.
+func structuredBody(body map[string]any) (message, bodyJSON string) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", ""
+	}
+	bodyJSON = string(data)
+	for _, key := range bodyMessageKeys {
+		if s, ok := body[key].(string); ok && s != "" {
+			return s, bodyJSON
+		}
+	}
+	return bodyJSON, bodyJSON
+}
+
+// OTLPAnyValueJSON converts an OTLP AnyValue into the plain value it
+// encodes: kvlists become maps and arrays slices, so it marshals to JSON
+// without the protobuf wrapper objects. Bytes become hex.
+func OTLPAnyValueJSON(av *commonpb.AnyValue) any {
+	if av == nil {
+		return nil
+	}
+	switch v := av.Value.(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return v.BoolValue
+	case *commonpb.AnyValue_IntValue:
+		return v.IntValue
+	case *commonpb.AnyValue_DoubleValue:
+		return v.DoubleValue
+	case *commonpb.AnyValue_BytesValue:
+		return hex.EncodeToString(v.BytesValue)
+	case *commonpb.AnyValue_ArrayValue:
+		values := make([]any, 0, len(v.ArrayValue.GetValues()))
+		for _, val := range v.ArrayValue.GetValues() {
+			values = append(values, OTLPAnyValueJSON(val))
+		}
+		return values
+	case *commonpb.AnyValue_KvlistValue:
+		fields := make(map[string]any, len(v.KvlistValue.GetValues()))
+		for _, kv := range v.KvlistValue.GetValues() {
+			fields[kv.GetKey()] = OTLPAnyValueJSON(kv.GetValue())
+		}
+		return fields
+	default:
+		return nil
+	}
+}
+
+// otelJSONAnyValue is OTLPAnyValueJSON for the OTLP/JSON encoding of an
+// AnyValue ({"kvlistValue": {"values": [...]}}). Values that are not
+// wrapped pass through unchanged.
+func otelJSONAnyValue(value any) any {
+	anyValue, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	if v, ok := anyValue["stringValue"]; ok {
+		return v
+	}
+	if v, ok := anyValue["boolValue"]; ok {
+		return v
+	}
+	if v, ok := anyValue["intValue"]; ok {
+		// OTLP/JSON encodes 64-bit integers as strings.
+		if s, ok := v.(string); ok {
+			if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return n
+			}
+		}
+		return v
+	}
+	if v, ok := anyValue["doubleValue"]; ok {
+		return v
+	}
+	if v, ok := anyValue["bytesValue"]; ok {
+		return v
+	}
+	if arrayValue, ok := anyValue["arrayValue"].(map[string]any); ok {
+		vals, _ := arrayValue["values"].([]any)
+		values := make([]any, 0, len(vals))
+		for _, val := range vals {
+			values = append(values, otelJSONAnyValue(val))
+		}
+		return values
+	}
+	if kvlistValue, ok := anyValue["kvlistValue"].(map[string]any); ok {
+		kvs, _ := kvlistValue["values"].([]any)
+		fields := make(map[string]any, len(kvs))
+		for _, item := range kvs {
+			kv, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			if key := ExtractStringField(kv, "key"); key != "" {
+				fields[key] = otelJSONAnyValue(kv["value"])
+			}
+		}
+		return fields
+	}
+	return value
+}
@@ -0,0 +1,55 @@
+package ingest
+
+import (
+	"encoding/json"
+	"testing"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+func TestConvertOTLPLogRecord_StructuredBody(t *testing.T) {
+	body := &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: []*commonpb.KeyValue{
+		stringKV("msg", "order placed"),
+		{Key: "order", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: []*commonpb.KeyValue{
+			{Key: "id", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: 42}}},
+			{Key: "items", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: []*commonpb.AnyValue{
+				{Value: &commonpb.AnyValue_StringValue{StringValue: "book"}},
+			}}}}},
+		}}}}},
+	}}}}
+
+	record := ConvertOTLPLogRecord(&logspb.LogRecord{Body: body}, nil)
+	if record.Message != "order placed" {
+		t.Errorf("Message = %q, want the body's msg field", record.Message)
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(record.BodyJSON), &got); err != nil {
+		t.Fatalf("BodyJSON %q: %v", record.BodyJSON, err)
+	}
+	order, _ := got["order"].(map[string]any)
+	if order["id"] != float64(42) || order["items"].([]any)[0] != "book" {
+		t.Errorf("BodyJSON = %s", record.BodyJSON)
+	}
+
+	// A scalar body stays the message, with no structured body.
+	scalar := ConvertOTLPLogRecord(&logspb.LogRecord{Body: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "plain"}}}, nil)
+	if scalar.Message != "plain" || scalar.BodyJSON != "" {
+		t.Errorf("scalar body = %q/%q", scalar.Message, scalar.BodyJSON)
+	}
+}
+
+func TestParseJSONLogEntry_OTELStructuredBody(t *testing.T) {
+	line := `{"body":{"kvlistValue":{"values":[{"key":"user","value":{"stringValue":"ana"}},{"key":"attempts","value":{"intValue":"3"}}]}},"attributes":[]}`
+	entry := ParseJSONLogEntry(line)
+	if entry == nil {
+		t.Fatal("expected a record")
+	}
+	if entry.BodyJSON != `{"attempts":3,"user":"ana"}` {
+		t.Errorf("BodyJSON = %s", entry.BodyJSON)
+	}
+	// Without a message field the JSON is the message.
+	if entry.Message != entry.BodyJSON {
+		t.Errorf("Message = %q, want the body JSON", entry.Message)
+	}
+}
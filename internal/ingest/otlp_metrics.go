@@ -0,0 +1,140 @@
+package ingest
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// Metric kinds stored with each sample.
+const (
+	MetricKindGauge     = "gauge"
+	MetricKindSum       = "sum"
+	MetricKindCounter   = "counter" // monotonic sum
+	MetricKindHistogram = "histogram"
+	MetricKindSummary   = "summary"
+)
+
+// DecodeOTLPMetricsData parses a binary OTLP MetricsData protobuf message
+// into samples.
+func DecodeOTLPMetricsData(data []byte) ([]*model.MetricSample, error) {
+	var metricsData metricspb.MetricsData
+	if err := proto.Unmarshal(data, &metricsData); err != nil {
+		return nil, fmt.Errorf("decode OTLP MetricsData: %w", err)
+	}
+	return SamplesFromResourceMetrics(metricsData.GetResourceMetrics()), nil
+}
+
+// SamplesFromResourceMetrics flattens OTLP resource/scope/metric nesting
+// into samples, with point attributes overriding scope attributes
+// overriding resource attributes.
+//
+// Gauges and sums give one sample per point. Histograms, exponential
+// histograms, and summaries give <name>_count and <name>_sum samples;
+// summaries also give one <name> sample per quantile, labelled "quantile".
+// Bucket counts are not kept. Points flagged as having no recorded value
+// and NaN values are skipped.
+func SamplesFromResourceMetrics(resourceMetrics []*metricspb.ResourceMetrics) []*model.MetricSample {
+	var samples []*model.MetricSample
+	for _, rm := range resourceMetrics {
+		resourceAttrs := OTLPResourceAttributes(rm.GetResource())
+		for _, sm := range rm.GetScopeMetrics() {
+			scopeAttrs := OTLPScopeAttributes(resourceAttrs, sm.GetScope())
+			for _, m := range sm.GetMetrics() {
+				samples = append(samples, ConvertOTLPMetric(m, scopeAttrs)...)
+			}
+		}
+	}
+	return samples
+}
+
+// ConvertOTLPMetric converts the data points of one OTLP metric into samples.
+// inherited contains merged resource + scope attributes.
+func ConvertOTLPMetric(m *metricspb.Metric, inherited map[string]string) []*model.MetricSample {
+	receiveTime := time.Now()
+	var samples []*model.MetricSample
+	add := func(name, kind string, value float64, timeUnixNano uint64, flags uint32, attrs []*commonpb.KeyValue, extra map[string]string) {
+		if flags&uint32(metricspb.DataPointFlags_DATA_POINT_FLAGS_NO_RECORDED_VALUE_MASK) != 0 || math.IsNaN(value) {
+			return
+		}
+		labels := CloneAttributes(inherited)
+		MergeOTLPKeyValues(labels, attrs)
+		for k, v := range extra {
+			labels[k] = v
+		}
+		ts := receiveTime
+		if timeUnixNano > 0 {
+			ts = time.Unix(0, int64(timeUnixNano))
+		}
+		app := ExtractApp(labels)
+		if app == "" {
+			app = "default"
+		}
+		samples = append(samples, &model.MetricSample{
+			Timestamp: ts,
+			Name:      name,
+			Kind:      kind,
+			Unit:      m.GetUnit(),
+			Value:     value,
+			Labels:    labels,
+			Source:    "otlp",
+			App:       app,
+			Service:   ExtractService(labels),
+		})
+	}
+
+	name := m.GetName()
+	switch data := m.GetData().(type) {
+	case *metricspb.Metric_Gauge:
+		for _, dp := range data.Gauge.GetDataPoints() {
+			add(name, MetricKindGauge, numberValue(dp), dp.GetTimeUnixNano(), dp.GetFlags(), dp.GetAttributes(), nil)
+		}
+	case *metricspb.Metric_Sum:
+		kind := MetricKindSum
+		if data.Sum.GetIsMonotonic() {
+			kind = MetricKindCounter
+		}
+		for _, dp := range data.Sum.GetDataPoints() {
+			add(name, kind, numberValue(dp), dp.GetTimeUnixNano(), dp.GetFlags(), dp.GetAttributes(), nil)
+		}
+	case *metricspb.Metric_Histogram:
+		for _, dp := range data.Histogram.GetDataPoints() {
+			add(name+"_count", MetricKindHistogram, float64(dp.GetCount()), dp.GetTimeUnixNano(), dp.GetFlags(), dp.GetAttributes(), nil)
+			if dp.Sum != nil {
+				add(name+"_sum", MetricKindHistogram, dp.GetSum(), dp.GetTimeUnixNano(), dp.GetFlags(), dp.GetAttributes(), nil)
+			}
+		}
+	case *metricspb.Metric_ExponentialHistogram:
+		for _, dp := range data.ExponentialHistogram.GetDataPoints() {
+			add(name+"_count", MetricKindHistogram, float64(dp.GetCount()), dp.GetTimeUnixNano(), dp.GetFlags(), dp.GetAttributes(), nil)
+			if dp.Sum != nil {
+				add(name+"_sum", MetricKindHistogram, dp.GetSum(), dp.GetTimeUnixNano(), dp.GetFlags(), dp.GetAttributes(), nil)
+			}
+		}
+	case *metricspb.Metric_Summary:
+		for _, dp := range data.Summary.GetDataPoints() {
+			add(name+"_count", MetricKindSummary, float64(dp.GetCount()), dp.GetTimeUnixNano(), dp.GetFlags(), dp.GetAttributes(), nil)
+			add(name+"_sum", MetricKindSummary, dp.GetSum(), dp.GetTimeUnixNano(), dp.GetFlags(), dp.GetAttributes(), nil)
+			for _, q := range dp.GetQuantileValues() {
+				quantile := map[string]string{"quantile": strconv.FormatFloat(q.GetQuantile(), 'g', -1, 64)}
+				add(name, MetricKindSummary, q.GetValue(), dp.GetTimeUnixNano(), dp.GetFlags(), dp.GetAttributes(), quantile)
+			}
+		}
+	}
+	return samples
+}
+
+// numberValue returns a number data point's value as a float64.
+func numberValue(dp *metricspb.NumberDataPoint) float64 {
+	if v, ok := dp.GetValue().(*metricspb.NumberDataPoint_AsInt); ok {
+		return float64(v.AsInt)
+	}
+	return dp.GetAsDouble()
+}
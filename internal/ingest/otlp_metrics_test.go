@@ -0,0 +1,75 @@
+package ingest
+
+import (
+	"testing"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func stringKV(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+func TestDecodeOTLPMetricsData(t *testing.T) {
+	sum := 12.5
+	data, err := proto.Marshal(&metricspb.MetricsData{ResourceMetrics: []*metricspb.ResourceMetrics{{
+		Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{stringKV("service.name", "api")}},
+		ScopeMetrics: []*metricspb.ScopeMetrics{{Metrics: []*metricspb.Metric{
+			{Name: "queue.depth", Unit: "1", Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: []*metricspb.NumberDataPoint{
+				{TimeUnixNano: 1700000000000000000, Value: &metricspb.NumberDataPoint_AsInt{AsInt: 7}, Attributes: []*commonpb.KeyValue{stringKV("queue", "mail")}},
+				{TimeUnixNano: 1700000000000000000, Flags: uint32(metricspb.DataPointFlags_DATA_POINT_FLAGS_NO_RECORDED_VALUE_MASK)},
+			}}}},
+			{Name: "requests", Data: &metricspb.Metric_Sum{Sum: &metricspb.Sum{IsMonotonic: true, DataPoints: []*metricspb.NumberDataPoint{
+				{Value: &metricspb.NumberDataPoint_AsDouble{AsDouble: 3}},
+			}}}},
+			{Name: "latency", Unit: "ms", Data: &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{DataPoints: []*metricspb.HistogramDataPoint{
+				{Count: 4, Sum: &sum, BucketCounts: []uint64{1, 3}, ExplicitBounds: []float64{5}},
+			}}}},
+			{Name: "rpc", Data: &metricspb.Metric_Summary{Summary: &metricspb.Summary{DataPoints: []*metricspb.SummaryDataPoint{
+				{Count: 2, Sum: 9, QuantileValues: []*metricspb.SummaryDataPoint_ValueAtQuantile{{Quantile: 0.99, Value: 8}}},
+			}}}},
+		}}},
+	}}})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	samples, err := DecodeOTLPMetricsData(data)
+	if err != nil {
+		t.Fatalf("DecodeOTLPMetricsData: %v", err)
+	}
+	type want struct {
+		name, kind string
+		value      float64
+	}
+	wants := []want{
+		{"queue.depth", MetricKindGauge, 7},
+		{"requests", MetricKindCounter, 3},
+		{"latency_count", MetricKindHistogram, 4},
+		{"latency_sum", MetricKindHistogram, 12.5},
+		{"rpc_count", MetricKindSummary, 2},
+		{"rpc_sum", MetricKindSummary, 9},
+		{"rpc", MetricKindSummary, 8},
+	}
+	if len(samples) != len(wants) {
+		t.Fatalf("got %d samples, want %d", len(samples), len(wants))
+	}
+	for i, w := range wants {
+		got := samples[i]
+		if got.Name != w.name || got.Kind != w.kind || got.Value != w.value {
+			t.Errorf("sample %d = %s/%s/%v, want %s/%s/%v", i, got.Name, got.Kind, got.Value, w.name, w.kind, w.value)
+		}
+		if got.Service != "api" || got.Labels["service.name"] != "api" || got.Source != "otlp" || got.App == "" {
+			t.Errorf("sample %d origin = %q/%v/%q/%q", i, got.Service, got.Labels, got.Source, got.App)
+		}
+	}
+	if samples[0].Labels["queue"] != "mail" || samples[0].Unit != "1" || samples[0].Timestamp.UnixNano() != 1700000000000000000 {
+		t.Errorf("gauge sample = %+v", samples[0])
+	}
+	if samples[6].Labels["quantile"] != "0.99" {
+		t.Errorf("quantile label = %q, want 0.99", samples[6].Labels["quantile"])
+	}
+}
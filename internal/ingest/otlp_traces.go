@@ -0,0 +1,92 @@
+package ingest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// DecodeOTLPTracesData parses a binary OTLP TracesData protobuf message
+// into spans.
+func DecodeOTLPTracesData(data []byte) ([]*model.Span, error) {
+	var tracesData tracepb.TracesData
+	if err := proto.Unmarshal(data, &tracesData); err != nil {
+		return nil, fmt.Errorf("decode OTLP TracesData: %w", err)
+	}
+	return SpansFromResourceSpans(tracesData.GetResourceSpans()), nil
+}
+
+// SpansFromResourceSpans flattens OTLP resource/scope/span nesting into
+// spans, with span attributes overriding scope attributes overriding
+// resource attributes. Spans without a trace or span id are skipped; span
+// events and links are not kept.
+func SpansFromResourceSpans(resourceSpans []*tracepb.ResourceSpans) []*model.Span {
+	var spans []*model.Span
+	for _, rs := range resourceSpans {
+		resourceAttrs := OTLPResourceAttributes(rs.GetResource())
+		for _, ss := range rs.GetScopeSpans() {
+			scopeAttrs := OTLPScopeAttributes(resourceAttrs, ss.GetScope())
+			for _, sp := range ss.GetSpans() {
+				if span := ConvertOTLPSpan(sp, scopeAttrs); span != nil {
+					spans = append(spans, span)
+				}
+			}
+		}
+	}
+	return spans
+}
+
+// ConvertOTLPSpan converts one OTLP span, or returns nil when it has no
+// trace or span id. inherited contains merged resource + scope attributes.
+func ConvertOTLPSpan(sp *tracepb.Span, inherited map[string]string) *model.Span {
+	if len(sp.GetTraceId()) == 0 || len(sp.GetSpanId()) == 0 {
+		return nil
+	}
+	attrs := CloneAttributes(inherited)
+	MergeOTLPKeyValues(attrs, sp.GetAttributes())
+
+	span := &model.Span{
+		TraceID:       hex.EncodeToString(sp.GetTraceId()),
+		SpanID:        hex.EncodeToString(sp.GetSpanId()),
+		ParentSpanID:  hex.EncodeToString(sp.GetParentSpanId()),
+		Name:          sp.GetName(),
+		Kind:          spanKind(sp.GetKind()),
+		StatusCode:    spanStatus(sp.GetStatus().GetCode()),
+		StatusMessage: sp.GetStatus().GetMessage(),
+		Attributes:    attrs,
+		Service:       ExtractService(attrs),
+		App:           ExtractApp(attrs),
+	}
+	if span.App == "" {
+		span.App = "default"
+	}
+	if ns := sp.GetStartTimeUnixNano(); ns > 0 {
+		span.StartTime = time.Unix(0, int64(ns))
+	} else {
+		span.StartTime = time.Now()
+	}
+	if ns := sp.GetEndTimeUnixNano(); ns > 0 {
+		span.EndTime = time.Unix(0, int64(ns))
+	}
+	return span
+}
+
+// spanKind returns the lowercase name of an OTLP span kind ("server"), or
+// "" when unspecified.
+func spanKind(kind tracepb.Span_SpanKind) string {
+	if kind == tracepb.Span_SPAN_KIND_UNSPECIFIED {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(kind.String(), "SPAN_KIND_"))
+}
+
+// spanStatus returns the lowercase name of an OTLP status code ("error").
+func spanStatus(code tracepb.Status_StatusCode) string {
+	return strings.ToLower(strings.TrimPrefix(code.String(), "STATUS_CODE_"))
+}
@@ -0,0 +1,65 @@
+package ingest
+
+import (
+	"testing"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeOTLPTracesData(t *testing.T) {
+	traceID := []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+	data, err := proto.Marshal(&tracepb.TracesData{ResourceSpans: []*tracepb.ResourceSpans{{
+		Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{stringKV("service.name", "api")}},
+		ScopeSpans: []*tracepb.ScopeSpans{{Spans: []*tracepb.Span{
+			{
+				TraceId:           traceID,
+				SpanId:            []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Name:              "GET /orders",
+				Kind:              tracepb.Span_SPAN_KIND_SERVER,
+				StartTimeUnixNano: 1700000000000000000,
+				EndTimeUnixNano:   1700000000250000000,
+				Status:            &tracepb.Status{Code: tracepb.Status_STATUS_CODE_ERROR, Message: "timeout"},
+				Attributes:        []*commonpb.KeyValue{stringKV("http.route", "/orders")},
+			},
+			{
+				TraceId:           traceID,
+				SpanId:            []byte{9, 9, 9, 9, 9, 9, 9, 9},
+				ParentSpanId:      []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Name:              "SELECT orders",
+				StartTimeUnixNano: 1700000000010000000,
+			},
+			{Name: "no ids"},
+		}}},
+	}}})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	spans, err := DecodeOTLPTracesData(data)
+	if err != nil {
+		t.Fatalf("DecodeOTLPTracesData: %v", err)
+	}
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2 (the span without ids is skipped)", len(spans))
+	}
+	root := spans[0]
+	if root.TraceID != "00112233445566778899aabbccddeeff" || root.SpanID != "0102030405060708" || root.ParentSpanID != "" {
+		t.Errorf("root ids = %q/%q/%q", root.TraceID, root.SpanID, root.ParentSpanID)
+	}
+	if root.Kind != "server" || root.StatusCode != "error" || root.StatusMessage != "timeout" {
+		t.Errorf("root kind/status = %q/%q/%q", root.Kind, root.StatusCode, root.StatusMessage)
+	}
+	if got := root.EndTime.Sub(root.StartTime); got.Milliseconds() != 250 {
+		t.Errorf("root duration = %v, want 250ms", got)
+	}
+	if root.Service != "api" || root.Attributes["http.route"] != "/orders" || root.App == "" {
+		t.Errorf("root origin = %q/%v/%q", root.Service, root.Attributes, root.App)
+	}
+	child := spans[1]
+	if child.ParentSpanID != "0102030405060708" || child.Kind != "" || child.StatusCode != "unset" || !child.EndTime.IsZero() {
+		t.Errorf("child = %+v", child)
+	}
+}
@@ -0,0 +1,225 @@
+package ingest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/logparse"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/timestamp"
+)
+
+// Built-in line parser names, selectable per source with source-parsers.
+const (
+	ParserAuto    = "auto"     // OTEL JSON, then CEF/LEEF, syslog, klog, and logfmt
+	ParserOTEL    = "otel"     // OTEL JSON only
+	ParserLogfmt  = "logfmt"   // key=value pairs
+	ParserAccess  = "access"   // Apache/nginx access logs
+	ParserKlog    = "klog"     // Kubernetes klog/glog
+	ParserCEF     = "cef"      // ArcSight Common Event Format
+	ParserLEEF    = "leef"     // IBM QRadar Log Event Extended Format
+	ParserCRI     = "cri"      // containerd/CRI-O container logs
+	ParserAppJSON = "app-json" // OTEL JSON, then pino/winston/bunyan/zap JSON
+	ParserSyslog  = "syslog"   // RFC 5424/3164 syslog, Heroku Logplex
+)
+
+// LineParser turns one text line into records. It returns nil when the line
+// is not in the parser's format; the line is then dropped.
+type LineParser interface {
+	Name() string
+	Parse(line string) []*model.LogRecord
+}
+
+type lineParserFunc struct {
+	name  string
+	parse func(line string) []*model.LogRecord
+}
+
+func (p lineParserFunc) Name() string                         { return p.name }
+func (p lineParserFunc) Parse(line string) []*model.LogRecord { return p.parse(line) }
+
+// singleRecord adapts a one-record parse function to LineParser.Parse.
+func singleRecord(parse func(string) *model.LogRecord) func(string) []*model.LogRecord {
+	return func(line string) []*model.LogRecord {
+		if record := parse(line); record != nil {
+			return []*model.LogRecord{record}
+		}
+		return nil
+	}
+}
+
+var builtinParsers = map[string]LineParser{
+	ParserAuto:    lineParserFunc{name: ParserAuto, parse: parseAuto},
+	ParserOTEL:    lineParserFunc{name: ParserOTEL, parse: ParseJSONLogEntries},
+	ParserLogfmt:  lineParserFunc{name: ParserLogfmt, parse: singleRecord(ParseLogfmtLine)},
+	ParserAccess:  lineParserFunc{name: ParserAccess, parse: singleRecord(ParseAccessLogLine)},
+	ParserKlog:    lineParserFunc{name: ParserKlog, parse: singleRecord(ParseKlogLine)},
+	ParserCEF:     lineParserFunc{name: ParserCEF, parse: singleRecord(ParseCEFLine)},
+	ParserLEEF:    lineParserFunc{name: ParserLEEF, parse: singleRecord(ParseLEEFLine)},
+	ParserCRI:     lineParserFunc{name: ParserCRI, parse: ParseCRILine},
+	ParserAppJSON: lineParserFunc{name: ParserAppJSON, parse: ParseAppJSONLine},
+	ParserSyslog:  lineParserFunc{name: ParserSyslog, parse: singleRecord(ParseSyslogLine)},
+}
+
+// parseAuto is the default: OTEL JSON, falling back to CEF/LEEF, syslog,
+// klog, and then logfmt. The CEF/LEEF, syslog, and klog headers are strict
+// enough to try before logfmt, which would otherwise take those lines with
+// an UNKNOWN level. CEF/LEEF go first as they usually arrive behind a
+// syslog header.
+func parseAuto(line string) []*model.LogRecord {
+	if records := ParseJSONLogEntries(line); len(records) > 0 {
+		return records
+	}
+	if record := ParseCEFLine(line); record != nil {
+		return []*model.LogRecord{record}
+	}
+	if record := ParseLEEFLine(line); record != nil {
+		return []*model.LogRecord{record}
+	}
+	if record := ParseSyslogLine(line); record != nil {
+		return []*model.LogRecord{record}
+	}
+	if record := ParseKlogLine(line); record != nil {
+		return []*model.LogRecord{record}
+	}
+	if record := ParseLogfmtLine(line); record != nil {
+		return []*model.LogRecord{record}
+	}
+	return nil
+}
+
+// ParserByName returns a built-in line parser.
+func ParserByName(name string) (LineParser, error) {
+	if p, ok := builtinParsers[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("unknown parser %q (available: %s)", name, strings.Join(ParserNames(), ", "))
+}
+
+// ParserNames lists the built-in parser names.
+func ParserNames() []string {
+	names := make([]string, 0, len(builtinParsers))
+	for name := range builtinParsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SourceParsers resolves a source name -> parser name map. Names are looked
+// up in custom (user-defined parsers such as grok) and then the built-ins.
+func SourceParsers(names map[string]string, custom map[string]LineParser) (map[string]LineParser, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]LineParser, len(names))
+	for source, name := range names {
+		if p, ok := custom[strings.ToLower(strings.TrimSpace(name))]; ok {
+			out[source] = p
+			continue
+		}
+		p, err := ParserByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("source %q: %w", source, err)
+		}
+		out[source] = p
+	}
+	return out, nil
+}
+
+// acceptsJSON reports whether lines for p may be multi-line JSON objects
+// that need accumulating before parsing.
+func acceptsJSON(p LineParser) bool {
+	if e, ok := p.(*RegexExtractor); ok {
+		return acceptsJSON(e.fallback)
+	}
+	switch p.Name() {
+	case ParserAuto, ParserOTEL, ParserAppJSON:
+		return true
+	}
+	return false
+}
+
+// Field names with a meaning beyond "attribute" for parsers that extract
+// named fields.
+var (
+	fieldMessageKeys   = []string{"message", "msg"}
+	fieldLevelKeys     = []string{"level", "severity", "loglevel"}
+	fieldTimestampKeys = []string{"timestamp", "time", "ts"}
+)
+
+var fieldTimestampParser = timestamp.NewParser()
+
+// SetTimestampLayouts adds Go time layouts (see timestamp.Layout) that
+// parsers try, before the built-in ones, when reading a timestamp field.
+// It is not safe to call while lines are being processed.
+func SetTimestampLayouts(layouts []string) {
+	fieldTimestampParser = timestamp.NewParser(layouts...)
+}
+
+// recordFromFields builds a record from named fields extracted from line:
+// message, level, and timestamp fill the record and the rest become
+// attributes. Used by parsers that extract fields by name (grok, regex
+// extractors).
+func recordFromFields(line string, attributes map[string]string) *model.LogRecord {
+	message := takeAttribute(attributes, fieldMessageKeys...)
+	level := takeAttribute(attributes, fieldLevelKeys...)
+	var origTimestamp time.Time
+	for _, key := range fieldTimestampKeys {
+		if ts, ok := parseFieldTimestamp(attributes[key]); ok {
+			origTimestamp = ts
+			delete(attributes, key)
+			break
+		}
+	}
+
+	if message == "" {
+		message = line
+	}
+	message = SanitizeMessage(message)
+	if level == "" {
+		level = logparse.ExtractSeverityFromText(message)
+	}
+	normalizedSeverity := logparse.NormalizeSeverity(level)
+
+	app := ExtractApp(attributes)
+	if app == "" {
+		app = "default"
+	}
+
+	return &model.LogRecord{
+		Timestamp:     time.Now(),
+		OrigTimestamp: origTimestamp,
+		Level:         normalizedSeverity,
+		LevelNum:      DefaultSeverityNumber(normalizedSeverity),
+		Message:       message,
+		RawLine:       line,
+		Attributes:    attributes,
+		App:           app,
+	}
+}
+
+// parseFieldTimestamp parses an extracted timestamp: RFC 3339, epoch seconds,
+// the access log layout, or any layout the timestamp package knows.
+// Syslog-style values without a year get the current year.
+func parseFieldTimestamp(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if ts, ok := parseLogfmtTimestamp(value); ok {
+		return ts, true
+	}
+	if ts, err := time.Parse(accessLogTimeLayout, value); err == nil {
+		return ts, true
+	}
+	ts, ok := fieldTimestampParser.ParseTimestamp(value)
+	if !ok {
+		return time.Time{}, false
+	}
+	if ts.Year() == 0 {
+		ts = ts.AddDate(time.Now().Year(), 0, 0)
+	}
+	return ts, true
+}
@@ -0,0 +1,29 @@
+package ingest
+
+import (
+	"github.com/tinytelemetry/tiny-telemetry/internal/drain3"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// PatternSink forwards records to the next sink and mines their message
+// templates on the way.
+type PatternSink struct {
+	next  model.RecordSink
+	miner *drain3.Miner
+}
+
+// NewPatternSink wraps next, feeding every record to miner.
+func NewPatternSink(next model.RecordSink, miner *drain3.Miner) *PatternSink {
+	return &PatternSink{next: next, miner: miner}
+}
+
+// Add mines record and forwards it to the next sink.
+func (s *PatternSink) Add(record *model.LogRecord) {
+	if record == nil {
+		return
+	}
+	s.miner.Add(record)
+	if s.next != nil {
+		s.next.Add(record)
+	}
+}
@@ -18,6 +18,13 @@ type Processor struct {
 	mu         sync.Mutex
 	sink       model.RecordSink
 	sourceName string
+	parsers    map[string]LineParser // per-source overrides of the auto parser
+	multiline  map[string]*Multiline // per-source continuation-line rules
+
+	// Records waiting for continuation lines, keyed by source
+	held map[string]*heldRecord
+	// Partial CRI lines waiting for their final part, keyed by source
+	criPartials map[string]*criPartial
 
 	// JSON accumulation for multi-line JSON support
 	jsonBuffer   strings.Builder
@@ -31,15 +38,39 @@ type Processor struct {
 
 func (p *Processor) Name() string { return ProcessorNameOTEL }
 
+// Config holds optional Processor settings.
+type Config struct {
+	// SourceParsers selects the line parser per source name ("tcp",
+	// "stdin"). Sources not listed use the auto parser.
+	SourceParsers map[string]LineParser
+	// Multiline folds continuation lines (stack traces) into the record
+	// before them, per source name. Sources not listed are not joined.
+	Multiline map[string]*Multiline
+}
+
 // NewProcessor creates a new log processor.
 func NewProcessor(
 	sink model.RecordSink,
 	sourceName string,
+	conf ...Config,
 ) *Processor {
-	return &Processor{
+	p := &Processor{
 		sink:       sink,
 		sourceName: sourceName,
 	}
+	if len(conf) > 0 {
+		p.parsers = conf[0].SourceParsers
+		p.multiline = conf[0].Multiline
+	}
+	return p
+}
+
+// parserFor returns the line parser configured for source.
+func (p *Processor) parserFor(source string) LineParser {
+	if parser, ok := p.parsers[source]; ok {
+		return parser
+	}
+	return builtinParsers[ParserAuto]
 }
 
 // ProcessResult holds the result of processing a log line.
@@ -59,22 +90,51 @@ func (p *Processor) ProcessLine(line string) *ProcessResult {
 
 // ProcessEnvelope processes one source-tagged line and returns the parsed entry.
 // Returns nil if the line is being accumulated as part of a multi-line JSON object.
+// env.Done is called once the line's record has been handed to the sink:
+// on return, or, for a partial CRI line or a record held for continuation
+// lines, when the held record is stored. A line held for JSON accumulation
+// counts as handled.
 // Safe for concurrent use.
 func (p *Processor) ProcessEnvelope(env model.IngestEnvelope) *ProcessResult {
+	var done acks
+	if env.Done != nil {
+		done = acks{env.Done}
+	}
+	defer func() { done.run() }()
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	source := env.Source
+	if source == "" {
+		source = p.sourceName
+	}
+
+	// Binary OTLP frames skip text parsing and JSON accumulation entirely.
+	if len(env.OTLP) > 0 {
+		return p.processOTLP(env.OTLP, source)
+	}
+
 	if env.Line == "" {
 		return nil
 	}
 
-	source := env.Source
-	if source == "" {
-		source = p.sourceName
+	line := env.Line
+	parser := p.parserFor(source)
+	if joinsCRI(parser) {
+		var complete bool
+		if line, complete = p.joinCRI(source, line, &done); !complete {
+			return nil
+		}
+	}
+
+	// Fold continuation lines into the record held for this source.
+	if !p.inJsonObject && p.continues(source, line) {
+		p.foldHeld(source, line, &done)
+		return nil
 	}
 
 	// Handle multi-line JSON accumulation
-	if p.tryAccumulateJSON(env.Line, source) {
+	if acceptsJSON(parser) && p.tryAccumulateJSON(line, source, &done) {
 		// If accumulation completed a JSON object, return its result
 		if p.lastResult != nil {
 			result := p.lastResult
@@ -84,28 +144,61 @@ func (p *Processor) ProcessEnvelope(env model.IngestEnvelope) *ProcessResult {
 		return nil
 	}
 
-	return p.processEntry(env.Line, source)
+	return p.processEntry(line, source, &done)
+}
+
+// processEntry parses a line with the source's parser, enriches it, and stores it.
+// With a multiline rule for the source, a single record is held for
+// continuation lines instead, and lines the parser rejects (an exception
+// header, say) are folded into the held record, which takes the callbacks
+// in done. Caller must hold p.mu.
+func (p *Processor) processEntry(line, source string, done *acks) *ProcessResult {
+	records := p.parserFor(source).Parse(line)
+	if h := p.held[source]; h != nil {
+		if len(records) == 0 && h.lines < p.multiline[source].maxLines {
+			p.foldHeld(source, line, done)
+			return nil
+		}
+		p.storeHeld(source)
+	}
+	if p.multiline[source] != nil && len(records) == 1 {
+		p.enrich(records, source)
+		p.hold(source, records[0], done)
+		return &ProcessResult{Record: records[0]}
+	}
+	return p.storeRecords(records, source)
 }
 
-// processEntry parses an OTEL line, enriches it, and stores it.
-// Caller must hold p.mu. The lock is released before calling insertBuffer.Add()
+// acks holds the Done callbacks of the envelopes that make up a record.
+type acks []func()
+
+// run calls every callback.
+func (a acks) run() {
+	for _, done := range a {
+		done()
+	}
+}
+
+// processOTLP decodes a binary OTLP LogsData frame and stores its records.
+// Caller must hold p.mu.
+func (p *Processor) processOTLP(data []byte, source string) *ProcessResult {
+	records, err := DecodeOTLPLogsData(data)
+	if err != nil {
+		log.Printf("ingest: dropping %d-byte OTLP frame from %s: %v", len(data), source, err)
+		return nil
+	}
+	return p.storeRecords(records, source)
+}
+
+// storeRecords enriches parsed records and hands them to the sink.
+// Caller must hold p.mu. The lock is released before calling sink.Add()
 // to avoid holding the mutex during potential backpressure-induced DuckDB flushes.
-func (p *Processor) processEntry(line, source string) *ProcessResult {
-	// Parse-mode accepts OTEL JSON only.
-	records := ParseJSONLogEntries(line)
+func (p *Processor) storeRecords(records []*model.LogRecord, source string) *ProcessResult {
 	if len(records) == 0 {
 		return nil
 	}
 
-	for _, record := range records {
-		// Fill in fields derived by the processor.
-		record.Service = ExtractService(record.Attributes)
-		if record.Service == "unknown" && record.App != "" && record.App != "default" {
-			record.Service = record.App
-		}
-		record.Hostname = ExtractHostname(record.Attributes)
-		record.Source = source
-	}
+	p.enrich(records, source)
 
 	sink := p.sink
 	// Release lock before potentially slow buffer insertion.
@@ -125,9 +218,21 @@ func (p *Processor) processEntry(line, source string) *ProcessResult {
 	}
 }
 
+// enrich fills in the fields derived by the processor.
+func (p *Processor) enrich(records []*model.LogRecord, source string) {
+	for _, record := range records {
+		record.Service = ExtractService(record.Attributes)
+		if record.Service == "unknown" && record.App != "" && record.App != "default" {
+			record.Service = record.App
+		}
+		record.Hostname = ExtractHostname(record.Attributes)
+		record.Source = source
+	}
+}
+
 // tryAccumulateJSON attempts to accumulate multi-line JSON and process when complete.
 // Returns true if the line was consumed (either accumulated or completed).
-func (p *Processor) tryAccumulateJSON(line, source string) bool {
+func (p *Processor) tryAccumulateJSON(line, source string, done *acks) bool {
 	trimmed := strings.TrimSpace(line)
 
 	if !p.inJsonObject {
@@ -145,7 +250,7 @@ func (p *Processor) tryAccumulateJSON(line, source string) bool {
 				completeJSON := strings.TrimSpace(p.jsonBuffer.String())
 				jsonSource := p.jsonSource
 				p.resetJSONAccumulation()
-				p.processCompleteJSON(completeJSON, jsonSource)
+				p.processCompleteJSON(completeJSON, jsonSource, done)
 				return true
 			}
 
@@ -169,7 +274,7 @@ func (p *Processor) tryAccumulateJSON(line, source string) bool {
 		completeJSON := strings.TrimSpace(p.jsonBuffer.String())
 		jsonSource := p.jsonSource
 		p.resetJSONAccumulation()
-		p.processCompleteJSON(completeJSON, jsonSource)
+		p.processCompleteJSON(completeJSON, jsonSource, done)
 		return true
 	}
 
@@ -218,9 +323,9 @@ func (p *Processor) resetJSONAccumulation() {
 }
 
 // processCompleteJSON processes a complete JSON object (single or multi-line).
-func (p *Processor) processCompleteJSON(jsonStr, source string) {
+func (p *Processor) processCompleteJSON(jsonStr, source string, done *acks) {
 	// This goes through the same path as a single line
-	p.lastResult = p.processEntry(jsonStr, source)
+	p.lastResult = p.processEntry(jsonStr, source, done)
 }
 
 // SetSourceName updates the source name used for log records.
@@ -0,0 +1,98 @@
+package ingest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// RegexExtractor tries config-defined regular expressions before a source's
+// parser. The first expression that matches builds the record from its
+// named groups; lines no expression matches go to the fallback parser, so
+// JSON and logfmt lines keep working on the same source.
+type RegexExtractor struct {
+	patterns []*regexp.Regexp
+	fallback LineParser
+}
+
+// NewRegexExtractor compiles exprs, which must each have at least one named
+// group. Groups named message, level (or severity), and timestamp fill the
+// record; app sets the app; every other group, including service and host,
+// is stored as an attribute.
+func NewRegexExtractor(exprs []string, fallback LineParser) (*RegexExtractor, error) {
+	patterns := make([]*regexp.Regexp, 0, len(exprs))
+	for _, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("extractor %q: %w", expr, err)
+		}
+		named := false
+		for _, name := range re.SubexpNames() {
+			if name != "" {
+				named = true
+				break
+			}
+		}
+		if !named {
+			return nil, fmt.Errorf("extractor %q: no named groups", expr)
+		}
+		patterns = append(patterns, re)
+	}
+	return &RegexExtractor{patterns: patterns, fallback: fallback}, nil
+}
+
+// Name implements LineParser.
+func (e *RegexExtractor) Name() string { return "regex+" + e.fallback.Name() }
+
+// Parse implements LineParser.
+func (e *RegexExtractor) Parse(line string) []*model.LogRecord {
+	trimmed := strings.TrimRight(line, "\r")
+	for _, re := range e.patterns {
+		m := re.FindStringSubmatch(trimmed)
+		if m == nil {
+			continue
+		}
+		attributes := make(map[string]string)
+		for i, name := range re.SubexpNames() {
+			if name != "" && m[i] != "" {
+				attributes[name] = m[i]
+			}
+		}
+		return []*model.LogRecord{recordFromFields(line, attributes)}
+	}
+	return e.fallback.Parse(line)
+}
+
+// RegexExtractors wraps each source's parser in parsers (auto when unset)
+// with the extractors configured for it in exprs. The result holds every
+// source from both maps.
+func RegexExtractors(exprs map[string][]string, parsers map[string]LineParser) (map[string]LineParser, error) {
+	if len(exprs) == 0 {
+		return parsers, nil
+	}
+	out := make(map[string]LineParser, len(parsers)+len(exprs))
+	for source, p := range parsers {
+		out[source] = p
+	}
+
+	sources := make([]string, 0, len(exprs))
+	for source := range exprs {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		fallback, ok := out[source]
+		if !ok {
+			fallback = builtinParsers[ParserAuto]
+		}
+		e, err := NewRegexExtractor(exprs[source], fallback)
+		if err != nil {
+			return nil, fmt.Errorf("source %q: %w", source, err)
+		}
+		out[source] = e
+	}
+	return out, nil
+}
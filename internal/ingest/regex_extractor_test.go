@@ -0,0 +1,116 @@
+package ingest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestRegexExtractor_MatchesThenFallsBack(t *testing.T) {
+	t.Parallel()
+
+	parsers, err := RegexExtractors(map[string][]string{
+		"file": {
+			`^(?P<timestamp>\S+) \[(?P<severity>\w+)\] (?P<service>[\w-]+): (?P<message>.*)$`,
+			`^(?P<level>\w+) (?P<message>.*)$`,
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("RegexExtractors: %v", err)
+	}
+	p := parsers["file"]
+
+	r := p.Parse("2026-02-18T10:22:23Z [warning] billing-api: card declined")
+	if len(r) != 1 || r[0].Level != "WARN" || r[0].Message != "card declined" || r[0].Attributes["service"] != "billing-api" {
+		t.Fatalf("first extractor record = %+v", r)
+	}
+	if r[0].OrigTimestamp.IsZero() {
+		t.Fatal("timestamp group should set OrigTimestamp")
+	}
+
+	if r := p.Parse("ERROR disk full"); len(r) != 1 || r[0].Level != "ERROR" || r[0].Message != "disk full" {
+		t.Fatalf("second extractor record = %+v", r)
+	}
+
+	// Unmatched lines go through the auto parser.
+	r = p.Parse(`level=info msg="from logfmt" user=42`)
+	if len(r) != 1 || r[0].Message != "from logfmt" || r[0].Attributes["user"] != "42" {
+		t.Fatalf("fallback record = %+v", r)
+	}
+	if !acceptsJSON(p) {
+		t.Fatal("extractor over auto should still accumulate multi-line JSON")
+	}
+}
+
+func TestRegexExtractors_WrapsSourceParser(t *testing.T) {
+	t.Parallel()
+
+	base, err := SourceParsers(map[string]string{"tcp": ParserAccess, "stdin": ParserLogfmt}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsers, err := RegexExtractors(map[string][]string{"tcp": {`^legacy: (?P<message>.*)$`}}, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsers["stdin"].Name() != ParserLogfmt {
+		t.Fatalf("stdin parser = %s, want untouched logfmt", parsers["stdin"].Name())
+	}
+	if acceptsJSON(parsers["tcp"]) {
+		t.Fatal("extractor over access should not accumulate JSON")
+	}
+	r := parsers["tcp"].Parse(`10.0.0.1 - - [18/Feb/2026:10:22:23 +0000] "GET / HTTP/1.1" 200 5`)
+	if len(r) != 1 || r[0].Attributes["http.response.status_code"] == "" {
+		t.Fatalf("access fallback record = %+v", r)
+	}
+
+	sink := &recordingSink{}
+	proc := NewProcessor(sink, "tcp", Config{SourceParsers: parsers})
+	if proc.ProcessEnvelope(model.IngestEnvelope{Line: "legacy: hello"}) == nil {
+		t.Fatal("extractor line should be parsed")
+	}
+	if len(sink.records) != 1 || sink.records[0].Message != "hello" {
+		t.Fatalf("sink records = %+v", sink.records)
+	}
+}
+
+func TestNewRegexExtractor_Errors(t *testing.T) {
+	t.Parallel()
+
+	for expr, want := range map[string]string{
+		`(?P<message>.*`: "missing closing",
+		`^\w+ .*$`:       "no named groups",
+	} {
+		if _, err := NewRegexExtractor([]string{expr}, builtinParsers[ParserAuto]); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("NewRegexExtractor(%q) error = %v, want %q", expr, err, want)
+		}
+	}
+}
+
+// Not parallel: SetTimestampLayouts replaces package state.
+func TestSetTimestampLayouts(t *testing.T) {
+	t.Cleanup(func() { SetTimestampLayouts(nil) })
+
+	p, err := NewRegexExtractor([]string{`^(?P<timestamp>\S+ \S+) (?P<message>.*)$`}, nil)
+	if err != nil {
+		t.Fatalf("NewRegexExtractor: %v", err)
+	}
+	line := "13.02.2024 08:30:00 legacy batch done"
+	if r := p.Parse(line); len(r) != 1 || !r[0].OrigTimestamp.IsZero() {
+		t.Fatalf("day-first timestamp should not parse by default: %+v", r)
+	}
+
+	SetTimestampLayouts([]string{"02.01.2006 15:04:05"})
+	r := p.Parse(line)
+	if len(r) != 1 || r[0].Message != "legacy batch done" {
+		t.Fatalf("record = %+v", r)
+	}
+	if want := time.Date(2024, time.February, 13, 8, 30, 0, 0, time.UTC); !r[0].OrigTimestamp.Equal(want) {
+		t.Fatalf("OrigTimestamp = %s, want %s", r[0].OrigTimestamp, want)
+	}
+	if _, ok := r[0].Attributes["timestamp"]; ok {
+		t.Fatal("parsed timestamp should not stay an attribute")
+	}
+}
@@ -0,0 +1,148 @@
+package ingest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/logparse"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// SampleRule keeps Rate (0 to 1) of the records of one app and severity.
+// App AllAppsKey and an empty Level match every app and every severity.
+type SampleRule struct {
+	App   string
+	Level string
+	Rate  float64
+}
+
+// SamplingSink forwards a fixed fraction of the records matching each
+// sampling rule to the next sink, so noisy services cannot grow storage
+// unpredictably. The first rule matching a record applies; records no rule
+// matches are all kept. Dropped records are counted per app and level.
+// All methods are safe for concurrent use.
+type SamplingSink struct {
+	next  model.RecordSink
+	rules []sampleRule
+
+	mu      sync.Mutex
+	seen    []int64                     // records matched, per rule
+	dropped map[string]map[string]int64 // app -> level -> count
+}
+
+type sampleRule struct {
+	app   string // "" = every app
+	level string // normalized; "" = every level
+	rate  float64
+}
+
+// NewSamplingSink wraps next with sampling rules, checked in order.
+func NewSamplingSink(next model.RecordSink, rules []SampleRule) (*SamplingSink, error) {
+	s := &SamplingSink{
+		next:    next,
+		rules:   make([]sampleRule, len(rules)),
+		seen:    make([]int64, len(rules)),
+		dropped: make(map[string]map[string]int64),
+	}
+	for i, rule := range rules {
+		app := strings.TrimSpace(rule.App)
+		if app == "" {
+			return nil, fmt.Errorf("sampling rule %d: empty app name", i)
+		}
+		if app == AllAppsKey {
+			app = ""
+		}
+		level := strings.TrimSpace(rule.Level)
+		if level != "" && level != "*" {
+			if _, err := severityThresholdNumber(level); err != nil {
+				return nil, fmt.Errorf("sampling rule %d: %w", i, err)
+			}
+			level = logparse.NormalizeSeverity(level)
+		} else {
+			level = ""
+		}
+		if rule.Rate < 0 || rule.Rate > 1 {
+			return nil, fmt.Errorf("sampling rule %d: rate %v out of range (want 0 to 1)", i, rule.Rate)
+		}
+		s.rules[i] = sampleRule{app: app, level: level, rate: rule.Rate}
+	}
+	return s, nil
+}
+
+// Add forwards record to the next sink, or counts it when its rule samples
+// it out.
+func (s *SamplingSink) Add(record *model.LogRecord) {
+	if record == nil {
+		return
+	}
+	if s.keeps(record) {
+		if s.next != nil {
+			s.next.Add(record)
+		}
+		return
+	}
+
+	app := record.App
+	if app == "" {
+		app = "default"
+	}
+	s.mu.Lock()
+	byLevel, ok := s.dropped[app]
+	if !ok {
+		byLevel = make(map[string]int64)
+		s.dropped[app] = byLevel
+	}
+	byLevel[record.Level]++
+	s.mu.Unlock()
+}
+
+// keeps reports whether record survives sampling. A rule keeps the n-th
+// record it matches when that raises floor(n*rate), so it keeps exactly
+// rate of its records, spread evenly, rather than a random share.
+func (s *SamplingSink) keeps(record *model.LogRecord) bool {
+	for i, rule := range s.rules {
+		if rule.app != "" && rule.app != record.App {
+			continue
+		}
+		if rule.level != "" && rule.level != logparse.NormalizeSeverity(record.Level) {
+			continue
+		}
+		s.mu.Lock()
+		n := s.seen[i]
+		s.seen[i]++
+		s.mu.Unlock()
+		return int64(float64(n+1)*rule.rate) > int64(float64(n)*rule.rate)
+	}
+	return true
+}
+
+// Dropped returns a snapshot of sampled-out record counts by app and level.
+func (s *SamplingSink) Dropped() map[string]map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make(map[string]map[string]int64, len(s.dropped))
+	for app, byLevel := range s.dropped {
+		levels := make(map[string]int64, len(byLevel))
+		for level, count := range byLevel {
+			levels[level] = count
+		}
+		out[app] = levels
+	}
+	return out
+}
+
+// DroppedTotal returns the number of records sampled out so far.
+func (s *SamplingSink) DroppedTotal() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total int64
+	for _, byLevel := range s.dropped {
+		for _, count := range byLevel {
+			total += count
+		}
+	}
+	return total
+}
@@ -0,0 +1,53 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestSamplingSink_KeepsRate(t *testing.T) {
+	t.Parallel()
+
+	next := &recordingSink{}
+	sink, err := NewSamplingSink(next, []SampleRule{
+		{App: "payments", Level: "debug", Rate: 0.1},
+		{App: AllAppsKey, Level: "TRACE", Rate: 0},
+	})
+	if err != nil {
+		t.Fatalf("NewSamplingSink: %v", err)
+	}
+
+	for range 100 {
+		sink.Add(&model.LogRecord{App: "payments", Level: "DEBUG"})
+	}
+	sink.Add(&model.LogRecord{App: "payments", Level: "INFO"})
+	sink.Add(&model.LogRecord{App: "checkout", Level: "DEBUG"})
+	sink.Add(&model.LogRecord{App: "checkout", Level: "TRACE"})
+
+	if got := len(next.records); got != 12 {
+		t.Fatalf("forwarded records = %d, want 10 sampled DEBUG plus 2 unmatched", got)
+	}
+	dropped := sink.Dropped()
+	if dropped["payments"]["DEBUG"] != 90 || dropped["checkout"]["TRACE"] != 1 {
+		t.Fatalf("unexpected dropped counts: %v", dropped)
+	}
+	if got := sink.DroppedTotal(); got != 91 {
+		t.Fatalf("DroppedTotal = %d, want 91", got)
+	}
+}
+
+func TestSamplingSink_RejectsInvalidRules(t *testing.T) {
+	t.Parallel()
+
+	for _, rules := range [][]SampleRule{
+		{{App: "", Level: "DEBUG", Rate: 0.5}},
+		{{App: "api", Level: "loud", Rate: 0.5}},
+		{{App: "api", Level: "DEBUG", Rate: 1.5}},
+		{{App: "api", Rate: -0.1}},
+	} {
+		if _, err := NewSamplingSink(nil, rules); err == nil {
+			t.Errorf("NewSamplingSink(%+v) accepted invalid rules", rules)
+		}
+	}
+}
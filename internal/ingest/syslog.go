@@ -0,0 +1,224 @@
+package ingest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// Attribute keys written by the syslog parser.
+const (
+	attrSyslogFacility = "syslog.facility"
+	attrSyslogAppName  = "syslog.appname"
+	attrSyslogProcID   = "syslog.procid"
+	attrSyslogMsgID    = "syslog.msgid"
+)
+
+// syslog3164Regex matches the BSD header: <PRI>Mmm dd hh:mm:ss host tag[pid]: msg
+var syslog3164Regex = regexp.MustCompile(`^<(\d{1,3})>([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}) (\S+) ([^\s:\[]+)(?:\[([^\]]*)\])?: ?(.*)$`)
+
+// syslogSeverities maps the PRI severity (0-7) to a level.
+var syslogSeverities = [8]string{"FATAL", "FATAL", "FATAL", "ERROR", "WARN", "INFO", "INFO", "DEBUG"}
+
+// syslogHeader is one parsed header of an RFC 5424 or RFC 3164 message.
+type syslogHeader struct {
+	priority  int
+	timestamp time.Time
+	hostname  string
+	appName   string
+	procID    string
+	msgID     string
+	sd        map[string]string
+	message   string
+}
+
+// ParseSyslogLine parses an RFC 5424 or RFC 3164 syslog message, as sent by
+// rsyslog/syslog-ng forwarders and Heroku Logplex drains. The message part
+// goes through the auto parser, so logfmt (Heroku router) or JSON bodies
+// keep their fields; a plain message takes its level from the PRI
+// severity. Hostname, app name, process id, message id, and structured
+// data params (as sdid.param) become attributes; the app name is also the
+// service name.
+// Returns nil when the line is not a syslog message.
+func ParseSyslogLine(line string) *model.LogRecord {
+	trimmed := strings.TrimRight(line, "\r\n")
+	h, ok := parseSyslog5424(trimmed)
+	if !ok {
+		if h, ok = parseSyslog3164(trimmed); !ok {
+			return nil
+		}
+	}
+
+	var record *model.LogRecord
+	if records := parseAuto(h.message); len(records) > 0 {
+		record = records[0]
+	} else {
+		level := syslogSeverities[h.priority%8]
+		record = &model.LogRecord{
+			Timestamp:  time.Now(),
+			Level:      level,
+			LevelNum:   DefaultSeverityNumber(level),
+			Message:    SanitizeMessage(h.message),
+			Attributes: make(map[string]string),
+		}
+	}
+	record.RawLine = line
+	if record.OrigTimestamp.IsZero() {
+		record.OrigTimestamp = h.timestamp
+	}
+
+	attributes := map[string]string{attrSyslogFacility: strconv.Itoa(h.priority / 8)}
+	for k, v := range h.sd {
+		attributes[k] = v
+	}
+	if h.hostname != "" {
+		attributes["host.name"] = h.hostname
+	}
+	if h.appName != "" {
+		attributes[attrSyslogAppName] = h.appName
+		attributes["service.name"] = h.appName
+	}
+	if h.procID != "" {
+		attributes[attrSyslogProcID] = h.procID
+	}
+	if h.msgID != "" {
+		attributes[attrSyslogMsgID] = h.msgID
+	}
+	// Fields from the message win over the header.
+	for k, v := range attributes {
+		if _, ok := record.Attributes[k]; !ok {
+			record.Attributes[k] = v
+		}
+	}
+	if record.App == "" || record.App == "default" {
+		if app := ExtractApp(record.Attributes); app != "" {
+			record.App = app
+		} else {
+			record.App = "default"
+		}
+	}
+	return record
+}
+
+// parseSyslog5424 parses "<PRI>1 TIMESTAMP HOST APP PROCID MSGID [SD] MSG".
+// Logplex omits the structured data field, so a message that does not
+// start with "-" or "[" is taken as MSG directly.
+func parseSyslog5424(line string) (syslogHeader, bool) {
+	var h syslogHeader
+	pri, rest, ok := cutSyslogPRI(line)
+	if !ok {
+		return h, false
+	}
+	version, rest, ok := strings.Cut(rest, " ")
+	if !ok || version != "1" {
+		return h, false
+	}
+	var fields [5]string
+	for i := range fields {
+		if fields[i], rest, ok = strings.Cut(rest, " "); !ok && i < 4 {
+			return h, false
+		}
+	}
+	h.priority = pri
+	if fields[0] != "-" {
+		ts, err := time.Parse(time.RFC3339Nano, fields[0])
+		if err != nil {
+			return h, false
+		}
+		h.timestamp = ts
+	}
+	h.hostname = syslogNil(fields[1])
+	h.appName = syslogNil(fields[2])
+	h.procID = syslogNil(fields[3])
+	h.msgID = syslogNil(fields[4])
+
+	switch {
+	case strings.HasPrefix(rest, "-"):
+		rest = strings.TrimPrefix(strings.TrimPrefix(rest, "-"), " ")
+	case strings.HasPrefix(rest, "["):
+		h.sd, rest = parseSyslogSD(rest)
+	}
+	h.message = strings.TrimPrefix(rest, "\ufeff") // UTF-8 BOM
+	return h, true
+}
+
+// parseSyslog3164 parses "<PRI>Mmm dd hh:mm:ss HOST TAG[PID]: MSG". The
+// timestamp has no year or zone and is taken in local time this year.
+func parseSyslog3164(line string) (syslogHeader, bool) {
+	m := syslog3164Regex.FindStringSubmatch(line)
+	if m == nil {
+		return syslogHeader{}, false
+	}
+	pri, err := strconv.Atoi(m[1])
+	if err != nil || pri > 191 {
+		return syslogHeader{}, false
+	}
+	h := syslogHeader{priority: pri, hostname: m[3], appName: m[4], procID: m[5], message: m[6]}
+	if ts, err := time.ParseInLocation(time.Stamp, m[2], time.Local); err == nil {
+		h.timestamp = ts.AddDate(time.Now().Year(), 0, 0)
+	}
+	return h, true
+}
+
+// cutSyslogPRI parses the leading <PRI> (0-191).
+func cutSyslogPRI(line string) (int, string, bool) {
+	if !strings.HasPrefix(line, "<") {
+		return 0, "", false
+	}
+	end := strings.IndexByte(line, '>')
+	if end < 2 || end > 4 {
+		return 0, "", false
+	}
+	pri, err := strconv.Atoi(line[1:end])
+	if err != nil || pri > 191 {
+		return 0, "", false
+	}
+	return pri, line[end+1:], true
+}
+
+// parseSyslogSD parses [id k="v" ...] elements into id.k attributes and
+// returns the rest of the line.
+func parseSyslogSD(s string) (map[string]string, string) {
+	sd := make(map[string]string)
+	for strings.HasPrefix(s, "[") {
+		end := -1
+		inQuote := false
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '"':
+				inQuote = !inQuote
+			case ']':
+				if !inQuote {
+					end = i
+				}
+			}
+			if end >= 0 {
+				break
+			}
+		}
+		if end < 0 {
+			break
+		}
+		id, params, _ := strings.Cut(s[1:end], " ")
+		if pairs, _, ok := tokenizeLogfmt(params); ok {
+			for _, p := range pairs {
+				sd[id+"."+p.key] = p.value
+			}
+		}
+		s = s[end+1:]
+	}
+	return sd, strings.TrimPrefix(s, " ")
+}
+
+// syslogNil maps the RFC 5424 NILVALUE "-" to "".
+func syslogNil(s string) string {
+	if s == "-" {
+		return ""
+	}
+	return s
+}
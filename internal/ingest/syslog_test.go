@@ -0,0 +1,92 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSyslogLine_RFC5424(t *testing.T) {
+	t.Parallel()
+
+	r := ParseSyslogLine(`<165>1 2024-01-01T12:00:00.5Z web-1 billing 4242 ID47 [exampleSDID@32473 iut="3" eventSource="Application"] invoice ERROR: card declined`)
+	if r == nil {
+		t.Fatal("RFC 5424 line should parse")
+	}
+	if r.Message != "invoice ERROR: card declined" || r.Level != "INFO" {
+		t.Fatalf("message/level = %q/%q", r.Message, r.Level)
+	}
+	want := map[string]string{
+		"host.name":                     "web-1",
+		"service.name":                  "billing",
+		"syslog.appname":                "billing",
+		"syslog.procid":                 "4242",
+		"syslog.msgid":                  "ID47",
+		"syslog.facility":               "20",
+		"exampleSDID@32473.iut":         "3",
+		"exampleSDID@32473.eventSource": "Application",
+	}
+	for k, v := range want {
+		if r.Attributes[k] != v {
+			t.Errorf("%s = %q, want %q", k, r.Attributes[k], v)
+		}
+	}
+	if want := time.Date(2024, 1, 1, 12, 0, 0, 5e8, time.UTC); !r.OrigTimestamp.Equal(want) {
+		t.Fatalf("OrigTimestamp = %s", r.OrigTimestamp)
+	}
+	if r.App != "billing" {
+		t.Fatalf("app = %q", r.App)
+	}
+}
+
+func TestParseSyslogLine_Logplex(t *testing.T) {
+	t.Parallel()
+
+	r := ParseSyslogLine(`<158>1 2024-01-01T12:00:00+00:00 host heroku router - at=info method=GET path="/" host=myapp.herokuapp.com status=200 bytes=512`)
+	if r == nil {
+		t.Fatal("Logplex router line should parse")
+	}
+	if r.Attributes["status"] != "200" || r.Attributes["method"] != "GET" || r.Attributes["syslog.procid"] != "router" {
+		t.Fatalf("attributes = %v", r.Attributes)
+	}
+	if r.Attributes["host"] != "myapp.herokuapp.com" {
+		t.Fatalf("message host should win over the header, got %q", r.Attributes["host"])
+	}
+
+	r = ParseSyslogLine(`<190>1 2024-01-01T12:00:00+00:00 host app web.1 - State changed from starting to up`)
+	if r == nil || r.Message != "State changed from starting to up" || r.Attributes["syslog.procid"] != "web.1" {
+		t.Fatalf("plain Logplex record = %+v", r)
+	}
+}
+
+func TestParseSyslogLine_RFC3164(t *testing.T) {
+	t.Parallel()
+
+	r := ParseSyslogLine("<34>Oct 11 22:14:15 mymachine su[812]: 'su root' failed for lonvick on /dev/pts/8")
+	if r == nil {
+		t.Fatal("RFC 3164 line should parse")
+	}
+	if r.Level != "FATAL" || r.Message != "'su root' failed for lonvick on /dev/pts/8" {
+		t.Fatalf("level/message = %q/%q", r.Level, r.Message)
+	}
+	if r.Attributes["host.name"] != "mymachine" || r.Attributes["syslog.appname"] != "su" || r.Attributes["syslog.procid"] != "812" {
+		t.Fatalf("attributes = %v", r.Attributes)
+	}
+	if ts := r.OrigTimestamp; ts.Month() != time.October || ts.Day() != 11 || ts.Year() != time.Now().Year() {
+		t.Fatalf("OrigTimestamp = %s", ts)
+	}
+
+	for _, line := range []string{"level=info msg=hi", "<999>1 - - - - - x", "<13>2 2024-01-01T00:00:00Z h a p m msg"} {
+		if ParseSyslogLine(line) != nil {
+			t.Errorf("%q should not parse as syslog", line)
+		}
+	}
+}
+
+func TestParseAuto_CEFBehindSyslogHeader(t *testing.T) {
+	t.Parallel()
+
+	r := parseAuto("<134>Feb 18 10:22:23 fw01 CEF:0|Vendor|Product|1|sig|Blocked|5|src=1.2.3.4")
+	if len(r) != 1 || r[0].Attributes["cef.device.vendor"] != "Vendor" {
+		t.Fatalf("records = %+v", r)
+	}
+}
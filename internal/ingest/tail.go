@@ -0,0 +1,146 @@
+package ingest
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// defaultTailBuffer is how many records a live tail holds for a subscriber
+// that asked for no particular buffer.
+const defaultTailBuffer = 1024
+
+// TailSink forwards records to the next sink and pushes a copy of each to
+// the live tails it matches. A tail that falls behind loses records rather
+// than slowing ingestion; they are counted for it instead.
+// All methods are safe for concurrent use.
+type TailSink struct {
+	next model.RecordSink
+
+	mu    sync.RWMutex
+	tails map[*liveTail]struct{}
+}
+
+// NewTailSink wraps next, feeding the live tails opened with TailLogs.
+func NewTailSink(next model.RecordSink) *TailSink {
+	return &TailSink{next: next, tails: make(map[*liveTail]struct{})}
+}
+
+// Add forwards record to the next sink, then offers it to every live tail.
+func (s *TailSink) Add(record *model.LogRecord) {
+	if record == nil {
+		return
+	}
+	if s.next != nil {
+		s.next.Add(record)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var copied *model.LogRecord
+	for t := range s.tails {
+		if !t.matches(record) {
+			continue
+		}
+		if copied == nil {
+			// The tails read the record on other goroutines; give them a
+			// copy the pipeline cannot change under them, named as the
+			// store will name it.
+			r := *record
+			r.Attributes = maps.Clone(record.Attributes)
+			if r.App == "" {
+				r.App = "default"
+			}
+			copied = &r
+		}
+		select {
+		case t.records <- *copied:
+		default:
+			t.dropped.Add(1)
+		}
+	}
+}
+
+// TailLogs opens a live tail of the records matching filter from now on.
+// A buffer of zero or less holds defaultTailBuffer records.
+func (s *TailSink) TailLogs(filter model.TailFilter, buffer int) (model.LogTail, error) {
+	t := &liveTail{sink: s}
+	if filter.MessagePattern != "" {
+		re, err := regexp.Compile(filter.MessagePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message pattern: %w", err)
+		}
+		t.pattern = re
+	}
+	t.app = filter.App
+	t.levels = slices.Clone(filter.SeverityLevels)
+	t.facets = maps.Clone(filter.Facets)
+	if buffer <= 0 {
+		buffer = defaultTailBuffer
+	}
+	t.records = make(chan model.LogRecord, buffer)
+
+	s.mu.Lock()
+	s.tails[t] = struct{}{}
+	s.mu.Unlock()
+	return t, nil
+}
+
+// Tails returns how many live tails are open.
+func (s *TailSink) Tails() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.tails)
+}
+
+// liveTail is a model.LogTail fed by a TailSink.
+type liveTail struct {
+	sink    *TailSink
+	app     string
+	levels  []string
+	facets  map[string]string
+	pattern *regexp.Regexp
+
+	records   chan model.LogRecord
+	dropped   atomic.Int64
+	closeOnce sync.Once
+}
+
+func (t *liveTail) matches(r *model.LogRecord) bool {
+	if t.app != "" {
+		app := r.App
+		if app == "" {
+			app = "default"
+		}
+		if app != t.app {
+			return false
+		}
+	}
+	if len(t.levels) > 0 && !slices.Contains(t.levels, r.Level) {
+		return false
+	}
+	if !model.MatchesFacets(r, t.facets) {
+		return false
+	}
+	return t.pattern == nil || t.pattern.MatchString(r.Message)
+}
+
+func (t *liveTail) Records() <-chan model.LogRecord { return t.records }
+
+func (t *liveTail) Dropped() int64 { return t.dropped.Swap(0) }
+
+// Close removes the tail from its sink and closes its records channel.
+// Records are only sent under the sink's read lock, so none can follow.
+func (t *liveTail) Close() {
+	t.closeOnce.Do(func() {
+		t.sink.mu.Lock()
+		delete(t.sink.tails, t)
+		t.sink.mu.Unlock()
+		close(t.records)
+	})
+}
@@ -0,0 +1,80 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestTailSink_DeliversMatchingRecords(t *testing.T) {
+	t.Parallel()
+
+	next := &recordingSink{}
+	sink := NewTailSink(next)
+	if _, err := sink.TailLogs(model.TailFilter{MessagePattern: "("}, 0); err == nil {
+		t.Fatal("TailLogs accepted an invalid pattern")
+	}
+	tail, err := sink.TailLogs(model.TailFilter{App: "shop", SeverityLevels: []string{"ERROR"}, MessagePattern: "^pay"}, 2)
+	if err != nil {
+		t.Fatalf("TailLogs: %v", err)
+	}
+
+	attrs := map[string]string{"order": "1"}
+	sink.Add(&model.LogRecord{App: "shop", Level: "ERROR", Message: "payment failed", Attributes: attrs})
+	sink.Add(&model.LogRecord{App: "shop", Level: "INFO", Message: "payment ok"})
+	sink.Add(&model.LogRecord{App: "blog", Level: "ERROR", Message: "payment failed"})
+	sink.Add(&model.LogRecord{App: "shop", Level: "ERROR", Message: "cart failed"})
+	attrs["order"] = "2"
+
+	if got := len(next.records); got != 4 {
+		t.Fatalf("forwarded records = %d, want all 4", got)
+	}
+	r := <-tail.Records()
+	if r.Message != "payment failed" || r.Attributes["order"] != "1" {
+		t.Fatalf("tailed record = %+v, want the shop payment error as added", r)
+	}
+	if len(tail.Records()) != 0 {
+		t.Fatalf("%d more records tailed, want none", len(tail.Records()))
+	}
+
+	// A tail that falls behind loses records and counts them.
+	for range 5 {
+		sink.Add(&model.LogRecord{App: "shop", Level: "ERROR", Message: "payment failed"})
+	}
+	if got := tail.Dropped(); got != 3 {
+		t.Fatalf("Dropped = %d, want 3", got)
+	}
+	if got := tail.Dropped(); got != 0 {
+		t.Fatalf("Dropped after reading = %d, want 0", got)
+	}
+
+	tail.Close()
+	tail.Close()
+	if sink.Tails() != 0 {
+		t.Fatalf("Tails = %d after Close, want 0", sink.Tails())
+	}
+	for range tail.Records() {
+	}
+	sink.Add(&model.LogRecord{App: "shop", Level: "ERROR", Message: "payment failed"})
+}
+
+func TestTailSink_MatchesFacets(t *testing.T) {
+	t.Parallel()
+
+	sink := NewTailSink(&recordingSink{})
+	facets := map[string]string{"service": "api", "region": "eu"}
+	tail, err := sink.TailLogs(model.TailFilter{Facets: facets}, 4)
+	if err != nil {
+		t.Fatalf("TailLogs: %v", err)
+	}
+	defer tail.Close()
+	facets["region"] = "us"
+
+	sink.Add(&model.LogRecord{Service: "api", Message: "kept", Attributes: map[string]string{"region": "eu"}})
+	sink.Add(&model.LogRecord{Service: "api", Message: "wrong region", Attributes: map[string]string{"region": "us"}})
+	sink.Add(&model.LogRecord{Service: "web", Message: "wrong service", Attributes: map[string]string{"region": "eu"}})
+
+	if r := <-tail.Records(); r.Message != "kept" || len(tail.Records()) != 0 {
+		t.Fatalf("tailed %q and %d more, want only the api record in eu", r.Message, len(tail.Records()))
+	}
+}
@@ -0,0 +1,227 @@
+package ingest
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/logparse"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// AllAppsKey applies a minimum severity to every app without its own entry.
+const AllAppsKey = "*"
+
+// SeverityThresholdSink forwards records to the next sink only when their
+// severity meets the minimum configured for their app. Records below the
+// threshold are counted per app and level instead of being stored; with
+// PersistSuppressed the counts also reach the store's minute rollups.
+// All methods are safe for concurrent use.
+type SeverityThresholdSink struct {
+	next     model.RecordSink
+	perApp   map[string]int
+	fallback int // 0 = no threshold for unlisted apps
+
+	mu         sync.Mutex
+	suppressed map[string]map[string]int64 // app -> level -> count
+	counter    model.SuppressedCounter
+	pending    map[suppressedKey]int64 // not yet written to counter
+	done       chan struct{}
+	wg         sync.WaitGroup
+}
+
+// suppressedKey identifies one minute rollup of suppressed records.
+type suppressedKey struct {
+	minute  time.Time
+	app     string
+	service string
+	level   string
+}
+
+// NewSeverityThresholdSink wraps next with per-app minimum severities.
+// Keys are app names (or AllAppsKey); values are severity names such as "INFO".
+func NewSeverityThresholdSink(next model.RecordSink, minSeverity map[string]string) (*SeverityThresholdSink, error) {
+	s := &SeverityThresholdSink{
+		next:       next,
+		perApp:     make(map[string]int, len(minSeverity)),
+		suppressed: make(map[string]map[string]int64),
+	}
+	for app, level := range minSeverity {
+		app = strings.TrimSpace(app)
+		if app == "" {
+			return nil, fmt.Errorf("min severity: empty app name")
+		}
+		num, err := severityThresholdNumber(level)
+		if err != nil {
+			return nil, fmt.Errorf("min severity for app %q: %w", app, err)
+		}
+		if app == AllAppsKey {
+			s.fallback = num
+			continue
+		}
+		s.perApp[app] = num
+	}
+	return s, nil
+}
+
+// Add forwards record to the next sink, or counts it when it falls below
+// its app's minimum severity.
+func (s *SeverityThresholdSink) Add(record *model.LogRecord) {
+	if record == nil {
+		return
+	}
+	if s.allows(record) {
+		if s.next != nil {
+			s.next.Add(record)
+		}
+		return
+	}
+
+	app := record.App
+	if app == "" {
+		app = "default"
+	}
+	s.mu.Lock()
+	byLevel, ok := s.suppressed[app]
+	if !ok {
+		byLevel = make(map[string]int64)
+		s.suppressed[app] = byLevel
+	}
+	byLevel[record.Level]++
+	if s.counter != nil {
+		ts := record.Timestamp
+		if ts.IsZero() {
+			ts = time.Now()
+		}
+		key := suppressedKey{minute: ts.UTC().Truncate(time.Minute), app: app, service: record.Service, level: record.Level}
+		s.pending[key]++
+	}
+	s.mu.Unlock()
+}
+
+// PersistSuppressed writes suppressed counts to counter every interval, so
+// severity counts include records kept out of storage. Stop writes the rest.
+func (s *SeverityThresholdSink) PersistSuppressed(counter model.SuppressedCounter, interval time.Duration) {
+	if counter == nil {
+		return
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+	s.mu.Lock()
+	s.counter = counter
+	s.pending = make(map[suppressedKey]int64)
+	done := make(chan struct{})
+	s.done = done
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.flushSuppressed()
+			case <-done:
+				s.flushSuppressed()
+				return
+			}
+		}
+	}()
+}
+
+// Stop writes pending suppressed counts and stops the PersistSuppressed loop.
+func (s *SeverityThresholdSink) Stop() {
+	s.mu.Lock()
+	done := s.done
+	s.done = nil
+	s.mu.Unlock()
+	if done == nil {
+		return
+	}
+	close(done)
+	s.wg.Wait()
+}
+
+// flushSuppressed writes pending counts to the counter. A failed write puts
+// them back for the next flush.
+func (s *SeverityThresholdSink) flushSuppressed() {
+	s.mu.Lock()
+	if len(s.pending) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	pending := s.pending
+	s.pending = make(map[suppressedKey]int64)
+	counter := s.counter
+	s.mu.Unlock()
+
+	counts := make([]model.SuppressedCount, 0, len(pending))
+	for key, count := range pending {
+		counts = append(counts, model.SuppressedCount{Minute: key.minute, App: key.app, Service: key.service, Level: key.level, Count: count})
+	}
+	if err := counter.AddSuppressedCounts(counts); err != nil {
+		log.Printf("ingest: writing suppressed counts: %v", err)
+		s.mu.Lock()
+		for key, count := range pending {
+			s.pending[key] += count
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Suppressed returns a snapshot of below-threshold record counts by app and level.
+func (s *SeverityThresholdSink) Suppressed() map[string]map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make(map[string]map[string]int64, len(s.suppressed))
+	for app, byLevel := range s.suppressed {
+		levels := make(map[string]int64, len(byLevel))
+		for level, count := range byLevel {
+			levels[level] = count
+		}
+		out[app] = levels
+	}
+	return out
+}
+
+// SuppressedTotal returns the number of records kept out of storage so far.
+func (s *SeverityThresholdSink) SuppressedTotal() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total int64
+	for _, byLevel := range s.suppressed {
+		for _, count := range byLevel {
+			total += count
+		}
+	}
+	return total
+}
+
+func (s *SeverityThresholdSink) allows(record *model.LogRecord) bool {
+	min, ok := s.perApp[record.App]
+	if !ok {
+		min = s.fallback
+	}
+	if min == 0 {
+		return true
+	}
+	return DefaultSeverityNumber(record.Level) >= min
+}
+
+// severityThresholdNumber validates a configured severity name and returns
+// its canonical OTEL severity number.
+func severityThresholdNumber(level string) (int, error) {
+	switch strings.ToUpper(strings.TrimSpace(level)) {
+	case "TRACE", "DEBUG", "INFO", "WARN", "WARNING", "ERROR", "FATAL", "CRITICAL":
+		return DefaultSeverityNumber(logparse.NormalizeSeverity(level)), nil
+	default:
+		return 0, fmt.Errorf("unknown severity %q", level)
+	}
+}
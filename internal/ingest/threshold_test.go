@@ -0,0 +1,70 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestSeverityThresholdSink_PerAppMinimum(t *testing.T) {
+	t.Parallel()
+
+	next := &recordingSink{}
+	sink, err := NewSeverityThresholdSink(next, map[string]string{"payments": "info"})
+	if err != nil {
+		t.Fatalf("NewSeverityThresholdSink: %v", err)
+	}
+
+	sink.Add(&model.LogRecord{App: "payments", Level: "DEBUG", Message: "noisy"})
+	sink.Add(&model.LogRecord{App: "payments", Level: "INFO", Message: "kept"})
+	sink.Add(&model.LogRecord{App: "checkout", Level: "DEBUG", Message: "other app"})
+
+	if got := len(next.records); got != 2 {
+		t.Fatalf("forwarded records = %d, want 2", got)
+	}
+	if next.records[0].Message != "kept" || next.records[1].Message != "other app" {
+		t.Fatalf("unexpected forwarded records: %q, %q", next.records[0].Message, next.records[1].Message)
+	}
+
+	suppressed := sink.Suppressed()
+	if got := suppressed["payments"]["DEBUG"]; got != 1 {
+		t.Fatalf("suppressed payments/DEBUG = %d, want 1", got)
+	}
+	if got := sink.SuppressedTotal(); got != 1 {
+		t.Fatalf("SuppressedTotal = %d, want 1", got)
+	}
+}
+
+func TestSeverityThresholdSink_WildcardFallback(t *testing.T) {
+	t.Parallel()
+
+	next := &recordingSink{}
+	sink, err := NewSeverityThresholdSink(next, map[string]string{
+		AllAppsKey: "WARN",
+		"checkout": "DEBUG",
+	})
+	if err != nil {
+		t.Fatalf("NewSeverityThresholdSink: %v", err)
+	}
+
+	sink.Add(&model.LogRecord{App: "api", Level: "INFO"})
+	sink.Add(&model.LogRecord{App: "api", Level: "ERROR"})
+	sink.Add(&model.LogRecord{App: "checkout", Level: "DEBUG"})
+	sink.Add(&model.LogRecord{App: "checkout", Level: "TRACE"})
+
+	if got := len(next.records); got != 2 {
+		t.Fatalf("forwarded records = %d, want 2", got)
+	}
+	suppressed := sink.Suppressed()
+	if suppressed["api"]["INFO"] != 1 || suppressed["checkout"]["TRACE"] != 1 {
+		t.Fatalf("unexpected suppressed counts: %v", suppressed)
+	}
+}
+
+func TestSeverityThresholdSink_RejectsUnknownSeverity(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewSeverityThresholdSink(nil, map[string]string{"api": "loud"}); err == nil {
+		t.Fatal("expected error for unknown severity")
+	}
+}
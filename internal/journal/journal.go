@@ -20,6 +20,8 @@ const (
 	defaultDirMode  = 0755
 )
 
+var errJournalClosed = errors.New("journal: closed")
+
 type entry struct {
 	Seq    uint64          `json:"seq"`
 	Record model.LogRecord `json:"record"`
@@ -34,6 +36,7 @@ type Journal struct {
 	file       *os.File
 	nextSeq    uint64
 	committed  uint64
+	writeErr   error // of the last Append; see Check
 }
 
 // Open creates or opens a journal at path. On startup it compacts committed
@@ -84,6 +87,9 @@ func (j *Journal) Append(record *model.LogRecord) (uint64, error) {
 
 	j.mu.Lock()
 	defer j.mu.Unlock()
+	if j.file == nil {
+		return 0, errJournalClosed
+	}
 
 	seq := j.nextSeq
 	j.nextSeq++
@@ -99,14 +105,35 @@ func (j *Journal) Append(record *model.LogRecord) (uint64, error) {
 	line = append(line, '\n')
 
 	if _, err := j.file.Write(line); err != nil {
-		return 0, fmt.Errorf("journal: write entry: %w", err)
+		j.writeErr = fmt.Errorf("journal: write entry: %w", err)
+		return 0, j.writeErr
 	}
 	if err := j.file.Sync(); err != nil {
-		return 0, fmt.Errorf("journal: sync entry: %w", err)
+		j.writeErr = fmt.Errorf("journal: sync entry: %w", err)
+		return 0, j.writeErr
 	}
+	j.writeErr = nil
 	return seq, nil
 }
 
+// Check reports why the journal cannot take records: it is closed, its
+// last append failed, or its file can no longer be opened for writing.
+func (j *Journal) Check() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return errJournalClosed
+	}
+	if j.writeErr != nil {
+		return j.writeErr
+	}
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, defaultFileMode)
+	if err != nil {
+		return fmt.Errorf("journal: not writable: %w", err)
+	}
+	return f.Close()
+}
+
 // Commit marks all entries up to seq as committed.
 func (j *Journal) Commit(seq uint64) error {
 	j.mu.Lock()
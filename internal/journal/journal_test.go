@@ -113,3 +113,22 @@ func TestOpenIgnoresPartialTrailingLine(t *testing.T) {
 		t.Fatalf("Replay after torn write=%v, want [ok]", replayed)
 	}
 }
+
+func TestCheck(t *testing.T) {
+	j, err := Open(filepath.Join(t.TempDir(), "ingest.journal"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := j.Check(); err != nil {
+		t.Fatalf("Check on an open journal: %v", err)
+	}
+	if err := j.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := j.Check(); err == nil {
+		t.Error("Check on a closed journal = nil, want an error")
+	}
+	if _, err := j.Append(&model.LogRecord{Message: "late"}); err == nil {
+		t.Error("Append on a closed journal = nil, want an error")
+	}
+}
@@ -0,0 +1,98 @@
+package logsource
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/tcpserver"
+)
+
+// DefaultTCPBuffer is the default channel buffer size for TCP lines.
+const DefaultTCPBuffer = 50_000
+
+// TCPConfig holds tunable parameters for the TCP source.
+type TCPConfig struct {
+	BufferSize  int
+	MaxLineSize int
+	Listener    net.Listener // pre-opened listener; addr is ignored when set
+	Acks        bool         // acknowledge batches once processed (tcpserver.Config.Acks)
+	TLS         *tls.Config  // serve TLS (tcpserver.Config.TLS)
+}
+
+// TCPSource receives newline-delimited logs or length-prefixed binary OTLP
+// frames (plain, gzip, or zstd) over TCP.
+type TCPSource struct {
+	ch       chan model.IngestEnvelope
+	ctx      context.Context
+	cancel   context.CancelFunc
+	server   *tcpserver.Server
+	stopOnce sync.Once
+}
+
+// NewTCPSource starts listening on addr and returns a source of its lines.
+func NewTCPSource(ctx context.Context, addr string, conf ...TCPConfig) (*TCPSource, error) {
+	bufferSize := DefaultTCPBuffer
+	var serverConf tcpserver.Config
+	if len(conf) > 0 {
+		if conf[0].BufferSize > 0 {
+			bufferSize = conf[0].BufferSize
+		}
+		serverConf.MaxLineSize = conf[0].MaxLineSize
+		serverConf.Listener = conf[0].Listener
+		serverConf.Acks = conf[0].Acks
+		serverConf.TLS = conf[0].TLS
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &TCPSource{
+		ch:     make(chan model.IngestEnvelope, bufferSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	serverConf.FrameHandler = s.pushFrame
+	s.server = tcpserver.NewServer(addr, s.push, serverConf)
+	if err := s.server.Start(); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Stop the source when the parent context ends.
+	go func() {
+		<-ctx.Done()
+		s.Stop()
+	}()
+	return s, nil
+}
+
+// push blocks while the channel is full, so slow processing applies
+// backpressure to senders instead of dropping lines.
+func (s *TCPSource) push(line string, done func()) {
+	select {
+	case s.ch <- model.IngestEnvelope{Source: s.Name(), Line: line, Done: done}:
+	case <-s.ctx.Done():
+	}
+}
+
+// pushFrame forwards a binary OTLP frame with the same backpressure as push.
+func (s *TCPSource) pushFrame(frame []byte, done func()) {
+	select {
+	case s.ch <- model.IngestEnvelope{Source: s.Name(), OTLP: frame, Done: done}:
+	case <-s.ctx.Done():
+	}
+}
+
+// Addr returns the actual listen address.
+func (s *TCPSource) Addr() string { return s.server.Addr() }
+
+func (s *TCPSource) Lines() <-chan model.IngestEnvelope { return s.ch }
+func (s *TCPSource) Stop() {
+	s.stopOnce.Do(func() {
+		s.cancel()
+		s.server.Stop()
+		close(s.ch)
+	})
+}
+func (s *TCPSource) Name() string { return "tcp" }
@@ -0,0 +1,54 @@
+package logsource
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestTCPSource_EmitsLines(t *testing.T) {
+	t.Parallel()
+
+	src, err := NewTCPSource(context.Background(), "127.0.0.1:0", TCPConfig{BufferSize: 4})
+	if err != nil {
+		t.Fatalf("NewTCPSource: %v", err)
+	}
+	defer src.Stop()
+
+	conn, err := net.Dial("tcp", src.Addr())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	conn.Write([]byte("hello over tcp\n"))
+
+	select {
+	case env := <-src.Lines():
+		if env.Line != "hello over tcp" || env.Source != "tcp" {
+			t.Fatalf("unexpected envelope: %+v", env)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for line")
+	}
+}
+
+func TestTCPSource_StopClosesChannel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	src, err := NewTCPSource(ctx, "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("NewTCPSource: %v", err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-src.Lines():
+		if ok {
+			t.Fatal("expected closed channel")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel not closed after context cancel")
+	}
+}
@@ -0,0 +1,70 @@
+package lumberjack
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
+	"github.com/tinytelemetry/tiny-telemetry/internal/logparse"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// convertEvent converts one Beats JSON event into a model.LogRecord.
+// Nested event fields are flattened into dotted attribute keys (host.name,
+// log.file.path, fields.env, ...). Returns nil when the payload is not a JSON object.
+func convertEvent(payload []byte) *model.LogRecord {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil
+	}
+
+	message := ingest.ExtractStringField(raw, "message")
+	delete(raw, "message")
+
+	var origTimestamp time.Time
+	if ts := ingest.ExtractStringField(raw, "@timestamp"); ts != "" {
+		if parsed, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			origTimestamp = parsed
+		}
+	}
+	delete(raw, "@timestamp")
+
+	attributes := make(map[string]string, len(raw))
+	ingest.FlattenJSONAttributes("", raw, attributes)
+
+	if message == "" {
+		message = string(payload)
+	}
+	message = ingest.SanitizeMessage(message)
+
+	level := ""
+	for _, key := range []string{"log.level", "level", "fields.level", "severity"} {
+		if v := attributes[key]; v != "" {
+			level = v
+			break
+		}
+	}
+	if level == "" {
+		level = logparse.ExtractSeverityFromText(message)
+	}
+	normalizedSeverity := logparse.NormalizeSeverity(level)
+
+	app := ingest.ExtractApp(attributes)
+	if app == "" {
+		app = "default"
+	}
+
+	return &model.LogRecord{
+		Timestamp:     time.Now(),
+		OrigTimestamp: origTimestamp,
+		Level:         normalizedSeverity,
+		LevelNum:      ingest.DefaultSeverityNumber(normalizedSeverity),
+		Message:       message,
+		RawLine:       string(payload),
+		Attributes:    attributes,
+		Source:        "beats",
+		App:           app,
+		Service:       ingest.ExtractService(attributes),
+		Hostname:      ingest.ExtractHostname(attributes),
+	}
+}
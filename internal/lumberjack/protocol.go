@@ -0,0 +1,188 @@
+package lumberjack
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Lumberjack v2 wire format (as spoken by Filebeat/Winlogbeat/etc.)
+//
+//   Frame         Layout
+//   ──────────    ─────────────────────────────────────────────────────────
+//   Window   'W'  '2' 'W' uint32(window size)
+//   JSON     'J'  '2' 'J' uint32(seq) uint32(len) <len bytes of JSON event>
+//   Compress 'C'  '2' 'C' uint32(len) <len bytes of zlib data holding frames>
+//   Ack      'A'  '2' 'A' uint32(seq)                       (server -> client)
+//
+// All integers are big-endian. Sequence numbers restart at 1 in every window;
+// acking seq N acknowledges every event in the window up to and including N.
+
+const (
+	protocolVersion = '2'
+
+	frameWindow     = 'W'
+	frameJSON       = 'J'
+	frameCompressed = 'C'
+	frameAck        = 'A'
+
+	// maxFrameSize bounds JSON and compressed payloads (16 MB, matching the OTLP receiver).
+	maxFrameSize = 16 << 20
+)
+
+// ErrUnsupportedVersion is returned when a client speaks anything other than lumberjack v2.
+var ErrUnsupportedVersion = errors.New("lumberjack: unsupported protocol version")
+
+// event is one decoded JSON event and its in-window sequence number.
+type event struct {
+	seq     uint32
+	payload []byte
+}
+
+// frame is one decoded top-level frame.
+type frame struct {
+	kind   byte
+	window uint32  // set for window frames
+	events []event // set for JSON and compressed frames
+}
+
+// frameReader decodes lumberjack v2 frames from a stream.
+type frameReader struct {
+	r *bufio.Reader
+}
+
+func newFrameReader(r io.Reader) *frameReader {
+	if br, ok := r.(*bufio.Reader); ok {
+		return &frameReader{r: br}
+	}
+	return &frameReader{r: bufio.NewReader(r)}
+}
+
+// next reads one frame. Compressed frames are inflated and returned as the
+// events they contain.
+func (fr *frameReader) next() (frame, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(fr.r, header[:]); err != nil {
+		return frame{}, err
+	}
+	if header[0] != protocolVersion {
+		return frame{}, fmt.Errorf("%w: %q", ErrUnsupportedVersion, header[0])
+	}
+
+	f := frame{kind: header[1]}
+	switch f.kind {
+	case frameWindow:
+		size, err := fr.readUint32()
+		if err != nil {
+			return frame{}, err
+		}
+		f.window = size
+
+	case frameJSON:
+		ev, err := fr.readJSON()
+		if err != nil {
+			return frame{}, err
+		}
+		f.events = []event{ev}
+
+	case frameCompressed:
+		events, err := fr.readCompressed()
+		if err != nil {
+			return frame{}, err
+		}
+		f.events = events
+
+	default:
+		return frame{}, fmt.Errorf("lumberjack: unknown frame type %q", f.kind)
+	}
+	return f, nil
+}
+
+// buffered reports whether more decoded input is already waiting.
+func (fr *frameReader) buffered() bool {
+	return fr.r.Buffered() > 0
+}
+
+func (fr *frameReader) readUint32() (uint32, error) {
+	var buf [4]byte
+	if _, err := io.ReadFull(fr.r, buf[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(buf[:]), nil
+}
+
+func (fr *frameReader) readPayload() ([]byte, error) {
+	size, err := fr.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if size > maxFrameSize {
+		return nil, fmt.Errorf("lumberjack: frame of %d bytes exceeds %d byte limit", size, maxFrameSize)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(fr.r, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func (fr *frameReader) readJSON() (event, error) {
+	seq, err := fr.readUint32()
+	if err != nil {
+		return event{}, err
+	}
+	payload, err := fr.readPayload()
+	if err != nil {
+		return event{}, err
+	}
+	return event{seq: seq, payload: payload}, nil
+}
+
+// readCompressed inflates a 'C' frame and decodes the frames inside it.
+func (fr *frameReader) readCompressed() ([]event, error) {
+	payload, err := fr.readPayload()
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("lumberjack: compressed frame: %w", err)
+	}
+	defer zr.Close()
+
+	// Bound the inflated size so a small frame cannot expand without limit.
+	inflated, err := io.ReadAll(io.LimitReader(zr, maxFrameSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("lumberjack: compressed frame: %w", err)
+	}
+	if len(inflated) > maxFrameSize {
+		return nil, fmt.Errorf("lumberjack: compressed frame inflates past %d bytes", maxFrameSize)
+	}
+
+	inner := newFrameReader(bytes.NewReader(inflated))
+	var events []event
+	for {
+		f, err := inner.next()
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, f.events...)
+	}
+}
+
+// writeAck writes an 'A' frame acknowledging seq.
+func writeAck(w io.Writer, seq uint32) error {
+	var buf [6]byte
+	buf[0] = protocolVersion
+	buf[1] = frameAck
+	binary.BigEndian.PutUint32(buf[2:], seq)
+	_, err := w.Write(buf[:])
+	return err
+}
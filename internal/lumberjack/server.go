@@ -0,0 +1,169 @@
+// Package lumberjack implements a Beats (lumberjack v2) log receiver so
+// Filebeat and friends can ship directly to tiny-telemetry.
+package lumberjack
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// idleTimeout closes connections that send nothing for this long.
+// Beats keeps connections open between batches but re-dials on demand.
+const idleTimeout = 5 * time.Minute
+
+// Server is a lumberjack v2 log receiver.
+//
+// Events are handed to the sink as they are decoded. Because the sink
+// (InsertBuffer) journals synchronously in Add, a window is acknowledged
+// only after every event in it has been accepted for storage; a client that
+// never sees the ack resends the window.
+type Server struct {
+	addr     string
+	sink     model.RecordSink
+	listener net.Listener
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+
+	connMu sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+// NewServer creates a new lumberjack server.
+func NewServer(addr string, sink model.RecordSink) *Server {
+	return &Server{
+		addr:  addr,
+		sink:  sink,
+		conns: make(map[net.Conn]struct{}),
+	}
+}
+
+// Start begins listening and accepting connections in a background goroutine.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.listener = ln
+
+	s.wg.Add(1)
+	go s.acceptLoop()
+	return nil
+}
+
+// Stop closes the listener and all open connections, then waits for
+// connection handlers to exit.
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() {
+		if s.listener != nil {
+			s.listener.Close()
+		}
+		s.connMu.Lock()
+		s.closed = true
+		for conn := range s.conns {
+			conn.Close()
+		}
+		s.connMu.Unlock()
+		s.wg.Wait()
+	})
+}
+
+// Addr returns the actual listen address (useful when port 0 is used).
+func (s *Server) Addr() string {
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+	return s.addr
+}
+
+func (s *Server) acceptLoop() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Printf("lumberjack: accept: %v", err)
+			}
+			return
+		}
+		if !s.track(conn) {
+			conn.Close()
+			return
+		}
+		s.wg.Add(1)
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) track(conn net.Conn) bool {
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	return true
+}
+
+func (s *Server) untrack(conn net.Conn) {
+	s.connMu.Lock()
+	delete(s.conns, conn)
+	s.connMu.Unlock()
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer s.wg.Done()
+	defer s.untrack(conn)
+	defer conn.Close()
+
+	fr := newFrameReader(bufio.NewReader(conn))
+	var (
+		window   uint32 // announced window size; 0 until the first 'W' frame
+		received uint32 // events received in the current window
+		lastSeq  uint32
+	)
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(idleTimeout))
+		f, err := fr.next()
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				log.Printf("lumberjack: %s: %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+
+		if f.kind == frameWindow {
+			window = f.window
+			received = 0
+			continue
+		}
+
+		for _, ev := range f.events {
+			if record := convertEvent(ev.payload); record != nil {
+				s.sink.Add(record)
+			}
+			lastSeq = ev.seq
+			received++
+		}
+		if len(f.events) == 0 {
+			continue
+		}
+
+		// Ack when the window is complete. Also ack progress whenever the
+		// client has paused, so a slow or partial window does not stall it.
+		if window == 0 || received >= window || !fr.buffered() {
+			if err := writeAck(conn, lastSeq); err != nil {
+				log.Printf("lumberjack: %s: ack: %v", conn.RemoteAddr(), err)
+				return
+			}
+		}
+	}
+}
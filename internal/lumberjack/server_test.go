@@ -0,0 +1,167 @@
+package lumberjack
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+type mockSink struct {
+	mu      sync.Mutex
+	records []*model.LogRecord
+}
+
+func (m *mockSink) Add(r *model.LogRecord) {
+	m.mu.Lock()
+	m.records = append(m.records, r)
+	m.mu.Unlock()
+}
+
+func (m *mockSink) snapshot() []*model.LogRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*model.LogRecord(nil), m.records...)
+}
+
+func windowFrame(size uint32) []byte {
+	buf := []byte{protocolVersion, frameWindow, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(buf[2:], size)
+	return buf
+}
+
+func jsonFrame(seq uint32, payload string) []byte {
+	buf := make([]byte, 10, 10+len(payload))
+	buf[0] = protocolVersion
+	buf[1] = frameJSON
+	binary.BigEndian.PutUint32(buf[2:], seq)
+	binary.BigEndian.PutUint32(buf[6:], uint32(len(payload)))
+	return append(buf, payload...)
+}
+
+func compressedFrame(t *testing.T, inner []byte) []byte {
+	t.Helper()
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	if _, err := zw.Write(inner); err != nil {
+		t.Fatalf("zlib write: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zlib close: %v", err)
+	}
+	buf := make([]byte, 6, 6+z.Len())
+	buf[0] = protocolVersion
+	buf[1] = frameCompressed
+	binary.BigEndian.PutUint32(buf[2:], uint32(z.Len()))
+	return append(buf, z.Bytes()...)
+}
+
+func startServer(t *testing.T) (*Server, *mockSink, net.Conn) {
+	t.Helper()
+	sink := &mockSink{}
+	srv := NewServer("127.0.0.1:0", sink)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(srv.Stop)
+
+	conn, err := net.Dial("tcp", srv.Addr())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return srv, sink, conn
+}
+
+func readAck(t *testing.T, conn net.Conn) uint32 {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var buf [6]byte
+	if _, err := io.ReadFull(conn, buf[:]); err != nil {
+		t.Fatalf("read ack: %v", err)
+	}
+	if buf[0] != protocolVersion || buf[1] != frameAck {
+		t.Fatalf("unexpected ack header %q", buf[:2])
+	}
+	return binary.BigEndian.Uint32(buf[2:])
+}
+
+func TestServer_AcksWindowAfterSinkAccepts(t *testing.T) {
+	t.Parallel()
+
+	_, sink, conn := startServer(t)
+
+	var batch []byte
+	batch = append(batch, windowFrame(2)...)
+	batch = append(batch, jsonFrame(1, `{"@timestamp":"2024-01-15T10:30:00Z","message":"first","host":{"name":"web-1"}}`)...)
+	batch = append(batch, jsonFrame(2, `{"message":"ERROR second","fields":{"app":"checkout"}}`)...)
+	if _, err := conn.Write(batch); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if seq := readAck(t, conn); seq != 2 {
+		t.Fatalf("ack seq = %d, want 2", seq)
+	}
+
+	records := sink.snapshot()
+	if len(records) != 2 {
+		t.Fatalf("records = %d, want 2", len(records))
+	}
+	if records[0].Message != "first" || records[0].Source != "beats" {
+		t.Fatalf("unexpected first record: %+v", records[0])
+	}
+	if records[0].Attributes["host.name"] != "web-1" || records[0].Hostname != "web-1" {
+		t.Fatalf("host.name not mapped: %+v", records[0])
+	}
+	if records[0].OrigTimestamp.IsZero() {
+		t.Fatal("expected @timestamp to populate OrigTimestamp")
+	}
+	if records[1].Level != "ERROR" {
+		t.Fatalf("second record level = %q, want ERROR", records[1].Level)
+	}
+}
+
+func TestServer_CompressedFrame(t *testing.T) {
+	t.Parallel()
+
+	_, sink, conn := startServer(t)
+
+	var inner []byte
+	for i := uint32(1); i <= 3; i++ {
+		inner = append(inner, jsonFrame(i, `{"message":"compressed"}`)...)
+	}
+	batch := append(windowFrame(3), compressedFrame(t, inner)...)
+	if _, err := conn.Write(batch); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if seq := readAck(t, conn); seq != 3 {
+		t.Fatalf("ack seq = %d, want 3", seq)
+	}
+	if got := len(sink.snapshot()); got != 3 {
+		t.Fatalf("records = %d, want 3", got)
+	}
+}
+
+func TestServer_RejectsUnknownVersion(t *testing.T) {
+	t.Parallel()
+
+	_, sink, conn := startServer(t)
+
+	if _, err := conn.Write([]byte{'1', frameWindow, 0, 0, 0, 1}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := conn.Read(make([]byte, 1)); err == nil {
+		t.Fatal("expected server to close connection")
+	}
+	if got := len(sink.snapshot()); got != 0 {
+		t.Fatalf("records = %d, want 0", got)
+	}
+}
@@ -0,0 +1,645 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// exportChunk is how many records StreamLogs hands over at a time.
+const exportChunk = 5000
+
+// The queries below mirror the DuckDB backend's SQL, including ordering and
+// tie-breaks, so both backends return the same answers for the same data.
+
+// each calls fn for every record matching opts. Callers hold s.mu.
+func (s *Store) each(opts model.QueryOpts, fn func(r *model.LogRecord)) {
+	for i := range s.records {
+		if r := &s.records[i]; inScope(r, opts) {
+			fn(r)
+		}
+	}
+}
+
+// inScope reports whether r is in opts's app and time range.
+func inScope(r *model.LogRecord, opts model.QueryOpts) bool {
+	if opts.App != "" && r.App != opts.App {
+		return false
+	}
+	if !opts.From.IsZero() && r.Timestamp.Before(opts.From) {
+		return false
+	}
+	return opts.To.IsZero() || r.Timestamp.Before(opts.To)
+}
+
+// TopWords returns the most frequent words.
+func (s *Store) TopWords(limit int, opts model.QueryOpts) ([]model.WordCount, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int64)
+	s.each(opts, func(r *model.LogRecord) {
+		for _, word := range strings.Split(strings.ToLower(r.Message), " ") {
+			word = strings.TrimFunc(word, func(c rune) bool { return !isWordRune(c) })
+			if n := utf8.RuneCountInString(word); n >= 3 && n <= 50 {
+				counts[word]++
+			}
+		}
+	})
+
+	results := make([]model.WordCount, 0, len(counts))
+	for word, count := range counts {
+		results = append(results, model.WordCount{Word: word, Count: count})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+		return results[i].Word < results[j].Word
+	})
+	return truncate(results, limit), nil
+}
+
+func isWordRune(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_'
+}
+
+// TopAttributes returns the most frequent attribute key-value pairs.
+func (s *Store) TopAttributes(limit int, opts model.QueryOpts) ([]model.AttributeStat, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type pair struct{ key, value string }
+	counts := make(map[pair]int64)
+	s.each(opts, func(r *model.LogRecord) {
+		for k, v := range r.Attributes {
+			counts[pair{k, v}]++
+		}
+	})
+
+	results := make([]model.AttributeStat, 0, len(counts))
+	for p, count := range counts {
+		results = append(results, model.AttributeStat{Key: p.key, Value: p.value, Count: count})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		return a.Value < b.Value
+	})
+	return truncate(results, limit), nil
+}
+
+// TopAttributeKeys returns attribute keys sorted by number of unique values.
+func (s *Store) TopAttributeKeys(limit int, opts model.QueryOpts) ([]model.AttributeKeyStat, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	values := make(map[string]map[string]struct{})
+	totals := make(map[string]int64)
+	s.each(opts, func(r *model.LogRecord) {
+		for k, v := range r.Attributes {
+			if values[k] == nil {
+				values[k] = make(map[string]struct{})
+			}
+			values[k][v] = struct{}{}
+			totals[k]++
+		}
+	})
+
+	results := make([]model.AttributeKeyStat, 0, len(values))
+	for key, uniq := range values {
+		results = append(results, model.AttributeKeyStat{Key: key, UniqueValues: len(uniq), TotalCount: totals[key]})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].UniqueValues != results[j].UniqueValues {
+			return results[i].UniqueValues > results[j].UniqueValues
+		}
+		return results[i].Key < results[j].Key
+	})
+	return truncate(results, limit), nil
+}
+
+// AttributeKeyValues returns value counts for a specific attribute key.
+func (s *Store) AttributeKeyValues(key string, limit int, opts model.QueryOpts) (map[string]int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int64)
+	s.each(opts, func(r *model.LogRecord) {
+		if v, ok := r.Attributes[key]; ok {
+			counts[v]++
+		}
+	})
+
+	ranked := make([]model.DimensionCount, 0, len(counts))
+	for value, count := range counts {
+		ranked = append(ranked, model.DimensionCount{Value: value, Count: count})
+	}
+	sortDimensions(ranked)
+
+	result := make(map[string]int64)
+	for _, item := range truncate(ranked, limit) {
+		result[item.Value] = item.Count
+	}
+	return result, nil
+}
+
+// DistinctAttributeValues returns the most frequent non-empty values of
+// attribute key that start with prefix, ignoring case.
+func (s *Store) DistinctAttributeValues(key, prefix string, limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
+	prefix = strings.ToLower(prefix)
+	return s.topDimension(limit, opts, func(r *model.LogRecord) (string, bool) {
+		v := r.Attributes[key]
+		return v, v != "" && strings.HasPrefix(strings.ToLower(v), prefix)
+	})
+}
+
+// SeverityCounts returns the total count per severity level.
+func (s *Store) SeverityCounts(opts model.QueryOpts) (map[string]int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string]int64)
+	s.each(opts, func(r *model.LogRecord) {
+		result[r.Level]++
+	})
+	return result, nil
+}
+
+// SeverityCountsByMinute returns per-minute severity breakdowns for all logs.
+func (s *Store) SeverityCountsByMinute(opts model.QueryOpts) ([]model.MinuteCounts, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byMinute := make(map[int64]*model.MinuteCounts)
+	s.each(opts, func(r *model.LogRecord) {
+		minute := r.Timestamp.UTC().Truncate(time.Minute)
+		mc := byMinute[minute.Unix()]
+		if mc == nil {
+			mc = &model.MinuteCounts{Minute: minute}
+			byMinute[minute.Unix()] = mc
+		}
+		switch r.Level {
+		case "TRACE":
+			mc.Trace++
+		case "DEBUG":
+			mc.Debug++
+		case "INFO":
+			mc.Info++
+		case "WARN":
+			mc.Warn++
+		case "ERROR":
+			mc.Error++
+		case "FATAL":
+			mc.Fatal++
+		}
+		mc.Total++
+	})
+
+	results := make([]model.MinuteCounts, 0, len(byMinute))
+	for _, mc := range byMinute {
+		results = append(results, *mc)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Minute.Before(results[j].Minute) })
+	return results, nil
+}
+
+// TotalLogCount returns the total number of stored logs.
+func (s *Store) TotalLogCount(opts model.QueryOpts) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var count int64
+	s.each(opts, func(*model.LogRecord) { count++ })
+	return count, nil
+}
+
+// TotalLogBytes returns the total raw-line length of stored logs, counted in
+// characters like DuckDB's length().
+func (s *Store) TotalLogBytes(opts model.QueryOpts) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var total int64
+	s.each(opts, func(r *model.LogRecord) {
+		total += int64(utf8.RuneCountInString(r.RawLine))
+	})
+	return total, nil
+}
+
+// TopHosts returns hostnames by descending log count.
+func (s *Store) TopHosts(limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
+	return s.topDimension(limit, opts, func(r *model.LogRecord) (string, bool) {
+		return r.Hostname, true
+	})
+}
+
+// TopServices returns services by descending log count.
+func (s *Store) TopServices(limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
+	return s.topDimension(limit, opts, func(r *model.LogRecord) (string, bool) {
+		return r.Service, true
+	})
+}
+
+// TopServicesBySeverity returns the top services for a given severity level.
+func (s *Store) TopServicesBySeverity(severity string, limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
+	return s.topDimension(limit, opts, func(r *model.LogRecord) (string, bool) {
+		return r.Service, r.Level == severity
+	})
+}
+
+// topDimension counts the value returned by pick for each matching record;
+// empty values are reported as "unknown".
+func (s *Store) topDimension(limit int, opts model.QueryOpts, pick func(r *model.LogRecord) (string, bool)) ([]model.DimensionCount, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int64)
+	s.each(opts, func(r *model.LogRecord) {
+		value, ok := pick(r)
+		if !ok {
+			return
+		}
+		if value == "" {
+			value = "unknown"
+		}
+		counts[value]++
+	})
+
+	results := make([]model.DimensionCount, 0, len(counts))
+	for value, count := range counts {
+		results = append(results, model.DimensionCount{Value: value, Count: count})
+	}
+	sortDimensions(results)
+	return truncate(results, limit), nil
+}
+
+func sortDimensions(items []model.DimensionCount) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].Value < items[j].Value
+	})
+}
+
+// RateByDimension returns log counts per step-wide bucket for each value of
+// dimension over the last window, optionally limited to severity levels.
+func (s *Store) RateByDimension(dimension string, window, step time.Duration, severityLevels []string, opts model.QueryOpts) ([]model.DimensionRate, error) {
+	opts, err := model.RateScope(dimension, window, step, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type key struct {
+		bucket int64
+		value  string
+	}
+	counts := make(map[key]int64)
+	s.each(opts, func(r *model.LogRecord) {
+		if len(severityLevels) > 0 && !slices.Contains(severityLevels, r.Level) {
+			return
+		}
+		var value string
+		switch dimension {
+		case "service":
+			value = r.Service
+		case "host":
+			value = r.Hostname
+		case "app":
+			value = r.App
+		case "level":
+			value = r.Level
+		}
+		if value == "" {
+			value = "unknown"
+		}
+		// Align buckets to the Unix epoch, like DuckDB's time_bucket.
+		ns := r.Timestamp.UnixNano()
+		counts[key{ns - ns%int64(step), value}]++
+	})
+
+	results := make([]model.DimensionRate, 0, len(counts))
+	for k, count := range counts {
+		results = append(results, model.DimensionRate{Bucket: time.Unix(0, k.bucket).UTC(), Value: k.value, Count: count})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if !a.Bucket.Equal(b.Bucket) {
+			return a.Bucket.Before(b.Bucket)
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Value < b.Value
+	})
+	return results, nil
+}
+
+// IngestRate returns the logs, and their raw-line length, per step-wide
+// bucket over the last window.
+func (s *Store) IngestRate(window, step time.Duration, opts model.QueryOpts) ([]model.IngestPoint, error) {
+	opts, err := model.IngestScope(window, step, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	points := make(map[int64]*model.IngestPoint)
+	s.each(opts, func(r *model.LogRecord) {
+		ns := r.Timestamp.UnixNano()
+		bucket := ns - ns%int64(step)
+		p := points[bucket]
+		if p == nil {
+			p = &model.IngestPoint{Bucket: time.Unix(0, bucket).UTC()}
+			points[bucket] = p
+		}
+		p.Count++
+		p.Bytes += int64(utf8.RuneCountInString(r.RawLine))
+	})
+
+	results := make([]model.IngestPoint, 0, len(points))
+	for _, p := range points {
+		results = append(results, *p)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Bucket.Before(results[j].Bucket) })
+	return results, nil
+}
+
+// Catalog returns the values of dimension (app, service, or host) with
+// their log counts and latest log timestamp, most logs first.
+func (s *Store) Catalog(dimension string, limit int, opts model.QueryOpts) ([]model.CatalogEntry, error) {
+	if !slices.Contains(model.CatalogDimensions, dimension) {
+		return nil, fmt.Errorf("invalid dimension %q (want one of %v)", dimension, model.CatalogDimensions)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make(map[string]*model.CatalogEntry)
+	s.each(opts, func(r *model.LogRecord) {
+		value := r.App
+		switch dimension {
+		case "service":
+			value = r.Service
+		case "host":
+			value = r.Hostname
+		}
+		if value == "" {
+			value = "unknown"
+		}
+		e, ok := entries[value]
+		if !ok {
+			e = &model.CatalogEntry{Value: value}
+			entries[value] = e
+		}
+		e.Count++
+		if r.Timestamp.After(e.LastSeen) {
+			e.LastSeen = r.Timestamp
+		}
+	})
+
+	results := make([]model.CatalogEntry, 0, len(entries))
+	for _, e := range entries {
+		results = append(results, *e)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+		return results[i].Value < results[j].Value
+	})
+	return truncate(results, limit), nil
+}
+
+// ListApps returns all distinct app names.
+func (s *Store) ListApps() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]struct{})
+	var apps []string
+	for i := range s.records {
+		app := s.records[i].App
+		if _, ok := seen[app]; !ok {
+			seen[app] = struct{}{}
+			apps = append(apps, app)
+		}
+	}
+	sort.Strings(apps)
+	return apps, nil
+}
+
+// RecentLogsFiltered returns recent log records with optional filtering by app,
+// time range, severity levels, facets, and message pattern (regex), oldest
+// first.
+func (s *Store) RecentLogsFiltered(limit int, opts model.QueryOpts, severityLevels []string, facets map[string]string, messagePattern string) ([]model.LogRecord, error) {
+	var re *regexp.Regexp
+	if messagePattern != "" {
+		var err error
+		if re, err = regexp.Compile(messagePattern); err != nil {
+			return nil, fmt.Errorf("invalid message pattern: %w", err)
+		}
+	}
+	var levels map[string]struct{}
+	if len(severityLevels) > 0 {
+		levels = make(map[string]struct{}, len(severityLevels))
+		for _, lvl := range severityLevels {
+			levels[lvl] = struct{}{}
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matched []*model.LogRecord
+	s.each(opts, func(r *model.LogRecord) {
+		if levels != nil {
+			if _, ok := levels[r.Level]; !ok {
+				return
+			}
+		}
+		if !model.MatchesFacets(r, facets) {
+			return
+		}
+		if re != nil && !re.MatchString(r.Message) {
+			return
+		}
+		matched = append(matched, r)
+	})
+
+	sortByTimestamp(matched)
+	if limit >= 0 && len(matched) > limit {
+		matched = matched[len(matched)-limit:]
+	}
+	return copyRecords(matched), nil
+}
+
+// SearchLogs performs a case-insensitive substring search on log messages,
+// newest first.
+func (s *Store) SearchLogs(term string, limit int, opts model.QueryOpts) ([]model.LogRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	needle := strings.ToLower(term)
+	var matched []*model.LogRecord
+	s.each(opts, func(r *model.LogRecord) {
+		if strings.Contains(strings.ToLower(r.Message), needle) {
+			matched = append(matched, r)
+		}
+	})
+
+	sortByTimestamp(matched)
+	for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+		matched[i], matched[j] = matched[j], matched[i]
+	}
+	return copyRecords(truncate(matched, limit)), nil
+}
+
+// FindLogs returns a page of the logs matching filter, newest first (ties
+// by insertion, latest first), and the cursor of the next page.
+func (s *Store) FindLogs(filter model.LogFilter) (model.LogPage, error) {
+	if filter.Limit <= 0 || filter.Limit > model.MaxLogPage {
+		return model.LogPage{}, fmt.Errorf("limit must be between 1 and %d", model.MaxLogPage)
+	}
+	var cursor model.LogCursor
+	if filter.Cursor != "" {
+		var err error
+		if cursor, err = model.ParseLogCursor(filter.Cursor); err != nil {
+			return model.LogPage{}, err
+		}
+	}
+	var re *regexp.Regexp
+	if filter.Pattern != "" {
+		var err error
+		if re, err = regexp.Compile(filter.Pattern); err != nil {
+			return model.LogPage{}, fmt.Errorf("invalid message pattern: %w", err)
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matched []*model.LogRecord
+	s.each(filter.QueryOpts, func(r *model.LogRecord) {
+		if filter.Cursor != "" && r.Timestamp.After(cursor.Timestamp) {
+			return
+		}
+		if len(filter.Levels) > 0 && !slices.Contains(filter.Levels, r.Level) {
+			return
+		}
+		for key, value := range filter.Attributes {
+			if v, ok := r.Attributes[key]; !ok || v != value {
+				return
+			}
+		}
+		if re != nil && !re.MatchString(r.Message) {
+			return
+		}
+		matched = append(matched, r)
+	})
+
+	sortByTimestamp(matched)
+	slices.Reverse(matched)
+	matched = matched[min(cursor.Skip, len(matched)):]
+	page := model.LogPage{Logs: copyRecords(truncate(matched, filter.Limit))}
+	if len(page.Logs) == filter.Limit {
+		page.NextCursor = model.NextLogCursor(cursor, page.Logs).String()
+	}
+	return page, nil
+}
+
+// StreamLogs hands the logs matching opts to fn, oldest first, in chunks,
+// until maxRows (0 = no limit) have been handed over, fn fails, or ctx
+// ends, and returns how many it handed over.
+func (s *Store) StreamLogs(ctx context.Context, opts model.QueryOpts, maxRows int64, fn func([]model.LogRecord) error) (int64, error) {
+	s.mu.RLock()
+	var matched []*model.LogRecord
+	s.each(opts, func(r *model.LogRecord) {
+		matched = append(matched, r)
+	})
+	sortByTimestamp(matched)
+	if maxRows > 0 && int64(len(matched)) > maxRows {
+		matched = matched[:maxRows]
+	}
+	records := copyRecords(matched)
+	s.mu.RUnlock()
+
+	var total int64
+	for chunk := range slices.Chunk(records, exportChunk) {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		if err := fn(chunk); err != nil {
+			return total, err
+		}
+		total += int64(len(chunk))
+	}
+	return total, nil
+}
+
+// ExecuteQuery is not supported by the in-memory backend.
+func (s *Store) ExecuteQuery(query string, args ...any) ([]map[string]interface{}, error) {
+	return nil, ErrQueryUnsupported
+}
+
+// EstimateQueryScanRows is not supported by the in-memory backend.
+func (s *Store) EstimateQueryScanRows(query string, args ...any) (int64, error) {
+	return 0, ErrQueryUnsupported
+}
+
+// GetSchemaDescription describes the record fields held in memory.
+func (s *Store) GetSchemaDescription() string {
+	return `In-memory log store (no SQL). Records: timestamp, orig_timestamp, ` +
+		`level (TRACE/DEBUG/INFO/WARN/ERROR/FATAL), level_num, message, raw_line, ` +
+		`service, hostname, pid, attributes (map), source (tcp/stdin/file), app, event_id.`
+}
+
+// TableRowCounts reports the stored records under the "logs" table name used
+// by the DuckDB backend.
+func (s *Store) TableRowCounts() (map[string]int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return map[string]int64{"logs": int64(len(s.records))}, nil
+}
+
+// sortByTimestamp sorts oldest first, keeping insertion order for ties.
+func sortByTimestamp(records []*model.LogRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+}
+
+// copyRecords returns detached copies so callers cannot mutate stored maps.
+func copyRecords(records []*model.LogRecord) []model.LogRecord {
+	out := make([]model.LogRecord, len(records))
+	for i, r := range records {
+		out[i] = *r
+		out[i].Attributes = cloneAttributes(r.Attributes)
+	}
+	return out
+}
+
+func truncate[T any](items []T, limit int) []T {
+	if limit >= 0 && len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
@@ -0,0 +1,153 @@
+// Package memstore is an in-memory StorageBackend. It keeps records in a
+// bounded slice and answers the LogQuerier contract in plain Go, so it needs
+// no cgo and no files. It is meant for embedded use, tests, and as a
+// reference when writing another backend; it does not support ad-hoc SQL.
+package memstore
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// DefaultMaxRecords bounds memory use when Config.MaxRecords is unset.
+const DefaultMaxRecords = 1_000_000
+
+// ErrQueryUnsupported is returned by ExecuteQuery and EstimateQueryScanRows;
+// the in-memory backend has no SQL engine.
+var ErrQueryUnsupported = errors.New("SQL queries are not supported by the memory storage backend")
+
+// Config holds optional settings for NewStore.
+type Config struct {
+	// MaxRecords is how many records are kept; the oldest inserted are
+	// evicted first. Defaults to DefaultMaxRecords.
+	MaxRecords int
+}
+
+// Store keeps log records in memory.
+type Store struct {
+	mu         sync.RWMutex
+	records    []model.LogRecord // insertion order
+	maxRecords int
+	evicted    int64
+}
+
+var eventIDCounter atomic.Uint64
+
+// NewStore creates an empty in-memory store.
+func NewStore(conf ...Config) *Store {
+	maxRecords := DefaultMaxRecords
+	if len(conf) > 0 && conf[0].MaxRecords > 0 {
+		maxRecords = conf[0].MaxRecords
+	}
+	return &Store{maxRecords: maxRecords}
+}
+
+// MaxRecords returns the configured capacity.
+func (s *Store) MaxRecords() int {
+	return s.maxRecords
+}
+
+// Evicted returns how many records were dropped to stay within MaxRecords.
+func (s *Store) Evicted() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.evicted
+}
+
+// Close releases the stored records.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = nil
+	return nil
+}
+
+// InsertLogBatch copies records into the store, applying the same defaults
+// as the DuckDB backend (app "default", a generated event id).
+func (s *Store) InsertLogBatch(records []*model.LogRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range records {
+		if r == nil {
+			continue
+		}
+		rec := *r
+		rec.Attributes = cloneAttributes(r.Attributes)
+		if rec.App == "" {
+			rec.App = "default"
+		}
+		if rec.EventID == "" {
+			rec.EventID = nextEventID()
+		}
+		s.records = append(s.records, rec)
+	}
+
+	if over := len(s.records) - s.maxRecords; over > 0 {
+		clear(s.records[:over])
+		s.records = s.records[over:]
+		s.evicted += int64(over)
+	}
+	return nil
+}
+
+// DeleteBefore deletes all records with a timestamp before cutoff.
+// Returns the number of records deleted.
+func (s *Store) DeleteBefore(cutoff time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.records[:0]
+	for _, r := range s.records {
+		if !r.Timestamp.Before(cutoff) {
+			kept = append(kept, r)
+		}
+	}
+	deleted := len(s.records) - len(kept)
+	clear(s.records[len(kept):])
+	s.records = kept
+	return int64(deleted), nil
+}
+
+// DeleteLogs deletes the records of opts's app and time range.
+// Returns the number of records deleted.
+func (s *Store) DeleteLogs(opts model.QueryOpts) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.records[:0]
+	for _, r := range s.records {
+		if !inScope(&r, opts) {
+			kept = append(kept, r)
+		}
+	}
+	deleted := len(s.records) - len(kept)
+	clear(s.records[len(kept):])
+	s.records = kept
+	return int64(deleted), nil
+}
+
+func cloneAttributes(attrs map[string]string) map[string]string {
+	out := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		out[k] = v
+	}
+	return out
+}
+
+func nextEventID() string {
+	n := eventIDCounter.Add(1)
+	return fmt.Sprintf("%x-%x", time.Now().UTC().UnixNano(), n)
+}
+
+// Store implements the full storage contract.
+var _ model.StorageBackend = (*Store)(nil)
@@ -0,0 +1,255 @@
+package memstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func parityRecords(base time.Time) []*model.LogRecord {
+	return []*model.LogRecord{
+		{Timestamp: base, Level: "INFO", Message: "request processed successfully", RawLine: "a", Service: "api", Hostname: "web1", App: "shop",
+			Attributes: map[string]string{"region": "us-east", "route": "/cart"}},
+		{Timestamp: base.Add(10 * time.Second), Level: "ERROR", Message: "Request FAILED: timeout!", RawLine: "bb", Service: "api", App: "shop",
+			Attributes: map[string]string{"region": "us-west"}},
+		{Timestamp: base.Add(70 * time.Second), Level: "WARN", Message: "disk usage high", RawLine: "ccc", Hostname: "web2",
+			Attributes: map[string]string{"region": "us-east"}},
+		{Timestamp: base.Add(65 * time.Second), Level: "ERROR", Message: "request failed again", RawLine: "dddd", Service: "worker", App: "jobs"},
+	}
+}
+
+// TestParityWithDuckDB runs the same reads against both backends.
+func TestParityWithDuckDB(t *testing.T) {
+	base := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+
+	ddb, err := duckdb.NewStore("")
+	if err != nil {
+		t.Fatalf("duckdb.NewStore: %v", err)
+	}
+	t.Cleanup(func() { ddb.Close() })
+	mem := NewStore()
+
+	for _, backend := range []model.StorageBackend{ddb, mem} {
+		if err := backend.InsertLogBatch(parityRecords(base)); err != nil {
+			t.Fatalf("InsertLogBatch: %v", err)
+		}
+	}
+
+	check := func(name string, query func(model.StorageBackend) (any, error)) {
+		t.Helper()
+		want, err := query(ddb)
+		if err != nil {
+			t.Fatalf("%s (duckdb): %v", name, err)
+		}
+		got, err := query(mem)
+		if err != nil {
+			t.Fatalf("%s (memory): %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s mismatch:\n memory = %+v\n duckdb = %+v", name, got, want)
+		}
+	}
+
+	for _, opts := range []model.QueryOpts{{}, {App: "shop"}, {From: base.Add(time.Second), To: base.Add(time.Minute)}} {
+		check("TotalLogCount", func(b model.StorageBackend) (any, error) { return b.TotalLogCount(opts) })
+		check("TotalLogBytes", func(b model.StorageBackend) (any, error) { return b.TotalLogBytes(opts) })
+		check("TopWords", func(b model.StorageBackend) (any, error) { return b.TopWords(10, opts) })
+		check("TopAttributes", func(b model.StorageBackend) (any, error) { return b.TopAttributes(10, opts) })
+		check("TopAttributeKeys", func(b model.StorageBackend) (any, error) { return b.TopAttributeKeys(10, opts) })
+		check("SeverityCounts", func(b model.StorageBackend) (any, error) { return b.SeverityCounts(opts) })
+		check("TopHosts", func(b model.StorageBackend) (any, error) { return b.TopHosts(10, opts) })
+		check("TopServices", func(b model.StorageBackend) (any, error) { return b.TopServices(10, opts) })
+		check("TopServicesBySeverity", func(b model.StorageBackend) (any, error) { return b.TopServicesBySeverity("ERROR", 10, opts) })
+		check("AttributeKeyValues", func(b model.StorageBackend) (any, error) { return b.AttributeKeyValues("region", 10, opts) })
+		check("DistinctAttributeValues", func(b model.StorageBackend) (any, error) {
+			return b.DistinctAttributeValues("region", "US", 10, opts)
+		})
+		for _, dimension := range model.CatalogDimensions {
+			check("Catalog/"+dimension, func(b model.StorageBackend) (any, error) {
+				entries, err := b.(model.Cataloger).Catalog(dimension, 10, opts)
+				for i := range entries {
+					entries[i].LastSeen = entries[i].LastSeen.UTC()
+				}
+				return entries, err
+			})
+		}
+	}
+	check("ListApps", func(b model.StorageBackend) (any, error) { return b.ListApps() })
+	check("TableRowCounts", func(b model.StorageBackend) (any, error) { return b.TableRowCounts() })
+
+	minutes := func(b model.StorageBackend) (any, error) {
+		rows, err := b.SeverityCountsByMinute(model.QueryOpts{})
+		for i := range rows {
+			rows[i].Minute = rows[i].Minute.UTC()
+		}
+		return rows, err
+	}
+	check("SeverityCountsByMinute", minutes)
+
+	rateOpts := model.QueryOpts{To: base.Add(time.Hour)}
+	for _, tc := range []struct {
+		dimension string
+		step      time.Duration
+		levels    []string
+	}{{"service", time.Minute, nil}, {"level", 30 * time.Second, nil}, {"host", time.Minute, []string{"ERROR", "WARN"}}, {"app", 5 * time.Minute, nil}} {
+		check("RateByDimension/"+tc.dimension, func(b model.StorageBackend) (any, error) {
+			rows, err := b.RateByDimension(tc.dimension, 2*time.Hour, tc.step, tc.levels, rateOpts)
+			for i := range rows {
+				rows[i].Bucket = rows[i].Bucket.UTC()
+			}
+			return rows, err
+		})
+	}
+
+	for _, step := range []time.Duration{30 * time.Second, time.Minute} {
+		check("IngestRate/"+step.String(), func(b model.StorageBackend) (any, error) {
+			points, err := b.(model.IngestRater).IngestRate(2*time.Hour, step, rateOpts)
+			for i := range points {
+				points[i].Bucket = points[i].Bucket.UTC()
+			}
+			return points, err
+		})
+	}
+
+	messages := func(records []model.LogRecord, err error) (any, error) {
+		out := make([]string, len(records))
+		for i, r := range records {
+			out[i] = r.Message
+		}
+		return out, err
+	}
+	check("RecentLogsFiltered", func(b model.StorageBackend) (any, error) {
+		return messages(b.RecentLogsFiltered(2, model.QueryOpts{}, []string{"ERROR", "WARN"}, nil, "(?i)fail|disk"))
+	})
+	check("RecentLogsFiltered/range", func(b model.StorageBackend) (any, error) {
+		window := model.QueryOpts{From: base.Add(10 * time.Second), To: base.Add(70 * time.Second)}
+		return messages(b.RecentLogsFiltered(10, window, nil, nil, ""))
+	})
+	for _, facets := range []map[string]string{{"service": "api"}, {"host": "web2"}, {"service": "api", "region": "us-east"}, {"region": "eu"}} {
+		check(fmt.Sprintf("RecentLogsFiltered/facets %v", facets), func(b model.StorageBackend) (any, error) {
+			return messages(b.RecentLogsFiltered(10, model.QueryOpts{}, nil, facets, ""))
+		})
+	}
+	check("SearchLogs", func(b model.StorageBackend) (any, error) {
+		return messages(b.SearchLogs("FAILED", 10, model.QueryOpts{}))
+	})
+	for _, filter := range []model.LogFilter{
+		{Limit: 1},
+		{Limit: 2, Levels: []string{"ERROR"}, Pattern: "(?i)fail"},
+		{Limit: 10, QueryOpts: model.QueryOpts{App: "shop"}, Attributes: map[string]string{"region": "us-west"}},
+	} {
+		// Page through every match, one cursor at a time.
+		check("FindLogs", func(b model.StorageBackend) (any, error) {
+			var all []string
+			filter := filter
+			for {
+				page, err := b.(model.LogFinder).FindLogs(filter)
+				if err != nil {
+					return nil, err
+				}
+				for _, r := range page.Logs {
+					all = append(all, r.Message)
+				}
+				if page.NextCursor == "" {
+					return all, nil
+				}
+				filter.Cursor = page.NextCursor
+			}
+		})
+	}
+	type logStreamer interface {
+		StreamLogs(context.Context, model.QueryOpts, int64, func([]model.LogRecord) error) (int64, error)
+	}
+	check("StreamLogs", func(b model.StorageBackend) (any, error) {
+		var all []model.LogRecord
+		_, err := b.(logStreamer).StreamLogs(context.Background(), model.QueryOpts{App: "shop"}, 0, func(chunk []model.LogRecord) error {
+			all = append(all, chunk...)
+			return nil
+		})
+		return messages(all, err)
+	})
+}
+
+func TestInsertLogBatch_EvictsOldest(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore(Config{MaxRecords: 3})
+	base := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := s.InsertLogBatch([]*model.LogRecord{{Timestamp: base.Add(time.Duration(i) * time.Second), Message: string(rune('a' + i))}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logs, err := s.RecentLogsFiltered(10, model.QueryOpts{}, nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 3 || logs[0].Message != "c" || logs[2].Message != "e" {
+		t.Fatalf("logs = %+v, want c..e", logs)
+	}
+	if s.Evicted() != 2 {
+		t.Fatalf("Evicted = %d, want 2", s.Evicted())
+	}
+	if logs[0].App != "default" || logs[0].EventID == "" {
+		t.Fatalf("defaults not applied: app=%q event_id=%q", logs[0].App, logs[0].EventID)
+	}
+}
+
+func TestInsertLogBatch_CopiesAttributes(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore()
+	attrs := map[string]string{"k": "v"}
+	if err := s.InsertLogBatch([]*model.LogRecord{{Timestamp: time.Now(), Message: "x", Attributes: attrs}}); err != nil {
+		t.Fatal(err)
+	}
+	attrs["k"] = "changed"
+
+	logs, _ := s.RecentLogsFiltered(1, model.QueryOpts{}, nil, nil, "")
+	logs[0].Attributes["k"] = "mutated"
+	again, _ := s.RecentLogsFiltered(1, model.QueryOpts{}, nil, nil, "")
+	if again[0].Attributes["k"] != "v" {
+		t.Fatalf("stored attribute = %q, want v", again[0].Attributes["k"])
+	}
+}
+
+func TestDeleteBefore(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore()
+	now := time.Now()
+	_ = s.InsertLogBatch([]*model.LogRecord{
+		{Timestamp: now.Add(-48 * time.Hour), Message: "old"},
+		{Timestamp: now, Message: "new"},
+	})
+
+	deleted, err := s.DeleteBefore(now.Add(-24 * time.Hour))
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeleteBefore = %d, %v; want 1", deleted, err)
+	}
+	if n, _ := s.TotalLogCount(model.QueryOpts{}); n != 1 {
+		t.Fatalf("TotalLogCount = %d, want 1", n)
+	}
+}
+
+func TestSQLUnsupported(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore()
+	if _, err := s.ExecuteQuery("SELECT 1"); !errors.Is(err, ErrQueryUnsupported) {
+		t.Fatalf("ExecuteQuery err = %v, want ErrQueryUnsupported", err)
+	}
+	if _, err := s.EstimateQueryScanRows("SELECT 1"); !errors.Is(err, ErrQueryUnsupported) {
+		t.Fatalf("EstimateQueryScanRows err = %v, want ErrQueryUnsupported", err)
+	}
+	if _, err := s.RecentLogsFiltered(10, model.QueryOpts{}, nil, nil, "("); err == nil {
+		t.Fatal("expected error for invalid message pattern")
+	}
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// States of an Alert.
+const (
+	AlertPending  = "pending"  // the rule's condition holds, not yet for its full duration
+	AlertFiring   = "firing"   // the rule's condition has held for its full duration
+	AlertResolved = "resolved" // the rule fired and its condition no longer holds
+)
+
+// ErrUnknownAlert is returned when silencing or acknowledging a rule the
+// alert engine does not know.
+var ErrUnknownAlert = errors.New("unknown alert rule")
+
+// Alert is the state of one alert rule as its engine last evaluated it.
+type Alert struct {
+	Rule     string    `json:"rule"`
+	State    string    `json:"state"`
+	Severity string    `json:"severity,omitempty"`
+	Summary  string    `json:"summary,omitempty"`
+	Since    time.Time `json:"since"` // when the rule entered State
+	Value    float64   `json:"value"` // the rule's last evaluated value
+	// History holds the rule's recent evaluated values, oldest first.
+	History []float64 `json:"history,omitempty"`
+	// SilencedUntil is when a silence on the rule ends; zero when none.
+	SilencedUntil time.Time `json:"silenced_until"`
+	// Acknowledged is set when a user acknowledged the current firing;
+	// the engine clears it when the rule resolves.
+	Acknowledged bool `json:"acknowledged,omitempty"`
+}
+
+// Silenced reports whether a silence on the alert is in effect at now.
+func (a Alert) Silenced(now time.Time) bool {
+	return now.Before(a.SilencedUntil)
+}
+
+// AlertManager is implemented by alert engines: it lists the rules that are
+// firing, pending, or recently resolved, and silences or acknowledges them.
+type AlertManager interface {
+	Alerts() ([]Alert, error)
+	// SilenceAlert mutes rule's notifications for d; d <= 0 lifts a
+	// silence.
+	SilenceAlert(rule string, d time.Duration) error
+	AcknowledgeAlert(rule string) error
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// Kinds of ServerEvent.
+const (
+	EventAppSeen      = "app_seen"      // the first record of an app arrived
+	EventStoreError   = "store_error"   // writing records to the store failed
+	EventBackupDone   = "backup_done"   // a backup snapshot succeeded
+	EventBackupFailed = "backup_failed" // a backup snapshot failed
+	EventAlertFiring  = "alert_firing"  // an alert rule started firing
+)
+
+// ServerEvent is something that happened on the server that connected
+// clients may want to show as it happens.
+type ServerEvent struct {
+	Time    time.Time
+	Kind    string
+	Message string
+	App     string `json:",omitempty"` // the app concerned, if any
+}
+
+// EventSubscription is a live subscription to server events.
+type EventSubscription interface {
+	// Events delivers events in the order they happened. It is closed when
+	// the subscription ends, by Close or because its source went away.
+	Events() <-chan ServerEvent
+	Close()
+}
+
+// EventSource pushes server events to subscribers.
+type EventSource interface {
+	// SubscribeEvents subscribes to the events published from now on,
+	// buffering up to buffer of them for the subscriber.
+	SubscribeEvents(buffer int) (EventSubscription, error)
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// Component health statuses.
+const (
+	HealthOK      = "ok"
+	HealthFailing = "failing"
+)
+
+// ComponentHealth is the state of one server component (the store, the
+// insert buffer, the journal, sources, backups, retention) as last checked.
+type ComponentHealth struct {
+	Name     string    `json:"name"`
+	Status   string    `json:"status"` // HealthOK or HealthFailing
+	Required bool      `json:"required"`
+	Error    string    `json:"error,omitempty"` // why it is failing
+	Since    time.Time `json:"since"`           // when it entered Status
+	// LastError and LastErrorAt are the latest failure, kept after the
+	// component recovers; LastErrorAt is zero when it never failed.
+	LastError   string    `json:"last_error,omitempty"`
+	LastErrorAt time.Time `json:"last_error_at"`
+	// Restarts counts how often the component came back after failing.
+	Restarts int `json:"restarts"`
+}
+
+// HealthReporter checks the server's components (the health registry, or
+// the socket client asking the server for it).
+type HealthReporter interface {
+	Health() ([]ComponentHealth, error)
+}
@@ -1,10 +1,21 @@
 package model
 
+import (
+	"errors"
+	"time"
+)
+
 // QueryOpts holds optional filters applied to most queries.
 type QueryOpts struct {
-	App string // empty = all apps
+	App  string    // empty = all apps
+	From time.Time `json:",omitzero"` // inclusive lower bound on timestamp; zero = unbounded
+	To   time.Time `json:",omitzero"` // exclusive upper bound on timestamp; zero = unbounded
 }
 
+// ErrOverloaded is returned by a store that shed a query under load rather
+// than queue it; the caller may retry.
+var ErrOverloaded = errors.New("query overloaded")
+
 // LogQuerier provides read-only queries on log data.
 type LogQuerier interface {
 	TotalLogCount(opts QueryOpts) (int64, error)
@@ -12,24 +23,82 @@ type LogQuerier interface {
 	TopWords(limit int, opts QueryOpts) ([]WordCount, error)
 	TopAttributes(limit int, opts QueryOpts) ([]AttributeStat, error)
 	TopAttributeKeys(limit int, opts QueryOpts) ([]AttributeKeyStat, error)
-	AttributeKeyValues(key string, limit int) (map[string]int64, error)
+	AttributeKeyValues(key string, limit int, opts QueryOpts) (map[string]int64, error)
+	DistinctAttributeValues(key, prefix string, limit int, opts QueryOpts) ([]DimensionCount, error)
 	SeverityCounts(opts QueryOpts) (map[string]int64, error)
 	SeverityCountsByMinute(opts QueryOpts) ([]MinuteCounts, error)
 	TopHosts(limit int, opts QueryOpts) ([]DimensionCount, error)
 	TopServices(limit int, opts QueryOpts) ([]DimensionCount, error)
 	TopServicesBySeverity(severity string, limit int, opts QueryOpts) ([]DimensionCount, error)
 	ListApps() ([]string, error)
-	RecentLogsFiltered(limit int, app string, severityLevels []string, messagePattern string) ([]LogRecord, error)
+	// RecentLogsFiltered's records match every facet (see MatchesFacets).
+	RecentLogsFiltered(limit int, opts QueryOpts, severityLevels []string, facets map[string]string, messagePattern string) ([]LogRecord, error)
 	SearchLogs(term string, limit int, opts QueryOpts) ([]LogRecord, error)
+	RateByDimension(dimension string, window, step time.Duration, severityLevels []string, opts QueryOpts) ([]DimensionRate, error)
+}
+
+// LogFinder is implemented by stores that page through logs matching
+// structured filters, newest first.
+type LogFinder interface {
+	FindLogs(filter LogFilter) (LogPage, error)
+}
+
+// Cataloger is implemented by stores that list the values of a catalog
+// dimension (app, service, or host) with their log counts and latest log,
+// most logs first.
+type Cataloger interface {
+	Catalog(dimension string, limit int, opts QueryOpts) ([]CatalogEntry, error)
+}
+
+// Errors of SavedQueryStore.
+var (
+	ErrSavedQueryNotFound = errors.New("saved query not found")
+	ErrSavedQueryExists   = errors.New("a saved query with that name exists")
+	ErrSavedQueryInvalid  = errors.New("invalid saved query")
+)
+
+// SavedQueryStore is implemented by stores that keep named SQL queries
+// (the DuckDB backend).
+type SavedQueryStore interface {
+	// SaveQuery creates q when its ID is zero and replaces the query with
+	// its ID otherwise, returning it as stored.
+	SaveQuery(q SavedQuery) (SavedQuery, error)
+	// SavedQueries lists the saved queries by name.
+	SavedQueries() ([]SavedQuery, error)
+	SavedQuery(id int64) (SavedQuery, error)
+	DeleteSavedQuery(id int64) error
 }
 
 // SchemaQuerier provides schema introspection and arbitrary read-only queries.
 type SchemaQuerier interface {
-	ExecuteQuery(query string) ([]map[string]interface{}, error)
+	// ExecuteQuery and EstimateQueryScanRows bind args to the query's ?
+	// placeholders.
+	ExecuteQuery(query string, args ...any) ([]map[string]interface{}, error)
+	EstimateQueryScanRows(query string, args ...any) (int64, error)
 	GetSchemaDescription() string
 	TableRowCounts() (map[string]int64, error)
 }
 
+// SchemaVersioner is implemented by stores whose schema is versioned by
+// migrations (the DuckDB backend).
+type SchemaVersioner interface {
+	// SchemaRevision returns the last migration applied.
+	SchemaRevision() (int, error)
+}
+
+// MethodSupporter is implemented by remote stores that learn which of
+// their methods the server behind them serves (the socket client). A store
+// that does not implement it serves every method it has.
+type MethodSupporter interface {
+	Supports(method string) bool
+}
+
+// Reconnector is implemented by remote stores that redial a server they
+// lost (the socket client). Their calls fail while Reconnecting.
+type Reconnector interface {
+	Reconnecting() bool
+}
+
 // LogWriter provides append-oriented write operations for processed logs.
 type LogWriter interface {
 	InsertLogBatch(records []*LogRecord) error
@@ -46,6 +115,88 @@ type ReadAPI interface {
 	LogReader
 }
 
+// LogPruner deletes records older than a cutoff, for retention.
+type LogPruner interface {
+	DeleteBefore(cutoff time.Time) (int64, error)
+}
+
+// LogDeleter is implemented by stores that can delete the logs of an app,
+// a time range, or both, on demand.
+type LogDeleter interface {
+	DeleteLogs(opts QueryOpts) (int64, error)
+}
+
+// CapacityPruner is implemented by stores that can enforce size limits for
+// retention: they report their footprint and delete the oldest records first.
+type CapacityPruner interface {
+	LogPruner
+	TotalLogCount(opts QueryOpts) (int64, error)
+	StorageBytes() (int64, error)
+	DeleteOldest(n int64) (int64, error)
+}
+
+// LogArchiver is implemented by stores that can move old records to cheaper
+// storage while keeping them queryable.
+type LogArchiver interface {
+	ArchiveBefore(cutoff time.Time) (int64, error)
+}
+
+// LogRotator is implemented by stores that can move finished periods out of
+// their active database file while keeping them queryable.
+type LogRotator interface {
+	Rotate(now time.Time) (int64, error)
+}
+
+// Compactor is implemented by stores that can checkpoint their write-ahead
+// log and return freed space to the file system.
+type Compactor interface {
+	// Compact returns the bytes the store's files shrank by.
+	Compact() (int64, error)
+}
+
+// TailFilter selects the records a live tail delivers. Empty fields match
+// everything.
+type TailFilter struct {
+	App            string
+	SeverityLevels []string
+	Facets         map[string]string `json:",omitempty"` // see MatchesFacets
+	MessagePattern string            // regular expression on the message
+}
+
+// LogTail is a live subscription to ingested records.
+type LogTail interface {
+	// Records delivers matching records in arrival order. It is closed when
+	// the tail ends, by Close or because its source went away.
+	Records() <-chan LogRecord
+	// Dropped returns how many matching records were dropped since the
+	// last call because the subscriber fell behind.
+	Dropped() int64
+	Close()
+}
+
+// LogTailer pushes records to live tails as they are ingested, so readers
+// need not poll the store for new ones.
+type LogTailer interface {
+	// TailLogs subscribes to the records matching filter, buffering up to
+	// buffer of them for the subscriber.
+	TailLogs(filter TailFilter, buffer int) (LogTail, error)
+}
+
+// StorageBackend is the full contract a log store implements: writes,
+// reads, retention, and shutdown. DuckDB is the default backend.
+type StorageBackend interface {
+	LogWriter
+	LogReader
+	LogPruner
+	Close() error
+}
+
+// SuppressedCounter records counts of records that were kept out of storage,
+// so severity counts still include them.
+type SuppressedCounter interface {
+	AddSuppressedCounts(counts []SuppressedCount) error
+}
+
 // RecordSink accepts processed log records for storage.
 type RecordSink interface {
 	Add(*LogRecord)
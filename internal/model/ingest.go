@@ -5,4 +5,15 @@ package model
 type IngestEnvelope struct {
 	Source string
 	Line   string
+	// OTLP holds a binary OTLP LogsData protobuf instead of a text line.
+	OTLP []byte
+	// Done, when set, is called once the envelope has been handed to the
+	// record sink (which journals it) or dropped. Sources use it to
+	// acknowledge senders.
+	Done func()
+}
+
+// Empty reports whether the envelope carries nothing to process.
+func (e IngestEnvelope) Empty() bool {
+	return e.Line == "" && len(e.OTLP) == 0
 }
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxLogPage bounds LogFilter.Limit.
+const MaxLogPage = 1000
+
+// LogFilter selects logs for FindLogs. Every set field must match.
+type LogFilter struct {
+	QueryOpts
+	Levels     []string          // any of these severities; empty = all
+	Attributes map[string]string // attribute key = value
+	Pattern    string            // regular expression the message matches
+	Limit      int               // page size, 1 to MaxLogPage
+	Cursor     string            // NextCursor of the previous page; "" = newest
+}
+
+// Facet keys that match a record's own fields rather than its attributes.
+const (
+	FacetService = "service"
+	FacetHost    = "host"
+)
+
+// MatchesFacets reports whether r has every facet key = value: its service
+// for FacetService, its hostname for FacetHost, and the attribute of that
+// name for any other key.
+func MatchesFacets(r *LogRecord, facets map[string]string) bool {
+	for key, value := range facets {
+		var v string
+		var ok bool
+		switch key {
+		case FacetService:
+			v, ok = r.Service, true
+		case FacetHost:
+			v, ok = r.Hostname, true
+		default:
+			v, ok = r.Attributes[key]
+		}
+		if !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// LogPage is one page of FindLogs results, newest first.
+type LogPage struct {
+	Logs []LogRecord
+	// NextCursor continues after the last log; "" when there are no more.
+	NextCursor string
+}
+
+// LogCursor is the decoded position of a page: logs at or before Timestamp,
+// skipping the first Skip logs at exactly Timestamp, which earlier pages
+// returned.
+type LogCursor struct {
+	Timestamp time.Time
+	Skip      int
+}
+
+// String encodes c as an opaque cursor.
+func (c LogCursor) String() string {
+	raw := strconv.FormatInt(c.Timestamp.UnixNano(), 10) + ":" + strconv.Itoa(c.Skip)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseLogCursor decodes a cursor made by LogCursor.String.
+func ParseLogCursor(s string) (LogCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return LogCursor{}, fmt.Errorf("invalid cursor")
+	}
+	ts, skip, ok := strings.Cut(string(raw), ":")
+	nanos, err1 := strconv.ParseInt(ts, 10, 64)
+	n, err2 := strconv.Atoi(skip)
+	if !ok || err1 != nil || err2 != nil || n < 0 {
+		return LogCursor{}, fmt.Errorf("invalid cursor")
+	}
+	return LogCursor{Timestamp: time.Unix(0, nanos).UTC(), Skip: n}, nil
+}
+
+// NextLogCursor returns the cursor following page, a full page read from
+// cur, newest first.
+func NextLogCursor(cur LogCursor, page []LogRecord) LogCursor {
+	last := page[len(page)-1].Timestamp
+	next := LogCursor{Timestamp: last}
+	if last.Equal(cur.Timestamp) {
+		next.Skip = cur.Skip
+	}
+	for _, r := range page {
+		if r.Timestamp.Equal(last) {
+			next.Skip++
+		}
+	}
+	return next
+}
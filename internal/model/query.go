@@ -0,0 +1,37 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// QueryArgs decodes the params of an ad-hoc or saved query request into
+// values for its ? placeholders: strings, numbers (integers when whole),
+// booleans, and null.
+func QueryArgs(params []json.RawMessage) ([]any, error) {
+	args := make([]any, len(params))
+	for i, raw := range params {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("params[%d]: %v", i, err)
+		}
+		switch v := v.(type) {
+		case json.Number:
+			if n, err := v.Int64(); err == nil {
+				args[i] = n
+			} else if f, err := v.Float64(); err == nil {
+				args[i] = f
+			} else {
+				return nil, fmt.Errorf("params[%d]: %v", i, err)
+			}
+		case string, bool, nil:
+			args[i] = v
+		default:
+			return nil, fmt.Errorf("params[%d]: must be a string, number, boolean, or null", i)
+		}
+	}
+	return args, nil
+}
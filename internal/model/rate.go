@@ -0,0 +1,59 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// RateDimensions are the dimensions RateByDimension groups by.
+var RateDimensions = []string{"service", "host", "app", "level"}
+
+// MaxRateBuckets caps how many steps a RateByDimension or IngestRate window
+// may span.
+const MaxRateBuckets = 1440
+
+// IngestPoint is the number of logs, and their raw-line length, stored with
+// timestamps in one time bucket.
+type IngestPoint struct {
+	Bucket time.Time `json:"bucket"` // start of the step-wide bucket
+	Count  int64     `json:"count"`
+	Bytes  int64     `json:"bytes"`
+}
+
+// IngestRater is implemented by stores that report log volume over time
+// (the DuckDB and in-memory backends).
+type IngestRater interface {
+	// IngestRate returns the logs per step-wide bucket over the last window,
+	// oldest first. Buckets are aligned to multiples of step since the Unix
+	// epoch; buckets without logs are omitted.
+	IngestRate(window, step time.Duration, opts QueryOpts) ([]IngestPoint, error)
+}
+
+// RateScope validates RateByDimension arguments and returns opts narrowed to
+// the window: the last window before opts.To, or before now when To is zero.
+// A From inside the window narrows it further.
+func RateScope(dimension string, window, step time.Duration, opts QueryOpts) (QueryOpts, error) {
+	if !slices.Contains(RateDimensions, dimension) {
+		return opts, fmt.Errorf("invalid dimension %q (want one of %v)", dimension, RateDimensions)
+	}
+	return IngestScope(window, step, opts)
+}
+
+// IngestScope validates IngestRate arguments and narrows opts to the window
+// like RateScope.
+func IngestScope(window, step time.Duration, opts QueryOpts) (QueryOpts, error) {
+	if window <= 0 || step <= 0 {
+		return opts, fmt.Errorf("window and step must be positive")
+	}
+	if window/step > MaxRateBuckets {
+		return opts, fmt.Errorf("window %s spans more than %d steps of %s", window, MaxRateBuckets, step)
+	}
+	if opts.To.IsZero() {
+		opts.To = time.Now()
+	}
+	if start := opts.To.Add(-window); start.After(opts.From) {
+		opts.From = start
+	}
+	return opts, nil
+}
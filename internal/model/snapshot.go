@@ -0,0 +1,93 @@
+package model
+
+import "sync"
+
+// SnapshotRequest selects the sections of a DashboardSnapshot. Counts and
+// top lists are scoped by Opts; the recent logs by LogOpts and the filters.
+// A zero TopLimit or LogLimit leaves that section out.
+type SnapshotRequest struct {
+	Opts     QueryOpts
+	TopLimit int  // top services and hosts; 0 = none
+	Minutes  bool // severity counts by minute
+
+	LogOpts        QueryOpts
+	LogLimit       int // recent logs; 0 = none
+	SeverityLevels []string
+	Facets         map[string]string `json:",omitempty"`
+	MessagePattern string
+}
+
+// DashboardSnapshot holds what a dashboard refresh reads, loaded in one call.
+type DashboardSnapshot struct {
+	TotalCount     int64
+	SeverityCounts map[string]int64
+	Apps           []string
+	MinuteCounts   []MinuteCounts   `json:",omitempty"`
+	TopServices    []DimensionCount `json:",omitempty"`
+	TopHosts       []DimensionCount `json:",omitempty"`
+	RecentLogs     []LogRecord      `json:",omitempty"`
+}
+
+// DashboardSnapshotter is implemented by stores that load a whole
+// DashboardSnapshot in one roundtrip (the socket client).
+type DashboardSnapshotter interface {
+	DashboardSnapshot(req SnapshotRequest) (DashboardSnapshot, error)
+}
+
+// ReadDashboardSnapshot loads the sections req selects from q, running the
+// queries concurrently. It returns the first error any of them hit.
+func ReadDashboardSnapshot(q LogQuerier, req SnapshotRequest) (DashboardSnapshot, error) {
+	var (
+		snap     DashboardSnapshot
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	run := func(read func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := read(); err != nil {
+				errOnce.Do(func() { firstErr = err })
+			}
+		}()
+	}
+
+	run(func() (err error) {
+		snap.TotalCount, err = q.TotalLogCount(req.Opts)
+		return err
+	})
+	run(func() (err error) {
+		snap.SeverityCounts, err = q.SeverityCounts(req.Opts)
+		return err
+	})
+	run(func() (err error) {
+		snap.Apps, err = q.ListApps()
+		return err
+	})
+	if req.Minutes {
+		run(func() (err error) {
+			snap.MinuteCounts, err = q.SeverityCountsByMinute(req.Opts)
+			return err
+		})
+	}
+	if req.TopLimit > 0 {
+		run(func() (err error) {
+			snap.TopServices, err = q.TopServices(req.TopLimit, req.Opts)
+			return err
+		})
+		run(func() (err error) {
+			snap.TopHosts, err = q.TopHosts(req.TopLimit, req.Opts)
+			return err
+		})
+	}
+	if req.LogLimit > 0 {
+		run(func() (err error) {
+			snap.RecentLogs, err = q.RecentLogsFiltered(req.LogLimit, req.LogOpts, req.SeverityLevels, req.Facets, req.MessagePattern)
+			return err
+		})
+	}
+
+	wg.Wait()
+	return snap, firstErr
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -15,13 +16,107 @@ type AnalyticsEvent struct {
 	App        string
 }
 
-// MetricSample represents one numeric metric datapoint collected via pull/scrape.
+// MetricSample represents one numeric metric datapoint, pushed over OTLP or
+// collected via pull/scrape.
 type MetricSample struct {
 	Timestamp time.Time
 	Name      string
+	Kind      string // gauge, sum, counter (monotonic sum), histogram, summary
+	Unit      string
 	Value     float64
 	Labels    map[string]string
-	Source    string
+	Source    string // "otlp", or the collector name
+	App       string // application name, defaults to "default"
+	Service   string
+}
+
+// MetricSeries summarizes one series: a metric name and label set.
+type MetricSeries struct {
+	Name      string
+	Kind      string
+	Unit      string
+	Labels    map[string]string
+	Points    int64
+	LastSeen  time.Time
+	LastValue float64
+}
+
+// MetricPoint aggregates the samples of one step of a range query.
+type MetricPoint struct {
+	Time    time.Time
+	Avg     float64
+	Min     float64
+	Max     float64
+	Samples int64
+}
+
+// MetricWriter stores metric samples.
+type MetricWriter interface {
+	InsertMetricBatch(samples []*MetricSample) error
+}
+
+// MetricQuerier reads stored metric samples.
+type MetricQuerier interface {
+	// MetricSeries lists the series whose name contains filter (all when
+	// empty), by name.
+	MetricSeries(filter string, limit int, opts QueryOpts) ([]MetricSeries, error)
+	// MetricRange buckets the samples of name whose labels include labels
+	// into steps of step, oldest first.
+	MetricRange(name string, labels map[string]string, step time.Duration, opts QueryOpts) ([]MetricPoint, error)
+}
+
+// Span represents one span of a trace, pushed over OTLP. Trace and span
+// ids are lowercase hex.
+type Span struct {
+	TraceID       string
+	SpanID        string
+	ParentSpanID  string
+	Name          string
+	Kind          string // internal, server, client, producer, consumer
+	StartTime     time.Time
+	EndTime       time.Time
+	StatusCode    string // unset, ok, error
+	StatusMessage string
+	Attributes    map[string]string
+	Service       string
+	App           string // application name, defaults to "default"
+}
+
+// TraceIDKeys and SpanIDKeys are the attributes a record's trace context is
+// taken from, in order: the OTLP receiver's keys, then common JSON names.
+var (
+	TraceIDKeys = []string{"trace.id", "trace_id", "traceId"}
+	SpanIDKeys  = []string{"span.id", "span_id", "spanId"}
+)
+
+// TraceContext returns the trace and span ids in a record's attributes.
+func TraceContext(attrs map[string]string) (traceID, spanID string) {
+	for _, k := range TraceIDKeys {
+		if v := attrs[k]; v != "" {
+			traceID = strings.ToLower(v)
+			break
+		}
+	}
+	for _, k := range SpanIDKeys {
+		if v := attrs[k]; v != "" {
+			spanID = strings.ToLower(v)
+			break
+		}
+	}
+	return traceID, spanID
+}
+
+// SpanWriter stores spans.
+type SpanWriter interface {
+	InsertSpanBatch(spans []*Span) error
+}
+
+// TraceQuerier correlates logs and spans by trace id.
+type TraceQuerier interface {
+	// TraceLogs returns up to limit logs carrying traceID, oldest first.
+	TraceLogs(traceID string, limit int) ([]LogRecord, error)
+	// TraceSpans returns the spans of traceID by start time.
+	TraceSpans(traceID string) ([]Span, error)
 }
 
 // MetricsCollector defines the minimal contract for pull-based metrics collection.
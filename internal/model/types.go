@@ -18,6 +18,7 @@ type LogRecord struct {
 	Source        string // "tcp", "stdin"
 	App           string // application name, defaults to "default"
 	EventID       string // internal unique id for dedupe-safe replay
+	BodyJSON      string // structured OTLP body as JSON; "" when the body is a scalar
 }
 
 // WordCount represents a word and its frequency count.
@@ -58,3 +59,82 @@ type MinuteCounts struct {
 	Fatal  int64
 	Total  int64
 }
+
+// SuppressedCount is the number of records of one minute, app, service,
+// and level that ingest counted but did not store.
+type SuppressedCount struct {
+	Minute  time.Time
+	App     string
+	Service string
+	Level   string
+	Count   int64
+}
+
+// RetentionStats counts the records retention deleted, by policy, and
+// those it archived.
+type RetentionStats struct {
+	Expired      int64     `json:"expired"`       // older than the retention period
+	SizeEvicted  int64     `json:"size_evicted"`  // over the storage size limit
+	RowsEvicted  int64     `json:"rows_evicted"`  // over the row count limit
+	Archived     int64     `json:"archived"`      // moved to the archive, not deleted
+	Rotated      int64     `json:"rotated"`       // moved to a read-only file, not deleted
+	StorageBytes int64     `json:"storage_bytes"` // after the last run; 0 if unknown
+	LastRun      time.Time `json:"last_run"`
+}
+
+// QueryStats reports the load on a store's read query scheduler.
+type QueryStats struct {
+	Slots           int   `json:"slots"`
+	Running         int   `json:"running"`
+	Queued          int   `json:"queued"`
+	ShedInteractive int64 `json:"shed_interactive"` // dashboard queries shed since startup
+	ShedBulk        int64 `json:"shed_bulk"`        // ad-hoc SQL queries shed since startup
+}
+
+// MaintenanceStats reports what periodic compaction has done since startup.
+type MaintenanceStats struct {
+	Runs          int64     `json:"runs"`
+	Reclaimed     int64     `json:"reclaimed_bytes"`      // total since startup
+	LastReclaimed int64     `json:"last_reclaimed_bytes"` // by the last run
+	LastRun       time.Time `json:"last_run"`
+}
+
+// CatalogDimensions are the dimensions a Cataloger lists.
+var CatalogDimensions = []string{"app", "service", "host"}
+
+// CatalogEntry is one value of a catalog dimension; empty values are
+// listed as "unknown".
+type CatalogEntry struct {
+	Value    string    `json:"value"`
+	Count    int64     `json:"count"`
+	LastSeen time.Time `json:"last_seen"` // timestamp of its latest log
+}
+
+// DimensionRate is the log count for one dimension value in one time bucket.
+type DimensionRate struct {
+	Bucket time.Time `json:"bucket"` // start of the step-wide bucket
+	Value  string    `json:"value"`
+	Count  int64     `json:"count"`
+}
+
+// LogPattern is a message template mined from the logs of one severity and
+// service, with the number of messages it matched.
+type LogPattern struct {
+	Template  string    `json:"template"` // variable tokens read <*>
+	Level     string    `json:"level"`
+	Service   string    `json:"service"`
+	Count     int64     `json:"count"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// SavedQuery is a named read-only SQL query kept for reuse, shared by the
+// HTTP API and the TUI.
+type SavedQuery struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	SQL         string    `json:"sql"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 
 	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
@@ -21,17 +25,7 @@ func (h *logsHandler) Export(ctx context.Context, req *collogspb.ExportLogsServi
 		resourceAttrs := extractResourceAttrs(rl.GetResource())
 
 		for _, sl := range rl.GetScopeLogs() {
-			scopeAttrs := ingest.CloneAttributes(resourceAttrs)
-
-			if scope := sl.GetScope(); scope != nil {
-				if scope.Name != "" {
-					scopeAttrs["otel.scope.name"] = scope.Name
-				}
-				if scope.Version != "" {
-					scopeAttrs["otel.scope.version"] = scope.Version
-				}
-				mergeKeyValues(scopeAttrs, scope.Attributes)
-			}
+			scopeAttrs := ingest.OTLPScopeAttributes(resourceAttrs, sl.GetScope())
 
 			for _, lr := range sl.GetLogRecords() {
 				if err := ctx.Err(); err != nil {
@@ -45,3 +39,41 @@ func (h *logsHandler) Export(ctx context.Context, req *collogspb.ExportLogsServi
 
 	return &collogspb.ExportLogsServiceResponse{}, nil
 }
+
+// metricsHandler implements the OTLP MetricsService gRPC server.
+type metricsHandler struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	writer model.MetricWriter
+}
+
+// Export handles an incoming ExportMetricsServiceRequest. A failed write is
+// reported as Unavailable, which OTLP exporters retry.
+func (h *metricsHandler) Export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	samples := ingest.SamplesFromResourceMetrics(req.GetResourceMetrics())
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := h.writer.InsertMetricBatch(samples); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "store metrics: %v", err)
+	}
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+// tracesHandler implements the OTLP TraceService gRPC server.
+type tracesHandler struct {
+	coltracepb.UnimplementedTraceServiceServer
+	writer model.SpanWriter
+}
+
+// Export handles an incoming ExportTraceServiceRequest. A failed write is
+// reported as Unavailable, which OTLP exporters retry.
+func (h *tracesHandler) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	spans := ingest.SpansFromResourceSpans(req.GetResourceSpans())
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := h.writer.InsertSpanBatch(spans); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "store spans: %v", err)
+	}
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
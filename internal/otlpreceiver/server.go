@@ -6,15 +6,20 @@ import (
 	"sync"
 
 	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	"google.golang.org/grpc"
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 )
 
-// Server is an OTLP/gRPC log receiver.
+// Server is an OTLP/gRPC log receiver, and a metrics or trace receiver
+// when given a metric or span writer.
 type Server struct {
 	addr     string
 	sink     model.RecordSink
+	metrics  model.MetricWriter // nil = MetricsService not served
+	spans    model.SpanWriter   // nil = TraceService not served
 	grpc     *grpc.Server
 	listener net.Listener
 	stopOnce sync.Once
@@ -28,6 +33,18 @@ func NewServer(addr string, sink model.RecordSink) *Server {
 	}
 }
 
+// SetMetricWriter serves the OTLP MetricsService, writing each export
+// request's samples to w as one batch. Call before Start.
+func (s *Server) SetMetricWriter(w model.MetricWriter) {
+	s.metrics = w
+}
+
+// SetSpanWriter serves the OTLP TraceService, writing each export
+// request's spans to w as one batch. Call before Start.
+func (s *Server) SetSpanWriter(w model.SpanWriter) {
+	s.spans = w
+}
+
 // Start begins listening and serving gRPC in a background goroutine.
 func (s *Server) Start() error {
 	ln, err := net.Listen("tcp", s.addr)
@@ -40,6 +57,12 @@ func (s *Server) Start() error {
 		grpc.MaxRecvMsgSize(16 << 20), // 16 MB for large OTLP batches
 	)
 	collogspb.RegisterLogsServiceServer(s.grpc, &logsHandler{sink: s.sink})
+	if s.metrics != nil {
+		colmetricspb.RegisterMetricsServiceServer(s.grpc, &metricsHandler{writer: s.metrics})
+	}
+	if s.spans != nil {
+		coltracepb.RegisterTraceServiceServer(s.grpc, &tracesHandler{writer: s.spans})
+	}
 
 	go func() {
 		if err := s.grpc.Serve(ln); err != nil {
@@ -2,16 +2,23 @@ package otlpreceiver
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
 
 	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
 	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 )
@@ -122,3 +129,128 @@ func TestServer_StopIsIdempotent(t *testing.T) {
 	srv.Stop()
 	srv.Stop() // should not panic
 }
+
+type mockMetricWriter struct {
+	mu      sync.Mutex
+	batches [][]*model.MetricSample
+	err     error
+}
+
+func (m *mockMetricWriter) InsertMetricBatch(samples []*model.MetricSample) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.batches = append(m.batches, samples)
+	return nil
+}
+
+func TestServer_Metrics(t *testing.T) {
+	t.Parallel()
+
+	writer := &mockMetricWriter{}
+	srv := NewServer("127.0.0.1:0", &mockSink{})
+	srv.SetMetricWriter(writer)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(srv.Addr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc dial: %v", err)
+	}
+	defer conn.Close()
+	client := colmetricspb.NewMetricsServiceClient(conn)
+
+	req := &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			ScopeMetrics: []*metricspb.ScopeMetrics{{Metrics: []*metricspb.Metric{{
+				Name: "cpu.utilization",
+				Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: []*metricspb.NumberDataPoint{
+					{TimeUnixNano: 1700000000000000000, Value: &metricspb.NumberDataPoint_AsDouble{AsDouble: 0.5}},
+					{TimeUnixNano: 1700000001000000000, Value: &metricspb.NumberDataPoint_AsDouble{AsDouble: 0.7}},
+				}}},
+			}}}},
+		}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.Export(ctx, req); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	writer.mu.Lock()
+	if len(writer.batches) != 1 || len(writer.batches[0]) != 2 || writer.batches[0][1].Value != 0.7 {
+		t.Errorf("batches = %+v, want one batch of 2 samples", writer.batches)
+	}
+	writer.err = errors.New("disk full")
+	writer.mu.Unlock()
+
+	if _, err := client.Export(ctx, req); status.Code(err) != codes.Unavailable {
+		t.Errorf("Export with failing writer: %v, want Unavailable", err)
+	}
+}
+
+type mockSpanWriter struct {
+	mu      sync.Mutex
+	batches [][]*model.Span
+	err     error
+}
+
+func (m *mockSpanWriter) InsertSpanBatch(spans []*model.Span) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.batches = append(m.batches, spans)
+	return nil
+}
+
+func TestServer_Traces(t *testing.T) {
+	t.Parallel()
+
+	writer := &mockSpanWriter{}
+	srv := NewServer("127.0.0.1:0", &mockSink{})
+	srv.SetSpanWriter(writer)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(srv.Addr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc dial: %v", err)
+	}
+	defer conn.Close()
+	client := coltracepb.NewTraceServiceClient(conn)
+
+	req := &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{{
+			ScopeSpans: []*tracepb.ScopeSpans{{Spans: []*tracepb.Span{{
+				TraceId:           []byte{0xab, 0xcd, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+				SpanId:            []byte{0, 0, 0, 0, 0, 0, 0, 2},
+				Name:              "checkout",
+				StartTimeUnixNano: 1700000000000000000,
+			}}}},
+		}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.Export(ctx, req); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	writer.mu.Lock()
+	if len(writer.batches) != 1 || len(writer.batches[0]) != 1 || writer.batches[0][0].TraceID != "abcd0000000000000000000000000001" {
+		t.Errorf("batches = %+v, want one batch of 1 span", writer.batches)
+	}
+	writer.err = errors.New("disk full")
+	writer.mu.Unlock()
+
+	if _, err := client.Export(ctx, req); status.Code(err) != codes.Unavailable {
+		t.Errorf("Export with failing writer: %v, want Unavailable", err)
+	}
+}
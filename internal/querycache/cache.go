@@ -0,0 +1,206 @@
+// Package querycache memoizes read API aggregates for a short TTL, so the
+// decks of several dashboards polling the same query on the same tick share
+// one trip to the store.
+package querycache
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// maxEntries bounds the cache; expired entries are swept once it is reached.
+const maxEntries = 1024
+
+// Reader wraps a read API and caches the results of its aggregate queries
+// for ttl. Identical queries arriving while one is running wait for it
+// instead of running again. Errors are not cached. Log listings, search,
+// and SQL pass through uncached.
+//
+// Cached maps and slices are shared between callers, who must not modify
+// them.
+type Reader struct {
+	model.ReadAPI
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	hits    int64
+	misses  int64
+}
+
+type entry struct {
+	done    chan struct{} // closed once value and err are set
+	value   any
+	err     error
+	expires time.Time // zero while the query runs
+}
+
+// New returns a Reader caching store's aggregates for ttl.
+func New(store model.ReadAPI, ttl time.Duration) *Reader {
+	return &Reader{
+		ReadAPI: store,
+		ttl:     ttl,
+		entries: make(map[string]*entry),
+	}
+}
+
+// Stats returns how many lookups were served from the cache and how many
+// ran the query.
+func (r *Reader) Stats() (hits, misses int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.hits, r.misses
+}
+
+// cached returns the result of load for key, running it only when no live
+// or in-flight entry exists.
+func cached[T any](r *Reader, key string, load func() (T, error)) (T, error) {
+	now := time.Now()
+	r.mu.Lock()
+	if e, ok := r.entries[key]; ok && (e.expires.IsZero() || now.Before(e.expires)) {
+		r.hits++
+		r.mu.Unlock()
+		<-e.done
+		if e.err != nil {
+			var zero T
+			return zero, e.err
+		}
+		return e.value.(T), nil
+	}
+	r.misses++
+	if len(r.entries) >= maxEntries {
+		r.sweep(now)
+	}
+	e := &entry{done: make(chan struct{})}
+	r.entries[key] = e
+	r.mu.Unlock()
+
+	value, err := load()
+
+	r.mu.Lock()
+	e.value, e.err = value, err
+	if err != nil {
+		delete(r.entries, key)
+	} else {
+		e.expires = time.Now().Add(r.ttl)
+	}
+	close(e.done)
+	r.mu.Unlock()
+	return value, err
+}
+
+// sweep drops expired entries; if none expired it drops everything settled,
+// so the map stays bounded. Callers hold r.mu.
+func (r *Reader) sweep(now time.Time) {
+	for key, e := range r.entries {
+		if !e.expires.IsZero() && !now.Before(e.expires) {
+			delete(r.entries, key)
+		}
+	}
+	if len(r.entries) < maxEntries {
+		return
+	}
+	for key, e := range r.entries {
+		if !e.expires.IsZero() {
+			delete(r.entries, key)
+		}
+	}
+}
+
+// cacheKey joins a method name and its arguments into a cache key.
+func cacheKey(method string, args ...any) string {
+	var b strings.Builder
+	b.WriteString(method)
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case string, []string:
+			fmt.Fprintf(&b, "\x00%q", v)
+		default:
+			fmt.Fprintf(&b, "\x00%v", v)
+		}
+	}
+	return b.String()
+}
+
+func (r *Reader) TotalLogCount(opts model.QueryOpts) (int64, error) {
+	return cached(r, cacheKey("TotalLogCount", opts), func() (int64, error) {
+		return r.ReadAPI.TotalLogCount(opts)
+	})
+}
+
+func (r *Reader) TotalLogBytes(opts model.QueryOpts) (int64, error) {
+	return cached(r, cacheKey("TotalLogBytes", opts), func() (int64, error) {
+		return r.ReadAPI.TotalLogBytes(opts)
+	})
+}
+
+func (r *Reader) TopWords(limit int, opts model.QueryOpts) ([]model.WordCount, error) {
+	return cached(r, cacheKey("TopWords", limit, opts), func() ([]model.WordCount, error) {
+		return r.ReadAPI.TopWords(limit, opts)
+	})
+}
+
+func (r *Reader) TopAttributes(limit int, opts model.QueryOpts) ([]model.AttributeStat, error) {
+	return cached(r, cacheKey("TopAttributes", limit, opts), func() ([]model.AttributeStat, error) {
+		return r.ReadAPI.TopAttributes(limit, opts)
+	})
+}
+
+func (r *Reader) TopAttributeKeys(limit int, opts model.QueryOpts) ([]model.AttributeKeyStat, error) {
+	return cached(r, cacheKey("TopAttributeKeys", limit, opts), func() ([]model.AttributeKeyStat, error) {
+		return r.ReadAPI.TopAttributeKeys(limit, opts)
+	})
+}
+
+func (r *Reader) AttributeKeyValues(key string, limit int, opts model.QueryOpts) (map[string]int64, error) {
+	return cached(r, cacheKey("AttributeKeyValues", key, limit, opts), func() (map[string]int64, error) {
+		return r.ReadAPI.AttributeKeyValues(key, limit, opts)
+	})
+}
+
+func (r *Reader) SeverityCounts(opts model.QueryOpts) (map[string]int64, error) {
+	return cached(r, cacheKey("SeverityCounts", opts), func() (map[string]int64, error) {
+		return r.ReadAPI.SeverityCounts(opts)
+	})
+}
+
+func (r *Reader) SeverityCountsByMinute(opts model.QueryOpts) ([]model.MinuteCounts, error) {
+	return cached(r, cacheKey("SeverityCountsByMinute", opts), func() ([]model.MinuteCounts, error) {
+		return r.ReadAPI.SeverityCountsByMinute(opts)
+	})
+}
+
+func (r *Reader) TopHosts(limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
+	return cached(r, cacheKey("TopHosts", limit, opts), func() ([]model.DimensionCount, error) {
+		return r.ReadAPI.TopHosts(limit, opts)
+	})
+}
+
+func (r *Reader) TopServices(limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
+	return cached(r, cacheKey("TopServices", limit, opts), func() ([]model.DimensionCount, error) {
+		return r.ReadAPI.TopServices(limit, opts)
+	})
+}
+
+func (r *Reader) TopServicesBySeverity(severity string, limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
+	return cached(r, cacheKey("TopServicesBySeverity", severity, limit, opts), func() ([]model.DimensionCount, error) {
+		return r.ReadAPI.TopServicesBySeverity(severity, limit, opts)
+	})
+}
+
+func (r *Reader) RateByDimension(dimension string, window, step time.Duration, severityLevels []string, opts model.QueryOpts) ([]model.DimensionRate, error) {
+	return cached(r, cacheKey("RateByDimension", dimension, window, step, severityLevels, opts), func() ([]model.DimensionRate, error) {
+		return r.ReadAPI.RateByDimension(dimension, window, step, severityLevels, opts)
+	})
+}
+
+func (r *Reader) ListApps() ([]string, error) {
+	return cached(r, cacheKey("ListApps"), r.ReadAPI.ListApps)
+}
+
+// Reader is a drop-in read API.
+var _ model.ReadAPI = (*Reader)(nil)
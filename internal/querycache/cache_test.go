@@ -0,0 +1,132 @@
+package querycache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/memstore"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// countingStore counts SeverityCounts calls, optionally blocking each on
+// gate and failing while fail is set.
+type countingStore struct {
+	model.ReadAPI
+	calls atomic.Int64
+	gate  chan struct{}
+	fail  atomic.Bool
+}
+
+func (c *countingStore) SeverityCounts(opts model.QueryOpts) (map[string]int64, error) {
+	c.calls.Add(1)
+	if c.gate != nil {
+		<-c.gate
+	}
+	if c.fail.Load() {
+		return nil, errors.New("store unavailable")
+	}
+	return c.ReadAPI.SeverityCounts(opts)
+}
+
+func newCountingStore(t *testing.T) *countingStore {
+	t.Helper()
+	mem := memstore.NewStore()
+	if err := mem.InsertLogBatch([]*model.LogRecord{
+		{Timestamp: time.Now(), Level: "INFO", Message: "a", App: "shop"},
+		{Timestamp: time.Now(), Level: "ERROR", Message: "b", App: "jobs"},
+	}); err != nil {
+		t.Fatalf("InsertLogBatch: %v", err)
+	}
+	return &countingStore{ReadAPI: mem}
+}
+
+func TestReader_CachesWithinTTL(t *testing.T) {
+	store := newCountingStore(t)
+	r := New(store, 50*time.Millisecond)
+
+	for range 3 {
+		counts, err := r.SeverityCounts(model.QueryOpts{})
+		if err != nil || counts["INFO"] != 1 || counts["ERROR"] != 1 {
+			t.Fatalf("SeverityCounts = %v, %v", counts, err)
+		}
+	}
+	if _, err := r.SeverityCounts(model.QueryOpts{App: "shop"}); err != nil {
+		t.Fatalf("SeverityCounts(shop): %v", err)
+	}
+	if got := store.calls.Load(); got != 2 {
+		t.Errorf("store calls = %d, want 2 (one per distinct opts)", got)
+	}
+	if hits, misses := r.Stats(); hits != 2 || misses != 2 {
+		t.Errorf("Stats = %d hits, %d misses; want 2, 2", hits, misses)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := r.SeverityCounts(model.QueryOpts{}); err != nil {
+		t.Fatalf("SeverityCounts after TTL: %v", err)
+	}
+	if got := store.calls.Load(); got != 3 {
+		t.Errorf("store calls after TTL = %d, want 3", got)
+	}
+}
+
+func TestReader_ErrorsNotCached(t *testing.T) {
+	store := newCountingStore(t)
+	r := New(store, time.Minute)
+
+	store.fail.Store(true)
+	if _, err := r.SeverityCounts(model.QueryOpts{}); err == nil {
+		t.Fatal("expected the store error")
+	}
+	store.fail.Store(false)
+	if counts, err := r.SeverityCounts(model.QueryOpts{}); err != nil || counts["INFO"] != 1 {
+		t.Fatalf("SeverityCounts after recovery = %v, %v", counts, err)
+	}
+	if got := store.calls.Load(); got != 2 {
+		t.Errorf("store calls = %d, want 2", got)
+	}
+}
+
+func TestReader_CoalescesConcurrentQueries(t *testing.T) {
+	store := newCountingStore(t)
+	store.gate = make(chan struct{})
+	r := New(store, time.Minute)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := r.SeverityCounts(model.QueryOpts{}); err != nil {
+				t.Errorf("SeverityCounts: %v", err)
+			}
+		}()
+	}
+	// Let the callers queue up behind the first before releasing it.
+	for {
+		if hits, misses := r.Stats(); hits+misses == 8 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(store.gate)
+	wg.Wait()
+
+	if got := store.calls.Load(); got != 1 {
+		t.Errorf("store calls = %d, want 1", got)
+	}
+}
+
+func TestReader_PassesThroughListings(t *testing.T) {
+	store := newCountingStore(t)
+	r := New(store, time.Minute)
+	logs, err := r.RecentLogsFiltered(10, model.QueryOpts{}, nil, nil, "")
+	if err != nil || len(logs) != 2 {
+		t.Fatalf("RecentLogsFiltered = %d logs, %v; want 2", len(logs), err)
+	}
+	if hits, misses := r.Stats(); hits+misses != 0 {
+		t.Errorf("Stats = %d hits, %d misses; want no cache lookups", hits, misses)
+	}
+}
@@ -0,0 +1,98 @@
+package readrpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/httpserver"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/readrpc/readpb"
+)
+
+// queryOpts converts request options; unset times stay unbounded. Under a
+// key limited to apps, app must name one of them (PermissionDenied
+// otherwise) and defaults to the key's only app (InvalidArgument when it
+// has several).
+func queryOpts(ctx context.Context, o *readpb.QueryOpts) (model.QueryOpts, error) {
+	var opts model.QueryOpts
+	app, err := httpserver.RestrictApp(o.GetApp(), grantedApps(ctx))
+	switch {
+	case errors.Is(err, httpserver.ErrAppDenied):
+		return opts, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return opts, status.Error(codes.InvalidArgument, err.Error())
+	}
+	opts.App = app
+	if o.GetFrom() != nil {
+		opts.From = o.GetFrom().AsTime()
+	}
+	if o.GetTo() != nil {
+		opts.To = o.GetTo().AsTime()
+	}
+	return opts, nil
+}
+
+// timestamp converts t, leaving zero times unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func logRecords(records []model.LogRecord) []*readpb.LogRecord {
+	out := make([]*readpb.LogRecord, len(records))
+	for i, r := range records {
+		out[i] = &readpb.LogRecord{
+			Timestamp:     timestamp(r.Timestamp),
+			OrigTimestamp: timestamp(r.OrigTimestamp),
+			Level:         r.Level,
+			LevelNum:      int32(r.LevelNum),
+			Message:       r.Message,
+			RawLine:       r.RawLine,
+			Service:       r.Service,
+			Hostname:      r.Hostname,
+			Pid:           int32(r.PID),
+			Attributes:    r.Attributes,
+			Source:        r.Source,
+			App:           r.App,
+			EventId:       r.EventID,
+			BodyJson:      r.BodyJSON,
+		}
+	}
+	return out
+}
+
+func dimensionCounts(counts []model.DimensionCount) []*readpb.DimensionCount {
+	out := make([]*readpb.DimensionCount, len(counts))
+	for i, c := range counts {
+		out[i] = &readpb.DimensionCount{Value: c.Value, Count: c.Count}
+	}
+	return out
+}
+
+func spans(spans []model.Span) []*readpb.Span {
+	out := make([]*readpb.Span, len(spans))
+	for i, s := range spans {
+		out[i] = &readpb.Span{
+			TraceId:       s.TraceID,
+			SpanId:        s.SpanID,
+			ParentSpanId:  s.ParentSpanID,
+			Name:          s.Name,
+			Kind:          s.Kind,
+			StartTime:     timestamp(s.StartTime),
+			EndTime:       timestamp(s.EndTime),
+			StatusCode:    s.StatusCode,
+			StatusMessage: s.StatusMessage,
+			Attributes:    s.Attributes,
+			Service:       s.Service,
+			App:           s.App,
+		}
+	}
+	return out
+}
@@ -0,0 +1,5 @@
+// Package readpb holds the protobuf messages and gRPC stubs of the read
+// API, generated from read.proto.
+package readpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative read.proto
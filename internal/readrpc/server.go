@@ -0,0 +1,150 @@
+// Package readrpc serves the read API over gRPC (readpb.ReadService), for
+// programs that want typed access without the socket's JSON-RPC.
+package readrpc
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log"
+	"net"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/httpserver"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/readrpc/readpb"
+)
+
+// Server is a gRPC server for the read API.
+type Server struct {
+	addr     string
+	store    model.ReadAPI
+	traces   model.TraceQuerier  // nil = trace methods unimplemented
+	keyring  *httpserver.Keyring // nil = no API key required
+	tls      *tls.Config         // nil = plaintext
+	grpc     *grpc.Server
+	listener net.Listener
+	stopOnce sync.Once
+}
+
+// NewServer creates a gRPC read API server.
+func NewServer(addr string, store model.ReadAPI) *Server {
+	return &Server{
+		addr:  addr,
+		store: store,
+	}
+}
+
+// SetTraceQuerier serves TraceLogs and TraceSpans from q. Call before Start.
+func (s *Server) SetTraceQuerier(q model.TraceQuerier) {
+	s.traces = q
+}
+
+// SetKeyring requires every call to carry one of k's keys with the read
+// scope, as the HTTP API does. Call before Start.
+func (s *Server) SetKeyring(k *httpserver.Keyring) {
+	s.keyring = k
+}
+
+// SetTLSConfig serves over TLS with c. Call before Start.
+func (s *Server) SetTLSConfig(c *tls.Config) {
+	s.tls = c
+}
+
+// Start begins listening and serving gRPC in a background goroutine.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.listener = ln
+
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(s.authorize)}
+	if s.tls != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tls)))
+	}
+	s.grpc = grpc.NewServer(opts...)
+	readpb.RegisterReadServiceServer(s.grpc, &service{store: s.store, traces: s.traces})
+
+	go func() {
+		if err := s.grpc.Serve(ln); err != nil {
+			log.Printf("readrpc: grpc.Serve exited: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop gracefully shuts down the gRPC server.
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() {
+		if s.grpc != nil {
+			s.grpc.GracefulStop()
+		}
+	})
+}
+
+// Addr returns the actual listen address (useful when port 0 is used).
+func (s *Server) Addr() string {
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+	return s.addr
+}
+
+// authorize rejects calls without a key granting the read scope when a
+// keyring is set: Unauthenticated without a known key, PermissionDenied
+// without the scope. The apps a key is limited to travel in the context.
+func (s *Server) authorize(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if s.keyring == nil {
+		return handler(ctx, req)
+	}
+	token := presentedKey(ctx)
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "API key required")
+	}
+	_, apps, err := s.keyring.Authorize(token, httpserver.ScopeRead)
+	if err != nil {
+		if errors.Is(err, httpserver.ErrScopeDenied) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if apps != nil {
+		ctx = context.WithValue(ctx, appsKey{}, apps)
+	}
+	return handler(ctx, req)
+}
+
+// appsKey is the context key of the apps the call's key is limited to.
+type appsKey struct{}
+
+// grantedApps returns the apps the call's key is limited to; nil when it
+// reads every app or no keyring is set.
+func grantedApps(ctx context.Context) []string {
+	apps, _ := ctx.Value(appsKey{}).([]string)
+	return apps
+}
+
+// presentedKey returns the key in the call's "authorization: Bearer KEY" or
+// "x-api-key: KEY" metadata.
+func presentedKey(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 {
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+	if values := md.Get("x-api-key"); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
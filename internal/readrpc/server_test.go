@@ -0,0 +1,176 @@
+package readrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/httpserver"
+	"github.com/tinytelemetry/tiny-telemetry/internal/memstore"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/readrpc/readpb"
+)
+
+func startServer(t *testing.T, store model.ReadAPI, configure func(*Server)) readpb.ReadServiceClient {
+	t.Helper()
+	srv := NewServer("127.0.0.1:0", store)
+	if configure != nil {
+		configure(srv)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(srv.Addr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return readpb.NewReadServiceClient(conn)
+}
+
+func TestServer_Queries(t *testing.T) {
+	t.Parallel()
+
+	store := memstore.NewStore()
+	base := time.Date(2026, 5, 6, 7, 8, 0, 0, time.UTC)
+	if err := store.InsertLogBatch([]*model.LogRecord{
+		{Timestamp: base, App: "shop", Service: "api", Level: "INFO", Message: "checkout ok", Attributes: map[string]string{"region": "eu"}},
+		{Timestamp: base.Add(time.Minute), App: "shop", Service: "api", Level: "ERROR", Message: "checkout failed"},
+		{Timestamp: base.Add(2 * time.Minute), App: "billing", Service: "worker", Level: "ERROR", Message: "invoice failed"},
+	}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	client := startServer(t, store, nil)
+	ctx := context.Background()
+	shop := &readpb.QueryOpts{App: "shop"}
+
+	count, err := client.TotalLogCount(ctx, &readpb.OptsRequest{Opts: shop})
+	if err != nil || count.GetCount() != 2 {
+		t.Fatalf("TotalLogCount(shop) = %v, %v; want 2", count, err)
+	}
+	count, err = client.TotalLogCount(ctx, &readpb.OptsRequest{Opts: &readpb.QueryOpts{From: timestamppb.New(base.Add(time.Minute))}})
+	if err != nil || count.GetCount() != 2 {
+		t.Errorf("TotalLogCount(from) = %v, %v; want 2", count, err)
+	}
+
+	severities, err := client.SeverityCounts(ctx, &readpb.OptsRequest{})
+	if err != nil || severities.GetCounts()["ERROR"] != 2 {
+		t.Errorf("SeverityCounts = %v, %v; want 2 errors", severities, err)
+	}
+
+	apps, err := client.ListApps(ctx, &readpb.ListAppsRequest{})
+	if err != nil || len(apps.GetApps()) != 2 {
+		t.Errorf("ListApps = %v, %v; want 2 apps", apps, err)
+	}
+
+	logs, err := client.RecentLogsFiltered(ctx, &readpb.RecentLogsRequest{Limit: 10, Opts: shop, SeverityLevels: []string{"INFO"}})
+	if err != nil || len(logs.GetLogs()) != 1 {
+		t.Fatalf("RecentLogsFiltered = %v, %v; want 1 log", logs, err)
+	}
+	got := logs.GetLogs()[0]
+	if got.GetMessage() != "checkout ok" || got.GetAttributes()["region"] != "eu" || !got.GetTimestamp().AsTime().Equal(base) || got.GetOrigTimestamp() != nil {
+		t.Errorf("log = %v", got)
+	}
+
+	logs, err = client.RecentLogsFiltered(ctx, &readpb.RecentLogsRequest{Limit: 10, Facets: map[string]string{"service": "worker"}})
+	if err != nil || len(logs.GetLogs()) != 1 || logs.GetLogs()[0].GetMessage() != "invoice failed" {
+		t.Errorf("RecentLogsFiltered(service=worker) = %v, %v; want the worker's log", logs, err)
+	}
+
+	services, err := client.TopServicesBySeverity(ctx, &readpb.TopServicesBySeverityRequest{Severity: "ERROR", Limit: 5})
+	if err != nil || len(services.GetCounts()) != 2 {
+		t.Errorf("TopServicesBySeverity = %v, %v; want 2 services", services, err)
+	}
+
+	rates, err := client.RateByDimension(ctx, &readpb.RateByDimensionRequest{
+		Dimension: "app", Window: durationpb.New(time.Hour), Step: durationpb.New(time.Minute),
+		Opts: &readpb.QueryOpts{To: timestamppb.New(base.Add(time.Hour))},
+	})
+	if err != nil || len(rates.GetRates()) != 3 {
+		t.Errorf("RateByDimension = %v, %v; want 3 buckets", rates, err)
+	}
+
+	if _, err := client.TraceSpans(ctx, &readpb.TraceRequest{TraceId: "abc"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("TraceSpans without a trace querier: %v, want Unimplemented", err)
+	}
+}
+
+func TestServer_APIKeys(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := httpserver.NewKeyring([]httpserver.APIKey{
+		{Name: "reader", Key: "read-key-00000001", Scopes: []string{httpserver.ScopeRead}},
+		{Name: "shipper", Key: "ingest-key-000001", Scopes: []string{httpserver.ScopeIngest}},
+	})
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	client := startServer(t, memstore.NewStore(), func(s *Server) { s.SetKeyring(keyring) })
+
+	call := func(md ...string) codes.Code {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), md...)
+		_, err := client.TotalLogCount(ctx, &readpb.OptsRequest{})
+		return status.Code(err)
+	}
+	if code := call(); code != codes.Unauthenticated {
+		t.Errorf("without a key: %v, want Unauthenticated", code)
+	}
+	if code := call("authorization", "Bearer wrong-key-000000001"); code != codes.Unauthenticated {
+		t.Errorf("with an unknown key: %v, want Unauthenticated", code)
+	}
+	if code := call("x-api-key", "ingest-key-000001"); code != codes.PermissionDenied {
+		t.Errorf("with an ingest key: %v, want PermissionDenied", code)
+	}
+	if code := call("authorization", "Bearer read-key-00000001"); code != codes.OK {
+		t.Errorf("with a read key: %v, want OK", code)
+	}
+}
+
+func TestServer_AppLimitedKeys(t *testing.T) {
+	t.Parallel()
+
+	store := memstore.NewStore()
+	base := time.Date(2026, 5, 6, 7, 8, 0, 0, time.UTC)
+	if err := store.InsertLogBatch([]*model.LogRecord{
+		{Timestamp: base, App: "shop", Service: "api", Level: "INFO", Message: "checkout ok"},
+		{Timestamp: base.Add(time.Minute), App: "billing", Service: "worker", Level: "ERROR", Message: "invoice failed"},
+		{Timestamp: base.Add(2 * time.Minute), App: "search", Service: "indexer", Level: "INFO", Message: "indexed"},
+	}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	keyring, err := httpserver.NewKeyring([]httpserver.APIKey{
+		{Name: "shop-team", Key: "shop-team-key-0001", Apps: []string{"shop"}},
+		{Name: "finance", Key: "finance-key-00001", Apps: []string{"shop", "billing"}},
+	})
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	client := startServer(t, store, func(s *Server) { s.SetKeyring(keyring) })
+	shop := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "shop-team-key-0001")
+	finance := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "finance-key-00001")
+
+	count, err := client.TotalLogCount(shop, &readpb.OptsRequest{})
+	if err != nil || count.GetCount() != 1 {
+		t.Errorf("TotalLogCount without app = %v, %v; want the shop log", count, err)
+	}
+	if _, err := client.TotalLogCount(shop, &readpb.OptsRequest{Opts: &readpb.QueryOpts{App: "billing"}}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("TotalLogCount(billing): %v, want PermissionDenied", err)
+	}
+	if _, err := client.TotalLogCount(finance, &readpb.OptsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("TotalLogCount without app for several apps: %v, want InvalidArgument", err)
+	}
+	apps, err := client.ListApps(finance, &readpb.ListAppsRequest{})
+	if err != nil || len(apps.GetApps()) != 2 {
+		t.Errorf("ListApps = %v, %v; want shop and billing", apps, err)
+	}
+}
@@ -0,0 +1,284 @@
+package readrpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/readrpc/readpb"
+)
+
+// service implements readpb.ReadServiceServer on a store; each method maps
+// 1:1 to the model.LogQuerier or model.TraceQuerier method of its name.
+// Calls with a key limited to apps read only those apps' logs and spans.
+type service struct {
+	readpb.UnimplementedReadServiceServer
+	store  model.ReadAPI
+	traces model.TraceQuerier
+}
+
+// queryError maps a store error to a gRPC status: Unavailable when the
+// query was shed, DeadlineExceeded when it timed out, Unknown otherwise.
+func queryError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrOverloaded):
+		return status.Error(codes.Unavailable, "query overloaded; retry")
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return status.Error(codes.DeadlineExceeded, "query timed out; retry")
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
+
+func (s *service) TotalLogCount(ctx context.Context, req *readpb.OptsRequest) (*readpb.CountResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	n, err := s.store.TotalLogCount(opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &readpb.CountResponse{Count: n}, nil
+}
+
+func (s *service) TotalLogBytes(ctx context.Context, req *readpb.OptsRequest) (*readpb.CountResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	n, err := s.store.TotalLogBytes(opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &readpb.CountResponse{Count: n}, nil
+}
+
+func (s *service) TopWords(ctx context.Context, req *readpb.TopRequest) (*readpb.WordCountsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	words, err := s.store.TopWords(int(req.GetLimit()), opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	resp := &readpb.WordCountsResponse{Words: make([]*readpb.WordCount, len(words))}
+	for i, w := range words {
+		resp.Words[i] = &readpb.WordCount{Word: w.Word, Count: w.Count}
+	}
+	return resp, nil
+}
+
+func (s *service) TopAttributes(ctx context.Context, req *readpb.TopRequest) (*readpb.AttributeStatsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.store.TopAttributes(int(req.GetLimit()), opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	resp := &readpb.AttributeStatsResponse{Attributes: make([]*readpb.AttributeStat, len(stats))}
+	for i, a := range stats {
+		resp.Attributes[i] = &readpb.AttributeStat{Key: a.Key, Value: a.Value, Count: a.Count}
+	}
+	return resp, nil
+}
+
+func (s *service) TopAttributeKeys(ctx context.Context, req *readpb.TopRequest) (*readpb.AttributeKeyStatsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.store.TopAttributeKeys(int(req.GetLimit()), opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	resp := &readpb.AttributeKeyStatsResponse{Keys: make([]*readpb.AttributeKeyStat, len(stats))}
+	for i, k := range stats {
+		resp.Keys[i] = &readpb.AttributeKeyStat{Key: k.Key, UniqueValues: int64(k.UniqueValues), TotalCount: k.TotalCount}
+	}
+	return resp, nil
+}
+
+func (s *service) AttributeKeyValues(ctx context.Context, req *readpb.AttributeKeyValuesRequest) (*readpb.CountsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.store.AttributeKeyValues(req.GetKey(), int(req.GetLimit()), opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &readpb.CountsResponse{Counts: counts}, nil
+}
+
+func (s *service) DistinctAttributeValues(ctx context.Context, req *readpb.DistinctAttributeValuesRequest) (*readpb.DimensionCountsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.store.DistinctAttributeValues(req.GetKey(), req.GetPrefix(), int(req.GetLimit()), opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &readpb.DimensionCountsResponse{Counts: dimensionCounts(values)}, nil
+}
+
+func (s *service) SeverityCounts(ctx context.Context, req *readpb.OptsRequest) (*readpb.CountsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.store.SeverityCounts(opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &readpb.CountsResponse{Counts: counts}, nil
+}
+
+func (s *service) SeverityCountsByMinute(ctx context.Context, req *readpb.OptsRequest) (*readpb.MinuteCountsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	minutes, err := s.store.SeverityCountsByMinute(opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	resp := &readpb.MinuteCountsResponse{Minutes: make([]*readpb.MinuteCounts, len(minutes))}
+	for i, m := range minutes {
+		resp.Minutes[i] = &readpb.MinuteCounts{
+			Minute: timestamp(m.Minute),
+			Trace:  m.Trace,
+			Debug:  m.Debug,
+			Info:   m.Info,
+			Warn:   m.Warn,
+			Error:  m.Error,
+			Fatal:  m.Fatal,
+			Total:  m.Total,
+		}
+	}
+	return resp, nil
+}
+
+func (s *service) TopHosts(ctx context.Context, req *readpb.TopRequest) (*readpb.DimensionCountsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	hosts, err := s.store.TopHosts(int(req.GetLimit()), opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &readpb.DimensionCountsResponse{Counts: dimensionCounts(hosts)}, nil
+}
+
+func (s *service) TopServices(ctx context.Context, req *readpb.TopRequest) (*readpb.DimensionCountsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	services, err := s.store.TopServices(int(req.GetLimit()), opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &readpb.DimensionCountsResponse{Counts: dimensionCounts(services)}, nil
+}
+
+func (s *service) TopServicesBySeverity(ctx context.Context, req *readpb.TopServicesBySeverityRequest) (*readpb.DimensionCountsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	services, err := s.store.TopServicesBySeverity(req.GetSeverity(), int(req.GetLimit()), opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &readpb.DimensionCountsResponse{Counts: dimensionCounts(services)}, nil
+}
+
+func (s *service) ListApps(ctx context.Context, _ *readpb.ListAppsRequest) (*readpb.ListAppsResponse, error) {
+	apps, err := s.store.ListApps()
+	if err != nil {
+		return nil, queryError(err)
+	}
+	if allowed := grantedApps(ctx); allowed != nil {
+		apps = slices.DeleteFunc(apps, func(app string) bool { return !slices.Contains(allowed, app) })
+	}
+	return &readpb.ListAppsResponse{Apps: apps}, nil
+}
+
+func (s *service) RecentLogsFiltered(ctx context.Context, req *readpb.RecentLogsRequest) (*readpb.LogsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	logs, err := s.store.RecentLogsFiltered(int(req.GetLimit()), opts, req.GetSeverityLevels(), req.GetFacets(), req.GetMessagePattern())
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &readpb.LogsResponse{Logs: logRecords(logs)}, nil
+}
+
+func (s *service) SearchLogs(ctx context.Context, req *readpb.SearchLogsRequest) (*readpb.LogsResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	logs, err := s.store.SearchLogs(req.GetTerm(), int(req.GetLimit()), opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &readpb.LogsResponse{Logs: logRecords(logs)}, nil
+}
+
+func (s *service) RateByDimension(ctx context.Context, req *readpb.RateByDimensionRequest) (*readpb.DimensionRatesResponse, error) {
+	opts, err := queryOpts(ctx, req.GetOpts())
+	if err != nil {
+		return nil, err
+	}
+	rates, err := s.store.RateByDimension(req.GetDimension(), req.GetWindow().AsDuration(), req.GetStep().AsDuration(),
+		req.GetSeverityLevels(), opts)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	resp := &readpb.DimensionRatesResponse{Rates: make([]*readpb.DimensionRate, len(rates))}
+	for i, r := range rates {
+		resp.Rates[i] = &readpb.DimensionRate{Bucket: timestamp(r.Bucket), Value: r.Value, Count: r.Count}
+	}
+	return resp, nil
+}
+
+func (s *service) TraceLogs(ctx context.Context, req *readpb.TraceRequest) (*readpb.LogsResponse, error) {
+	if s.traces == nil {
+		return s.UnimplementedReadServiceServer.TraceLogs(ctx, req)
+	}
+	logs, err := s.traces.TraceLogs(req.GetTraceId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, queryError(err)
+	}
+	if allowed := grantedApps(ctx); allowed != nil {
+		logs = slices.DeleteFunc(logs, func(r model.LogRecord) bool { return !slices.Contains(allowed, r.App) })
+	}
+	return &readpb.LogsResponse{Logs: logRecords(logs)}, nil
+}
+
+func (s *service) TraceSpans(ctx context.Context, req *readpb.TraceRequest) (*readpb.SpansResponse, error) {
+	if s.traces == nil {
+		return s.UnimplementedReadServiceServer.TraceSpans(ctx, req)
+	}
+	found, err := s.traces.TraceSpans(req.GetTraceId())
+	if err != nil {
+		return nil, queryError(err)
+	}
+	if allowed := grantedApps(ctx); allowed != nil {
+		found = slices.DeleteFunc(found, func(sp model.Span) bool { return !slices.Contains(allowed, sp.App) })
+	}
+	return &readpb.SpansResponse{Spans: spans(found)}, nil
+}
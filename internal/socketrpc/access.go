@@ -0,0 +1,171 @@
+package socketrpc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"slices"
+	"strconv"
+)
+
+// SocketAccess controls the Unix socket file and who may use it.
+type SocketAccess struct {
+	Mode  os.FileMode // socket file permissions; 0 keeps what the umask gives
+	Owner string      // user name or id to chown the socket to; "" = unchanged
+	Group string      // group name or id; "" = unchanged
+
+	// AllowUsers and AllowGroups (names or ids) limit who may connect, by
+	// the peer credentials of the connecting process (Linux only). A peer
+	// is allowed when its user is listed, or its primary or a
+	// supplementary group is. The server's own user is always allowed.
+	// Both empty allows anyone who can open the socket file.
+	AllowUsers  []string
+	AllowGroups []string
+}
+
+// socketAccess is a SocketAccess with its names resolved.
+type socketAccess struct {
+	mode     os.FileMode
+	uid, gid int          // -1 = unchanged
+	allow    *peerAllowed // nil = any peer
+}
+
+// peerAllowed is the allowlist of Unix socket peers.
+type peerAllowed struct {
+	self   uint32
+	users  []uint32
+	groups []uint32
+}
+
+// SetSocketAccess sets the socket file's mode and ownership and the peers
+// allowed to connect. It fails for unknown users or groups, and for an
+// allowlist where peer credentials cannot be read. Call before Start.
+func (s *Server) SetSocketAccess(a SocketAccess) error {
+	access := socketAccess{mode: a.Mode.Perm(), uid: -1, gid: -1}
+	if a.Owner != "" {
+		uid, err := lookupUser(a.Owner)
+		if err != nil {
+			return err
+		}
+		access.uid = int(uid)
+	}
+	if a.Group != "" {
+		gid, err := lookupGroup(a.Group)
+		if err != nil {
+			return err
+		}
+		access.gid = int(gid)
+	}
+	if len(a.AllowUsers) > 0 || len(a.AllowGroups) > 0 {
+		if !peerCredSupported {
+			return fmt.Errorf("socketrpc: socket allowlists need peer credentials, which this platform lacks")
+		}
+		allow := &peerAllowed{self: uint32(os.Getuid())}
+		for _, name := range a.AllowUsers {
+			uid, err := lookupUser(name)
+			if err != nil {
+				return err
+			}
+			allow.users = append(allow.users, uid)
+		}
+		for _, name := range a.AllowGroups {
+			gid, err := lookupGroup(name)
+			if err != nil {
+				return err
+			}
+			allow.groups = append(allow.groups, gid)
+		}
+		access.allow = allow
+	}
+	s.access = access
+	return nil
+}
+
+// applySocketAccess sets the mode and ownership of the socket file.
+func (s *Server) applySocketAccess() error {
+	if s.access.mode != 0 {
+		if err := os.Chmod(s.socketPath, s.access.mode); err != nil {
+			return fmt.Errorf("socketrpc: chmod: %w", err)
+		}
+	}
+	if s.access.uid >= 0 || s.access.gid >= 0 {
+		if err := os.Chown(s.socketPath, s.access.uid, s.access.gid); err != nil {
+			return fmt.Errorf("socketrpc: chown: %w", err)
+		}
+	}
+	return nil
+}
+
+// refusePeer returns why the peer of a Unix socket connection may not use
+// it, or "" when it may.
+func (s *Server) refusePeer(conn net.Conn) string {
+	if s.access.allow == nil {
+		return ""
+	}
+	uid, gid, err := peerCred(conn)
+	if err != nil {
+		return fmt.Sprintf("peer credentials unavailable: %v", err)
+	}
+	if !s.access.allow.allows(uid, gid) {
+		return fmt.Sprintf("uid %d is not allowed on this socket", uid)
+	}
+	return ""
+}
+
+// allows reports whether the peer user uid, running with primary group
+// gid, is on the allowlist.
+func (p *peerAllowed) allows(uid, gid uint32) bool {
+	if uid == p.self || slices.Contains(p.users, uid) || slices.Contains(p.groups, gid) {
+		return true
+	}
+	if len(p.groups) == 0 {
+		return false
+	}
+	u, err := user.LookupId(strconv.FormatUint(uint64(uid), 10))
+	if err != nil {
+		return false
+	}
+	ids, err := u.GroupIds()
+	if err != nil {
+		return false
+	}
+	for _, id := range ids {
+		if g, err := strconv.ParseUint(id, 10, 32); err == nil && slices.Contains(p.groups, uint32(g)) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupUser returns the id of a user given by name or id.
+func lookupUser(name string) (uint32, error) {
+	if id, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return uint32(id), nil
+	}
+	u, err := user.Lookup(name)
+	if err != nil {
+		return 0, fmt.Errorf("socketrpc: %w", err)
+	}
+	id, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("socketrpc: user %s has no numeric id", name)
+	}
+	return uint32(id), nil
+}
+
+// lookupGroup returns the id of a group given by name or id.
+func lookupGroup(name string) (uint32, error) {
+	if id, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return uint32(id), nil
+	}
+	g, err := user.LookupGroup(name)
+	if err != nil {
+		return 0, fmt.Errorf("socketrpc: %w", err)
+	}
+	id, err := strconv.ParseUint(g.Gid, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("socketrpc: group %s has no numeric id", name)
+	}
+	return uint32(id), nil
+}
@@ -0,0 +1,81 @@
+package socketrpc
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestSocketAccess_ModeAndOwner(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "access.sock")
+	srv := NewServer(sockPath, &stubQuerier{})
+	if err := srv.SetSocketAccess(SocketAccess{Owner: "no-such-user-tiny-telemetry"}); err == nil {
+		t.Fatal("SetSocketAccess accepted an unknown user")
+	}
+	self := strconv.Itoa(os.Getuid())
+	if err := srv.SetSocketAccess(SocketAccess{Mode: 0600, Owner: self, Group: strconv.Itoa(os.Getgid())}); err != nil {
+		t.Fatalf("SetSocketAccess: %v", err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer srv.Stop()
+
+	info, err := os.Stat(sockPath)
+	if err != nil {
+		t.Fatalf("stat socket: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Fatalf("socket mode = %o, want 600", perm)
+	}
+}
+
+func TestSocketAccess_PeerAllowlist(t *testing.T) {
+	if !peerCredSupported {
+		t.Skip("no peer credentials on this platform")
+	}
+	uid, gid := uint32(os.Getuid()), uint32(os.Getgid())
+	dial := func(t *testing.T, access func(srv *Server)) error {
+		t.Helper()
+		sockPath := filepath.Join(t.TempDir(), "allow.sock")
+		srv := NewServer(sockPath, &stubQuerier{})
+		access(srv)
+		if err := srv.Start(); err != nil {
+			t.Fatalf("start: %v", err)
+		}
+		defer srv.Stop()
+		client, err := Dial(sockPath)
+		if err == nil {
+			client.Close()
+		}
+		return err
+	}
+
+	err := dial(t, func(srv *Server) {
+		if err := srv.SetSocketAccess(SocketAccess{AllowUsers: []string{strconv.Itoa(int(uid))}}); err != nil {
+			t.Fatalf("SetSocketAccess: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("allowed user refused: %v", err)
+	}
+
+	// As if the server ran as someone else and this user were not listed.
+	err = dial(t, func(srv *Server) {
+		srv.access.allow = &peerAllowed{self: uid + 1, users: []uint32{uid + 2}}
+	})
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32003 {
+		t.Fatalf("Dial by a user not allowed error = %v, want -32003", err)
+	}
+
+	// A listed group admits the peer by its primary group.
+	err = dial(t, func(srv *Server) {
+		srv.access.allow = &peerAllowed{self: uid + 1, groups: []uint32{gid}}
+	})
+	if err != nil {
+		t.Fatalf("allowed group refused: %v", err)
+	}
+}
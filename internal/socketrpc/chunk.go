@@ -0,0 +1,111 @@
+package socketrpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	// featureChunked names the chunked result framing in Hello.
+	featureChunked = "chunked"
+	// chunkThreshold is the encoded result size above which a connection
+	// that negotiated chunking gets the result compressed and chunked.
+	chunkThreshold = 256 * 1024
+	// chunkSize caps the compressed bytes one chunk carries; base64 makes
+	// its line about a third longer.
+	chunkSize = 1024 * 1024
+)
+
+// Chunk carries part of a large result. On a connection whose client asked
+// for the "chunked" feature in Hello, a result over chunkThreshold is gzip
+// compressed and split across responses with the request's id, each with a
+// Chunk and no Result; the last has More unset.
+type Chunk struct {
+	Data []byte `json:"data"` // base64 in JSON
+	More bool   `json:"more,omitempty"`
+}
+
+// wantsChunks reports whether Hello params ask for chunked results.
+func wantsChunks(params json.RawMessage) bool {
+	var p struct{ Features []string }
+	if json.Unmarshal(params, &p) != nil {
+		return false
+	}
+	for _, f := range p.Features {
+		if f == featureChunked {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeResponse writes resp, compressed and chunked when chunked is set
+// and its result is large.
+func encodeResponse(encoder *json.Encoder, resp Response, chunked bool) error {
+	if !chunked || len(resp.Result) <= chunkThreshold {
+		return encoder.Encode(resp)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(resp.Result); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+	for {
+		n := min(len(data), chunkSize)
+		part := Response{JSONRPC: resp.JSONRPC, ID: resp.ID, Chunk: &Chunk{Data: data[:n], More: n < len(data)}}
+		if err := encoder.Encode(part); err != nil {
+			return err
+		}
+		data = data[n:]
+		if len(data) == 0 {
+			return nil
+		}
+	}
+}
+
+// readResponse reads the next response, reassembling and decompressing a
+// chunked result. The chunks of one result arrive together.
+func readResponse(next func() ([]byte, error)) (Response, error) {
+	var compressed []byte
+	id := -1
+	for {
+		line, err := next()
+		if err != nil {
+			return Response{}, err
+		}
+		var resp Response
+		if err := json.Unmarshal(line, &resp); err != nil {
+			return Response{}, fmt.Errorf("socketrpc: unmarshal response: %w", err)
+		}
+		if resp.Chunk == nil {
+			return resp, nil
+		}
+		if id < 0 {
+			id = resp.ID
+		} else if resp.ID != id {
+			return Response{}, fmt.Errorf("socketrpc: chunk for request %d, want %d", resp.ID, id)
+		}
+		compressed = append(compressed, resp.Chunk.Data...)
+		if resp.Chunk.More {
+			continue
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return Response{}, fmt.Errorf("socketrpc: chunked result: %w", err)
+		}
+		result, err := io.ReadAll(zr)
+		if err != nil {
+			return Response{}, fmt.Errorf("socketrpc: chunked result: %w", err)
+		}
+		resp.Chunk = nil
+		resp.Result = result
+		return resp, nil
+	}
+}
@@ -0,0 +1,77 @@
+package socketrpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestEncodeResponse_Chunks(t *testing.T) {
+	t.Parallel()
+
+	// About 4 MB of JSON that compresses to more than one chunk.
+	rng := rand.New(rand.NewPCG(1, 2))
+	var sb strings.Builder
+	sb.WriteString(`[`)
+	for i := range 100000 {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, `{"n":%d,"h":"%016x"}`, i, rng.Uint64())
+	}
+	sb.WriteString(`]`)
+	result := json.RawMessage(sb.String())
+	resp := Response{JSONRPC: "2.0", ID: 9, Result: result}
+
+	var buf bytes.Buffer
+	if err := encodeResponse(json.NewEncoder(&buf), resp, false); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Fatal("a connection without chunking got several lines")
+	}
+
+	buf.Reset()
+	if err := encodeResponse(json.NewEncoder(&buf), resp, true); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("chunked result sent in %d lines, want several", len(lines))
+	}
+	for _, line := range lines {
+		if len(line) > chunkSize*3/2 {
+			t.Fatalf("chunk line of %d bytes", len(line))
+		}
+	}
+	if buf.Len() >= len(result) {
+		t.Fatalf("chunked result is %d bytes, not smaller than %d", buf.Len(), len(result))
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	scanner.Buffer(nil, scannerMaxTokenSize)
+	next := func() ([]byte, error) {
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("ran out of lines")
+		}
+		return scanner.Bytes(), nil
+	}
+	got, err := readResponse(next)
+	if err != nil {
+		t.Fatalf("readResponse: %v", err)
+	}
+	if got.ID != 9 || got.Chunk != nil || !bytes.Equal(got.Result, result) {
+		t.Fatalf("reassembled result differs (%d bytes, want %d)", len(got.Result), len(result))
+	}
+
+	// Small results stay plain even on a chunked connection.
+	buf.Reset()
+	encodeResponse(json.NewEncoder(&buf), Response{JSONRPC: "2.0", ID: 1, Result: json.RawMessage(`42`)}, true)
+	if strings.Contains(buf.String(), "chunk") {
+		t.Fatalf("small result chunked: %s", buf.String())
+	}
+}
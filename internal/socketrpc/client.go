@@ -3,83 +3,309 @@ package socketrpc
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 )
 
-// Client implements model.LogQuerier over a Unix domain socket using JSON-RPC 2.0.
+const (
+	// callTimeout is how long a call waits for its response.
+	callTimeout = 30 * time.Second
+	// reconnectMin and reconnectMax bound the wait between attempts to
+	// reconnect; it doubles after each failed one.
+	reconnectMin = 100 * time.Millisecond
+	reconnectMax = 5 * time.Second
+)
+
+// ErrReconnecting wraps the errors of calls made while the client is
+// reconnecting to a server it lost.
+var ErrReconnecting = errors.New("socketrpc: reconnecting")
+
+// Client implements model.LogQuerier over a Unix domain socket, or a TCP
+// connection (see DialTCP), using JSON-RPC 2.0. Calls from several
+// goroutines share the connection: each is sent as soon as it is made and
+// gets its own response, in whatever order the server answers.
+// When the connection is lost, say because the server restarted, the
+// client redials in the background and calls fail with ErrReconnecting
+// until it is back.
 type Client struct {
-	conn    net.Conn
+	dial func() (net.Conn, *bufio.Scanner, error) // opens a ready connection
+
+	writeMu sync.Mutex // serializes requests on conn
+
 	mu      sync.Mutex
-	nextID  int
-	scanner *bufio.Scanner
+	conn    net.Conn
 	encoder *json.Encoder
+	nextID  int
+	pending map[int]chan Response // calls awaiting a response, by request id
+	err     error                 // why calls fail now; nil while connected
+	ready   bool                  // conn finished connect; losing it reconnects
+	closed  bool
+	done    chan struct{} // closed by Close; stops reconnecting
+
+	hello       *Hello          // nil when the server predates Hello
+	unsupported map[string]bool // methods the server answered with method not found
 }
 
 // Dial connects to the socket RPC server at the given path.
 func Dial(socketPath string) (*Client, error) {
-	conn, err := net.DialTimeout("unix", socketPath, 5*time.Second)
+	return newClient(func() (net.Conn, *bufio.Scanner, error) {
+		conn, err := net.DialTimeout("unix", socketPath, 5*time.Second)
+		if err != nil {
+			return nil, nil, fmt.Errorf("socketrpc: dial: %w", err)
+		}
+		return conn, newScanner(conn), nil
+	})
+}
+
+// newClient returns a client on the first connection dial opens, after
+// asking the server what it serves; dial opens the others its live tails
+// use, and the ones that replace a lost connection.
+func newClient(dial func() (net.Conn, *bufio.Scanner, error)) (*Client, error) {
+	c := &Client{
+		dial:    dial,
+		pending: make(map[int]chan Response),
+		done:    make(chan struct{}),
+	}
+	conn, scanner, err := dial()
 	if err != nil {
-		return nil, fmt.Errorf("socketrpc: dial: %w", err)
+		return nil, err
+	}
+	if err := c.connect(conn, scanner); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// connect makes conn the client's connection and asks the server what it
+// serves, forgetting what the previous server did.
+func (c *Client) connect(conn net.Conn, scanner *bufio.Scanner) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		conn.Close()
+		return errors.New("socketrpc: client closed")
+	}
+	c.conn = conn
+	c.encoder = json.NewEncoder(conn)
+	c.err = nil
+	c.ready = false
+	c.hello = nil
+	c.unsupported = make(map[string]bool)
+	c.mu.Unlock()
+	go c.readLoop(conn, scanner)
+
+	var hello Hello
+	err := c.call("Hello", map[string]interface{}{"Features": []string{featureChunked}}, &hello)
+	if err != nil && !isMethodNotFound(err) {
+		conn.Close()
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err // lost already
+	}
+	if err == nil {
+		c.hello = &hello
+	}
+	c.ready = true
+	return nil
+}
+
+// Hello returns what the server reported on connecting, or false when it
+// predates Hello.
+func (c *Client) Hello() (Hello, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.hello == nil {
+		return Hello{}, false
+	}
+	return *c.hello, true
+}
+
+// Supports reports whether the server serves method: whether Hello listed
+// it, or, for a server that predates Hello, whether it has not yet
+// answered it with method not found.
+func (c *Client) Supports(method string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.unsupported[method] {
+		return false
+	}
+	return c.hello == nil || slices.Contains(c.hello.Methods, method)
+}
+
+// Reconnecting reports whether the client lost its connection and is
+// dialing the server again.
+func (c *Client) Reconnecting() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return errors.Is(c.err, ErrReconnecting)
+}
+
+func isMethodNotFound(err error) bool {
+	var rpcErr *RPCError
+	return errors.As(err, &rpcErr) && rpcErr.Code == -32601
+}
+
+// readLoop hands each response on conn to the call waiting for it. When
+// the connection fails it fails the waiting calls, and when it was ready
+// and the client is not closed starts reconnecting.
+func (c *Client) readLoop(conn net.Conn, scanner *bufio.Scanner) {
+	readLine := func() ([]byte, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, fmt.Errorf("socketrpc: read: %w", err)
+			}
+			return nil, fmt.Errorf("socketrpc: connection closed")
+		}
+		return scanner.Bytes(), nil
+	}
+	for {
+		resp, err := readResponse(readLine)
+		c.mu.Lock()
+		if err != nil {
+			conn.Close()
+			if c.conn != conn {
+				c.mu.Unlock()
+				return // replaced already
+			}
+			for id, ch := range c.pending {
+				close(ch)
+				delete(c.pending, id)
+			}
+			restart := c.ready && !c.closed
+			c.ready = false
+			if c.closed {
+				c.err = errors.New("socketrpc: client closed")
+			} else {
+				c.err = fmt.Errorf("%w: %v", ErrReconnecting, err)
+			}
+			c.mu.Unlock()
+			if restart {
+				go c.reconnect()
+			}
+			return
+		}
+		ch, ok := c.pending[resp.ID]
+		delete(c.pending, resp.ID)
+		c.mu.Unlock()
+		if ok {
+			ch <- resp // buffered; a response to a call that gave up is dropped
+		}
+	}
+}
+
+// reconnect dials until a connection is back, waiting longer after each
+// failed attempt, or the client is closed.
+func (c *Client) reconnect() {
+	wait := reconnectMin
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-time.After(wait):
+		}
+		conn, scanner, err := c.dial()
+		if err == nil {
+			if err = c.connect(conn, scanner); err == nil {
+				return
+			}
+		}
+		c.mu.Lock()
+		if !c.closed {
+			c.err = fmt.Errorf("%w: %v", ErrReconnecting, err)
+		}
+		c.mu.Unlock()
+		wait = min(2*wait, reconnectMax)
 	}
+}
+
+func newScanner(conn net.Conn) *bufio.Scanner {
 	scanner := bufio.NewScanner(conn)
 	scanner.Buffer(make([]byte, 0, 1024*1024), 10*1024*1024)
-	return &Client{
-		conn:    conn,
-		scanner: scanner,
-		encoder: json.NewEncoder(conn),
-	}, nil
+	return scanner
 }
 
-// Close closes the underlying connection.
+// Close closes the connection and stops reconnecting.
 func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	close(c.done)
+	if c.conn == nil {
+		return nil
+	}
 	return c.conn.Close()
 }
 
 // call performs a JSON-RPC call and unmarshals the result into dest.
 func (c *Client) call(method string, params interface{}, dest interface{}) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	c.nextID++
-	id := c.nextID
-
 	paramsData, err := json.Marshal(params)
 	if err != nil {
 		return fmt.Errorf("socketrpc: marshal params: %w", err)
 	}
 
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return c.err
+	}
+	c.nextID++
+	id := c.nextID
+	done := make(chan Response, 1)
+	c.pending[id] = done
+	conn, encoder := c.conn, c.encoder
+	c.mu.Unlock()
+
 	req := Request{
 		JSONRPC: "2.0",
 		ID:      id,
 		Method:  method,
 		Params:  paramsData,
 	}
-
-	c.conn.SetDeadline(time.Now().Add(30 * time.Second))
-	defer c.conn.SetDeadline(time.Time{})
-
-	if err := c.encoder.Encode(req); err != nil {
+	c.writeMu.Lock()
+	conn.SetWriteDeadline(time.Now().Add(callTimeout))
+	err = encoder.Encode(req)
+	c.writeMu.Unlock()
+	if err != nil {
+		c.forget(id)
 		return fmt.Errorf("socketrpc: send: %w", err)
 	}
 
-	if !c.scanner.Scan() {
-		if err := c.scanner.Err(); err != nil {
-			return fmt.Errorf("socketrpc: read: %w", err)
-		}
-		return fmt.Errorf("socketrpc: connection closed")
-	}
-
+	timer := time.NewTimer(callTimeout)
+	defer timer.Stop()
 	var resp Response
-	if err := json.Unmarshal(c.scanner.Bytes(), &resp); err != nil {
-		return fmt.Errorf("socketrpc: unmarshal response: %w", err)
+	select {
+	case r, ok := <-done:
+		if !ok {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return c.err
+		}
+		resp = r
+	case <-timer.C:
+		c.forget(id)
+		return fmt.Errorf("socketrpc: %s: no response within %s", method, callTimeout)
 	}
 
 	if resp.Error != nil {
+		if resp.Error.Code == -32601 {
+			c.mu.Lock()
+			c.unsupported[method] = true
+			c.mu.Unlock()
+		}
 		return resp.Error
 	}
 
@@ -91,6 +317,13 @@ func (c *Client) call(method string, params interface{}, dest interface{}) error
 	return nil
 }
 
+// forget stops waiting for the response to request id.
+func (c *Client) forget(id int) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
 func (c *Client) TotalLogCount(opts model.QueryOpts) (int64, error) {
 	var result int64
 	err := c.call("TotalLogCount", map[string]interface{}{"Opts": opts}, &result)
@@ -121,9 +354,15 @@ func (c *Client) TopAttributeKeys(limit int, opts model.QueryOpts) ([]model.Attr
 	return result, err
 }
 
-func (c *Client) AttributeKeyValues(key string, limit int) (map[string]int64, error) {
+func (c *Client) AttributeKeyValues(key string, limit int, opts model.QueryOpts) (map[string]int64, error) {
 	var result map[string]int64
-	err := c.call("AttributeKeyValues", map[string]interface{}{"Key": key, "Limit": limit}, &result)
+	err := c.call("AttributeKeyValues", map[string]interface{}{"Key": key, "Limit": limit, "Opts": opts}, &result)
+	return result, err
+}
+
+func (c *Client) DistinctAttributeValues(key, prefix string, limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
+	var result []model.DimensionCount
+	err := c.call("DistinctAttributeValues", map[string]interface{}{"Key": key, "Prefix": prefix, "Limit": limit, "Opts": opts}, &result)
 	return result, err
 }
 
@@ -157,20 +396,31 @@ func (c *Client) TopServicesBySeverity(severity string, limit int, opts model.Qu
 	return result, err
 }
 
+func (c *Client) RateByDimension(dimension string, window, step time.Duration, severityLevels []string, opts model.QueryOpts) ([]model.DimensionRate, error) {
+	var result []model.DimensionRate
+	err := c.call("RateByDimension", map[string]interface{}{"Dimension": dimension, "Window": window, "Step": step, "SeverityLevels": severityLevels, "Opts": opts}, &result)
+	return result, err
+}
+
 func (c *Client) ListApps() ([]string, error) {
 	var result []string
 	err := c.call("ListApps", map[string]interface{}{}, &result)
 	return result, err
 }
 
-func (c *Client) RecentLogsFiltered(limit int, app string, severityLevels []string, messagePattern string) ([]model.LogRecord, error) {
-	var result []model.LogRecord
-	err := c.call("RecentLogsFiltered", map[string]interface{}{
+func (c *Client) RecentLogsFiltered(limit int, opts model.QueryOpts, severityLevels []string, facets map[string]string, messagePattern string) ([]model.LogRecord, error) {
+	params := map[string]interface{}{
 		"Limit":          limit,
-		"App":            app,
+		"App":            opts.App,
+		"Opts":           opts,
 		"SeverityLevels": severityLevels,
 		"MessagePattern": messagePattern,
-	}, &result)
+	}
+	if len(facets) > 0 {
+		params["Facets"] = facets
+	}
+	var result []model.LogRecord
+	err := c.call("RecentLogsFiltered", params, &result)
 	return result, err
 }
 
@@ -183,3 +433,281 @@ func (c *Client) SearchLogs(term string, limit int, opts model.QueryOpts) ([]mod
 	}, &result)
 	return result, err
 }
+
+// ExecuteQuery runs a read-only SQL query on the server, binding args to
+// its ? placeholders.
+func (c *Client) ExecuteQuery(query string, args ...any) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	err := c.call("ExecuteQuery", map[string]interface{}{
+		"Query":  query,
+		"Params": args,
+	}, &result)
+	return result, err
+}
+
+// GetSchemaDescription returns the server's schema description, or "" when
+// the call fails.
+func (c *Client) GetSchemaDescription() string {
+	var result string
+	if err := c.call("GetSchemaDescription", nil, &result); err != nil {
+		return ""
+	}
+	return result
+}
+
+func (c *Client) TableRowCounts() (map[string]int64, error) {
+	var result map[string]int64
+	err := c.call("TableRowCounts", nil, &result)
+	return result, err
+}
+
+// DashboardSnapshot loads the sections req selects in one roundtrip; the
+// server runs their queries concurrently.
+func (c *Client) DashboardSnapshot(req model.SnapshotRequest) (model.DashboardSnapshot, error) {
+	var result model.DashboardSnapshot
+	err := c.call("DashboardSnapshot", req, &result)
+	return result, err
+}
+
+func (c *Client) TraceLogs(traceID string, limit int) ([]model.LogRecord, error) {
+	var result []model.LogRecord
+	err := c.call("TraceLogs", map[string]interface{}{
+		"TraceID": traceID,
+		"Limit":   limit,
+	}, &result)
+	return result, err
+}
+
+func (c *Client) TraceSpans(traceID string) ([]model.Span, error) {
+	var result []model.Span
+	err := c.call("TraceSpans", map[string]interface{}{
+		"TraceID": traceID,
+	}, &result)
+	return result, err
+}
+
+func (c *Client) IngestRate(window, step time.Duration, opts model.QueryOpts) ([]model.IngestPoint, error) {
+	var result []model.IngestPoint
+	err := c.call("IngestRate", map[string]interface{}{"Window": window, "Step": step, "Opts": opts}, &result)
+	return result, err
+}
+
+func (c *Client) MetricSeries(filter string, limit int, opts model.QueryOpts) ([]model.MetricSeries, error) {
+	var result []model.MetricSeries
+	err := c.call("MetricSeries", map[string]interface{}{"Filter": filter, "Limit": limit, "Opts": opts}, &result)
+	return result, err
+}
+
+func (c *Client) MetricRange(name string, labels map[string]string, step time.Duration, opts model.QueryOpts) ([]model.MetricPoint, error) {
+	var result []model.MetricPoint
+	err := c.call("MetricRange", map[string]interface{}{"Name": name, "Labels": labels, "Step": step, "Opts": opts}, &result)
+	return result, err
+}
+
+func (c *Client) Alerts() ([]model.Alert, error) {
+	var result []model.Alert
+	err := c.call("Alerts", nil, &result)
+	return result, err
+}
+
+func (c *Client) SilenceAlert(rule string, d time.Duration) error {
+	return c.call("SilenceAlert", map[string]interface{}{"Rule": rule, "Duration": d}, nil)
+}
+
+func (c *Client) AcknowledgeAlert(rule string) error {
+	return c.call("AcknowledgeAlert", map[string]interface{}{"Rule": rule}, nil)
+}
+
+func (c *Client) Health() ([]model.ComponentHealth, error) {
+	var result []model.ComponentHealth
+	err := c.call("Health", nil, &result)
+	return result, err
+}
+
+func (c *Client) SavedQueries() ([]model.SavedQuery, error) {
+	var result []model.SavedQuery
+	err := c.call("ListSavedQueries", nil, &result)
+	return result, err
+}
+
+func (c *Client) SavedQuery(id int64) (model.SavedQuery, error) {
+	var result model.SavedQuery
+	err := c.call("GetSavedQuery", map[string]interface{}{
+		"ID": id,
+	}, &result)
+	return result, err
+}
+
+func (c *Client) SaveQuery(q model.SavedQuery) (model.SavedQuery, error) {
+	var result model.SavedQuery
+	err := c.call("SaveQuery", q, &result)
+	return result, err
+}
+
+func (c *Client) DeleteSavedQuery(id int64) error {
+	return c.call("DeleteSavedQuery", map[string]interface{}{
+		"ID": id,
+	}, nil)
+}
+
+// RunSavedQuery runs the saved query with id on the server, binding params
+// to its ? placeholders.
+func (c *Client) RunSavedQuery(id int64, params ...any) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	err := c.call("RunSavedQuery", map[string]interface{}{
+		"ID":     id,
+		"Params": params,
+	}, &result)
+	return result, err
+}
+
+// TailLogs subscribes to the records matching filter as they are ingested.
+// The subscription takes a connection of its own, since Subscribe puts its
+// connection into push mode; closing the tail closes it. Records the
+// caller is too slow for are dropped by the server and counted in Dropped.
+func (c *Client) TailLogs(filter model.TailFilter, buffer int) (model.LogTail, error) {
+	conn, scanner, err := c.subscribe("Subscribe", filter)
+	if err != nil {
+		return nil, err
+	}
+	if buffer <= 0 {
+		buffer = tailBatchSize
+	}
+	t := &clientTail{conn: conn, records: make(chan model.LogRecord, buffer), done: make(chan struct{})}
+	go t.read(scanner)
+	return t, nil
+}
+
+// subscribe dials a connection of its own and sends method, which switches
+// it into push mode. It returns the connection once the server accepts.
+func (c *Client) subscribe(method string, params interface{}) (net.Conn, *bufio.Scanner, error) {
+	conn, scanner, err := c.dial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("socketrpc: marshal params: %w", err)
+	}
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	if err := json.NewEncoder(conn).Encode(Request{JSONRPC: "2.0", ID: 1, Method: method, Params: raw}); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("socketrpc: send: %w", err)
+	}
+	if !scanner.Scan() {
+		conn.Close()
+		return nil, nil, fmt.Errorf("socketrpc: connection closed")
+	}
+	var resp Response
+	if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("socketrpc: unmarshal response: %w", err)
+	}
+	if resp.Error != nil {
+		conn.Close()
+		if resp.Error.Code == -32601 {
+			c.mu.Lock()
+			c.unsupported[method] = true
+			c.mu.Unlock()
+		}
+		return nil, nil, resp.Error
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, scanner, nil
+}
+
+// clientTail is a model.LogTail read from a Subscribe connection.
+type clientTail struct {
+	conn      net.Conn
+	records   chan model.LogRecord
+	dropped   atomic.Int64
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// read delivers the records of each Logs notification until the
+// connection ends, then closes the records channel.
+func (t *clientTail) read(scanner *bufio.Scanner) {
+	defer close(t.records)
+	for scanner.Scan() {
+		var n Notification
+		if err := json.Unmarshal(scanner.Bytes(), &n); err != nil || n.Method != "Logs" {
+			continue
+		}
+		var batch TailBatch
+		if err := json.Unmarshal(n.Params, &batch); err != nil {
+			continue
+		}
+		t.dropped.Add(batch.Dropped)
+		for _, r := range batch.Logs {
+			select {
+			case t.records <- r:
+			case <-t.done:
+				return
+			}
+		}
+	}
+}
+
+func (t *clientTail) Records() <-chan model.LogRecord { return t.records }
+
+func (t *clientTail) Dropped() int64 { return t.dropped.Swap(0) }
+
+func (t *clientTail) Close() {
+	t.closeOnce.Do(func() {
+		close(t.done)
+		t.conn.Close()
+	})
+}
+
+// SubscribeEvents opens a connection of its own that receives the server's
+// events (see model.ServerEvent) until Close. While buffer events are
+// waiting for the caller, newer ones are dropped.
+func (c *Client) SubscribeEvents(buffer int) (model.EventSubscription, error) {
+	conn, scanner, err := c.subscribe("SubscribeEvents", nil)
+	if err != nil {
+		return nil, err
+	}
+	if buffer <= 0 {
+		buffer = eventBuffer
+	}
+	sub := &clientEvents{conn: conn, events: make(chan model.ServerEvent, buffer)}
+	go sub.read(scanner)
+	return sub, nil
+}
+
+// clientEvents is a model.EventSubscription read from a SubscribeEvents
+// connection.
+type clientEvents struct {
+	conn      net.Conn
+	events    chan model.ServerEvent
+	closeOnce sync.Once
+}
+
+// read delivers each Event notification until the connection ends, then
+// closes the events channel.
+func (e *clientEvents) read(scanner *bufio.Scanner) {
+	defer close(e.events)
+	for scanner.Scan() {
+		var n Notification
+		if err := json.Unmarshal(scanner.Bytes(), &n); err != nil || n.Method != "Event" {
+			continue
+		}
+		var ev model.ServerEvent
+		if err := json.Unmarshal(n.Params, &ev); err != nil {
+			continue
+		}
+		select {
+		case e.events <- ev:
+		default:
+		}
+	}
+}
+
+func (e *clientEvents) Events() <-chan model.ServerEvent { return e.events }
+
+func (e *clientEvents) Close() {
+	e.closeOnce.Do(func() { e.conn.Close() })
+}
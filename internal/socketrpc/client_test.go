@@ -1,10 +1,18 @@
 package socketrpc_test
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/tinytelemetry/tiny-telemetry/internal/events"
+	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 	"github.com/tinytelemetry/tiny-telemetry/internal/socketrpc"
 )
@@ -29,9 +37,12 @@ func (m *mockQuerier) TopAttributes(limit int, opts model.QueryOpts) ([]model.At
 func (m *mockQuerier) TopAttributeKeys(limit int, opts model.QueryOpts) ([]model.AttributeKeyStat, error) {
 	return []model.AttributeKeyStat{{Key: "env", UniqueValues: 2, TotalCount: 10}}, nil
 }
-func (m *mockQuerier) AttributeKeyValues(key string, limit int) (map[string]int64, error) {
+func (m *mockQuerier) AttributeKeyValues(key string, limit int, opts model.QueryOpts) (map[string]int64, error) {
 	return map[string]int64{"prod": 5, "dev": 3}, nil
 }
+func (m *mockQuerier) DistinctAttributeValues(key, prefix string, limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
+	return []model.DimensionCount{{Value: prefix + "rod", Count: 5}}, nil
+}
 func (m *mockQuerier) TopHosts(limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
 	return []model.DimensionCount{{Value: "host1", Count: 20}}, nil
 }
@@ -41,10 +52,14 @@ func (m *mockQuerier) TopServices(limit int, opts model.QueryOpts) ([]model.Dime
 func (m *mockQuerier) TopServicesBySeverity(severity string, limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
 	return []model.DimensionCount{{Value: "api", Count: 8}}, nil
 }
+func (m *mockQuerier) RateByDimension(dimension string, window, step time.Duration, severityLevels []string, opts model.QueryOpts) ([]model.DimensionRate, error) {
+	// Echo the arguments so the roundtrip can check their encoding.
+	return []model.DimensionRate{{Bucket: time.Unix(0, 0).UTC().Add(window), Value: dimension + "/" + step.String(), Count: int64(len(severityLevels))}}, nil
+}
 func (m *mockQuerier) ListApps() ([]string, error) {
 	return []string{"app1", "app2"}, nil
 }
-func (m *mockQuerier) RecentLogsFiltered(limit int, app string, severityLevels []string, messagePattern string) ([]model.LogRecord, error) {
+func (m *mockQuerier) RecentLogsFiltered(limit int, opts model.QueryOpts, severityLevels []string, _ map[string]string, messagePattern string) ([]model.LogRecord, error) {
 	return []model.LogRecord{{
 		Timestamp:  time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
 		Level:      "INFO",
@@ -56,10 +71,15 @@ func (m *mockQuerier) RecentLogsFiltered(limit int, app string, severityLevels [
 		App:        "app1",
 	}}, nil
 }
-func (m *mockQuerier) ExecuteQuery(query string) ([]map[string]interface{}, error) {
-	return []map[string]interface{}{{"ok": true}}, nil
+func (m *mockQuerier) SearchLogs(term string, limit int, opts model.QueryOpts) ([]model.LogRecord, error) {
+	return []model.LogRecord{{Level: "INFO", Message: term, App: "app1"}}, nil
+}
+func (m *mockQuerier) ExecuteQuery(query string, args ...any) ([]map[string]interface{}, error) {
+	// Echo the arguments so the roundtrip can check their binding.
+	return []map[string]interface{}{{"query": query, "args": args}}, nil
 }
-func (m *mockQuerier) GetSchemaDescription() string { return "schema" }
+func (m *mockQuerier) EstimateQueryScanRows(query string, args ...any) (int64, error) { return 1, nil }
+func (m *mockQuerier) GetSchemaDescription() string                                   { return "schema" }
 func (m *mockQuerier) TableRowCounts() (map[string]int64, error) {
 	return map[string]int64{"logs": 1}, nil
 }
@@ -147,7 +167,7 @@ func TestRoundtrip(t *testing.T) {
 	})
 
 	t.Run("AttributeKeyValues", func(t *testing.T) {
-		vals, err := client.AttributeKeyValues("env", 10)
+		vals, err := client.AttributeKeyValues("env", 10, opts)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -196,6 +216,27 @@ func TestRoundtrip(t *testing.T) {
 		}
 	})
 
+	t.Run("DistinctAttributeValues", func(t *testing.T) {
+		values, err := client.DistinctAttributeValues("env", "p", 5, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(values) != 1 || values[0].Value != "prod" {
+			t.Fatalf("unexpected values: %v", values)
+		}
+	})
+
+	t.Run("RateByDimension", func(t *testing.T) {
+		rates, err := client.RateByDimension("service", time.Hour, 5*time.Minute, []string{"ERROR"}, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := model.DimensionRate{Bucket: time.Unix(0, 0).UTC().Add(time.Hour), Value: "service/5m0s", Count: 1}
+		if len(rates) != 1 || rates[0] != want {
+			t.Fatalf("unexpected rates: %v", rates)
+		}
+	})
+
 	t.Run("ListApps", func(t *testing.T) {
 		apps, err := client.ListApps()
 		if err != nil {
@@ -207,7 +248,7 @@ func TestRoundtrip(t *testing.T) {
 	})
 
 	t.Run("RecentLogsFiltered", func(t *testing.T) {
-		logs, err := client.RecentLogsFiltered(100, "", nil, "")
+		logs, err := client.RecentLogsFiltered(100, model.QueryOpts{}, nil, nil, "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -215,6 +256,47 @@ func TestRoundtrip(t *testing.T) {
 			t.Fatalf("unexpected logs: %v", logs)
 		}
 	})
+
+	t.Run("ExecuteQuery", func(t *testing.T) {
+		rows, err := client.ExecuteQuery("SELECT * FROM logs WHERE app = ? LIMIT ?", "shop", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 1 || rows[0]["query"] != "SELECT * FROM logs WHERE app = ? LIMIT ?" || fmt.Sprint(rows[0]["args"]) != "[shop 10]" {
+			t.Fatalf("unexpected rows: %v", rows)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		if got := client.GetSchemaDescription(); got != "schema" {
+			t.Fatalf("GetSchemaDescription = %q", got)
+		}
+		counts, err := client.TableRowCounts()
+		if err != nil || counts["logs"] != 1 {
+			t.Fatalf("TableRowCounts = %v, %v", counts, err)
+		}
+	})
+
+	t.Run("DashboardSnapshot", func(t *testing.T) {
+		snap, err := client.DashboardSnapshot(model.SnapshotRequest{LogLimit: 100})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if snap.TotalCount != 42 || snap.SeverityCounts["ERROR"] != 2 || len(snap.Apps) != 2 || len(snap.RecentLogs) != 1 {
+			t.Fatalf("unexpected snapshot: %+v", snap)
+		}
+		if snap.MinuteCounts != nil || snap.TopServices != nil || snap.TopHosts != nil {
+			t.Fatalf("snapshot holds sections it was not asked for: %+v", snap)
+		}
+
+		snap, err = client.DashboardSnapshot(model.SnapshotRequest{TopLimit: 5, Minutes: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(snap.MinuteCounts) != 1 || snap.TopServices[0].Value != "api" || snap.TopHosts[0].Value != "host1" || snap.RecentLogs != nil {
+			t.Fatalf("unexpected snapshot: %+v", snap)
+		}
+	})
 }
 
 func TestMethodNotFound(t *testing.T) {
@@ -292,3 +374,286 @@ func TestStopClosesConns(t *testing.T) {
 		t.Fatal("client call hung after server stop")
 	}
 }
+
+func TestTailLogs(t *testing.T) {
+	sockPath, srv := startTestServer(t)
+	client, err := socketrpc.Dial(sockPath)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	var rpcErr *socketrpc.RPCError
+	if _, err := client.TailLogs(model.TailFilter{}, 0); !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Fatalf("TailLogs without a tailer error = %v, want method not found", err)
+	}
+	client.Close()
+	srv.Stop()
+
+	srv = socketrpc.NewServer(sockPath, &mockQuerier{})
+	sink := ingest.NewTailSink(nil)
+	srv.SetLogTailer(sink)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("restart: %v", err)
+	}
+	defer srv.Stop()
+	client, err = socketrpc.Dial(sockPath)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.TailLogs(model.TailFilter{MessagePattern: "("}, 0); !errors.As(err, &rpcErr) || rpcErr.Code != -32602 {
+		t.Fatalf("TailLogs with a bad pattern error = %v, want invalid params", err)
+	}
+	tail, err := client.TailLogs(model.TailFilter{SeverityLevels: []string{"ERROR"}}, 0)
+	if err != nil {
+		t.Fatalf("TailLogs: %v", err)
+	}
+	// The client's request connection keeps working beside the tail.
+	if _, err := client.ListApps(); err != nil {
+		t.Fatalf("ListApps beside a tail: %v", err)
+	}
+
+	waitFor(t, func() bool { return sink.Tails() == 1 })
+	sink.Add(&model.LogRecord{Level: "INFO", Message: "skipped"})
+	sink.Add(&model.LogRecord{Level: "ERROR", Message: "boom"})
+	select {
+	case r := <-tail.Records():
+		if r.Message != "boom" {
+			t.Fatalf("tailed %q, want boom", r.Message)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no record pushed")
+	}
+
+	tail.Close()
+	waitFor(t, func() bool { return sink.Tails() == 0 })
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "events.sock")
+	srv := socketrpc.NewServer(sockPath, &mockQuerier{})
+	hub := events.NewHub()
+	srv.SetEventSource(hub)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	defer srv.Stop()
+	client, err := socketrpc.Dial(sockPath)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+	if !client.Supports("SubscribeEvents") {
+		t.Fatal("SubscribeEvents not listed by Hello")
+	}
+
+	sub, err := client.SubscribeEvents(0)
+	if err != nil {
+		t.Fatalf("SubscribeEvents: %v", err)
+	}
+	waitFor(t, func() bool { return hub.Subscribers() == 1 })
+	hub.Publish(model.ServerEvent{Kind: model.EventAppSeen, Message: "new app shop", App: "shop"})
+	select {
+	case ev := <-sub.Events():
+		if ev.Kind != model.EventAppSeen || ev.App != "shop" || ev.Time.IsZero() {
+			t.Fatalf("event = %+v", ev)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event pushed")
+	}
+
+	sub.Close()
+	waitFor(t, func() bool { return hub.Subscribers() == 0 })
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 2s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestClientReconnects(t *testing.T) {
+	sockPath, srv := startTestServer(t)
+	client, err := socketrpc.Dial(sockPath)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	srv.Stop()
+	waitFor(t, client.Reconnecting)
+	if _, err := client.ListApps(); !errors.Is(err, socketrpc.ErrReconnecting) {
+		t.Fatalf("ListApps while the server is down error = %v, want ErrReconnecting", err)
+	}
+
+	srv = socketrpc.NewServer(sockPath, &mockQuerier{})
+	srv.SetLogTailer(ingest.NewTailSink(nil))
+	if err := srv.Start(); err != nil {
+		t.Fatalf("restart: %v", err)
+	}
+	defer srv.Stop()
+	waitFor(t, func() bool { return !client.Reconnecting() })
+	if apps, err := client.ListApps(); err != nil || len(apps) != 2 {
+		t.Fatalf("ListApps after the restart = %v, %v", apps, err)
+	}
+	// What the new server serves replaces what the old one did.
+	if !client.Supports("Subscribe") {
+		t.Fatal("Supports kept the old server's methods")
+	}
+}
+
+func TestClientHello(t *testing.T) {
+	sockPath, srv := startTestServer(t)
+	defer srv.Stop()
+	client, err := socketrpc.Dial(sockPath)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	hello, ok := client.Hello()
+	if !ok || hello.Protocol != socketrpc.ProtocolVersion {
+		t.Fatalf("Hello = %+v, %v", hello, ok)
+	}
+	if !client.Supports("DashboardSnapshot") || client.Supports("Subscribe") || client.Supports("TraceLogs") {
+		t.Fatal("Supports disagrees with the server's methods")
+	}
+}
+
+func TestClientHello_OlderServer(t *testing.T) {
+	// A server from before Hello answers every method it lacks with
+	// method not found; this one lacks them all.
+	sockPath := filepath.Join(t.TempDir(), "old.sock")
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			var req socketrpc.Request
+			json.Unmarshal(scanner.Bytes(), &req)
+			json.NewEncoder(conn).Encode(socketrpc.Response{JSONRPC: "2.0", ID: req.ID, Error: &socketrpc.RPCError{Code: -32601, Message: "method not found"}})
+		}
+	}()
+
+	client, err := socketrpc.Dial(sockPath)
+	if err != nil {
+		t.Fatalf("dial an older server: %v", err)
+	}
+	defer client.Close()
+	if _, ok := client.Hello(); ok {
+		t.Fatal("Hello reported by a server without it")
+	}
+	if !client.Supports("DashboardSnapshot") {
+		t.Fatal("an untried method is unsupported")
+	}
+	if _, err := client.DashboardSnapshot(model.SnapshotRequest{}); err == nil {
+		t.Fatal("DashboardSnapshot succeeded on a server without it")
+	}
+	if client.Supports("DashboardSnapshot") {
+		t.Fatal("a method answered with method not found is still supported")
+	}
+}
+
+// slowQuerier holds TopWords until release is closed.
+type slowQuerier struct {
+	mockQuerier
+	release chan struct{}
+}
+
+func (q *slowQuerier) TopWords(limit int, opts model.QueryOpts) ([]model.WordCount, error) {
+	<-q.release
+	return q.mockQuerier.TopWords(limit, opts)
+}
+
+func TestPipelinedCalls(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "pipeline.sock")
+	q := &slowQuerier{release: make(chan struct{})}
+	srv := socketrpc.NewServer(sockPath, q)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	defer srv.Stop()
+	client, err := socketrpc.Dial(sockPath)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	slow := make(chan error, 1)
+	go func() {
+		_, err := client.TopWords(5, model.QueryOpts{})
+		slow <- err
+	}()
+
+	// Calls behind the slow one on the same connection are answered first.
+	for range 3 {
+		if apps, err := client.ListApps(); err != nil || len(apps) != 2 {
+			t.Fatalf("ListApps beside a slow call = %v, %v", apps, err)
+		}
+	}
+	select {
+	case err := <-slow:
+		t.Fatalf("slow call returned early: %v", err)
+	default:
+	}
+
+	close(q.release)
+	select {
+	case err := <-slow:
+		if err != nil {
+			t.Fatalf("TopWords: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("slow call never answered")
+	}
+}
+
+// bigQuerier returns more recent logs than fit in one 10 MB line.
+type bigQuerier struct{ mockQuerier }
+
+func (q *bigQuerier) RecentLogsFiltered(limit int, opts model.QueryOpts, severityLevels []string, _ map[string]string, messagePattern string) ([]model.LogRecord, error) {
+	records := make([]model.LogRecord, limit)
+	for i := range records {
+		records[i] = model.LogRecord{Level: "INFO", Message: fmt.Sprintf("%06d %s", i, strings.Repeat("payload ", 60))}
+	}
+	return records, nil
+}
+
+func TestLargeResult(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "big.sock")
+	srv := socketrpc.NewServer(sockPath, &bigQuerier{})
+	if err := srv.Start(); err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	defer srv.Stop()
+	client, err := socketrpc.Dial(sockPath)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	logs, err := client.RecentLogsFiltered(25000, model.QueryOpts{}, nil, nil, "")
+	if err != nil {
+		t.Fatalf("RecentLogsFiltered of over 10 MB: %v", err)
+	}
+	if len(logs) != 25000 || !strings.HasPrefix(logs[24999].Message, "024999 ") {
+		t.Fatalf("got %d logs", len(logs))
+	}
+	// The connection goes on serving requests after a chunked result.
+	if n, err := client.TotalLogCount(model.QueryOpts{}); err != nil || n != 42 {
+		t.Fatalf("TotalLogCount after a chunked result = %d, %v", n, err)
+	}
+}
@@ -2,9 +2,13 @@ package socketrpc
 
 import (
 	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/tinytelemetry/tiny-telemetry/internal/events"
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 )
 
@@ -22,7 +26,7 @@ func (q *stubQuerier) TopAttributes(limit int, opts model.QueryOpts) ([]model.At
 func (q *stubQuerier) TopAttributeKeys(limit int, opts model.QueryOpts) ([]model.AttributeKeyStat, error) {
 	return []model.AttributeKeyStat{{Key: "env", UniqueValues: 2, TotalCount: 10}}, nil
 }
-func (q *stubQuerier) AttributeKeyValues(key string, limit int) (map[string]int64, error) {
+func (q *stubQuerier) AttributeKeyValues(key string, limit int, opts model.QueryOpts) (map[string]int64, error) {
 	return map[string]int64{"prod": 7}, nil
 }
 func (q *stubQuerier) SeverityCounts(opts model.QueryOpts) (map[string]int64, error) {
@@ -31,6 +35,9 @@ func (q *stubQuerier) SeverityCounts(opts model.QueryOpts) (map[string]int64, er
 func (q *stubQuerier) SeverityCountsByMinute(opts model.QueryOpts) ([]model.MinuteCounts, error) {
 	return []model.MinuteCounts{{Minute: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Info: 5, Total: 5}}, nil
 }
+func (q *stubQuerier) DistinctAttributeValues(key, prefix string, limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
+	return []model.DimensionCount{{Value: "prod", Count: 5}}, nil
+}
 func (q *stubQuerier) TopHosts(limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
 	return []model.DimensionCount{{Value: "host1", Count: 20}}, nil
 }
@@ -40,8 +47,11 @@ func (q *stubQuerier) TopServices(limit int, opts model.QueryOpts) ([]model.Dime
 func (q *stubQuerier) TopServicesBySeverity(severity string, limit int, opts model.QueryOpts) ([]model.DimensionCount, error) {
 	return []model.DimensionCount{{Value: "api", Count: 8}}, nil
 }
+func (q *stubQuerier) RateByDimension(dimension string, window, step time.Duration, severityLevels []string, opts model.QueryOpts) ([]model.DimensionRate, error) {
+	return []model.DimensionRate{{Bucket: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Value: "api", Count: 3}}, nil
+}
 func (q *stubQuerier) ListApps() ([]string, error) { return []string{"default"}, nil }
-func (q *stubQuerier) RecentLogsFiltered(limit int, app string, severityLevels []string, messagePattern string) ([]model.LogRecord, error) {
+func (q *stubQuerier) RecentLogsFiltered(limit int, opts model.QueryOpts, severityLevels []string, _ map[string]string, messagePattern string) ([]model.LogRecord, error) {
 	return []model.LogRecord{{
 		Timestamp: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
 		Level:     "INFO",
@@ -49,10 +59,14 @@ func (q *stubQuerier) RecentLogsFiltered(limit int, app string, severityLevels [
 		App:       "default",
 	}}, nil
 }
-func (q *stubQuerier) ExecuteQuery(query string) ([]map[string]interface{}, error) {
+func (q *stubQuerier) SearchLogs(term string, limit int, opts model.QueryOpts) ([]model.LogRecord, error) {
+	return []model.LogRecord{{Level: "INFO", Message: term, App: "default"}}, nil
+}
+func (q *stubQuerier) ExecuteQuery(query string, args ...any) ([]map[string]interface{}, error) {
 	return []map[string]interface{}{{"ok": true}}, nil
 }
-func (q *stubQuerier) GetSchemaDescription() string { return "schema" }
+func (q *stubQuerier) EstimateQueryScanRows(query string, args ...any) (int64, error) { return 1, nil }
+func (q *stubQuerier) GetSchemaDescription() string                                   { return "schema" }
 func (q *stubQuerier) TableRowCounts() (map[string]int64, error) {
 	return map[string]int64{"logs": 1}, nil
 }
@@ -75,6 +89,7 @@ func TestDispatch_AllMethods(t *testing.T) {
 		{"TopAttributes", `{"Limit":10,"Opts":{}}`},
 		{"TopAttributeKeys", `{"Limit":10,"Opts":{}}`},
 		{"AttributeKeyValues", `{"Key":"env","Limit":10}`},
+		{"DistinctAttributeValues", `{"Key":"env","Prefix":"p","Limit":10}`},
 		{"SeverityCounts", `{"Opts":{}}`},
 		{"SeverityCountsByMinute", `{"Opts":{}}`},
 		{"TopHosts", `{"Limit":10,"Opts":{}}`},
@@ -82,6 +97,12 @@ func TestDispatch_AllMethods(t *testing.T) {
 		{"TopServicesBySeverity", `{"Severity":"ERROR","Limit":10,"Opts":{}}`},
 		{"ListApps", `{}`},
 		{"RecentLogsFiltered", `{"Limit":100}`},
+		{"SearchLogs", `{"Term":"x","Limit":10}`},
+		{"RateByDimension", `{"Dimension":"service","Window":3600000000000,"Step":60000000000}`},
+		{"ExecuteQuery", `{"Query":"SELECT ?","Params":[1]}`},
+		{"GetSchemaDescription", ``},
+		{"TableRowCounts", ``},
+		{"DashboardSnapshot", `{"Opts":{},"TopLimit":5,"Minutes":true,"LogLimit":10}`},
 	}
 
 	for _, tt := range tests {
@@ -145,6 +166,12 @@ func TestDispatch_InvalidParams(t *testing.T) {
 	if resp.Error.Code != -32602 {
 		t.Errorf("error code = %d, want -32602 (invalid params)", resp.Error.Code)
 	}
+
+	// Query params must be scalars.
+	resp = srv.dispatch(Request{JSONRPC: "2.0", ID: 3, Method: "ExecuteQuery", Params: json.RawMessage(`{"Query":"SELECT ?","Params":[{"a":1}]}`)})
+	if resp.Error == nil || resp.Error.Code != -32602 {
+		t.Errorf("ExecuteQuery with an object param = %+v, want invalid params", resp)
+	}
 }
 
 func TestDispatch_EmptyParamsOnOptionalMethods(t *testing.T) {
@@ -187,3 +214,324 @@ func TestDispatch_PreservesRequestID(t *testing.T) {
 		}
 	}
 }
+
+// stubTraces records the trace id it was asked for.
+type stubTraces struct{ traceID string }
+
+func (q *stubTraces) TraceLogs(traceID string, limit int) ([]model.LogRecord, error) {
+	q.traceID = traceID
+	return []model.LogRecord{{Level: "ERROR", Message: "timeout", App: "default"}}, nil
+}
+func (q *stubTraces) TraceSpans(traceID string) ([]model.Span, error) {
+	q.traceID = traceID
+	return []model.Span{{TraceID: traceID, SpanID: "01", Name: "checkout"}}, nil
+}
+
+func TestDispatch_TraceMethods(t *testing.T) {
+	t.Parallel()
+	srv := newTestDispatcher()
+
+	req := Request{JSONRPC: "2.0", ID: 1, Method: "TraceSpans", Params: json.RawMessage(`{"TraceID":"abc"}`)}
+	if resp := srv.dispatch(req); resp.Error == nil || resp.Error.Code != -32601 {
+		t.Fatalf("TraceSpans without a trace querier = %+v, want method not found", resp)
+	}
+
+	traces := &stubTraces{}
+	srv.SetTraceQuerier(traces)
+	resp := srv.dispatch(req)
+	if resp.Error != nil {
+		t.Fatalf("TraceSpans: %s", resp.Error.Message)
+	}
+	var spans []model.Span
+	if err := json.Unmarshal(resp.Result, &spans); err != nil || len(spans) != 1 || spans[0].Name != "checkout" {
+		t.Fatalf("TraceSpans result = %s, %v", resp.Result, err)
+	}
+
+	resp = srv.dispatch(Request{JSONRPC: "2.0", ID: 2, Method: "TraceLogs", Params: json.RawMessage(`{"TraceID":"def","Limit":50}`)})
+	if resp.Error != nil || traces.traceID != "def" {
+		t.Fatalf("TraceLogs = %+v, trace id %q", resp, traces.traceID)
+	}
+}
+
+// stubMetrics serves IngestRate and the metric methods, recording what it
+// was asked for.
+type stubMetrics struct {
+	step   time.Duration
+	labels map[string]string
+}
+
+func (q *stubMetrics) IngestRate(window, step time.Duration, opts model.QueryOpts) ([]model.IngestPoint, error) {
+	q.step = step
+	return []model.IngestPoint{{Bucket: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Count: 3, Bytes: 120}}, nil
+}
+func (q *stubMetrics) MetricSeries(filter string, limit int, opts model.QueryOpts) ([]model.MetricSeries, error) {
+	return []model.MetricSeries{{Name: "cpu_usage", Kind: "gauge", LastValue: 0.5}}, nil
+}
+func (q *stubMetrics) MetricRange(name string, labels map[string]string, step time.Duration, opts model.QueryOpts) ([]model.MetricPoint, error) {
+	q.step, q.labels = step, labels
+	return []model.MetricPoint{{Avg: 0.5, Samples: 2}}, nil
+}
+
+func TestDispatch_MetricMethods(t *testing.T) {
+	t.Parallel()
+	srv := newTestDispatcher()
+
+	ingest := Request{JSONRPC: "2.0", ID: 1, Method: "IngestRate", Params: json.RawMessage(`{"Window":3600000000000,"Step":60000000000}`)}
+	series := Request{JSONRPC: "2.0", ID: 2, Method: "MetricSeries", Params: json.RawMessage(`{"Limit":10}`)}
+	for _, req := range []Request{ingest, series} {
+		if resp := srv.dispatch(req); resp.Error == nil || resp.Error.Code != -32601 {
+			t.Fatalf("%s without a store for it = %+v, want method not found", req.Method, resp)
+		}
+	}
+
+	stub := &stubMetrics{}
+	srv.SetIngestRater(stub)
+	srv.SetMetricQuerier(stub)
+	resp := srv.dispatch(ingest)
+	var points []model.IngestPoint
+	if resp.Error != nil || json.Unmarshal(resp.Result, &points) != nil || len(points) != 1 || points[0].Bytes != 120 || stub.step != time.Minute {
+		t.Fatalf("IngestRate = %+v, step %s", resp, stub.step)
+	}
+	resp = srv.dispatch(series)
+	var got []model.MetricSeries
+	if resp.Error != nil || json.Unmarshal(resp.Result, &got) != nil || len(got) != 1 || got[0].Name != "cpu_usage" {
+		t.Fatalf("MetricSeries = %+v", resp)
+	}
+	resp = srv.dispatch(Request{JSONRPC: "2.0", ID: 3, Method: "MetricRange", Params: json.RawMessage(`{"Name":"cpu_usage","Labels":{"host":"a"},"Step":1000000000}`)})
+	if resp.Error != nil || stub.step != time.Second || stub.labels["host"] != "a" {
+		t.Fatalf("MetricRange = %+v, step %s, labels %v", resp, stub.step, stub.labels)
+	}
+}
+
+// stubAlerts serves the alert methods, recording what it was asked to do.
+type stubAlerts struct {
+	silenced map[string]time.Duration
+	acked    []string
+}
+
+func (a *stubAlerts) Alerts() ([]model.Alert, error) {
+	return []model.Alert{{Rule: "high_error_rate", State: model.AlertFiring, History: []float64{1, 4}}}, nil
+}
+func (a *stubAlerts) SilenceAlert(rule string, d time.Duration) error {
+	if rule != "high_error_rate" {
+		return model.ErrUnknownAlert
+	}
+	a.silenced[rule] = d
+	return nil
+}
+func (a *stubAlerts) AcknowledgeAlert(rule string) error {
+	a.acked = append(a.acked, rule)
+	return nil
+}
+
+func TestDispatch_AlertMethods(t *testing.T) {
+	t.Parallel()
+	srv := newTestDispatcher()
+
+	list := Request{JSONRPC: "2.0", ID: 1, Method: "Alerts"}
+	if resp := srv.dispatch(list); resp.Error == nil || resp.Error.Code != -32601 {
+		t.Fatalf("Alerts without an alert engine = %+v, want method not found", resp)
+	}
+
+	stub := &stubAlerts{silenced: map[string]time.Duration{}}
+	srv.SetAlertManager(stub)
+	resp := srv.dispatch(list)
+	var alerts []model.Alert
+	if resp.Error != nil || json.Unmarshal(resp.Result, &alerts) != nil || len(alerts) != 1 || alerts[0].State != model.AlertFiring || len(alerts[0].History) != 2 {
+		t.Fatalf("Alerts = %+v", resp)
+	}
+	resp = srv.dispatch(Request{JSONRPC: "2.0", ID: 2, Method: "SilenceAlert", Params: json.RawMessage(`{"Rule":"high_error_rate","Duration":3600000000000}`)})
+	if resp.Error != nil || stub.silenced["high_error_rate"] != time.Hour {
+		t.Fatalf("SilenceAlert = %+v, silenced %v", resp, stub.silenced)
+	}
+	resp = srv.dispatch(Request{JSONRPC: "2.0", ID: 3, Method: "SilenceAlert", Params: json.RawMessage(`{"Rule":"nope","Duration":1}`)})
+	if resp.Error == nil || resp.Error.Code != -32000 {
+		t.Fatalf("SilenceAlert of an unknown rule = %+v, want an application error", resp)
+	}
+	resp = srv.dispatch(Request{JSONRPC: "2.0", ID: 4, Method: "AcknowledgeAlert", Params: json.RawMessage(`{"Rule":"high_error_rate"}`)})
+	if resp.Error != nil || len(stub.acked) != 1 {
+		t.Fatalf("AcknowledgeAlert = %+v, acked %v", resp, stub.acked)
+	}
+}
+
+type stubHealth []model.ComponentHealth
+
+func (h stubHealth) Health() ([]model.ComponentHealth, error) { return h, nil }
+
+func TestDispatch_Health(t *testing.T) {
+	t.Parallel()
+	srv := newTestDispatcher()
+
+	req := Request{JSONRPC: "2.0", ID: 1, Method: "Health"}
+	if resp := srv.dispatch(req); resp.Error == nil || resp.Error.Code != -32601 {
+		t.Fatalf("Health without component checks = %+v, want method not found", resp)
+	}
+
+	failedAt := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	srv.SetHealthReporter(stubHealth{{Name: "journal", Status: model.HealthOK, Required: true, LastErrorAt: failedAt, Restarts: 2}})
+	resp := srv.dispatch(req)
+	var got []model.ComponentHealth
+	if resp.Error != nil || json.Unmarshal(resp.Result, &got) != nil || len(got) != 1 || got[0].Restarts != 2 || !got[0].LastErrorAt.Equal(failedAt) {
+		t.Fatalf("Health = %+v", resp)
+	}
+}
+
+type stubSaved struct{ deleted int64 }
+
+func (q *stubSaved) SaveQuery(sq model.SavedQuery) (model.SavedQuery, error) {
+	sq.ID = 7
+	return sq, nil
+}
+func (q *stubSaved) SavedQueries() ([]model.SavedQuery, error) {
+	return []model.SavedQuery{{ID: 7, Name: "errors", SQL: "SELECT ?"}}, nil
+}
+func (q *stubSaved) SavedQuery(id int64) (model.SavedQuery, error) {
+	if id != 7 {
+		return model.SavedQuery{}, model.ErrSavedQueryNotFound
+	}
+	return model.SavedQuery{ID: 7, Name: "errors", SQL: "SELECT ?"}, nil
+}
+func (q *stubSaved) DeleteSavedQuery(id int64) error {
+	q.deleted = id
+	return nil
+}
+
+// argsQuerier records the query and args ExecuteQuery is called with.
+type argsQuerier struct {
+	stubQuerier
+	query string
+	args  []any
+}
+
+func (q *argsQuerier) ExecuteQuery(query string, args ...any) ([]map[string]interface{}, error) {
+	q.query, q.args = query, args
+	return q.stubQuerier.ExecuteQuery(query, args...)
+}
+
+func TestDispatch_SavedQueryMethods(t *testing.T) {
+	t.Parallel()
+	store := &argsQuerier{}
+	srv := &Server{store: store}
+
+	list := Request{JSONRPC: "2.0", ID: 1, Method: "ListSavedQueries"}
+	if resp := srv.dispatch(list); resp.Error == nil || resp.Error.Code != -32601 {
+		t.Fatalf("ListSavedQueries without a saved query store = %+v, want method not found", resp)
+	}
+
+	saved := &stubSaved{}
+	srv.SetSavedQueries(saved)
+	resp := srv.dispatch(list)
+	var queries []model.SavedQuery
+	if resp.Error != nil || json.Unmarshal(resp.Result, &queries) != nil || len(queries) != 1 {
+		t.Fatalf("ListSavedQueries = %+v", resp)
+	}
+
+	resp = srv.dispatch(Request{JSONRPC: "2.0", ID: 2, Method: "SaveQuery", Params: json.RawMessage(`{"name":"apps","sql":"SELECT 1"}`)})
+	var created model.SavedQuery
+	if resp.Error != nil || json.Unmarshal(resp.Result, &created) != nil || created.ID != 7 || created.Name != "apps" {
+		t.Fatalf("SaveQuery = %+v", resp)
+	}
+
+	resp = srv.dispatch(Request{JSONRPC: "2.0", ID: 3, Method: "RunSavedQuery", Params: json.RawMessage(`{"ID":7,"Params":[42,1.5,"shop"]}`)})
+	if resp.Error != nil {
+		t.Fatalf("RunSavedQuery: %s", resp.Error.Message)
+	}
+	if store.query != "SELECT ?" || len(store.args) != 3 || store.args[0] != int64(42) || store.args[1] != 1.5 || store.args[2] != "shop" {
+		t.Errorf("RunSavedQuery executed %q with %#v", store.query, store.args)
+	}
+
+	resp = srv.dispatch(Request{JSONRPC: "2.0", ID: 4, Method: "GetSavedQuery", Params: json.RawMessage(`{"ID":8}`)})
+	if resp.Error == nil || resp.Error.Code != -32000 {
+		t.Errorf("GetSavedQuery of a missing id = %+v, want an error", resp)
+	}
+
+	resp = srv.dispatch(Request{JSONRPC: "2.0", ID: 5, Method: "DeleteSavedQuery", Params: json.RawMessage(`{"ID":7}`)})
+	if resp.Error != nil || saved.deleted != 7 {
+		t.Errorf("DeleteSavedQuery = %+v, deleted %d", resp, saved.deleted)
+	}
+}
+
+// scanQuerier estimates every query to scan estimated rows.
+type scanQuerier struct {
+	argsQuerier
+	estimated int64
+}
+
+func (q *scanQuerier) EstimateQueryScanRows(string, ...any) (int64, error) { return q.estimated, nil }
+
+func TestDispatch_ExecuteQueryScanLimit(t *testing.T) {
+	t.Parallel()
+	store := &scanQuerier{estimated: 1000}
+	srv := &Server{store: store}
+	srv.SetMaxScanRows(100)
+
+	resp := srv.dispatch(Request{JSONRPC: "2.0", ID: 1, Method: "ExecuteQuery", Params: json.RawMessage(`{"Query":"SELECT * FROM logs"}`)})
+	if resp.Error == nil || resp.Error.Code != -32000 || !strings.Contains(resp.Error.Message, "estimated to scan 1000 rows") {
+		t.Fatalf("ExecuteQuery over the scan limit = %+v, want rejected", resp)
+	}
+	if store.query != "" {
+		t.Fatalf("rejected query ran: %q", store.query)
+	}
+
+	resp = srv.dispatch(Request{JSONRPC: "2.0", ID: 2, Method: "ExecuteQuery", Params: json.RawMessage(`{"Query":"SELECT * FROM logs","Force":true}`)})
+	if resp.Error != nil || store.query != "SELECT * FROM logs" {
+		t.Fatalf("forced ExecuteQuery = %+v, ran %q", resp, store.query)
+	}
+}
+
+type stubTailer struct{}
+
+func (stubTailer) TailLogs(model.TailFilter, int) (model.LogTail, error) {
+	return nil, errors.New("no tails")
+}
+
+func TestDispatch_Hello(t *testing.T) {
+	t.Parallel()
+	srv := newTestDispatcher()
+	srv.SetVersion("1.2.3", 12)
+
+	hello := func() Hello {
+		t.Helper()
+		resp := srv.dispatch(Request{JSONRPC: "2.0", ID: 1, Method: "Hello"})
+		if resp.Error != nil {
+			t.Fatalf("Hello: %v", resp.Error)
+		}
+		var h Hello
+		if err := json.Unmarshal(resp.Result, &h); err != nil {
+			t.Fatalf("unmarshal Hello: %v", err)
+		}
+		return h
+	}
+
+	h := hello()
+	if h.Version != "1.2.3" || h.Protocol != ProtocolVersion || h.SchemaRevision != 12 {
+		t.Fatalf("Hello = %+v", h)
+	}
+	if !slices.Contains(h.Methods, "DashboardSnapshot") || slices.Contains(h.Methods, "TraceLogs") || slices.Contains(h.Methods, "Subscribe") {
+		t.Fatalf("Hello methods without optional stores = %v", h.Methods)
+	}
+
+	srv.SetTraceQuerier(&stubTraces{})
+	srv.SetSavedQueries(&stubSaved{})
+	srv.SetLogTailer(stubTailer{})
+	srv.SetEventSource(events.NewHub())
+	srv.SetIngestRater(&stubMetrics{})
+	srv.SetMetricQuerier(&stubMetrics{})
+	h = hello()
+	for _, method := range []string{"TraceSpans", "RunSavedQuery", "Subscribe", "SubscribeEvents", "IngestRate", "MetricRange"} {
+		if !slices.Contains(h.Methods, method) {
+			t.Errorf("Hello methods lack %s: %v", method, h.Methods)
+		}
+	}
+	// Every method listed is one the server answers; the subscriptions are
+	// served by the connection rather than dispatch.
+	for _, method := range h.Methods {
+		if method == "Subscribe" || method == "SubscribeEvents" {
+			continue
+		}
+		resp := srv.dispatch(Request{JSONRPC: "2.0", ID: 2, Method: method, Params: json.RawMessage(`{}`)})
+		if resp.Error != nil && resp.Error.Code == -32601 {
+			t.Errorf("Hello lists %s, which is not served", method)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package socketrpc
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+const peerCredSupported = true
+
+// peerCred returns the user and group of the process on the other end of
+// a Unix socket connection, as of when it connected (SO_PEERCRED).
+func peerCred(conn net.Conn) (uid, gid uint32, err error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, 0, fmt.Errorf("not a unix connection")
+	}
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return 0, 0, err
+	}
+	var cred *syscall.Ucred
+	var credErr error
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return 0, 0, err
+	}
+	if credErr != nil {
+		return 0, 0, credErr
+	}
+	return cred.Uid, cred.Gid, nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package socketrpc
+
+import (
+	"errors"
+	"net"
+)
+
+const peerCredSupported = false
+
+// peerCred is unsupported off Linux; SetSocketAccess refuses allowlists.
+func peerCred(net.Conn) (uid, gid uint32, err error) {
+	return 0, 0, errors.New("peer credentials are not supported on this platform")
+}
@@ -4,30 +4,97 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 )
 
 // JSON-RPC 2.0 Method Reference
 //
-// The socket RPC server exposes the log-query subset of model.ReadAPI over Unix domain socket.
+// The socket RPC server exposes the log-query subset of model.ReadAPI over Unix domain socket,
+// and optionally over TCP (Server.ListenTCP). A TCP connection must first send
+// Authenticate {Token: string}, answered true; anything else, or a wrong token,
+// fails with -32002 and closes the connection.
 // Each method maps 1:1 to the LogQuerier portion of that interface.
 //
 //   Method                    Params                                              Result
 //   ──────────────────────    ──────────────────────────────────────────────────   ─────────────────────────
+//   Hello                     {Features: []string} (optional)                     Hello
 //   TotalLogCount             {Opts: QueryOpts}                                   int64
 //   TotalLogBytes             {Opts: QueryOpts}                                   int64
 //   TopWords                  {Limit: int, Opts: QueryOpts}                       []WordCount
 //   TopAttributes             {Limit: int, Opts: QueryOpts}                       []AttributeStat
 //   TopAttributeKeys          {Limit: int, Opts: QueryOpts}                       []AttributeKeyStat
-//   AttributeKeyValues        {Key: string, Limit: int}                           map[string]int64
+//   AttributeKeyValues        {Key: string, Limit: int, Opts: QueryOpts}          map[string]int64
+//   DistinctAttributeValues   {Key: string, Prefix: string, Limit: int, Opts: QueryOpts}  []DimensionCount
 //   SeverityCounts            {Opts: QueryOpts}                                   map[string]int64
 //   SeverityCountsByMinute    {Window: time.Duration, Opts: QueryOpts}            []MinuteCounts
 //   TopHosts                  {Limit: int, Opts: QueryOpts}                       []DimensionCount
 //   TopServices               {Limit: int, Opts: QueryOpts}                       []DimensionCount
 //   TopServicesBySeverity     {Severity: string, Limit: int, Opts: QueryOpts}     []DimensionCount
 //   ListApps                  (none)                                              []string
-//   RecentLogsFiltered        {Limit: int, App: string, SeverityLevels: []string, MessagePattern: string}  []LogRecord
+//   RecentLogsFiltered        {Limit: int, Opts: QueryOpts, SeverityLevels: []string, Facets: map[string]string, MessagePattern: string}  []LogRecord
+//   SearchLogs                {Term: string, Limit: int, Opts: QueryOpts}         []LogRecord
+//   RateByDimension           {Dimension: string, Window: Duration, Step: Duration, SeverityLevels: []string, Opts: QueryOpts}  []DimensionRate
+//   ExecuteQuery              {Query: string, Params: []any, Force: bool}         []map[string]any
+//   GetSchemaDescription      (none)                                              string
+//   TableRowCounts            (none)                                              map[string]int64
+//   DashboardSnapshot         SnapshotRequest                                     DashboardSnapshot
+//   TraceLogs                 {TraceID: string, Limit: int}                       []LogRecord
+//   TraceSpans                {TraceID: string}                                   []Span
+//   IngestRate                {Window: Duration, Step: Duration, Opts: QueryOpts} []IngestPoint
+//   MetricSeries              {Filter: string, Limit: int, Opts: QueryOpts}       []MetricSeries
+//   MetricRange               {Name: string, Labels: map[string]string, Step: Duration, Opts: QueryOpts}  []MetricPoint
+//   ListSavedQueries          (none)                                              []SavedQuery
+//   GetSavedQuery             {ID: int64}                                         SavedQuery
+//   SaveQuery                 SavedQuery (ID 0 creates)                           SavedQuery
+//   DeleteSavedQuery          {ID: int64}                                         null
+//   RunSavedQuery             {ID: int64, Params: []any, Force: bool}             []map[string]any
+//   Subscribe                 TailFilter (optional)                               true, then Logs notifications
+//   SubscribeEvents           (none)                                              true, then Event notifications
+//   Alerts                    (none)                                              []Alert
+//   SilenceAlert              {Rule: string, Duration: Duration}                  null
+//   AcknowledgeAlert          {Rule: string}                                      null
+//   Health                    (none)                                              []ComponentHealth
 //
-// QueryOpts: {App: string} — empty string means all apps.
+// Subscribe switches its connection into push mode: after the true result
+// the server sends only {"jsonrpc":"2.0","method":"Logs","params":TailBatch}
+// notifications, each carrying the matching records ingested since the last
+// and how many were dropped because the client fell behind. Closing the
+// connection, or sending anything on it, ends the tail. TailFilter:
+// {App: string, SeverityLevels: []string, Facets: map[string]string,
+// MessagePattern: string}, empty fields matching everything. Subscribe is
+// served only when the server was given a log tailer; an invalid
+// MessagePattern fails with -32602 and leaves the connection serving
+// requests.
+// SubscribeEvents switches its connection into push mode the same way; the
+// server then sends {"jsonrpc":"2.0","method":"Event","params":ServerEvent}
+// as things happen on it: an app's first record, a failed store write, a
+// finished or failed backup. Events a slow client cannot take are dropped.
+// Requests on one connection run concurrently, up to 8 at a time, and are
+// answered as they finish, so responses may come out of order; clients
+// match them by id. Hello, and the subscriptions, wait for the requests
+// before them.
+// Clients call Hello on connecting: it names the server's version, protocol
+// revision, schema revision, and the methods it serves, so a client can skip
+// the ones an older server lacks. A server that predates Hello answers it
+// with method not found. A client that lists "chunked" in Features gets
+// results larger than 256 KiB gzip-compressed and split into Chunk
+// responses of at most 1 MiB each, so no line nears the 10 MB line cap.
+// TraceLogs and TraceSpans are served only when the store keeps traces,
+// IngestRate only when it reports log volume, MetricSeries and MetricRange
+// only when it keeps metrics, the saved query methods only when it keeps
+// saved queries, the alert methods only when the server was given an
+// alert engine, and Health only when it was given its component checks;
+// otherwise they fail with method not found. A SilenceAlert
+// Duration of 0 or less lifts the rule's silence.
+// QueryOpts: {App: string, From: time, To: time} — empty App means all apps;
+// From (inclusive) and To (exclusive) bound the record timestamp when set.
+// Durations are nanoseconds, as encoding/json writes time.Duration.
+// ExecuteQuery and RunSavedQuery bind Params (strings, numbers, booleans,
+// null) to the query's ? placeholders; the query must be read-only. When
+// the server sets a scan limit (query-max-scan-rows), a query the planner
+// estimates will scan more rows fails with -32000 unless Force is set.
+// RecentLogsFiltered also accepts a top-level App from older clients.
 // Methods with optional params (TotalLogCount, TotalLogBytes, SeverityCounts,
 // RecentLogsFiltered) accept empty or null params gracefully.
 //
@@ -37,6 +104,46 @@ import (
 //   -32602  Invalid params
 //   -32603  Internal error (marshal failure)
 //   -32000  Application error (query failure)
+//   -32001  Query shed by the store's scheduler or timed out; retry
+//   -32002  TCP connection not authenticated
+//   -32003  Unix socket peer not allowed (see SocketAccess); the connection is closed
+
+// ProtocolVersion is the protocol revision Hello reports. It changes only
+// when an existing method changes incompatibly; clients discover added
+// methods from Hello's Methods instead.
+const ProtocolVersion = 1
+
+// coreMethods are the methods every server serves, Hello included.
+var coreMethods = []string{
+	"Hello",
+	"TotalLogCount", "TotalLogBytes", "TopWords", "TopAttributes", "TopAttributeKeys",
+	"AttributeKeyValues", "DistinctAttributeValues", "SeverityCounts", "SeverityCountsByMinute",
+	"TopHosts", "TopServices", "TopServicesBySeverity", "ListApps", "RecentLogsFiltered",
+	"SearchLogs", "RateByDimension", "ExecuteQuery", "GetSchemaDescription", "TableRowCounts",
+	"DashboardSnapshot",
+}
+
+// Optional method groups, served when the server was given what they need.
+var (
+	traceMethods      = []string{"TraceLogs", "TraceSpans"}
+	ingestMethods     = []string{"IngestRate"}
+	metricMethods     = []string{"MetricSeries", "MetricRange"}
+	savedQueryMethods = []string{"ListSavedQueries", "GetSavedQuery", "SaveQuery", "DeleteSavedQuery", "RunSavedQuery"}
+	tailMethods       = []string{"Subscribe"}
+	eventMethods      = []string{"SubscribeEvents"}
+	alertMethods      = []string{"Alerts", "SilenceAlert", "AcknowledgeAlert"}
+	healthMethods     = []string{"Health"}
+)
+
+// Hello is the result of the Hello method, which a client calls on
+// connecting to learn what the server can do.
+type Hello struct {
+	Version        string   // the server's build version
+	Protocol       int      // the server's ProtocolVersion
+	SchemaRevision int      // last storage migration applied; 0 for stores without migrations
+	Methods        []string // the methods this server serves
+	Features       []string // protocol features it offers: "chunked" (see Chunk)
+}
 
 // Request is a JSON-RPC 2.0 request.
 type Request struct {
@@ -52,6 +159,23 @@ type Response struct {
 	ID      int             `json:"id"`
 	Result  json.RawMessage `json:"result,omitempty"`
 	Error   *RPCError       `json:"error,omitempty"`
+	Chunk   *Chunk          `json:"chunk,omitempty"`
+}
+
+// Notification is a JSON-RPC 2.0 message the server pushes without a
+// request; it has no id.
+type Notification struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+// TailBatch is the params of a Logs notification: the records ingested
+// since the last one, and how many matching records were dropped because
+// the client fell behind.
+type TailBatch struct {
+	Logs    []model.LogRecord
+	Dropped int64
 }
 
 // RPCError represents a JSON-RPC 2.0 error object.
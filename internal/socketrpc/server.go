@@ -10,6 +10,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
@@ -21,18 +22,46 @@ const (
 	scannerInitBufSize = 1024 * 1024
 	// scannerMaxTokenSize is the maximum token size the scanner will accept (10 MB).
 	scannerMaxTokenSize = 10 * 1024 * 1024
+	// tailBuffer is how many records a Subscribe connection holds for a
+	// client that is behind before dropping them.
+	tailBuffer = 4096
+	// tailBatchSize caps the records in one Logs notification.
+	tailBatchSize = 500
+	// connRequests caps the requests of one connection that run at once;
+	// the connection's further requests wait to be read.
+	connRequests = 8
+	// eventBuffer is how many events a SubscribeEvents connection holds
+	// for a client that is behind before dropping them.
+	eventBuffer = 64
 )
 
-// Server exposes the read API over a Unix domain socket using JSON-RPC 2.0.
+// Server exposes the read API over a Unix domain socket, and optionally TCP
+// (see ListenTCP), using JSON-RPC 2.0.
 type Server struct {
-	socketPath string
-	store      model.ReadAPI
-	listener   net.Listener
-	wg         sync.WaitGroup
-	quit       chan struct{}
-	stopOnce   sync.Once
-	connMu     sync.Mutex
-	conns      map[net.Conn]struct{}
+	socketPath  string
+	store       model.ReadAPI
+	traces      model.TraceQuerier    // nil = trace methods not served
+	ingest      model.IngestRater     // nil = IngestRate not served
+	metrics     model.MetricQuerier   // nil = metric methods not served
+	saved       model.SavedQueryStore // nil = saved query methods not served
+	tailer      model.LogTailer       // nil = Subscribe not served
+	events      model.EventSource     // nil = SubscribeEvents not served
+	alerts      model.AlertManager    // nil = alert methods not served
+	health      model.HealthReporter  // nil = Health not served
+	maxScanRows int64                 // see SetMaxScanRows; 0 = no limit
+	listener    net.Listener
+	tcp         net.Listener // nil = no TCP listener
+	token       string       // required on TCP connections
+	wg          sync.WaitGroup
+	quit        chan struct{}
+	stopOnce    sync.Once
+	connMu      sync.Mutex
+	conns       map[net.Conn]struct{}
+
+	version        string // reported by Hello
+	schemaRevision int
+
+	access socketAccess // see SetSocketAccess
 }
 
 // NewServer creates a new socket RPC server.
@@ -40,11 +69,100 @@ func NewServer(socketPath string, store model.ReadAPI) *Server {
 	return &Server{
 		socketPath: socketPath,
 		store:      store,
+		access:     socketAccess{uid: -1, gid: -1},
 		quit:       make(chan struct{}),
 		conns:      make(map[net.Conn]struct{}),
 	}
 }
 
+// SetVersion sets the server version and storage schema revision Hello
+// reports. Call before Start.
+func (s *Server) SetVersion(version string, schemaRevision int) {
+	s.version = version
+	s.schemaRevision = schemaRevision
+}
+
+// methods returns the methods the server serves.
+func (s *Server) methods() []string {
+	methods := slices.Clone(coreMethods)
+	if s.traces != nil {
+		methods = append(methods, traceMethods...)
+	}
+	if s.ingest != nil {
+		methods = append(methods, ingestMethods...)
+	}
+	if s.metrics != nil {
+		methods = append(methods, metricMethods...)
+	}
+	if s.saved != nil {
+		methods = append(methods, savedQueryMethods...)
+	}
+	if s.tailer != nil {
+		methods = append(methods, tailMethods...)
+	}
+	if s.events != nil {
+		methods = append(methods, eventMethods...)
+	}
+	if s.alerts != nil {
+		methods = append(methods, alertMethods...)
+	}
+	if s.health != nil {
+		methods = append(methods, healthMethods...)
+	}
+	return methods
+}
+
+// SetTraceQuerier serves the TraceLogs and TraceSpans methods from q.
+// Call before Start.
+func (s *Server) SetTraceQuerier(q model.TraceQuerier) {
+	s.traces = q
+}
+
+// SetIngestRater serves the IngestRate method from r. Call before Start.
+func (s *Server) SetIngestRater(r model.IngestRater) {
+	s.ingest = r
+}
+
+// SetMetricQuerier serves the MetricSeries and MetricRange methods from q.
+// Call before Start.
+func (s *Server) SetMetricQuerier(q model.MetricQuerier) {
+	s.metrics = q
+}
+
+// SetSavedQueries serves the saved query methods from q, running saved
+// queries on the server's store. Call before Start.
+func (s *Server) SetSavedQueries(q model.SavedQueryStore) {
+	s.saved = q
+}
+
+// SetLogTailer serves the Subscribe method from t. Call before Start.
+func (s *Server) SetLogTailer(t model.LogTailer) {
+	s.tailer = t
+}
+
+// SetEventSource serves the SubscribeEvents method from e. Call before
+// Start.
+func (s *Server) SetEventSource(e model.EventSource) {
+	s.events = e
+}
+
+// SetAlertManager serves the alert methods from a. Call before Start.
+func (s *Server) SetAlertManager(a model.AlertManager) {
+	s.alerts = a
+}
+
+// SetHealthReporter serves the Health method from h. Call before Start.
+func (s *Server) SetHealthReporter(h model.HealthReporter) {
+	s.health = h
+}
+
+// SetMaxScanRows rejects ExecuteQuery and RunSavedQuery calls that the
+// planner estimates will scan more than n rows unless they set Force, as
+// /api/query does. Zero disables the check. Call before Start.
+func (s *Server) SetMaxScanRows(n int64) {
+	s.maxScanRows = max(n, 0)
+}
+
 // Start begins listening on the Unix socket and accepting connections.
 func (s *Server) Start() error {
 	// Ensure the parent directory exists.
@@ -68,10 +186,14 @@ func (s *Server) Start() error {
 	if err != nil {
 		return fmt.Errorf("socketrpc: listen: %w", err)
 	}
+	if err := s.applySocketAccess(); err != nil {
+		ln.Close()
+		return err
+	}
 	s.listener = ln
 
 	s.wg.Add(1)
-	go s.acceptLoop()
+	go s.acceptLoop(ln, false)
 
 	log.Printf("socketrpc: listening on %s", s.socketPath)
 	return nil
@@ -84,6 +206,9 @@ func (s *Server) Stop() {
 		if s.listener != nil {
 			_ = s.listener.Close()
 		}
+		if s.tcp != nil {
+			_ = s.tcp.Close()
+		}
 		s.closeActiveConnections()
 
 		waitDone := make(chan struct{})
@@ -94,14 +219,19 @@ func (s *Server) Stop() {
 		case <-waitDone:
 		case <-timer.C:
 		}
-		_ = os.Remove(s.socketPath)
+		if s.listener != nil {
+			// Only a socket this server created; another's stays.
+			_ = os.Remove(s.socketPath)
+		}
 	})
 }
 
-func (s *Server) acceptLoop() {
+// acceptLoop serves the connections of ln; with auth set, each must
+// authenticate first.
+func (s *Server) acceptLoop(ln net.Listener, auth bool) {
 	defer s.wg.Done()
 	for {
-		conn, err := s.listener.Accept()
+		conn, err := ln.Accept()
 		if err != nil {
 			select {
 			case <-s.quit:
@@ -115,11 +245,11 @@ func (s *Server) acceptLoop() {
 		}
 		s.trackConn(conn)
 		s.wg.Add(1)
-		go s.handleConn(conn)
+		go s.handleConn(conn, auth)
 	}
 }
 
-func (s *Server) handleConn(conn net.Conn) {
+func (s *Server) handleConn(conn net.Conn, auth bool) {
 	defer s.wg.Done()
 	defer s.untrackConn(conn)
 	defer conn.Close()
@@ -127,6 +257,33 @@ func (s *Server) handleConn(conn net.Conn) {
 	scanner := bufio.NewScanner(conn)
 	scanner.Buffer(make([]byte, 0, scannerInitBufSize), scannerMaxTokenSize)
 	encoder := json.NewEncoder(conn)
+	chunked := false // large results compressed and chunked; see Chunk
+
+	// Requests run concurrently, up to connRequests at once, so a slow
+	// query does not hold up the others; each response carries its
+	// request's id and is written whole under writeMu.
+	var (
+		writeMu  sync.Mutex
+		inflight sync.WaitGroup
+		slots    = make(chan struct{}, connRequests)
+	)
+	defer inflight.Wait()
+	send := func(resp Response, chunked bool) {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		if err := encodeResponse(encoder, resp, chunked); err != nil {
+			conn.Close() // ends the read loop
+		}
+	}
+
+	var refused string
+	if !auth {
+		refused = s.refusePeer(conn)
+	}
+	if auth || refused != "" {
+		// A client that never authenticates does not hold the connection.
+		conn.SetReadDeadline(time.Now().Add(authTimeout))
+	}
 
 	for scanner.Scan() {
 		select {
@@ -137,15 +294,177 @@ func (s *Server) handleConn(conn net.Conn) {
 
 		var req Request
 		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
-			resp := Response{JSONRPC: "2.0", ID: 0, Error: &RPCError{Code: -32700, Message: "parse error"}}
-			encoder.Encode(resp)
+			send(Response{JSONRPC: "2.0", ID: 0, Error: &RPCError{Code: -32700, Message: "parse error"}}, false)
 			continue
 		}
 
-		resp := s.dispatch(req)
-		if err := encoder.Encode(resp); err != nil {
+		if refused != "" {
+			// Answered rather than closed at once, so the client learns why.
+			log.Printf("socketrpc: refused connection: %s", refused)
+			encoder.Encode(Response{JSONRPC: "2.0", ID: req.ID, Error: &RPCError{Code: -32003, Message: "permission denied: " + refused}})
 			return
 		}
+		if auth {
+			if !s.authenticate(encoder, req) {
+				return
+			}
+			auth = false
+			conn.SetReadDeadline(time.Time{})
+			continue
+		}
+
+		// Subscriptions take over the connection once its requests are done.
+		if req.Method == "Subscribe" && s.tailer != nil {
+			inflight.Wait()
+			if s.serveTail(conn, scanner, encoder, req) {
+				return
+			}
+			continue
+		}
+		if req.Method == "SubscribeEvents" && s.events != nil {
+			inflight.Wait()
+			if s.serveEvents(conn, scanner, encoder, req) {
+				return
+			}
+			continue
+		}
+
+		if req.Method == "Hello" {
+			// Answered in order: it sets how the later responses are framed.
+			chunked = wantsChunks(req.Params)
+			send(s.dispatch(req), chunked)
+			continue
+		}
+		slots <- struct{}{}
+		inflight.Add(1)
+		go func(req Request, chunked bool) {
+			defer inflight.Done()
+			defer func() { <-slots }()
+			send(s.dispatch(req), chunked)
+		}(req, chunked)
+	}
+}
+
+// serveTail answers a Subscribe request and, once it succeeds, pushes the
+// matching records as Logs notifications until the client closes the
+// connection or sends anything else, the server stops, or the tail ends.
+// It reports whether the connection went into push mode; if not, the
+// request was answered with an error and the connection serves requests
+// as before.
+func (s *Server) serveTail(conn net.Conn, scanner *bufio.Scanner, encoder *json.Encoder, req Request) bool {
+	resp := Response{JSONRPC: "2.0", ID: req.ID}
+	var filter model.TailFilter
+	if len(req.Params) > 0 && string(req.Params) != "null" {
+		if err := json.Unmarshal(req.Params, &filter); err != nil {
+			resp.Error = &RPCError{Code: -32602, Message: fmt.Sprintf("invalid params: %v", err)}
+			encoder.Encode(resp)
+			return false
+		}
+	}
+	tail, err := s.tailer.TailLogs(filter, tailBuffer)
+	if err != nil {
+		resp.Error = &RPCError{Code: -32602, Message: fmt.Sprintf("invalid params: %v", err)}
+		encoder.Encode(resp)
+		return false
+	}
+	defer tail.Close()
+
+	resp.Result = json.RawMessage("true")
+	if err := encoder.Encode(resp); err != nil {
+		return true
+	}
+
+	// The client sends nothing more; a line or EOF ends the tail.
+	hangup := make(chan struct{})
+	go func() {
+		scanner.Scan()
+		close(hangup)
+	}()
+	defer conn.Close() // unblocks the reader when the tail ends first
+
+	for {
+		var batch TailBatch
+		select {
+		case r, ok := <-tail.Records():
+			if !ok {
+				return true
+			}
+			batch.Logs = append(batch.Logs, r)
+		case <-hangup:
+			return true
+		case <-s.quit:
+			return true
+		}
+		// Send what else is already waiting in the same notification.
+	drain:
+		for len(batch.Logs) < tailBatchSize {
+			select {
+			case r, ok := <-tail.Records():
+				if !ok {
+					break drain
+				}
+				batch.Logs = append(batch.Logs, r)
+			default:
+				break drain
+			}
+		}
+		batch.Dropped = tail.Dropped()
+		params, err := json.Marshal(batch)
+		if err != nil {
+			log.Printf("socketrpc: marshal tail batch: %v", err)
+			return true
+		}
+		if err := encoder.Encode(Notification{JSONRPC: "2.0", Method: "Logs", Params: params}); err != nil {
+			return true
+		}
+	}
+}
+
+// serveEvents answers a SubscribeEvents request and, once it succeeds,
+// pushes server events as Event notifications until the client closes the
+// connection or sends anything else, or the server stops. It reports
+// whether the connection went into push mode, as serveTail does.
+func (s *Server) serveEvents(conn net.Conn, scanner *bufio.Scanner, encoder *json.Encoder, req Request) bool {
+	resp := Response{JSONRPC: "2.0", ID: req.ID}
+	sub, err := s.events.SubscribeEvents(eventBuffer)
+	if err != nil {
+		resp.Error = &RPCError{Code: -32000, Message: err.Error()}
+		encoder.Encode(resp)
+		return false
+	}
+	defer sub.Close()
+
+	resp.Result = json.RawMessage("true")
+	if err := encoder.Encode(resp); err != nil {
+		return true
+	}
+
+	hangup := make(chan struct{})
+	go func() {
+		scanner.Scan()
+		close(hangup)
+	}()
+	defer conn.Close()
+
+	for {
+		select {
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return true
+			}
+			params, err := json.Marshal(ev)
+			if err != nil {
+				log.Printf("socketrpc: marshal event: %v", err)
+				return true
+			}
+			if err := encoder.Encode(Notification{JSONRPC: "2.0", Method: "Event", Params: params}); err != nil {
+				return true
+			}
+		case <-hangup:
+			return true
+		case <-s.quit:
+			return true
+		}
 	}
 }
 
@@ -174,13 +493,32 @@ func (s *Server) closeActiveConnections() {
 	}
 }
 
+// executeQuery runs a read-only query, first rejecting it when the planner
+// estimates it will scan more than maxScanRows rows and force is unset.
+func (s *Server) executeQuery(query string, args []any, force bool) ([]map[string]interface{}, error) {
+	if s.maxScanRows > 0 && !force {
+		estimated, err := s.store.EstimateQueryScanRows(query, args...)
+		if err != nil {
+			return nil, err
+		}
+		if estimated > s.maxScanRows {
+			return nil, fmt.Errorf("query is estimated to scan %d rows (limit %d); narrow it or resend with Force", estimated, s.maxScanRows)
+		}
+	}
+	return s.store.ExecuteQuery(query, args...)
+}
+
 func (s *Server) dispatch(req Request) Response {
 	resp := Response{JSONRPC: "2.0", ID: req.ID}
 
 	marshalResult := func(v interface{}, err error) Response {
 		if err != nil {
-			if errorsIsQueryOverload(err) {
-				resp.Error = &RPCError{Code: -32001, Message: "query overloaded or timed out; retry"}
+			if errors.Is(err, model.ErrOverloaded) {
+				resp.Error = &RPCError{Code: -32001, Message: "query overloaded; retry"}
+				return resp
+			}
+			if errorsIsQueryTimeout(err) {
+				resp.Error = &RPCError{Code: -32001, Message: "query timed out; retry"}
 				return resp
 			}
 			resp.Error = &RPCError{Code: -32000, Message: err.Error()}
@@ -201,6 +539,15 @@ func (s *Server) dispatch(req Request) Response {
 	}
 
 	switch req.Method {
+	case "Hello":
+		return marshalResult(Hello{
+			Version:        s.version,
+			Protocol:       ProtocolVersion,
+			SchemaRevision: s.schemaRevision,
+			Methods:        s.methods(),
+			Features:       []string{featureChunked},
+		}, nil)
+
 	case "TotalLogCount":
 		var p struct{ Opts model.QueryOpts }
 		if err := json.Unmarshal(req.Params, &p); err != nil && len(req.Params) > 0 {
@@ -249,11 +596,24 @@ func (s *Server) dispatch(req Request) Response {
 		var p struct {
 			Key   string
 			Limit int
+			Opts  model.QueryOpts
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(s.store.AttributeKeyValues(p.Key, p.Limit, p.Opts))
+
+	case "DistinctAttributeValues":
+		var p struct {
+			Key    string
+			Prefix string
+			Limit  int
+			Opts   model.QueryOpts
 		}
 		if err := json.Unmarshal(req.Params, &p); err != nil {
 			return invalidParams(err)
 		}
-		return marshalResult(s.store.AttributeKeyValues(p.Key, p.Limit))
+		return marshalResult(s.store.DistinctAttributeValues(p.Key, p.Prefix, p.Limit, p.Opts))
 
 	case "SeverityCounts":
 		var p struct{ Opts model.QueryOpts }
@@ -308,15 +668,20 @@ func (s *Server) dispatch(req Request) Response {
 	case "RecentLogsFiltered":
 		var p struct {
 			Limit          int
-			App            string
+			App            string // pre-Opts clients; Opts.App wins when set
+			Opts           model.QueryOpts
 			SeverityLevels []string
+			Facets         map[string]string
 			MessagePattern string
 		}
 		// Allow empty/null params for defaults; only reject genuinely malformed JSON.
 		if err := json.Unmarshal(req.Params, &p); err != nil && len(req.Params) > 0 {
 			return invalidParams(err)
 		}
-		return marshalResult(s.store.RecentLogsFiltered(p.Limit, p.App, p.SeverityLevels, p.MessagePattern))
+		if p.Opts.App == "" {
+			p.Opts.App = p.App
+		}
+		return marshalResult(s.store.RecentLogsFiltered(p.Limit, p.Opts, p.SeverityLevels, p.Facets, p.MessagePattern))
 
 	case "SearchLogs":
 		var p struct {
@@ -329,12 +694,210 @@ func (s *Server) dispatch(req Request) Response {
 		}
 		return marshalResult(s.store.SearchLogs(p.Term, p.Limit, p.Opts))
 
-	default:
-		resp.Error = &RPCError{Code: -32601, Message: fmt.Sprintf("method not found: %s", req.Method)}
-		return resp
+	case "RateByDimension":
+		var p struct {
+			Dimension      string
+			Window         time.Duration
+			Step           time.Duration
+			SeverityLevels []string
+			Opts           model.QueryOpts
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(s.store.RateByDimension(p.Dimension, p.Window, p.Step, p.SeverityLevels, p.Opts))
+
+	case "ExecuteQuery":
+		var p struct {
+			Query  string
+			Params []json.RawMessage
+			Force  bool
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		args, err := model.QueryArgs(p.Params)
+		if err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(s.executeQuery(p.Query, args, p.Force))
+
+	case "GetSchemaDescription":
+		return marshalResult(s.store.GetSchemaDescription(), nil)
+
+	case "TableRowCounts":
+		return marshalResult(s.store.TableRowCounts())
+
+	case "DashboardSnapshot":
+		var p model.SnapshotRequest
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(model.ReadDashboardSnapshot(s.store, p))
+
+	case "TraceLogs":
+		if s.traces == nil {
+			break
+		}
+		var p struct {
+			TraceID string
+			Limit   int
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(s.traces.TraceLogs(p.TraceID, p.Limit))
+
+	case "TraceSpans":
+		if s.traces == nil {
+			break
+		}
+		var p struct{ TraceID string }
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(s.traces.TraceSpans(p.TraceID))
+
+	case "IngestRate":
+		if s.ingest == nil {
+			break
+		}
+		var p struct {
+			Window time.Duration
+			Step   time.Duration
+			Opts   model.QueryOpts
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(s.ingest.IngestRate(p.Window, p.Step, p.Opts))
+
+	case "MetricSeries":
+		if s.metrics == nil {
+			break
+		}
+		var p struct {
+			Filter string
+			Limit  int
+			Opts   model.QueryOpts
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(s.metrics.MetricSeries(p.Filter, p.Limit, p.Opts))
+
+	case "MetricRange":
+		if s.metrics == nil {
+			break
+		}
+		var p struct {
+			Name   string
+			Labels map[string]string
+			Step   time.Duration
+			Opts   model.QueryOpts
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(s.metrics.MetricRange(p.Name, p.Labels, p.Step, p.Opts))
+
+	case "Alerts":
+		if s.alerts == nil {
+			break
+		}
+		return marshalResult(s.alerts.Alerts())
+
+	case "SilenceAlert":
+		if s.alerts == nil {
+			break
+		}
+		var p struct {
+			Rule     string
+			Duration time.Duration
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(nil, s.alerts.SilenceAlert(p.Rule, p.Duration))
+
+	case "AcknowledgeAlert":
+		if s.alerts == nil {
+			break
+		}
+		var p struct{ Rule string }
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(nil, s.alerts.AcknowledgeAlert(p.Rule))
+
+	case "Health":
+		if s.health == nil {
+			break
+		}
+		return marshalResult(s.health.Health())
+
+	case "ListSavedQueries":
+		if s.saved == nil {
+			break
+		}
+		return marshalResult(s.saved.SavedQueries())
+
+	case "GetSavedQuery":
+		if s.saved == nil {
+			break
+		}
+		var p struct{ ID int64 }
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(s.saved.SavedQuery(p.ID))
+
+	case "SaveQuery":
+		if s.saved == nil {
+			break
+		}
+		var p model.SavedQuery
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(s.saved.SaveQuery(p))
+
+	case "DeleteSavedQuery":
+		if s.saved == nil {
+			break
+		}
+		var p struct{ ID int64 }
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		return marshalResult(nil, s.saved.DeleteSavedQuery(p.ID))
+
+	case "RunSavedQuery":
+		if s.saved == nil {
+			break
+		}
+		var p struct {
+			ID     int64
+			Params []json.RawMessage
+			Force  bool
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return invalidParams(err)
+		}
+		args, err := model.QueryArgs(p.Params)
+		if err != nil {
+			return invalidParams(err)
+		}
+		q, err := s.saved.SavedQuery(p.ID)
+		if err != nil {
+			return marshalResult(nil, err)
+		}
+		return marshalResult(s.executeQuery(q.SQL, args, p.Force))
 	}
+	resp.Error = &RPCError{Code: -32601, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	return resp
 }
 
-func errorsIsQueryOverload(err error) bool {
+func errorsIsQueryTimeout(err error) bool {
 	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
@@ -0,0 +1,126 @@
+package socketrpc
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"time"
+)
+
+const (
+	// authTimeout is how long a TCP client has to authenticate.
+	authTimeout = 10 * time.Second
+	// MinTokenLength is the shortest token ListenTCP accepts.
+	MinTokenLength = 16
+)
+
+// ListenTCP also serves the server's methods on a TCP address, over TLS
+// when conf is set. Each connection must first send Authenticate with
+// token. Call after Start; Stop closes it with the socket.
+func (s *Server) ListenTCP(addr string, conf *tls.Config, token string) error {
+	if len(token) < MinTokenLength {
+		return fmt.Errorf("socketrpc: TCP token must be at least %d characters", MinTokenLength)
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("socketrpc: listen: %w", err)
+	}
+	if conf != nil {
+		ln = tls.NewListener(ln, conf)
+	}
+	s.tcp = ln
+	s.token = token
+
+	s.wg.Add(1)
+	go s.acceptLoop(ln, true)
+
+	log.Printf("socketrpc: listening on tcp %s", ln.Addr())
+	return nil
+}
+
+// TCPAddr returns the TCP listener's address, or nil when there is none.
+func (s *Server) TCPAddr() net.Addr {
+	if s.tcp == nil {
+		return nil
+	}
+	return s.tcp.Addr()
+}
+
+// authenticate answers the first request of a TCP connection, which must
+// be Authenticate with the server's token. It reports whether the
+// connection may go on.
+func (s *Server) authenticate(encoder *json.Encoder, req Request) bool {
+	resp := Response{JSONRPC: "2.0", ID: req.ID}
+	var p struct{ Token string }
+	switch {
+	case req.Method != "Authenticate":
+		resp.Error = &RPCError{Code: -32002, Message: "authentication required"}
+	case json.Unmarshal(req.Params, &p) != nil || subtle.ConstantTimeCompare([]byte(p.Token), []byte(s.token)) != 1:
+		resp.Error = &RPCError{Code: -32002, Message: "invalid token"}
+	default:
+		resp.Result = json.RawMessage("true")
+	}
+	encoder.Encode(resp)
+	return resp.Error == nil
+}
+
+// TCPOptions configures DialTCP.
+type TCPOptions struct {
+	Token string      // the server's rpc-tcp-token
+	TLS   *tls.Config // nil = plaintext
+}
+
+// DialTCP connects to a socket RPC server's TCP listener at addr
+// (host:port) and authenticates with opts.Token.
+func DialTCP(addr string, opts TCPOptions) (*Client, error) {
+	return newClient(func() (net.Conn, *bufio.Scanner, error) {
+		dialer := &net.Dialer{Timeout: 5 * time.Second}
+		var conn net.Conn
+		var err error
+		if opts.TLS != nil {
+			conn, err = tls.DialWithDialer(dialer, "tcp", addr, opts.TLS)
+		} else {
+			conn, err = dialer.Dial("tcp", addr)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("socketrpc: dial: %w", err)
+		}
+		scanner := newScanner(conn)
+		if err := authenticateConn(conn, scanner, opts.Token); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		return conn, scanner, nil
+	})
+}
+
+// authenticateConn sends Authenticate with token on a new connection.
+func authenticateConn(conn net.Conn, scanner *bufio.Scanner, token string) error {
+	params, err := json.Marshal(map[string]string{"Token": token})
+	if err != nil {
+		return fmt.Errorf("socketrpc: marshal params: %w", err)
+	}
+	conn.SetDeadline(time.Now().Add(authTimeout))
+	defer conn.SetDeadline(time.Time{})
+	if err := json.NewEncoder(conn).Encode(Request{JSONRPC: "2.0", ID: 0, Method: "Authenticate", Params: params}); err != nil {
+		return fmt.Errorf("socketrpc: send: %w", err)
+	}
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("socketrpc: read: %w", err)
+		}
+		return fmt.Errorf("socketrpc: connection closed")
+	}
+	var resp Response
+	if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+		return fmt.Errorf("socketrpc: unmarshal response: %w", err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return nil
+}
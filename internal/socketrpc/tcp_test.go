@@ -0,0 +1,137 @@
+package socketrpc_test
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/socketrpc"
+)
+
+const testToken = "tcp-token-0123456789abcdef"
+
+func TestTCP_Authenticates(t *testing.T) {
+	srv := socketrpc.NewServer(filepath.Join(t.TempDir(), "test.sock"), &mockQuerier{})
+	sink := ingest.NewTailSink(nil)
+	srv.SetLogTailer(sink)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	defer srv.Stop()
+	if err := srv.ListenTCP("127.0.0.1:0", nil, testToken); err != nil {
+		t.Fatalf("ListenTCP: %v", err)
+	}
+	addr := srv.TCPAddr().String()
+
+	var rpcErr *socketrpc.RPCError
+	if _, err := socketrpc.DialTCP(addr, socketrpc.TCPOptions{Token: "wrong"}); !errors.As(err, &rpcErr) || rpcErr.Code != -32002 {
+		t.Fatalf("DialTCP with a wrong token error = %v, want -32002", err)
+	}
+
+	// A connection that skips Authenticate is answered once and closed.
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"ListApps"}` + "\n"))
+	scanner := bufio.NewScanner(conn)
+	if !scanner.Scan() || !strings.Contains(scanner.Text(), "-32002") {
+		t.Fatalf("unauthenticated call answered %q, want -32002", scanner.Text())
+	}
+	if scanner.Scan() {
+		t.Fatalf("connection still open after %q", scanner.Text())
+	}
+
+	client, err := socketrpc.DialTCP(addr, socketrpc.TCPOptions{Token: testToken})
+	if err != nil {
+		t.Fatalf("DialTCP: %v", err)
+	}
+	defer client.Close()
+	if n, err := client.TotalLogCount(model.QueryOpts{}); err != nil || n != 42 {
+		t.Fatalf("TotalLogCount over TCP = %d, %v", n, err)
+	}
+
+	// Live tails dial and authenticate a connection of their own.
+	tail, err := client.TailLogs(model.TailFilter{}, 0)
+	if err != nil {
+		t.Fatalf("TailLogs over TCP: %v", err)
+	}
+	defer tail.Close()
+	waitFor(t, func() bool { return sink.Tails() == 1 })
+	sink.Add(&model.LogRecord{Level: "INFO", Message: "remote"})
+	if r := <-tail.Records(); r.Message != "remote" {
+		t.Fatalf("tailed record = %+v", r)
+	}
+}
+
+func TestTCP_TLS(t *testing.T) {
+	_, srv := startTestServer(t)
+	defer srv.Stop()
+
+	cert := selfSignedCert(t)
+	if err := srv.ListenTCP("127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}}, testToken); err != nil {
+		t.Fatalf("ListenTCP: %v", err)
+	}
+	addr := srv.TCPAddr().String()
+
+	if _, err := socketrpc.DialTCP(addr, socketrpc.TCPOptions{Token: testToken}); err == nil {
+		t.Fatal("plaintext client authenticated to a TLS listener")
+	}
+	if _, err := socketrpc.DialTCP(addr, socketrpc.TCPOptions{Token: testToken, TLS: &tls.Config{}}); err == nil {
+		t.Fatal("client trusted an unknown certificate")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert.Leaf)
+	client, err := socketrpc.DialTCP(addr, socketrpc.TCPOptions{Token: testToken, TLS: &tls.Config{RootCAs: pool}})
+	if err != nil {
+		t.Fatalf("DialTCP over TLS: %v", err)
+	}
+	defer client.Close()
+	if apps, err := client.ListApps(); err != nil || len(apps) != 2 {
+		t.Fatalf("ListApps over TLS = %v, %v", apps, err)
+	}
+}
+
+// selfSignedCert returns a certificate for 127.0.0.1.
+func selfSignedCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "tiny-telemetry test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
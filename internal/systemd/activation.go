@@ -0,0 +1,79 @@
+// Package systemd adopts listening sockets passed in by systemd socket
+// activation (sd_listen_fds), so the service can be started on demand and
+// serve privileged ports without running as root.
+package systemd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// listenFDsStart is the first file descriptor systemd passes (SD_LISTEN_FDS_START).
+const listenFDsStart = 3
+
+// Listeners returns the stream sockets passed by systemd, keyed by their
+// FileDescriptorName= (e.g. "tcp", "api"). It returns nil when the process
+// was not socket-activated. The LISTEN_* variables are cleared so child
+// processes do not try to adopt the same descriptors.
+func Listeners() (map[string]net.Listener, error) {
+	pid, fds, names := os.Getenv("LISTEN_PID"), os.Getenv("LISTEN_FDS"), os.Getenv("LISTEN_FDNAMES")
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	if pid == "" || fds == "" {
+		return nil, nil
+	}
+	if p, err := strconv.Atoi(pid); err != nil || p != os.Getpid() {
+		return nil, nil
+	}
+	return adopt(fds, names, listenFDsStart)
+}
+
+// adopt wraps n descriptors starting at start as listeners.
+func adopt(fds, names string, start int) (map[string]net.Listener, error) {
+	n, err := strconv.Atoi(fds)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid LISTEN_FDS %q", fds)
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	var nameList []string
+	if names != "" {
+		nameList = strings.Split(names, ":")
+	}
+
+	listeners := make(map[string]net.Listener, n)
+	closeAll := func() {
+		for _, ln := range listeners {
+			ln.Close()
+		}
+	}
+	for i := 0; i < n; i++ {
+		name := "unknown"
+		if i < len(nameList) && nameList[i] != "" {
+			name = nameList[i]
+		}
+		if _, dup := listeners[name]; dup {
+			closeAll()
+			return nil, fmt.Errorf("duplicate socket name %q; set a distinct FileDescriptorName= per socket", name)
+		}
+
+		// FileListener dups the descriptor (close-on-exec), so the
+		// inherited one can be closed right away.
+		f := os.NewFile(uintptr(start+i), name)
+		ln, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("socket %q (fd %d): %w", name, start+i, err)
+		}
+		listeners[name] = ln
+	}
+	return listeners, nil
+}
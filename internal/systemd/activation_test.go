@@ -0,0 +1,70 @@
+package systemd
+
+import (
+	"net"
+	"strconv"
+	"syscall"
+	"testing"
+)
+
+func TestListenersNotActivated(t *testing.T) {
+	t.Setenv("LISTEN_PID", "")
+	t.Setenv("LISTEN_FDS", "")
+	lns, err := Listeners()
+	if err != nil || lns != nil {
+		t.Fatalf("got %v, %v; want nil, nil", lns, err)
+	}
+
+	// Variables meant for another process are ignored.
+	t.Setenv("LISTEN_PID", "1")
+	t.Setenv("LISTEN_FDS", "1")
+	lns, err = Listeners()
+	if err != nil || lns != nil {
+		t.Fatalf("got %v, %v; want nil, nil for foreign pid", lns, err)
+	}
+}
+
+func TestAdoptNamedListener(t *testing.T) {
+	orig, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer orig.Close()
+	f, err := orig.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// adopt takes ownership of the descriptor, like the inherited ones.
+	fd, err := syscall.Dup(int(f.Fd()))
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lns, err := adopt("1", "api", fd)
+	if err != nil {
+		t.Fatalf("adopt: %v", err)
+	}
+	ln := lns["api"]
+	if ln == nil {
+		t.Fatalf("listeners = %v, want key api", lns)
+	}
+	defer ln.Close()
+	if ln.Addr().String() != orig.Addr().String() {
+		t.Fatalf("addr = %s, want %s", ln.Addr(), orig.Addr())
+	}
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial adopted listener: %v", err)
+	}
+	conn.Close()
+}
+
+func TestAdoptRejectsBadCount(t *testing.T) {
+	if _, err := adopt("x", "", listenFDsStart); err == nil {
+		t.Fatal("expected error for non-numeric LISTEN_FDS")
+	}
+	if lns, err := adopt(strconv.Itoa(0), "", listenFDsStart); err != nil || lns != nil {
+		t.Fatalf("zero fds: got %v, %v", lns, err)
+	}
+}
@@ -0,0 +1,61 @@
+package tcpserver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// ackWriteTimeout bounds how long an acknowledgement write may block on a
+// sender that is not reading.
+const ackWriteTimeout = 10 * time.Second
+
+// errStopped is returned for acks abandoned because the server is stopping.
+var errStopped = errors.New("server stopping")
+
+// ackBatch tracks the messages of one acknowledged batch.
+//
+// With acks enabled, a sender ends a batch with an empty line (or a
+// zero-length frame on binary connections). Once every message in the
+// batch has been handled, the server writes "ACK <n>\n" on the connection,
+// n being the batch's message count. The reply is plain text even on
+// compressed streams. A sender that never sees the ack resends the batch,
+// so delivery is at-least-once.
+type ackBatch struct {
+	enabled bool
+	pending sync.WaitGroup
+	n       int
+}
+
+// add registers one message and returns its done callback, or nil when
+// acks are off.
+func (b *ackBatch) add() func() {
+	if !b.enabled {
+		return nil
+	}
+	b.pending.Add(1)
+	b.n++
+	return b.pending.Done
+}
+
+// ack waits for the batch to be handled, acknowledges it, and starts the
+// next batch.
+func (s *Server) ack(conn net.Conn, b *ackBatch) error {
+	handled := make(chan struct{})
+	go func() {
+		b.pending.Wait()
+		close(handled)
+	}()
+	select {
+	case <-handled:
+	case <-s.quit:
+		return errStopped
+	}
+
+	conn.SetWriteDeadline(time.Now().Add(ackWriteTimeout))
+	_, err := fmt.Fprintf(conn, "ACK %d\n", b.n)
+	b.n = 0
+	return err
+}
@@ -0,0 +1,84 @@
+package tcpserver
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec identifies how a connection's byte stream is encoded.
+type Codec string
+
+const (
+	CodecNone Codec = "none"
+	CodecGzip Codec = "gzip"
+	CodecZstd Codec = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// zstdMaxWindow bounds decoder memory per connection (8 MB).
+const zstdMaxWindow = 8 << 20
+
+// detectCodec inspects the first bytes of a connection without consuming
+// them. Neither magic number starts with a printable log byte other than
+// '(', so plain-text streams are only delayed when a line starts with '('
+// and fewer than four bytes have arrived.
+func detectCodec(br *bufio.Reader) (Codec, error) {
+	first, err := br.Peek(1)
+	if err != nil {
+		return CodecNone, err
+	}
+
+	switch first[0] {
+	case gzipMagic[0]:
+		if hasPrefix(br, gzipMagic) {
+			return CodecGzip, nil
+		}
+	case zstdMagic[0]:
+		if hasPrefix(br, zstdMagic) {
+			return CodecZstd, nil
+		}
+	}
+	return CodecNone, nil
+}
+
+func hasPrefix(br *bufio.Reader, magic []byte) bool {
+	peeked, err := br.Peek(len(magic))
+	if err != nil {
+		return false
+	}
+	for i := range magic {
+		if peeked[i] != magic[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// newDecoder wraps r to decode the given codec. Concatenated gzip members
+// and zstd frames are read as one stream, so shippers may flush or restart
+// compression at batch boundaries.
+func newDecoder(codec Codec, r io.Reader) (io.ReadCloser, error) {
+	switch codec {
+	case CodecGzip:
+		return gzip.NewReader(r)
+	case CodecZstd:
+		dec, err := zstd.NewReader(r,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderLowmem(true),
+			zstd.WithDecoderMaxWindow(zstdMaxWindow),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	default:
+		return io.NopCloser(r), nil
+	}
+}
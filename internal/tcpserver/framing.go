@@ -0,0 +1,149 @@
+package tcpserver
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Framing identifies how a decoded stream is split into messages.
+type Framing string
+
+const (
+	// FramingLines is newline-delimited text (JSON or plain lines).
+	FramingLines Framing = "lines"
+	// FramingOTLP is a sequence of length-prefixed binary OTLP messages.
+	FramingOTLP Framing = "otlp"
+	// FramingOctetCounting is RFC 6587 octet-counted syslog: each message
+	// is preceded by its length in ASCII digits and a space.
+	FramingOctetCounting Framing = "octet-counting"
+)
+
+// maxOctetCountDigits bounds the length prefix read while detecting
+// octet-counted framing.
+const maxOctetCountDigits = 10
+
+// DefaultMaxFrameSize is the default maximum size (in bytes) of one binary frame.
+const DefaultMaxFrameSize = 8 * 1024 * 1024 // 8MB
+
+// frameHeaderSize is the 4-byte big-endian payload length preceding each frame.
+const frameHeaderSize = 4
+
+// FrameHandler receives each binary OTLP LogsData payload. Like LineHandler
+// it may block to apply backpressure, and done follows the same rules. The
+// slice is owned by the handler.
+type FrameHandler func(frame []byte, done func())
+
+// detectFraming peeks at the first decoded bytes. Text lines never start
+// with NUL, while a length-prefixed frame under 16 MB always does, so a
+// leading zero selects binary framing for the whole connection. A stream
+// starting with digits, a space, and "<" (a syslog PRI) is octet-counted.
+func detectFraming(br *bufio.Reader) (Framing, error) {
+	first, err := br.Peek(1)
+	if err != nil {
+		return FramingLines, err
+	}
+	if first[0] == 0x00 {
+		return FramingOTLP, nil
+	}
+	if first[0] < '1' || first[0] > '9' {
+		return FramingLines, nil
+	}
+	// Peek one byte at a time so a short plain line never blocks on bytes
+	// that are not coming.
+	for n := 2; ; n++ {
+		b, err := br.Peek(n)
+		if err != nil {
+			return FramingLines, nil
+		}
+		if c := b[n-1]; c >= '0' && c <= '9' {
+			if n > maxOctetCountDigits {
+				return FramingLines, nil
+			}
+			continue
+		} else if c != ' ' {
+			return FramingLines, nil
+		}
+		if b, err := br.Peek(n + 1); err == nil && b[n] == '<' {
+			return FramingOctetCounting, nil
+		}
+		return FramingLines, nil
+	}
+}
+
+// ReadOctetCounted reads RFC 6587 octet-counted messages ("LEN SP MSG")
+// until EOF and calls handle with each message, minus any trailing
+// newline. Logplex HTTP drain bodies use the same framing.
+func ReadOctetCounted(r *bufio.Reader, maxSize int, handle func(msg string)) error {
+	for {
+		prefix, err := r.ReadString(' ')
+		if err != nil {
+			if err == io.EOF && strings.TrimSpace(prefix) != "" {
+				return fmt.Errorf("truncated octet count")
+			}
+			return err
+		}
+		// Tolerate newlines between messages from senders that add them.
+		size, err := strconv.Atoi(strings.TrimLeft(prefix[:len(prefix)-1], "\r\n"))
+		if err != nil || size <= 0 {
+			return fmt.Errorf("invalid octet count %q", strings.TrimSpace(prefix))
+		}
+		if size > maxSize {
+			return fmt.Errorf("message of %d bytes exceeds max size (%d bytes)", size, maxSize)
+		}
+		msg := make([]byte, size)
+		if _, err := io.ReadFull(r, msg); err != nil {
+			if err == io.ErrUnexpectedEOF || err == io.EOF {
+				return fmt.Errorf("truncated message: %w", io.ErrUnexpectedEOF)
+			}
+			return err
+		}
+		if text := strings.TrimRight(string(msg), "\r\n"); text != "" {
+			handle(text)
+		}
+	}
+}
+
+// readFrames reads length-prefixed frames until EOF. Zero-length frames
+// are keepalives; they also end an ack batch when endBatch is set.
+func readFrames(r io.Reader, maxFrameSize int, handle func(frame []byte), endBatch func() error) error {
+	var header [frameHeaderSize]byte
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				return fmt.Errorf("truncated frame header")
+			}
+			return err
+		}
+		size := binary.BigEndian.Uint32(header[:])
+		if size == 0 {
+			if endBatch != nil {
+				if err := endBatch(); err != nil {
+					return fmt.Errorf("ack: %w", err)
+				}
+			}
+			continue
+		}
+		if uint64(size) > uint64(maxFrameSize) {
+			return fmt.Errorf("frame of %d bytes exceeds max size (%d bytes)", size, maxFrameSize)
+		}
+		frame := make([]byte, size)
+		if _, err := io.ReadFull(r, frame); err != nil {
+			if err == io.ErrUnexpectedEOF || err == io.EOF {
+				return fmt.Errorf("truncated frame: %w", io.ErrUnexpectedEOF)
+			}
+			return err
+		}
+		handle(frame)
+	}
+}
+
+// AppendFrame appends payload to dst with its length prefix, for senders
+// and tests that produce the binary framing.
+func AppendFrame(dst, payload []byte) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(payload)))
+	return append(dst, payload...)
+}
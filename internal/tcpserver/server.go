@@ -0,0 +1,286 @@
+// Package tcpserver accepts newline-delimited log streams, RFC 6587
+// octet-counted syslog, and length-prefixed binary OTLP frames over TCP.
+package tcpserver
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxLineSize is the default maximum size (in bytes) of a single line.
+	DefaultMaxLineSize = 1024 * 1024 // 1MB
+
+	// DefaultIdleTimeout closes connections that send nothing for this long.
+	DefaultIdleTimeout = 10 * time.Minute
+
+	readBufferSize = 64 * 1024
+)
+
+// Config holds tunable parameters for the TCP server.
+type Config struct {
+	MaxLineSize  int
+	MaxFrameSize int
+	IdleTimeout  time.Duration
+	// FrameHandler receives binary OTLP frames. Connections that start
+	// with a frame are closed when it is nil.
+	FrameHandler FrameHandler
+	// Listener, when set, is served instead of listening on addr
+	// (e.g. a socket passed in by systemd).
+	Listener net.Listener
+	// Acks enables per-batch acknowledgements; see ackBatch.
+	Acks bool
+	// TLS, when set, serves TLS on the listener; set ClientCAs and
+	// ClientAuth on it to require client certificates.
+	TLS *tls.Config
+}
+
+// LineHandler receives each non-empty line. It may block to apply
+// backpressure; the connection is not read while it runs. With acks
+// enabled, done must be called once the line has been handled; otherwise
+// done is nil.
+type LineHandler func(line string, done func())
+
+// Server accepts TCP connections and splits each stream into lines,
+// octet-counted syslog messages, or, when the stream starts with a binary
+// length prefix, OTLP frames.
+// Streams may be plain or gzip/zstd compressed; encoding and framing are
+// detected per connection from its first bytes. With Config.Acks, senders
+// can have batches acknowledged (see ackBatch); octet-counted streams have
+// no batch marker and are never acknowledged.
+type Server struct {
+	addr         string
+	handle       LineHandler
+	handleFrame  FrameHandler
+	maxLineSize  int
+	maxFrameSize int
+	idleTimeout  time.Duration
+	acks         bool
+	tlsConfig    *tls.Config
+
+	listener net.Listener
+	quit     chan struct{} // closed by Stop; unblocks pending acks
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+
+	connMu sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+// NewServer creates a TCP line server that calls handle for every line.
+func NewServer(addr string, handle LineHandler, conf ...Config) *Server {
+	s := &Server{
+		addr:         addr,
+		handle:       handle,
+		maxLineSize:  DefaultMaxLineSize,
+		maxFrameSize: DefaultMaxFrameSize,
+		idleTimeout:  DefaultIdleTimeout,
+		quit:         make(chan struct{}),
+		conns:        make(map[net.Conn]struct{}),
+	}
+	if len(conf) > 0 {
+		if conf[0].MaxLineSize > 0 {
+			s.maxLineSize = conf[0].MaxLineSize
+		}
+		if conf[0].MaxFrameSize > 0 {
+			s.maxFrameSize = conf[0].MaxFrameSize
+		}
+		s.handleFrame = conf[0].FrameHandler
+		if conf[0].IdleTimeout > 0 {
+			s.idleTimeout = conf[0].IdleTimeout
+		}
+		s.listener = conf[0].Listener
+		s.acks = conf[0].Acks
+		s.tlsConfig = conf[0].TLS
+	}
+	return s
+}
+
+// Start begins listening and accepting connections in a background goroutine.
+func (s *Server) Start() error {
+	if s.listener == nil {
+		ln, err := net.Listen("tcp", s.addr)
+		if err != nil {
+			return err
+		}
+		s.listener = ln
+	}
+	if s.tlsConfig != nil {
+		// The handshake runs on the first read, under the idle timeout.
+		s.listener = tls.NewListener(s.listener, s.tlsConfig)
+	}
+
+	s.wg.Add(1)
+	go s.acceptLoop()
+	return nil
+}
+
+// Stop closes the listener and all open connections, then waits for
+// connection handlers to exit.
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.quit)
+		if s.listener != nil {
+			s.listener.Close()
+		}
+		s.connMu.Lock()
+		s.closed = true
+		for conn := range s.conns {
+			conn.Close()
+		}
+		s.connMu.Unlock()
+		s.wg.Wait()
+	})
+}
+
+// Addr returns the actual listen address (useful when port 0 is used).
+func (s *Server) Addr() string {
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+	return s.addr
+}
+
+func (s *Server) acceptLoop() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Printf("tcpserver: accept: %v", err)
+			}
+			return
+		}
+		if !s.track(conn) {
+			conn.Close()
+			return
+		}
+		s.wg.Add(1)
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) track(conn net.Conn) bool {
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	return true
+}
+
+func (s *Server) untrack(conn net.Conn) {
+	s.connMu.Lock()
+	delete(s.conns, conn)
+	s.connMu.Unlock()
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer s.wg.Done()
+	defer s.untrack(conn)
+	defer conn.Close()
+
+	br := bufio.NewReaderSize(&deadlineReader{conn: conn, timeout: s.idleTimeout}, readBufferSize)
+	codec, err := detectCodec(br)
+	if err != nil {
+		if !isClosedErr(err) {
+			log.Printf("tcpserver: %s: %v", conn.RemoteAddr(), err)
+		}
+		return
+	}
+
+	stream, err := newDecoder(codec, br)
+	if err != nil {
+		log.Printf("tcpserver: %s: %s stream: %v", conn.RemoteAddr(), codec, err)
+		return
+	}
+	defer stream.Close()
+
+	decoded := bufio.NewReaderSize(stream, readBufferSize)
+	framing, err := detectFraming(decoded)
+	if err != nil {
+		if !isClosedErr(err) {
+			log.Printf("tcpserver: %s: %s stream: %v", conn.RemoteAddr(), codec, err)
+		}
+		return
+	}
+	if framing == FramingOTLP {
+		s.readFrames(conn, codec, decoded)
+		return
+	}
+	if framing == FramingOctetCounting {
+		handle := func(msg string) { s.handle(msg, nil) }
+		if err := ReadOctetCounted(decoded, s.maxLineSize, handle); err != nil && !isClosedErr(err) {
+			log.Printf("tcpserver: %s: %s octet-counted stream: %v, closing connection", conn.RemoteAddr(), codec, err)
+		}
+		return
+	}
+
+	batch := &ackBatch{enabled: s.acks}
+	scanner := bufio.NewScanner(decoded)
+	scanner.Buffer(make([]byte, 0, readBufferSize), s.maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if s.acks {
+				if err := s.ack(conn, batch); err != nil {
+					if !isClosedErr(err) {
+						log.Printf("tcpserver: %s: ack: %v", conn.RemoteAddr(), err)
+					}
+					return
+				}
+			}
+			continue
+		}
+		s.handle(line, batch.add())
+	}
+	if err := scanner.Err(); err != nil && !isClosedErr(err) {
+		if errors.Is(err, bufio.ErrTooLong) {
+			log.Printf("tcpserver: %s: line exceeded max size (%d bytes), closing connection", conn.RemoteAddr(), s.maxLineSize)
+			return
+		}
+		log.Printf("tcpserver: %s: %s stream: %v", conn.RemoteAddr(), codec, err)
+	}
+}
+
+func (s *Server) readFrames(conn net.Conn, codec Codec, r io.Reader) {
+	if s.handleFrame == nil {
+		log.Printf("tcpserver: %s: binary OTLP frames are not accepted here, closing connection", conn.RemoteAddr())
+		return
+	}
+	batch := &ackBatch{enabled: s.acks}
+	handle := func(frame []byte) { s.handleFrame(frame, batch.add()) }
+	var endBatch func() error
+	if s.acks {
+		endBatch = func() error { return s.ack(conn, batch) }
+	}
+	if err := readFrames(r, s.maxFrameSize, handle, endBatch); err != nil && !isClosedErr(err) {
+		log.Printf("tcpserver: %s: %s OTLP frames: %v, closing connection", conn.RemoteAddr(), codec, err)
+	}
+}
+
+// deadlineReader extends the connection's read deadline before every read,
+// so only idle connections time out.
+type deadlineReader struct {
+	conn    net.Conn
+	timeout time.Duration
+}
+
+func (r *deadlineReader) Read(p []byte) (int, error) {
+	r.conn.SetReadDeadline(time.Now().Add(r.timeout))
+	return r.conn.Read(p)
+}
+
+func isClosedErr(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || errors.Is(err, errStopped) ||
+		(errors.As(err, &netErr) && netErr.Timeout())
+}
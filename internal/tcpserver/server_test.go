@@ -0,0 +1,457 @@
+package tcpserver
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+type lineCollector struct {
+	mu    sync.Mutex
+	lines []string
+	added chan struct{}
+}
+
+func newLineCollector() *lineCollector {
+	return &lineCollector{added: make(chan struct{}, 100)}
+}
+
+func (c *lineCollector) handle(line string, done func()) {
+	c.mu.Lock()
+	c.lines = append(c.lines, line)
+	c.mu.Unlock()
+	c.added <- struct{}{}
+	if done != nil {
+		done()
+	}
+}
+
+func (c *lineCollector) wait(t *testing.T, n int) []string {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for i := 0; i < n; i++ {
+		select {
+		case <-c.added:
+		case <-deadline:
+			t.Fatalf("timed out waiting for %d lines", n)
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.lines...)
+}
+
+func sendStream(t *testing.T, payload []byte) {
+	t.Helper()
+	collector := newLineCollector()
+	srv := NewServer("127.0.0.1:0", collector.handle)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Stop()
+
+	conn, err := net.Dial("tcp", srv.Addr())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	if _, err := conn.Write(payload); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	conn.Close()
+
+	lines := collector.wait(t, 3)
+	want := []string{`{"msg":"one"}`, `{"msg":"two"}`, `{"msg":"three"}`}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("lines = %q, want %q", lines, want)
+		}
+	}
+}
+
+const sampleLines = "{\"msg\":\"one\"}\n\n{\"msg\":\"two\"}\n{\"msg\":\"three\"}\n"
+
+func TestServer_PlainLines(t *testing.T) {
+	t.Parallel()
+	sendStream(t, []byte(sampleLines))
+}
+
+func TestServer_GzipStream(t *testing.T) {
+	t.Parallel()
+
+	// Two gzip members, as written by a shipper that restarts compression per batch.
+	var buf bytes.Buffer
+	for _, part := range []string{"{\"msg\":\"one\"}\n\n{\"msg\":\"two\"}\n", "{\"msg\":\"three\"}\n"} {
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(part))
+		zw.Close()
+	}
+	sendStream(t, buf.Bytes())
+}
+
+func TestServer_ZstdStream(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("zstd writer: %v", err)
+	}
+	zw.Write([]byte(sampleLines))
+	zw.Close()
+	sendStream(t, buf.Bytes())
+}
+
+func TestServer_PlainLineStartingWithParen(t *testing.T) {
+	t.Parallel()
+
+	collector := newLineCollector()
+	srv := NewServer("127.0.0.1:0", collector.handle)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Stop()
+
+	conn, err := net.Dial("tcp", srv.Addr())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	conn.Write([]byte("(startup) ready\n"))
+
+	if lines := collector.wait(t, 1); lines[0] != "(startup) ready" {
+		t.Fatalf("line = %q", lines[0])
+	}
+}
+
+func TestServer_BinaryFrames(t *testing.T) {
+	t.Parallel()
+
+	frames := make(chan []byte, 4)
+	lines := newLineCollector()
+	srv := NewServer("127.0.0.1:0", lines.handle, Config{
+		FrameHandler: func(frame []byte, _ func()) { frames <- frame },
+	})
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Stop()
+
+	// Frames are detected after decompression, so compressed binary works too.
+	var raw []byte
+	raw = AppendFrame(raw, []byte("first"))
+	raw = AppendFrame(raw, nil) // keepalive
+	raw = AppendFrame(raw, []byte("second"))
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("zstd writer: %v", err)
+	}
+	zw.Write(raw)
+	zw.Close()
+
+	conn, err := net.Dial("tcp", srv.Addr())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	conn.Write(buf.Bytes())
+	conn.Close()
+
+	for _, want := range []string{"first", "second"} {
+		select {
+		case got := <-frames:
+			if string(got) != want {
+				t.Fatalf("frame = %q, want %q", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for frame %q", want)
+		}
+	}
+}
+
+func TestReadFrames_Limits(t *testing.T) {
+	t.Parallel()
+
+	oversized := AppendFrame(nil, make([]byte, 64))
+	if err := readFrames(bytes.NewReader(oversized), 32, func([]byte) {}, nil); err == nil {
+		t.Fatal("expected error for oversized frame")
+	}
+
+	truncated := AppendFrame(nil, []byte("payload"))
+	truncated = truncated[:len(truncated)-2]
+	if err := readFrames(bytes.NewReader(truncated), DefaultMaxFrameSize, func([]byte) {}, nil); err == nil {
+		t.Fatal("expected error for truncated frame")
+	}
+}
+
+func TestServer_AcksBatchAfterHandled(t *testing.T) {
+	t.Parallel()
+
+	handled := make(chan func(), 4)
+	srv := NewServer("127.0.0.1:0", func(_ string, done func()) { handled <- done }, Config{Acks: true})
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Stop()
+
+	conn, err := net.Dial("tcp", srv.Addr())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte("first\nsecond\n\n")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var dones []func()
+	for range 2 {
+		select {
+		case done := <-handled:
+			dones = append(dones, done)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for lines")
+		}
+	}
+
+	// Nothing is acknowledged while a line is still in flight.
+	dones[0]()
+	reply := make([]byte, 16)
+	conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if n, err := conn.Read(reply); err == nil {
+		t.Fatalf("got %q before the batch was handled", reply[:n])
+	}
+
+	dones[1]()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(reply)
+	if err != nil {
+		t.Fatalf("read ack: %v", err)
+	}
+	if got := string(reply[:n]); got != "ACK 2\n" {
+		t.Fatalf("ack = %q, want %q", got, "ACK 2\n")
+	}
+}
+
+// recordingSink stands in for the insert buffer, which journals records in Add.
+type recordingSink struct {
+	mu      sync.Mutex
+	records []*model.LogRecord
+}
+
+func (s *recordingSink) Add(record *model.LogRecord) {
+	s.mu.Lock()
+	s.records = append(s.records, record)
+	s.mu.Unlock()
+}
+
+func (s *recordingSink) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records)
+}
+
+func TestServer_AcksHeldMultilineRecordOnceStored(t *testing.T) {
+	t.Parallel()
+
+	rules, err := ingest.MultilineRules(map[string]ingest.MultilineRule{"tcp": {Timeout: time.Hour}})
+	if err != nil {
+		t.Fatalf("MultilineRules: %v", err)
+	}
+	sink := &recordingSink{}
+	proc := ingest.NewProcessor(sink, "tcp", ingest.Config{Multiline: rules})
+	handle := func(line string, done func()) {
+		proc.ProcessEnvelope(model.IngestEnvelope{Source: "tcp", Line: line, Done: done})
+	}
+	srv := NewServer("127.0.0.1:0", handle, Config{Acks: true})
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Stop()
+
+	conn, err := net.Dial("tcp", srv.Addr())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte("level=error msg=boom\n  at main.go:12\n\n")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// The record waits for more continuation lines, so it is not stored
+	// and the batch is not acknowledged.
+	reply := make([]byte, 16)
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	if n, err := conn.Read(reply); err == nil {
+		t.Fatalf("got %q before the held record was stored", reply[:n])
+	}
+	if n := sink.len(); n != 0 {
+		t.Fatalf("stored %d records, want the record still held", n)
+	}
+
+	proc.Flush()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(reply)
+	if err != nil {
+		t.Fatalf("read ack: %v", err)
+	}
+	if got := string(reply[:n]); got != "ACK 2\n" {
+		t.Fatalf("ack = %q, want %q", got, "ACK 2\n")
+	}
+	if n := sink.len(); n != 1 {
+		t.Fatalf("stored %d records, want 1", n)
+	}
+}
+
+func TestServer_OctetCountedSyslog(t *testing.T) {
+	t.Parallel()
+
+	collector := newLineCollector()
+	srv := NewServer("127.0.0.1:0", collector.handle)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Stop()
+
+	conn, err := net.Dial("tcp", srv.Addr())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	msgs := []string{
+		"<190>1 2024-01-01T00:00:00+00:00 host app web.1 - first\nline",
+		"<190>1 2024-01-01T00:00:01+00:00 host app web.1 - second",
+	}
+	for _, msg := range msgs {
+		fmt.Fprintf(conn, "%d %s", len(msg), msg)
+	}
+
+	lines := collector.wait(t, 2)
+	for i := range msgs {
+		if lines[i] != msgs[i] {
+			t.Fatalf("messages = %q, want %q", lines, msgs)
+		}
+	}
+}
+
+func TestDetectFraming_DigitsWithoutPRIAreLines(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"200 OK\n", "12345\n", "7 <", "1234567890123 <x"} {
+		framing, _ := detectFraming(bufio.NewReader(strings.NewReader(input)))
+		want := FramingLines
+		if input == "7 <" {
+			want = FramingOctetCounting
+		}
+		if framing != want {
+			t.Errorf("detectFraming(%q) = %s, want %s", input, framing, want)
+		}
+	}
+}
+
+func TestReadOctetCounted_Errors(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]string{
+		"6 <1>ab":   "truncated message",
+		"x <1>a":    "invalid octet count",
+		"99 <1>a":   "exceeds max size",
+		"3 <1>\n12": "truncated octet count",
+	} {
+		err := ReadOctetCounted(bufio.NewReader(strings.NewReader(input)), 10, func(string) {})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ReadOctetCounted(%q) = %v, want %q", input, err, want)
+		}
+	}
+}
+
+// issueCert returns a certificate for 127.0.0.1 signed by parent (self
+// signed when parent is nil), usable as a CA, server, or client.
+func issueCert(t *testing.T, parent *tls.Certificate) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "tiny-telemetry test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	signer, signerKey := tmpl, any(key)
+	if parent != nil {
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func TestServer_MutualTLS(t *testing.T) {
+	t.Parallel()
+	ca := issueCert(t, nil)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Leaf)
+
+	collector := newLineCollector()
+	srv := NewServer("127.0.0.1:0", collector.handle, Config{TLS: &tls.Config{
+		Certificates: []tls.Certificate{issueCert(t, &ca)},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}})
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Stop()
+
+	// Without a client certificate the handshake fails and nothing is read.
+	if conn, err := tls.Dial("tcp", srv.Addr(), &tls.Config{RootCAs: pool}); err == nil {
+		conn.Write([]byte("rejected\n"))
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		if _, err := conn.Read(make([]byte, 1)); err == nil {
+			t.Fatal("server answered a client without a certificate")
+		}
+		conn.Close()
+	}
+
+	conn, err := tls.Dial("tcp", srv.Addr(), &tls.Config{RootCAs: pool, Certificates: []tls.Certificate{issueCert(t, &ca)}})
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	if _, err := conn.Write([]byte(sampleLines)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	conn.Close()
+
+	lines := collector.wait(t, 3)
+	if strings.Join(lines, ",") != `{"msg":"one"},{"msg":"two"},{"msg":"three"}` {
+		t.Fatalf("lines = %q", lines)
+	}
+}
@@ -0,0 +1,85 @@
+package timestamp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// strptimeDirectives maps strptime conversions to Go layout elements.
+var strptimeDirectives = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'j': "002",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'f': "999999999", // digits after "%S." or "%S,"
+	'p': "PM",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'z': "-0700",
+	'Z': "MST",
+	'T': "15:04:05",
+	'D': "01/02/06",
+	'F': "2006-01-02",
+	'%': "%",
+}
+
+// Layout converts a user-supplied timestamp format to a Go time layout.
+// Formats containing "%" are read as strptime (e.g. "%d/%m/%Y %H:%M:%S");
+// anything else must already be a Go layout (e.g. "02.01.2006 15:04:05").
+// A layout without any date or time element is rejected.
+func Layout(format string) (string, error) {
+	layout := format
+	if strings.Contains(format, "%") {
+		var b strings.Builder
+		for i := 0; i < len(format); i++ {
+			if format[i] != '%' {
+				b.WriteByte(format[i])
+				continue
+			}
+			if i+1 == len(format) {
+				return "", fmt.Errorf("timestamp format %q: trailing %%", format)
+			}
+			i++
+			elem, ok := strptimeDirectives[format[i]]
+			if !ok {
+				return "", fmt.Errorf("timestamp format %q: unsupported directive %%%c", format, format[i])
+			}
+			b.WriteString(elem)
+		}
+		layout = b.String()
+	}
+
+	// A probe time differing from the reference time in every element
+	// formats differently from the layout unless nothing is substituted.
+	probe := time.Date(2011, time.November, 12, 13, 14, 16, 0, time.UTC)
+	if probe.Format(layout) == layout {
+		return "", fmt.Errorf("timestamp format %q: no date or time elements", format)
+	}
+	if _, err := time.Parse(layout, probe.Format(layout)); err != nil {
+		return "", fmt.Errorf("timestamp format %q: %w", format, err)
+	}
+	return layout, nil
+}
+
+// Layouts converts each format with Layout.
+func Layouts(formats []string) ([]string, error) {
+	layouts := make([]string, 0, len(formats))
+	for _, format := range formats {
+		layout, err := Layout(format)
+		if err != nil {
+			return nil, err
+		}
+		layouts = append(layouts, layout)
+	}
+	return layouts, nil
+}
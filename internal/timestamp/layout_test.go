@@ -0,0 +1,48 @@
+package timestamp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%d/%m/%Y %H:%M:%S", "02/01/2006 15:04:05"},
+		{"%Y-%m-%dT%H:%M:%S.%f%z", "2006-01-02T15:04:05.999999999-0700"},
+		{"%b %e %T", "Jan _2 15:04:05"},
+		{"02.01.2006 15:04:05", "02.01.2006 15:04:05"},
+	}
+	for _, tt := range tests {
+		got, err := Layout(tt.format)
+		if err != nil || got != tt.want {
+			t.Errorf("Layout(%q) = %q, %v; want %q", tt.format, got, err, tt.want)
+		}
+	}
+
+	for _, format := range []string{"%Q", "%Y-%m-%", "no time here"} {
+		if _, err := Layout(format); err == nil {
+			t.Errorf("Layout(%q) should fail", format)
+		}
+	}
+}
+
+func TestNewParser_ExtraLayouts(t *testing.T) {
+	layouts, err := Layouts([]string{"%d/%m/%Y %H:%M:%S"})
+	if err != nil {
+		t.Fatalf("Layouts: %v", err)
+	}
+
+	if _, ok := NewParser().ParseTimestamp("13/02/2024 08:30:00"); ok {
+		t.Fatal("built-in layouts should not parse day-first dates")
+	}
+	ts, ok := NewParser(layouts...).ParseTimestamp("13/02/2024 08:30:00")
+	if !ok {
+		t.Fatal("extra layout did not parse")
+	}
+	if want := time.Date(2024, time.February, 13, 8, 30, 0, 0, time.UTC); !ts.Equal(want) {
+		t.Fatalf("ParseTimestamp = %s, want %s", ts, want)
+	}
+}
@@ -33,12 +33,13 @@ type ParseResult struct {
 	Remaining string // Text with timestamp removed (for log message extraction)
 }
 
-// NewParser creates a new timestamp parser
-func NewParser() *Parser {
+// NewParser creates a new timestamp parser. Extra Go layouts (see Layout)
+// are tried before the built-in ones.
+func NewParser(extraLayouts ...string) *Parser {
 	return &Parser{
 		// Ordered list of timestamp layouts for parsing
 		// Most common formats first for better performance
-		layouts: []string{
+		layouts: append(append([]string(nil), extraLayouts...),
 			// ISO 8601 and RFC3339 variants
 			time.RFC3339Nano,
 			time.RFC3339,
@@ -92,7 +93,7 @@ func NewParser() *Parser {
 			"15:04:05,000000000", // International comma format
 			"15:04:05,000000",
 			"15:04:05,000",
-		},
+		),
 	}
 }
 
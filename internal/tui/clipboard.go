@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// noticeShown is how long the status bar shows a notice.
+const noticeShown = 5 * time.Second
+
+// clipboardMsg reports the outcome of a copy to the clipboard.
+type clipboardMsg struct {
+	what string // what was copied, for the status bar
+	err  error
+}
+
+// copyToClipboard copies text with an OSC 52 sequence, which reaches the
+// clipboard of the terminal showing the TUI even over SSH, and with the
+// system clipboard (pbcopy, wl-copy, xclip, xsel, or Windows') when there
+// is one, for terminals that ignore OSC 52. Mouse capture keeps the
+// terminal's own selection from working, so this is the way to copy.
+func copyToClipboard(what, text string) tea.Cmd {
+	return func() tea.Msg {
+		seq := osc52.New(text)
+		switch {
+		case os.Getenv("TMUX") != "":
+			seq = seq.Tmux()
+		case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+			seq = seq.Screen()
+		}
+		_, oscErr := seq.WriteTo(os.Stderr)
+
+		nativeErr := errors.New("no clipboard tool found")
+		if !clipboard.Unsupported {
+			nativeErr = clipboard.WriteAll(text)
+		}
+		if oscErr != nil && nativeErr != nil {
+			return clipboardMsg{what: what, err: nativeErr}
+		}
+		return clipboardMsg{what: what}
+	}
+}
+
+// handleClipboard shows the outcome of a copy in the status bar.
+func (m *DashboardModel) handleClipboard(msg clipboardMsg) {
+	m.notice, m.noticeErr, m.noticeAt = "Copied "+msg.what, false, time.Now()
+	if msg.err != nil {
+		m.notice, m.noticeErr = "Copy failed: "+msg.err.Error(), true
+	}
+}
+
+// logRecordJSON returns r's raw line when it is JSON, or r encoded as an
+// NDJSON export writes it.
+func logRecordJSON(r model.LogRecord) string {
+	if raw := strings.TrimSpace(r.RawLine); raw != "" && json.Valid([]byte(raw)) {
+		return raw
+	}
+	data, _ := json.Marshal(toExportLog(r))
+	return string(data)
+}
+
+// plainText strips styling and box borders from rendered output, leaving
+// the text a reader sees, with blank lines around it dropped.
+func plainText(rendered string) string {
+	var lines []string
+	for _, line := range strings.Split(ansi.Strip(rendered), "\n") {
+		if strings.Trim(line, "╭╮╰╯─│┌┐└┘ ") == "" {
+			lines = append(lines, "")
+			continue
+		}
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(line, "│")
+		line = strings.TrimSuffix(line, "│")
+		lines = append(lines, strings.TrimRight(strings.TrimPrefix(line, " "), " "))
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// selectedLog returns the selected log entry, if any.
+func (m *DashboardModel) selectedLog() (model.LogRecord, bool) {
+	if m.selectedLogIndex < 0 || m.selectedLogIndex >= len(m.logEntries) {
+		return model.LogRecord{}, false
+	}
+	return m.logEntries[m.selectedLogIndex], true
+}
+
+// copySelectedLog copies the selected log's message, or its JSON.
+func (m *DashboardModel) copySelectedLog(asJSON bool) tea.Cmd {
+	entry, ok := m.selectedLog()
+	if !ok {
+		return nil
+	}
+	if asJSON {
+		return copyToClipboard("log JSON", logRecordJSON(entry))
+	}
+	return copyToClipboard("log message", entry.Message)
+}
+
+// copyActiveDeck copies the active deck as it is rendered, as plain text.
+func (m *DashboardModel) copyActiveDeck() tea.Cmd {
+	if m.activeDeckIdx < 0 || m.activeDeckIdx >= len(m.decks) {
+		return nil
+	}
+	d := m.decks[m.activeDeckIdx]
+	selIdx := 0
+	if m.activeDeckIdx < len(m.deckSelIdx) {
+		selIdx = m.deckSelIdx[m.activeDeckIdx]
+	}
+	ctx := m.viewContext()
+	text := plainText(d.Render(ctx, max(80, m.width-2), min(d.ContentLines(ctx), 100)+3, false, selIdx))
+	return copyToClipboard(d.Title(), text)
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLogRecordJSON(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if got := logRecordJSON(model.LogRecord{Message: "ok", RawLine: ` {"msg":"ok"} `}); got != `{"msg":"ok"}` {
+		t.Errorf("JSON raw line = %q, want it as received", got)
+	}
+	got := logRecordJSON(model.LogRecord{Timestamp: ts, Level: "INFO", Message: "plain", RawLine: "plain"})
+	if want := `{"timestamp":"2026-03-01T12:00:00Z","level":"INFO","level_num":0,"message":"plain","raw_line":"plain","service":"","hostname":"","pid":0,"attributes":null,"source":"","app":""}`; got != want {
+		t.Errorf("text raw line = %s, want %s", got, want)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	t.Parallel()
+
+	rendered := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Width(20).Render(
+		lipgloss.NewStyle().Foreground(ColorBlue).Render("Top Hosts") + "\nweb1   42\nweb2    7")
+	if got, want := plainText(rendered), "Top Hosts\nweb1   42\nweb2    7"; got != want {
+		t.Fatalf("plainText = %q, want %q", got, want)
+	}
+}
+
+func TestCopyTargets(t *testing.T) {
+	t.Parallel()
+
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	if m.copySelectedLog(false) != nil {
+		t.Fatal("copy with no log selected returned a command")
+	}
+	m.logEntries = []model.LogRecord{{Message: "payment failed"}}
+	m.selectedLogIndex = 0
+	if m.copySelectedLog(false) == nil || m.copySelectedLog(true) == nil {
+		t.Fatal("copy of the selected log returned no command")
+	}
+
+	m.handleClipboard(clipboardMsg{what: "log message"})
+	if m.notice != "Copied log message" || m.noticeErr {
+		t.Fatalf("notice = %q (err %v)", m.notice, m.noticeErr)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 )
 
 // renderBranding renders "Tiny Telemetry!" with a green to light blue gradient
@@ -108,7 +109,7 @@ func (m *DashboardModel) renderStatusLine() string {
 		} else if medium {
 			statusText = "?: Help • ↑↓: Navigate • Home/End • PgUp/Dn • Enter: Details • []: View"
 		} else {
-			statusText = "?: Help • Wheel: scroll • ↑↓: Navigate • Home: Top • End: Latest • PgUp/PgDn: Page • []: Switch view • Enter: Details"
+			statusText = "?: Help • Wheel: scroll • ↑↓: Navigate • Home: Top • End: Latest • PgUp/PgDn: Page • []: Switch view • m: Pin • y: Copy • W: Workspace • Enter: Details"
 		}
 	} else if m.HasModal() {
 		statusText = "ESC: Close"
@@ -135,7 +136,9 @@ func (m *DashboardModel) renderStatusLine() string {
 	}
 
 	if !m.filterActive && !m.searchActive && !m.HasModal() {
-		if m.liveUpdatesPaused() {
+		if m.tailMode && !m.viewPaused {
+			statusInfo = "▶ Live tail"
+		} else if m.liveUpdatesPaused() {
 			if m.viewPaused {
 				statusInfo = "⏸ Manual"
 			} else {
@@ -153,7 +156,11 @@ func (m *DashboardModel) renderStatusLine() string {
 
 	// Add data source connectivity indicator
 	var dataSourceInfo string
-	if m.dataSource != "" && !veryNarrow {
+	lost := reconnecting(m.store)
+	if lost {
+		dot := lipgloss.NewStyle().Background(ColorNavy).Foreground(lipgloss.Color("#FFAA00")).Render("●")
+		dataSourceInfo = dot + " reconnecting…"
+	} else if m.dataSource != "" && !veryNarrow {
 		var dot string
 		stale := time.Since(m.lastTickAt) > 3*m.updateInterval
 		if !m.lastTickOK {
@@ -178,7 +185,7 @@ func (m *DashboardModel) renderStatusLine() string {
 
 	// Add DB error indicator (auto-clears after 30s)
 	var dbErrorInfo string
-	if m.lastError != "" && time.Since(m.lastErrorAt) < 30*time.Second {
+	if !lost && m.lastError != "" && time.Since(m.lastErrorAt) < 30*time.Second {
 		dbErrorStyle := lipgloss.NewStyle().
 			Background(ColorNavy).
 			Foreground(lipgloss.Color("#FF6666")).
@@ -186,8 +193,34 @@ func (m *DashboardModel) renderStatusLine() string {
 		dbErrorInfo = dbErrorStyle.Render("DB error")
 	}
 
+	// Add the last notice (auto-clears after noticeShown)
+	var noticeInfo string
+	if m.notice != "" && time.Since(m.noticeAt) < noticeShown {
+		noticeStyle := lipgloss.NewStyle().Background(ColorNavy).Foreground(lipgloss.Color("#44FF44"))
+		if m.noticeErr {
+			noticeStyle = noticeStyle.Foreground(lipgloss.Color("#FF6666"))
+		}
+		noticeInfo = noticeStyle.Render(truncatePreview(m.notice, 40))
+	}
+
+	// Add the last server event (auto-clears like the DB error)
+	var serverEventInfo string
+	if !veryNarrow && m.lastServerEvent.Message != "" && time.Since(m.lastServerEventAt) < serverEventShown {
+		eventStyle := lipgloss.NewStyle().Background(ColorNavy).Foreground(lipgloss.Color("#AAAAFF"))
+		if kind := m.lastServerEvent.Kind; kind == model.EventStoreError || kind == model.EventBackupFailed || kind == model.EventAlertFiring {
+			eventStyle = eventStyle.Foreground(lipgloss.Color("#FF6666"))
+		}
+		serverEventInfo = eventStyle.Render(truncatePreview(m.lastServerEvent.Message, 40))
+	}
+
 	// Combine status info, timestamp mode, and version update
 	var rightParts []string
+	if noticeInfo != "" {
+		rightParts = append(rightParts, noticeInfo)
+	}
+	if serverEventInfo != "" {
+		rightParts = append(rightParts, serverEventInfo)
+	}
 	if dbErrorInfo != "" {
 		rightParts = append(rightParts, dbErrorInfo)
 	}
@@ -273,35 +306,56 @@ func (m *DashboardModel) renderFilter() string {
 		title = "🔍 Filter (editing)"
 		content = m.filterInput.View()
 		styleColor = ColorGreen
-		if m.filterRegex != nil {
+		if m.filterErr == nil && m.filterRegex != nil {
 			content += fmt.Sprintf(" | Showing: %d/%d entries", len(m.logEntries), m.currentTotalLogs())
 		}
+		if len(m.filterSuggestions) > 0 {
+			content += " | Tab: " + strings.Join(m.filterSuggestions, " · ")
+		}
+		content += " | Ctrl+E: expand"
+		if m.filterErr != nil {
+			content += lipgloss.NewStyle().Foreground(ColorRed).Render(" ⚠ " + m.filterErr.Error())
+		}
 	} else if m.searchActive {
 		// Actively editing search
 		title = "🔎 Search (editing)"
 		content = m.searchInput.View()
 		styleColor = ColorYellow
-		if m.searchTerm != "" {
-			content += fmt.Sprintf(" | Highlighting: %q", m.searchTerm)
+		if len(m.highlightTerms) > 0 {
+			content += " | " + m.highlightLegend()
 		}
-	} else if m.filterRegex != nil || m.filterInput.Value() != "" {
-		// Filter applied but not editing - show the filter value
+		content += " | Enter: add term"
+	} else if m.filterRegex != nil || m.filterInput.Value() != "" || len(m.facets) > 0 {
+		// Filter applied but not editing - show the filter value and facets
 		title = "🔍 Filter"
 		content = fmt.Sprintf("[%s]", m.filterInput.Value())
+		if len(m.facets) > 0 {
+			content += " " + facetsLabel(m.facets)
+		}
 		styleColor = ColorGreen
 		content += fmt.Sprintf(" | Showing: %d/%d entries", len(m.logEntries), m.currentTotalLogs())
 		content += " | Press '/' to edit"
-	} else if m.searchTerm != "" || m.searchInput.Value() != "" {
-		// Search applied but not editing - show the search term
+		if len(m.highlightTerms) > 0 {
+			content += " | " + m.highlightLegend()
+		}
+	} else if m.searchTerm != "" || m.searchInput.Value() != "" || len(m.highlightTerms) > 0 {
+		// Search applied but not editing - show the search term and the
+		// highlight terms in their colors
 		title = "🔎 Search"
+		styleColor = ColorYellow
 		searchValue := m.searchTerm
 		if searchValue == "" {
 			searchValue = m.searchInput.Value()
 		}
-		content = fmt.Sprintf("[%s]", searchValue)
-		styleColor = ColorYellow
-		content += fmt.Sprintf(" | Highlighting: %q", searchValue)
-		content += " | Press 's' to edit"
+		if searchValue != "" {
+			content = fmt.Sprintf("[%s]", searchValue)
+			content += fmt.Sprintf(" | Highlighting: %q", searchValue)
+			content += " | "
+		}
+		if len(m.highlightTerms) > 0 {
+			content += m.highlightLegend() + " | S: toggle terms | "
+		}
+		content += "Press 's' to add a term"
 	} else {
 		// Nothing active or applied
 		return ""
@@ -341,18 +395,28 @@ func (m *DashboardModel) clampInstructionsScroll(availableLines int) {
 func (m *DashboardModel) renderLogScrollContent(height int, logWidth int) []string {
 	var logLines []string
 
-	// Add focus-lock indicator and help text when log section is active.
-	if m.activeSection == SectionLogs {
+	// Tail mode shows whether it follows, or how much arrived below.
+	if m.tailMode {
+		logLines = append(logLines, m.renderTailStatusLine())
+		height--
+	} else if m.activeSection == SectionLogs {
+		// Add focus-lock indicator and help text when log section is active.
 		pausedStyle := lipgloss.NewStyle().
 			Foreground(ColorYellow).
 			Bold(true)
 		statusLine := pausedStyle.Render("Focus lock on: live updates paused while reading logs • Tab/click away to resume")
+		if s := m.scrollbackStatus(); s != "" {
+			statusLine = pausedStyle.Render("Scrollback: " + s + " • Tab/click away to resume")
+		}
 		logLines = append(logLines, statusLine)
 		height-- // Reduce available height for logs
 	}
 
 	// Add column headers when columns are enabled
-	if m.showColumns {
+	if tmpl := m.activeLogTemplate(); tmpl != nil && m.showColumns {
+		logLines = append(logLines, tmpl.Header(logWidth))
+		height--
+	} else if m.showColumns {
 		timestampHeader := lipgloss.NewStyle().Foreground(ColorWhite).Render("Time    ")
 		severityHeader := lipgloss.NewStyle().Foreground(ColorWhite).Render("Level")
 
@@ -381,7 +445,9 @@ func (m *DashboardModel) renderLogScrollContent(height int, logWidth int) []stri
 	}
 
 	// When in log section or log viewer modal, don't auto-scroll to latest
-	if m.activeSection != SectionLogs && !m.isLogViewerOpen() && len(m.logEntries) > maxLines {
+	if m.tailMode && m.logAutoScroll && len(m.logEntries) > maxLines {
+		startIdx = len(m.logEntries) - maxLines
+	} else if m.activeSection != SectionLogs && !m.isLogViewerOpen() && len(m.logEntries) > maxLines {
 		startIdx = len(m.logEntries) - maxLines
 	} else if m.activeSection == SectionLogs || m.isLogViewerOpen() {
 		// Keep selected log in view
@@ -528,10 +594,22 @@ func (m *DashboardModel) buildFilterStatus() []string {
 		}
 	}
 
+	if len(m.facets) > 0 {
+		filters = append(filters, "  • Facets: "+facetsLabel(m.facets))
+	}
+
 	// Check search term
 	if m.searchTerm != "" {
 		filters = append(filters, "  • Search highlight: "+m.searchTerm)
 	}
+	if len(m.highlightTerms) > 0 {
+		filters = append(filters, "  • Highlight terms: "+m.highlightLegend())
+	}
+
+	// Check heatmap drill-down window
+	if !m.timeWindow.IsZero() {
+		filters = append(filters, "  • Time window: "+m.timeWindowLabel())
+	}
 
 	// Add instructions for clearing filters if any are active
 	if len(filters) > 0 {
@@ -543,9 +621,18 @@ func (m *DashboardModel) buildFilterStatus() []string {
 		if m.filterRegex != nil {
 			filters = append(filters, "    • / → Backspace/Delete → Enter (clear regex)")
 		}
+		if len(m.facets) > 0 {
+			filters = append(filters, "    • Esc (clear facets, regex and search)")
+		}
 		if m.searchTerm != "" {
 			filters = append(filters, "    • s → Backspace/Delete → Enter (clear search)")
 		}
+		if len(m.highlightTerms) > 0 {
+			filters = append(filters, "    • S → d (remove a highlight term)")
+		}
+		if !m.timeWindow.IsZero() {
+			filters = append(filters, "    • f → t (show all time)")
+		}
 	}
 
 	return filters
@@ -0,0 +1,279 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// minDeckRefresh is the shortest refresh interval a configured deck may
+// ask for.
+const minDeckRefresh = 500 * time.Millisecond
+
+// PageConfig declares a page of the TUI config's pages list. Its views are
+// shown after the built-in pages, or added to the built-in page of the
+// same id.
+type PageConfig struct {
+	ID    string       `mapstructure:"id"`
+	Title string       `mapstructure:"title"`
+	Views []ViewConfig `mapstructure:"views"`
+}
+
+// ViewConfig declares a view of a configured page and its decks.
+type ViewConfig struct {
+	ID    string       `mapstructure:"id"`
+	Title string       `mapstructure:"title"`
+	Decks []DeckConfig `mapstructure:"decks"`
+}
+
+// DeckConfig declares a deck of a configured view: a built-in deck type
+// with its own title, query scope, refresh interval, and size.
+type DeckConfig struct {
+	Type    string        `mapstructure:"type"`    // a key of configDeckTypes
+	Title   string        `mapstructure:"title"`   // "" = the type's title
+	App     string        `mapstructure:"app"`     // query this app instead of the sidebar's
+	Window  time.Duration `mapstructure:"window"`  // query only the last Window; 0 = all time
+	Refresh time.Duration `mapstructure:"refresh"` // tick interval; 0 = the type's
+	Size    string        `mapstructure:"size"`    // "quarter" or "full"; "" = the type's
+}
+
+// configDeckTypes builds the decks a config may name, by type.
+var configDeckTypes = map[string]func(deps DeckDeps) Deck{
+	"words": func(DeckDeps) Deck { return NewWordsDeck() },
+	"attributes": func(deps DeckDeps) Deck {
+		return NewAttributesDeck(deps.Store, deps.FormatAttrModal, deps.PushContentModal)
+	},
+	"patterns":           func(deps DeckDeps) Deck { return NewPatternsDeck(deps.Drain3Manager, deps.PushPatternsModal) },
+	"counts":             func(deps DeckDeps) Deck { return NewCountsDeck(deps.PushCountsModal) },
+	"volume":             func(DeckDeps) Deck { return NewVolumeDeck() },
+	"severity":           func(deps DeckDeps) Deck { return NewSeverityDeck(deps.PushSeverityModal) },
+	"pattern-table":      func(deps DeckDeps) Deck { return NewPatternTableDeck(deps.Drain3Manager, deps.DrillDownPattern) },
+	"attribute-explorer": func(deps DeckDeps) Deck { return NewAttributeExplorerDeck(deps.Store, deps.PushValuesModal) },
+	"list":               func(deps DeckDeps) Deck { return NewListDeck(deps.Model) },
+	"ingest-rate":        func(DeckDeps) Deck { return NewIngestRateDeck() },
+	"ingest-bytes":       func(DeckDeps) Deck { return NewIngestBytesDeck() },
+	"service-rates":      func(DeckDeps) Deck { return NewServiceRatesDeck() },
+	"metric-charts":      func(DeckDeps) Deck { return NewMetricChartsDeck() },
+	"hosts":              func(deps DeckDeps) Deck { return NewHostsDeck(deps.ToggleFacet) },
+	"services":           func(deps DeckDeps) Deck { return NewServicesDeck(deps.ToggleFacet) },
+	"alerts-firing":      func(deps DeckDeps) Deck { return NewAlertsDeck(model.AlertFiring, deps.PushAlertsModal) },
+	"alerts-pending":     func(deps DeckDeps) Deck { return NewAlertsDeck(model.AlertPending, deps.PushAlertsModal) },
+	"alerts-resolved":    func(deps DeckDeps) Deck { return NewAlertsDeck(model.AlertResolved, deps.PushAlertsModal) },
+	"health":             func(DeckDeps) Deck { return NewHealthDeck() },
+	"health-errors":      func(DeckDeps) Deck { return NewHealthErrorsDeck() },
+}
+
+// ConfigDeckTypes returns the deck types a config may name, sorted.
+func ConfigDeckTypes() []string {
+	types := make([]string, 0, len(configDeckTypes))
+	for t := range configDeckTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// PageSpecsFromConfig validates configured pages and returns their specs.
+func PageSpecsFromConfig(pages []PageConfig) ([]PageSpec, error) {
+	specs := make([]PageSpec, 0, len(pages))
+	for pi, pc := range pages {
+		if pc.ID == "" {
+			return nil, fmt.Errorf("pages[%d]: id is required", pi)
+		}
+		spec := PageSpec{ID: pc.ID, Title: pc.Title}
+		if spec.Title == "" {
+			spec.Title = pc.ID
+		}
+		for vi, vc := range pc.Views {
+			where := fmt.Sprintf("pages[%d].views[%d]", pi, vi)
+			if vc.ID == "" {
+				return nil, fmt.Errorf("%s: id is required", where)
+			}
+			for di, dc := range vc.Decks {
+				if err := dc.validate(); err != nil {
+					return nil, fmt.Errorf("%s.decks[%d]: %w", where, di, err)
+				}
+			}
+			title := vc.Title
+			if title == "" {
+				title = vc.ID
+			}
+			viewID, decks := vc.ID, slices.Clone(vc.Decks)
+			spec.ViewSpecs = append(spec.ViewSpecs, ViewSpec{
+				ID:    viewID,
+				Title: title,
+				Build: func(deps DeckDeps) []Deck {
+					out := make([]Deck, 0, len(decks))
+					for i, dc := range decks {
+						out = append(out, newConfiguredDeck(viewID+"#"+strconv.Itoa(i), dc, configDeckTypes[dc.Type](deps)))
+					}
+					return out
+				},
+			})
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func (dc DeckConfig) validate() error {
+	if _, ok := configDeckTypes[dc.Type]; !ok {
+		return fmt.Errorf("unknown deck type %q (one of %v)", dc.Type, ConfigDeckTypes())
+	}
+	switch {
+	case dc.Window < 0:
+		return fmt.Errorf("window must not be negative")
+	case dc.Refresh != 0 && dc.Refresh < minDeckRefresh:
+		return fmt.Errorf("refresh must be at least %s", minDeckRefresh)
+	case dc.Size != "" && dc.Size != "quarter" && dc.Size != "full":
+		return fmt.Errorf("size must be quarter or full, not %q", dc.Size)
+	}
+	return nil
+}
+
+// mergePageSpecs adds configured pages to base: the views of a page whose
+// id is already in base join that page, other pages follow base. View ids
+// must stay unique, since views are persisted and templated by id.
+func mergePageSpecs(base, extra []PageSpec) ([]PageSpec, error) {
+	out := slices.Clone(base)
+	viewIDs := make(map[string]bool)
+	for _, ps := range base {
+		for _, vs := range ps.ViewSpecs {
+			viewIDs[vs.ID] = true
+		}
+	}
+	for _, ps := range extra {
+		for _, vs := range ps.ViewSpecs {
+			if viewIDs[vs.ID] {
+				return nil, fmt.Errorf("page %q: view id %q is already used", ps.ID, vs.ID)
+			}
+			viewIDs[vs.ID] = true
+		}
+		idx := slices.IndexFunc(out, func(p PageSpec) bool { return p.ID == ps.ID })
+		if idx < 0 {
+			out = append(out, ps)
+			continue
+		}
+		out[idx].ViewSpecs = append(slices.Clone(out[idx].ViewSpecs), ps.ViewSpecs...)
+	}
+	return out, nil
+}
+
+// SetConfigPages shows configured pages alongside DefaultPageSpecs.
+func (m *DashboardModel) SetConfigPages(pages []PageConfig) error {
+	if len(pages) == 0 {
+		return nil
+	}
+	specs, err := PageSpecsFromConfig(pages)
+	if err != nil {
+		return err
+	}
+	merged, err := mergePageSpecs(DefaultPageSpecs(), specs)
+	if err != nil {
+		return err
+	}
+	m.SetPages(merged)
+	return nil
+}
+
+// configuredDeck is a built-in deck as a config declares it. It has its
+// own TypeID when it ticks, so its scope and interval are its own rather
+// than shared with the other decks of its type.
+type configuredDeck struct {
+	Deck
+	id   string
+	conf DeckConfig
+}
+
+// configuredTickableDeck is a configuredDeck around a TickableDeck.
+type configuredTickableDeck struct {
+	*configuredDeck
+	tickable TickableDeck
+}
+
+func newConfiguredDeck(id string, conf DeckConfig, inner Deck) Deck {
+	d := &configuredDeck{Deck: inner, id: id, conf: conf}
+	if tp, ok := inner.(TickableDeck); ok {
+		return &configuredTickableDeck{configuredDeck: d, tickable: tp}
+	}
+	return d
+}
+
+func (p *configuredDeck) ID() string { return p.id }
+
+func (p *configuredDeck) Title() string {
+	if p.conf.Title != "" {
+		return p.conf.Title
+	}
+	return p.Deck.Title()
+}
+
+// scope narrows the dashboard's query scope to the deck's app and window.
+func (p *configuredDeck) scope(opts model.QueryOpts) model.QueryOpts {
+	if p.conf.App != "" {
+		opts.App = p.conf.App
+	}
+	if p.conf.Window > 0 {
+		if from := time.Now().Add(-p.conf.Window); from.After(opts.From) {
+			opts.From = from
+		}
+	}
+	return opts
+}
+
+func (p *configuredDeck) Refresh(store model.LogQuerier, opts model.QueryOpts) {
+	p.Deck.Refresh(store, p.scope(opts))
+}
+
+func (p *configuredDeck) Render(ctx ViewContext, width, height int, active bool, selIdx int) string {
+	if p.conf.Title != "" {
+		ctx.DeckTitle = p.conf.Title
+	}
+	return p.Deck.Render(ctx, width, height, active, selIdx)
+}
+
+func (p *configuredDeck) QuarterSized() bool {
+	switch p.conf.Size {
+	case "quarter":
+		return true
+	case "full":
+		return false
+	}
+	qs, ok := p.Deck.(QuarterSizedDeck)
+	return ok && qs.QuarterSized()
+}
+
+func (p *configuredTickableDeck) TypeID() string { return p.id }
+
+func (p *configuredTickableDeck) DefaultInterval() time.Duration {
+	if p.conf.Refresh > 0 {
+		return p.conf.Refresh
+	}
+	return p.tickable.DefaultInterval()
+}
+
+// FetchCmd fetches in the deck's scope and tags the data with its TypeID.
+func (p *configuredTickableDeck) FetchCmd(store model.LogQuerier, opts model.QueryOpts) tea.Cmd {
+	cmd := p.tickable.FetchCmd(store, p.scope(opts))
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg := cmd()
+		if data, ok := msg.(DeckDataMsg); ok {
+			data.DeckTypeID = p.id
+			return data
+		}
+		return msg
+	}
+}
+
+func (p *configuredTickableDeck) ApplyData(data any, err error) {
+	p.tickable.ApplyData(data, err)
+}
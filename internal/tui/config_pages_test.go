@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestPageSpecsFromConfig_Validates(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		pages []PageConfig
+		want  string
+	}{
+		{"page id", []PageConfig{{Title: "X"}}, "pages[0]: id is required"},
+		{"view id", []PageConfig{{ID: "x", Views: []ViewConfig{{}}}}, "pages[0].views[0]: id is required"},
+		{"deck type", []PageConfig{{ID: "x", Views: []ViewConfig{{ID: "v", Decks: []DeckConfig{{Type: "pie"}}}}}}, `unknown deck type "pie"`},
+		{"refresh", []PageConfig{{ID: "x", Views: []ViewConfig{{ID: "v", Decks: []DeckConfig{{Type: "words", Refresh: time.Millisecond}}}}}}, "refresh must be at least"},
+		{"size", []PageConfig{{ID: "x", Views: []ViewConfig{{ID: "v", Decks: []DeckConfig{{Type: "words", Size: "huge"}}}}}}, "size must be quarter or full"},
+	}
+	for _, tc := range cases {
+		if _, err := PageSpecsFromConfig(tc.pages); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestSetConfigPages(t *testing.T) {
+	t.Parallel()
+
+	store := &countingStore{}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	err := m.SetConfigPages([]PageConfig{
+		{ID: "logs", Views: []ViewConfig{{ID: "errors", Title: "Errors", Decks: []DeckConfig{{Type: "severity"}}}}},
+		{ID: "checkout", Title: "Checkout", Views: []ViewConfig{{ID: "checkout-overview", Decks: []DeckConfig{
+			{Type: "words", Title: "Checkout words", App: "checkout", Window: time.Hour, Refresh: 10 * time.Second, Size: "quarter"},
+			{Type: "list"},
+		}}}},
+	})
+	if err != nil {
+		t.Fatalf("SetConfigPages: %v", err)
+	}
+
+	logs := m.pages[0]
+	if last := logs.Views[len(logs.Views)-1]; last.ID != "errors" || len(last.Decks) != 1 {
+		t.Fatalf("logs page views end with %+v, want the configured view", last)
+	}
+	page := m.pages[len(m.pages)-1]
+	if page.ID != "checkout" || len(page.Views) != 1 || len(page.Views[0].Decks) != 2 {
+		t.Fatalf("last page = %+v, want the configured page", page)
+	}
+
+	words, ok := page.Views[0].Decks[0].(TickableDeck)
+	if !ok {
+		t.Fatal("configured words deck does not tick")
+	}
+	if _, ok := page.Views[0].Decks[1].(TickableDeck); ok {
+		t.Fatal("configured list deck ticks")
+	}
+	if words.TypeID() != "checkout-overview#0" || words.DefaultInterval() != 10*time.Second || words.Title() != "Checkout words" {
+		t.Fatalf("words deck: type %q, interval %s, title %q", words.TypeID(), words.DefaultInterval(), words.Title())
+	}
+	if qs, ok := words.(QuarterSizedDeck); !ok || !qs.QuarterSized() {
+		t.Fatal("size: quarter not applied")
+	}
+
+	opts := words.(*configuredTickableDeck).scope(model.QueryOpts{App: "api"})
+	if opts.App != "checkout" || time.Since(opts.From) < 59*time.Minute {
+		t.Fatalf("scope = %+v, want the checkout app over the last hour", opts)
+	}
+	msg, ok := words.FetchCmd(store, model.QueryOpts{})().(DeckDataMsg)
+	if !ok || msg.DeckTypeID != "checkout-overview#0" || store.topWordsCalls != 1 {
+		t.Fatalf("fetch = %+v, want data tagged with the deck's own type", msg)
+	}
+	if view := words.Render(ViewContext{ContentWidth: 100}, 40, 12, false, 0); !strings.Contains(view, "Checkout words") {
+		t.Fatalf("render is missing the configured title:\n%s", view)
+	}
+
+	if err := m.SetConfigPages([]PageConfig{{ID: "x", Views: []ViewConfig{{ID: "base"}}}}); err == nil {
+		t.Fatal("a view id of a built-in view was accepted")
+	}
+}
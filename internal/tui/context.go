@@ -14,6 +14,7 @@ type ViewContext struct {
 	DeckLastError string // per-deck last error (set per render)
 	DeckLoading       bool   // true when deck's data fetch is in-flight
 	SpinnerFrame  int    // model-driven spinner frame for loading indicators
+	DeckTitle     string // title configured for the deck, replacing its own
 }
 
 // ModalContext provides read-only context to modals for rendering, replacing
@@ -29,6 +30,7 @@ type Action int
 const (
 	ActionSetSearchTerm Action = iota
 	ActionPushModal
+	ActionShowTrace // Payload: trace id
 )
 
 // ActionMsg is returned by panel OnSelect to communicate with the dashboard
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// alertStates are the alert states in the order the Alerts page shows them.
+var alertStates = []string{model.AlertFiring, model.AlertPending, model.AlertResolved}
+
+// alertStateTitle returns the deck and modal title of an alert state.
+func alertStateTitle(state string) string {
+	switch state {
+	case model.AlertFiring:
+		return "Firing"
+	case model.AlertPending:
+		return "Pending"
+	default:
+		return "Recently Resolved"
+	}
+}
+
+// alertStateColor returns the color alerts of state are drawn in.
+func alertStateColor(state string) lipgloss.Color {
+	switch state {
+	case model.AlertFiring:
+		return ColorRed
+	case model.AlertPending:
+		return ColorYellow
+	default:
+		return ColorGreen
+	}
+}
+
+// alertsInState returns the alerts in state, the longest-standing first
+// (the most recently resolved first for resolved ones).
+func alertsInState(alerts []model.Alert, state string) []model.Alert {
+	var out []model.Alert
+	for _, a := range alerts {
+		if a.State == state {
+			out = append(out, a)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if state == model.AlertResolved {
+			return out[i].Since.After(out[j].Since)
+		}
+		return out[i].Since.Before(out[j].Since)
+	})
+	return out
+}
+
+// formatAlertAge renders how long ago t was as "45s", "12m", "3h", or "2d".
+func formatAlertAge(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", max(0, int(d.Seconds())))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// alertFlags renders the silence and acknowledgement marks of a.
+func alertFlags(a model.Alert, now time.Time) string {
+	var flags []string
+	if a.Silenced(now) {
+		flags = append(flags, "silenced "+formatAlertAge(now, a.SilencedUntil))
+	}
+	if a.Acknowledged {
+		flags = append(flags, "ack")
+	}
+	return strings.Join(flags, " ")
+}
+
+// AlertsDeck lists the alert rules in one state with a sparkline of each
+// rule's recent values. The decks of all states share one Alerts call.
+type AlertsDeck struct {
+	state       string
+	all         []model.Alert // alerts in every state, for the modal
+	data        []model.Alert // alerts in state
+	unsupported bool
+	pushModal   func(state string, alerts []model.Alert) tea.Cmd
+}
+
+// NewAlertsDeck creates a deck listing the alerts in state; Enter opens
+// the alerts modal through pushModal.
+func NewAlertsDeck(state string, pushModal func(state string, alerts []model.Alert) tea.Cmd) *AlertsDeck {
+	return &AlertsDeck{state: state, pushModal: pushModal}
+}
+
+func (p *AlertsDeck) ID() string    { return "alerts-" + p.state }
+func (p *AlertsDeck) Title() string { return alertStateTitle(p.state) }
+
+func (p *AlertsDeck) Refresh(_ model.LogQuerier, _ model.QueryOpts) {}
+
+func (p *AlertsDeck) TypeID() string                 { return "alerts" }
+func (p *AlertsDeck) DefaultInterval() time.Duration { return 5 * time.Second }
+
+func (p *AlertsDeck) FetchCmd(store model.LogQuerier, _ model.QueryOpts) tea.Cmd {
+	return func() tea.Msg {
+		alerts, err := fetchAlerts(store)
+		return DeckDataMsg{DeckTypeID: "alerts", Data: alerts, Err: err}
+	}
+}
+
+// fetchAlerts returns the store's alerts, or deckUnsupported{} when it has
+// no alert engine.
+func fetchAlerts(store model.LogQuerier) (any, error) {
+	manager, ok := store.(model.AlertManager)
+	if !ok || !supports(store, "Alerts") {
+		return deckUnsupported{}, nil
+	}
+	return manager.Alerts()
+}
+
+func (p *AlertsDeck) ApplyData(data any, err error) {
+	if err != nil {
+		return
+	}
+	switch data := data.(type) {
+	case deckUnsupported:
+		p.unsupported = true
+	case []model.Alert:
+		p.unsupported = false
+		p.all = data
+		p.data = alertsInState(data, p.state)
+	}
+}
+
+func (p *AlertsDeck) ContentLines(ctx ViewContext) int {
+	if ctx.ContentWidth < 80 {
+		return 6
+	}
+	return 8
+}
+
+func (p *AlertsDeck) ItemCount() int { return len(p.data) }
+
+// OnSelect opens the alerts modal on this deck's state.
+func (p *AlertsDeck) OnSelect(_ ViewContext, _ int) tea.Cmd {
+	if p.unsupported || p.pushModal == nil {
+		return nil
+	}
+	return p.pushModal(p.state, p.all)
+}
+
+func (p *AlertsDeck) Render(ctx ViewContext, width, height int, active bool, _ int) string {
+	style := sectionStyle.Width(width).Height(height - 2)
+	if active {
+		style = activeSectionStyle.Width(width).Height(height - 2)
+	}
+	titleText := alertStateTitle(p.state)
+	if !p.unsupported {
+		titleText = fmt.Sprintf("%s (%d)", titleText, len(p.data))
+	}
+	title := deckTitleStyle.Render(deckTitleWithBadges(titleText, ctx))
+
+	contentLines := max(1, height-3)
+	var content string
+	switch {
+	case p.unsupported:
+		content = helpStyle.Render("No alert engine on this server")
+	case len(p.data) > 0:
+		now := time.Now()
+		var lines []string
+		for i, a := range p.data {
+			if i == contentLines-1 && len(p.data) > contentLines {
+				lines = append(lines, helpStyle.Render(fmt.Sprintf("… %d more • Enter: open", len(p.data)-i)))
+				break
+			}
+			lines = append(lines, renderAlertLine(a, width-4, now))
+		}
+		content = strings.Join(lines, "\n")
+	case ctx.DeckLoading:
+		content = renderLoadingPlaceholder(width-2, contentLines, ctx.SpinnerFrame)
+	default:
+		content = helpStyle.Render(fmt.Sprintf("No %s alerts", p.state))
+	}
+
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+}
+
+// renderAlertLine renders one alert as its rule, age, last value, flags,
+// and a sparkline of its history, fitted to width.
+func renderAlertLine(a model.Alert, width int, now time.Time) string {
+	ruleWidth := max(10, width/3)
+	rule := lipgloss.NewStyle().Foreground(alertStateColor(a.State)).Render("● " + fitWidth(a.Rule, ruleWidth-2))
+	stats := fmt.Sprintf(" %4s %10.4g ", formatAlertAge(a.Since, now), a.Value)
+	flags := alertFlags(a, now)
+	if flags != "" {
+		flags = lipgloss.NewStyle().Foreground(ColorGray).Render(flags) + " "
+	}
+	spark := sparkline(a.History, width-lipgloss.Width(rule)-lipgloss.Width(stats)-lipgloss.Width(flags))
+	return rule + lipgloss.NewStyle().Foreground(ColorWhite).Render(stats) + flags + spark
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// alertStore is a store with an alert engine that applies silences and
+// acknowledgements to its alerts.
+type alertStore struct {
+	countingStore
+	alerts []model.Alert
+}
+
+func (s *alertStore) Alerts() ([]model.Alert, error) {
+	return append([]model.Alert(nil), s.alerts...), nil
+}
+
+func (s *alertStore) SilenceAlert(rule string, d time.Duration) error {
+	for i := range s.alerts {
+		if s.alerts[i].Rule == rule {
+			s.alerts[i].SilencedUntil = time.Time{}
+			if d > 0 {
+				s.alerts[i].SilencedUntil = time.Now().Add(d)
+			}
+			return nil
+		}
+	}
+	return model.ErrUnknownAlert
+}
+
+func (s *alertStore) AcknowledgeAlert(rule string) error {
+	for i := range s.alerts {
+		if s.alerts[i].Rule == rule {
+			s.alerts[i].Acknowledged = true
+			return nil
+		}
+	}
+	return model.ErrUnknownAlert
+}
+
+func TestAlertsDeck_ListsItsState(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	store := &alertStore{alerts: []model.Alert{
+		{Rule: "disk_full", State: model.AlertFiring, Since: now.Add(-time.Minute)},
+		{Rule: "error_rate", State: model.AlertFiring, Since: now.Add(-time.Hour), History: []float64{1, 2, 8}},
+		{Rule: "latency", State: model.AlertPending, Since: now},
+	}}
+	firing := NewAlertsDeck(model.AlertFiring, nil)
+	fetchDeck(t, firing, store)
+
+	if firing.ItemCount() != 2 || firing.data[0].Rule != "error_rate" {
+		t.Fatalf("firing = %+v, want the longest-firing rule first", firing.data)
+	}
+	view := firing.Render(ViewContext{ContentWidth: 120}, 80, 10, false, 0)
+	if !strings.Contains(view, "Firing (2)") || !strings.Contains(view, "error_rate") || strings.Contains(view, "latency") {
+		t.Fatalf("firing deck:\n%s", view)
+	}
+
+	plain := NewAlertsDeck(model.AlertFiring, nil)
+	fetchDeck(t, plain, &countingStore{})
+	if view := plain.Render(ViewContext{}, 60, 8, false, 0); !strings.Contains(view, "No alert engine") {
+		t.Fatalf("store without alerts:\n%s", view)
+	}
+}
+
+func TestAlertsModal_SilencesAndAcknowledges(t *testing.T) {
+	t.Parallel()
+
+	store := &alertStore{alerts: []model.Alert{
+		{Rule: "error_rate", State: model.AlertFiring},
+		{Rule: "latency", State: model.AlertPending},
+	}}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	modal := NewAlertsModal(m, model.AlertPending, store.alerts)
+	if modal.alerts[modal.cursor].Rule != "latency" {
+		t.Fatalf("cursor on %q, want the first pending alert", modal.alerts[modal.cursor].Rule)
+	}
+
+	press := func(k string) {
+		t.Helper()
+		_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		if cmd == nil {
+			t.Fatalf("%s did nothing", k)
+		}
+		modal.Update(cmd())
+	}
+	press("x")
+	if !modal.alerts[modal.cursor].Silenced(time.Now()) || !strings.Contains(modal.notice, "silenced latency") {
+		t.Fatalf("latency = %+v, notice %q; want silenced", modal.alerts[modal.cursor], modal.notice)
+	}
+	press("x")
+	if modal.alerts[modal.cursor].Silenced(time.Now()) {
+		t.Fatal("x on a silenced alert did not lift the silence")
+	}
+
+	modal.Update(tea.KeyMsg{Type: tea.KeyUp})
+	press("a")
+	if got := modal.alerts[modal.cursor]; got.Rule != "error_rate" || !got.Acknowledged {
+		t.Fatalf("selected %+v, want error_rate acknowledged", got)
+	}
+	if view := modal.View(120, 40); !strings.Contains(view, "ack") || !strings.Contains(view, "Pending") {
+		t.Fatalf("modal view:\n%s", view)
+	}
+}
@@ -0,0 +1,248 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// explorerKeyLimit is how many attribute keys the explorer lists.
+	explorerKeyLimit = 100
+	// explorerValueLimit is how many values of a key the explorer and its
+	// values modal list.
+	explorerValueLimit = 100
+)
+
+// attributeExplorerData is one fetch of the explorer: the keys, and the
+// values of the key that was selected when the fetch started.
+type attributeExplorerData struct {
+	keys      []model.AttributeKeyStat
+	valuesKey string
+	values    []model.DimensionCount
+}
+
+// AttributeExplorerDeck fills the Attributes view with two panes: the
+// attribute keys on the left, and the values of the selected key with
+// their counts on the right. Enter opens the key's values to filter by one
+// or open its logs.
+type AttributeExplorerDeck struct {
+	store     model.LogQuerier
+	pushModal func(key string, values []model.DimensionCount) tea.Cmd
+	data      attributeExplorerData
+	selected  string          // key selected at the last render
+	opts      model.QueryOpts // scope of the last fetch, reused on select
+}
+
+// NewAttributeExplorerDeck creates an attribute explorer; pushModal opens
+// the values modal for a key.
+func NewAttributeExplorerDeck(store model.LogQuerier, pushModal func(key string, values []model.DimensionCount) tea.Cmd) *AttributeExplorerDeck {
+	return &AttributeExplorerDeck{store: store, pushModal: pushModal}
+}
+
+func (p *AttributeExplorerDeck) ID() string    { return "attribute-explorer" }
+func (p *AttributeExplorerDeck) Title() string { return "Attributes" }
+
+func (p *AttributeExplorerDeck) Refresh(_ model.LogQuerier, _ model.QueryOpts) {}
+
+func (p *AttributeExplorerDeck) TypeID() string                 { return "attribute-explorer" }
+func (p *AttributeExplorerDeck) DefaultInterval() time.Duration { return 2 * time.Second }
+
+// FetchCmd fetches the keys and the values of the selected key, or of the
+// busiest key before anything was selected.
+func (p *AttributeExplorerDeck) FetchCmd(store model.LogQuerier, opts model.QueryOpts) tea.Cmd {
+	p.opts = opts
+	selected := p.selected
+	return func() tea.Msg {
+		keys, err := store.TopAttributeKeys(explorerKeyLimit, opts)
+		if err != nil {
+			return DeckDataMsg{DeckTypeID: "attribute-explorer", Err: err}
+		}
+		data := attributeExplorerData{keys: keys, valuesKey: selected}
+		if data.valuesKey == "" && len(keys) > 0 {
+			data.valuesKey = keys[0].Key
+		}
+		if data.valuesKey != "" {
+			values, err := store.AttributeKeyValues(data.valuesKey, explorerValueLimit, opts)
+			if err != nil {
+				return DeckDataMsg{DeckTypeID: "attribute-explorer", Err: err}
+			}
+			data.values = sortedAttributeValues(values)
+		}
+		return DeckDataMsg{DeckTypeID: "attribute-explorer", Data: data}
+	}
+}
+
+func (p *AttributeExplorerDeck) ApplyData(data any, err error) {
+	if err != nil {
+		return
+	}
+	if d, ok := data.(attributeExplorerData); ok {
+		p.data = d
+	}
+}
+
+// sortedAttributeValues returns values most frequent first, then by value.
+func sortedAttributeValues(values map[string]int64) []model.DimensionCount {
+	out := make([]model.DimensionCount, 0, len(values))
+	for value, count := range values {
+		out = append(out, model.DimensionCount{Value: value, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count == out[j].Count {
+			return out[i].Value < out[j].Value
+		}
+		return out[i].Count > out[j].Count
+	})
+	return out
+}
+
+// ContentLines returns a large value so the grid scaler gives this deck
+// all available height (it is the only deck in the Attributes view).
+func (p *AttributeExplorerDeck) ContentLines(_ ViewContext) int { return 100 }
+
+func (p *AttributeExplorerDeck) ItemCount() int { return len(p.data.keys) }
+
+// OnSelect opens the selected key's values, fetched afresh so the modal
+// lists them in full.
+func (p *AttributeExplorerDeck) OnSelect(_ ViewContext, selIdx int) tea.Cmd {
+	if selIdx < 0 || selIdx >= len(p.data.keys) || p.pushModal == nil {
+		return nil
+	}
+	key := p.data.keys[selIdx].Key
+	var values []model.DimensionCount
+	if key == p.data.valuesKey {
+		values = p.data.values
+	}
+	if p.store != nil {
+		if fresh, err := p.store.AttributeKeyValues(key, explorerValueLimit, p.opts); err == nil {
+			values = sortedAttributeValues(fresh)
+		}
+	}
+	return p.pushModal(key, values)
+}
+
+func (p *AttributeExplorerDeck) Render(ctx ViewContext, width, height int, active bool, selIdx int) string {
+	style := sectionStyle.Width(width).Height(height - 2)
+	if active {
+		style = activeSectionStyle.Width(width).Height(height - 2)
+	}
+	if selIdx >= 0 && selIdx < len(p.data.keys) {
+		p.selected = p.data.keys[selIdx].Key
+	}
+
+	titleText := "Attributes"
+	if len(p.data.keys) > 0 {
+		titleText = fmt.Sprintf("Attributes (%d keys)", len(p.data.keys))
+	}
+	title := deckTitleStyle.Render(deckTitleWithBadges(titleText, ctx))
+
+	contentLines := max(1, height-3)
+	var content string
+	switch {
+	case len(p.data.keys) > 0:
+		inner := width - 4
+		keysWidth := min(max(24, inner*2/5), inner/2)
+		left := p.renderKeys(keysWidth, contentLines, active, selIdx)
+		right := p.renderValues(max(1, inner-keysWidth-3), contentLines)
+		sep := lipgloss.NewStyle().Foreground(ColorGray).Render(strings.TrimSuffix(strings.Repeat("│\n", contentLines), "\n"))
+		content = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(keysWidth).Render(left), " ", sep, " ", right)
+	case ctx.DeckLoading:
+		content = renderLoadingPlaceholder(width-2, contentLines, ctx.SpinnerFrame)
+	default:
+		content = helpStyle.Render("No attributes recorded")
+	}
+
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+}
+
+// renderKeys renders the key pane: each key with its unique values and
+// occurrences, scrolled to keep the selected key in view.
+func (p *AttributeExplorerDeck) renderKeys(width, lines int, active bool, selIdx int) string {
+	header := fmt.Sprintf("%-*s %6s %8s", max(1, width-16), "KEY", "VALUES", "COUNT")
+	out := []string{lipgloss.NewStyle().Foreground(ColorGray).Bold(true).Render(fitWidth(header, width))}
+
+	rows := max(1, lines-1)
+	start := 0
+	if selIdx >= rows {
+		start = selIdx - rows + 1
+	}
+	for i := start; i < min(len(p.data.keys), start+rows); i++ {
+		k := p.data.keys[i]
+		line := fmt.Sprintf("%-*s %6d %8d", max(1, width-16), fitWidth(k.Key, max(1, width-16)), k.UniqueValues, k.TotalCount)
+		if active && i == selIdx {
+			line = lipgloss.NewStyle().Background(ColorBlue).Foreground(ColorBlack).Render(fitWidth(line, width))
+		} else {
+			line = lipgloss.NewStyle().Foreground(ColorWhite).Render(fitWidth(line, width))
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// renderValues renders the value pane for the selected key, or a
+// placeholder until the next fetch brings its values.
+func (p *AttributeExplorerDeck) renderValues(width, lines int) string {
+	header := lipgloss.NewStyle().Foreground(ColorGray).Bold(true).Render(fitWidth("VALUES OF "+p.selected, width))
+	if p.selected != p.data.valuesKey {
+		return header + "\n" + helpStyle.Render("Loading values…")
+	}
+	if len(p.data.values) == 0 {
+		return header + "\n" + helpStyle.Render("No values recorded")
+	}
+
+	var high int64
+	for _, v := range p.data.values {
+		high = max(high, v.Count)
+	}
+	const barWidth = 10
+	out := []string{header}
+	for _, v := range p.data.values[:min(len(p.data.values), max(1, lines-1))] {
+		filled := max(1, int(v.Count*barWidth/max(1, high)))
+		bar := lipgloss.NewStyle().Foreground(ColorBlue).Render(strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled))
+		count := fmt.Sprintf(" %8d ", v.Count)
+		out = append(out, bar+lipgloss.NewStyle().Foreground(ColorGray).Render(count)+
+			lipgloss.NewStyle().Foreground(ColorWhite).Render(fitWidth(v.Value, max(1, width-barWidth-len(count)))))
+	}
+	return strings.Join(out, "\n")
+}
+
+// facetsLabel renders facets as key=value pairs in key order.
+func facetsLabel(facets map[string]string) string {
+	keys := make([]string, 0, len(facets))
+	for k := range facets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + facets[k]
+	}
+	return strings.Join(parts, " ")
+}
+
+// addFacet narrows the log list to records whose key is value, replacing
+// any earlier value of key, and applies it. The stores match facets on the
+// record's fields, not its message, so they stack with the regex filter.
+func (m *DashboardModel) addFacet(key, value string) {
+	if m.facets == nil {
+		m.facets = make(map[string]string)
+	}
+	m.facets[key] = value
+	m.reloadLogEntries()
+}
+
+// drillDownToAttribute narrows the log list by the key=value facet, then
+// opens the log viewer on the result.
+func (m *DashboardModel) drillDownToAttribute(key, value string) tea.Cmd {
+	m.addFacet(key, value)
+	m.selectedLogIndex = max(0, len(m.logEntries)-1)
+	return actionMsg(ActionMsg{Action: ActionPushModal, Payload: NewLogViewerModal(m)})
+}
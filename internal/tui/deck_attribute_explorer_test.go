@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// attributeStore serves attribute keys and their values.
+type attributeStore struct {
+	countingStore
+	keys   []model.AttributeKeyStat
+	values map[string]map[string]int64
+}
+
+func (s *attributeStore) TopAttributeKeys(limit int, _ model.QueryOpts) ([]model.AttributeKeyStat, error) {
+	return s.keys[:min(limit, len(s.keys))], nil
+}
+
+func (s *attributeStore) AttributeKeyValues(key string, _ int, _ model.QueryOpts) (map[string]int64, error) {
+	return s.values[key], nil
+}
+
+func TestAttributeExplorerDeck_ValuesFollowSelection(t *testing.T) {
+	t.Parallel()
+
+	store := &attributeStore{
+		keys: []model.AttributeKeyStat{
+			{Key: "http.method", UniqueValues: 2, TotalCount: 30},
+			{Key: "region", UniqueValues: 2, TotalCount: 10},
+		},
+		values: map[string]map[string]int64{
+			"http.method": {"GET": 20, "POST": 10},
+			"region":      {"eu": 7, "us": 3},
+		},
+	}
+	d := NewAttributeExplorerDeck(store, nil)
+	ctx := ViewContext{ContentWidth: 120}
+
+	fetchDeck(t, d, store)
+	view := d.Render(ctx, 100, 12, true, 0)
+	if !strings.Contains(view, "VALUES OF http.method") || !strings.Contains(view, "GET") {
+		t.Fatalf("values of the busiest key missing:\n%s", view)
+	}
+
+	// Selecting another key shows its values once the next fetch lands.
+	if view := d.Render(ctx, 100, 12, true, 1); !strings.Contains(view, "Loading values") {
+		t.Fatalf("stale values shown for the new key:\n%s", view)
+	}
+	fetchDeck(t, d, store)
+	view = d.Render(ctx, 100, 12, true, 1)
+	if !strings.Contains(view, "VALUES OF region") || !strings.Contains(view, "eu") || strings.Contains(view, "GET") {
+		t.Fatalf("values of region missing:\n%s", view)
+	}
+}
+
+func TestAttributeValuesModal_Actions(t *testing.T) {
+	t.Parallel()
+
+	store := &attributeStore{
+		keys:   []model.AttributeKeyStat{{Key: "http.method", UniqueValues: 2, TotalCount: 30}},
+		values: map[string]map[string]int64{"http.method": {"GET": 20, "POST?": 10}},
+	}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	m.filterInput.SetValue("timeout")
+
+	d := NewAttributeExplorerDeck(store, m.pushAttributeValuesModalCmd)
+	fetchDeck(t, d, store)
+	m.Update(d.OnSelect(m.viewContext(), 0)())
+	if top := m.TopModal(); top == nil || top.ID() != "attribute-values" {
+		t.Fatalf("top modal = %v, want attribute-values", top)
+	}
+
+	// f adds the value as a facet, next to the regex filter.
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if want := map[string]string{"http.method": "POST?"}; !maps.Equal(store.lastLogFacets, want) || !maps.Equal(m.facets, want) {
+		t.Fatalf("log query facets = %v (model %v), want %v", store.lastLogFacets, m.facets, want)
+	}
+	if got := m.filterInput.Value(); got != "timeout" {
+		t.Fatalf("filter = %q, want it unchanged", got)
+	}
+	if m.TopModal() != nil {
+		t.Fatalf("top modal = %v, want closed after adding the facet", m.TopModal())
+	}
+	if view := m.renderFilter(); !strings.Contains(view, "http.method=POST?") {
+		t.Fatalf("filter bar does not show the facet:\n%s", view)
+	}
+
+	// Enter opens the logs matching the value alone.
+	m.Update(d.OnSelect(m.viewContext(), 0)())
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter on a value should return a command")
+	}
+	m.Update(cmd())
+	if top := m.TopModal(); top == nil || top.ID() != "logviewer" {
+		t.Fatalf("top modal = %v, want logviewer", top)
+	}
+	if want := map[string]string{"http.method": "GET"}; !maps.Equal(store.lastLogFacets, want) {
+		t.Fatalf("log query facets = %v, want %v", store.lastLogFacets, want)
+	}
+
+	// Esc clears the facets with the filter.
+	m.PopModal()
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.facets) != 0 {
+		t.Fatalf("facets = %v after Esc, want none", m.facets)
+	}
+}
@@ -17,6 +17,7 @@ type AttributesDeck struct {
 	formatModal    func(entry *AttributeEntry, maxWidth int) string
 	pushContentCmd func(content string) tea.Cmd
 	data           []AttributeEntry
+	opts           model.QueryOpts // scope of the last fetch, reused on select
 }
 
 // NewAttributesDeck creates a new attributes deck.
@@ -37,6 +38,7 @@ func (p *AttributesDeck) TypeID() string               { return "attributes" }
 func (p *AttributesDeck) DefaultInterval() time.Duration { return 2 * time.Second }
 
 func (p *AttributesDeck) FetchCmd(store model.LogQuerier, opts model.QueryOpts) tea.Cmd {
+	p.opts = opts
 	return func() tea.Msg {
 		attrKeys, err := store.TopAttributeKeys(50, opts)
 		var entries []AttributeEntry
@@ -116,7 +118,7 @@ func (p *AttributesDeck) OnSelect(ctx ViewContext, selIdx int) tea.Cmd {
 		entry := p.data[selIdx]
 		// Fetch heavy value distribution on demand to avoid N+1 queries on each tick.
 		if p.store != nil {
-			if values, err := p.store.AttributeKeyValues(entry.Key, 100); err == nil {
+			if values, err := p.store.AttributeKeyValues(entry.Key, 100, p.opts); err == nil {
 				entry.Values = values
 			}
 		}
@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dimensionErrorLevels are the levels a dimension deck counts as errors.
+var dimensionErrorLevels = []string{"ERROR", "FATAL", "CRITICAL"}
+
+// dimensionCount is one host's or service's logs, and how many of them are
+// errors, over the Metrics page's window.
+type dimensionCount struct {
+	value  string
+	count  int64
+	errors int64
+}
+
+// errorRate returns the share of the logs that are errors.
+func (d dimensionCount) errorRate() float64 {
+	if d.count == 0 {
+		return 0
+	}
+	return float64(d.errors) / float64(d.count)
+}
+
+// dimensionErrorColor colors an error rate: green for none, yellow under
+// 5%, orange under 20%, red above.
+func dimensionErrorColor(rate float64) lipgloss.Color {
+	switch {
+	case rate == 0:
+		return ColorGreen
+	case rate < 0.05:
+		return ColorYellow
+	case rate < 0.2:
+		return ColorOrange
+	default:
+		return ColorRed
+	}
+}
+
+// DimensionDeck lists the hosts or services logging the most over the
+// Metrics page's window with their error rate. Enter facets the log list on
+// the selected one.
+type DimensionDeck struct {
+	facet       string // model.FacetHost or model.FacetService
+	toggleFacet func(key, value string) tea.Cmd
+	data        []dimensionCount
+}
+
+// NewHostsDeck creates a deck of the busiest hosts.
+func NewHostsDeck(toggleFacet func(key, value string) tea.Cmd) *DimensionDeck {
+	return &DimensionDeck{facet: model.FacetHost, toggleFacet: toggleFacet}
+}
+
+// NewServicesDeck creates a deck of the busiest services.
+func NewServicesDeck(toggleFacet func(key, value string) tea.Cmd) *DimensionDeck {
+	return &DimensionDeck{facet: model.FacetService, toggleFacet: toggleFacet}
+}
+
+func (p *DimensionDeck) ID() string { return p.facet + "s" }
+
+func (p *DimensionDeck) Title() string {
+	if p.facet == model.FacetHost {
+		return "Hosts"
+	}
+	return "Services"
+}
+
+func (p *DimensionDeck) Refresh(_ model.LogQuerier, _ model.QueryOpts) {}
+
+func (p *DimensionDeck) TypeID() string                 { return p.ID() }
+func (p *DimensionDeck) DefaultInterval() time.Duration { return 5 * time.Second }
+
+func (p *DimensionDeck) FetchCmd(store model.LogQuerier, opts model.QueryOpts) tea.Cmd {
+	typeID, facet := p.TypeID(), p.facet
+	return func() tea.Msg {
+		counts, err := topDimension(store, facet, metricsScope(opts))
+		if err != nil {
+			return DeckDataMsg{DeckTypeID: typeID, Err: err}
+		}
+		errs, err := store.RateByDimension(facet, metricsWindow, metricsStep, dimensionErrorLevels, opts)
+		if err != nil {
+			return DeckDataMsg{DeckTypeID: typeID, Err: err}
+		}
+		return DeckDataMsg{DeckTypeID: typeID, Data: dimensionCounts(counts, errs)}
+	}
+}
+
+// topDimension returns the busiest hosts or services.
+func topDimension(store model.LogQuerier, facet string, opts model.QueryOpts) ([]model.DimensionCount, error) {
+	if facet == model.FacetHost {
+		return store.TopHosts(metricsTopN, opts)
+	}
+	return store.TopServices(metricsTopN, opts)
+}
+
+// dimensionCounts pairs the busiest values with their error counts over the
+// window, keeping the busiest-first order.
+func dimensionCounts(counts []model.DimensionCount, errs []model.DimensionRate) []dimensionCount {
+	byValue := make(map[string]int64, len(counts))
+	for _, row := range errs {
+		byValue[row.Value] += row.Count
+	}
+	out := make([]dimensionCount, 0, len(counts))
+	for _, c := range counts {
+		out = append(out, dimensionCount{value: c.Value, count: c.Count, errors: min(byValue[c.Value], c.Count)})
+	}
+	return out
+}
+
+func (p *DimensionDeck) ApplyData(data any, err error) {
+	if err != nil {
+		return
+	}
+	if counts, ok := data.([]dimensionCount); ok {
+		p.data = counts
+	}
+}
+
+func (p *DimensionDeck) ContentLines(ctx ViewContext) int {
+	if ctx.ContentWidth < 80 {
+		return 6
+	}
+	return metricsTopN
+}
+
+func (p *DimensionDeck) ItemCount() int { return len(p.data) }
+
+// OnSelect facets the log list on the selected host or service, or lifts
+// the facet when it is already applied. Logs without one are listed as
+// "unknown" and cannot be faceted on.
+func (p *DimensionDeck) OnSelect(_ ViewContext, selIdx int) tea.Cmd {
+	if selIdx >= len(p.data) || p.toggleFacet == nil {
+		return nil
+	}
+	value := p.data[selIdx].value
+	if value == "unknown" {
+		return nil
+	}
+	return p.toggleFacet(p.facet, value)
+}
+
+func (p *DimensionDeck) Render(ctx ViewContext, width, height int, active bool, selIdx int) string {
+	style := sectionStyle.Width(width).Height(height - 2)
+	if active {
+		style = activeSectionStyle.Width(width).Height(height - 2)
+	}
+	title := deckTitleStyle.Render(deckTitleWithBadges(p.Title()+" (30m)", ctx))
+
+	contentLines := max(1, height-3)
+	var content string
+	switch {
+	case len(p.data) > 0:
+		var lines []string
+		labelWidth := max(8, width-4-23)
+		for i, d := range p.data[:min(len(p.data), contentLines)] {
+			label := fmt.Sprintf("%-*s %9d ", labelWidth, truncatePreview(d.value, labelWidth), d.count)
+			rate := fmt.Sprintf("%5.1f%% err", 100*d.errorRate())
+			lineStyle := lipgloss.NewStyle().Foreground(ColorWhite)
+			rateStyle := lipgloss.NewStyle().Foreground(dimensionErrorColor(d.errorRate()))
+			if i == selIdx && active {
+				lineStyle = lineStyle.Background(ColorBlue)
+				rateStyle = rateStyle.Background(ColorBlue)
+			}
+			lines = append(lines, lineStyle.Render(label)+rateStyle.Render(rate))
+		}
+		content = strings.Join(lines, "\n")
+	case ctx.DeckLoading:
+		content = renderLoadingPlaceholder(width-2, contentLines, ctx.SpinnerFrame)
+	default:
+		content = helpStyle.Render("No data available")
+	}
+
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+}
+
+// toggleFacet facets the log list on key=value, or lifts the facet when
+// key already has that value.
+func (m *DashboardModel) toggleFacet(key, value string) tea.Cmd {
+	if m.facets[key] == value {
+		delete(m.facets, key)
+		m.reloadLogEntries()
+		return nil
+	}
+	m.addFacet(key, value)
+	return nil
+}
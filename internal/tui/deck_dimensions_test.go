@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/memstore"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestDimensionDecks_ErrorRatesAndFacets(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	store := memstore.NewStore()
+	var batch []*model.LogRecord
+	add := func(n int, service, host, level string) {
+		for range n {
+			batch = append(batch, &model.LogRecord{Timestamp: now.Add(-time.Minute), Level: level, Service: service, Hostname: host, Message: "m"})
+		}
+	}
+	add(6, "api", "web-1", "INFO")
+	add(2, "api", "web-1", "ERROR")
+	add(3, "worker", "", "INFO")
+	if err := store.InsertLogBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.width, m.height = 160, 40
+	m.reloadLogEntries()
+	hosts, services := NewHostsDeck(m.toggleFacet), NewServicesDeck(m.toggleFacet)
+	fetchDeck(t, hosts, store)
+	fetchDeck(t, services, store)
+
+	want := []dimensionCount{{value: "api", count: 8, errors: 2}, {value: "worker", count: 3}}
+	if len(services.data) != 2 || services.data[0] != want[0] || services.data[1] != want[1] {
+		t.Fatalf("services = %+v, want %+v", services.data, want)
+	}
+	if view := services.Render(ViewContext{ContentWidth: 120}, 60, 10, false, 0); !strings.Contains(view, "25.0% err") || !strings.Contains(view, "Services (30m)") {
+		t.Fatalf("services deck:\n%s", view)
+	}
+	if dimensionErrorColor(0.25) != ColorRed || dimensionErrorColor(0) != ColorGreen {
+		t.Error("error rates colored wrong")
+	}
+
+	// Enter facets the logs on the host, again lifts it; unknown hosts
+	// cannot be faceted on.
+	hosts.OnSelect(ViewContext{}, 0)
+	if m.facets[model.FacetHost] != "web-1" || len(m.logEntries) != 8 {
+		t.Fatalf("facets %v, %d log entries; want web-1's 8", m.facets, len(m.logEntries))
+	}
+	hosts.OnSelect(ViewContext{}, 0)
+	if len(m.facets) != 0 || len(m.logEntries) != 11 {
+		t.Fatalf("facets %v, %d log entries; want the facet lifted", m.facets, len(m.logEntries))
+	}
+	if hosts.data[1].value != "unknown" {
+		t.Fatalf("hosts = %+v, want web-1 then unknown", hosts.data)
+	}
+	hosts.OnSelect(ViewContext{}, 1)
+	if len(m.facets) != 0 {
+		t.Fatalf("faceted on the unknown host: %v", m.facets)
+	}
+}
@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HealthDeck shows the server's component checks: either every
+// component's status, or the components that have failed, latest failure
+// first. Both kinds share one Health call.
+type HealthDeck struct {
+	errors      bool
+	data        []model.ComponentHealth
+	unsupported bool
+}
+
+// NewHealthDeck creates a deck listing each component's status.
+func NewHealthDeck() *HealthDeck {
+	return &HealthDeck{}
+}
+
+// NewHealthErrorsDeck creates a deck listing the components' latest
+// failures.
+func NewHealthErrorsDeck() *HealthDeck {
+	return &HealthDeck{errors: true}
+}
+
+func (p *HealthDeck) ID() string {
+	if p.errors {
+		return "health-errors"
+	}
+	return "health"
+}
+
+func (p *HealthDeck) Title() string {
+	if p.errors {
+		return "Recent Errors"
+	}
+	return "Components"
+}
+
+func (p *HealthDeck) Refresh(_ model.LogQuerier, _ model.QueryOpts) {}
+
+func (p *HealthDeck) TypeID() string                 { return "health" }
+func (p *HealthDeck) DefaultInterval() time.Duration { return 5 * time.Second }
+
+func (p *HealthDeck) FetchCmd(store model.LogQuerier, _ model.QueryOpts) tea.Cmd {
+	return func() tea.Msg {
+		reporter, ok := store.(model.HealthReporter)
+		if !ok || !supports(store, "Health") {
+			return DeckDataMsg{DeckTypeID: "health", Data: deckUnsupported{}}
+		}
+		components, err := reporter.Health()
+		return DeckDataMsg{DeckTypeID: "health", Data: components, Err: err}
+	}
+}
+
+func (p *HealthDeck) ApplyData(data any, err error) {
+	if err != nil {
+		return
+	}
+	switch data := data.(type) {
+	case deckUnsupported:
+		p.unsupported = true
+	case []model.ComponentHealth:
+		p.unsupported = false
+		p.data = data
+		if p.errors {
+			p.data = failedComponents(data)
+		}
+	}
+}
+
+// failedComponents returns the components that have failed, latest
+// failure first.
+func failedComponents(components []model.ComponentHealth) []model.ComponentHealth {
+	var failed []model.ComponentHealth
+	for _, c := range components {
+		if !c.LastErrorAt.IsZero() {
+			failed = append(failed, c)
+		}
+	}
+	sort.SliceStable(failed, func(i, j int) bool { return failed[i].LastErrorAt.After(failed[j].LastErrorAt) })
+	return failed
+}
+
+func (p *HealthDeck) ContentLines(ctx ViewContext) int {
+	if ctx.ContentWidth < 80 {
+		return 6
+	}
+	return 8
+}
+
+func (p *HealthDeck) ItemCount() int { return len(p.data) }
+
+func (p *HealthDeck) OnSelect(_ ViewContext, _ int) tea.Cmd { return nil }
+
+func (p *HealthDeck) Render(ctx ViewContext, width, height int, active bool, _ int) string {
+	style := sectionStyle.Width(width).Height(height - 2)
+	if active {
+		style = activeSectionStyle.Width(width).Height(height - 2)
+	}
+
+	leftTitle := deckTitleWithBadges(p.Title(), ctx)
+	var rightStats string
+	if !p.errors && len(p.data) > 0 {
+		rightStats = healthSummary(p.data)
+	}
+	headerText := leftTitle
+	if spacer := width - 4 - lipgloss.Width(leftTitle) - lipgloss.Width(rightStats); rightStats != "" && spacer > 0 {
+		headerText = leftTitle + strings.Repeat(" ", spacer) + rightStats
+	}
+	title := deckTitleStyle.Render(headerText)
+
+	contentLines := max(1, height-3)
+	var content string
+	switch {
+	case p.unsupported:
+		content = helpStyle.Render("Health is not served by this server")
+	case len(p.data) > 0:
+		now := time.Now()
+		var lines []string
+		for _, c := range p.data[:min(len(p.data), contentLines)] {
+			if p.errors {
+				lines = append(lines, renderHealthError(c, width-4, now))
+			} else {
+				lines = append(lines, renderHealthComponent(c, width-4, now))
+			}
+		}
+		content = strings.Join(lines, "\n")
+	case ctx.DeckLoading:
+		content = renderLoadingPlaceholder(width-2, contentLines, ctx.SpinnerFrame)
+	case p.errors:
+		content = helpStyle.Render("No component has failed")
+	default:
+		content = helpStyle.Render("No data available")
+	}
+
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+}
+
+// healthSummary renders the server's readiness the way /api/health/ready
+// reports it: not ready, degraded, or ready.
+func healthSummary(components []model.ComponentHealth) string {
+	status, color := "ready", ColorGreen
+	for _, c := range components {
+		if c.Status != model.HealthFailing {
+			continue
+		}
+		if c.Required {
+			status, color = "not ready", ColorRed
+			break
+		}
+		status, color = "degraded", ColorYellow
+	}
+	return lipgloss.NewStyle().Foreground(color).Bold(true).Render(status)
+}
+
+// renderHealthComponent renders a component as its status, name, how long
+// it has had that status, its restarts, and why it is failing.
+func renderHealthComponent(c model.ComponentHealth, width int, now time.Time) string {
+	dot := lipgloss.NewStyle().Foreground(ColorGreen).Render("●")
+	if c.Status == model.HealthFailing {
+		color := ColorYellow
+		if c.Required {
+			color = ColorRed
+		}
+		dot = lipgloss.NewStyle().Foreground(color).Render("●")
+	}
+	name := c.Name
+	if c.Required {
+		name += "*"
+	}
+	line := fmt.Sprintf("%-14s %-7s %4s", fitWidth(name, 14), c.Status, formatAlertAge(c.Since, now))
+	if c.Restarts > 0 {
+		line += fmt.Sprintf("  %d restarts", c.Restarts)
+	}
+	if c.Error != "" {
+		line += "  " + c.Error
+	}
+	return dot + " " + lipgloss.NewStyle().Foreground(ColorWhite).Render(fitWidth(line, max(1, width-2)))
+}
+
+// renderHealthError renders a component's latest failure as how long ago
+// it was, the component, and the error.
+func renderHealthError(c model.ComponentHealth, width int, now time.Time) string {
+	age := lipgloss.NewStyle().Foreground(ColorGray).Render(fmt.Sprintf("%4s ago ", formatAlertAge(c.LastErrorAt, now)))
+	nameStyle := lipgloss.NewStyle().Foreground(ColorYellow)
+	if c.Status == model.HealthFailing {
+		nameStyle = lipgloss.NewStyle().Foreground(ColorRed)
+	}
+	name := nameStyle.Render(fitWidth(c.Name, 14))
+	rest := max(1, width-lipgloss.Width(age)-lipgloss.Width(name)-1)
+	return age + name + " " + lipgloss.NewStyle().Foreground(ColorWhite).Render(fitWidth(c.LastError, rest))
+}
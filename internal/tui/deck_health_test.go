@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// healthStore is a store whose server reports its component checks.
+type healthStore struct {
+	countingStore
+	components []model.ComponentHealth
+}
+
+func (s *healthStore) Health() ([]model.ComponentHealth, error) {
+	return s.components, nil
+}
+
+func TestHealthDecks(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	store := &healthStore{components: []model.ComponentHealth{
+		{Name: "store", Status: model.HealthOK, Required: true, Since: now.Add(-time.Hour)},
+		{Name: "journal", Status: model.HealthOK, Required: true, Since: now, LastError: "disk full", LastErrorAt: now.Add(-5 * time.Minute), Restarts: 1},
+		{Name: "backup", Status: model.HealthFailing, Since: now, Error: "bucket unreachable", LastError: "bucket unreachable", LastErrorAt: now.Add(-time.Minute)},
+	}}
+
+	components, failures := NewHealthDeck(), NewHealthErrorsDeck()
+	fetchDeck(t, components, store)
+	fetchDeck(t, failures, store)
+
+	view := components.Render(ViewContext{ContentWidth: 120}, 100, 10, false, 0)
+	for _, want := range []string{"degraded", "journal*", "1 restarts", "bucket unreachable"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("components deck lacks %q:\n%s", want, view)
+		}
+	}
+	if failures.ItemCount() != 2 || failures.data[0].Name != "backup" {
+		t.Fatalf("failures = %+v, want backup then journal", failures.data)
+	}
+	if view := failures.Render(ViewContext{ContentWidth: 120}, 100, 10, false, 0); !strings.Contains(view, "5m ago") || !strings.Contains(view, "disk full") {
+		t.Fatalf("errors deck:\n%s", view)
+	}
+
+	plain := NewHealthDeck()
+	fetchDeck(t, plain, &countingStore{})
+	if view := plain.Render(ViewContext{}, 60, 8, false, 0); !strings.Contains(view, "not served") {
+		t.Fatalf("store without health:\n%s", view)
+	}
+}
@@ -100,25 +100,50 @@ func (m *DashboardModel) deckRowHeightsFor(height int) []int {
 		rows = 2
 	}
 
-	// Distribute height equally across rows.
-	perRow := height / rows
-	if perRow < 3 {
-		perRow = 3
+	// Distribute height across rows by the view's row weights, equally
+	// when it has none.
+	weights := m.activeDeckLayout().Rows
+	if len(weights) != rows {
+		weights = make([]int, rows)
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	totalWeight := 0
+	for _, w := range weights {
+		totalWeight += max(1, w)
 	}
 
 	scaled := make([]int, rows)
-	for i := range scaled {
-		scaled[i] = perRow
+	used := 0
+	for i := range rows - 1 {
+		scaled[i] = max(minDeckRowHeight, height*max(1, weights[i])/totalWeight)
+		used += scaled[i]
 	}
 	// Give the last row any remaining pixels.
-	scaled[rows-1] = height - perRow*(rows-1)
-	if scaled[rows-1] < 3 {
-		scaled[rows-1] = 3
-	}
+	scaled[rows-1] = max(minDeckRowHeight, height-used)
 
 	return scaled
 }
 
+// deckColumnWidths returns the width of each deck column, borders
+// excluded, splitting two columns at the view's split.
+func (m *DashboardModel) deckColumnWidths(width int) []int {
+	// Each deck adds 2 chars for borders (left+right) on top of its Width.
+	borderWidth := 2
+	cols := m.deckColumnCount()
+	if cols == 1 {
+		return []int{width - borderWidth}
+	}
+	avail := width - 1 - cols*borderWidth // 1 char gap between columns
+	split := m.activeDeckLayout().Split
+	if split == 0 {
+		split = 50
+	}
+	left := max(25, avail*split/100)
+	return []int{left, max(25, avail-left)}
+}
+
 func (m *DashboardModel) deckAt(contentWidth int, chartHeight int, x int, y int) (int, bool) {
 	if len(m.decks) == 0 || x < 0 || y < 0 {
 		return 0, false
@@ -129,24 +154,14 @@ func (m *DashboardModel) deckAt(contentWidth int, chartHeight int, x int, y int)
 		return 0, false
 	}
 
-	deckWidth := contentWidth
-	colGap := 0
-	if cols > 1 {
-		colGap = 1
-		deckWidth = max(1, (contentWidth-colGap)/cols)
-	}
-
 	rowHeights := m.deckRowHeightsFor(chartHeight)
 	rowY := 0
 	for row, rowHeight := range rowHeights {
 		if y < rowY+rowHeight {
 			col := 0
-			if cols > 1 {
-				stride := deckWidth + colGap
-				col = x / stride
-				if col >= cols {
-					col = cols - 1
-				}
+			// The left column spans its deck, two borders, and the gap.
+			if cols > 1 && x >= m.deckColumnWidths(contentWidth)[0]+3 {
+				col = 1
 			}
 			idx := row*cols + col
 			if idx >= len(m.decks) {
@@ -162,6 +177,9 @@ func (m *DashboardModel) deckAt(contentWidth int, chartHeight int, x int, y int)
 
 // deckTitleWithBadges appends pause/error badges to a deck title based on ViewContext.
 func deckTitleWithBadges(title string, ctx ViewContext) string {
+	if ctx.DeckTitle != "" {
+		title = ctx.DeckTitle
+	}
 	if ctx.DeckPaused {
 		title += " ⏸"
 	}
@@ -187,20 +205,11 @@ func (m *DashboardModel) renderDecksGrid(width int, height int) string {
 	rowHeights := m.deckRowHeightsFor(height)
 	rows := len(rowHeights)
 
-	// Each deck adds 2 chars for borders (left+right) on top of its Width.
-	// Account for this so the total rendered row fits within the available width.
-	borderWidth := 2
-	deckWidth := width - borderWidth
-	colGap := 0
-	if cols > 1 {
-		colGap = 1
-		deckWidth = (width - colGap - cols*borderWidth) / cols
-		if deckWidth < 25 {
-			deckWidth = 25
-		}
-	}
+	// Column widths leave room for each deck's borders so the total
+	// rendered row fits within the available width.
+	colWidths := m.deckColumnWidths(width)
 
-	blankDeck := func(deckHeight int) string {
+	blankDeck := func(deckWidth, deckHeight int) string {
 		return lipgloss.NewStyle().
 			Width(deckWidth).
 			Height(deckHeight).
@@ -208,7 +217,7 @@ func (m *DashboardModel) renderDecksGrid(width int, height int) string {
 	}
 
 	baseCtx := m.viewContext()
-	renderDeck := func(idx int, h int) string {
+	renderDeck := func(idx int, deckWidth, h int) string {
 		active := m.activeSection == SectionDecks && m.activeDeckIdx == idx
 		selIdx := m.deckSelIdx[idx]
 		ctx := baseCtx
@@ -232,11 +241,11 @@ func (m *DashboardModel) renderDecksGrid(width int, height int) string {
 			idx := row*cols + col
 			if idx >= len(m.decks) {
 				if cols > 1 {
-					rowDecks = append(rowDecks, blankDeck(deckHeight))
+					rowDecks = append(rowDecks, blankDeck(colWidths[col], deckHeight))
 				}
 				continue
 			}
-			rowDecks = append(rowDecks, renderDeck(idx, deckHeight))
+			rowDecks = append(rowDecks, renderDeck(idx, colWidths[col], deckHeight))
 		}
 
 		rowView := rowDecks[0]
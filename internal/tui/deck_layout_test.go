@@ -34,8 +34,8 @@ func TestChartLayout_AllowsMoreThanFourPanels(t *testing.T) {
 	panels = append(panels, extra)
 	m.SetDecks(panels)
 
-	if got := len(m.decks); got != 5 {
-		t.Fatalf("panel count = %d, want 5", got)
+	if got := len(m.decks); got != 6 {
+		t.Fatalf("panel count = %d, want 6", got)
 	}
 
 	h := m.calculateRequiredDecksHeight()
@@ -45,7 +45,7 @@ func TestChartLayout_AllowsMoreThanFourPanels(t *testing.T) {
 
 	view := m.renderDecksGrid(120, h)
 	if view == "No decks registered" {
-		t.Fatal("expected rendered grid for 5 panels")
+		t.Fatal("expected rendered grid for 6 panels")
 	}
 }
 
@@ -0,0 +1,315 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const (
+	// deckLayoutsFile is the file in the config dir holding each view's
+	// customized deck layout.
+	deckLayoutsFile = "layouts.json"
+	// minDeckRowHeight is the fewest lines a row of decks gets.
+	minDeckRowHeight = 3
+	// deckRowStep and deckSplitStep are how far one key press moves a
+	// row border (in lines) and the column border (in percent).
+	deckRowStep   = 2
+	deckSplitStep = 5
+	// minDeckSplit and maxDeckSplit bound the left column's share.
+	minDeckSplit = 20
+	maxDeckSplit = 80
+)
+
+// DeckLayout is a view's customized deck layout. The zero value is the
+// default layout.
+type DeckLayout struct {
+	Order []string `json:"order,omitempty"` // deck IDs in grid order
+	Rows  []int    `json:"rows,omitempty"`  // relative row heights; empty = even
+	Split int      `json:"split,omitempty"` // left column share in percent; 0 = even
+}
+
+func (l DeckLayout) isZero() bool {
+	return len(l.Order) == 0 && len(l.Rows) == 0 && l.Split == 0
+}
+
+// deckDrag is a deck border being dragged with the mouse.
+type deckDrag struct {
+	row int // the row above a dragged row border; -1 for the column border
+}
+
+// LoadDeckLayouts reads the deck layouts saved in path, keyed by view ID.
+// A missing file holds none.
+func LoadDeckLayouts(path string) (map[string]DeckLayout, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var layouts map[string]DeckLayout
+	if err := json.Unmarshal(data, &layouts); err != nil {
+		return nil, fmt.Errorf("decode deck layouts %s: %w", path, err)
+	}
+	return layouts, nil
+}
+
+// SaveDeckLayouts replaces the deck layouts in path.
+func SaveDeckLayouts(path string, layouts map[string]DeckLayout) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	data, err := json.MarshalIndent(layouts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode deck layouts: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write deck layouts: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write deck layouts: %w", err)
+	}
+	return nil
+}
+
+// loadDeckLayouts reads the layouts saved in the config dir and applies
+// their deck order to every view.
+func (m *DashboardModel) loadDeckLayouts() {
+	m.deckLayouts = nil
+	if m.deckLayoutsPath == "" {
+		return
+	}
+	layouts, err := LoadDeckLayouts(m.deckLayoutsPath)
+	if err != nil {
+		m.lastError, m.lastErrorAt = err.Error(), time.Now()
+		return
+	}
+	m.deckLayouts = layouts
+
+	m.persistActiveViewState()
+	for _, vw := range m.allViews() {
+		vw.Decks, vw.DeckSelIdx = orderDecks(vw.Decks, vw.DeckSelIdx, layouts[vw.ID].Order)
+	}
+	if vw := m.activeViewInPage(); vw != nil {
+		m.loadView(vw)
+	}
+}
+
+// orderDecks returns decks and their selections in the order of ids. Decks
+// not in ids keep their place after the ordered ones.
+func orderDecks(decks []Deck, sel []int, ids []string) ([]Deck, []int) {
+	if len(ids) == 0 {
+		return decks, sel
+	}
+	if len(sel) != len(decks) {
+		sel = make([]int, len(decks))
+	}
+	used := make([]bool, len(decks))
+	outDecks := make([]Deck, 0, len(decks))
+	outSel := make([]int, 0, len(decks))
+	for _, id := range ids {
+		for i, d := range decks {
+			if !used[i] && d.ID() == id {
+				used[i] = true
+				outDecks, outSel = append(outDecks, d), append(outSel, sel[i])
+				break
+			}
+		}
+	}
+	for i, d := range decks {
+		if !used[i] {
+			outDecks, outSel = append(outDecks, d), append(outSel, sel[i])
+		}
+	}
+	return outDecks, outSel
+}
+
+// activeDeckLayout returns the active view's layout.
+func (m *DashboardModel) activeDeckLayout() DeckLayout {
+	vw := m.activeViewInPage()
+	if vw == nil {
+		return DeckLayout{}
+	}
+	return m.deckLayouts[vw.ID]
+}
+
+// setActiveDeckLayout replaces the active view's layout, saving the
+// layouts to the config dir when save is set.
+func (m *DashboardModel) setActiveDeckLayout(l DeckLayout, save bool) {
+	vw := m.activeViewInPage()
+	if vw == nil {
+		return
+	}
+	if m.deckLayouts == nil {
+		m.deckLayouts = make(map[string]DeckLayout)
+	}
+	if l.isZero() {
+		delete(m.deckLayouts, vw.ID)
+	} else {
+		m.deckLayouts[vw.ID] = l
+	}
+	if save {
+		m.saveDeckLayouts()
+	}
+}
+
+func (m *DashboardModel) saveDeckLayouts() {
+	if m.deckLayoutsPath == "" {
+		return
+	}
+	if err := SaveDeckLayouts(m.deckLayoutsPath, m.deckLayouts); err != nil {
+		m.lastError, m.lastErrorAt = err.Error(), time.Now()
+	}
+}
+
+// deckGridSize returns the width and height of the deck grid.
+func (m *DashboardModel) deckGridSize() (int, int) {
+	width := m.width
+	if m.sidebarVisible {
+		width -= sidebarWidth
+	}
+	height, _, _ := m.layoutHeights()
+	return width, height
+}
+
+// moveActiveDeck moves the focused deck delta places in the grid order,
+// swapping it with the deck there.
+func (m *DashboardModel) moveActiveDeck(delta int) {
+	from := m.activeDeckIdx
+	to := from + delta
+	if from < 0 || from >= len(m.decks) || to < 0 || to >= len(m.decks) {
+		return
+	}
+	m.decks[from], m.decks[to] = m.decks[to], m.decks[from]
+	m.deckSelIdx[from], m.deckSelIdx[to] = m.deckSelIdx[to], m.deckSelIdx[from]
+	m.activeDeckIdx = to
+	m.persistActiveViewState()
+
+	l := m.activeDeckLayout()
+	l.Order = make([]string, len(m.decks))
+	for i, d := range m.decks {
+		l.Order[i] = d.ID()
+	}
+	m.setActiveDeckLayout(l, true)
+}
+
+// resizeActiveDeckRow grows the focused deck's row by delta lines (shrinks
+// it when negative), taking them from the row below, or above for the
+// last row.
+func (m *DashboardModel) resizeActiveDeckRow(delta int) {
+	_, height := m.deckGridSize()
+	heights := m.deckRowHeightsFor(height)
+	row := m.activeDeckIdx / m.deckColumnCount()
+	if len(heights) < 2 || row >= len(heights) {
+		return
+	}
+	other := row + 1
+	if other == len(heights) {
+		other = row - 1
+	}
+	delta = max(minDeckRowHeight-heights[row], min(delta, heights[other]-minDeckRowHeight))
+	heights[row] += delta
+	heights[other] -= delta
+
+	l := m.activeDeckLayout()
+	l.Rows = heights
+	m.setActiveDeckLayout(l, true)
+}
+
+// resizeActiveDeckColumn widens the focused deck's column by delta percent
+// of the grid (narrows it when negative).
+func (m *DashboardModel) resizeActiveDeckColumn(delta int) {
+	if m.deckColumnCount() < 2 {
+		return
+	}
+	l := m.activeDeckLayout()
+	split := l.Split
+	if split == 0 {
+		split = 50
+	}
+	if m.activeDeckIdx%2 == 1 {
+		delta = -delta
+	}
+	l.Split = max(minDeckSplit, min(maxDeckSplit, split+delta))
+	m.setActiveDeckLayout(l, true)
+}
+
+// resetActiveDeckLayout restores the active view's default layout.
+func (m *DashboardModel) resetActiveDeckLayout() {
+	vw := m.activeViewInPage()
+	if vw == nil {
+		return
+	}
+	if len(m.activeDeckLayout().Order) > 0 {
+		var focused string
+		if m.activeDeckIdx < len(m.decks) {
+			focused = m.decks[m.activeDeckIdx].ID()
+		}
+		m.decks, m.deckSelIdx = orderDecks(m.decks, m.deckSelIdx, vw.DefaultOrder)
+		if idx := slices.IndexFunc(m.decks, func(d Deck) bool { return d.ID() == focused }); idx >= 0 {
+			m.activeDeckIdx = idx
+		}
+		m.persistActiveViewState()
+	}
+	m.setActiveDeckLayout(DeckLayout{}, true)
+}
+
+// deckBorderAt returns the deck border at x, y in the deck grid: the
+// column border, or the border below a row.
+func (m *DashboardModel) deckBorderAt(width, height, x, y int) (deckDrag, bool) {
+	if len(m.decks) == 0 || y < 0 || y >= height {
+		return deckDrag{}, false
+	}
+	if m.deckColumnCount() > 1 {
+		// The left deck's right border, the gap, and the right deck's
+		// left border.
+		edge := m.deckColumnWidths(width)[0] + 1
+		if x >= edge && x <= edge+2 {
+			return deckDrag{row: -1}, true
+		}
+	}
+	heights := m.deckRowHeightsFor(height)
+	rowY := 0
+	for row := range len(heights) - 1 {
+		rowY += heights[row]
+		// The row's bottom border and the next row's top border.
+		if y == rowY-1 || y == rowY {
+			return deckDrag{row: row}, true
+		}
+	}
+	return deckDrag{}, false
+}
+
+// dragDeckBorder moves the dragged border to x, y in the deck grid.
+func (m *DashboardModel) dragDeckBorder(drag deckDrag, width, height, x, y int) {
+	l := m.activeDeckLayout()
+	if drag.row < 0 {
+		avail := width - 1 - 2*2
+		if avail <= 0 {
+			return
+		}
+		l.Split = max(minDeckSplit, min(maxDeckSplit, (x-1)*100/avail))
+		m.setActiveDeckLayout(l, false)
+		return
+	}
+	heights := m.deckRowHeightsFor(height)
+	if drag.row+1 >= len(heights) {
+		return
+	}
+	rowY := 0
+	for _, h := range heights[:drag.row] {
+		rowY += h
+	}
+	pair := heights[drag.row] + heights[drag.row+1]
+	heights[drag.row] = max(minDeckRowHeight, min(pair-minDeckRowHeight, y-rowY+1))
+	heights[drag.row+1] = pair - heights[drag.row]
+	l.Rows = heights
+	m.setActiveDeckLayout(l, false)
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func deckIDs(decks []Deck) []string {
+	ids := make([]string, len(decks))
+	for i, d := range decks {
+		ids[i] = d.ID()
+	}
+	return ids
+}
+
+func TestDeckLayouts_SaveLoadRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tui", deckLayoutsFile)
+	if got, err := LoadDeckLayouts(path); err != nil || got != nil {
+		t.Fatalf("missing file: %v, %v", got, err)
+	}
+	want := map[string]DeckLayout{"base": {Order: []string{"b", "a"}, Rows: []int{10, 20}, Split: 60}}
+	if err := SaveDeckLayouts(path, want); err != nil {
+		t.Fatalf("SaveDeckLayouts: %v", err)
+	}
+	got, err := LoadDeckLayouts(path)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("LoadDeckLayouts = %+v, %v; want %+v", got, err, want)
+	}
+}
+
+func TestDeckLayouts_KeysResizeReorderAndPersist(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	m.SetConfigDir(dir)
+	m.width, m.height = 121, 40
+	m.sidebarVisible = false
+	m.activeSection = SectionDecks
+	defaults := deckIDs(m.decks)
+
+	// Move the first deck right, then down a row.
+	m.Update(tea.KeyMsg{Type: tea.KeyShiftRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	moved := deckIDs(m.decks)
+	if moved[3] != defaults[0] || m.activeDeckIdx != 3 {
+		t.Fatalf("decks %v, focus %d; want %s moved to 3", moved, m.activeDeckIdx, defaults[0])
+	}
+
+	width, height := m.deckGridSize()
+	before := m.deckRowHeightsFor(height)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	after := m.deckRowHeightsFor(height)
+	if after[1] != before[1]+deckRowStep || after[2] != before[2]-deckRowStep {
+		t.Fatalf("row heights %v -> %v, want row 1 taller by %d", before, after, deckRowStep)
+	}
+
+	// The focused deck is in the right column: widening it narrows the left.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	if cols := m.deckColumnWidths(width); cols[0] >= cols[1] {
+		t.Fatalf("column widths %v, want the right column wider", cols)
+	}
+
+	// A new session restores the order and sizes.
+	m2 := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	m2.SetConfigDir(dir)
+	m2.width, m2.height = 121, 40
+	m2.sidebarVisible = false
+	if got := deckIDs(m2.decks); !reflect.DeepEqual(got, moved) {
+		t.Fatalf("restored order %v, want %v", got, moved)
+	}
+	if got := m2.deckRowHeightsFor(height); !reflect.DeepEqual(got, after) {
+		t.Fatalf("restored row heights %v, want %v", got, after)
+	}
+	if got := m2.activeDeckLayout().Split; got != 50-deckSplitStep {
+		t.Fatalf("restored split %d, want %d", got, 50-deckSplitStep)
+	}
+
+	m2.activeSection = SectionDecks
+	m2.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'0'}})
+	if got := deckIDs(m2.decks); !reflect.DeepEqual(got, defaults) {
+		t.Fatalf("reset order %v, want %v", got, defaults)
+	}
+	if layouts, err := LoadDeckLayouts(filepath.Join(dir, deckLayoutsFile)); err != nil || len(layouts) != 0 {
+		t.Fatalf("after reset the saved layouts are %+v, %v", layouts, err)
+	}
+}
+
+func TestDeckLayouts_DragBorders(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	m.SetConfigDir(dir)
+	m.width, m.height = 121, 40
+	m.sidebarVisible = false
+	width, height := m.deckGridSize()
+
+	// Drag the column border to the left.
+	edge := m.deckColumnWidths(width)[0] + 2
+	m.Update(tea.MouseMsg{X: edge, Y: 1, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	m.Update(tea.MouseMsg{X: edge - 20, Y: 1, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft})
+	m.Update(tea.MouseMsg{X: edge - 20, Y: 1, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+	if cols := m.deckColumnWidths(width); cols[1]-cols[0] < 30 {
+		t.Fatalf("column widths %v after the drag, want the left one narrower", cols)
+	}
+
+	// Drag the first row's bottom border down.
+	before := m.deckRowHeightsFor(height)
+	y := before[0] - 1
+	m.Update(tea.MouseMsg{X: 5, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	m.Update(tea.MouseMsg{X: 5, Y: y + 4, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft})
+	m.Update(tea.MouseMsg{X: 5, Y: y + 4, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+	after := m.deckRowHeightsFor(height)
+	if after[0] != before[0]+4 || after[1] != before[1]-4 {
+		t.Fatalf("row heights %v -> %v, want 4 lines moved to row 0", before, after)
+	}
+
+	layouts, err := LoadDeckLayouts(filepath.Join(dir, deckLayoutsFile))
+	if err != nil || !reflect.DeepEqual(layouts["base"].Rows, after) {
+		t.Fatalf("saved layouts %+v, %v; want rows %v", layouts, err, after)
+	}
+}
@@ -0,0 +1,499 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// metricsWindow and metricsStep are the span and resolution of the
+	// Metrics page's charts.
+	metricsWindow = 30 * time.Minute
+	metricsStep   = time.Minute
+	// metricsTopN caps the services and metric series listed.
+	metricsTopN = 8
+)
+
+// deckUnsupported is the data of a deck whose store does not serve the
+// query behind it.
+type deckUnsupported struct{}
+
+// metricsScope narrows opts to the Metrics page's window.
+func metricsScope(opts model.QueryOpts) model.QueryOpts {
+	if opts.To.IsZero() {
+		opts.To = time.Now()
+	}
+	if start := opts.To.Add(-metricsWindow); start.After(opts.From) {
+		opts.From = start
+	}
+	return opts
+}
+
+// metricsBuckets returns the start of each metricsStep bucket in the window
+// ending at to, oldest first.
+func metricsBuckets(to time.Time) []time.Time {
+	n := int(metricsWindow / metricsStep)
+	last := to.Truncate(metricsStep)
+	buckets := make([]time.Time, n)
+	for i := range buckets {
+		buckets[i] = last.Add(-time.Duration(n-1-i) * metricsStep)
+	}
+	return buckets
+}
+
+// IngestDeck charts the rate logs are stored at, in logs or bytes per
+// second. The two kinds share one IngestRate query.
+type IngestDeck struct {
+	bytes       bool
+	data        []model.IngestPoint
+	unsupported bool
+}
+
+// NewIngestRateDeck creates a deck charting logs stored per second.
+func NewIngestRateDeck() *IngestDeck {
+	return &IngestDeck{}
+}
+
+// NewIngestBytesDeck creates a deck charting raw bytes stored per second.
+func NewIngestBytesDeck() *IngestDeck {
+	return &IngestDeck{bytes: true}
+}
+
+func (p *IngestDeck) ID() string {
+	if p.bytes {
+		return "ingest-bytes"
+	}
+	return "ingest-rate"
+}
+
+func (p *IngestDeck) Title() string {
+	if p.bytes {
+		return "Bytes/sec"
+	}
+	return "Ingest Rate"
+}
+
+func (p *IngestDeck) Refresh(_ model.LogQuerier, _ model.QueryOpts) {}
+
+func (p *IngestDeck) TypeID() string                 { return "ingest" }
+func (p *IngestDeck) DefaultInterval() time.Duration { return 5 * time.Second }
+
+func (p *IngestDeck) FetchCmd(store model.LogQuerier, opts model.QueryOpts) tea.Cmd {
+	return func() tea.Msg {
+		rater, ok := store.(model.IngestRater)
+		if !ok || !supports(store, "IngestRate") {
+			return DeckDataMsg{DeckTypeID: "ingest", Data: deckUnsupported{}}
+		}
+		points, err := rater.IngestRate(metricsWindow, metricsStep, opts)
+		return DeckDataMsg{DeckTypeID: "ingest", Data: points, Err: err}
+	}
+}
+
+func (p *IngestDeck) ApplyData(data any, err error) {
+	if err != nil {
+		return
+	}
+	switch data := data.(type) {
+	case deckUnsupported:
+		p.unsupported = true
+	case []model.IngestPoint:
+		p.unsupported = false
+		p.data = append([]model.IngestPoint(nil), data...)
+	}
+}
+
+func (p *IngestDeck) ContentLines(ctx ViewContext) int {
+	if ctx.ContentWidth < 80 {
+		return 6
+	}
+	return 8
+}
+
+func (p *IngestDeck) ItemCount() int { return 0 }
+
+func (p *IngestDeck) OnSelect(_ ViewContext, _ int) tea.Cmd { return nil }
+
+// rates returns the per-second rate of each bucket in the window, oldest
+// first; the last is the bucket still filling.
+func (p *IngestDeck) rates(now time.Time) []float64 {
+	byBucket := make(map[int64]model.IngestPoint, len(p.data))
+	for _, point := range p.data {
+		byBucket[point.Bucket.Unix()] = point
+	}
+	buckets := metricsBuckets(now)
+	rates := make([]float64, len(buckets))
+	for i, b := range buckets {
+		point := byBucket[b.Unix()]
+		value := point.Count
+		if p.bytes {
+			value = point.Bytes
+		}
+		rates[i] = float64(value) / metricsStep.Seconds()
+	}
+	return rates
+}
+
+func (p *IngestDeck) formatRate(rate float64) string {
+	if p.bytes {
+		return formatBytes(int64(rate)) + "/s"
+	}
+	return fmt.Sprintf("%.1f/s", rate)
+}
+
+func (p *IngestDeck) Render(ctx ViewContext, width, height int, active bool, _ int) string {
+	style := sectionStyle.Width(width).Height(height - 2)
+	if active {
+		style = activeSectionStyle.Width(width).Height(height - 2)
+	}
+
+	contentLines := max(1, height-3)
+	leftTitle := deckTitleWithBadges(p.Title(), ctx)
+	var content, rightStats string
+	switch {
+	case p.unsupported:
+		content = helpStyle.Render("Not served by this store")
+	case p.data == nil && ctx.DeckLoading:
+		content = renderLoadingPlaceholder(width-2, contentLines, ctx.SpinnerFrame)
+	default:
+		rates := p.rates(time.Now())
+		// The current bucket is still filling; report the last whole one.
+		rightStats = fmt.Sprintf("last min %s • peak %s", p.formatRate(rates[len(rates)-2]), p.formatRate(slices.Max(rates)))
+		color := lipgloss.Color("39")
+		if p.bytes {
+			color = lipgloss.Color("208")
+		}
+		content = renderColumnChart(rates, width-4, contentLines, lipgloss.NewStyle().Foreground(color))
+	}
+
+	headerText := leftTitle
+	if spacer := width - 4 - lipgloss.Width(leftTitle) - lipgloss.Width(rightStats); rightStats != "" && spacer > 0 {
+		headerText = leftTitle + strings.Repeat(" ", spacer) + rightStats
+	}
+	title := deckTitleStyle.Render(headerText)
+
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+}
+
+// serviceRate is one service's log rate over the Metrics page's window.
+type serviceRate struct {
+	service string
+	rates   []float64 // per second, oldest first
+	total   int64
+}
+
+// ServiceRatesDeck lists the busiest services with their log rate over the
+// last minute and a sparkline of the window.
+type ServiceRatesDeck struct {
+	data []serviceRate
+}
+
+// NewServiceRatesDeck creates a new per-service rate deck.
+func NewServiceRatesDeck() *ServiceRatesDeck {
+	return &ServiceRatesDeck{}
+}
+
+func (p *ServiceRatesDeck) ID() string    { return "service-rates" }
+func (p *ServiceRatesDeck) Title() string { return "Service Rates" }
+
+func (p *ServiceRatesDeck) Refresh(_ model.LogQuerier, _ model.QueryOpts) {}
+
+func (p *ServiceRatesDeck) TypeID() string                 { return "service-rates" }
+func (p *ServiceRatesDeck) DefaultInterval() time.Duration { return 5 * time.Second }
+
+func (p *ServiceRatesDeck) FetchCmd(store model.LogQuerier, opts model.QueryOpts) tea.Cmd {
+	return func() tea.Msg {
+		rows, err := store.RateByDimension("service", metricsWindow, metricsStep, nil, opts)
+		if err != nil {
+			return DeckDataMsg{DeckTypeID: "service-rates", Err: err}
+		}
+		return DeckDataMsg{DeckTypeID: "service-rates", Data: serviceRates(rows, time.Now())}
+	}
+}
+
+// serviceRates turns rate rows into per-service series over the window
+// ending at now, busiest first.
+func serviceRates(rows []model.DimensionRate, now time.Time) []serviceRate {
+	buckets := metricsBuckets(now)
+	index := make(map[int64]int, len(buckets))
+	for i, b := range buckets {
+		index[b.Unix()] = i
+	}
+	byService := make(map[string]*serviceRate)
+	for _, row := range rows {
+		i, ok := index[row.Bucket.Unix()]
+		if !ok {
+			continue
+		}
+		s := byService[row.Value]
+		if s == nil {
+			s = &serviceRate{service: row.Value, rates: make([]float64, len(buckets))}
+			byService[row.Value] = s
+		}
+		s.rates[i] += float64(row.Count) / metricsStep.Seconds()
+		s.total += row.Count
+	}
+	services := make([]serviceRate, 0, len(byService))
+	for _, name := range slices.Sorted(maps.Keys(byService)) {
+		services = append(services, *byService[name])
+	}
+	sort.SliceStable(services, func(i, j int) bool { return services[i].total > services[j].total })
+	if len(services) > metricsTopN {
+		services = services[:metricsTopN]
+	}
+	return services
+}
+
+func (p *ServiceRatesDeck) ApplyData(data any, err error) {
+	if err != nil {
+		return
+	}
+	if services, ok := data.([]serviceRate); ok {
+		p.data = services
+	}
+}
+
+func (p *ServiceRatesDeck) ContentLines(ctx ViewContext) int {
+	if ctx.ContentWidth < 80 {
+		return 6
+	}
+	return metricsTopN
+}
+
+func (p *ServiceRatesDeck) ItemCount() int { return len(p.data) }
+
+// OnSelect searches the logs for the selected service, or clears the
+// search when it already does.
+func (p *ServiceRatesDeck) OnSelect(ctx ViewContext, selIdx int) tea.Cmd {
+	if selIdx >= len(p.data) {
+		return nil
+	}
+	term := p.data[selIdx].service
+	if ctx.SearchTerm == term {
+		term = ""
+	}
+	return actionMsg(ActionMsg{Action: ActionSetSearchTerm, Payload: term})
+}
+
+func (p *ServiceRatesDeck) Render(ctx ViewContext, width, height int, active bool, selIdx int) string {
+	style := sectionStyle.Width(width).Height(height - 2)
+	if active {
+		style = activeSectionStyle.Width(width).Height(height - 2)
+	}
+	title := deckTitleStyle.Render(deckTitleWithBadges("Service Rates (last min)", ctx))
+
+	contentLines := max(1, height-3)
+	var content string
+	switch {
+	case len(p.data) > 0:
+		var lines []string
+		for i, s := range p.data[:min(len(p.data), contentLines)] {
+			label := truncatePreview(s.service, 16)
+			last := s.rates[len(s.rates)-2]
+			line := fmt.Sprintf("%-16s %8.1f/s ", label, last) + sparkline(s.rates, width-4-28)
+			lineStyle := lipgloss.NewStyle().Foreground(ColorWhite)
+			if i == selIdx && active {
+				lineStyle = lineStyle.Background(ColorBlue)
+			}
+			lines = append(lines, lineStyle.Render(line))
+		}
+		content = strings.Join(lines, "\n")
+	case ctx.DeckLoading:
+		content = renderLoadingPlaceholder(width-2, contentLines, ctx.SpinnerFrame)
+	default:
+		content = helpStyle.Render("No data available")
+	}
+
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+}
+
+// metricChart is one metric series and its points over the window.
+type metricChart struct {
+	series model.MetricSeries
+	points []model.MetricPoint
+}
+
+// MetricChartsDeck charts the metric series pushed over OTLP or collected
+// from scrape targets, one sparkline per series.
+type MetricChartsDeck struct {
+	data        []metricChart
+	unsupported bool
+}
+
+// NewMetricChartsDeck creates a new metric charts deck.
+func NewMetricChartsDeck() *MetricChartsDeck {
+	return &MetricChartsDeck{}
+}
+
+func (p *MetricChartsDeck) ID() string    { return "metric-charts" }
+func (p *MetricChartsDeck) Title() string { return "Metrics" }
+
+func (p *MetricChartsDeck) Refresh(_ model.LogQuerier, _ model.QueryOpts) {}
+
+func (p *MetricChartsDeck) TypeID() string                 { return "metric-charts" }
+func (p *MetricChartsDeck) DefaultInterval() time.Duration { return 10 * time.Second }
+
+func (p *MetricChartsDeck) FetchCmd(store model.LogQuerier, opts model.QueryOpts) tea.Cmd {
+	return func() tea.Msg {
+		metrics, ok := store.(model.MetricQuerier)
+		if !ok || !supports(store, "MetricRange") {
+			return DeckDataMsg{DeckTypeID: "metric-charts", Data: deckUnsupported{}}
+		}
+		opts = metricsScope(opts)
+		series, err := metrics.MetricSeries("", metricsTopN, opts)
+		if err != nil {
+			return DeckDataMsg{DeckTypeID: "metric-charts", Err: err}
+		}
+		charts := make([]metricChart, 0, len(series))
+		for _, s := range series {
+			points, err := metrics.MetricRange(s.Name, s.Labels, metricsStep, opts)
+			if err != nil {
+				return DeckDataMsg{DeckTypeID: "metric-charts", Err: err}
+			}
+			charts = append(charts, metricChart{series: s, points: points})
+		}
+		return DeckDataMsg{DeckTypeID: "metric-charts", Data: charts}
+	}
+}
+
+func (p *MetricChartsDeck) ApplyData(data any, err error) {
+	if err != nil {
+		return
+	}
+	switch data := data.(type) {
+	case deckUnsupported:
+		p.unsupported = true
+	case []metricChart:
+		p.unsupported = false
+		p.data = data
+	}
+}
+
+func (p *MetricChartsDeck) ContentLines(ctx ViewContext) int {
+	if ctx.ContentWidth < 80 {
+		return 6
+	}
+	return metricsTopN
+}
+
+func (p *MetricChartsDeck) ItemCount() int { return len(p.data) }
+
+func (p *MetricChartsDeck) OnSelect(_ ViewContext, _ int) tea.Cmd { return nil }
+
+// metricSeriesLabel renders a series as name{k=v,...}.
+func metricSeriesLabel(s model.MetricSeries) string {
+	if len(s.Labels) == 0 {
+		return s.Name
+	}
+	pairs := make([]string, 0, len(s.Labels))
+	for _, k := range slices.Sorted(maps.Keys(s.Labels)) {
+		pairs = append(pairs, k+"="+s.Labels[k])
+	}
+	return s.Name + "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (p *MetricChartsDeck) Render(ctx ViewContext, width, height int, active bool, selIdx int) string {
+	style := sectionStyle.Width(width).Height(height - 2)
+	if active {
+		style = activeSectionStyle.Width(width).Height(height - 2)
+	}
+	title := deckTitleStyle.Render(deckTitleWithBadges("Metrics", ctx))
+
+	contentLines := max(1, height-3)
+	var content string
+	switch {
+	case p.unsupported:
+		content = helpStyle.Render("Metrics are not kept by this store")
+	case len(p.data) > 0:
+		labelWidth := max(12, (width-4)/3)
+		var lines []string
+		for i, chart := range p.data[:min(len(p.data), contentLines)] {
+			avgs := make([]float64, len(chart.points))
+			for j, point := range chart.points {
+				avgs[j] = point.Avg
+			}
+			value := fmt.Sprintf("%10.4g", chart.series.LastValue)
+			if chart.series.Unit != "" {
+				value += " " + chart.series.Unit
+			}
+			line := fmt.Sprintf("%-*s %s ", labelWidth, truncatePreview(metricSeriesLabel(chart.series), labelWidth), value)
+			line += sparkline(avgs, width-4-lipgloss.Width(line))
+			lineStyle := lipgloss.NewStyle().Foreground(ColorWhite)
+			if i == selIdx && active {
+				lineStyle = lineStyle.Background(ColorBlue)
+			}
+			lines = append(lines, lineStyle.Render(line))
+		}
+		content = strings.Join(lines, "\n")
+	case ctx.DeckLoading:
+		content = renderLoadingPlaceholder(width-2, contentLines, ctx.SpinnerFrame)
+	default:
+		content = helpStyle.Render("No metrics received yet")
+	}
+
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+}
+
+// sparkBlocks are the eighth-height blocks sparklines and column charts
+// are drawn with.
+var sparkBlocks = []rune(" ▁▂▃▄▅▆▇█")
+
+// sparkline renders the last width values as one line of blocks scaled to
+// their maximum, right-aligned.
+func sparkline(values []float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	values = values[max(0, len(values)-width):]
+	high := 0.0
+	for _, v := range values {
+		high = max(high, v)
+	}
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", width-len(values)))
+	for _, v := range values {
+		level := 0
+		if high > 0 && v > 0 {
+			level = max(1, int(v/high*8))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// renderColumnChart draws the last width values as columns height rows
+// tall, scaled to their maximum, right-aligned.
+func renderColumnChart(values []float64, width, height int, style lipgloss.Style) string {
+	width, height = max(1, width), max(1, height)
+	values = values[max(0, len(values)-width):]
+	high := 0.0
+	for _, v := range values {
+		high = max(high, v)
+	}
+	// Each column's height in eighths of a row.
+	eighths := make([]int, len(values))
+	for i, v := range values {
+		if high > 0 && v > 0 {
+			eighths[i] = max(1, int(v/high*float64(height*8)))
+		}
+	}
+	pad := strings.Repeat(" ", width-len(values))
+	rows := make([]string, height)
+	for row := range rows {
+		floor := (height - 1 - row) * 8
+		var b strings.Builder
+		for _, e := range eighths {
+			b.WriteRune(sparkBlocks[min(8, max(0, e-floor))])
+		}
+		rows[row] = pad + style.Render(b.String())
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// metricsStore serves the Metrics page's optional queries.
+type metricsStore struct {
+	countingStore
+	ingest []model.IngestPoint
+	series []model.MetricSeries
+	ranges map[string][]model.MetricPoint
+}
+
+func (s *metricsStore) IngestRate(_, _ time.Duration, _ model.QueryOpts) ([]model.IngestPoint, error) {
+	return s.ingest, nil
+}
+
+func (s *metricsStore) MetricSeries(_ string, limit int, _ model.QueryOpts) ([]model.MetricSeries, error) {
+	return s.series[:min(limit, len(s.series))], nil
+}
+
+func (s *metricsStore) MetricRange(name string, _ map[string]string, _ time.Duration, _ model.QueryOpts) ([]model.MetricPoint, error) {
+	return s.ranges[name], nil
+}
+
+func fetchDeck(t *testing.T, d TickableDeck, store model.LogQuerier) {
+	t.Helper()
+	msg, ok := d.FetchCmd(store, model.QueryOpts{})().(DeckDataMsg)
+	if !ok || msg.Err != nil {
+		t.Fatalf("fetch = %+v", msg)
+	}
+	d.ApplyData(msg.Data, msg.Err)
+}
+
+func TestIngestDeck_RatesPerSecond(t *testing.T) {
+	t.Parallel()
+
+	lastMinute := time.Now().Truncate(time.Minute).Add(-time.Minute)
+	store := &metricsStore{ingest: []model.IngestPoint{
+		{Bucket: lastMinute.Add(-time.Minute), Count: 600, Bytes: 6000},
+		{Bucket: lastMinute, Count: 120, Bytes: 122880},
+	}}
+
+	logs, bytes := NewIngestRateDeck(), NewIngestBytesDeck()
+	fetchDeck(t, logs, store)
+	fetchDeck(t, bytes, store)
+
+	if view := logs.Render(ViewContext{ContentWidth: 120}, 80, 10, false, 0); !strings.Contains(view, "last min 2.0/s • peak 10.0/s") {
+		t.Fatalf("ingest rate header missing:\n%s", view)
+	}
+	if view := bytes.Render(ViewContext{ContentWidth: 120}, 80, 10, false, 0); !strings.Contains(view, "last min 2.0 KB/s") {
+		t.Fatalf("bytes/sec header missing:\n%s", view)
+	}
+
+	// Stores without the query say so instead of showing an empty chart.
+	plain := NewIngestRateDeck()
+	fetchDeck(t, plain, &countingStore{})
+	if view := plain.Render(ViewContext{}, 60, 8, false, 0); !strings.Contains(view, "Not served by this store") {
+		t.Fatalf("unsupported store view:\n%s", view)
+	}
+}
+
+func TestServiceRates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 4, 10, 17, 30, 0, time.UTC)
+	minute := func(m int) time.Time { return time.Date(2026, 3, 4, 10, m, 0, 0, time.UTC) }
+	services := serviceRates([]model.DimensionRate{
+		{Bucket: minute(16), Value: "api", Count: 60},
+		{Bucket: minute(17), Value: "api", Count: 30},
+		{Bucket: minute(16), Value: "web", Count: 600},
+		{Bucket: time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC), Value: "old", Count: 1}, // outside the window
+	}, now)
+
+	if len(services) != 2 || services[0].service != "web" || services[1].service != "api" {
+		t.Fatalf("services = %+v, want web then api", services)
+	}
+	api := services[1].rates
+	if len(api) != int(metricsWindow/metricsStep) || api[len(api)-2] != 1 || api[len(api)-1] != 0.5 {
+		t.Fatalf("api rates end with %v", api[len(api)-2:])
+	}
+}
+
+func TestMetricChartsDeck(t *testing.T) {
+	t.Parallel()
+
+	store := &metricsStore{
+		series: []model.MetricSeries{{Name: "queue_depth", Labels: map[string]string{"queue": "jobs"}, LastValue: 42}},
+		ranges: map[string][]model.MetricPoint{"queue_depth": {{Avg: 10}, {Avg: 42}}},
+	}
+	d := NewMetricChartsDeck()
+	fetchDeck(t, d, store)
+	view := d.Render(ViewContext{ContentWidth: 120}, 100, 8, false, 0)
+	if !strings.Contains(view, "queue_depth{queue=jobs}") || !strings.Contains(view, "42") {
+		t.Fatalf("metric chart missing the series:\n%s", view)
+	}
+
+	plain := NewMetricChartsDeck()
+	fetchDeck(t, plain, &countingStore{})
+	if view := plain.Render(ViewContext{}, 60, 8, false, 0); !strings.Contains(view, "not kept by this store") {
+		t.Fatalf("unsupported store view:\n%s", view)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	t.Parallel()
+
+	if got := sparkline([]float64{0, 4, 8}, 5); got != "   ▄█" {
+		t.Fatalf("sparkline = %q", got)
+	}
+	if got := sparkline([]float64{1, 2, 3, 4}, 2); got != "▆█" {
+		t.Fatalf("sparkline = %q, want the last two values", got)
+	}
+}
@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// patternSplitLevels is the order severities are drawn in a pattern's
+// severity split.
+var patternSplitLevels = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", "CRITICAL", "UNKNOWN"}
+
+// PatternTableDeck fills the Patterns view with every drain3 pattern: its
+// count, its trend over the last half hour, its severity split, and its
+// template. Enter opens the logs matching the selected pattern.
+type PatternTableDeck struct {
+	drain3Manager *Drain3Manager
+	drillDown     func(filter string) tea.Cmd
+	// patterns is a snapshot taken on each refresh, so the selection
+	// stays on the same row between refreshes.
+	patterns []PatternInfo
+}
+
+// NewPatternTableDeck creates a pattern table; drillDown opens the logs
+// matching a pattern's filter.
+func NewPatternTableDeck(drain3 *Drain3Manager, drillDown func(filter string) tea.Cmd) *PatternTableDeck {
+	return &PatternTableDeck{
+		drain3Manager: drain3,
+		drillDown:     drillDown,
+	}
+}
+
+func (p *PatternTableDeck) ID() string    { return "pattern-table" }
+func (p *PatternTableDeck) Title() string { return "Patterns" }
+
+func (p *PatternTableDeck) Refresh(_ model.LogQuerier, _ model.QueryOpts) {}
+
+// TypeID matches PatternsDeck's, so both refresh on the same signal.
+func (p *PatternTableDeck) TypeID() string                 { return "patterns" }
+func (p *PatternTableDeck) DefaultInterval() time.Duration { return 2 * time.Second }
+
+// FetchCmd returns a refresh signal (no DB query — patterns come from drain3).
+func (p *PatternTableDeck) FetchCmd(_ model.LogQuerier, _ model.QueryOpts) tea.Cmd {
+	return func() tea.Msg {
+		return DeckDataMsg{DeckTypeID: "patterns", Data: nil, Err: nil}
+	}
+}
+
+// ApplyData takes a new snapshot of the patterns.
+func (p *PatternTableDeck) ApplyData(_ any, _ error) {
+	p.snapshot()
+}
+
+func (p *PatternTableDeck) snapshot() {
+	if p.drain3Manager == nil {
+		return
+	}
+	p.patterns = p.drain3Manager.GetTopPatterns(0)
+}
+
+// ContentLines returns a large value so the grid scaler gives this deck
+// all available height (it is the only deck in the Patterns view).
+func (p *PatternTableDeck) ContentLines(_ ViewContext) int { return 100 }
+
+func (p *PatternTableDeck) ItemCount() int { return len(p.patterns) }
+
+func (p *PatternTableDeck) OnSelect(_ ViewContext, selIdx int) tea.Cmd {
+	if selIdx < 0 || selIdx >= len(p.patterns) || p.drillDown == nil {
+		return nil
+	}
+	return p.drillDown(p.patterns[selIdx].Filter)
+}
+
+func (p *PatternTableDeck) Render(ctx ViewContext, width, height int, active bool, selIdx int) string {
+	style := sectionStyle.Width(width).Height(height - 2)
+	if active {
+		style = activeSectionStyle.Width(width).Height(height - 2)
+	}
+	if p.patterns == nil {
+		p.snapshot()
+	}
+
+	titleText := "Patterns"
+	if len(p.patterns) > 0 {
+		titleText = fmt.Sprintf("Patterns (%d)", len(p.patterns))
+	}
+	title := deckTitleStyle.Render(deckTitleWithBadges(titleText, ctx))
+
+	contentLines := max(1, height-3)
+	var content string
+	switch {
+	case p.drain3Manager == nil:
+		content = helpStyle.Render("Pattern extraction not available")
+	case len(p.patterns) > 0:
+		content = p.renderTable(width-4, contentLines, active, selIdx)
+	case ctx.DeckLoading:
+		content = renderLoadingPlaceholder(width-2, contentLines, ctx.SpinnerFrame)
+	default:
+		content = helpStyle.Render("Extracting patterns")
+	}
+
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+}
+
+// renderTable renders a header and as many patterns as fit, scrolled to
+// keep the selected one in view.
+func (p *PatternTableDeck) renderTable(width, lines int, active bool, selIdx int) string {
+	const countWidth, trendWidth, splitWidth = 7, 12, 10
+	templateWidth := max(1, width-countWidth-trendWidth-splitWidth-3)
+
+	header := fmt.Sprintf("%*s %-*s %-*s %s", countWidth, "COUNT", trendWidth, "TREND 30m", splitWidth, "SEVERITY", "TEMPLATE")
+	out := []string{lipgloss.NewStyle().Foreground(ColorGray).Bold(true).Render(fitWidth(header, width))}
+
+	rows := max(1, lines-1)
+	start := 0
+	if selIdx >= rows {
+		start = selIdx - rows + 1
+	}
+	end := min(len(p.patterns), start+rows)
+	for i := start; i < end; i++ {
+		pat := p.patterns[i]
+		trend := make([]float64, len(pat.Trend))
+		for j, n := range pat.Trend {
+			trend[j] = float64(n)
+		}
+		count := lipgloss.NewStyle().Foreground(ColorWhite).Render(fmt.Sprintf("%*d", countWidth, pat.Count))
+		spark := lipgloss.NewStyle().Foreground(ColorBlue).Render(sparkline(trend, trendWidth))
+		template := fitWidth(pat.Template, templateWidth)
+		if active && i == selIdx {
+			template = lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render(template)
+		} else {
+			template = lipgloss.NewStyle().Foreground(ColorWhite).Render(template)
+		}
+		out = append(out, count+" "+spark+" "+renderSeveritySplit(pat.Levels, splitWidth)+" "+template)
+	}
+	return strings.Join(out, "\n")
+}
+
+// renderSeveritySplit draws a bar width cells wide split between
+// severities in proportion to their counts; every severity present gets at
+// least one cell while there is room.
+func renderSeveritySplit(levels map[string]int, width int) string {
+	total := 0
+	for _, n := range levels {
+		total += n
+	}
+	if total == 0 {
+		return lipgloss.NewStyle().Foreground(ColorGray).Render(strings.Repeat("░", width))
+	}
+
+	var b strings.Builder
+	used, cum := 0, 0
+	for _, level := range patternSplitLevels {
+		n := levels[level]
+		if n == 0 {
+			continue
+		}
+		cum += n
+		cells := min(max(1, cum*width/total-used), width-used)
+		if cells <= 0 {
+			break
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(GetSeverityColor(level)).Render(strings.Repeat("█", cells)))
+		used += cells
+	}
+	if used < width {
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorGray).Render(strings.Repeat("░", width-used)))
+	}
+	return b.String()
+}
+
+// drillDownToPattern filters the log list by a pattern's filter, then
+// opens the log viewer on the result. The filter stays in effect until
+// cleared like any other.
+func (m *DashboardModel) drillDownToPattern(filter string) tea.Cmd {
+	regex, err := lintFilterPattern(filter)
+	if err != nil {
+		return nil
+	}
+	m.filterInput.SetValue(filter)
+	m.filterRegex = regex
+	m.filterErr = nil
+	m.filterActive = false
+	m.filterInput.Blur()
+	m.recordQuery(HistoryFilter, filter)
+	m.reloadLogEntries()
+	m.selectedLogIndex = max(0, len(m.logEntries)-1)
+	return actionMsg(ActionMsg{Action: ActionPushModal, Payload: NewLogViewerModal(m)})
+}
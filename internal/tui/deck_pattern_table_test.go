@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPatternTableDeck_EnterDrillsDownToPattern(t *testing.T) {
+	t.Parallel()
+
+	store := &countingStore{
+		recentLogs: []model.LogRecord{{Message: "disk full on /var", Level: "ERROR", Timestamp: time.Now()}},
+	}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	now := time.Now()
+	m.applyDrain3Records([]model.LogRecord{
+		{Message: "user alice logged in", Level: "INFO", Timestamp: now},
+		{Message: "user bob logged in", Level: "INFO", Timestamp: now},
+		{Message: "user carol logged in", Level: "INFO", Timestamp: now},
+		{Message: "disk full on /var", Level: "ERROR", Timestamp: now},
+	}, 4)
+
+	for i, vw := range m.activePage().Views {
+		if vw.ID == "patterns" {
+			m.activateView(i)
+		}
+	}
+	if len(m.decks) != 1 || m.decks[0].ID() != "pattern-table" {
+		t.Fatalf("decks = %v, want the pattern table", m.decks)
+	}
+	m.activeSection = SectionDecks
+	m.decks[0].(*PatternTableDeck).ApplyData(nil, nil)
+
+	// Down selects the second, less frequent pattern.
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.deckSelIdx[0] != 1 {
+		t.Fatalf("selection = %d, want 1", m.deckSelIdx[0])
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter on a pattern should return a command")
+	}
+	m.Update(cmd())
+
+	if top := m.TopModal(); top == nil || top.ID() != "logviewer" {
+		t.Fatalf("top modal = %v, want logviewer", top)
+	}
+	re, err := regexp.Compile(store.lastLogPattern)
+	if err != nil || !re.MatchString("disk full on /var") || re.MatchString("user alice logged in") {
+		t.Fatalf("log query pattern = %q, want one matching only the disk pattern", store.lastLogPattern)
+	}
+	if m.filterInput.Value() != store.lastLogPattern || m.filterRegex == nil {
+		t.Fatalf("filter = %q, want the pattern's filter applied", m.filterInput.Value())
+	}
+}
+
+func TestRenderSeveritySplit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		levels map[string]int
+		want   int // filled cells
+	}{
+		{nil, 0},
+		{map[string]int{"INFO": 1}, 10},
+		{map[string]int{"INFO": 99, "ERROR": 1}, 10},
+		{map[string]int{"TRACE": 1, "DEBUG": 1, "INFO": 1, "WARN": 1, "ERROR": 1, "FATAL": 1, "CRITICAL": 1, "UNKNOWN": 100}, 10},
+	}
+	for _, tt := range tests {
+		got := renderSeveritySplit(tt.levels, 10)
+		if w := lipgloss.Width(got); w != 10 {
+			t.Errorf("split %v is %d cells wide, want 10", tt.levels, w)
+		}
+		if n := strings.Count(got, "█"); n != tt.want {
+			t.Errorf("split %v fills %d cells, want %d", tt.levels, n, tt.want)
+		}
+	}
+}
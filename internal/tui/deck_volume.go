@@ -0,0 +1,220 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// volumeBucketWidths are the bucket widths Enter cycles through on the
+// volume deck.
+var volumeBucketWidths = []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour}
+
+// VolumeDeck displays log volume as a histogram of fixed-width time buckets
+// ending now, each bar stacked by severity.
+type VolumeDeck struct {
+	data     []model.MinuteCounts
+	widthIdx int // index into volumeBucketWidths
+}
+
+// NewVolumeDeck creates a new volume histogram deck.
+func NewVolumeDeck() *VolumeDeck {
+	return &VolumeDeck{
+		data: make([]model.MinuteCounts, 0),
+	}
+}
+
+func (p *VolumeDeck) ID() string    { return "volume" }
+func (p *VolumeDeck) Title() string { return "Volume" }
+
+func (p *VolumeDeck) Refresh(_ model.LogQuerier, _ model.QueryOpts) {}
+
+func (p *VolumeDeck) TypeID() string                 { return "volume" }
+func (p *VolumeDeck) DefaultInterval() time.Duration { return 2 * time.Second }
+
+func (p *VolumeDeck) FetchCmd(store model.LogQuerier, opts model.QueryOpts) tea.Cmd {
+	return func() tea.Msg {
+		rows, err := store.SeverityCountsByMinute(opts)
+		return DeckDataMsg{DeckTypeID: "volume", Data: rows, Err: err}
+	}
+}
+
+func (p *VolumeDeck) ApplyData(data any, err error) {
+	if err != nil {
+		return
+	}
+	if rows, ok := data.([]model.MinuteCounts); ok {
+		p.data = append([]model.MinuteCounts(nil), rows...)
+	}
+}
+
+func (p *VolumeDeck) ContentLines(ctx ViewContext) int {
+	if len(p.data) == 0 {
+		return 1
+	}
+	deckHeight := 8
+	if ctx.ContentWidth < 80 {
+		deckHeight = 6
+	}
+	return deckHeight
+}
+
+func (p *VolumeDeck) ItemCount() int {
+	return len(p.data)
+}
+
+// BucketWidth returns the width of the deck's histogram buckets.
+func (p *VolumeDeck) BucketWidth() time.Duration {
+	return volumeBucketWidths[p.widthIdx]
+}
+
+func (p *VolumeDeck) Render(ctx ViewContext, width, height int, active bool, _ int) string {
+	style := sectionStyle.Width(width).Height(height - 2)
+	if active {
+		style = activeSectionStyle.Width(width).Height(height - 2)
+	}
+
+	leftTitle := deckTitleWithBadges("Volume", ctx)
+	rightHint := fmt.Sprintf("%s buckets • Enter: change", formatBucketWidth(p.BucketWidth()))
+	headerText := leftTitle
+	if spacer := width - 4 - lipgloss.Width(leftTitle) - lipgloss.Width(rightHint); spacer > 0 {
+		headerText = leftTitle + strings.Repeat(" ", spacer) + rightHint
+	}
+	title := deckTitleStyle.Render(headerText)
+
+	overhead := 3
+	contentLines := height - overhead
+	if contentLines < 1 {
+		contentLines = 1
+	}
+
+	var content string
+	if len(p.data) > 0 {
+		content = p.renderChart(width, contentLines, time.Now())
+	} else if ctx.DeckLoading {
+		content = renderLoadingPlaceholder(width-2, contentLines, ctx.SpinnerFrame)
+	} else {
+		content = helpStyle.Render("No data available")
+	}
+
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+}
+
+// OnSelect switches to the next bucket width.
+func (p *VolumeDeck) OnSelect(_ ViewContext, _ int) tea.Cmd {
+	p.widthIdx = (p.widthIdx + 1) % len(volumeBucketWidths)
+	return nil
+}
+
+// volumeBuckets sums the per-minute counts into n buckets of width ending
+// with the bucket that holds now, oldest first.
+func volumeBuckets(data []model.MinuteCounts, width time.Duration, n int, now time.Time) (time.Time, []model.MinuteCounts) {
+	end := now.Truncate(width).Add(width)
+	start := end.Add(-time.Duration(n) * width)
+	buckets := make([]model.MinuteCounts, n)
+	for _, mc := range data {
+		if mc.Minute.Before(start) || !mc.Minute.Before(end) {
+			continue
+		}
+		b := &buckets[int(mc.Minute.Sub(start)/width)]
+		b.Trace += mc.Trace
+		b.Debug += mc.Debug
+		b.Info += mc.Info
+		b.Warn += mc.Warn
+		b.Error += mc.Error
+		b.Fatal += mc.Fatal
+		b.Total += mc.Total
+	}
+	return start, buckets
+}
+
+func (p *VolumeDeck) renderChart(deckWidth, availHeight int, now time.Time) string {
+	showLegend := deckWidth >= 60
+	legendWidth, legendGap := 0, 0
+	if showLegend {
+		legendWidth, legendGap = 16, 2
+	}
+
+	yAxisWidthEstimate := 4
+	if deckWidth >= 100 {
+		yAxisWidthEstimate = 6
+	}
+	chartAreaWidth := deckWidth - yAxisWidthEstimate - 1 - legendGap - legendWidth - 2
+	if chartAreaWidth < 10 {
+		chartAreaWidth = 10
+	}
+	chartHeight := availHeight - 2
+	if chartHeight < 4 {
+		chartHeight = 4
+	}
+
+	const barWidth, stride = 1, 2
+	numBars := max(1, chartAreaWidth/stride)
+	width := p.BucketWidth()
+	start, buckets := volumeBuckets(p.data, width, numBars, now)
+
+	rawMax := int64(0)
+	var totals model.MinuteCounts
+	for _, b := range buckets {
+		rawMax = max(rawMax, b.Total)
+		totals.Trace += b.Trace
+		totals.Debug += b.Debug
+		totals.Info += b.Info
+		totals.Warn += b.Warn
+		totals.Error += b.Error
+		totals.Fatal += b.Fatal
+		totals.Total += b.Total
+	}
+	yCfg := computeYAxis(rawMax, 3)
+
+	barStyles := make(map[string]lipgloss.Style, len(chartSeverities))
+	for _, sev := range chartSeverities {
+		barStyles[sev.name] = lipgloss.NewStyle().Foreground(lipgloss.Color(sev.color))
+	}
+
+	var legendLines []string
+	if showLegend {
+		legendLines = buildLegendLines(totals, chartHeight+2)
+	}
+	withLegend := func(line string, i int) string {
+		if showLegend && i < len(legendLines) {
+			return line + strings.Repeat(" ", legendGap) + legendLines[i]
+		}
+		return line
+	}
+
+	outputLines := make([]string, 0, chartHeight+2)
+	for row := 0; row < chartHeight; row++ {
+		rowTopVal := yCfg.Max - (yCfg.Max*int64(row))/int64(chartHeight)
+		rowBotVal := yCfg.Max - (yCfg.Max*int64(row+1))/int64(chartHeight)
+
+		var barArea strings.Builder
+		for i, b := range buckets {
+			barArea.WriteString(renderBarCell(stackedSegments(b), b.Total, yCfg.Max, rowBotVal, rowTopVal, barWidth, barStyles))
+			if i < numBars-1 {
+				barArea.WriteString(" ")
+			}
+		}
+		outputLines = append(outputLines, withLegend(renderYLabel(yCfg, row, chartHeight)+"│"+barArea.String(), row))
+	}
+
+	xAxisLine := strings.Repeat(" ", yCfg.LabelWidth) + "└" + strings.Repeat("─┴", numBars-1) + "─"
+	outputLines = append(outputLines, withLegend(xAxisLine, chartHeight))
+	xLabels := buildAdaptiveTimeLabels(start, start.Add(time.Duration(numBars)*width), numBars, yCfg.LabelWidth+1, stride, chartAreaWidth)
+	outputLines = append(outputLines, withLegend(xLabels, chartHeight+1))
+
+	return strings.Join(outputLines, "\n")
+}
+
+// formatBucketWidth renders a bucket width as "5m" or "1h".
+func formatBucketWidth(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestVolumeBuckets(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 4, 10, 17, 30, 0, time.UTC)
+	minute := func(m int) time.Time { return time.Date(2026, 3, 4, 10, m, 0, 0, time.UTC) }
+	data := []model.MinuteCounts{
+		{Minute: minute(2), Info: 9, Total: 9}, // before the first bucket
+		{Minute: minute(5), Info: 1, Total: 1},
+		{Minute: minute(9), Error: 2, Total: 2},
+		{Minute: minute(17), Warn: 3, Info: 1, Total: 4},
+	}
+
+	start, buckets := volumeBuckets(data, 5*time.Minute, 3, now)
+	if !start.Equal(minute(5)) || len(buckets) != 3 {
+		t.Fatalf("start = %v, %d buckets; want 10:05 and 3", start, len(buckets))
+	}
+	if b := buckets[0]; b.Total != 3 || b.Info != 1 || b.Error != 2 {
+		t.Fatalf("10:05 bucket = %+v", b)
+	}
+	if buckets[1].Total != 0 {
+		t.Fatalf("10:10 bucket = %+v, want empty", buckets[1])
+	}
+	if b := buckets[2]; b.Total != 4 || b.Warn != 3 {
+		t.Fatalf("10:15 bucket = %+v", b)
+	}
+}
+
+func TestVolumeDeck_CyclesBucketWidth(t *testing.T) {
+	t.Parallel()
+
+	d := NewVolumeDeck()
+	d.ApplyData([]model.MinuteCounts{{Minute: time.Now().UTC().Truncate(time.Minute), Info: 5, Total: 5}}, nil)
+
+	var widths []string
+	for range len(volumeBucketWidths) + 1 {
+		widths = append(widths, formatBucketWidth(d.BucketWidth()))
+		d.OnSelect(ViewContext{}, 0)
+	}
+	if got := strings.Join(widths, ","); got != "1m,5m,15m,1h,1m" {
+		t.Fatalf("bucket widths = %s", got)
+	}
+	if view := d.Render(ViewContext{ContentWidth: 120}, 80, 12, false, 0); !strings.Contains(view, "5m buckets") {
+		t.Fatalf("render lacks the bucket width:\n%s", view)
+	}
+}
@@ -2,6 +2,8 @@ package tui
 
 import (
 	"github.com/tinytelemetry/tiny-telemetry/internal/drain3"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -9,11 +11,23 @@ import (
 	goDrain "github.com/jaeyo/go-drain3/pkg/drain3"
 )
 
+// patternTrendMinutes is how many minutes of per-pattern counts are kept
+// for the trend column.
+const patternTrendMinutes = 30
+
 // Drain3Manager manages the drain3 instance for pattern extraction
 type Drain3Manager struct {
 	drain      *drain3.Drain
 	lastReset  time.Time
 	totalCount int
+	stats      map[int64]*patternStats // by cluster ID, for records added with AddLogRecord
+}
+
+// patternStats is what a cluster's records had in common besides their
+// template: their severities and when they were logged.
+type patternStats struct {
+	levels  map[string]int // normalized severity -> count
+	minutes map[int64]int  // unix minute -> count
 }
 
 // PatternInfo represents a log pattern with its statistics
@@ -21,6 +35,13 @@ type PatternInfo struct {
 	Template   string
 	Count      int
 	Percentage float64
+	// Levels counts the pattern's records by normalized severity, and
+	// Trend by minute over the last patternTrendMinutes minutes, oldest
+	// first. Both only cover records added with AddLogRecord.
+	Levels map[string]int
+	Trend  []int
+	// Filter is a regex matching the messages of the pattern.
+	Filter string
 }
 
 // NewDrain3Manager creates a new drain3 manager with optimized settings for log pattern extraction
@@ -36,6 +57,7 @@ func NewDrain3Manager() *Drain3Manager {
 	return &Drain3Manager{
 		drain:     drain3.New(config),
 		lastReset: time.Now(),
+		stats:     make(map[int64]*patternStats),
 	}
 }
 
@@ -55,6 +77,59 @@ func (dm *Drain3Manager) AddLogMessage(message string) {
 	dm.totalCount++
 }
 
+// AddLogRecord processes a record's message like AddLogMessage and also
+// counts the record's severity and minute against its pattern.
+func (dm *Drain3Manager) AddLogRecord(r model.LogRecord) {
+	if dm.drain == nil || strings.TrimSpace(r.Message) == "" {
+		return
+	}
+
+	cluster, _, err := dm.drain.Drain.AddLogMessage(r.Message)
+	if err != nil || cluster == nil {
+		return
+	}
+	dm.totalCount++
+
+	st := dm.stats[cluster.ClusterId]
+	if st == nil {
+		dm.pruneStats()
+		st = &patternStats{levels: make(map[string]int), minutes: make(map[int64]int)}
+		dm.stats[cluster.ClusterId] = st
+	}
+	st.levels[normalizeSeverityLevel(r.Level)]++
+
+	ts := r.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	minute := ts.Unix() / 60
+	st.minutes[minute]++
+	if len(st.minutes) > patternTrendMinutes {
+		for m := range st.minutes {
+			if m <= minute-patternTrendMinutes {
+				delete(st.minutes, m)
+			}
+		}
+	}
+}
+
+// pruneStats drops the stats of clusters drain3 has evicted once there
+// are twice as many as it keeps.
+func (dm *Drain3Manager) pruneStats() {
+	if len(dm.stats) < 2*dm.drain.Drain.MaxClusters {
+		return
+	}
+	live := make(map[int64]bool)
+	for _, cluster := range dm.drain.GetClusters() {
+		live[cluster.ClusterId] = true
+	}
+	for id := range dm.stats {
+		if !live[id] {
+			delete(dm.stats, id)
+		}
+	}
+}
+
 // GetTopPatterns returns the top N patterns by frequency
 func (dm *Drain3Manager) GetTopPatterns(limit int) []PatternInfo {
 	if dm.drain == nil {
@@ -67,15 +142,28 @@ func (dm *Drain3Manager) GetTopPatterns(limit int) []PatternInfo {
 	}
 
 	// Convert to PatternInfo and sort by count
+	nowMinute := time.Now().Unix() / 60
 	patterns := make([]PatternInfo, 0, len(clusters))
 	for _, cluster := range clusters {
 		template := formatTemplate(cluster)
 		if template != "" {
-			patterns = append(patterns, PatternInfo{
+			info := PatternInfo{
 				Template:   template,
 				Count:      int(cluster.Size),
 				Percentage: 0, // Will calculate after sorting
-			})
+				Trend:      make([]int, patternTrendMinutes),
+				Filter:     templateFilter(cluster.LogTemplateTokens),
+			}
+			if st := dm.stats[cluster.ClusterId]; st != nil {
+				info.Levels = make(map[string]int, len(st.levels))
+				for level, n := range st.levels {
+					info.Levels[level] = n
+				}
+				for i := range info.Trend {
+					info.Trend[i] = st.minutes[nowMinute-int64(patternTrendMinutes-1-i)]
+				}
+			}
+			patterns = append(patterns, info)
 		}
 	}
 
@@ -126,12 +214,28 @@ func formatTemplate(cluster *goDrain.LogCluster) string {
 	return template
 }
 
+// templateFilter returns a regex matching the messages a template
+// describes: its literal tokens as they are, and any token for each
+// parameter, separated by single spaces as drain3 splits them.
+func templateFilter(tokens []string) string {
+	parts := make([]string, len(tokens))
+	for i, token := range tokens {
+		if token == "<*>" {
+			parts[i] = `\S*`
+		} else {
+			parts[i] = regexp.QuoteMeta(token)
+		}
+	}
+	return `^\s*` + strings.Join(parts, " ") + `\s*$`
+}
+
 // Reset clears the drain3 instance and starts fresh
 func (dm *Drain3Manager) Reset() {
 	if dm.drain != nil {
 		_ = dm.drain.Reset()
 		dm.lastReset = time.Now()
 		dm.totalCount = 0
+		dm.stats = make(map[int64]*patternStats)
 	}
 }
 
@@ -1,7 +1,11 @@
 package tui
 
 import (
+	"regexp"
 	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 )
 
 func TestDrain3Manager_AddLogMessage(t *testing.T) {
@@ -117,3 +121,54 @@ func TestDrain3Manager_Percentages(t *testing.T) {
 		t.Errorf("total percentage = %.1f, want ~100", totalPct)
 	}
 }
+
+func TestDrain3Manager_AddLogRecord(t *testing.T) {
+	t.Parallel()
+	dm := NewDrain3Manager()
+
+	now := time.Now()
+	dm.AddLogRecord(model.LogRecord{Message: "Connection refused from 192.168.1.1", Level: "ERROR", Timestamp: now})
+	dm.AddLogRecord(model.LogRecord{Message: "Connection refused from 10.0.0.1", Level: "warning", Timestamp: now})
+	dm.AddLogRecord(model.LogRecord{Message: "Connection refused from 172.16.0.1", Level: "ERROR", Timestamp: now.Add(-5 * time.Minute)})
+	dm.AddLogRecord(model.LogRecord{Message: "Connection refused from 172.16.0.2", Level: "ERROR", Timestamp: now.Add(-time.Hour)})
+
+	patterns := dm.GetTopPatterns(0)
+	if len(patterns) != 1 {
+		t.Fatalf("patterns = %d, want 1", len(patterns))
+	}
+	p := patterns[0]
+	if p.Levels["ERROR"] != 3 || p.Levels["WARN"] != 1 {
+		t.Errorf("levels = %v, want ERROR:3 WARN:1", p.Levels)
+	}
+	if len(p.Trend) != patternTrendMinutes {
+		t.Fatalf("trend = %d minutes, want %d", len(p.Trend), patternTrendMinutes)
+	}
+	sum := 0
+	for _, n := range p.Trend {
+		sum += n
+	}
+	if sum != 3 || p.Trend[patternTrendMinutes-1] != 2 {
+		t.Errorf("trend = %v, want 3 records in the window, 2 in the last minute", p.Trend)
+	}
+
+	re := regexp.MustCompile(p.Filter)
+	for _, msg := range []string{"Connection refused from 192.168.1.1", "Connection refused from localhost"} {
+		if !re.MatchString(msg) {
+			t.Errorf("filter %q does not match %q", p.Filter, msg)
+		}
+	}
+	for _, msg := range []string{"Connection accepted from 10.0.0.1", "Connection refused from 10.0.0.1 after retry"} {
+		if re.MatchString(msg) {
+			t.Errorf("filter %q matches %q", p.Filter, msg)
+		}
+	}
+}
+
+func TestTemplateFilter_QuotesLiterals(t *testing.T) {
+	t.Parallel()
+	got := templateFilter([]string{"GET", "/api/v1?x=1", "took", "<*>"})
+	want := `^\s*GET /api/v1\?x=1 took \S*\s*$`
+	if got != want {
+		t.Errorf("templateFilter = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// logExportFormats are the formats the TUI exports logs in, in the order
+// the export modal cycles through them.
+var logExportFormats = []string{"ndjson", "csv", "raw"}
+
+// exportCSVHeader is the column row of a CSV export, the same as
+// /api/export's.
+var exportCSVHeader = []string{"timestamp", "orig_timestamp", "level", "level_num", "message", "raw_line", "service", "hostname", "pid", "source", "app", "attributes", "body_json"}
+
+// exportLog is a record as an NDJSON export writes it, with the field
+// names of /api/export.
+type exportLog struct {
+	Timestamp     time.Time         `json:"timestamp"`
+	OrigTimestamp *time.Time        `json:"orig_timestamp,omitempty"`
+	Level         string            `json:"level"`
+	LevelNum      int               `json:"level_num"`
+	Message       string            `json:"message"`
+	RawLine       string            `json:"raw_line"`
+	Service       string            `json:"service"`
+	Hostname      string            `json:"hostname"`
+	PID           int               `json:"pid"`
+	Attributes    map[string]string `json:"attributes"`
+	Source        string            `json:"source"`
+	App           string            `json:"app"`
+	BodyJSON      string            `json:"body_json,omitempty"`
+}
+
+func toExportLog(r model.LogRecord) exportLog {
+	l := exportLog{
+		Timestamp:  r.Timestamp,
+		Level:      r.Level,
+		LevelNum:   r.LevelNum,
+		Message:    r.Message,
+		RawLine:    r.RawLine,
+		Service:    r.Service,
+		Hostname:   r.Hostname,
+		PID:        r.PID,
+		Attributes: r.Attributes,
+		Source:     r.Source,
+		App:        r.App,
+		BodyJSON:   r.BodyJSON,
+	}
+	if !r.OrigTimestamp.IsZero() {
+		orig := r.OrigTimestamp
+		l.OrigTimestamp = &orig
+	}
+	return l
+}
+
+// writeLogExport writes records to w in format: ndjson, csv, or raw (each
+// record's raw line, or its message when none was kept).
+func writeLogExport(w io.Writer, records []model.LogRecord, format string) error {
+	switch format {
+	case "ndjson":
+		enc := json.NewEncoder(w)
+		for _, r := range records {
+			if err := enc.Encode(toExportLog(r)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(exportCSVHeader)
+		for _, r := range records {
+			orig := ""
+			if !r.OrigTimestamp.IsZero() {
+				orig = r.OrigTimestamp.Format(time.RFC3339Nano)
+			}
+			attrs, _ := json.Marshal(r.Attributes)
+			cw.Write([]string{
+				r.Timestamp.Format(time.RFC3339Nano),
+				orig,
+				r.Level,
+				strconv.Itoa(r.LevelNum),
+				r.Message,
+				r.RawLine,
+				r.Service,
+				r.Hostname,
+				strconv.Itoa(r.PID),
+				r.Source,
+				r.App,
+				string(attrs),
+				r.BodyJSON,
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	case "raw":
+		for _, r := range records {
+			line := r.RawLine
+			if line == "" {
+				line = r.Message
+			}
+			if _, err := io.WriteString(w, strings.TrimRight(line, "\n")+"\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported export format: %q", format)
+	}
+}
+
+// exportLogsToFile writes records to path in format, expanding a leading
+// ~/ to the home directory, and returns the path written.
+func exportLogsToFile(path string, records []model.LogRecord, format string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", fmt.Errorf("export path is required")
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, rest)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", fmt.Errorf("create export dir: %w", err)
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if err := writeLogExport(f, records, format); err != nil {
+		f.Close()
+		return "", fmt.Errorf("write export: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("write export: %w", err)
+	}
+	return path, nil
+}
+
+// exportExtension returns the file extension of format.
+func exportExtension(format string) string {
+	if format == "raw" {
+		return ".log"
+	}
+	return "." + format
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWriteLogExport(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	records := []model.LogRecord{
+		{Timestamp: ts, Level: "ERROR", Message: "payment failed", RawLine: `{"msg":"payment failed"}`, Service: "api", Attributes: map[string]string{"order": "42"}},
+		{Timestamp: ts.Add(time.Second), Level: "INFO", Message: "retrying, once"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeLogExport(&buf, records, "ndjson"); err != nil {
+		t.Fatalf("ndjson: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var first map[string]any
+	if len(lines) != 2 || json.Unmarshal([]byte(lines[0]), &first) != nil || first["service"] != "api" || first["attributes"].(map[string]any)["order"] != "42" {
+		t.Fatalf("ndjson = %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeLogExport(&buf, records, "csv"); err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(rows) != 3 || rows[0][4] != "message" || rows[2][4] != "retrying, once" {
+		t.Fatalf("csv = %q, %v", rows, err)
+	}
+
+	buf.Reset()
+	if err := writeLogExport(&buf, records, "raw"); err != nil {
+		t.Fatalf("raw: %v", err)
+	}
+	if got, want := buf.String(), "{\"msg\":\"payment failed\"}\nretrying, once\n"; got != want {
+		t.Fatalf("raw = %q, want %q", got, want)
+	}
+
+	if err := writeLogExport(&buf, records, "xml"); err == nil {
+		t.Fatal("unknown format accepted")
+	}
+}
+
+func TestExportModal_WritesChosenSet(t *testing.T) {
+	t.Parallel()
+
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	m.logEntries = []model.LogRecord{
+		{Timestamp: time.Now(), Level: "INFO", Message: "one"},
+		{Timestamp: time.Now(), Level: "ERROR", Message: "two"},
+	}
+	m.togglePin(m.logEntries[1])
+
+	dir := t.TempDir()
+	e := NewExportModal(m)
+	if !strings.HasSuffix(e.input.Value(), ".ndjson") {
+		t.Fatalf("default path = %q, want an ndjson file", e.input.Value())
+	}
+	e.input.SetValue(filepath.Join(dir, "out.ndjson"))
+	// Tab moves to csv and renames the file; Shift+Tab picks the pinned logs.
+	e.Update(tea.KeyMsg{Type: tea.KeyTab})
+	e.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if pop, _ := e.Update(tea.KeyMsg{Type: tea.KeyEnter}); pop || e.err != nil {
+		t.Fatalf("export: pop=%v err=%v", pop, e.err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "out.csv"))
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll(); err != nil || len(rows) != 2 || rows[1][4] != "two" {
+		t.Fatalf("exported rows = %q, %v; want the header and the pinned log", rows, err)
+	}
+}
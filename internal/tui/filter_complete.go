@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"regexp"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// filterSuggestLimit is how many attribute values the filter offers.
+	filterSuggestLimit = 5
+	// filterSuggestDelay debounces value lookups while the filter is typed.
+	filterSuggestDelay = 150 * time.Millisecond
+)
+
+// attributeTokenPattern matches a trailing key=prefix token in a filter
+// pattern, such as "http.method=PO" at the end of "timeout http.method=PO".
+var attributeTokenPattern = regexp.MustCompile(`(?:^|\s)([A-Za-z_][\w.\-]*)=([^\s=]*)$`)
+
+// filterCompleteMsg asks for attribute values once typing pauses.
+type filterCompleteMsg struct {
+	seq    int
+	key    string
+	prefix string
+}
+
+// filterSuggestionsMsg carries the values found for a filterCompleteMsg.
+type filterSuggestionsMsg struct {
+	seq    int
+	values []string
+}
+
+// attributeCompletionTarget returns the key and value prefix of a trailing
+// key=prefix token in pattern.
+func attributeCompletionTarget(pattern string) (key, prefix string, ok bool) {
+	match := attributeTokenPattern.FindStringSubmatch(pattern)
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// scheduleFilterSuggestions drops the current suggestions and, when the
+// filter ends in a key=prefix token, looks up values after a short pause.
+func (m *DashboardModel) scheduleFilterSuggestions() tea.Cmd {
+	m.clearFilterSuggestions()
+	key, prefix, ok := attributeCompletionTarget(m.filterInput.Value())
+	if !ok || m.store == nil {
+		return nil
+	}
+	msg := filterCompleteMsg{seq: m.filterSuggestSeq, key: key, prefix: prefix}
+	return tea.Tick(filterSuggestDelay, func(time.Time) tea.Msg { return msg })
+}
+
+// lookupFilterSuggestions queries the store for values of the token in msg
+// unless the filter changed since it was scheduled.
+func (m *DashboardModel) lookupFilterSuggestions(msg filterCompleteMsg) tea.Cmd {
+	if msg.seq != m.filterSuggestSeq || !m.filterActive {
+		return nil
+	}
+	store, opts := m.store, m.logQueryOpts()
+	return func() tea.Msg {
+		counts, err := store.DistinctAttributeValues(msg.key, msg.prefix, filterSuggestLimit, opts)
+		if err != nil {
+			return filterSuggestionsMsg{seq: msg.seq}
+		}
+		values := make([]string, len(counts))
+		for i, c := range counts {
+			values[i] = c.Value
+		}
+		return filterSuggestionsMsg{seq: msg.seq, values: values}
+	}
+}
+
+// completeFilter replaces the value prefix of the trailing key=prefix token
+// with value, escaped so the filter regex matches it literally.
+func (m *DashboardModel) completeFilter(value string) {
+	pattern := m.filterInput.Value()
+	_, prefix, ok := attributeCompletionTarget(pattern)
+	if !ok {
+		return
+	}
+	m.filterInput.SetValue(pattern[:len(pattern)-len(prefix)] + regexp.QuoteMeta(value))
+	m.filterInput.CursorEnd()
+	regex, err := lintFilterPattern(m.filterInput.Value())
+	m.filterErr = err
+	if err == nil {
+		m.filterRegex = regex
+	}
+	m.clearFilterSuggestions()
+}
+
+// clearFilterSuggestions drops the offered values and any lookup in flight.
+func (m *DashboardModel) clearFilterSuggestions() {
+	m.filterSuggestions = nil
+	m.filterSuggestSeq++
+}
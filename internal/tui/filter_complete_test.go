@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAttributeCompletionTarget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern, key, prefix string
+		ok                   bool
+	}{
+		{"http.method=PO", "http.method", "PO", true},
+		{"timeout env=", "env", "", true},
+		{"env=prod timeout", "", "", false},
+		{"a==b", "", "", false},
+		{"plain text", "", "", false},
+	}
+	for _, tt := range tests {
+		key, prefix, ok := attributeCompletionTarget(tt.pattern)
+		if key != tt.key || prefix != tt.prefix || ok != tt.ok {
+			t.Errorf("attributeCompletionTarget(%q) = %q, %q, %v; want %q, %q, %v", tt.pattern, key, prefix, ok, tt.key, tt.prefix, tt.ok)
+		}
+	}
+}
+
+func TestFilterInput_CompletesAttributeValues(t *testing.T) {
+	t.Parallel()
+
+	store := &countingStore{attributeValues: []model.DimensionCount{{Value: "v1.2", Count: 3}, {Value: "v1.3", Count: 1}}}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	m.filterActive = true
+	m.filterInput.Focus()
+
+	var cmd tea.Cmd
+	for _, r := range "version=v1" {
+		_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	// Run the debounced lookup directly rather than waiting for the tick.
+	_, lookup := m.Update(filterCompleteMsg{seq: m.filterSuggestSeq, key: "version", prefix: "v1"})
+	if cmd == nil || lookup == nil {
+		t.Fatal("typing a key=prefix token should schedule a value lookup")
+	}
+	m.Update(lookup())
+	if store.lastAttributeKey != "version" || store.lastAttributePrefix != "v1" {
+		t.Errorf("looked up %q=%q, want version=v1", store.lastAttributeKey, store.lastAttributePrefix)
+	}
+	if len(m.filterSuggestions) != 2 {
+		t.Fatalf("suggestions = %v, want 2 values", m.filterSuggestions)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := m.filterInput.Value(); got != `version=v1\.2` {
+		t.Errorf("filter = %q, want the first value escaped", got)
+	}
+	if m.filterRegex == nil || !m.filterRegex.MatchString("version=v1.2") || len(m.filterSuggestions) != 0 {
+		t.Errorf("completed filter regex = %v, suggestions = %v", m.filterRegex, m.filterSuggestions)
+	}
+
+	// A lookup scheduled before the edit is dropped.
+	m.Update(filterSuggestionsMsg{seq: m.filterSuggestSeq - 1, values: []string{"stale"}})
+	if len(m.filterSuggestions) != 0 {
+		t.Errorf("stale suggestions applied: %v", m.filterSuggestions)
+	}
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// captureGroupColors cycles across capture groups when highlighting a sample.
+var captureGroupColors = []lipgloss.Color{"#0f93fc", "#49E209", "#FF8C42", "#FF69B4", "#FFD93D"}
+
+// joinFilterLines turns multi-line editor text into a single pattern.
+// Lines are concatenated and indentation on continuation lines is dropped,
+// so long expressions can be split and indented freely.
+func joinFilterLines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		lines[i] = strings.TrimLeft(lines[i], " \t")
+	}
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], "\r")
+	}
+	return strings.Join(lines, "")
+}
+
+// lintFilterPattern compiles a filter pattern and returns a short,
+// user-facing error when it is invalid. An empty pattern is valid and
+// yields a nil regex.
+func lintFilterPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		msg := strings.TrimPrefix(err.Error(), "error parsing regexp: ")
+		return nil, fmt.Errorf("%s", msg)
+	}
+	return re, nil
+}
+
+// captureGroupOwners returns, for each byte of sample, the index of the
+// innermost capture group covering it (0 = whole match, -1 = no match).
+func captureGroupOwners(re *regexp.Regexp, sample string) []int {
+	owners := make([]int, len(sample))
+	for i := range owners {
+		owners[i] = -1
+	}
+	for _, loc := range re.FindAllStringSubmatchIndex(sample, -1) {
+		// Nested groups have higher indexes, so later writes are innermost.
+		for g := 0; g*2+1 < len(loc); g++ {
+			start, end := loc[g*2], loc[g*2+1]
+			if start < 0 {
+				continue
+			}
+			for i := start; i < end; i++ {
+				owners[i] = g
+			}
+		}
+	}
+	return owners
+}
+
+// highlightCaptureGroups renders sample with the whole match underlined and
+// each capture group in its own color.
+func highlightCaptureGroups(re *regexp.Regexp, sample string) string {
+	if re == nil || sample == "" {
+		return sample
+	}
+	owners := captureGroupOwners(re, sample)
+
+	var b strings.Builder
+	start := 0
+	for i := 1; i <= len(sample); i++ {
+		if i < len(sample) && owners[i] == owners[start] {
+			continue
+		}
+		b.WriteString(captureGroupStyle(owners[start]).Render(sample[start:i]))
+		start = i
+	}
+	return b.String()
+}
+
+func captureGroupStyle(group int) lipgloss.Style {
+	switch {
+	case group < 0:
+		return lipgloss.NewStyle()
+	case group == 0:
+		return lipgloss.NewStyle().Underline(true)
+	default:
+		color := captureGroupColors[(group-1)%len(captureGroupColors)]
+		return lipgloss.NewStyle().Foreground(ColorBlack).Background(color).Underline(true)
+	}
+}
+
+// captureGroupSummary lists the values captured by the first match in sample.
+func captureGroupSummary(re *regexp.Regexp, sample string) []string {
+	if re == nil {
+		return nil
+	}
+	match := re.FindStringSubmatch(sample)
+	if match == nil {
+		return []string{"no match"}
+	}
+	names := re.SubexpNames()
+	lines := make([]string, 0, len(match))
+	for g := 1; g < len(match); g++ {
+		label := fmt.Sprintf("$%d", g)
+		if names[g] != "" {
+			label += " " + names[g]
+		}
+		lines = append(lines, captureGroupStyle(g).Render(label)+" "+match[g])
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "match (no capture groups)")
+	}
+	return lines
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestJoinFilterLines(t *testing.T) {
+	t.Parallel()
+
+	got := joinFilterLines("^(?P<method>GET|POST)\n    \\s+(?P<path>/api/\\S+)\n\t\\s+(\\d{3})$")
+	want := `^(?P<method>GET|POST)\s+(?P<path>/api/\S+)\s+(\d{3})$`
+	if got != want {
+		t.Fatalf("joinFilterLines = %q, want %q", got, want)
+	}
+}
+
+func TestLintFilterPattern(t *testing.T) {
+	t.Parallel()
+
+	if re, err := lintFilterPattern(""); re != nil || err != nil {
+		t.Fatalf("empty pattern = (%v, %v), want (nil, nil)", re, err)
+	}
+	_, err := lintFilterPattern("error(")
+	if err == nil {
+		t.Fatal("expected lint error for unbalanced parenthesis")
+	}
+	if got := err.Error(); got != "missing closing ): `error(`" {
+		t.Fatalf("lint error = %q", got)
+	}
+}
+
+func TestCaptureGroupOwners_InnermostGroupWins(t *testing.T) {
+	t.Parallel()
+
+	re := regexp.MustCompile(`id=((\d+)-x)`)
+	owners := captureGroupOwners(re, "a id=42-x b")
+
+	want := []int{-1, -1, 0, 0, 0, 2, 2, 1, 1, -1, -1}
+	for i, g := range want {
+		if owners[i] != g {
+			t.Fatalf("owners = %v, want %v", owners, want)
+		}
+	}
+}
+
+func TestFilterInput_KeepsLastValidRegexWhileInvalid(t *testing.T) {
+	t.Parallel()
+
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	m.filterActive = true
+	m.filterInput.Focus()
+
+	h := filterInputHandler{}
+	for _, r := range "err(" {
+		h.HandleKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if m.filterErr == nil {
+		t.Fatal("expected lint error for unbalanced pattern")
+	}
+	if m.filterRegex == nil || m.filterRegex.String() != "err" {
+		t.Fatalf("filterRegex = %v, want last valid pattern err", m.filterRegex)
+	}
+
+	h.HandleKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.filterActive {
+		t.Fatal("enter should not apply an invalid pattern")
+	}
+}
+
+func TestFilterEditorModal_AppliesJoinedPattern(t *testing.T) {
+	t.Parallel()
+
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	m.logEntries = []model.LogRecord{{Message: "GET /api/users 200"}}
+	m.filterActive = true
+
+	e := NewFilterEditorModal(m)
+	e.editor.SetValue("(GET)\n  \\s+(/api/\\S+)")
+	e.lint()
+	if e.err != nil {
+		t.Fatalf("unexpected lint error: %v", e.err)
+	}
+
+	if pop, _ := e.Update(tea.KeyMsg{Type: tea.KeyCtrlS}); !pop {
+		t.Fatal("ctrl+s should close the editor")
+	}
+	if got := m.filterInput.Value(); got != `(GET)\s+(/api/\S+)` {
+		t.Fatalf("filter value = %q", got)
+	}
+	if m.filterRegex == nil || !m.filterRegex.MatchString(m.logEntries[0].Message) {
+		t.Fatal("expected applied regex to match sample")
+	}
+	if m.filterActive {
+		t.Fatal("filter input should be closed after apply")
+	}
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FilterPreset is a named combination of filters that can be applied again
+// in one step: the regex filter, the severities, the app, and the facets.
+type FilterPreset struct {
+	Name       string            `json:"name"`
+	SavedAt    time.Time         `json:"saved_at"`
+	App        string            `json:"app,omitempty"`
+	Filter     string            `json:"filter,omitempty"`
+	Facets     map[string]string `json:"facets,omitempty"`
+	Severities map[string]bool   `json:"severities,omitempty"`
+}
+
+// filterPresetsFile is the file presets are kept in, within the config dir.
+const filterPresetsFile = "filters.json"
+
+// LoadFilterPresets reads the presets in path, sorted by name. A missing
+// file is not an error.
+func LoadFilterPresets(path string) ([]FilterPreset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var presets []FilterPreset
+	if err := json.Unmarshal(data, &presets); err != nil {
+		return nil, fmt.Errorf("decode filter presets %s: %w", path, err)
+	}
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	return presets, nil
+}
+
+// SaveFilterPresets replaces the presets in path.
+func SaveFilterPresets(path string, presets []FilterPreset) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	data, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode filter presets: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write filter presets: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write filter presets: %w", err)
+	}
+	return nil
+}
+
+// putFilterPreset returns presets with p added, replacing a preset of the
+// same name, sorted by name.
+func putFilterPreset(presets []FilterPreset, p FilterPreset) []FilterPreset {
+	out := make([]FilterPreset, 0, len(presets)+1)
+	for _, existing := range presets {
+		if existing.Name != p.Name {
+			out = append(out, existing)
+		}
+	}
+	out = append(out, p)
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// SetConfigDir sets the directory filter presets and deck layouts are
+// saved in, and applies the deck layouts saved there.
+func (m *DashboardModel) SetConfigDir(dir string) {
+	m.filterPresetsPath, m.deckLayoutsPath = "", ""
+	if dir != "" {
+		m.filterPresetsPath = filepath.Join(dir, filterPresetsFile)
+		m.deckLayoutsPath = filepath.Join(dir, deckLayoutsFile)
+	}
+	m.loadDeckLayouts()
+}
+
+// captureFilterPreset records the filters in effect under name.
+func (m *DashboardModel) captureFilterPreset(name string) FilterPreset {
+	p := FilterPreset{
+		Name:    strings.TrimSpace(name),
+		SavedAt: time.Now(),
+		App:     m.selectedApp,
+		Facets:  m.logFacets(),
+	}
+	if m.filterRegex != nil {
+		p.Filter = m.filterInput.Value()
+	}
+	if m.severityFilterActive {
+		p.Severities = maps.Clone(m.severityFilter)
+	}
+	return p
+}
+
+// applyFilterPreset replaces the filters in effect with p's and reloads
+// the log list. The filter is validated before any state changes.
+func (m *DashboardModel) applyFilterPreset(p FilterPreset) error {
+	filter, err := lintFilterPattern(p.Filter)
+	if err != nil {
+		return fmt.Errorf("preset filter: %w", err)
+	}
+
+	m.selectedApp = p.App
+	m.filterActive = false
+	m.filterInput.Blur()
+	m.filterInput.SetValue(p.Filter)
+	m.filterRegex = filter
+	m.filterErr = nil
+	m.facets = maps.Clone(p.Facets)
+	for level := range m.severityFilter {
+		m.severityFilter[level] = true
+	}
+	for level, enabled := range p.Severities {
+		m.severityFilter[level] = enabled
+	}
+	m.updateSeverityFilterActiveStatus()
+	m.recordQuery(HistoryFilter, p.Filter)
+	m.reloadLogEntries()
+	return nil
+}
+
+// filterPresetSummary describes p's filters on one line.
+func filterPresetSummary(p FilterPreset) string {
+	var parts []string
+	if p.App != "" {
+		parts = append(parts, "app="+p.App)
+	}
+	if p.Filter != "" {
+		parts = append(parts, "/"+p.Filter+"/")
+	}
+	if len(p.Facets) > 0 {
+		parts = append(parts, facetsLabel(p.Facets))
+	}
+	var hidden []string
+	for level, enabled := range p.Severities {
+		if !enabled {
+			hidden = append(hidden, level)
+		}
+	}
+	if len(hidden) > 0 {
+		sort.Strings(hidden)
+		parts = append(parts, "-"+strings.Join(hidden, ",-"))
+	}
+	if len(parts) == 0 {
+		return "no filters"
+	}
+	return strings.Join(parts, "  ")
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"maps"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFilterPresets_SaveAndApply(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store := &countingStore{}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.SetConfigDir(dir)
+	m.selectedApp = "payments"
+	m.filterInput.SetValue("card.*declined")
+	m.filterRegex, _ = lintFilterPattern("card.*declined")
+	m.facets = map[string]string{"service": "checkout"}
+	m.severityFilter["DEBUG"] = false
+	m.updateSeverityFilterActiveStatus()
+
+	// s names and saves the filters in effect.
+	m.PushModal(NewFilterPresetsModal(m))
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	for _, r := range "declines" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	presets, err := LoadFilterPresets(filepath.Join(dir, filterPresetsFile))
+	if err != nil || len(presets) != 1 || presets[0].Name != "declines" || presets[0].Filter != "card.*declined" {
+		t.Fatalf("LoadFilterPresets = %+v, %v", presets, err)
+	}
+	m.PopModal()
+
+	// Enter applies the preset to a dashboard with other filters.
+	m.selectedApp = ""
+	m.filterInput.SetValue("")
+	m.filterRegex = nil
+	m.facets = nil
+	m.severityFilter["DEBUG"] = true
+	m.severityFilter["INFO"] = false
+	m.updateSeverityFilterActiveStatus()
+
+	m.PushModal(NewFilterPresetsModal(m))
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.TopModal() != nil {
+		t.Fatalf("top modal = %v, want closed after applying", m.TopModal())
+	}
+	if m.selectedApp != "payments" || m.filterRegex == nil || m.filterInput.Value() != "card.*declined" {
+		t.Fatalf("app %q, filter %v %q; want the preset's", m.selectedApp, m.filterRegex, m.filterInput.Value())
+	}
+	if m.severityFilter["DEBUG"] || !m.severityFilter["INFO"] {
+		t.Fatalf("severities = %v, want only DEBUG hidden", m.severityFilter)
+	}
+	if want := map[string]string{"service": "checkout"}; !maps.Equal(store.lastLogFacets, want) || store.lastLogPattern != "card.*declined" {
+		t.Fatalf("log query = %v %q, want the preset's facets and filter", store.lastLogFacets, store.lastLogPattern)
+	}
+
+	// Saving a name again replaces the preset; d deletes it.
+	path := filepath.Join(dir, filterPresetsFile)
+	if err := SaveFilterPresets(path, putFilterPreset(presets, FilterPreset{Name: "declines", Filter: "declined"})); err != nil {
+		t.Fatalf("SaveFilterPresets: %v", err)
+	}
+	m.PushModal(NewFilterPresetsModal(m))
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if presets, err := LoadFilterPresets(path); err != nil || len(presets) != 0 {
+		t.Fatalf("presets after delete = %+v, %v; want none", presets, err)
+	}
+}
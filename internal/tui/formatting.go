@@ -15,6 +15,19 @@ func (m *DashboardModel) formatLogEntry(entry model.LogRecord, availableWidth in
 	// Use getDisplayTimestamp to respect the useLogTime setting
 	timestamp := m.getDisplayTimestamp(entry).Format("15:04:05")
 
+	// A configured display template replaces the default column layout.
+	if tmpl := m.activeLogTemplate(); tmpl != nil {
+		if isSelected {
+			line := tmpl.Render(entry, m.getDisplayTimestamp(entry), availableWidth, false, nil)
+			return lipgloss.NewStyle().Background(ColorBlue).Foreground(ColorWhite).Render(line)
+		}
+		var highlight func(string) string
+		if len(m.activeHighlights()) > 0 {
+			highlight = m.highlightLogText
+		}
+		return tmpl.Render(entry, m.getDisplayTimestamp(entry), availableWidth, true, highlight)
+	}
+
 	// If selected, apply selection style to entire row
 	if isSelected {
 		// Format the entire row without individual component styling
@@ -187,8 +200,12 @@ func (m *DashboardModel) formatLogEntry(entry model.LogRecord, availableWidth in
 	}
 
 	// Apply search term highlighting to message (word-level highlighting)
-	if m.searchTerm != "" {
-		message = m.highlightText(message, m.searchTerm)
+	if len(m.activeHighlights()) > 0 {
+		message = m.highlightLogText(message)
+	} else if m.tailMode && tailTinted(entry.Level) {
+		// Tail mode colors warnings and errors whole so they stand out
+		// as they scroll by.
+		message = lipgloss.NewStyle().Foreground(severityColor).Render(message)
 	}
 
 	// Create the complete log line
@@ -1,23 +1,37 @@
 package tui
 
 import (
+	"time"
+
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// heatmapSeverities are the heatmap rows, top to bottom.
+var heatmapSeverities = []string{"FATAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE"}
+
+// heatmapMinutes is how many minutes back the heatmap reaches.
+const heatmapMinutes = 60
+
 // CountsModal displays log counts analysis with heatmap and services.
 // It owns its own data fields (moved off DashboardModel).
 type CountsModal struct {
-	ctx        ModalContext
-	viewport   viewport.Model
-	renderView func(vp *viewport.Model, cm *CountsModal, width, height int) string
-	refreshFn  func(cm *CountsModal)
+	ctx         ModalContext
+	viewport    viewport.Model
+	renderView  func(vp *viewport.Model, cm *CountsModal, width, height int) string
+	refreshFn   func(cm *CountsModal)
+	drillDownFn func(severity string, minute time.Time) tea.Cmd
 
 	// Data owned by this modal — only fetched while modal is visible.
 	countsHeatmapData  []model.MinuteCounts
 	countsServicesData map[string][]model.DimensionCount
+
+	// Heatmap cursor: a row in heatmapSeverities and a column counted in
+	// minutes back from now (0 = the current minute).
+	cursorSeverity   int
+	cursorMinutesAgo int
 }
 
 func NewCountsModal(m *DashboardModel) *CountsModal {
@@ -38,15 +52,15 @@ func NewCountsModal(m *DashboardModel) *CountsModal {
 				cm.countsHeatmapData = rows
 			}
 
-			severities := []string{"FATAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE"}
-			servicesData := make(map[string][]model.DimensionCount, len(severities))
-			for _, severity := range severities {
+			servicesData := make(map[string][]model.DimensionCount, len(heatmapSeverities))
+			for _, severity := range heatmapSeverities {
 				if services, err := store.TopServicesBySeverity(severity, 3, opts); err == nil {
 					servicesData[severity] = services
 				}
 			}
 			cm.countsServicesData = servicesData
 		},
+		drillDownFn: m.drillDownToMinute,
 	}
 	// Fetch data immediately on open.
 	cm.Refresh()
@@ -65,11 +79,19 @@ func (c *CountsModal) Update(msg tea.Msg) (bool, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "up", "k":
-			c.viewport.ScrollUp(1)
+			c.cursorSeverity = max(0, c.cursorSeverity-1)
 			return false, nil
 		case "down", "j":
-			c.viewport.ScrollDown(1)
+			c.cursorSeverity = min(len(heatmapSeverities)-1, c.cursorSeverity+1)
 			return false, nil
+		case "left", "h":
+			c.cursorMinutesAgo = min(heatmapMinutes, c.cursorMinutesAgo+1)
+			return false, nil
+		case "right", "l":
+			c.cursorMinutesAgo = max(0, c.cursorMinutesAgo-1)
+			return false, nil
+		case "enter":
+			return true, c.drillDownFn(c.cursorSeverityName(), c.cursorMinute(time.Now()))
 		case "pgup":
 			c.viewport.HalfPageUp()
 			return false, nil
@@ -108,6 +130,36 @@ func (c *CountsModal) Update(msg tea.Msg) (bool, tea.Cmd) {
 	return false, nil
 }
 
+// cursorSeverityName returns the severity of the selected heatmap row.
+func (c *CountsModal) cursorSeverityName() string {
+	return heatmapSeverities[c.cursorSeverity]
+}
+
+// cursorMinute returns the start of the selected heatmap minute.
+func (c *CountsModal) cursorMinute(now time.Time) time.Time {
+	return now.Add(time.Duration(-c.cursorMinutesAgo) * time.Minute).Truncate(time.Minute)
+}
+
+// drillDownToMinute narrows the log list to one severity within the minute
+// starting at minute, then opens the log viewer on the result.
+func (m *DashboardModel) drillDownToMinute(severity string, minute time.Time) tea.Cmd {
+	return m.drillDownToWindow(severity, minute, minute.Add(time.Minute))
+}
+
+// drillDownToWindow narrows the log list to one severity within [from, to),
+// then opens the log viewer on the result. The time window stays in effect
+// until cleared from the log viewer.
+func (m *DashboardModel) drillDownToWindow(severity string, from, to time.Time) tea.Cmd {
+	m.timeWindow = WorkspaceTimeRange{From: from, To: to}
+	for level := range m.severityFilter {
+		m.severityFilter[level] = level == severity
+	}
+	m.updateSeverityFilterActiveStatus()
+	m.reloadLogEntries()
+	m.selectedLogIndex = max(0, len(m.logEntries)-1)
+	return actionMsg(ActionMsg{Action: ActionPushModal, Payload: NewLogViewerModal(m)})
+}
+
 func (c *CountsModal) View(width, height int) string {
 	return c.renderView(&c.viewport, c, width, height)
 }
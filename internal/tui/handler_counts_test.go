@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCountsModal_EnterDrillsDownToMinute(t *testing.T) {
+	t.Parallel()
+
+	store := &countingStore{
+		recentLogs: []model.LogRecord{{Message: "boom", Level: "ERROR", Timestamp: time.Now()}},
+	}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	m.PushModal(NewCountsModal(m))
+
+	// Move to the ERROR row, two minutes back.
+	for _, key := range []tea.KeyType{tea.KeyDown, tea.KeyLeft, tea.KeyLeft} {
+		m.Update(tea.KeyMsg{Type: key})
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter on a heatmap cell should return a command")
+	}
+	m.Update(cmd())
+
+	if top := m.TopModal(); top == nil || top.ID() != "logviewer" {
+		t.Fatalf("top modal = %v, want logviewer", top)
+	}
+	if len(m.modalStack) != 1 {
+		t.Fatalf("modal stack depth = %d, want counts modal replaced by log viewer", len(m.modalStack))
+	}
+
+	opts := store.lastLogOpts
+	if !opts.From.Equal(opts.From.Truncate(time.Minute)) || opts.To.Sub(opts.From) != time.Minute {
+		t.Fatalf("log query range = [%s, %s), want one whole minute", opts.From, opts.To)
+	}
+	if ago := time.Since(opts.From); ago < 2*time.Minute || ago > 4*time.Minute {
+		t.Fatalf("log query starts %s ago, want the minute two back", ago)
+	}
+	if len(store.lastLogLevels) != 1 || store.lastLogLevels[0] != "ERROR" {
+		t.Fatalf("severity levels = %v, want [ERROR]", store.lastLogLevels)
+	}
+	if len(m.logEntries) != 1 || m.logEntries[0].Message != "boom" {
+		t.Fatalf("log entries = %+v, want the drill-down result", m.logEntries)
+	}
+
+	// t in the log viewer clears the window and refetches.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if !m.timeWindow.IsZero() {
+		t.Fatalf("time window = %+v, want cleared", m.timeWindow)
+	}
+	if !store.lastLogOpts.From.IsZero() || !store.lastLogOpts.To.IsZero() {
+		t.Fatalf("log query after clear = %+v, want unbounded", store.lastLogOpts)
+	}
+}
@@ -1,8 +1,6 @@
 package tui
 
 import (
-	"regexp"
-
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -17,6 +15,8 @@ func (h filterInputHandler) HandleKey(m *DashboardModel, msg tea.KeyMsg) (bool,
 		m.filterInput.Blur()
 		m.filterInput.SetValue("")
 		m.filterRegex = nil
+		m.filterErr = nil
+		m.clearFilterSuggestions()
 		if m.activeSection == SectionFilter {
 			m.activeSection = SectionDecks
 			if m.activeDeckIdx >= len(m.decks) {
@@ -25,21 +25,33 @@ func (h filterInputHandler) HandleKey(m *DashboardModel, msg tea.KeyMsg) (bool,
 		}
 		return true, nil
 	case "enter":
+		if m.filterErr != nil {
+			return true, nil // keep editing until the pattern compiles
+		}
 		m.filterActive = false
 		m.filterInput.Blur()
+		m.clearFilterSuggestions()
 		m.activeSection = SectionLogs
+		m.recordQuery(HistoryFilter, m.filterInput.Value())
+		return true, nil
+	case "tab":
+		if len(m.filterSuggestions) == 0 {
+			return true, nil
+		}
+		m.completeFilter(m.filterSuggestions[0])
+		return true, nil
+	case "ctrl+e":
+		m.PushModal(NewFilterEditorModal(m))
 		return true, nil
 	default:
 		var cmd tea.Cmd
 		m.filterInput, cmd = m.filterInput.Update(msg)
-		if m.filterInput.Value() != "" {
-			if regex, err := regexp.Compile(m.filterInput.Value()); err == nil {
-				m.filterRegex = regex
-			}
-		} else {
-			m.filterRegex = nil
+		regex, err := lintFilterPattern(m.filterInput.Value())
+		m.filterErr = err
+		if err == nil {
+			m.filterRegex = regex
 		}
-		return true, cmd
+		return true, tea.Batch(cmd, m.scheduleFilterSuggestions())
 	}
 }
 
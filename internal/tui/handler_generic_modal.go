@@ -1,10 +1,14 @@
 package tui
 
 import (
+	"strings"
+
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // DetailModal displays detail content (log details or top values).
@@ -14,6 +18,15 @@ type DetailModal struct {
 	content    string
 	logEntry   *model.LogRecord // non-nil for log details view
 	renderView func(vp *viewport.Model, width, height int) string
+
+	// Log details show the raw line, body, and attributes as foldable
+	// JSON trees with a line cursor and a search.
+	trees     []jsonSection
+	cursor    int  // index into treeLines()
+	follow    bool // scroll the cursor into view on the next render
+	search    textinput.Model
+	searching bool
+	term      string // lowercase search term
 }
 
 func NewDetailModal(m *DashboardModel, entry *model.LogRecord) *DetailModal {
@@ -24,8 +37,13 @@ func NewDetailModal(m *DashboardModel, entry *model.LogRecord) *DetailModal {
 	}
 	if entry != nil {
 		dm.content = m.formatLogDetails(*entry, 60)
+		dm.trees = logJSONSections(*entry)
+		dm.search = textinput.New()
+		dm.search.Prompt = "Search: "
+		dm.search.PromptStyle = lipgloss.NewStyle().Foreground(ColorBlue)
+		dm.search.CharLimit = 200
 		dm.renderView = func(vp *viewport.Model, width, height int) string {
-			return m.renderSplitModalView(vp, dm.logEntry, width, height)
+			return m.renderSplitModalView(vp, dm, width, height)
 		}
 	} else {
 		dm.renderView = func(vp *viewport.Model, width, height int) string {
@@ -49,9 +67,172 @@ func NewDetailModalWithContent(m *DashboardModel, content string) *DetailModal {
 
 func (d *DetailModal) ID() string { return "detail" }
 
+// treeLines returns the visible lines of every tree, in order.
+func (d *DetailModal) treeLines() []jsonLine {
+	var lines []jsonLine
+	for _, s := range d.trees {
+		lines = jsonLines(s.root, 0, false, lines)
+	}
+	return lines
+}
+
+// moveCursor moves the tree cursor to line i, clamped to the visible lines.
+func (d *DetailModal) moveCursor(i int) {
+	d.cursor = max(0, min(i, len(d.treeLines())-1))
+	d.follow = true
+}
+
+// lineMatches reports whether l holds the search term.
+func (d *DetailModal) lineMatches(l jsonLine) bool {
+	return d.term != "" && !l.close && strings.Contains(strings.ToLower(jsonLineText(l)), d.term)
+}
+
+// jumpToMatch moves the cursor to the next match after it, or the previous
+// one before it, wrapping around.
+func (d *DetailModal) jumpToMatch(forward bool) {
+	lines := d.treeLines()
+	for step := 1; step <= len(lines); step++ {
+		i := d.cursor + step
+		if !forward {
+			i = d.cursor - step
+		}
+		i = (i%len(lines) + len(lines)) % len(lines)
+		if d.lineMatches(lines[i]) {
+			d.moveCursor(i)
+			return
+		}
+	}
+}
+
+// openLine returns the index of the opening line of the container whose
+// closing line is at i.
+func openLine(lines []jsonLine, i int) int {
+	for j := i - 1; j >= 0; j-- {
+		if lines[j].node == lines[i].node && !lines[j].close {
+			return j
+		}
+	}
+	return i
+}
+
+// updateTree handles the keys of the log details trees, and reports
+// whether it used the key.
+func (d *DetailModal) updateTree(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if d.searching {
+		switch msg.String() {
+		case "enter":
+			d.searching = false
+			d.search.Blur()
+			d.term = strings.ToLower(strings.TrimSpace(d.search.Value()))
+			if d.term != "" {
+				for _, s := range d.trees {
+					expandJSONMatches(s.root, d.term)
+				}
+				d.cursor = -1
+				d.jumpToMatch(true)
+				if d.cursor < 0 {
+					d.moveCursor(0)
+				}
+			}
+		case "esc", "escape":
+			d.searching = false
+			d.search.Blur()
+		default:
+			var cmd tea.Cmd
+			d.search, cmd = d.search.Update(msg)
+			return true, cmd
+		}
+		return true, nil
+	}
+
+	lines := d.treeLines()
+	if len(lines) == 0 {
+		return false, nil
+	}
+	d.cursor = max(0, min(d.cursor, len(lines)-1))
+	line := lines[d.cursor]
+	foldable := line.node.kind != 0 && len(line.node.children) > 0
+
+	switch msg.String() {
+	case "up", "k":
+		d.moveCursor(d.cursor - 1)
+	case "down", "j":
+		d.moveCursor(d.cursor + 1)
+	case "pgup":
+		d.moveCursor(d.cursor - 10)
+	case "pgdown":
+		d.moveCursor(d.cursor + 10)
+	case "home":
+		d.moveCursor(0)
+	case "end":
+		d.moveCursor(len(lines) - 1)
+	case "enter", " ":
+		if foldable {
+			if line.close {
+				d.cursor = openLine(lines, d.cursor)
+			}
+			line.node.collapsed = !line.node.collapsed
+			d.moveCursor(d.cursor)
+		}
+	case "left", "h":
+		switch {
+		case foldable && !line.node.collapsed:
+			if line.close {
+				d.cursor = openLine(lines, d.cursor)
+			}
+			line.node.collapsed = true
+			d.moveCursor(d.cursor)
+		case line.depth > 0:
+			for j := d.cursor - 1; j >= 0; j-- {
+				if lines[j].depth == line.depth-1 && !lines[j].close {
+					d.moveCursor(j)
+					break
+				}
+			}
+		}
+	case "right", "l":
+		if foldable && line.node.collapsed {
+			line.node.collapsed = false
+			d.moveCursor(d.cursor)
+		}
+	case "-":
+		for _, s := range d.trees {
+			setJSONCollapsed(s.root, true)
+		}
+		d.moveCursor(0)
+	case "+", "=":
+		for _, s := range d.trees {
+			setJSONCollapsed(s.root, false)
+		}
+		d.moveCursor(d.cursor)
+	case "/":
+		d.searching = true
+		d.search.SetValue(d.term)
+		d.search.CursorEnd()
+		return true, d.search.Focus()
+	case "n":
+		d.jumpToMatch(true)
+	case "N":
+		d.jumpToMatch(false)
+	case "esc", "escape":
+		if d.term == "" {
+			return false, nil
+		}
+		d.term = ""
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
 func (d *DetailModal) Update(msg tea.Msg) (bool, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if len(d.trees) > 0 {
+			if used, cmd := d.updateTree(msg); used {
+				return false, cmd
+			}
+		}
 		switch msg.String() {
 		case "up", "k":
 			d.viewport.ScrollUp(1)
@@ -65,6 +246,23 @@ func (d *DetailModal) Update(msg tea.Msg) (bool, tea.Cmd) {
 		case "pgdown":
 			d.viewport.HalfPageDown()
 			return false, nil
+		case "y":
+			if d.logEntry != nil {
+				return false, copyToClipboard("log message", d.logEntry.Message)
+			}
+			return false, copyToClipboard("details", plainText(d.content))
+		case "Y":
+			if d.logEntry != nil {
+				return false, copyToClipboard("log JSON", logRecordJSON(*d.logEntry))
+			}
+			return false, nil
+		case "t":
+			// Drill down to the log's trace: filter the logs to it and
+			// open its timeline.
+			if d.logEntry != nil && d.logEntry.Attributes[traceIDAttr] != "" {
+				return true, actionMsg(ActionMsg{Action: ActionShowTrace, Payload: d.logEntry.Attributes[traceIDAttr]})
+			}
+			return false, nil
 		case "escape", "esc":
 			return true, nil
 		}
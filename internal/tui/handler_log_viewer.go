@@ -22,15 +22,21 @@ func (l *LogViewerModal) Update(msg tea.Msg) (bool, tea.Cmd) {
 		case "up", "k":
 			if m.selectedLogIndex > 0 {
 				m.selectedLogIndex--
+				return false, nil
 			}
-			return false, nil
+			return false, m.loadOlderLogs(-1)
 		case "down", "j":
 			if m.selectedLogIndex < len(m.logEntries)-1 {
 				m.selectedLogIndex++
 			}
 			return false, nil
 		case "pgup":
-			m.selectedLogIndex = max(0, m.selectedLogIndex-10)
+			if m.selectedLogIndex < 10 {
+				move := m.selectedLogIndex - 10
+				m.selectedLogIndex = 0
+				return false, m.loadOlderLogs(move)
+			}
+			m.selectedLogIndex -= 10
 			return false, nil
 		case "pgdown":
 			m.selectedLogIndex = min(len(m.logEntries)-1, m.selectedLogIndex+10)
@@ -64,6 +70,17 @@ func (l *LogViewerModal) Update(msg tea.Msg) (bool, tea.Cmd) {
 		case "c":
 			m.showColumns = !m.showColumns
 			return false, nil
+		case "m":
+			m.toggleSelectedPin()
+			return false, nil
+		case "y", "Y":
+			return false, m.copySelectedLog(msg.String() == "Y")
+		case "t":
+			if !m.timeWindow.IsZero() {
+				m.timeWindow = WorkspaceTimeRange{}
+				m.reloadLogEntries()
+			}
+			return false, nil
 		case "escape", "esc", "f":
 			return true, nil
 		}
@@ -1,6 +1,10 @@
 package tui
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 type searchInputHandler struct{}
 
@@ -21,10 +25,19 @@ func (h searchInputHandler) HandleKey(m *DashboardModel, msg tea.KeyMsg) (bool,
 		}
 		return true, nil
 	case "enter":
+		// The term joins the highlight terms in its own color, and the
+		// input is left empty for the next one.
+		term := m.searchInput.Value()
 		m.searchActive = false
 		m.searchInput.Blur()
-		m.searchTerm = m.searchInput.Value()
+		m.searchInput.SetValue("")
+		m.searchTerm = ""
 		m.activeSection = SectionLogs
+		if err := m.addHighlightTerm(term); err != nil {
+			m.lastError, m.lastErrorAt = err.Error(), time.Now()
+			return true, nil
+		}
+		m.recordQuery(HistorySearch, term)
 		return true, nil
 	default:
 		var cmd tea.Cmd
@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"time"
+
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -9,10 +11,11 @@ import (
 
 // SeverityModal displays a full-view severity timeline chart with time range selection.
 type SeverityModal struct {
-	ctx        ModalContext
-	viewport   viewport.Model
-	renderView func(vp *viewport.Model, sm *SeverityModal, width, height int) string
-	refreshFn  func(sm *SeverityModal)
+	ctx         ModalContext
+	viewport    viewport.Model
+	renderView  func(vp *viewport.Model, sm *SeverityModal, width, height int) string
+	refreshFn   func(sm *SeverityModal)
+	drillDownFn func(severity string, from, to time.Time) tea.Cmd
 
 	// Time range selection: 0=1 day, 1=1 week, 2=1 month
 	activeRange int
@@ -20,6 +23,13 @@ type SeverityModal struct {
 
 	// Data owned by this modal
 	data []model.MinuteCounts
+
+	// Bar cursor: a row in heatmapSeverities and a bar counted back from
+	// the newest (0 = the bar holding the current minute). layout is the
+	// bucketing of the last render, which the cursor's bar refers to.
+	cursorSeverity int
+	cursorBarsAgo  int
+	layout         timelineLayout
 }
 
 func NewSeverityModal(m *DashboardModel) *SeverityModal {
@@ -41,6 +51,7 @@ func NewSeverityModal(m *DashboardModel) *SeverityModal {
 				sm.data = rows
 			}
 		},
+		drillDownFn: m.drillDownToWindow,
 	}
 	sm.Refresh()
 	return sm
@@ -58,27 +69,39 @@ func (s *SeverityModal) Update(msg tea.Msg) (bool, tea.Cmd) {
 		switch msg.String() {
 		case "escape", "esc":
 			return true, nil
-		case "tab", "right", "l":
-			s.activeRange = (s.activeRange + 1) % len(s.rangeLabels)
+		case "tab":
+			s.setRange((s.activeRange + 1) % len(s.rangeLabels))
 			return false, nil
-		case "shift+tab", "left", "h":
-			s.activeRange = (s.activeRange - 1 + len(s.rangeLabels)) % len(s.rangeLabels)
+		case "shift+tab":
+			s.setRange((s.activeRange - 1 + len(s.rangeLabels)) % len(s.rangeLabels))
 			return false, nil
 		case "1":
-			s.activeRange = 0
+			s.setRange(0)
 			return false, nil
 		case "2":
-			s.activeRange = 1
+			s.setRange(1)
 			return false, nil
 		case "3":
-			s.activeRange = 2
+			s.setRange(2)
 			return false, nil
 		case "up", "k":
-			s.viewport.ScrollUp(1)
+			s.cursorSeverity = max(0, s.cursorSeverity-1)
 			return false, nil
 		case "down", "j":
-			s.viewport.ScrollDown(1)
+			s.cursorSeverity = min(len(heatmapSeverities)-1, s.cursorSeverity+1)
+			return false, nil
+		case "left", "h":
+			s.cursorBarsAgo = min(max(0, s.layout.bars-1), s.cursorBarsAgo+1)
 			return false, nil
+		case "right", "l":
+			s.cursorBarsAgo = max(0, s.cursorBarsAgo-1)
+			return false, nil
+		case "enter":
+			from, to, ok := s.layout.barWindow(s.cursorBarsAgo)
+			if !ok {
+				return false, nil
+			}
+			return true, s.drillDownFn(s.cursorSeverityName(), from, to)
 		case "pgup":
 			s.viewport.HalfPageUp()
 			return false, nil
@@ -115,6 +138,18 @@ func (s *SeverityModal) Update(msg tea.Msg) (bool, tea.Cmd) {
 	return false, nil
 }
 
+// setRange switches the time range and puts the cursor back on the newest
+// bar, since bars are rebucketed for the new range.
+func (s *SeverityModal) setRange(idx int) {
+	s.activeRange = idx
+	s.cursorBarsAgo = 0
+}
+
+// cursorSeverityName returns the severity of the selected row.
+func (s *SeverityModal) cursorSeverityName() string {
+	return heatmapSeverities[s.cursorSeverity]
+}
+
 func (s *SeverityModal) View(width, height int) string {
 	return s.renderView(&s.viewport, s, width, height)
 }
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/memstore"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSeverityModal_EnterDrillsDownToBar(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	store := memstore.NewStore()
+	if err := store.InsertLogBatch([]*model.LogRecord{
+		{Timestamp: now, Level: "ERROR", Message: "boom"},
+		{Timestamp: now, Level: "INFO", Message: "fine"},
+		{Timestamp: now.Add(-6 * time.Hour), Level: "ERROR", Message: "earlier boom"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+
+	// Enter on the severity deck opens the timeline; its bars are laid out
+	// when it renders.
+	m.Update(m.pushSeverityModalCmd()())
+	sm, ok := m.TopModal().(*SeverityModal)
+	if !ok {
+		t.Fatalf("top modal = %v, want the severity timeline", m.TopModal())
+	}
+	sm.View(m.width, m.height)
+	if sm.layout.bars == 0 {
+		t.Fatal("timeline rendered no bars")
+	}
+
+	// ERROR row, newest bar.
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter on a bar should return a command")
+	}
+	m.Update(cmd())
+
+	if top := m.TopModal(); top == nil || top.ID() != "logviewer" {
+		t.Fatalf("top modal = %v, want logviewer", top)
+	}
+	if m.timeWindow.To.Before(now) || now.Sub(m.timeWindow.From) > 2*sm.layout.bucket {
+		t.Fatalf("time window = %+v, want the newest bar", m.timeWindow)
+	}
+	if len(m.logEntries) != 1 || m.logEntries[0].Message != "boom" {
+		t.Fatalf("log entries = %+v, want only the newest ERROR", m.logEntries)
+	}
+}
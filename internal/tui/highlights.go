@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HighlightTerm is a search term highlighted in the logs in its own color.
+// Terms are added with s and toggled or removed in the highlights modal.
+type HighlightTerm struct {
+	Text  string `json:"text"`
+	Color int    `json:"color"`         // index into highlightPalette
+	Off   bool   `json:"off,omitempty"` // kept in the legend but not highlighted
+}
+
+// highlightPalette returns the background colors of highlight terms, in
+// the order new terms take them. It reads the skin's colors on each call
+// since a skin may replace them.
+func highlightPalette() []lipgloss.Color {
+	return []lipgloss.Color{ColorYellow, ColorPink, ColorGreen, ColorOrange, ColorBlue, ColorRed}
+}
+
+// maxHighlightTerms is how many terms may be highlighted at once, one per
+// palette color.
+const maxHighlightTerms = 6
+
+func highlightStyle(color int) lipgloss.Style {
+	palette := highlightPalette()
+	return lipgloss.NewStyle().
+		Background(palette[color%len(palette)]).
+		Foreground(ColorBlack).
+		Bold(true)
+}
+
+// nextHighlightColor returns the first palette color no term has.
+func (m *DashboardModel) nextHighlightColor() int {
+	for c := range maxHighlightTerms {
+		if !slices.ContainsFunc(m.highlightTerms, func(t HighlightTerm) bool { return t.Color == c }) {
+			return c
+		}
+	}
+	return len(m.highlightTerms) % maxHighlightTerms
+}
+
+// addHighlightTerm highlights text in the next free color. A term already
+// listed is turned back on instead.
+func (m *DashboardModel) addHighlightTerm(text string) error {
+	if text == "" {
+		return nil
+	}
+	for i, t := range m.highlightTerms {
+		if strings.EqualFold(t.Text, text) {
+			m.highlightTerms[i].Off = false
+			return nil
+		}
+	}
+	if len(m.highlightTerms) >= maxHighlightTerms {
+		return fmt.Errorf("at most %d highlight terms; remove one with S", maxHighlightTerms)
+	}
+	m.highlightTerms = append(m.highlightTerms, HighlightTerm{Text: text, Color: m.nextHighlightColor()})
+	return nil
+}
+
+// activeHighlights returns the terms highlighted in the logs: the ones
+// turned on, and the search term being typed in the color it will take.
+func (m *DashboardModel) activeHighlights() []HighlightTerm {
+	var terms []HighlightTerm
+	for _, t := range m.highlightTerms {
+		if !t.Off {
+			terms = append(terms, t)
+		}
+	}
+	if m.searchTerm != "" && !slices.ContainsFunc(m.highlightTerms, func(t HighlightTerm) bool { return strings.EqualFold(t.Text, m.searchTerm) }) {
+		terms = append(terms, HighlightTerm{Text: m.searchTerm, Color: m.nextHighlightColor()})
+	}
+	return terms
+}
+
+// highlightLogText highlights every active term in text, each in its
+// color.
+func (m *DashboardModel) highlightLogText(text string) string {
+	terms := m.activeHighlights()
+	if len(terms) == 0 {
+		return text
+	}
+	return markTerms(text, terms, func(t HighlightTerm, match string) string {
+		return highlightStyle(t.Color).Render(match)
+	})
+}
+
+// markTerms replaces every match of terms in text with mark's rendering of
+// it. Matching is case-insensitive; where terms overlap, the earlier match
+// wins, then the longer one.
+func markTerms(text string, terms []HighlightTerm, mark func(t HighlightTerm, match string) string) string {
+	fold := strings.ToLower
+	if len(strings.ToLower(text)) != len(text) {
+		// Lowercasing changed byte offsets; match case-sensitively.
+		fold = func(s string) string { return s }
+	}
+	haystack := fold(text)
+	needles := make([]string, len(terms))
+	for i, t := range terms {
+		needles[i] = fold(t.Text)
+	}
+
+	var result strings.Builder
+	pos := 0
+	for pos < len(text) {
+		best, bestAt := -1, len(text)
+		for i, n := range needles {
+			if n == "" {
+				continue
+			}
+			idx := strings.Index(haystack[pos:], n)
+			if idx < 0 {
+				continue
+			}
+			at := pos + idx
+			if at < bestAt || (at == bestAt && len(n) > len(needles[best])) {
+				best, bestAt = i, at
+			}
+		}
+		if best < 0 {
+			break
+		}
+		end := bestAt + len(needles[best])
+		result.WriteString(text[pos:bestAt])
+		result.WriteString(mark(terms[best], text[bestAt:end]))
+		pos = end
+	}
+	result.WriteString(text[pos:])
+	return result.String()
+}
+
+// highlightLegend renders the highlight terms in their colors, numbered
+// as in the highlights modal; terms turned off are dimmed and struck.
+func (m *DashboardModel) highlightLegend() string {
+	parts := make([]string, 0, len(m.highlightTerms))
+	for i, t := range m.highlightTerms {
+		label := fmt.Sprintf("%d %s", i+1, t.Text)
+		if t.Off {
+			parts = append(parts, lipgloss.NewStyle().Foreground(ColorGray).Strikethrough(true).Render(label))
+			continue
+		}
+		parts = append(parts, highlightStyle(t.Color).Render(label))
+	}
+	return strings.Join(parts, " ")
+}
+
+// highlightSummary lists the search term and the highlight terms as plain
+// text.
+func (m *DashboardModel) highlightSummary() string {
+	texts := make([]string, 0, len(m.highlightTerms)+1)
+	for _, t := range m.highlightTerms {
+		texts = append(texts, t.Text)
+	}
+	if m.searchTerm != "" {
+		texts = append(texts, m.searchTerm)
+	}
+	return strings.Join(texts, ", ")
+}
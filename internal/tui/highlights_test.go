@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeSearch(m *DashboardModel, term string) {
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(term)})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestHighlightTerms_AddToggleRemove(t *testing.T) {
+	t.Parallel()
+
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	m.width, m.height = 160, 40
+
+	typeSearch(m, "req-1")
+	typeSearch(m, "REQ-2")
+	typeSearch(m, "req-1") // already listed
+	if len(m.highlightTerms) != 2 || m.searchTerm != "" || m.searchInput.Value() != "" {
+		t.Fatalf("terms %+v, search %q, input %q", m.highlightTerms, m.searchTerm, m.searchInput.Value())
+	}
+	if m.highlightTerms[0].Color == m.highlightTerms[1].Color {
+		t.Fatalf("terms share color %d", m.highlightTerms[0].Color)
+	}
+
+	mark := func(t HighlightTerm, match string) string { return fmt.Sprintf("[%d:%s]", t.Color, match) }
+	line := "Req-2 then req-1, req-10 then other"
+	if got, want := markTerms(line, m.activeHighlights(), mark), "[1:Req-2] then [0:req-1], [0:req-1]0 then other"; got != want {
+		t.Fatalf("marked %q, want %q", got, want)
+	}
+	if !strings.Contains(ansi.Strip(m.renderFilter()), "1 req-1 2 REQ-2") {
+		t.Errorf("filter bar has no legend: %q", ansi.Strip(m.renderFilter()))
+	}
+
+	// Toggle the first term off in the legend modal, then remove it.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	if !m.highlightTerms[0].Off {
+		t.Fatal("1 did not turn the first term off")
+	}
+	if got, want := markTerms(line, m.activeHighlights(), mark), "[1:Req-2] then req-1, req-10 then other"; got != want {
+		t.Fatalf("with the first term off: %q, want %q", got, want)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.highlightTerms) != 1 || m.highlightTerms[0].Text != "REQ-2" || m.TopModal() != nil {
+		t.Fatalf("after removing: %+v", m.highlightTerms)
+	}
+
+	// A freed color goes to the next term.
+	typeSearch(m, "req-3")
+	if m.highlightTerms[1].Color != 0 {
+		t.Fatalf("new term color %d, want the freed color 0", m.highlightTerms[1].Color)
+	}
+
+	for i := range maxHighlightTerms {
+		typeSearch(m, strings.Repeat("x", i+1)+"-term")
+	}
+	if len(m.highlightTerms) != maxHighlightTerms || m.lastError == "" {
+		t.Fatalf("%d terms, error %q; want the cap enforced", len(m.highlightTerms), m.lastError)
+	}
+}
@@ -0,0 +1,246 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// jsonNode is one value of a JSON document shown as a foldable tree.
+// Objects keep their members in document order.
+type jsonNode struct {
+	key       string // member name; "" for the root and array elements
+	member    bool   // the node is an object member, so key is shown
+	kind      byte   // '{' or '[' for containers, 0 for scalars
+	scalar    string // a scalar's JSON text
+	children  []*jsonNode
+	collapsed bool
+}
+
+// jsonSection is a titled tree in the detail modal.
+type jsonSection struct {
+	title string
+	root  *jsonNode
+}
+
+// jsonLine is one visible line of a tree: a scalar, a container's opening
+// line (or the whole container when collapsed or empty), or its closing
+// bracket.
+type jsonLine struct {
+	node  *jsonNode
+	depth int
+	close bool
+	comma bool
+}
+
+// parseJSONTree parses a JSON document into a tree.
+func parseJSONTree(data []byte) (*jsonNode, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	root, err := decodeJSONNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return root, nil
+}
+
+func decodeJSONNode(dec *json.Decoder) (*jsonNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch v := tok.(type) {
+	case json.Delim:
+		n := &jsonNode{kind: byte(v)}
+		for dec.More() {
+			var key string
+			if n.kind == '{' {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ = keyTok.(string)
+			}
+			child, err := decodeJSONNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			child.key, child.member = key, n.kind == '{'
+			n.children = append(n.children, child)
+		}
+		if _, err := dec.Token(); err != nil { // closing delimiter
+			return nil, err
+		}
+		return n, nil
+	case string:
+		quoted, _ := json.Marshal(v)
+		return &jsonNode{scalar: string(quoted)}, nil
+	case json.Number:
+		return &jsonNode{scalar: v.String()}, nil
+	case bool:
+		return &jsonNode{scalar: fmt.Sprint(v)}, nil
+	default:
+		return &jsonNode{scalar: "null"}, nil
+	}
+}
+
+// parseJSONContainer parses s when it is a JSON object or array.
+func parseJSONContainer(s string) (*jsonNode, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || (s[0] != '{' && s[0] != '[') {
+		return nil, false
+	}
+	n, err := parseJSONTree([]byte(s))
+	return n, err == nil
+}
+
+// logJSONSections returns the foldable trees of a log's details: its raw
+// line when it is JSON, its structured body, and its attributes, with
+// attribute values that are JSON objects or arrays expanded.
+func logJSONSections(entry model.LogRecord) []jsonSection {
+	var sections []jsonSection
+	if root, ok := parseJSONContainer(entry.RawLine); ok {
+		sections = append(sections, jsonSection{title: "Raw line", root: root})
+	}
+	if body := strings.TrimSpace(entry.BodyJSON); body != "" {
+		root, err := parseJSONTree([]byte(body))
+		if err != nil {
+			quoted, _ := json.Marshal(body)
+			root = &jsonNode{scalar: string(quoted)}
+		}
+		sections = append(sections, jsonSection{title: "Body", root: root})
+	}
+	if len(entry.Attributes) > 0 {
+		keys := make([]string, 0, len(entry.Attributes))
+		for k := range entry.Attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		root := &jsonNode{kind: '{'}
+		for _, k := range keys {
+			child, ok := parseJSONContainer(entry.Attributes[k])
+			if !ok {
+				quoted, _ := json.Marshal(entry.Attributes[k])
+				child = &jsonNode{scalar: string(quoted)}
+			}
+			child.key, child.member = k, true
+			root.children = append(root.children, child)
+		}
+		sections = append(sections, jsonSection{title: "Attributes", root: root})
+	}
+	return sections
+}
+
+// jsonLines flattens the visible part of the tree under n.
+func jsonLines(n *jsonNode, depth int, comma bool, out []jsonLine) []jsonLine {
+	out = append(out, jsonLine{node: n, depth: depth, comma: comma && (n.kind == 0 || n.collapsed || len(n.children) == 0)})
+	if n.kind == 0 || n.collapsed || len(n.children) == 0 {
+		return out
+	}
+	for i, child := range n.children {
+		out = jsonLines(child, depth+1, i < len(n.children)-1, out)
+	}
+	return append(out, jsonLine{node: n, depth: depth, close: true, comma: comma})
+}
+
+// setJSONCollapsed folds or unfolds every container under n; the root
+// stays unfolded.
+func setJSONCollapsed(n *jsonNode, collapsed bool) {
+	for _, child := range n.children {
+		if child.kind != 0 {
+			child.collapsed = collapsed
+			setJSONCollapsed(child, collapsed)
+		}
+	}
+}
+
+// expandJSONMatches unfolds the containers holding a key or scalar that
+// contains term (lowercase), and reports whether n holds one.
+func expandJSONMatches(n *jsonNode, term string) bool {
+	found := false
+	for _, child := range n.children {
+		if expandJSONMatches(child, term) {
+			found = true
+		}
+	}
+	if found {
+		n.collapsed = false
+	}
+	self := strings.Contains(strings.ToLower(n.key), term) || strings.Contains(strings.ToLower(n.scalar), term)
+	return found || self
+}
+
+// jsonLineParts returns a line's indentation, fold marker, key, and value
+// text, unstyled.
+func jsonLineParts(l jsonLine) (indent, marker, key, value string) {
+	n := l.node
+	indent = strings.Repeat("  ", l.depth)
+	comma := ""
+	if l.comma {
+		comma = ","
+	}
+	closer := "}"
+	if n.kind == '[' {
+		closer = "]"
+	}
+	if l.close {
+		return indent, "  ", "", closer + comma
+	}
+	if n.member {
+		quoted, _ := json.Marshal(n.key)
+		key = string(quoted) + ": "
+	}
+	switch {
+	case n.kind == 0:
+		return indent, "  ", key, n.scalar + comma
+	case len(n.children) == 0:
+		return indent, "  ", key, string(n.kind) + closer + comma
+	case n.collapsed:
+		unit := "keys"
+		if n.kind == '[' {
+			unit = "items"
+		}
+		return indent, "▸ ", key, fmt.Sprintf("%c…%s%s %d %s", n.kind, closer, comma, len(n.children), unit)
+	default:
+		return indent, "▾ ", key, string(n.kind)
+	}
+}
+
+// jsonLineText returns a line as plain text.
+func jsonLineText(l jsonLine) string {
+	indent, marker, key, value := jsonLineParts(l)
+	return indent + marker + key + value
+}
+
+// renderJSONLine renders a line with syntax colors.
+func renderJSONLine(l jsonLine) string {
+	indent, marker, key, value := jsonLineParts(l)
+	valueStyle := lipgloss.NewStyle().Foreground(ColorWhite)
+	switch {
+	case l.close || l.node.kind != 0:
+		if l.node.collapsed && !l.close {
+			valueStyle = lipgloss.NewStyle().Foreground(ColorGray)
+		}
+	case strings.HasPrefix(l.node.scalar, `"`):
+		valueStyle = lipgloss.NewStyle().Foreground(ColorGreen)
+	case l.node.scalar == "true" || l.node.scalar == "false" || l.node.scalar == "null":
+		valueStyle = lipgloss.NewStyle().Foreground(ColorPink)
+	default:
+		valueStyle = lipgloss.NewStyle().Foreground(ColorYellow)
+	}
+	out := indent + lipgloss.NewStyle().Foreground(ColorGray).Render(marker)
+	if key != "" {
+		out += lipgloss.NewStyle().Foreground(ColorBlue).Render(key)
+	}
+	return out + valueStyle.Render(value)
+}
@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func treeText(root *jsonNode) string {
+	var out []string
+	for _, l := range jsonLines(root, 0, false, nil) {
+		out = append(out, jsonLineText(l))
+	}
+	return strings.Join(out, "\n")
+}
+
+func TestJSONTree_KeepsOrderAndFolds(t *testing.T) {
+	t.Parallel()
+
+	root, err := parseJSONTree([]byte(`{"z":1,"a":{"b":[true,null]},"s":"x"}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := strings.Join([]string{
+		`▾ {`,
+		`    "z": 1,`,
+		`  ▾ "a": {`,
+		`    ▾ "b": [`,
+		`        true,`,
+		`        null`,
+		`      ]`,
+		`    },`,
+		`    "s": "x"`,
+		`  }`,
+	}, "\n")
+	if got := treeText(root); got != want {
+		t.Fatalf("tree =\n%s\nwant\n%s", got, want)
+	}
+
+	setJSONCollapsed(root, true)
+	want = strings.Join([]string{
+		`▾ {`,
+		`    "z": 1,`,
+		`  ▸ "a": {…}, 1 keys`,
+		`    "s": "x"`,
+		`  }`,
+	}, "\n")
+	if got := treeText(root); got != want {
+		t.Fatalf("folded tree =\n%s\nwant\n%s", got, want)
+	}
+
+	if !expandJSONMatches(root, "null") || root.children[1].collapsed || root.children[1].children[0].collapsed {
+		t.Fatalf("search did not unfold the path to the match:\n%s", treeText(root))
+	}
+
+	if _, err := parseJSONTree([]byte(`{"a":1} {}`)); err == nil {
+		t.Fatal("trailing data accepted")
+	}
+}
+
+func TestLogJSONSections(t *testing.T) {
+	t.Parallel()
+
+	sections := logJSONSections(model.LogRecord{
+		RawLine:    `{"msg":"hi"}`,
+		BodyJSON:   `"plain body"`,
+		Attributes: map[string]string{"b": "2", "a": `{"nested":true}`},
+	})
+	if len(sections) != 3 || sections[0].title != "Raw line" || sections[1].title != "Body" || sections[2].title != "Attributes" {
+		t.Fatalf("sections = %+v", sections)
+	}
+	attrs := sections[2].root.children
+	if len(attrs) != 2 || attrs[0].key != "a" || attrs[0].kind != '{' || attrs[1].scalar != `"2"` {
+		t.Fatalf("attributes = %+v, want sorted keys with JSON values expanded", attrs)
+	}
+
+	if sections := logJSONSections(model.LogRecord{RawLine: "not json"}); len(sections) != 0 {
+		t.Fatalf("sections of a plain log = %+v", sections)
+	}
+}
+
+func TestDetailModal_FoldsAndSearchesTrees(t *testing.T) {
+	t.Parallel()
+
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	entry := model.LogRecord{
+		Timestamp: time.Now(),
+		Level:     "INFO",
+		Message:   "hi",
+		RawLine:   `{"req":{"id":"abc","path":"/pay"},"msg":"hi"}`,
+	}
+	d := NewDetailModal(m, &entry)
+	key := func(s string) {
+		t.Helper()
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+		switch s {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		}
+		if pop, _ := d.Update(msg); pop {
+			t.Fatalf("%q closed the modal", s)
+		}
+	}
+
+	// Fold "req", then search for a value inside it.
+	key("down")
+	key("enter")
+	if n := len(d.treeLines()); n != 4 {
+		t.Fatalf("lines after folding req = %d, want 4", n)
+	}
+	key("/")
+	for _, r := range "PAY" {
+		key(string(r))
+	}
+	key("enter")
+	if d.term != "pay" || d.trees[0].root.children[0].collapsed {
+		t.Fatalf("search term=%q, req folded=%v", d.term, d.trees[0].root.children[0].collapsed)
+	}
+	if got := jsonLineText(d.treeLines()[d.cursor]); !strings.Contains(got, "/pay") {
+		t.Fatalf("cursor on %q, want the match", got)
+	}
+	if view := d.View(120, 40); !strings.Contains(view, "Raw line") || !strings.Contains(view, `"path"`) {
+		t.Fatalf("view is missing the tree:\n%s", view)
+	}
+
+	// Esc clears the search before it closes the modal.
+	if pop, _ := d.Update(tea.KeyMsg{Type: tea.KeyEsc}); pop || d.term != "" {
+		t.Fatalf("first esc: pop=%v term=%q", pop, d.term)
+	}
+	if pop, _ := d.Update(tea.KeyMsg{Type: tea.KeyEsc}); !pop {
+		t.Fatal("second esc kept the modal open")
+	}
+}
@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"gopkg.in/yaml.v3"
+)
+
+// keymapFile is the keymap file looked up in the config dir when none is
+// configured.
+const keymapFile = "keymap.yml"
+
+// KeyMapFile is a keymap file: a preset to start from and the keys of the
+// actions it rebinds, by action name (see KeyActions).
+//
+//	preset: vim
+//	keys:
+//	  filter: ["/", "ctrl+l"]
+//	  quit: [q]
+type KeyMapFile struct {
+	Preset string              `yaml:"preset"`
+	Keys   map[string][]string `yaml:"keys"`
+}
+
+// keyMapPresets are the keymaps a keymap file or the keymap setting may
+// name, as overrides of DefaultKeyMap.
+var keyMapPresets = map[string]map[string][]string{
+	"default": nil,
+	// vim moves with hjkl, jumps with g/G, and pages with ctrl+u/ctrl+d.
+	// Help keeps only ?, and search moves off G.
+	"vim": {
+		"help":         {"?"},
+		"left":         {"left", "h"},
+		"right":        {"right", "l"},
+		"home":         {"home", "g"},
+		"end":          {"end", "G"},
+		"page-up":      {"pgup", "ctrl+u", "ctrl+b"},
+		"page-down":    {"pgdown", "pagedown", "ctrl+d"},
+		"search-modal": {"ctrl+g"},
+	},
+}
+
+// KeyMapPresets returns the names of the keymap presets, sorted.
+func KeyMapPresets() []string {
+	names := make([]string, 0, len(keyMapPresets))
+	for name := range keyMapPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keyActionName turns a KeyMap field name into its action name:
+// ToggleSidebar is toggle-sidebar, CopyJSON is copy-json.
+func keyActionName(field string) string {
+	var b strings.Builder
+	runes := []rune(field)
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('-')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// keyBindings returns the bindings of k by action name.
+func (k *KeyMap) keyBindings() map[string]*key.Binding {
+	v := reflect.ValueOf(k).Elem()
+	out := make(map[string]*key.Binding, v.NumField())
+	for i := range v.NumField() {
+		if b, ok := v.Field(i).Addr().Interface().(*key.Binding); ok {
+			out[keyActionName(v.Type().Field(i).Name)] = b
+		}
+	}
+	return out
+}
+
+// KeyActions returns the action names a keymap file may rebind, sorted.
+func KeyActions() []string {
+	var k KeyMap
+	names := make([]string, 0, 64)
+	for name := range k.keyBindings() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// rebind replaces the keys of the actions in overrides. The help shows the
+// first key.
+func (k *KeyMap) rebind(overrides map[string][]string) error {
+	bindings := k.keyBindings()
+	for _, action := range slices.Sorted(maps.Keys(overrides)) {
+		keys := overrides[action]
+		b, ok := bindings[action]
+		if !ok {
+			return fmt.Errorf("unknown action %q (one of %s)", action, strings.Join(KeyActions(), ", "))
+		}
+		if len(keys) == 0 || slices.Contains(keys, "") {
+			return fmt.Errorf("%s: keys must not be empty", action)
+		}
+		b.SetKeys(keys...)
+		b.SetHelp(keys[0], b.Help().Desc)
+	}
+	return nil
+}
+
+// BuildKeyMap returns DefaultKeyMap with the file's preset and keys
+// applied, in that order.
+func BuildKeyMap(f KeyMapFile) (KeyMap, error) {
+	k := DefaultKeyMap()
+	if f.Preset != "" {
+		preset, ok := keyMapPresets[f.Preset]
+		if !ok {
+			return k, fmt.Errorf("unknown keymap preset %q (one of %s)", f.Preset, strings.Join(KeyMapPresets(), ", "))
+		}
+		if err := k.rebind(preset); err != nil {
+			return k, err
+		}
+	}
+	if err := k.rebind(f.Keys); err != nil {
+		return k, err
+	}
+	return k, nil
+}
+
+// LoadKeyMap builds the keymap the keymap setting names: a preset, or a
+// keymap file, relative to configDir unless absolute. With no setting,
+// keymap.yml in configDir is used when present.
+func LoadKeyMap(name, configDir string) (KeyMap, error) {
+	if _, ok := keyMapPresets[name]; ok {
+		return BuildKeyMap(KeyMapFile{Preset: name})
+	}
+	path := name
+	if path == "" {
+		path = filepath.Join(configDir, keymapFile)
+	} else if !filepath.IsAbs(path) {
+		path = filepath.Join(configDir, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if name == "" && errors.Is(err, os.ErrNotExist) {
+			return DefaultKeyMap(), nil
+		}
+		return DefaultKeyMap(), fmt.Errorf("read keymap file: %w", err)
+	}
+	var f KeyMapFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return DefaultKeyMap(), fmt.Errorf("parse keymap file %s: %w", path, err)
+	}
+	k, err := BuildKeyMap(f)
+	if err != nil {
+		return DefaultKeyMap(), fmt.Errorf("keymap file %s: %w", path, err)
+	}
+	return k, nil
+}
+
+// KeyConflict is a key bound to more than one action. Only the action the
+// dashboard checks first gets it.
+type KeyConflict struct {
+	Key     string
+	Actions []string
+}
+
+// Conflicts returns the keys bound to more than one action, sorted by key.
+func (k KeyMap) Conflicts() []KeyConflict {
+	byKey := make(map[string][]string)
+	for action, b := range k.keyBindings() {
+		for _, kk := range b.Keys() {
+			byKey[kk] = append(byKey[kk], action)
+		}
+	}
+	var out []KeyConflict
+	for kk, actions := range byKey {
+		if len(actions) > 1 {
+			sort.Strings(actions)
+			out = append(out, KeyConflict{Key: kk, Actions: actions})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// customizedKeys returns the actions whose keys differ from DefaultKeyMap,
+// with their keys, sorted by action.
+func (k KeyMap) customizedKeys() [][2]string {
+	defaults := DefaultKeyMap()
+	def := defaults.keyBindings()
+	var out [][2]string
+	for action, b := range k.keyBindings() {
+		if !slices.Equal(b.Keys(), def[action].Keys()) {
+			out = append(out, [2]string{action, strings.Join(b.Keys(), ", ")})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+	return out
+}
+
+// SetKeyMap replaces the dashboard's key bindings.
+func (m *DashboardModel) SetKeyMap(k KeyMap) {
+	m.keys = k
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestKeyMap_DefaultsAndPresetsHaveNoConflicts(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range KeyMapPresets() {
+		k, err := BuildKeyMap(KeyMapFile{Preset: name})
+		if err != nil {
+			t.Fatalf("preset %s: %v", name, err)
+		}
+		if c := k.Conflicts(); len(c) > 0 {
+			t.Errorf("preset %s has conflicts: %+v", name, c)
+		}
+	}
+	for _, name := range []string{"toggle-sidebar", "copy-json", "deck-layout-reset", "page-down"} {
+		if !strings.Contains(","+strings.Join(KeyActions(), ",")+",", ","+name+",") {
+			t.Errorf("action %q missing from %v", name, KeyActions())
+		}
+	}
+}
+
+func TestLoadKeyMap(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if k, err := LoadKeyMap("", dir); err != nil || len(k.customizedKeys()) != 0 {
+		t.Fatalf("no keymap file: %v, %v", k.customizedKeys(), err)
+	}
+	if _, err := LoadKeyMap("missing.yml", dir); err == nil {
+		t.Fatal("a missing keymap file that was asked for was accepted")
+	}
+
+	vim, err := LoadKeyMap("vim", dir)
+	if err != nil || !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}}, vim.Right) {
+		t.Fatalf("vim preset: l does not move right (%v)", err)
+	}
+
+	file := "preset: vim\nkeys:\n  filter: [\"ctrl+l\", \"/\"]\n  toggle-sidebar: [s]\n"
+	if err := os.WriteFile(filepath.Join(dir, keymapFile), []byte(file), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	k, err := LoadKeyMap("", dir)
+	if err != nil {
+		t.Fatalf("LoadKeyMap: %v", err)
+	}
+	if k.Filter.Help().Key != "ctrl+l" || !key.Matches(tea.KeyMsg{Type: tea.KeyCtrlL}, k.Filter) || k.Help.Keys()[0] != "?" {
+		t.Fatalf("filter keys %v (help %q), help keys %v", k.Filter.Keys(), k.Filter.Help().Key, k.Help.Keys())
+	}
+	conflicts := k.Conflicts()
+	if len(conflicts) != 1 || conflicts[0].Key != "s" || strings.Join(conflicts[0].Actions, ",") != "search,toggle-sidebar" {
+		t.Fatalf("conflicts = %+v, want s bound to search and toggle-sidebar", conflicts)
+	}
+
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	m.SetKeyMap(k)
+	help := m.renderHelpModalContent()
+	for _, want := range []string{"KEYMAP", "filter", "ctrl+l, /", "KEYMAP CONFLICTS", "search, toggle-sidebar"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help is missing %q", want)
+		}
+	}
+
+	for _, bad := range []string{"keys:\n  fly: [x]\n", "preset: emacs\n", "keys:\n  quit: []\n"} {
+		if err := os.WriteFile(filepath.Join(dir, "bad.yml"), []byte(bad), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadKeyMap("bad.yml", dir); err == nil {
+			t.Errorf("keymap file %q was accepted", bad)
+		}
+	}
+}
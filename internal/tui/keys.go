@@ -31,6 +31,7 @@ type KeyMap struct {
 	// Actions
 	Filter         key.Binding
 	Search         key.Binding
+	Highlights     key.Binding
 	SeverityFilter key.Binding
 	LogViewer      key.Binding
 	Inspect        key.Binding
@@ -42,6 +43,24 @@ type KeyMap struct {
 	Pause          key.Binding
 	DeckPause      key.Binding
 	SearchModal    key.Binding
+	Pin            key.Binding
+	Workspace      key.Binding
+	FilterPresets  key.Binding
+	Export         key.Binding
+	Copy           key.Binding
+	CopyJSON       key.Binding
+	TailMode       key.Binding
+
+	// Deck layout
+	DeckGrow        key.Binding
+	DeckShrink      key.Binding
+	DeckWiden       key.Binding
+	DeckNarrow      key.Binding
+	DeckMoveLeft    key.Binding
+	DeckMoveRight   key.Binding
+	DeckMoveUp      key.Binding
+	DeckMoveDown    key.Binding
+	DeckLayoutReset key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings.
@@ -130,6 +149,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("s"),
 			key.WithHelp("s", "search"),
 		),
+		Highlights: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "highlight terms"),
+		),
 		SeverityFilter: key.NewBinding(
 			key.WithKeys("ctrl+f"),
 			key.WithHelp("ctrl+f", "severity filter"),
@@ -174,5 +197,69 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("G"),
 			key.WithHelp("G", "search logs"),
 		),
+		Pin: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "pin log"),
+		),
+		Workspace: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "workspace"),
+		),
+		FilterPresets: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "filter presets"),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "export logs"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy"),
+		),
+		CopyJSON: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy log JSON"),
+		),
+		TailMode: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "live tail"),
+		),
+		DeckGrow: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", "taller deck"),
+		),
+		DeckShrink: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "shorter deck"),
+		),
+		DeckWiden: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "wider deck"),
+		),
+		DeckNarrow: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "narrower deck"),
+		),
+		DeckMoveLeft: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+←", "move deck left"),
+		),
+		DeckMoveRight: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+→", "move deck right"),
+		),
+		DeckMoveUp: key.NewBinding(
+			key.WithKeys("shift+up"),
+			key.WithHelp("shift+↑", "move deck up"),
+		),
+		DeckMoveDown: key.NewBinding(
+			key.WithKeys("shift+down"),
+			key.WithHelp("shift+↓", "move deck down"),
+		),
+		DeckLayoutReset: key.NewBinding(
+			key.WithKeys("0"),
+			key.WithHelp("0", "reset deck layout"),
+		),
 	}
 }
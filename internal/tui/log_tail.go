@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// logTailBuffer is how many pushed records the log scroll's tail holds
+// between updates.
+const logTailBuffer = 1024
+
+// logTailMsg carries the records pushed to a live tail of the log scroll.
+type logTailMsg struct {
+	tail    model.LogTail
+	records []model.LogRecord
+	closed  bool // the tail ended after records
+}
+
+// waitForLogTail waits for the next record pushed to tail and takes the
+// others already waiting with it.
+func waitForLogTail(tail model.LogTail) tea.Cmd {
+	return func() tea.Msg {
+		r, ok := <-tail.Records()
+		if !ok {
+			return logTailMsg{tail: tail, closed: true}
+		}
+		records := []model.LogRecord{r}
+		for len(records) < logTailBuffer {
+			select {
+			case r, ok := <-tail.Records():
+				if !ok {
+					return logTailMsg{tail: tail, records: records, closed: true}
+				}
+				records = append(records, r)
+			default:
+				return logTailMsg{tail: tail, records: records}
+			}
+		}
+		return logTailMsg{tail: tail, records: records}
+	}
+}
+
+// logTailFilter returns the live tail matching the log list's filters, or
+// false when the list cannot be tailed: a time window is not live, and an
+// empty severity selection shows nothing.
+func (m *DashboardModel) logTailFilter() (model.TailFilter, bool) {
+	opts := m.logQueryOpts()
+	levels := m.activeSeverityLevels()
+	if !opts.From.IsZero() || !opts.To.IsZero() || (levels != nil && len(levels) == 0) {
+		return model.TailFilter{}, false
+	}
+	// activeSeverityLevels walks a map; sort so equal filters compare equal.
+	slices.Sort(levels)
+	filter := model.TailFilter{App: opts.App, SeverityLevels: levels, Facets: m.logFacets()}
+	if m.filterRegex != nil {
+		filter.MessagePattern = m.filterRegex.String()
+	}
+	return filter, true
+}
+
+func tailFilterEqual(a, b model.TailFilter) bool {
+	return a.App == b.App && a.MessagePattern == b.MessagePattern && slices.Equal(a.SeverityLevels, b.SeverityLevels) && maps.Equal(a.Facets, b.Facets)
+}
+
+// syncLogTail keeps a live tail of the log list open for its current
+// filters when the store can push records. It reports whether the tail
+// already feeds the list, so the tick need not poll for it. A tail opened
+// now reports false: the tick still loads the stored records once, and
+// the records pushed meanwhile are merged into them. The command waits for
+// the new tail's records.
+func (m *DashboardModel) syncLogTail() (bool, tea.Cmd) {
+	tailer, ok := m.store.(model.LogTailer)
+	if !ok || !supports(m.store, "Subscribe") {
+		return false, nil
+	}
+	filter, ok := m.logTailFilter()
+	if !ok || reconnecting(m.store) {
+		// A tail that failed while the server was away is retried once
+		// it is back.
+		m.closeLogTail()
+		return false, nil
+	}
+	if m.logTail != nil && tailFilterEqual(m.logTailFilterOpen, filter) {
+		return m.logTailSeeded, nil
+	}
+	if m.logTail == nil && m.logTailFailed && tailFilterEqual(m.logTailFilterOpen, filter) {
+		return false, nil // poll rather than redial every tick
+	}
+	m.closeLogTail()
+	m.logTailFilterOpen = filter
+	tail, err := tailer.TailLogs(filter, logTailBuffer)
+	if err != nil {
+		m.logTailFailed = true
+		return false, nil
+	}
+	m.logTail = tail
+	return false, waitForLogTail(tail)
+}
+
+// closeLogTail ends the live tail, if any; the list is polled again until
+// syncLogTail opens another.
+func (m *DashboardModel) closeLogTail() {
+	if m.logTail != nil {
+		m.logTail.Close()
+	}
+	m.logTail = nil
+	m.logTailSeeded = false
+	m.logTailFailed = false
+	m.logTailPending = nil
+}
+
+// handleLogTail adds pushed records to the log list. Records from a tail
+// that no longer matches the filters, or that lost records, end it; the
+// next tick reloads the list and opens a fresh one.
+func (m *DashboardModel) handleLogTail(msg logTailMsg) tea.Cmd {
+	if msg.tail != m.logTail {
+		return nil // a tail since closed
+	}
+	filter, ok := m.logTailFilter()
+	if !ok || !tailFilterEqual(filter, m.logTailFilterOpen) || m.liveUpdatesPaused() || msg.tail.Dropped() > 0 {
+		m.closeLogTail()
+		return nil
+	}
+	if m.logTailSeeded {
+		m.applyLogEntries(mergeLogEntries(m.logEntries, msg.records, m.logListLimit()))
+	} else {
+		m.logTailPending = mergeLogEntries(m.logTailPending, msg.records, m.logListLimit())
+	}
+	if msg.closed {
+		m.closeLogTail()
+		return nil
+	}
+	return waitForLogTail(msg.tail)
+}
+
+// mergeLogEntries appends to base the records of extra it does not already
+// hold, keeping the newest limit.
+func mergeLogEntries(base, extra []model.LogRecord, limit int) []model.LogRecord {
+	seen := make(map[string]struct{}, len(base)+len(extra))
+	merged := make([]model.LogRecord, 0, len(base)+len(extra))
+	for _, records := range [][]model.LogRecord{base, extra} {
+		for _, r := range records {
+			key := logEntryKey(r)
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			merged = append(merged, r)
+		}
+	}
+	if limit > 0 && len(merged) > limit {
+		merged = merged[len(merged)-limit:]
+	}
+	return merged
+}
+
+// logEntryKey identifies a record across the store and the live tail. The
+// store keeps timestamps to the microsecond and does not return event ids.
+func logEntryKey(r model.LogRecord) string {
+	return strconv.FormatInt(r.Timestamp.UnixMicro(), 10) + "\x00" + r.App + "\x00" + r.Level + "\x00" + r.Message
+}
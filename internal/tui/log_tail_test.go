@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// tailingStore is a countingStore that pushes records added to its sink.
+type tailingStore struct {
+	countingStore
+	*ingest.TailSink
+}
+
+func TestLogTail_FeedsLogList(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	store := &tailingStore{TailSink: ingest.NewTailSink(nil)}
+	store.recentLogs = []model.LogRecord{{Message: "stored", Level: "INFO", App: "default", Timestamp: now}}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.activeSection = SectionDecks
+
+	m.Update(TickMsg(now))
+	if store.Tails() != 1 || m.logTail == nil {
+		t.Fatalf("tick opened %d tails, want 1", store.Tails())
+	}
+
+	// Records pushed before the stored ones load are merged into them;
+	// one the store already returned is not shown twice.
+	store.Add(&model.LogRecord{Message: "stored", Level: "INFO", Timestamp: now})
+	store.Add(&model.LogRecord{Message: "pushed early", Level: "INFO", Timestamp: now.Add(time.Second)})
+	m.Update(waitForLogTail(m.logTail)())
+	m.Update(m.fetchTickDataCmd(m.logQueryOpts(), nil, "", 10, 0)())
+	if got := messages(m.logEntries); got != "stored,pushed early" {
+		t.Fatalf("log list after the seed = %s", got)
+	}
+	if tailing, _ := m.syncLogTail(); !tailing {
+		t.Fatal("seeded tail does not feed the list")
+	}
+
+	polls := store.recentLogsFilteredCalls
+	m.Update(m.fetchTickDataCmd(m.logQueryOpts(), nil, "", -1, 0)())
+	if store.recentLogsFilteredCalls != polls {
+		t.Fatal("tick polled the log list while tailing")
+	}
+
+	store.Add(&model.LogRecord{Message: "pushed", Level: "INFO", Timestamp: now.Add(2 * time.Second)})
+	m.Update(waitForLogTail(m.logTail)())
+	if got := messages(m.logEntries); got != "stored,pushed early,pushed" {
+		t.Fatalf("log list after a push = %s", got)
+	}
+
+	// Records from a tail the filters moved away from end it.
+	m.selectedApp = "shop"
+	store.Add(&model.LogRecord{Message: "other", Level: "INFO", Timestamp: now.Add(3 * time.Second)})
+	m.Update(waitForLogTail(m.logTail)())
+	if store.Tails() != 0 || m.logTail != nil {
+		t.Fatal("stale tail still open")
+	}
+
+	// A time window is not live; pausing closes the tail.
+	m.Update(TickMsg(now))
+	if store.Tails() != 1 {
+		t.Fatal("tick did not reopen the tail for the new app")
+	}
+	m.viewPaused = true
+	m.Update(TickMsg(now))
+	if store.Tails() != 0 {
+		t.Fatal("paused view kept its tail")
+	}
+	m.viewPaused = false
+	m.tickInFlight = false // the reopening tick's load never ran
+	m.timeWindow = WorkspaceTimeRange{From: now.Add(-time.Hour), To: now}
+	m.Update(TickMsg(now))
+	if store.Tails() != 0 {
+		t.Fatal("tick tailed a time window")
+	}
+}
+
+func messages(records []model.LogRecord) string {
+	var s string
+	for i, r := range records {
+		if i > 0 {
+			s += ","
+		}
+		s += r.Message
+	}
+	return s
+}
+
+// reconnectingStore is a tailingStore whose server can go away.
+type reconnectingStore struct {
+	tailingStore
+	lost bool
+}
+
+func (s *reconnectingStore) Reconnecting() bool { return s.lost }
+
+func TestLogTail_WaitsForReconnect(t *testing.T) {
+	t.Parallel()
+
+	store := &reconnectingStore{tailingStore: tailingStore{TailSink: ingest.NewTailSink(nil)}}
+	store.lost = true
+	m := NewDashboardModel(1000, time.Second, false, false, store, "Socket")
+	m.activeSection = SectionDecks
+	m.width = 200
+	m.lastError = "socketrpc: reconnecting"
+	m.lastErrorAt = time.Now()
+
+	m.Update(TickMsg(time.Now()))
+	if store.Tails() != 0 {
+		t.Fatal("tick tailed a lost server")
+	}
+	status := m.renderStatusLine()
+	if !strings.Contains(status, "reconnecting…") || strings.Contains(status, "DB error") {
+		t.Fatalf("status line while reconnecting = %q", status)
+	}
+
+	store.lost = false
+	m.tickInFlight = false
+	m.Update(TickMsg(time.Now()))
+	if store.Tails() != 1 {
+		t.Fatal("tick did not tail once the server was back")
+	}
+}
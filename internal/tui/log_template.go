@@ -0,0 +1,261 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// LogTemplateAllViews is the template key applied to views without their own template.
+const LogTemplateAllViews = "*"
+
+// LogTemplateViewer is the template key for the fullscreen log viewer.
+const LogTemplateViewer = "log-viewer"
+
+// logTemplateMinMessage is the narrowest a flexible {message} field gets.
+const logTemplateMinMessage = 10
+
+// LogTemplate renders one log record per line from a pattern such as
+// "{time} {level:5} {k8s.pod:20} {message}".
+//
+// Placeholders name a built-in field (time, timestamp, level, message,
+// host, service, app, source) or any attribute key. An optional ":N"
+// pads or truncates the value to N columns. A {message} without a width
+// takes whatever space is left on the line. "{{" and "}}" are literal braces.
+type LogTemplate struct {
+	raw      string
+	segments []templateSegment
+}
+
+type templateSegment struct {
+	literal string
+	field   string // empty for literal segments
+	width   int    // 0 = natural width
+}
+
+// ParseLogTemplate compiles a display template.
+func ParseLogTemplate(s string) (*LogTemplate, error) {
+	t := &LogTemplate{raw: s}
+	var lit strings.Builder
+	flushLiteral := func() {
+		if lit.Len() > 0 {
+			t.segments = append(t.segments, templateSegment{literal: lit.String()})
+			lit.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			if i+1 < len(s) && s[i+1] == '{' {
+				lit.WriteByte('{')
+				i++
+				continue
+			}
+			end := strings.IndexByte(s[i+1:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed placeholder at offset %d", i)
+			}
+			seg, err := parsePlaceholder(s[i+1 : i+1+end])
+			if err != nil {
+				return nil, err
+			}
+			flushLiteral()
+			t.segments = append(t.segments, seg)
+			i += end + 1
+		case '}':
+			if i+1 < len(s) && s[i+1] == '}' {
+				i++
+			}
+			lit.WriteByte('}')
+		default:
+			lit.WriteByte(s[i])
+		}
+	}
+	flushLiteral()
+
+	hasField := false
+	for _, seg := range t.segments {
+		if seg.field != "" {
+			hasField = true
+			break
+		}
+	}
+	if !hasField {
+		return nil, fmt.Errorf("template has no {field} placeholders")
+	}
+	return t, nil
+}
+
+func parsePlaceholder(body string) (templateSegment, error) {
+	name, widthStr, hasWidth := strings.Cut(strings.TrimSpace(body), ":")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return templateSegment{}, fmt.Errorf("empty placeholder {%s}", body)
+	}
+	seg := templateSegment{field: name}
+	if hasWidth {
+		w, err := strconv.Atoi(strings.TrimSpace(widthStr))
+		if err != nil || w <= 0 {
+			return templateSegment{}, fmt.Errorf("invalid width in {%s}", body)
+		}
+		seg.width = w
+	}
+	return seg, nil
+}
+
+// String returns the template source.
+func (t *LogTemplate) String() string { return t.raw }
+
+// Fields returns the placeholder names in order.
+func (t *LogTemplate) Fields() []string {
+	var fields []string
+	for _, seg := range t.segments {
+		if seg.field != "" {
+			fields = append(fields, seg.field)
+		}
+	}
+	return fields
+}
+
+// templateValue resolves a placeholder against a record.
+func templateValue(field string, entry model.LogRecord, ts time.Time) string {
+	switch field {
+	case "time":
+		return ts.Format("15:04:05")
+	case "timestamp":
+		return ts.Format("2006-01-02 15:04:05.000")
+	case "level", "severity":
+		return entry.Level
+	case "message", "msg":
+		return strings.ReplaceAll(entry.Message, "\n", " ")
+	case "host":
+		if v := entry.Attributes["host.name"]; v != "" {
+			return v
+		}
+		return entry.Hostname
+	case "service":
+		if v := entry.Attributes["service.name"]; v != "" {
+			return v
+		}
+		return entry.Service
+	case "app":
+		return entry.App
+	case "source":
+		return entry.Source
+	}
+	return entry.Attributes[field]
+}
+
+// templateFieldStyle picks the same colors the default columns use.
+func templateFieldStyle(field, level string) lipgloss.Style {
+	switch field {
+	case "time", "timestamp":
+		return lipgloss.NewStyle().Foreground(ColorGray)
+	case "level", "severity":
+		return lipgloss.NewStyle().Foreground(GetSeverityColor(level)).Bold(true)
+	case "host", "k8s.namespace":
+		return lipgloss.NewStyle().Foreground(ColorGreen)
+	case "service", "k8s.pod":
+		return lipgloss.NewStyle().Foreground(ColorBlue)
+	}
+	return lipgloss.NewStyle()
+}
+
+func isMessageField(field string) bool {
+	return field == "message" || field == "msg"
+}
+
+// fitWidth pads or truncates s to exactly w display columns.
+func fitWidth(s string, w int) string {
+	if runewidth.StringWidth(s) > w {
+		if w <= 3 {
+			return runewidth.Truncate(s, w, "")
+		}
+		return runewidth.Truncate(s, w, "...")
+	}
+	return runewidth.FillRight(s, w)
+}
+
+// layout resolves every segment to plain text, giving flexible message
+// fields the width left over after everything else.
+func (t *LogTemplate) layout(value func(seg templateSegment) string, availableWidth int) []string {
+	parts := make([]string, len(t.segments))
+	used := 0
+	flexible := 0
+	for i, seg := range t.segments {
+		switch {
+		case seg.field == "":
+			parts[i] = seg.literal
+		case seg.width > 0:
+			parts[i] = fitWidth(value(seg), seg.width)
+		case isMessageField(seg.field):
+			flexible++
+			continue
+		default:
+			parts[i] = value(seg)
+		}
+		used += runewidth.StringWidth(parts[i])
+	}
+
+	if flexible > 0 {
+		room := (availableWidth - used) / flexible
+		if room < logTemplateMinMessage {
+			room = logTemplateMinMessage
+		}
+		for i, seg := range t.segments {
+			if seg.field != "" && seg.width == 0 && isMessageField(seg.field) {
+				v := value(seg)
+				if runewidth.StringWidth(v) > room {
+					v = runewidth.Truncate(v, room, "...")
+				}
+				parts[i] = v
+			}
+		}
+	}
+	return parts
+}
+
+// Header renders the placeholder names aligned with Render's columns.
+func (t *LogTemplate) Header(availableWidth int) string {
+	parts := t.layout(func(seg templateSegment) string {
+		name := seg.field
+		if len(name) > 0 {
+			name = strings.ToUpper(name[:1]) + name[1:]
+		}
+		return name
+	}, availableWidth)
+	return lipgloss.NewStyle().Foreground(ColorWhite).Render(strings.Join(parts, ""))
+}
+
+// Render formats entry for the list. Selected rows are rendered as plain
+// text so the caller can apply the selection style to the whole line.
+func (t *LogTemplate) Render(entry model.LogRecord, ts time.Time, availableWidth int, styled bool, highlight func(string) string) string {
+	parts := t.layout(func(seg templateSegment) string {
+		return templateValue(seg.field, entry, ts)
+	}, availableWidth)
+	if !styled {
+		return strings.Join(parts, "")
+	}
+
+	var b strings.Builder
+	for i, seg := range t.segments {
+		part := parts[i]
+		if seg.field == "" {
+			b.WriteString(part)
+			continue
+		}
+		if isMessageField(seg.field) && highlight != nil {
+			b.WriteString(highlight(part))
+			continue
+		}
+		b.WriteString(templateFieldStyle(seg.field, entry.Level).Render(part))
+	}
+	return b.String()
+}
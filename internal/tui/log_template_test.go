@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestParseLogTemplate(t *testing.T) {
+	tmpl, err := ParseLogTemplate("{time} {level:5} {k8s.pod:10} {{literal}} {message}")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got := strings.Join(tmpl.Fields(), ",")
+	if got != "time,level,k8s.pod,message" {
+		t.Fatalf("fields = %q", got)
+	}
+
+	for _, bad := range []string{"", "no placeholders", "{time", "{}", "{level:0}", "{level:x}"} {
+		if _, err := ParseLogTemplate(bad); err == nil {
+			t.Errorf("ParseLogTemplate(%q) should fail", bad)
+		}
+	}
+}
+
+func TestLogTemplateRender(t *testing.T) {
+	tmpl, err := ParseLogTemplate("{time} {level:5} [{k8s.pod:8}] {{x}} {message}")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	entry := model.LogRecord{
+		Level:      "WARN",
+		Message:    strings.Repeat("m", 100),
+		Attributes: map[string]string{"k8s.pod": "api-7f9c8d-abcde"},
+	}
+	ts := time.Date(2025, 1, 1, 12, 30, 45, 0, time.UTC)
+
+	line := tmpl.Render(entry, ts, 60, false, nil)
+	if !strings.HasPrefix(line, "12:30:45 WARN  [api-7...] {x} mmm") {
+		t.Fatalf("line = %q", line)
+	}
+	if len(line) != 60 || !strings.HasSuffix(line, "...") {
+		t.Fatalf("message should fill the remaining width, got %d chars: %q", len(line), line)
+	}
+
+	header := tmpl.Header(60)
+	if !strings.Contains(header, "Time") || !strings.Contains(header, "K8s.pod") {
+		t.Fatalf("header = %q", header)
+	}
+}
+
+func TestActiveLogTemplatePerView(t *testing.T) {
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	if m.activeLogTemplate() != nil {
+		t.Fatal("no template should be active by default")
+	}
+
+	if err := m.SetLogTemplates(map[string]string{"list": "{level} {message}", "*": "{time} {message}"}); err != nil {
+		t.Fatalf("SetLogTemplates: %v", err)
+	}
+	if got := m.activeLogTemplate(); got == nil || got.String() != "{time} {message}" {
+		t.Fatalf("base view should use the fallback template, got %v", got)
+	}
+
+	pg := m.activePage()
+	for i, vw := range pg.Views {
+		if vw.ID == "list" {
+			pg.ActiveViewIdx = i
+		}
+	}
+	if got := m.activeLogTemplate(); got == nil || got.String() != "{level} {message}" {
+		t.Fatalf("list view template = %v", got)
+	}
+
+	if err := m.SetLogTemplates(map[string]string{"list": "{oops"}); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
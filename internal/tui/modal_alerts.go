@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// alertSilenceFor is how long x silences an alert rule.
+const alertSilenceFor = time.Hour
+
+// alertActionMsg carries the outcome of a silence or acknowledgement and
+// the alerts as they are after it.
+type alertActionMsg struct {
+	alerts []model.Alert
+	notice string
+	err    error
+}
+
+// AlertsModal lists the alerts of every state and silences or
+// acknowledges the selected one on the server's alert engine.
+type AlertsModal struct {
+	dashboard *DashboardModel
+	alerts    []model.Alert // grouped by state in alertStates order
+	cursor    int
+	busy      bool
+	notice    string
+	err       error
+}
+
+// NewAlertsModal creates an alerts modal from alerts, with the cursor on
+// the first alert in state.
+func NewAlertsModal(m *DashboardModel, state string, alerts []model.Alert) *AlertsModal {
+	a := &AlertsModal{dashboard: m}
+	a.setAlerts(alerts)
+	for i, alert := range a.alerts {
+		if alert.State == state {
+			a.cursor = i
+			break
+		}
+	}
+	return a
+}
+
+func (a *AlertsModal) ID() string { return "alerts" }
+
+// setAlerts replaces the listed alerts, keeping the cursor on the same
+// rule when it is still listed.
+func (a *AlertsModal) setAlerts(alerts []model.Alert) {
+	var selected string
+	if a.cursor < len(a.alerts) {
+		selected = a.alerts[a.cursor].Rule
+	}
+	var grouped []model.Alert
+	for _, state := range alertStates {
+		grouped = append(grouped, alertsInState(alerts, state)...)
+	}
+	a.alerts = grouped
+	a.cursor = min(a.cursor, max(0, len(a.alerts)-1))
+	for i, alert := range a.alerts {
+		if alert.Rule == selected {
+			a.cursor = i
+			break
+		}
+	}
+}
+
+// act runs do against the store's alert engine for the selected rule, then
+// reloads the alerts.
+func (a *AlertsModal) act(do func(model.AlertManager, string) error, notice string) tea.Cmd {
+	manager, ok := a.dashboard.store.(model.AlertManager)
+	if a.busy || !ok || a.cursor >= len(a.alerts) {
+		return nil
+	}
+	a.busy = true
+	rule := a.alerts[a.cursor].Rule
+	return func() tea.Msg {
+		if err := do(manager, rule); err != nil {
+			return alertActionMsg{err: err}
+		}
+		alerts, err := manager.Alerts()
+		return alertActionMsg{alerts: alerts, notice: fmt.Sprintf(notice, rule), err: err}
+	}
+}
+
+func (a *AlertsModal) Update(msg tea.Msg) (pop bool, cmd tea.Cmd) {
+	switch msg := msg.(type) {
+	case alertActionMsg:
+		a.busy = false
+		a.err = msg.err
+		if msg.err == nil {
+			a.notice = msg.notice
+			a.setAlerts(msg.alerts)
+		}
+		return false, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "escape", "q":
+			return true, nil
+		case "up", "k":
+			if a.cursor > 0 {
+				a.cursor--
+			}
+		case "down", "j":
+			if a.cursor < len(a.alerts)-1 {
+				a.cursor++
+			}
+		case "x":
+			if a.cursor >= len(a.alerts) {
+				return false, nil
+			}
+			if a.alerts[a.cursor].Silenced(time.Now()) {
+				return false, a.act(func(am model.AlertManager, rule string) error {
+					return am.SilenceAlert(rule, 0)
+				}, "lifted the silence on %s")
+			}
+			return false, a.act(func(am model.AlertManager, rule string) error {
+				return am.SilenceAlert(rule, alertSilenceFor)
+			}, "silenced %s for 1h")
+		case "a":
+			return false, a.act(model.AlertManager.AcknowledgeAlert, "acknowledged %s")
+		}
+	}
+	return false, nil
+}
+
+func (a *AlertsModal) View(width, height int) string {
+	modalWidth := min(width-8, 110)
+	if modalWidth < 40 {
+		modalWidth = 40
+	}
+	innerWidth := modalWidth - 4
+
+	labelStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	headerStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	sections := []string{headerStyle.Render("Alerts")}
+
+	now := time.Now()
+	visible := max(3, height-12)
+	start := 0
+	if a.cursor >= visible {
+		start = a.cursor - visible + 1
+	}
+	lastState := ""
+	for i, alert := range a.alerts {
+		if i < start || i >= start+visible {
+			continue
+		}
+		if alert.State != lastState {
+			sections = append(sections, renderThinSeparator(innerWidth), labelStyle.Render(alertStateTitle(alert.State)))
+			lastState = alert.State
+		}
+		line := renderAlertLine(alert, innerWidth-2, now)
+		if i == a.cursor {
+			line = lipgloss.NewStyle().Background(lipgloss.Color("#1a3a5c")).Render("▸ " + line)
+		} else {
+			line = "  " + line
+		}
+		sections = append(sections, line)
+		if i == a.cursor && alert.Summary != "" {
+			sections = append(sections, labelStyle.Render(fitWidth("    "+alert.Summary, innerWidth)))
+		}
+	}
+	if len(a.alerts) == 0 {
+		sections = append(sections, renderThinSeparator(innerWidth),
+			lipgloss.NewStyle().Foreground(ColorGray).Italic(true).Render("no firing, pending, or recently resolved alerts"))
+	}
+
+	sections = append(sections, renderThinSeparator(innerWidth))
+	switch {
+	case a.busy:
+		sections = append(sections, labelStyle.Render("working…"))
+	case a.err != nil:
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorRed).Render("error: "+a.err.Error()))
+	case a.notice != "":
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorGreen).Render(fitWidth(a.notice, innerWidth)))
+	}
+	sections = append(sections, labelStyle.Render("x: Silence 1h/unsilence  a: Acknowledge  up/down: Select  Esc: Close"))
+
+	return lipgloss.NewStyle().
+		Width(modalWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Padding(1, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
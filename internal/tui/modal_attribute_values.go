@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// AttributeValuesModal lists one attribute key's values with their counts
+// and filters the logs by the selected one.
+type AttributeValuesModal struct {
+	dashboard *DashboardModel
+	key       string
+	values    []model.DimensionCount
+	cursor    int
+}
+
+// NewAttributeValuesModal creates a values modal for key.
+func NewAttributeValuesModal(m *DashboardModel, key string, values []model.DimensionCount) *AttributeValuesModal {
+	return &AttributeValuesModal{dashboard: m, key: key, values: values}
+}
+
+func (a *AttributeValuesModal) ID() string { return "attribute-values" }
+
+func (a *AttributeValuesModal) Update(msg tea.Msg) (pop bool, cmd tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+	switch keyMsg.String() {
+	case "esc", "escape", "q":
+		return true, nil
+	case "up", "k":
+		if a.cursor > 0 {
+			a.cursor--
+		}
+	case "down", "j":
+		if a.cursor < len(a.values)-1 {
+			a.cursor++
+		}
+	case "enter":
+		if a.cursor < len(a.values) {
+			return true, a.dashboard.drillDownToAttribute(a.key, a.values[a.cursor].Value)
+		}
+	case "f":
+		if a.cursor < len(a.values) {
+			a.dashboard.addFacet(a.key, a.values[a.cursor].Value)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (a *AttributeValuesModal) View(width, height int) string {
+	modalWidth := min(width-8, 100)
+	if modalWidth < 40 {
+		modalWidth = 40
+	}
+	innerWidth := modalWidth - 4
+
+	labelStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	headerStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	sections := []string{
+		headerStyle.Render(fitWidth(fmt.Sprintf("Values of %s (%d)", a.key, len(a.values)), innerWidth)),
+		renderThinSeparator(innerWidth),
+	}
+
+	var total int64
+	for _, v := range a.values {
+		total += v.Count
+	}
+	visible := max(3, height-10)
+	start := 0
+	if a.cursor >= visible {
+		start = a.cursor - visible + 1
+	}
+	for i := start; i < min(len(a.values), start+visible); i++ {
+		v := a.values[i]
+		share := fmt.Sprintf("%8d %5.1f%%  ", v.Count, float64(v.Count)*100/float64(max(1, total)))
+		line := labelStyle.Render(share) + lipgloss.NewStyle().Foreground(ColorWhite).Render(fitWidth(v.Value, max(1, innerWidth-2-len(share))))
+		if i == a.cursor {
+			line = lipgloss.NewStyle().Background(lipgloss.Color("#1a3a5c")).Render("▸ " + line)
+		} else {
+			line = "  " + line
+		}
+		sections = append(sections, line)
+	}
+	if len(a.values) == 0 {
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorGray).Italic(true).Render("no values recorded for this attribute"))
+	}
+
+	sections = append(sections,
+		renderThinSeparator(innerWidth),
+		labelStyle.Render("Enter: Open matching logs  f: Add facet  up/down: Select  Esc: Close"),
+	)
+
+	return lipgloss.NewStyle().
+		Width(modalWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Padding(1, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
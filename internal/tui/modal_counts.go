@@ -47,7 +47,7 @@ func (m *DashboardModel) renderCountsModalWithViewport(vp *viewport.Model, cm *C
 	// Status bar
 	statusBar := lipgloss.NewStyle().
 		Foreground(ColorGray).
-		Render("up/down/Wheel: Scroll | PgUp/PgDn: Page | ESC: Close")
+		Render("arrows/hjkl: Select cell | Enter: Show logs | PgUp/PgDn/Wheel: Scroll | ESC: Close")
 
 	// Combine all parts
 	modal := lipgloss.JoinVertical(lipgloss.Left, header, contentPane, statusBar)
@@ -78,7 +78,7 @@ func (m *DashboardModel) renderCountsModalContent(contentWidth int, cm *CountsMo
 	sections = append(sections, "")
 
 	// Heatmap section - full width
-	heatmapSection := m.renderHeatmapSection(contentWidth, cm.countsHeatmapData)
+	heatmapSection := m.renderHeatmapSection(contentWidth, cm)
 	sections = append(sections, heatmapSection)
 	sections = append(sections, "")
 
@@ -95,8 +95,11 @@ func (m *DashboardModel) renderCountsModalContent(contentWidth int, cm *CountsMo
 	return strings.Join(sections, "\n")
 }
 
-// renderHeatmapSection renders the severity heatmap chart using provided data
-func (m *DashboardModel) renderHeatmapSection(width int, minuteData []model.MinuteCounts) string {
+// renderHeatmapSection renders the severity heatmap chart using the modal's
+// data, highlighting the cell under its cursor.
+func (m *DashboardModel) renderHeatmapSection(width int, cm *CountsModal) string {
+	minuteData := cm.countsHeatmapData
+
 	// Use deckTitleStyle for consistent title formatting
 	titleContent := deckTitleStyle.Render("Severity Activity Heatmap (Last 60 Minutes)")
 
@@ -137,7 +140,7 @@ func (m *DashboardModel) renderHeatmapSection(width int, minuteData []model.Minu
 	}
 
 	// Get severity order and colors
-	severities := []string{"FATAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE"}
+	severities := heatmapSeverities
 	colors := map[string]lipgloss.Color{
 		"FATAL": ColorRed, "ERROR": ColorRed, "WARN": ColorOrange,
 		"INFO": ColorBlue, "DEBUG": ColorGray, "TRACE": ColorGray,
@@ -160,14 +163,16 @@ func (m *DashboardModel) renderHeatmapSection(width int, minuteData []model.Minu
 		}
 	}
 
+	cursorStyle := lipgloss.NewStyle().Reverse(true)
+
 	// Render each severity level row
-	for _, severity := range severities {
+	for row, severity := range severities {
 		severityWithCount := fmt.Sprintf("%s (%d)", severity, severityTotals[severity])
 		coloredLabel := lipgloss.NewStyle().Foreground(getSeverityColor(severity)).Bold(true).Render(fmt.Sprintf("%-12s", severityWithCount))
 
 		line := coloredLabel + "    "
 
-		for i := 60; i >= 0; i-- {
+		for i := heatmapMinutes; i >= 0; i-- {
 			minuteTime := now.Add(time.Duration(-i) * time.Minute).Truncate(time.Minute)
 
 			var minuteActivity int64
@@ -193,7 +198,9 @@ func (m *DashboardModel) renderHeatmapSection(width int, minuteData []model.Minu
 				}
 			}
 
-			if found && minuteActivity > 0 {
+			if row == cm.cursorSeverity && i == cm.cursorMinutesAgo {
+				line += cursorStyle.Render(symbol)
+			} else if found && minuteActivity > 0 {
 				styledSymbol := lipgloss.NewStyle().Foreground(colors[severity]).Render(symbol)
 				line += styledSymbol
 			} else {
@@ -206,6 +213,9 @@ func (m *DashboardModel) renderHeatmapSection(width int, minuteData []model.Minu
 
 	contentLines = append(contentLines, "")
 	contentLines = append(contentLines, "Legend: █ High Activity  ▓ Medium Activity  ▒ Low Activity  . No Activity")
+	cursorMinute := cm.cursorMinute(now)
+	contentLines = append(contentLines, fmt.Sprintf("Selected: %s at %s–%s (%d mins ago) — Enter to show logs",
+		cm.cursorSeverityName(), cursorMinute.Format("15:04"), cursorMinute.Add(time.Minute).Format("15:04"), cm.cursorMinutesAgo))
 
 	content := strings.Join(contentLines, "\n")
 
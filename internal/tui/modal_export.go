@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ExportModal writes the filtered log list, or the pinned logs, to a file.
+// Tab cycles the format, Shift+Tab switches between the two sets.
+type ExportModal struct {
+	dashboard *DashboardModel
+	input     textinput.Model
+	format    int  // index into logExportFormats
+	pinned    bool // export the pinned logs instead of the filtered list
+	notice    string
+	err       error
+}
+
+// NewExportModal creates an export modal with a path in the current
+// directory named after the time.
+func NewExportModal(m *DashboardModel) *ExportModal {
+	ti := textinput.New()
+	ti.Prompt = "Path: "
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(ColorBlue)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(ColorWhite)
+	ti.CharLimit = 500
+	ti.SetValue("logs-" + time.Now().Format("20060102-150405") + exportExtension(logExportFormats[0]))
+	ti.CursorEnd()
+	ti.Focus()
+	return &ExportModal{dashboard: m, input: ti}
+}
+
+func (e *ExportModal) ID() string { return "export" }
+
+// records returns the set being exported.
+func (e *ExportModal) records() []model.LogRecord {
+	if e.pinned {
+		out := make([]model.LogRecord, len(e.dashboard.pinned))
+		for i, p := range e.dashboard.pinned {
+			out[i] = p.Record
+		}
+		return out
+	}
+	return e.dashboard.logEntries
+}
+
+func (e *ExportModal) Update(msg tea.Msg) (pop bool, cmd tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+	switch keyMsg.String() {
+	case "esc", "escape":
+		return true, nil
+	case "tab":
+		prev := exportExtension(logExportFormats[e.format])
+		e.format = (e.format + 1) % len(logExportFormats)
+		if path := e.input.Value(); strings.HasSuffix(path, prev) {
+			e.input.SetValue(strings.TrimSuffix(path, prev) + exportExtension(logExportFormats[e.format]))
+			e.input.CursorEnd()
+		}
+		return false, nil
+	case "shift+tab":
+		e.pinned = !e.pinned && len(e.dashboard.pinned) > 0
+		return false, nil
+	case "enter":
+		records := e.records()
+		if len(records) == 0 {
+			e.err = fmt.Errorf("no logs to export")
+			return false, nil
+		}
+		path, err := exportLogsToFile(e.input.Value(), records, logExportFormats[e.format])
+		if err != nil {
+			e.err = err
+			return false, nil
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		e.err = nil
+		e.notice = fmt.Sprintf("wrote %d logs to %s", len(records), path)
+		return false, nil
+	}
+	var c tea.Cmd
+	e.input, c = e.input.Update(keyMsg)
+	return false, c
+}
+
+func (e *ExportModal) View(width, height int) string {
+	modalWidth := min(width-8, 90)
+	if modalWidth < 40 {
+		modalWidth = 40
+	}
+	innerWidth := modalWidth - 4
+	e.input.Width = innerWidth - lipgloss.Width(e.input.Prompt) - 1
+
+	labelStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	headerStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	selectedStyle := lipgloss.NewStyle().Background(lipgloss.Color("#1a3a5c")).Foreground(ColorWhite)
+
+	choice := func(label string, selected bool) string {
+		if selected {
+			return selectedStyle.Render(" " + label + " ")
+		}
+		return labelStyle.Render(" " + label + " ")
+	}
+
+	var formats []string
+	for i, f := range logExportFormats {
+		formats = append(formats, choice(f, i == e.format))
+	}
+	sets := choice(fmt.Sprintf("filtered list (%d)", len(e.dashboard.logEntries)), !e.pinned) +
+		choice(fmt.Sprintf("pinned (%d)", len(e.dashboard.pinned)), e.pinned)
+
+	sections := []string{
+		headerStyle.Render("Export logs"),
+		labelStyle.Render(fmt.Sprintf("%-8s", "Logs")) + sets,
+		labelStyle.Render(fmt.Sprintf("%-8s", "Format")) + strings.Join(formats, ""),
+		renderThinSeparator(innerWidth),
+		e.input.View(),
+		renderThinSeparator(innerWidth),
+	}
+	switch {
+	case e.err != nil:
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorRed).Render("error: "+e.err.Error()))
+	case e.notice != "":
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorGreen).Render(fitWidth(e.notice, innerWidth)))
+	}
+	sections = append(sections, labelStyle.Render("Enter: Export  Tab: Format  Shift+Tab: Filtered/pinned  Esc: Close"))
+
+	return lipgloss.NewStyle().
+		Width(modalWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Padding(1, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
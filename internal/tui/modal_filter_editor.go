@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// filterPatternLimit bounds the length of a filter pattern in either editor.
+const filterPatternLimit = 2000
+
+// FilterEditorModal is a multi-line editor for the log filter regex. It lints
+// the pattern as it is typed and previews capture groups on a sample log.
+type FilterEditorModal struct {
+	dashboard *DashboardModel
+	editor    textarea.Model
+	regex     *regexp.Regexp
+	err       error
+	sampleIdx int // index into dashboard.logEntries
+}
+
+// NewFilterEditorModal opens the editor seeded with the current filter.
+func NewFilterEditorModal(m *DashboardModel) *FilterEditorModal {
+	ta := textarea.New()
+	ta.Placeholder = "Filter regex (newlines and leading indentation are ignored)..."
+	ta.CharLimit = filterPatternLimit
+	ta.ShowLineNumbers = true
+	ta.SetValue(m.filterInput.Value())
+	ta.Focus()
+
+	sampleIdx := len(m.logEntries) - 1
+	if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+		sampleIdx = m.selectedLogIndex
+	}
+
+	e := &FilterEditorModal{
+		dashboard: m,
+		editor:    ta,
+		sampleIdx: sampleIdx,
+	}
+	e.lint()
+	return e
+}
+
+func (e *FilterEditorModal) ID() string { return "filter-editor" }
+
+func (e *FilterEditorModal) Update(msg tea.Msg) (pop bool, cmd tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("esc", "escape"))):
+		return true, nil
+
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("ctrl+s"))):
+		if e.err != nil {
+			return false, nil // keep editing until the pattern compiles
+		}
+		e.apply()
+		return true, nil
+
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("ctrl+n"))):
+		if e.sampleIdx < len(e.dashboard.logEntries)-1 {
+			e.sampleIdx++
+		}
+		return false, nil
+
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("ctrl+p"))):
+		if e.sampleIdx > 0 {
+			e.sampleIdx--
+		}
+		return false, nil
+	}
+
+	e.editor, cmd = e.editor.Update(keyMsg)
+	e.lint()
+	return false, cmd
+}
+
+// pattern returns the editor contents as a single-line regex.
+func (e *FilterEditorModal) pattern() string {
+	return joinFilterLines(e.editor.Value())
+}
+
+func (e *FilterEditorModal) lint() {
+	e.regex, e.err = lintFilterPattern(e.pattern())
+}
+
+// apply copies the pattern into the inline filter and activates it.
+func (e *FilterEditorModal) apply() {
+	m := e.dashboard
+	m.filterInput.SetValue(e.pattern())
+	m.filterRegex = e.regex
+	m.filterErr = nil
+	m.filterActive = false
+	m.filterInput.Blur()
+	m.activeSection = SectionLogs
+	m.recordQuery(HistoryFilter, m.filterInput.Value())
+}
+
+func (e *FilterEditorModal) sample() string {
+	entries := e.dashboard.logEntries
+	if e.sampleIdx < 0 || e.sampleIdx >= len(entries) {
+		return ""
+	}
+	return entries[e.sampleIdx].Message
+}
+
+func (e *FilterEditorModal) View(width, height int) string {
+	modalWidth := min(width-8, 100)
+	if modalWidth < 40 {
+		modalWidth = 40
+	}
+	innerWidth := modalWidth - 4
+
+	editorHeight := min(8, max(3, height-20))
+	e.editor.SetWidth(innerWidth)
+	e.editor.SetHeight(editorHeight)
+
+	header := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render("Edit Filter")
+
+	var lintLine string
+	switch {
+	case e.err != nil:
+		lintLine = lipgloss.NewStyle().Foreground(ColorRed).Render("⚠ " + e.err.Error())
+	case e.regex == nil:
+		lintLine = lipgloss.NewStyle().Foreground(ColorGray).Render("empty pattern clears the filter")
+	default:
+		lintLine = lipgloss.NewStyle().Foreground(ColorGreen).
+			Render(fmt.Sprintf("✓ valid regex, %d capture group(s)", e.regex.NumSubexp()))
+	}
+
+	sections := []string{header, e.editor.View(), lintLine, renderThinSeparator(innerWidth)}
+
+	sample := e.sample()
+	sampleTitle := lipgloss.NewStyle().Foreground(ColorGray).
+		Render(fmt.Sprintf("Sample %d/%d", e.sampleIdx+1, len(e.dashboard.logEntries)))
+	sections = append(sections, sampleTitle)
+	if sample == "" {
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorGray).Italic(true).Render("no log entries loaded"))
+	} else {
+		preview := lipgloss.NewStyle().Width(innerWidth).Render(highlightCaptureGroups(e.regex, truncatePreview(sample, innerWidth*3)))
+		sections = append(sections, preview)
+		if e.regex != nil {
+			sections = append(sections, captureGroupSummary(e.regex, sample)...)
+		}
+	}
+
+	status := lipgloss.NewStyle().Foreground(ColorGray).
+		Render("Ctrl+S: Apply | Ctrl+N/P: Next/Prev sample | Esc: Cancel")
+	sections = append(sections, status)
+
+	return lipgloss.NewStyle().
+		Width(modalWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
+
+// truncatePreview caps very long sample messages so the modal stays compact.
+func truncatePreview(s string, limit int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if limit <= 3 || len([]rune(s)) <= limit {
+		return s
+	}
+	return string([]rune(s)[:limit-3]) + "..."
+}
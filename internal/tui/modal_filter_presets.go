@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FilterPresetsModal lists the saved filter presets, applies one, or saves
+// the filters in effect as a new one.
+type FilterPresetsModal struct {
+	dashboard *DashboardModel
+	input     textinput.Model
+	naming    bool
+	presets   []FilterPreset
+	cursor    int
+	err       error
+}
+
+// NewFilterPresetsModal creates a presets modal and loads the saved presets.
+func NewFilterPresetsModal(m *DashboardModel) *FilterPresetsModal {
+	ti := textinput.New()
+	ti.Prompt = "Save as: "
+	ti.Placeholder = "preset name..."
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(ColorBlue)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(ColorWhite)
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(ColorGray)
+	ti.CharLimit = 100
+
+	f := &FilterPresetsModal{dashboard: m, input: ti}
+	if m.filterPresetsPath != "" {
+		f.presets, f.err = LoadFilterPresets(m.filterPresetsPath)
+	}
+	return f
+}
+
+func (f *FilterPresetsModal) ID() string { return "filter-presets" }
+
+func (f *FilterPresetsModal) Update(msg tea.Msg) (pop bool, cmd tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+	if f.naming {
+		return f.updateInput(keyMsg)
+	}
+
+	m := f.dashboard
+	switch keyMsg.String() {
+	case "esc", "escape", "F":
+		return true, nil
+	case "up", "k":
+		if f.cursor > 0 {
+			f.cursor--
+		}
+	case "down", "j":
+		if f.cursor < len(f.presets)-1 {
+			f.cursor++
+		}
+	case "s":
+		if m.filterPresetsPath == "" {
+			f.err = fmt.Errorf("no config directory to save presets in")
+			return false, nil
+		}
+		f.naming = true
+		f.err = nil
+		f.input.SetValue("")
+		return false, f.input.Focus()
+	case "d":
+		if f.cursor >= len(f.presets) {
+			return false, nil
+		}
+		presets := append(append([]FilterPreset(nil), f.presets[:f.cursor]...), f.presets[f.cursor+1:]...)
+		if err := SaveFilterPresets(m.filterPresetsPath, presets); err != nil {
+			f.err = err
+			return false, nil
+		}
+		f.presets = presets
+		f.cursor = max(0, min(f.cursor, len(presets)-1))
+	case "enter":
+		if f.cursor >= len(f.presets) {
+			return false, nil
+		}
+		if err := m.applyFilterPreset(f.presets[f.cursor]); err != nil {
+			f.err = err
+			return false, nil
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (f *FilterPresetsModal) updateInput(msg tea.KeyMsg) (bool, tea.Cmd) {
+	m := f.dashboard
+	switch msg.String() {
+	case "esc", "escape":
+		f.naming = false
+		f.input.Blur()
+		return false, nil
+	case "enter":
+		name := strings.TrimSpace(f.input.Value())
+		if name == "" {
+			return false, nil
+		}
+		presets := putFilterPreset(f.presets, m.captureFilterPreset(name))
+		if err := SaveFilterPresets(m.filterPresetsPath, presets); err != nil {
+			f.err = err
+			return false, nil
+		}
+		f.presets = presets
+		for i, p := range presets {
+			if p.Name == name {
+				f.cursor = i
+			}
+		}
+		f.naming = false
+		f.input.Blur()
+		return false, nil
+	}
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	return false, cmd
+}
+
+func (f *FilterPresetsModal) View(width, height int) string {
+	modalWidth := min(width-8, 100)
+	if modalWidth < 40 {
+		modalWidth = 40
+	}
+	innerWidth := modalWidth - 4
+	f.input.Width = innerWidth - lipgloss.Width(f.input.Prompt) - 1
+
+	labelStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	headerStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	sections := []string{
+		headerStyle.Render(fmt.Sprintf("Filter presets (%d)", len(f.presets))),
+		labelStyle.Render(fitWidth("Current: "+filterPresetSummary(f.dashboard.captureFilterPreset("")), innerWidth)),
+		renderThinSeparator(innerWidth),
+	}
+
+	nameWidth := 20
+	visible := max(3, height-14)
+	start := 0
+	if f.cursor >= visible {
+		start = f.cursor - visible + 1
+	}
+	for i := start; i < min(len(f.presets), start+visible); i++ {
+		p := f.presets[i]
+		name := lipgloss.NewStyle().Foreground(ColorWhite).Render(fmt.Sprintf("%-*s", nameWidth, fitWidth(p.Name, nameWidth)))
+		line := name + " " + labelStyle.Render(fitWidth(filterPresetSummary(p), max(1, innerWidth-nameWidth-3)))
+		if i == f.cursor {
+			line = lipgloss.NewStyle().Background(lipgloss.Color("#1a3a5c")).Render("▸ " + line)
+		} else {
+			line = "  " + line
+		}
+		sections = append(sections, line)
+	}
+	if len(f.presets) == 0 {
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorGray).Italic(true).Render("no saved presets yet — press s to save the filters in effect"))
+	}
+
+	sections = append(sections, renderThinSeparator(innerWidth))
+	switch {
+	case f.naming:
+		sections = append(sections, f.input.View())
+	case f.err != nil:
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorRed).Render("error: "+f.err.Error()))
+	}
+	sections = append(sections, labelStyle.Render("Enter: Apply  s: Save current  d: Delete  up/down: Select  Esc: Close"))
+
+	return lipgloss.NewStyle().
+		Width(modalWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Padding(1, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
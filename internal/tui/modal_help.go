@@ -1,6 +1,9 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -72,11 +75,24 @@ NAVIGATION:
 
 ACTIONS:
   /              - Activate filter (regex supported)
-  s              - Search and highlight text in logs
+  s              - Search and highlight text in logs; Enter adds the
+                   term, so several are highlighted, each in its color
+  S              - Highlight terms: legend, toggle (Space/1-9), remove (d)
   [ / ]          - Switch view (deck sets)
   G              - Search and jump to log entries
+  m              - Pin/unpin the selected log (Logs section, log viewer)
+  W              - Workspace: pins, notes, save/load snapshots
+  F              - Filter presets: save the regex, severities, app, and
+                   facets in effect under a name, or apply a saved one
+  E              - Export the filtered log list or the pinned logs to a
+                   file as ndjson, csv, or raw lines
+  y / Y          - Copy the selected log's message / JSON (Logs, log
+                   viewer, details), or the active deck as text; uses
+                   OSC 52 and the system clipboard tool when present
   Ctrl+f         - Open severity filter modal
   f              - Open fullscreen log viewer modal
+  t              - Live tail: keep Logs live and follow new entries;
+                   scroll up to read, End to follow again
   Space          - Pause/unpause UI updates (manual)
   c              - Toggle Host/Service columns in log view
   T              - Toggle timestamp mode (Log Time / Receive Time)
@@ -89,8 +105,30 @@ ACTIONS:
 LOG VIEWER NAVIGATION:
   Home           - Jump to top of log buffer (stops auto-scroll)
   End            - Jump to latest logs (resumes auto-scroll)
-  PgUp/PgDn      - Navigate by pages (10 entries at a time)
+  PgUp/PgDn      - Navigate by pages (10 entries at a time); moving past
+                   the top fetches older logs from the store, back to
+                   the start of history; past 20,000 lines the newest
+                   are dropped until End
   up/down or k/j - Navigate individual entries with smart auto-scroll
+  t              - Clear the heatmap time window (show all time)
+
+DECK LAYOUT (Decks section; saved per view):
+  Shift+arrows   - Move the focused deck within the grid
+  + / -          - Make the focused deck's row taller / shorter
+  > / <          - Make the focused deck's column wider / narrower
+  Mouse Drag     - Drag a deck border to resize the decks beside it
+  0              - Reset the view's layout
+
+LOG DETAILS:
+  up/down or k/j - Move through the raw line, body, and attribute trees
+  Enter/Space    - Fold or unfold the object or array under the cursor
+  left/right     - Fold / unfold (left on a leaf jumps to its parent)
+  - / +          - Fold / unfold everything
+  /              - Search keys and values; unfolds what matches
+  n / N          - Next / previous match
+  t              - Trace: filter the logs to the log's trace.id and open
+                   a timeline of its logs (and spans) across services;
+                   Enter shows a log's details there
 
 SECTIONS:
   Views (left)   - Sidebar view navigation (Base/Patterns/Attributes)
@@ -98,19 +136,72 @@ SECTIONS:
   Words          - Most frequent words in logs
   Attributes     - Log attributes by unique value count
   Log Patterns   - Common log message patterns (Drain3)
-  Counts         - Log counts over time
+  Patterns view  - Every pattern's count, 30m trend, and severity split;
+                   Enter shows the logs matching the selected pattern
+  Attrs view     - Attribute keys beside the selected key's values; Enter
+                   lists the values, where Enter shows a value's logs and
+                   f adds a key=value facet
+  Counts         - Log counts over time; Enter opens the severity
+                   heatmap, where Enter on a cell shows that minute's logs
+  Severity       - 24h timeline stacked by severity; Enter opens the full
+                   timeline, where ←→ picks a bar, ↑↓ a severity, and
+                   Enter shows those logs
+  Volume         - Log volume histogram stacked by severity; Enter
+                   cycles the bucket width (1m/5m/15m/1h)
   Logs           - Navigate and inspect individual log entries
-                 - Live updates auto-pause while Logs is focused
+                 - Live updates auto-pause while Logs is focused,
+                   except in live tail mode (t)
+  Metrics page   - Logs/s and bytes/s over 30m, busiest services' rates
+                   (Enter searches for a service), metric sparklines,
+                   and the busiest hosts and services colored by error
+                   rate (Enter facets the logs on one, again to lift)
+  Alerts page    - Firing, pending, and recently resolved alert rules;
+                   Enter opens them, x silences 1h (again to lift), a acks
+  Healthchecks   - Server components (store, insert buffer, journal,
+                   sources, backup, retention) and their recent errors
 
 FILTER & SEARCH:
   Filter (/): Type regex patterns to filter logs (searches message & attributes)
-  Search (s): Type text to highlight in displayed logs
+              Invalid patterns are flagged inline; Ctrl+E opens a multi-line
+              editor with capture-group preview on a sample log
+              Typing key=prefix offers that attribute's values; Tab completes
+  Facets: Exact key=value matches (service, host, or any attribute)
+          applied by the server next to the regex; Esc clears them
+  Search (s): Type text to highlight in displayed logs; each term added
+              with Enter keeps its own color, up to 6 at once (S toggles)
   Severity (Ctrl+f): Filter by log severity levels
   Examples: "error", "k8s.*pod", "service.name", "host.name.*prod"
 
-`
+WORKSPACES (W):
+  Save the app, page, filter, facets, search, severities, pinned logs,
+  notes, and query history as a named snapshot. Hand the file to a teammate; they load
+  it from the same modal or with tiny-telemetry-tui -workspace <file>.
+  s: Save  n: Add note  d: Unpin/delete note  Enter: Open/load  ESC: Close
+
+` + m.keymapHelp()
 
 	return lipgloss.NewStyle().
 		Width(65).
 		Render(helpContent)
 }
+
+// keymapHelp lists the key bindings the keymap changed and the keys bound
+// to more than one action; empty for the default keymap.
+func (m *DashboardModel) keymapHelp() string {
+	var b strings.Builder
+	if custom := m.keys.customizedKeys(); len(custom) > 0 {
+		b.WriteString("KEYMAP (overrides the keys above):\n")
+		for _, c := range custom {
+			fmt.Fprintf(&b, "  %-16s - %s\n", c[0], c[1])
+		}
+		b.WriteString("\n")
+	}
+	if conflicts := m.keys.Conflicts(); len(conflicts) > 0 {
+		b.WriteString("KEYMAP CONFLICTS (only the first action handled gets the key):\n")
+		for _, c := range conflicts {
+			fmt.Fprintf(&b, "  %-16s - %s\n", c.Key, strings.Join(c.Actions, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
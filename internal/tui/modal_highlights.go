@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HighlightsModal is the legend of the highlight terms, where each term is
+// turned on or off, or removed.
+type HighlightsModal struct {
+	dashboard *DashboardModel
+	cursor    int
+}
+
+// NewHighlightsModal creates a highlights modal.
+func NewHighlightsModal(m *DashboardModel) *HighlightsModal {
+	return &HighlightsModal{dashboard: m}
+}
+
+func (h *HighlightsModal) ID() string { return "highlights" }
+
+func (h *HighlightsModal) Update(msg tea.Msg) (pop bool, cmd tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+
+	m := h.dashboard
+	switch k := keyMsg.String(); k {
+	case "esc", "escape", "S":
+		return true, nil
+	case "up", "k":
+		if h.cursor > 0 {
+			h.cursor--
+		}
+	case "down", "j":
+		if h.cursor < len(m.highlightTerms)-1 {
+			h.cursor++
+		}
+	case "enter", " ":
+		if h.cursor < len(m.highlightTerms) {
+			m.highlightTerms[h.cursor].Off = !m.highlightTerms[h.cursor].Off
+		}
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if i := int(k[0] - '1'); i < len(m.highlightTerms) {
+			h.cursor = i
+			m.highlightTerms[i].Off = !m.highlightTerms[i].Off
+		}
+	case "d", "delete":
+		if h.cursor < len(m.highlightTerms) {
+			m.highlightTerms = append(m.highlightTerms[:h.cursor:h.cursor], m.highlightTerms[h.cursor+1:]...)
+			h.cursor = max(0, min(h.cursor, len(m.highlightTerms)-1))
+		}
+	}
+	return false, nil
+}
+
+func (h *HighlightsModal) View(width, height int) string {
+	m := h.dashboard
+	modalWidth := min(width-8, 70)
+	if modalWidth < 40 {
+		modalWidth = 40
+	}
+	innerWidth := modalWidth - 4
+
+	labelStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	headerStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	sections := []string{
+		headerStyle.Render(fmt.Sprintf("Highlight terms (%d/%d)", len(m.highlightTerms), maxHighlightTerms)),
+		renderThinSeparator(innerWidth),
+	}
+
+	for i, t := range m.highlightTerms {
+		swatch := highlightStyle(t.Color).Render(fmt.Sprintf(" %d ", i+1))
+		state := lipgloss.NewStyle().Foreground(ColorGreen).Render("on ")
+		text := lipgloss.NewStyle().Foreground(ColorWhite).Render(fitWidth(t.Text, max(1, innerWidth-12)))
+		if t.Off {
+			state = labelStyle.Render("off")
+			text = labelStyle.Strikethrough(true).Render(fitWidth(t.Text, max(1, innerWidth-12)))
+		}
+		line := swatch + " " + state + " " + text
+		if i == h.cursor {
+			line = lipgloss.NewStyle().Background(lipgloss.Color("#1a3a5c")).Render("▸ " + line)
+		} else {
+			line = "  " + line
+		}
+		sections = append(sections, line)
+	}
+	if len(m.highlightTerms) == 0 {
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorGray).Italic(true).Render("no highlight terms yet — press s, type a term, and Enter"))
+	}
+
+	sections = append(sections,
+		renderThinSeparator(innerWidth),
+		labelStyle.Render("Space/1-9: Toggle  d: Remove  up/down: Select  Esc: Close"),
+	)
+
+	return lipgloss.NewStyle().
+		Width(modalWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Padding(1, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
@@ -1,16 +1,17 @@
 package tui
 
 import (
+	"slices"
 	"strings"
 
-	"github.com/tinytelemetry/tiny-telemetry/internal/model"
-
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
-// renderSplitModalView renders log details modal with viewport scrolling.
-func (m *DashboardModel) renderSplitModalView(vp *viewport.Model, entry *model.LogRecord, width, height int) string {
+// renderSplitModalView renders log details modal with viewport scrolling:
+// the entry's fields, then its JSON trees with the cursor line kept in view.
+func (m *DashboardModel) renderSplitModalView(vp *viewport.Model, d *DetailModal, width, height int) string {
 	// Calculate dimensions
 	modalWidth := width - 8   // 4 chars margin on each side
 	modalHeight := height - 6 // 3 lines margin top and bottom
@@ -24,14 +25,46 @@ func (m *DashboardModel) renderSplitModalView(vp *viewport.Model, entry *model.L
 	vp.Height = contentHeight
 
 	// Update content with proper text wrapping
-	if entry != nil {
+	if entry := d.logEntry; entry != nil {
 		contentAreaWidth := contentWidth - 2
 		if contentAreaWidth < 10 {
 			contentAreaWidth = 10
 		}
 		infoContent := m.formatLogDetails(*entry, contentAreaWidth)
-		wrappedInfoContent := m.wrapTextToWidth(infoContent, contentAreaWidth)
-		vp.SetContent(wrappedInfoContent)
+		lines := strings.Split(strings.TrimRight(m.wrapTextToWidth(infoContent, contentAreaWidth), "\n"), "\n")
+
+		headerStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+		cursorStyle := lipgloss.NewStyle().Background(lipgloss.Color("#1a3a5c")).Foreground(ColorWhite)
+		cursorRow, i := -1, 0
+		for _, s := range d.trees {
+			lines = append(lines, "", headerStyle.Render(s.title))
+			for _, l := range jsonLines(s.root, 0, false, nil) {
+				text := jsonLineText(l)
+				switch {
+				case i == d.cursor:
+					cursorRow = len(lines)
+					lines = append(lines, cursorStyle.Render(fitWidth(text, contentAreaWidth)))
+				case d.lineMatches(l):
+					lines = append(lines, m.highlightText(fitWidth(text, contentAreaWidth), d.term))
+				default:
+					lines = append(lines, ansi.Truncate(renderJSONLine(l), contentAreaWidth, "..."))
+				}
+				i++
+			}
+		}
+		vp.SetContent(strings.Join(lines, "\n"))
+
+		if d.follow && cursorRow >= 0 {
+			d.follow = false
+			switch {
+			case d.cursor == 0:
+				vp.SetYOffset(0)
+			case cursorRow < vp.YOffset:
+				vp.SetYOffset(cursorRow)
+			case cursorRow >= vp.YOffset+vp.Height:
+				vp.SetYOffset(cursorRow - vp.Height + 1)
+			}
+		}
 	}
 
 	// Create content pane
@@ -51,10 +84,20 @@ func (m *DashboardModel) renderSplitModalView(vp *viewport.Model, entry *model.L
 
 	// Status bar
 	statusItems := []string{"up/down/Wheel: Scroll", "PgUp/PgDn: Page", "ESC: Close"}
+	if len(d.trees) > 0 {
+		statusItems = []string{"up/down: Move", "Enter: Fold", "-/+: Fold all", "/: Search", "n/N: Match", "y/Y: Copy", "ESC: Close"}
+		if d.logEntry.Attributes[traceIDAttr] != "" {
+			statusItems = slices.Insert(statusItems, len(statusItems)-1, "t: Trace")
+		}
+	}
 
 	statusBar := lipgloss.NewStyle().
 		Foreground(ColorGray).
 		Render(strings.Join(statusItems, " | "))
+	if d.searching {
+		d.search.Width = contentWidth - lipgloss.Width(d.search.Prompt) - 1
+		statusBar = d.search.View()
+	}
 
 	// Combine all parts
 	modal := lipgloss.JoinVertical(lipgloss.Left, header, contentPane, statusBar)
@@ -87,10 +87,29 @@ func (m *DashboardModel) renderLogViewerModalView(width, height int) string {
 		}
 	}
 
+	if !m.timeWindow.IsZero() {
+		statusParts = append(statusParts, "⏱ "+m.timeWindowLabel())
+	}
+
+	if len(m.pinned) > 0 {
+		pinPart := fmt.Sprintf("📌 %d", len(m.pinned))
+		if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) && m.isPinned(m.logEntries[m.selectedLogIndex]) {
+			pinPart += " (selected)"
+		}
+		statusParts = append(statusParts, pinPart)
+	}
+
+	if s := m.scrollbackStatus(); s != "" {
+		statusParts = append(statusParts, "⇡ "+s)
+	}
+
 	statusLeft = strings.Join(statusParts, " | ")
 
 	// Create concise help text that fits
-	helpText := "ESC:Close ↑↓:Nav Enter:Details /:Filter s:Search c:Columns"
+	helpText := "ESC:Close ↑↓:Nav Enter:Details /:Filter s:Search c:Columns m:Pin"
+	if !m.timeWindow.IsZero() {
+		helpText += " t:All time"
+	}
 
 	// Calculate available space for each side
 	leftWidth := lipgloss.Width(statusLeft)
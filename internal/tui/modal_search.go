@@ -77,6 +77,7 @@ func (s *SearchModal) Update(msg tea.Msg) (pop bool, cmd tea.Cmd) {
 
 		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
 			if s.cursor >= 0 && s.cursor < len(s.results) {
+				s.dashboard.recordQuery(HistorySearch, s.lastQuery)
 				s.jumpToResult(s.results[s.cursor])
 				return true, nil
 			}
@@ -21,7 +21,9 @@ func renderSeverityModalView(vp *viewport.Model, sm *SeverityModal, width, heigh
 	vp.Width = contentWidth
 	vp.Height = contentHeight
 
-	content := renderSeverityModalContent(sm, contentWidth, contentHeight)
+	content, layout := renderSeverityModalContent(sm, contentWidth, contentHeight)
+	sm.layout = layout
+	sm.cursorBarsAgo = min(sm.cursorBarsAgo, max(0, layout.bars-1))
 	vp.SetContent(content)
 
 	tabBar := renderTimeRangeTabs(sm.rangeLabels, sm.activeRange, contentWidth)
@@ -41,7 +43,7 @@ func renderSeverityModalView(vp *viewport.Model, sm *SeverityModal, width, heigh
 
 	statusBar := lipgloss.NewStyle().
 		Foreground(ColorGray).
-		Render("Tab/←→: Switch range | 1/2/3: Jump to range | ↑↓/Wheel: Scroll | ESC: Close")
+		Render("←→: Bar | ↑↓: Severity | Enter: Show logs | Tab: Switch range | 1/2/3: Jump to range | ESC: Close")
 
 	modal := lipgloss.JoinVertical(lipgloss.Left, header, tabBar, contentPane, statusBar)
 
@@ -73,9 +75,32 @@ func renderTimeRangeTabs(labels []string, active int, width int) string {
 	return lipgloss.NewStyle().Width(width).Render(tabLine)
 }
 
-func renderSeverityModalContent(sm *SeverityModal, width, height int) string {
+// timelineLayout is how a severity timeline was bucketed into bars.
+type timelineLayout struct {
+	start  time.Time // start of the first bar
+	end    time.Time // start of the current minute
+	bucket time.Duration
+	bars   int
+}
+
+// barWindow returns the time span of the bar barsAgo back from the newest.
+// The newest bar runs through the end of the current minute.
+func (l timelineLayout) barWindow(barsAgo int) (from, to time.Time, ok bool) {
+	if l.bars == 0 || barsAgo < 0 || barsAgo >= l.bars {
+		return time.Time{}, time.Time{}, false
+	}
+	idx := l.bars - 1 - barsAgo
+	from = l.start.Add(time.Duration(idx) * l.bucket)
+	to = from.Add(l.bucket)
+	if barsAgo == 0 {
+		to = l.end.Add(time.Minute)
+	}
+	return from, to, true
+}
+
+func renderSeverityModalContent(sm *SeverityModal, width, height int) (string, timelineLayout) {
 	if len(sm.data) == 0 {
-		return helpStyle.Render("No data available")
+		return helpStyle.Render("No data available"), timelineLayout{}
 	}
 
 	// Time span based on active range
@@ -89,10 +114,12 @@ func renderSeverityModalContent(sm *SeverityModal, width, height int) string {
 		totalSpan = 30 * 24 * time.Hour
 	}
 
-	return renderFullChart(sm.data, width, height, totalSpan)
+	return renderFullChart(sm.data, width, height, totalSpan, sm.cursorBarsAgo, sm.cursorSeverityName())
 }
 
-func renderFullChart(data []model.MinuteCounts, width, height int, totalSpan time.Duration) string {
+// renderFullChart draws the timeline with a marker under the bar barsAgo
+// back from the newest and a line summarizing that bar's severity count.
+func renderFullChart(data []model.MinuteCounts, width, height int, totalSpan time.Duration, barsAgo int, severity string) (string, timelineLayout) {
 	barWidth := 2
 	showLegend := true
 	legendWidth := 18
@@ -136,6 +163,8 @@ func renderFullChart(data []model.MinuteCounts, width, height int, totalSpan tim
 		bucketDuration = time.Minute
 	}
 	numBars := maxBars
+	layout := timelineLayout{start: timelineStart, end: now, bucket: bucketDuration, bars: numBars}
+	cursorIdx := numBars - 1 - min(max(0, barsAgo), numBars-1)
 
 	// Index source data by Unix timestamp (avoids timezone mismatch)
 	dataIndex := make(map[int64]*model.MinuteCounts, len(data))
@@ -200,10 +229,15 @@ func renderFullChart(data []model.MinuteCounts, width, height int, totalSpan tim
 		rows[row] = yLabel + "│" + barArea.String()
 	}
 
-	// X-axis line
+	// X-axis line, with the cursor marked under its bar
+	cursorStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
 	xAxisLine := strings.Repeat(" ", yAxisWidth) + "└"
 	for i := 0; i < numBars; i++ {
-		xAxisLine += strings.Repeat("─", barWidth)
+		if i == cursorIdx {
+			xAxisLine += cursorStyle.Render(strings.Repeat("▲", barWidth))
+		} else {
+			xAxisLine += strings.Repeat("─", barWidth)
+		}
 		if i < numBars-1 {
 			xAxisLine += "┴"
 		}
@@ -256,6 +290,11 @@ func renderFullChart(data []model.MinuteCounts, width, height int, totalSpan tim
 	last := now.Format("2006-01-02 15:04")
 	summaryStyle := lipgloss.NewStyle().Foreground(ColorGray)
 	outputLines = append(outputLines, summaryStyle.Render(fmt.Sprintf("Range: %s → %s", first, last)))
+	if from, to, ok := layout.barWindow(numBars - 1 - cursorIdx); ok {
+		count := countForSeverity(&buckets[cursorIdx].MinuteCounts, severity)
+		outputLines = append(outputLines, cursorStyle.Render(fmt.Sprintf("▲ %s %s → %s: %d logs (Enter shows them)",
+			severity, from.Format("2006-01-02 15:04"), to.Format("15:04"), count)))
+	}
 
-	return strings.Join(outputLines, "\n")
+	return strings.Join(outputLines, "\n"), layout
 }
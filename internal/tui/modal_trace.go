@@ -0,0 +1,301 @@
+package tui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// traceIDAttr is the attribute the ingest paths store a log's trace id
+	// in; the log view's trace drill-down facets on it.
+	traceIDAttr = "trace.id"
+	// traceLogLimit caps the logs the trace modal loads.
+	traceLogLimit = 1000
+	// traceGutterWidth is the width of the trace modal's timeline gutter.
+	traceGutterWidth = 16
+)
+
+// traceMsg carries the logs and spans of a trace.
+type traceMsg struct {
+	traceID string
+	logs    []model.LogRecord
+	spans   []model.Span
+	spanErr error // spans could not be loaded; the logs still show
+	err     error
+}
+
+// traceEntry is a row of the trace timeline: a log or a span.
+type traceEntry struct {
+	at   time.Time
+	end  time.Time // spans only
+	log  *model.LogRecord
+	span *model.Span
+}
+
+// drillDownToTrace narrows the log list to the records of traceID and
+// opens the trace timeline over them.
+func (m *DashboardModel) drillDownToTrace(traceID string) tea.Cmd {
+	m.addFacet(traceIDAttr, traceID)
+	t := NewTraceModal(m, traceID)
+	m.PushModal(t)
+	return t.load()
+}
+
+// TraceModal is a timeline of the logs (and spans, when the store keeps
+// them) sharing a trace id, ordered by time across services.
+type TraceModal struct {
+	dashboard *DashboardModel
+	traceID   string
+	entries   []traceEntry
+	loading   bool
+	spanErr   error
+	err       error
+	cursor    int
+}
+
+// NewTraceModal creates a trace modal for traceID; load fetches it.
+func NewTraceModal(m *DashboardModel, traceID string) *TraceModal {
+	return &TraceModal{dashboard: m, traceID: traceID, loading: true}
+}
+
+func (t *TraceModal) ID() string { return "trace" }
+
+// load fetches the trace from a store that correlates traces, or finds its
+// logs by the trace id attribute otherwise.
+func (t *TraceModal) load() tea.Cmd {
+	store, traceID := t.dashboard.store, t.traceID
+	if store == nil {
+		t.loading = false
+		return nil
+	}
+	return func() tea.Msg {
+		msg := traceMsg{traceID: traceID}
+		tq, ok := store.(model.TraceQuerier)
+		if !ok || !supports(store, "TraceLogs") {
+			msg.logs, msg.err = store.RecentLogsFiltered(traceLogLimit, model.QueryOpts{}, nil, map[string]string{traceIDAttr: traceID}, "")
+			return msg
+		}
+		if msg.logs, msg.err = tq.TraceLogs(traceID, traceLogLimit); msg.err != nil {
+			return msg
+		}
+		if supports(store, "TraceSpans") {
+			msg.spans, msg.spanErr = tq.TraceSpans(traceID)
+		}
+		return msg
+	}
+}
+
+// setTrace builds the timeline from the logs and spans of msg.
+func (t *TraceModal) setTrace(msg traceMsg) {
+	t.loading = false
+	t.err, t.spanErr = msg.err, msg.spanErr
+	t.entries = t.entries[:0]
+	for i := range msg.logs {
+		r := &msg.logs[i]
+		t.entries = append(t.entries, traceEntry{at: t.dashboard.getDisplayTimestamp(*r), log: r})
+	}
+	for i := range msg.spans {
+		s := &msg.spans[i]
+		t.entries = append(t.entries, traceEntry{at: s.StartTime, end: s.EndTime, span: s})
+	}
+	sort.SliceStable(t.entries, func(i, j int) bool { return t.entries[i].at.Before(t.entries[j].at) })
+	t.cursor = min(t.cursor, max(0, len(t.entries)-1))
+}
+
+// bounds returns when the trace starts and ends.
+func (t *TraceModal) bounds() (time.Time, time.Time) {
+	if len(t.entries) == 0 {
+		return time.Time{}, time.Time{}
+	}
+	start, end := t.entries[0].at, t.entries[0].at
+	for _, e := range t.entries {
+		end = maxTime(end, maxTime(e.at, e.end))
+	}
+	return start, end
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// services returns how many services the trace's entries come from.
+func (t *TraceModal) services() int {
+	seen := make(map[string]bool)
+	for _, e := range t.entries {
+		seen[e.service()] = true
+	}
+	return len(seen)
+}
+
+func (e traceEntry) service() string {
+	if e.span != nil {
+		return e.span.Service
+	}
+	return e.log.Service
+}
+
+func (t *TraceModal) Update(msg tea.Msg) (pop bool, cmd tea.Cmd) {
+	switch msg := msg.(type) {
+	case traceMsg:
+		if msg.traceID == t.traceID {
+			t.setTrace(msg)
+		}
+		return false, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "escape", "q":
+			return true, nil
+		case "up", "k":
+			t.cursor = max(0, t.cursor-1)
+		case "down", "j":
+			t.cursor = max(0, min(len(t.entries)-1, t.cursor+1))
+		case "pgup":
+			t.cursor = max(0, t.cursor-10)
+		case "pgdown":
+			t.cursor = max(0, min(len(t.entries)-1, t.cursor+10))
+		case "home":
+			t.cursor = 0
+		case "end":
+			t.cursor = max(0, len(t.entries)-1)
+		case "enter":
+			if t.cursor < len(t.entries) && t.entries[t.cursor].log != nil {
+				r := *t.entries[t.cursor].log
+				return false, actionMsg(ActionMsg{Action: ActionPushModal, Payload: NewDetailModal(t.dashboard, &r)})
+			}
+		case "y":
+			return false, copyToClipboard("trace id", t.traceID)
+		}
+	}
+	return false, nil
+}
+
+// traceServiceColor gives each service a stable color.
+func traceServiceColor(service string) lipgloss.Color {
+	h := fnv.New32a()
+	h.Write([]byte(service))
+	palette := highlightPalette()
+	return palette[h.Sum32()%uint32(len(palette))]
+}
+
+// traceGutter marks where from..to falls in the trace's span start..end:
+// a dot for a log, a bar for a span.
+func traceGutter(start, end, from, to time.Time, width int) string {
+	total := end.Sub(start)
+	pos := func(at time.Time) int {
+		if total <= 0 {
+			return 0
+		}
+		return min(width-1, int(int64(width-1)*int64(at.Sub(start))/int64(total)))
+	}
+	cells := []rune(strings.Repeat("·", width))
+	if to.IsZero() {
+		cells[pos(from)] = '●'
+	} else {
+		for i := pos(from); i <= pos(to); i++ {
+			cells[i] = '━'
+		}
+	}
+	return string(cells)
+}
+
+// renderTraceEntry renders a timeline row: its offset into the trace, the
+// gutter, its service, and the log line or span.
+func (t *TraceModal) renderTraceEntry(e traceEntry, start, end time.Time, width int) string {
+	labelStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	offset := labelStyle.Render(fmt.Sprintf("%9s", "+"+formatTraceOffset(e.at.Sub(start))))
+	gutter := lipgloss.NewStyle().Foreground(ColorBlue).Render(traceGutter(start, end, e.at, e.end, traceGutterWidth))
+	service := lipgloss.NewStyle().Foreground(traceServiceColor(e.service())).Render(fmt.Sprintf("%-14s", fitWidth(e.service(), 14)))
+
+	rest := max(1, width-9-traceGutterWidth-14-9)
+	var kind, text string
+	if e.span != nil {
+		kind = lipgloss.NewStyle().Foreground(ColorBlue).Render("SPAN ")
+		text = fmt.Sprintf("%s (%s)", e.span.Name, formatTraceOffset(e.end.Sub(e.at)))
+		if e.span.StatusCode == "error" {
+			text += " ✗ " + e.span.StatusMessage
+		}
+		text = lipgloss.NewStyle().Foreground(ColorWhite).Render(fitWidth(text, rest))
+	} else {
+		kind = lipgloss.NewStyle().Foreground(GetSeverityColor(e.log.Level)).Render(fmt.Sprintf("%-5s", e.log.Level))
+		text = fitWidth(e.log.Message, rest)
+	}
+	return strings.Join([]string{offset, gutter, service, kind, text}, " ")
+}
+
+// formatTraceOffset formats a duration inside a trace to the millisecond.
+func formatTraceOffset(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+func (t *TraceModal) View(width, height int) string {
+	modalWidth := min(width-8, 140)
+	if modalWidth < 60 {
+		modalWidth = 60
+	}
+	innerWidth := modalWidth - 4
+
+	labelStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	headerStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	start, end := t.bounds()
+	logs := 0
+	for _, e := range t.entries {
+		if e.log != nil {
+			logs++
+		}
+	}
+	sections := []string{
+		headerStyle.Render("Trace " + t.traceID),
+		labelStyle.Render(fmt.Sprintf("%d logs, %d spans across %d services over %s", logs, len(t.entries)-logs, t.services(), formatTraceOffset(end.Sub(start)))),
+		renderThinSeparator(innerWidth),
+	}
+
+	visible := max(3, height-14)
+	first := 0
+	if t.cursor >= visible {
+		first = t.cursor - visible + 1
+	}
+	for i := first; i < min(len(t.entries), first+visible); i++ {
+		line := t.renderTraceEntry(t.entries[i], start, end, innerWidth-2)
+		if i == t.cursor {
+			line = lipgloss.NewStyle().Background(lipgloss.Color("#1a3a5c")).Render("▸ " + line)
+		} else {
+			line = "  " + line
+		}
+		sections = append(sections, line)
+	}
+	switch {
+	case t.loading:
+		sections = append(sections, labelStyle.Render("loading trace…"))
+	case t.err != nil:
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorRed).Render("error: "+t.err.Error()))
+	case len(t.entries) == 0:
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorGray).Italic(true).Render("no logs carry this trace id"))
+	}
+
+	sections = append(sections, renderThinSeparator(innerWidth))
+	if t.spanErr != nil {
+		sections = append(sections, labelStyle.Render(fitWidth("spans unavailable: "+t.spanErr.Error(), innerWidth)))
+	}
+	sections = append(sections, labelStyle.Render("Enter: Log details  y: Copy trace id  up/down: Select  Esc: Close (Logs stay filtered to the trace)"))
+
+	return lipgloss.NewStyle().
+		Width(modalWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Padding(1, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/memstore"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTraceDrillDown_FromLogDetails(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := memstore.NewStore()
+	batch := []*model.LogRecord{
+		{Timestamp: base.Add(30 * time.Millisecond), Level: "ERROR", Service: "payments", Message: "card declined", Attributes: map[string]string{traceIDAttr: "abc"}},
+		{Timestamp: base, Level: "INFO", Service: "gateway", Message: "POST /checkout", Attributes: map[string]string{traceIDAttr: "abc"}},
+		{Timestamp: base.Add(10 * time.Millisecond), Level: "INFO", Service: "gateway", Message: "unrelated", Attributes: map[string]string{traceIDAttr: "def"}},
+		{Timestamp: base.Add(20 * time.Millisecond), Level: "INFO", Service: "cart", Message: "no trace"},
+	}
+	if err := store.InsertLogBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.width, m.height = 160, 40
+	m.reloadLogEntries()
+
+	// A log without a trace id offers no drill-down.
+	plain := NewDetailModal(m, batch[3])
+	if pop, cmd := plain.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}}); pop || cmd != nil {
+		t.Fatal("t drilled down from a log without a trace id")
+	}
+
+	m.PushModal(NewDetailModal(m, batch[0]))
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if cmd == nil {
+		t.Fatal("t in the log details did nothing")
+	}
+	_, load := m.Update(cmd())
+	trace, ok := m.TopModal().(*TraceModal)
+	if !ok || load == nil {
+		t.Fatalf("top modal = %T, want the trace modal loading", m.TopModal())
+	}
+	if m.facets[traceIDAttr] != "abc" || len(m.logEntries) != 2 {
+		t.Fatalf("facets %v, %d log entries; want the log list filtered to the trace", m.facets, len(m.logEntries))
+	}
+
+	// The timeline arrives even with the log details opened over it.
+	m.PushModal(NewDetailModal(m, batch[1]))
+	m.Update(load())
+	m.PopModal()
+	if len(trace.entries) != 2 || trace.entries[0].log.Service != "gateway" || trace.entries[1].log.Service != "payments" {
+		t.Fatalf("trace entries %+v, want gateway then payments", trace.entries)
+	}
+	view := trace.View(160, 40)
+	for _, want := range []string{"Trace abc", "2 logs, 0 spans across 2 services over 30ms", "+30ms", "card declined"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("trace view is missing %q:\n%s", want, view)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(cmd())
+	if d, ok := m.TopModal().(*DetailModal); !ok || d.logEntry.Message != "card declined" {
+		t.Fatalf("Enter opened %T, want the selected log's details", m.TopModal())
+	}
+}
+
+func TestTraceGutter(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(0, 0)
+	end := start.Add(100 * time.Millisecond)
+	if got := traceGutter(start, end, end, time.Time{}, 5); got != "····●" {
+		t.Errorf("log at the end: %q", got)
+	}
+	if got := traceGutter(start, end, start, start.Add(50*time.Millisecond), 5); got != "━━━··" {
+		t.Errorf("span over the first half: %q", got)
+	}
+}
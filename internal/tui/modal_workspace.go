@@ -0,0 +1,323 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type workspaceInputMode int
+
+const (
+	workspaceBrowse workspaceInputMode = iota
+	workspaceNaming
+	workspaceNoting
+)
+
+type workspaceItemKind int
+
+const (
+	workspaceItemPin workspaceItemKind = iota
+	workspaceItemNote
+	workspaceItemFile
+)
+
+type workspaceItem struct {
+	kind workspaceItemKind
+	idx  int
+}
+
+// WorkspaceModal shows the current investigation state and saves or loads
+// workspace snapshots.
+type WorkspaceModal struct {
+	dashboard *DashboardModel
+	input     textinput.Model
+	mode      workspaceInputMode
+	files     []WorkspaceFile
+	cursor    int
+	notice    string
+	err       error
+}
+
+// NewWorkspaceModal creates a workspace modal and lists saved snapshots.
+func NewWorkspaceModal(m *DashboardModel) *WorkspaceModal {
+	ti := textinput.New()
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(ColorBlue)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(ColorWhite)
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(ColorGray)
+	ti.CharLimit = 200
+
+	w := &WorkspaceModal{dashboard: m, input: ti}
+	w.refreshFiles()
+	return w
+}
+
+func (w *WorkspaceModal) ID() string { return "workspace" }
+
+func (w *WorkspaceModal) refreshFiles() {
+	if w.dashboard.workspaceDir == "" {
+		w.files = nil
+		return
+	}
+	files, err := ListWorkspaces(w.dashboard.workspaceDir)
+	if err != nil {
+		w.err = err
+	}
+	w.files = files
+}
+
+// items flattens pins, notes, and files into one cursor list.
+func (w *WorkspaceModal) items() []workspaceItem {
+	m := w.dashboard
+	items := make([]workspaceItem, 0, len(m.pinned)+len(m.annotations)+len(w.files))
+	for i := range m.pinned {
+		items = append(items, workspaceItem{kind: workspaceItemPin, idx: i})
+	}
+	for i := range m.annotations {
+		items = append(items, workspaceItem{kind: workspaceItemNote, idx: i})
+	}
+	for i := range w.files {
+		items = append(items, workspaceItem{kind: workspaceItemFile, idx: i})
+	}
+	return items
+}
+
+func (w *WorkspaceModal) startInput(mode workspaceInputMode, prompt, value, placeholder string) tea.Cmd {
+	w.mode = mode
+	w.input.Prompt = prompt
+	w.input.Placeholder = placeholder
+	w.input.SetValue(value)
+	w.input.CursorEnd()
+	w.err = nil
+	w.notice = ""
+	return w.input.Focus()
+}
+
+func (w *WorkspaceModal) Update(msg tea.Msg) (pop bool, cmd tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+	if w.mode != workspaceBrowse {
+		return w.updateInput(keyMsg)
+	}
+
+	m := w.dashboard
+	items := w.items()
+	switch keyMsg.String() {
+	case "esc", "escape", "W":
+		return true, nil
+	case "up", "k":
+		if w.cursor > 0 {
+			w.cursor--
+		}
+	case "down", "j":
+		if w.cursor < len(items)-1 {
+			w.cursor++
+		}
+	case "s":
+		if m.workspaceDir == "" {
+			w.err = fmt.Errorf("no workspace directory configured")
+			return false, nil
+		}
+		return false, w.startInput(workspaceNaming, "Save as: ", m.workspaceName, "incident name...")
+	case "n":
+		return false, w.startInput(workspaceNoting, "Note: ", "", "what did you find?")
+	case "d":
+		if w.cursor >= len(items) {
+			return false, nil
+		}
+		switch it := items[w.cursor]; it.kind {
+		case workspaceItemPin:
+			m.pinned = append(m.pinned[:it.idx], m.pinned[it.idx+1:]...)
+		case workspaceItemNote:
+			m.annotations = append(m.annotations[:it.idx], m.annotations[it.idx+1:]...)
+		}
+		if n := len(w.items()); w.cursor >= n {
+			w.cursor = max(0, n-1)
+		}
+	case "enter":
+		if w.cursor >= len(items) {
+			return false, nil
+		}
+		switch it := items[w.cursor]; it.kind {
+		case workspaceItemPin:
+			entry := m.pinned[it.idx].Record
+			m.PushModal(NewDetailModal(m, &entry))
+		case workspaceItemFile:
+			file := w.files[it.idx]
+			if err := m.ApplyWorkspaceFile(file.Path); err != nil {
+				w.err = err
+				return false, nil
+			}
+			w.cursor = 0
+			w.err = nil
+			w.notice = fmt.Sprintf("loaded %s", file.Name)
+		}
+	}
+	return false, nil
+}
+
+func (w *WorkspaceModal) updateInput(msg tea.KeyMsg) (bool, tea.Cmd) {
+	m := w.dashboard
+	switch msg.String() {
+	case "esc", "escape":
+		w.mode = workspaceBrowse
+		w.input.Blur()
+		return false, nil
+	case "enter":
+		value := strings.TrimSpace(w.input.Value())
+		switch w.mode {
+		case workspaceNaming:
+			if value == "" {
+				return false, nil
+			}
+			path, err := SaveWorkspace(m.workspaceDir, m.captureWorkspace(value))
+			if err != nil {
+				w.err = err
+				return false, nil
+			}
+			m.workspaceName = value
+			w.notice = fmt.Sprintf("saved %s", path)
+			w.refreshFiles()
+		case workspaceNoting:
+			m.addAnnotation(value)
+		}
+		w.mode = workspaceBrowse
+		w.input.Blur()
+		return false, nil
+	}
+	var cmd tea.Cmd
+	w.input, cmd = w.input.Update(msg)
+	return false, cmd
+}
+
+func (w *WorkspaceModal) View(width, height int) string {
+	m := w.dashboard
+	modalWidth := min(width-8, 90)
+	if modalWidth < 40 {
+		modalWidth = 40
+	}
+	innerWidth := modalWidth - 4
+	w.input.Width = innerWidth - lipgloss.Width(w.input.Prompt) - 1
+
+	labelStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	headerStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+
+	name := m.workspaceName
+	if name == "" {
+		name = "(unsaved)"
+	}
+	sections := []string{headerStyle.Render("Workspace: " + name)}
+
+	field := func(label, value string) string {
+		if value == "" {
+			value = "-"
+		}
+		return labelStyle.Render(fmt.Sprintf("%-10s", label)) + fitWidth(value, max(10, innerWidth-10))
+	}
+	var rangeText string
+	if rng := m.timeWindow; !rng.IsZero() {
+		rangeText = fmt.Sprintf("%s → %s", rng.From.Local().Format("2006-01-02 15:04:05"), rng.To.Local().Format("15:04:05"))
+	}
+	app := m.selectedApp
+	if app == "" {
+		app = "all apps"
+	}
+	var filterText string
+	if m.filterRegex != nil {
+		filterText = m.filterInput.Value()
+	}
+	sections = append(sections,
+		field("Range", rangeText),
+		field("App", app),
+		field("View", strings.TrimSpace(m.currentPageTitle()+" / "+m.currentViewTitle())),
+		field("Filter", filterText),
+		field("Search", m.highlightSummary()),
+		field("History", fmt.Sprintf("%d queries", len(m.queryHistory))),
+	)
+
+	items := w.items()
+	visible := max(3, height-22)
+	start := 0
+	if w.cursor >= visible {
+		start = w.cursor - visible + 1
+	}
+	lastKind := workspaceItemKind(-1)
+	for i, it := range items {
+		if i < start || i >= start+visible {
+			continue
+		}
+		if it.kind != lastKind {
+			sections = append(sections, renderThinSeparator(innerWidth), labelStyle.Render(w.kindTitle(it.kind)))
+			lastKind = it.kind
+		}
+		sections = append(sections, w.renderItem(it, innerWidth, i == w.cursor))
+	}
+	if len(items) == 0 {
+		sections = append(sections, renderThinSeparator(innerWidth),
+			lipgloss.NewStyle().Foreground(ColorGray).Italic(true).Render("no pins, notes, or saved workspaces yet — press m on a log to pin it"))
+	}
+
+	sections = append(sections, renderThinSeparator(innerWidth))
+	switch {
+	case w.mode != workspaceBrowse:
+		sections = append(sections, w.input.View())
+	case w.err != nil:
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorRed).Render("error: "+w.err.Error()))
+	case w.notice != "":
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorGreen).Render(fitWidth(w.notice, innerWidth)))
+	}
+	sections = append(sections, labelStyle.Render("s: Save  n: Note  d: Remove  Enter: Open/Load  Esc: Close"))
+
+	return lipgloss.NewStyle().
+		Width(modalWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Padding(1, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
+
+func (w *WorkspaceModal) kindTitle(kind workspaceItemKind) string {
+	m := w.dashboard
+	switch kind {
+	case workspaceItemPin:
+		return fmt.Sprintf("Pinned logs (%d)", len(m.pinned))
+	case workspaceItemNote:
+		return fmt.Sprintf("Notes (%d)", len(m.annotations))
+	default:
+		return fmt.Sprintf("Saved workspaces in %s", m.workspaceDir)
+	}
+}
+
+func (w *WorkspaceModal) renderItem(it workspaceItem, width int, selected bool) string {
+	m := w.dashboard
+	var line string
+	switch it.kind {
+	case workspaceItemPin:
+		rec := m.pinned[it.idx].Record
+		line = fmt.Sprintf("%s %-5s %s", m.getDisplayTimestamp(rec).Format("15:04:05"), rec.Level, strings.ReplaceAll(rec.Message, "\n", " "))
+	case workspaceItemNote:
+		note := m.annotations[it.idx]
+		prefix := note.Created.Format("15:04")
+		if !note.Anchor.IsZero() {
+			prefix = "@" + note.Anchor.Format("15:04:05")
+		}
+		if note.Author != "" {
+			prefix += " " + note.Author
+		}
+		line = prefix + ": " + note.Text
+	case workspaceItemFile:
+		f := w.files[it.idx]
+		line = fmt.Sprintf("%s  (%s)", f.Name, f.ModTime.Format("2006-01-02 15:04"))
+	}
+
+	line = fitWidth("  "+line, width)
+	if selected {
+		return lipgloss.NewStyle().Background(lipgloss.Color("#1a3a5c")).Foreground(ColorWhite).Render(line)
+	}
+	return line
+}
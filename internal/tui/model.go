@@ -1,7 +1,9 @@
 package tui
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
@@ -62,13 +64,23 @@ type FilterState struct {
 	filterInput  textinput.Model
 	filterActive bool
 	filterRegex  *regexp.Regexp
+	filterErr    error // lint error for the pattern being typed; filterRegex keeps the last valid one
+
+	facets map[string]string // key=value filters the store applies; see model.MatchesFacets
+
+	filterSuggestions []string // attribute values offered for a trailing key=prefix token
+	filterSuggestSeq  int      // bumped on every edit so stale lookups are dropped
 
 	searchInput  textinput.Model
 	searchActive bool
 	searchTerm   string // For 's' command - highlights just the term
 
+	highlightTerms []HighlightTerm // terms added with 's', each highlighted in its own color
+
 	severityFilter       map[string]bool // Which severity levels are enabled (true = show, false = hide)
 	severityFilterActive bool            // Whether severity filtering is active (any severity disabled)
+
+	timeWindow WorkspaceTimeRange // log-list time window set by heatmap drill-down; zero = unbounded
 }
 
 // SidebarState holds app sidebar state.
@@ -115,6 +127,7 @@ type ViewState struct {
 	Decks         []Deck
 	DeckSelIdx    []int
 	ActiveDeckIdx int
+	DefaultOrder  []string // deck IDs in the order the view's spec builds them
 }
 
 // DeckDeps provides dependencies for deck constructors, replacing *DashboardModel.
@@ -127,6 +140,10 @@ type DeckDeps struct {
 	PushSeverityModal tea.Cmd
 	FormatAttrModal   func(entry *AttributeEntry, maxWidth int) string
 	PushContentModal  func(content string) tea.Cmd
+	PushAlertsModal   func(state string, alerts []model.Alert) tea.Cmd
+	DrillDownPattern  func(filter string) tea.Cmd
+	PushValuesModal   func(key string, values []model.DimensionCount) tea.Cmd
+	ToggleFacet       func(key, value string) tea.Cmd
 }
 
 // PageSpec defines a top-level page and the views it contains.
@@ -145,12 +162,15 @@ type ViewSpec struct {
 
 // LogViewState holds log entries and scroll/selection state.
 type LogViewState struct {
-	logEntries               []model.LogRecord // Filtered view for display (refreshed from DuckDB)
-	selectedLogIndex         int               // For log section navigation
-	viewPaused               bool              // Pause view updates when navigating logs
-	logAutoScroll            bool              // Auto-scroll to latest logs in log viewer
-	instructionsScrollOffset int               // Scroll position for instructions/filter status screen
-	showColumns              bool              // Toggle Host and Service columns in log view
+	logEntries               []model.LogRecord       // Filtered view for display (refreshed from DuckDB)
+	selectedLogIndex         int                     // For log section navigation
+	viewPaused               bool                    // Pause view updates when navigating logs
+	logAutoScroll            bool                    // Auto-scroll to latest logs in log viewer
+	instructionsScrollOffset int                     // Scroll position for instructions/filter status screen
+	showColumns              bool                    // Toggle Host and Service columns in log view
+	logTemplates             map[string]*LogTemplate // Per-view display templates keyed by view ID
+	tailMode                 bool                    // Keep the log list live and following while focused
+	tailNewLines             int                     // Records that arrived below while scrolled up in tail mode
 }
 
 // DashboardModel represents the main TUI model.
@@ -163,6 +183,7 @@ type DashboardModel struct {
 	ModalStackState
 	NavigationState
 	LogViewState
+	WorkspaceState
 
 	// Window dimensions
 	width  int
@@ -199,6 +220,36 @@ type DashboardModel struct {
 
 	// Async tick query guard to avoid overlapping DB fetches.
 	tickInFlight bool
+	// Tail mode reload guard (see handleTailPoll).
+	tailPollInFlight bool
+
+	// Scrollback: older pages fetched in front of the log list when the
+	// user scrolls past its top (see loadOlderLogs).
+	scrollbackKey       string // logQueryKey of the pages held; "" when none
+	scrollbackLoading   bool
+	scrollbackExhausted bool // the oldest matching record is in the list
+	scrollbackTrimmed   bool // newest records dropped past scrollbackMaxLines
+
+	// Live tail of the log list, when the store pushes records (see
+	// syncLogTail); nil while the list is polled.
+	logTail           model.LogTail
+	logTailFilterOpen model.TailFilter  // filter logTail was opened with (or failed for)
+	logTailSeeded     bool              // the stored records were loaded after it opened
+	logTailFailed     bool              // opening it failed; poll until the filters change
+	logTailPending    []model.LogRecord // records pushed before the seed arrived
+
+	// Server events pushed to the status bar (see syncServerEvents); nil
+	// while not subscribed.
+	serverEvents         model.EventSubscription
+	serverEventsFailedAt time.Time // subscribing last failed; retried later
+	lastServerEvent      model.ServerEvent
+	lastServerEventAt    time.Time
+
+	// Notice shown in the status bar for noticeShown, such as how a copy
+	// to the clipboard went.
+	notice    string
+	noticeErr bool
+	noticeAt  time.Time
 
 	// Inline handlers for filter/search input (NOT modals — part of dashboard layout)
 	inlineHandlers []inlineHandlerEntry
@@ -214,6 +265,12 @@ type DashboardModel struct {
 	// Per-panel-type tick/pause/error tracking.
 	deckStates map[string]*DeckTypeState
 
+	// Per-view deck layouts customized with keys or border drags (see
+	// deck_layouts.go), and the border being dragged.
+	deckLayouts     map[string]DeckLayout
+	deckLayoutsPath string // where deck layouts are saved; "" disables saving
+	deckDrag        *deckDrag
+
 	// DuckDB read primitives used by the TUI.
 	store      model.LogQuerier
 	dataSource string // "Socket" or "DuckDB" — shown in status bar
@@ -268,7 +325,7 @@ func initializeDrain3BySeverity() map[string]*Drain3Manager {
 func NewDashboardModel(maxLogBuffer int, updateInterval time.Duration, reverseScrollWheel bool, useLogTime bool, store model.LogQuerier, dataSource string) *DashboardModel {
 	filterInput := textinput.New()
 	filterInput.Placeholder = "Filter logs by message or attributes (regex supported)..."
-	filterInput.CharLimit = 200
+	filterInput.CharLimit = filterPatternLimit
 
 	searchInput := textinput.New()
 	searchInput.Placeholder = "Search and highlight text..."
@@ -390,6 +447,10 @@ func (m *DashboardModel) SetPages(specs []PageSpec) {
 		PushSeverityModal: m.pushSeverityModalCmd(),
 		FormatAttrModal:   m.formatAttributeValuesModal,
 		PushContentModal:  m.pushContentModalCmd(),
+		PushAlertsModal:   m.pushAlertsModalCmd,
+		DrillDownPattern:  m.drillDownToPattern,
+		PushValuesModal:   m.pushAttributeValuesModalCmd,
+		ToggleFacet:       m.toggleFacet,
 	}
 
 	pages := make([]PageState, 0, len(specs))
@@ -403,12 +464,18 @@ func (m *DashboardModel) SetPages(specs []PageSpec) {
 				continue
 			}
 			panels := vs.Build(deps)
+			order := make([]string, len(panels))
+			for i, d := range panels {
+				order[i] = d.ID()
+			}
+			decks, sel := orderDecks(panels, make([]int, len(panels)), m.deckLayouts[vs.ID].Order)
 			view := ViewState{
 				ID:            vs.ID,
 				Title:         vs.Title,
-				Decks:         append([]Deck(nil), panels...),
-				DeckSelIdx:    make([]int, len(panels)),
+				Decks:         decks,
+				DeckSelIdx:    sel,
 				ActiveDeckIdx: 0,
+				DefaultOrder:  order,
 			}
 			page.Views = append(page.Views, view)
 		}
@@ -447,9 +514,24 @@ func DefaultPageSpecs() []PageSpec {
 							NewAttributesDeck(deps.Store, deps.FormatAttrModal, deps.PushContentModal),
 							NewPatternsDeck(deps.Drain3Manager, deps.PushPatternsModal),
 							NewCountsDeck(deps.PushCountsModal),
+							NewVolumeDeck(),
 						}
 					},
 				},
+				{
+					ID:    "patterns",
+					Title: "Patterns",
+					Build: func(deps DeckDeps) []Deck {
+						return []Deck{NewPatternTableDeck(deps.Drain3Manager, deps.DrillDownPattern)}
+					},
+				},
+				{
+					ID:    "attributes",
+					Title: "Attributes",
+					Build: func(deps DeckDeps) []Deck {
+						return []Deck{NewAttributeExplorerDeck(deps.Store, deps.PushValuesModal)}
+					},
+				},
 				{
 					ID:    "list",
 					Title: "List",
@@ -474,7 +556,14 @@ func DefaultPageSpecs() []PageSpec {
 					ID:    "metrics-overview",
 					Title: "Overview",
 					Build: func(deps DeckDeps) []Deck {
-						return nil // Placeholder — no decks yet
+						return []Deck{
+							NewIngestRateDeck(),
+							NewIngestBytesDeck(),
+							NewServiceRatesDeck(),
+							NewMetricChartsDeck(),
+							NewHostsDeck(deps.ToggleFacet),
+							NewServicesDeck(deps.ToggleFacet),
+						}
 					},
 				},
 			},
@@ -500,7 +589,11 @@ func DefaultPageSpecs() []PageSpec {
 					ID:    "alerts-overview",
 					Title: "Overview",
 					Build: func(deps DeckDeps) []Deck {
-						return nil // Placeholder — no decks yet
+						return []Deck{
+							NewAlertsDeck(model.AlertFiring, deps.PushAlertsModal),
+							NewAlertsDeck(model.AlertPending, deps.PushAlertsModal),
+							NewAlertsDeck(model.AlertResolved, deps.PushAlertsModal),
+						}
 					},
 				},
 			},
@@ -513,7 +606,10 @@ func DefaultPageSpecs() []PageSpec {
 					ID:    "healthchecks-overview",
 					Title: "Overview",
 					Build: func(deps DeckDeps) []Deck {
-						return nil // Placeholder — no decks yet
+						return []Deck{
+							NewHealthDeck(),
+							NewHealthErrorsDeck(),
+						}
 					},
 				},
 			},
@@ -537,6 +633,15 @@ func (m *DashboardModel) pushPatternsModalCmd() tea.Cmd {
 	}
 }
 
+// pushAttributeValuesModalCmd returns a tea.Cmd that pushes the values
+// modal for an attribute key.
+func (m *DashboardModel) pushAttributeValuesModalCmd(key string, values []model.DimensionCount) tea.Cmd {
+	return func() tea.Msg {
+		modal := NewAttributeValuesModal(m, key, values)
+		return ActionMsg{Action: ActionPushModal, Payload: modal}
+	}
+}
+
 // pushSeverityModalCmd returns a tea.Cmd that pushes the severity timeline modal.
 func (m *DashboardModel) pushSeverityModalCmd() tea.Cmd {
 	return func() tea.Msg {
@@ -545,6 +650,15 @@ func (m *DashboardModel) pushSeverityModalCmd() tea.Cmd {
 	}
 }
 
+// pushAlertsModalCmd returns a tea.Cmd that pushes the alerts modal with
+// the cursor on the first alert in state.
+func (m *DashboardModel) pushAlertsModalCmd(state string, alerts []model.Alert) tea.Cmd {
+	return func() tea.Msg {
+		modal := NewAlertsModal(m, state, alerts)
+		return ActionMsg{Action: ActionPushModal, Payload: modal}
+	}
+}
+
 // pushContentModalCmd returns a function that creates a tea.Cmd to push a detail modal with content.
 func (m *DashboardModel) pushContentModalCmd() func(content string) tea.Cmd {
 	return func(content string) tea.Cmd {
@@ -697,6 +811,18 @@ func (m *DashboardModel) queryOpts() model.QueryOpts {
 	return model.QueryOpts{App: m.selectedApp}
 }
 
+// logQueryOpts is queryOpts narrowed to the log-list time window.
+func (m *DashboardModel) logQueryOpts() model.QueryOpts {
+	opts := m.queryOpts()
+	opts.From, opts.To = m.timeWindow.From, m.timeWindow.To
+	return opts
+}
+
+// timeWindowLabel formats the log-list time window as "HH:MM–HH:MM".
+func (m *DashboardModel) timeWindowLabel() string {
+	return m.timeWindow.From.Local().Format("15:04") + "–" + m.timeWindow.To.Local().Format("15:04")
+}
+
 // modalContext builds a ModalContext snapshot for modal construction.
 func (m *DashboardModel) modalContext() ModalContext {
 	return ModalContext{
@@ -748,6 +874,43 @@ func (m *DashboardModel) SetVersionInfo(info *VersionInfo) {
 	m.versionInfo = info
 }
 
+// SetLogTemplates installs per-view display templates for the log list,
+// keyed by view ID ("list"), LogTemplateViewer, or LogTemplateAllViews.
+func (m *DashboardModel) SetLogTemplates(templates map[string]string) error {
+	parsed := make(map[string]*LogTemplate, len(templates))
+	for viewID, src := range templates {
+		if strings.TrimSpace(src) == "" {
+			continue
+		}
+		tmpl, err := ParseLogTemplate(src)
+		if err != nil {
+			return fmt.Errorf("log template %q: %w", viewID, err)
+		}
+		parsed[viewID] = tmpl
+	}
+	m.logTemplates = parsed
+	return nil
+}
+
+// activeLogTemplate returns the template for whatever is currently showing
+// the log list, or nil to use the default column layout.
+func (m *DashboardModel) activeLogTemplate() *LogTemplate {
+	if len(m.logTemplates) == 0 {
+		return nil
+	}
+	if m.isLogViewerOpen() {
+		if tmpl := m.logTemplates[LogTemplateViewer]; tmpl != nil {
+			return tmpl
+		}
+	}
+	if vw := m.activeViewInPage(); vw != nil {
+		if tmpl := m.logTemplates[vw.ID]; tmpl != nil {
+			return tmpl
+		}
+	}
+	return m.logTemplates[LogTemplateAllViews]
+}
+
 // hasK8sAttributes returns true if recent logs have k8s namespace/pod attributes
 func (m *DashboardModel) hasK8sAttributes() bool {
 	checkCount := min(10, len(m.logEntries))
@@ -832,7 +995,8 @@ func (m *DashboardModel) autoPauseLiveUpdates() bool {
 	return m.activeSection == SectionLogs || m.isLogViewerOpen()
 }
 
-// liveUpdatesPaused returns true when refreshes should be skipped.
+// liveUpdatesPaused returns true when refreshes should be skipped. Tail
+// mode keeps the log list live while reading; only a manual pause stops it.
 func (m *DashboardModel) liveUpdatesPaused() bool {
-	return m.viewPaused || m.autoPauseLiveUpdates()
+	return m.viewPaused || (!m.tailMode && m.autoPauseLiveUpdates())
 }
@@ -47,8 +47,8 @@ func (m *DashboardModel) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 
 	case key.Matches(msg, k.Escape):
-		// Clear applied filter/search even when not in input mode
-		if m.filterRegex != nil || m.filterInput.Value() != "" || m.searchTerm != "" || m.searchInput.Value() != "" {
+		// Clear applied filter/facets/search even when not in input mode
+		if m.filterRegex != nil || m.filterInput.Value() != "" || len(m.facets) > 0 || m.searchTerm != "" || m.searchInput.Value() != "" || len(m.highlightTerms) > 0 {
 			m.filterActive = false
 			m.searchActive = false
 			m.filterInput.Blur()
@@ -56,7 +56,10 @@ func (m *DashboardModel) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.filterInput.SetValue("")
 			m.searchInput.SetValue("")
 			m.filterRegex = nil
+			m.filterErr = nil
+			m.facets = nil
 			m.searchTerm = ""
+			m.highlightTerms = nil
 			if m.activeSection == SectionFilter {
 				m.activeSection = SectionDecks
 				if m.activeDeckIdx >= len(m.decks) {
@@ -80,6 +83,7 @@ func (m *DashboardModel) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.filterActive = true
 			m.filterInput.SetValue("")
 			m.filterRegex = nil
+			m.filterErr = nil
 			m.filterInput.Focus()
 		}
 		return m, nil
@@ -130,6 +134,9 @@ func (m *DashboardModel) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.useLogTime = !m.useLogTime
 		return m, nil
 
+	case key.Matches(msg, k.TailMode):
+		return m, m.toggleTailMode()
+
 	case key.Matches(msg, k.Inspect):
 		if m.activeSection == SectionLogs {
 			if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
@@ -158,6 +165,43 @@ func (m *DashboardModel) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.PushModal(NewSearchModal(m))
 		return m, nil
 
+	case key.Matches(msg, k.Workspace):
+		m.PushModal(NewWorkspaceModal(m))
+		return m, nil
+
+	case key.Matches(msg, k.Highlights):
+		m.PushModal(NewHighlightsModal(m))
+		return m, nil
+
+	case key.Matches(msg, k.FilterPresets):
+		m.PushModal(NewFilterPresetsModal(m))
+		return m, nil
+
+	case key.Matches(msg, k.Export):
+		m.PushModal(NewExportModal(m))
+		return m, nil
+
+	case key.Matches(msg, k.Copy):
+		switch m.activeSection {
+		case SectionLogs:
+			return m, m.copySelectedLog(false)
+		case SectionDecks:
+			return m, m.copyActiveDeck()
+		}
+		return m, nil
+
+	case key.Matches(msg, k.CopyJSON):
+		if m.activeSection == SectionLogs {
+			return m, m.copySelectedLog(true)
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Pin):
+		if m.activeSection == SectionLogs {
+			m.toggleSelectedPin()
+		}
+		return m, nil
+
 	case key.Matches(msg, k.DeckPause):
 		// Per-deck pause: toggle pause on focused deck's TypeID
 		if m.activeSection == SectionDecks && m.activeDeckIdx < len(m.decks) {
@@ -219,7 +263,8 @@ func (m *DashboardModel) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Grid-aware deck navigation (2-column layout).
 	// Spatial: Left/Right/Up/Down move naturally within the grid.
 	// Left at the left edge → sidebar (if visible). Right at the right edge → stop.
-	// Views are switched with [] keys, not arrow keys.
+	// Views are switched with [] keys, not arrow keys. A view with a single
+	// deck (List, Patterns) has no grid: Up/Down move its selection instead.
 	if m.activeSection == SectionDecks {
 		cols := m.deckColumnCount()
 		col := m.activeDeckIdx % cols
@@ -236,18 +281,52 @@ func (m *DashboardModel) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.activeSection = SectionSidebar
 			}
 			return m, nil
-		case key.Matches(msg, k.Down):
+		case key.Matches(msg, k.Down) && len(m.decks) > 1:
 			newIdx := m.activeDeckIdx + cols
 			if newIdx < len(m.decks) {
 				m.activeDeckIdx = newIdx
 			}
 			return m, nil
-		case key.Matches(msg, k.Up):
+		case key.Matches(msg, k.Up) && len(m.decks) > 1:
 			newIdx := m.activeDeckIdx - cols
 			if newIdx >= 0 {
 				m.activeDeckIdx = newIdx
 			}
 			return m, nil
+
+		// Layout: reorder the focused deck within the grid and resize its
+		// row and column. Layouts are saved per view.
+		case key.Matches(msg, k.DeckMoveLeft):
+			if col > 0 {
+				m.moveActiveDeck(-1)
+			}
+			return m, nil
+		case key.Matches(msg, k.DeckMoveRight):
+			if col < cols-1 {
+				m.moveActiveDeck(1)
+			}
+			return m, nil
+		case key.Matches(msg, k.DeckMoveUp):
+			m.moveActiveDeck(-cols)
+			return m, nil
+		case key.Matches(msg, k.DeckMoveDown):
+			m.moveActiveDeck(cols)
+			return m, nil
+		case key.Matches(msg, k.DeckGrow):
+			m.resizeActiveDeckRow(deckRowStep)
+			return m, nil
+		case key.Matches(msg, k.DeckShrink):
+			m.resizeActiveDeckRow(-deckRowStep)
+			return m, nil
+		case key.Matches(msg, k.DeckWiden):
+			m.resizeActiveDeckColumn(deckSplitStep)
+			return m, nil
+		case key.Matches(msg, k.DeckNarrow):
+			m.resizeActiveDeckColumn(-deckSplitStep)
+			return m, nil
+		case key.Matches(msg, k.DeckLayoutReset):
+			m.resetActiveDeckLayout()
+			return m, nil
 		}
 	}
 
@@ -268,6 +347,11 @@ func (m *DashboardModel) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+		if m.activeSection == SectionLogs && m.selectedLogIndex == 0 {
+			// Past the top: fetch the page before the first record.
+			m.logAutoScroll = false
+			return m, m.loadOlderLogs(-1)
+		}
 		m.moveSelection(-1)
 		return m, nil
 
@@ -298,6 +382,7 @@ func (m *DashboardModel) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			m.selectedLogIndex = max(0, len(m.logEntries)-1)
 			m.logAutoScroll = true
+			m.tailNewLines = 0
 			return m, nil
 		}
 
@@ -307,8 +392,16 @@ func (m *DashboardModel) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.instructionsScrollOffset = max(0, m.instructionsScrollOffset-5)
 				return m, nil
 			}
-			m.selectedLogIndex = max(0, m.selectedLogIndex-10)
-			if m.selectedLogIndex == 0 {
+			if m.selectedLogIndex < 10 {
+				// Past the top: fetch the page before the first record
+				// and finish the move in it.
+				move := m.selectedLogIndex - 10
+				m.selectedLogIndex = 0
+				m.logAutoScroll = false
+				return m, m.loadOlderLogs(move)
+			}
+			m.selectedLogIndex -= 10
+			if m.tailMode {
 				m.logAutoScroll = false
 			}
 			return m, nil
@@ -324,6 +417,7 @@ func (m *DashboardModel) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.selectedLogIndex = min(maxIndex, m.selectedLogIndex+10)
 			if m.selectedLogIndex == maxIndex {
 				m.logAutoScroll = true
+				m.tailNewLines = 0
 			}
 			return m, nil
 		}
@@ -452,6 +546,10 @@ func (m *DashboardModel) moveSelection(delta int) {
 			m.logAutoScroll = false
 		} else if m.selectedLogIndex == maxItems-1 {
 			m.logAutoScroll = true
+			m.tailNewLines = 0
+		} else if m.tailMode {
+			// Tail mode stops following as soon as the user scrolls up.
+			m.logAutoScroll = false
 		}
 		return
 	}
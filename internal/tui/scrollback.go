@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// scrollbackPageLines is how many older records one step past the top
+	// of the log list fetches.
+	scrollbackPageLines = 500
+	// scrollbackMaxLines caps the log list while older pages are loaded.
+	// Paging back past it drops records from the newest end; reloads of
+	// the latest records drop them from the oldest end.
+	scrollbackMaxLines = 20000
+)
+
+// scrollbackMsg carries a page of records older than the log list's first.
+type scrollbackMsg struct {
+	key     string // logQueryKey the page was fetched for
+	limit   int
+	move    int // selection change left over past the top of the list
+	records []model.LogRecord
+	err     error
+}
+
+// logQueryKey identifies the query behind the log list, so pages fetched
+// for other filters are not mixed into it.
+func (m *DashboardModel) logQueryKey() string {
+	levels := slices.Clone(m.activeSeverityLevels())
+	slices.Sort(levels)
+	var pattern string
+	if m.filterRegex != nil {
+		pattern = m.filterRegex.String()
+	}
+	return fmt.Sprint(m.logQueryOpts(), levels, m.facets, pattern)
+}
+
+// loadOlderLogs fetches the page of records before the first one in the
+// log list, keyset-paginating on the timestamp: the page ends just after
+// the first record's microsecond, and the records of that microsecond
+// already listed are fetched again and dropped, so none are skipped. move
+// is applied to the selection once the page is in.
+func (m *DashboardModel) loadOlderLogs(move int) tea.Cmd {
+	key := m.logQueryKey()
+	if m.store == nil || m.scrollbackLoading || len(m.logEntries) == 0 {
+		return nil
+	}
+	if m.scrollbackExhausted && m.scrollbackKey == key {
+		return nil
+	}
+	levels := m.activeSeverityLevels()
+	if levels != nil && len(levels) == 0 {
+		return nil
+	}
+
+	first := m.logEntries[0].Timestamp.UnixMicro()
+	boundary := 0
+	for _, r := range m.logEntries {
+		if r.Timestamp.UnixMicro() != first {
+			break
+		}
+		boundary++
+	}
+	opts := m.logQueryOpts()
+	opts.To = time.UnixMicro(first + 1)
+	limit := scrollbackPageLines + boundary
+
+	store, facets := m.store, m.logFacets()
+	var messagePattern string
+	if m.filterRegex != nil {
+		messagePattern = m.filterRegex.String()
+	}
+	m.scrollbackLoading = true
+	return func() tea.Msg {
+		records, err := store.RecentLogsFiltered(limit, opts, levels, facets, messagePattern)
+		return scrollbackMsg{key: key, limit: limit, move: move, records: records, err: err}
+	}
+}
+
+// handleScrollback puts a page of older records in front of the log list,
+// keeping the selected record selected before applying the leftover move.
+// Past scrollbackMaxLines the newest records are dropped, and the list
+// stops taking in reloads of the latest records until End is pressed.
+func (m *DashboardModel) handleScrollback(msg scrollbackMsg) {
+	m.scrollbackLoading = false
+	if msg.err != nil {
+		m.lastError = msg.err.Error()
+		m.lastErrorAt = time.Now()
+		return
+	}
+	if msg.key != m.logQueryKey() {
+		return
+	}
+	merged := mergeLogEntries(msg.records, m.logEntries, 0)
+	added := len(merged) - len(m.logEntries)
+	m.scrollbackKey = msg.key
+	m.scrollbackExhausted = len(msg.records) < msg.limit || added == 0
+	if len(merged) > scrollbackMaxLines {
+		merged = merged[:scrollbackMaxLines]
+		m.scrollbackTrimmed = true
+	}
+	m.logEntries = merged
+	m.logAutoScroll = false
+	m.selectedLogIndex = min(max(0, m.selectedLogIndex+added+msg.move), len(merged)-1)
+}
+
+// keepScrollback carries the older pages of the log list over a reload of
+// its latest records while the user reads them, and drops them once the
+// list follows the latest record again, loses focus, or its filters
+// change.
+func (m *DashboardModel) keepScrollback(records []model.LogRecord) []model.LogRecord {
+	if m.scrollbackKey == "" {
+		return records
+	}
+	reading := m.activeSection == SectionLogs || m.isLogViewerOpen()
+	if m.logAutoScroll || !reading || m.scrollbackKey != m.logQueryKey() {
+		m.scrollbackKey, m.scrollbackExhausted, m.scrollbackTrimmed = "", false, false
+		return records
+	}
+	if m.scrollbackTrimmed {
+		// The newest records were dropped; merging the latest back in
+		// would leave a gap in the middle of the list.
+		return m.logEntries
+	}
+	merged := mergeLogEntries(m.logEntries, records, 0)
+	if dropped := len(merged) - scrollbackMaxLines; dropped > 0 {
+		merged = merged[dropped:]
+		m.scrollbackExhausted = false
+		if !m.tailMode { // tail mode finds the selected record again by key
+			m.selectedLogIndex = max(0, m.selectedLogIndex-dropped)
+		}
+	}
+	return merged
+}
+
+// scrollbackStatus describes older pages being fetched, or the start of
+// history being reached, for the log panel's status line.
+func (m *DashboardModel) scrollbackStatus() string {
+	switch {
+	case m.scrollbackLoading:
+		return "loading older logs…"
+	case m.scrollbackExhausted && m.scrollbackKey != "" && m.selectedLogIndex == 0:
+		return "start of history"
+	case m.scrollbackTrimmed:
+		return fmt.Sprintf("%d lines loaded, newest dropped • End for latest", len(m.logEntries))
+	case m.scrollbackKey != "":
+		return fmt.Sprintf("%d lines loaded • End for latest", len(m.logEntries))
+	}
+	return ""
+}
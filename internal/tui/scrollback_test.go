@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/memstore"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestScrollback_PagesBackToTheStartOfHistory(t *testing.T) {
+	t.Parallel()
+
+	const total = 1200
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := memstore.NewStore()
+	var batch []*model.LogRecord
+	for i := range total {
+		ts := base.Add(time.Duration(i) * time.Millisecond)
+		if i >= 1185 && i < 1195 {
+			// A run of records in one microsecond straddles the first page.
+			ts = base.Add(1185 * time.Millisecond)
+		}
+		batch = append(batch, &model.LogRecord{Timestamp: ts, Level: "INFO", Message: fmt.Sprintf("line %d", i)})
+	}
+	if err := store.InsertLogBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.width, m.height = 200, 50
+	m.activeSection = SectionLogs
+	latest, _ := store.RecentLogsFiltered(10, model.QueryOpts{}, nil, nil, "")
+	m.applyLogEntries(latest)
+	m.selectedLogIndex = 3
+
+	// PageUp past the top fetches a page and finishes the move in it.
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	if cmd == nil || m.selectedLogIndex != 0 || !strings.Contains(m.scrollbackStatus(), "loading") {
+		t.Fatalf("pgup: cmd=%v selection=%d status=%q", cmd != nil, m.selectedLogIndex, m.scrollbackStatus())
+	}
+	m.Update(cmd())
+	if got, want := len(m.logEntries), 10+scrollbackPageLines; got != want {
+		t.Fatalf("after one page: %d records, want %d", got, want)
+	}
+	if got := m.logEntries[m.selectedLogIndex].Message; got != fmt.Sprintf("line %d", total-10-7) {
+		t.Fatalf("selected %q after pgup, want 7 lines above the old first one", got)
+	}
+
+	// A reload of the latest records keeps the pages while reading.
+	m.applyLogEntries(latest)
+	if len(m.logEntries) != 10+scrollbackPageLines {
+		t.Fatalf("reload dropped the scrollback: %d records", len(m.logEntries))
+	}
+
+	for range total {
+		m.selectedLogIndex = 0
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyUp})
+		if cmd == nil {
+			break
+		}
+		m.Update(cmd())
+	}
+	if len(m.logEntries) != total || m.logEntries[0].Message != "line 0" || m.scrollbackStatus() != "start of history" {
+		t.Fatalf("scrollback ended with %d records starting at %q, status %q", len(m.logEntries), m.logEntries[0].Message, m.scrollbackStatus())
+	}
+	seen := map[string]bool{}
+	for _, r := range m.logEntries {
+		if seen[r.Message] {
+			t.Fatalf("%q listed twice", r.Message)
+		}
+		seen[r.Message] = true
+	}
+
+	// New filters start over from the latest records.
+	m.facets = map[string]string{model.FacetService: "api"}
+	m.applyLogEntries(latest)
+	if len(m.logEntries) != 10 || m.scrollbackStatus() != "" {
+		t.Fatalf("after a filter change: %d records, status %q", len(m.logEntries), m.scrollbackStatus())
+	}
+}
+
+func TestScrollback_DropsTheNewestPastTheCap(t *testing.T) {
+	t.Parallel()
+
+	const total = scrollbackMaxLines + 700
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := memstore.NewStore()
+	batch := make([]*model.LogRecord, 0, total)
+	for i := range total {
+		batch = append(batch, &model.LogRecord{Timestamp: base.Add(time.Duration(i) * time.Millisecond), Level: "INFO", Message: fmt.Sprintf("line %d", i)})
+	}
+	if err := store.InsertLogBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.width, m.height = 200, 50
+	m.activeSection = SectionLogs
+	latest, _ := store.RecentLogsFiltered(10, model.QueryOpts{}, nil, nil, "")
+	m.applyLogEntries(latest)
+
+	for range total {
+		m.selectedLogIndex = 0
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyUp})
+		if cmd == nil {
+			break
+		}
+		m.Update(cmd())
+	}
+	if len(m.logEntries) != scrollbackMaxLines || m.logEntries[0].Message != "line 0" {
+		t.Fatalf("scrollback ended with %d records starting at %q, want the cap from line 0", len(m.logEntries), m.logEntries[0].Message)
+	}
+	if last := m.logEntries[len(m.logEntries)-1].Message; last != fmt.Sprintf("line %d", scrollbackMaxLines-1) {
+		t.Fatalf("last record %q, want the newest dropped", last)
+	}
+	m.selectedLogIndex = 5
+	if s := m.scrollbackStatus(); !strings.Contains(s, "newest dropped") {
+		t.Fatalf("status %q, want the cap reported", s)
+	}
+
+	// Reloads of the latest records leave the window alone until End.
+	m.applyLogEntries(latest)
+	if len(m.logEntries) != scrollbackMaxLines || m.logEntries[0].Message != "line 0" {
+		t.Fatalf("reload changed the scrollback: %d records starting at %q", len(m.logEntries), m.logEntries[0].Message)
+	}
+	m.logAutoScroll = true
+	m.applyLogEntries(latest)
+	if len(m.logEntries) != 10 || m.scrollbackStatus() != "" {
+		t.Fatalf("after End: %d records, status %q", len(m.logEntries), m.scrollbackStatus())
+	}
+}
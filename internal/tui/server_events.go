@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+const (
+	// serverEventBuffer is how many pushed server events wait for the TUI.
+	serverEventBuffer = 16
+	// serverEventRetry is how long after a failed subscription the tick
+	// tries again.
+	serverEventRetry = 30 * time.Second
+	// serverEventShown is how long the status bar shows the last event.
+	serverEventShown = 30 * time.Second
+)
+
+// serverEventMsg carries an event pushed by the server.
+type serverEventMsg struct {
+	sub    model.EventSubscription
+	event  model.ServerEvent
+	closed bool // the subscription ended; event is unset
+}
+
+// waitForServerEvent waits for the next event pushed to sub.
+func waitForServerEvent(sub model.EventSubscription) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-sub.Events()
+		if !ok {
+			return serverEventMsg{sub: sub, closed: true}
+		}
+		return serverEventMsg{sub: sub, event: ev}
+	}
+}
+
+// syncServerEvents keeps a subscription to the server's events open when
+// the store can push them, so the status bar shows them as they happen.
+// A subscription that failed to open is retried after serverEventRetry.
+// The command waits for the new subscription's events.
+func (m *DashboardModel) syncServerEvents() tea.Cmd {
+	source, ok := m.store.(model.EventSource)
+	if !ok || m.serverEvents != nil || !supports(m.store, "SubscribeEvents") {
+		return nil
+	}
+	if reconnecting(m.store) {
+		m.serverEventsFailedAt = time.Time{} // retry once it is back
+		return nil
+	}
+	if time.Since(m.serverEventsFailedAt) < serverEventRetry {
+		return nil
+	}
+	sub, err := source.SubscribeEvents(serverEventBuffer)
+	if err != nil {
+		m.serverEventsFailedAt = time.Now()
+		return nil
+	}
+	m.serverEvents = sub
+	return waitForServerEvent(sub)
+}
+
+// handleServerEvent shows a pushed event in the status bar. A subscription
+// that ended is reopened by the next tick.
+func (m *DashboardModel) handleServerEvent(msg serverEventMsg) tea.Cmd {
+	if msg.sub != m.serverEvents {
+		return nil // a subscription since closed
+	}
+	if msg.closed {
+		m.serverEvents.Close()
+		m.serverEvents = nil
+		return nil
+	}
+	m.lastServerEvent = msg.event
+	m.lastServerEventAt = time.Now()
+	return waitForServerEvent(msg.sub)
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/events"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// eventStore is a countingStore that pushes the events published to it.
+type eventStore struct {
+	countingStore
+	*events.Hub
+}
+
+func TestServerEvents_ShownInStatusBar(t *testing.T) {
+	t.Parallel()
+
+	store := &eventStore{Hub: events.NewHub()}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.width = 200
+
+	m.Update(TickMsg(time.Now()))
+	if store.Subscribers() != 1 || m.serverEvents == nil {
+		t.Fatalf("tick opened %d subscriptions, want 1", store.Subscribers())
+	}
+	m.Update(TickMsg(time.Now()))
+	if store.Subscribers() != 1 {
+		t.Fatal("tick subscribed again while subscribed")
+	}
+
+	store.Publish(model.ServerEvent{Kind: model.EventBackupDone, Message: "backup done"})
+	m.Update(waitForServerEvent(m.serverEvents)())
+	if !strings.Contains(m.renderStatusLine(), "backup done") {
+		t.Fatal("status bar does not show the event")
+	}
+	m.lastServerEventAt = time.Now().Add(-serverEventShown)
+	if strings.Contains(m.renderStatusLine(), "backup done") {
+		t.Fatal("status bar still shows an old event")
+	}
+
+	// A subscription that ends is reopened by the next tick.
+	sub := m.serverEvents
+	sub.Close()
+	m.Update(waitForServerEvent(sub)())
+	if m.serverEvents != nil {
+		t.Fatal("ended subscription kept")
+	}
+	m.Update(TickMsg(time.Now()))
+	if store.Subscribers() != 1 {
+		t.Fatal("tick did not resubscribe")
+	}
+}
@@ -91,13 +91,13 @@ func (m *DashboardModel) applySidebarItem(item sidebarItem) tea.Cmd {
 
 		// Refresh logs.
 		m.tickInFlight = false
-		opts := m.queryOpts()
+		opts := m.logQueryOpts()
 		severityLevels := m.activeSeverityLevels()
 		var messagePattern string
 		if m.filterRegex != nil {
 			messagePattern = m.filterRegex.String()
 		}
-		logLimit := m.visibleLogLines()
+		logLimit := m.logListLimit()
 		drainFrom := m.drain3LastProcessed
 		cmds = append(cmds, m.fetchTickDataCmd(opts, severityLevels, messagePattern, logLimit, drainFrom))
 
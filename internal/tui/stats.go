@@ -35,7 +35,7 @@ func (m *DashboardModel) renderStatsContent(contentWidth int, sm *StatsModal) st
 		{"Total Logs Processed", fmt.Sprintf("%d", totalLogs)},
 		{"Logs in DuckDB", fmt.Sprintf("%d", totalLogs)},
 		{"Filtered Logs Displayed", fmt.Sprintf("%d", len(m.logEntries))},
-		{"Total Bytes Processed", m.formatBytes(totalBytes)},
+		{"Total Bytes Processed", formatBytes(totalBytes)},
 		{"Uptime", m.formatUptime()},
 		{"Current Processing Rate", m.formatCurrentRate()},
 		{"Peak Logs per Second", fmt.Sprintf("%.1f", m.stats.PeakLogsPerSec)},
@@ -185,7 +185,7 @@ func calculateServiceStatsFrom(serviceStats []model.DimensionCount, total int64)
 	return formatDimensionStats(serviceStats, total)
 }
 
-func (m *DashboardModel) formatBytes(bytes int64) string {
+func formatBytes(bytes int64) string {
 	if bytes < 1024 {
 		return fmt.Sprintf("%d B", bytes)
 	} else if bytes < 1024*1024 {
@@ -8,85 +8,11 @@ import (
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
 
-	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/lipgloss"
 )
 
-// formatAttributesTable formats attributes using Bubbles table component
-func (m *DashboardModel) formatAttributesTable(attributes map[string]string, maxWidth int) string {
-	if len(attributes) == 0 {
-		return ""
-	}
-
-	// Sort keys for consistent display
-	keys := make([]string, 0, len(attributes))
-	for k := range attributes {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
-	// Calculate column widths
-	keyWidth := maxWidth / 3              // ~33% for keys
-	valueWidth := maxWidth - keyWidth - 4 // remainder for values, minus borders
-
-	if keyWidth < 10 {
-		keyWidth = 10
-	}
-	if valueWidth < 10 {
-		valueWidth = 10
-	}
-
-	// Create table columns
-	columns := []table.Column{
-		{Title: "Name", Width: keyWidth},
-		{Title: "Value", Width: valueWidth},
-	}
-
-	// Create table rows
-	rows := []table.Row{}
-	totalRows := 0
-	for _, key := range keys {
-		value := attributes[key]
-
-		// Always truncate long keys to fit (keys are less important to see in full)
-		displayKey := key
-		if len(displayKey) > keyWidth-3 {
-			displayKey = displayKey[:keyWidth-3] + "..."
-		}
-
-		// Truncate long values to fit
-		if len(value) > valueWidth-3 {
-			value = value[:valueWidth-3] + "..."
-		}
-		rows = append(rows, table.Row{displayKey, value})
-		totalRows++
-	}
-
-	// Create and configure table
-	t := table.New(
-		table.WithColumns(columns),
-		table.WithRows(rows),
-		table.WithHeight(totalRows+2), // +2 for header and padding
-		table.WithWidth(maxWidth),
-		table.WithFocused(false), // Disable focus to prevent selection
-	)
-
-	// Style the table
-	styles := table.DefaultStyles()
-	styles.Header = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(ColorBlue).
-		Width(keyWidth)
-	styles.Cell = lipgloss.NewStyle()     // Default cell style
-	styles.Selected = lipgloss.NewStyle() // No selection highlighting
-
-	t.SetStyles(styles)
-	t.Blur() // Ensure table is not focused
-
-	return t.View()
-}
-
-// formatLogDetails formats detailed log entry information for modal display
+// formatLogDetails formats a log entry's timestamps, severity, and message
+// for the detail modal, which shows its JSON as trees below them.
 func (m *DashboardModel) formatLogDetails(entry model.LogRecord, maxWidth int) string {
 	// Define styles
 	headerStyle := lipgloss.NewStyle().
@@ -138,12 +64,6 @@ func (m *DashboardModel) formatLogDetails(entry model.LogRecord, maxWidth int) s
 	details.WriteString(labelStyle.Render("Message:") + "\n" +
 		valueStyle.Render(entry.Message) + "\n")
 
-	// Attributes table
-	if len(entry.Attributes) > 0 {
-		details.WriteString("\n" + headerStyle.Render("Attributes") + "\n")
-		details.WriteString(m.formatAttributesTable(entry.Attributes, maxWidth))
-	}
-
 	return details.String()
 }
 
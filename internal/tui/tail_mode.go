@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+const (
+	// tailModeLines is how many records the log list keeps in tail mode,
+	// so scrolling up reaches past the visible page.
+	tailModeLines = 2000
+	// tailPollInterval is how often tail mode reloads the list when the
+	// store cannot push records.
+	tailPollInterval = 250 * time.Millisecond
+)
+
+// tailPollMsg asks tail mode to reload the log list.
+type tailPollMsg struct{}
+
+// tailPolledMsg carries a tail mode reload.
+type tailPolledMsg struct {
+	records []model.LogRecord
+	err     error
+}
+
+func tailPollCmd() tea.Cmd {
+	return tea.Tick(tailPollInterval, func(time.Time) tea.Msg { return tailPollMsg{} })
+}
+
+// toggleTailMode turns tail mode on or off. Tail mode keeps the log list
+// live while the Logs section is focused, follows new records until the
+// user scrolls up, and counts what arrives below meanwhile.
+func (m *DashboardModel) toggleTailMode() tea.Cmd {
+	m.tailMode = !m.tailMode
+	m.tailNewLines = 0
+	if !m.tailMode {
+		return nil
+	}
+	m.activeSection = SectionLogs
+	m.logAutoScroll = true
+	m.selectedLogIndex = max(0, len(m.logEntries)-1)
+	return tailPollCmd()
+}
+
+// logListLimit returns how many records the log list holds.
+func (m *DashboardModel) logListLimit() int {
+	if m.tailMode {
+		return tailModeLines
+	}
+	return m.visibleLogLines()
+}
+
+// tailStreaming reports whether a live tail feeds the log list.
+func (m *DashboardModel) tailStreaming() bool {
+	return m.logTail != nil && m.logTailSeeded
+}
+
+// handleTailPoll reloads the log list between ticks while tail mode is on
+// and no live tail feeds it. The poll stops when tail mode is turned off.
+func (m *DashboardModel) handleTailPoll() tea.Cmd {
+	if !m.tailMode {
+		return nil
+	}
+	if m.tailPollInFlight || m.tailStreaming() || m.liveUpdatesPaused() || m.store == nil {
+		return tailPollCmd()
+	}
+	m.tailPollInFlight = true
+	store, limit, opts, levels, facets := m.store, m.logListLimit(), m.logQueryOpts(), m.activeSeverityLevels(), m.logFacets()
+	var messagePattern string
+	if m.filterRegex != nil {
+		messagePattern = m.filterRegex.String()
+	}
+	return tea.Batch(tailPollCmd(), func() tea.Msg {
+		if levels != nil && len(levels) == 0 {
+			return tailPolledMsg{}
+		}
+		records, err := store.RecentLogsFiltered(limit, opts, levels, facets, messagePattern)
+		return tailPolledMsg{records: records, err: err}
+	})
+}
+
+// handleTailPolled applies a tail mode reload. Errors are left to the tick,
+// which reports them.
+func (m *DashboardModel) handleTailPolled(msg tailPolledMsg) {
+	m.tailPollInFlight = false
+	if !m.tailMode || msg.err != nil || m.tailStreaming() || m.liveUpdatesPaused() {
+		return
+	}
+	m.applyLogEntries(msg.records)
+}
+
+// anchorTailSelection keeps the selected record selected when records
+// replace the list in tail mode while the user is scrolled up, and adds
+// the records that arrived after the old last one to tailNewLines.
+func (m *DashboardModel) anchorTailSelection(records []model.LogRecord) {
+	if len(m.logEntries) == 0 {
+		return
+	}
+	last := logEntryKey(m.logEntries[len(m.logEntries)-1])
+	var selected string
+	if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+		selected = logEntryKey(m.logEntries[m.selectedLogIndex])
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		if logEntryKey(records[i]) == last {
+			m.tailNewLines += len(records) - 1 - i
+			break
+		}
+	}
+	for i := range records {
+		if logEntryKey(records[i]) == selected {
+			m.selectedLogIndex = i
+			return
+		}
+	}
+}
+
+// renderTailStatusLine returns the log panel's first line in tail mode.
+func (m *DashboardModel) renderTailStatusLine() string {
+	if !m.logAutoScroll {
+		style := lipgloss.NewStyle().Foreground(ColorYellow).Bold(true)
+		if m.tailNewLines == 0 {
+			if s := m.scrollbackStatus(); s != "" {
+				return style.Render("Live tail: " + s + " • End to follow • t to stop")
+			}
+			return style.Render("Live tail: scrolled up • End to follow • t to stop")
+		}
+		return style.Render(fmt.Sprintf("↓ %d new lines below • End to follow • t to stop", m.tailNewLines))
+	}
+	source := "streaming"
+	if !m.tailStreaming() {
+		source = "polling"
+	}
+	return lipgloss.NewStyle().Foreground(ColorGreen).Bold(true).
+		Render(fmt.Sprintf("● Live tail (%s): following new logs • t to stop", source))
+}
+
+// tailTinted reports whether tail mode colors a whole line of level.
+func tailTinted(level string) bool {
+	switch normalizeSeverityLevel(level) {
+	case "WARN", "ERROR", "FATAL", "CRITICAL":
+		return true
+	}
+	return false
+}
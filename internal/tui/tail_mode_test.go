@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestTailMode_FollowsAndCountsNewLines(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	record := func(i int) model.LogRecord {
+		return model.LogRecord{Message: "line" + string(rune('a'+i)), Level: "INFO", Timestamp: now.Add(time.Duration(i) * time.Second)}
+	}
+	store := &countingStore{}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	m.width, m.height = 200, 50
+	m.applyLogEntries([]model.LogRecord{record(0), record(1), record(2)})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if !m.tailMode || m.activeSection != SectionLogs || m.liveUpdatesPaused() {
+		t.Fatalf("tail mode = %v, section = %v, paused = %v; want live in the Logs section", m.tailMode, m.activeSection, m.liveUpdatesPaused())
+	}
+
+	// Without a live tail the list is polled between ticks.
+	if _, cmd := m.Update(tailPollMsg{}); cmd == nil || !m.tailPollInFlight {
+		t.Fatal("tail mode did not poll the store")
+	}
+	m.Update(tailPolledMsg{records: []model.LogRecord{record(0), record(1), record(2), record(3)}})
+	if m.selectedLogIndex != 3 || !strings.Contains(m.renderTailStatusLine(), "polling") {
+		t.Fatalf("selection = %d, status %q; want the latest line followed", m.selectedLogIndex, m.renderTailStatusLine())
+	}
+
+	// Scrolling up stops following; what arrives below is counted.
+	m.moveSelection(-1)
+	if m.logAutoScroll {
+		t.Fatal("tail mode still follows after scrolling up")
+	}
+	m.tailPollInFlight = false
+	m.Update(tailPolledMsg{records: []model.LogRecord{record(1), record(2), record(3), record(4), record(5)}})
+	if got := m.logEntries[m.selectedLogIndex].Message; got != "linec" {
+		t.Fatalf("selected %q after new lines, want the line read before", got)
+	}
+	if status := m.renderTailStatusLine(); !strings.Contains(status, "↓ 2 new lines below") {
+		t.Fatalf("status line = %q", status)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	if !m.logAutoScroll || m.tailNewLines != 0 || m.selectedLogIndex != 4 {
+		t.Fatal("End did not resume following")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if m.tailMode || !m.liveUpdatesPaused() {
+		t.Fatal("leaving tail mode did not restore the focus lock")
+	}
+	if _, cmd := m.Update(tailPollMsg{}); cmd != nil {
+		t.Fatal("polling went on after tail mode ended")
+	}
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// snapshotStore is a countingStore that also loads dashboard snapshots.
+type snapshotStore struct {
+	countingStore
+
+	snapshotCalls int
+	lastSnapshot  model.SnapshotRequest
+}
+
+func (s *snapshotStore) DashboardSnapshot(req model.SnapshotRequest) (model.DashboardSnapshot, error) {
+	s.snapshotCalls++
+	s.lastSnapshot = req
+	snap := model.DashboardSnapshot{TotalCount: s.totalCount, Apps: []string{"shop"}}
+	if req.LogLimit > 0 {
+		snap.RecentLogs = s.recentLogs
+	}
+	return snap, nil
+}
+
+func TestTick_LoadsOneSnapshot(t *testing.T) {
+	t.Parallel()
+
+	store := &snapshotStore{countingStore: countingStore{
+		totalCount: 4,
+		recentLogs: []model.LogRecord{{Message: "fresh", Level: "ERROR", Timestamp: time.Now()}},
+	}}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+	opts := model.QueryOpts{App: "shop", From: time.Now().Add(-time.Hour)}
+
+	msg := m.fetchTickDataCmd(opts, []string{"ERROR"}, "fre", 10, 4)().(tickDataLoadedMsg)
+	if store.snapshotCalls != 1 || store.totalLogCountCalls+store.listAppsCalls+store.recentLogsFilteredCalls != 0 {
+		t.Fatalf("tick made %d snapshot calls and %d single calls, want one snapshot only",
+			store.snapshotCalls, store.totalLogCountCalls+store.listAppsCalls+store.recentLogsFilteredCalls)
+	}
+	req := store.lastSnapshot
+	if req.Opts != (model.QueryOpts{App: "shop"}) || req.LogOpts != opts || req.LogLimit != 10 || req.MessagePattern != "fre" || len(req.SeverityLevels) != 1 {
+		t.Fatalf("snapshot request = %+v", req)
+	}
+	if req.TopLimit != 0 || req.Minutes {
+		t.Fatalf("snapshot request loads deck data: %+v", req)
+	}
+	if !msg.hasTotalCount || msg.totalCount != 4 || !msg.hasAppList || len(msg.appList) != 1 || !msg.hasLogEntries || messages(msg.logEntries) != "fresh" {
+		t.Fatalf("tick data = %+v", msg)
+	}
+
+	// A tailed list and an empty severity selection leave the logs out.
+	msg = m.fetchTickDataCmd(opts, nil, "", -1, 4)().(tickDataLoadedMsg)
+	if store.lastSnapshot.LogLimit != 0 || msg.hasLogEntries {
+		t.Fatalf("tailed tick loaded logs: request %+v", store.lastSnapshot)
+	}
+	msg = m.fetchTickDataCmd(opts, []string{}, "", 10, 4)().(tickDataLoadedMsg)
+	if store.lastSnapshot.LogLimit != 0 || !msg.hasLogEntries || len(msg.logEntries) != 0 {
+		t.Fatalf("empty selection: request %+v, entries %v", store.lastSnapshot, msg.logEntries)
+	}
+	if store.recentLogsFilteredCalls != 0 {
+		t.Fatalf("recent logs calls = %d, want 0", store.recentLogsFilteredCalls)
+	}
+}
+
+// olderServerStore lacks DashboardSnapshot, as the socket client learns
+// from the server's first answer.
+type olderServerStore struct {
+	snapshotStore
+	tried bool
+}
+
+func (s *olderServerStore) DashboardSnapshot(model.SnapshotRequest) (model.DashboardSnapshot, error) {
+	s.snapshotCalls++
+	s.tried = true
+	return model.DashboardSnapshot{}, errors.New("method not found")
+}
+
+func (s *olderServerStore) Supports(method string) bool {
+	return method != "DashboardSnapshot" || !s.tried
+}
+
+func TestTick_FallsBackWithoutSnapshot(t *testing.T) {
+	t.Parallel()
+
+	store := &olderServerStore{}
+	store.totalCount = 4
+	store.recentLogs = []model.LogRecord{{Message: "fresh", Level: "INFO", Timestamp: time.Now()}}
+	m := NewDashboardModel(1000, time.Second, false, false, store, "")
+
+	for range 2 {
+		msg := m.fetchTickDataCmd(model.QueryOpts{}, nil, "", 10, 4)().(tickDataLoadedMsg)
+		if msg.lastError != "" || !msg.hasTotalCount || !msg.hasAppList || messages(msg.logEntries) != "fresh" {
+			t.Fatalf("tick data = %+v", msg)
+		}
+	}
+	if store.snapshotCalls != 1 || store.totalLogCountCalls != 2 || store.recentLogsFilteredCalls != 2 {
+		t.Fatalf("snapshot calls = %d, count calls = %d, log calls = %d; want the snapshot tried once",
+			store.snapshotCalls, store.totalLogCountCalls, store.recentLogsFilteredCalls)
+	}
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"maps"
 	"time"
 
 	"github.com/tinytelemetry/tiny-telemetry/internal/model"
@@ -50,10 +51,23 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if modal, ok := msg.Payload.(Modal); ok {
 				m.PushModal(modal)
 			}
+		case ActionShowTrace:
+			if traceID, ok := msg.Payload.(string); ok {
+				return m, m.drillDownToTrace(traceID)
+			}
+		}
+		return m, nil
+
+	case traceMsg:
+		// The trace modal may be under a log details modal by now.
+		for _, modal := range m.modalStack {
+			if t, ok := modal.(*TraceModal); ok {
+				t.Update(msg)
+			}
 		}
 		return m, nil
 
-	case searchDebounceMsg, searchResultsMsg:
+	case searchDebounceMsg, searchResultsMsg, alertActionMsg:
 		if modal := m.TopModal(); modal != nil {
 			pop, cmd := modal.Update(msg)
 			if pop {
@@ -63,6 +77,15 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case filterCompleteMsg:
+		return m, m.lookupFilterSuggestions(msg)
+
+	case filterSuggestionsMsg:
+		if msg.seq == m.filterSuggestSeq && m.filterActive {
+			m.filterSuggestions = msg.values
+		}
+		return m, nil
+
 	case tea.MouseMsg:
 		return m.handleMouseEvent(msg)
 
@@ -80,33 +103,45 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case TickMsg:
+		// Server events only touch the status bar; they flow while paused.
+		eventsCmd := m.syncServerEvents()
+
 		// Freeze refresh while user is reading logs (or manually paused)
 		// so selection/scroll position remains stable.
 		if m.liveUpdatesPaused() {
-			return m, tea.Tick(m.updateInterval, func(t time.Time) tea.Msg {
+			// Pushed records would move the list too; reopen the tail
+			// and reload the list once reading is done.
+			m.closeLogTail()
+			return m, tea.Batch(eventsCmd, tea.Tick(m.updateInterval, func(t time.Time) tea.Msg {
 				return TickMsg(t)
-			})
+			}))
 		}
 
 		if m.tickInFlight {
-			return m, tea.Tick(m.updateInterval, func(t time.Time) tea.Msg {
+			return m, tea.Batch(eventsCmd, tea.Tick(m.updateInterval, func(t time.Time) tea.Msg {
 				return TickMsg(t)
-			})
+			}))
 		}
 		m.tickInFlight = true
 
-		opts := m.queryOpts()
+		opts := m.logQueryOpts()
 		severityLevels := m.activeSeverityLevels()
 		var messagePattern string
 		if m.filterRegex != nil {
 			messagePattern = m.filterRegex.String()
 		}
-		logLimit := m.visibleLogLines()
+		logLimit := m.logListLimit()
 		drainFrom := m.drain3LastProcessed
+		tailing, tailCmd := m.syncLogTail()
+		if tailing {
+			logLimit = -1 // the live tail feeds the list
+		}
 
 		// Continue periodic ticks
 		return m, tea.Batch(
 			m.fetchTickDataCmd(opts, severityLevels, messagePattern, logLimit, drainFrom),
+			tailCmd,
+			eventsCmd,
 			tea.Tick(m.updateInterval, func(t time.Time) tea.Msg {
 				return TickMsg(t)
 			}),
@@ -121,6 +156,27 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// to avoid blocking the event loop with DB calls.
 		return m, nil
 
+	case logTailMsg:
+		return m, m.handleLogTail(msg)
+
+	case serverEventMsg:
+		return m, m.handleServerEvent(msg)
+
+	case clipboardMsg:
+		m.handleClipboard(msg)
+		return m, nil
+
+	case tailPollMsg:
+		return m, m.handleTailPoll()
+
+	case tailPolledMsg:
+		m.handleTailPolled(msg)
+		return m, nil
+
+	case scrollbackMsg:
+		m.handleScrollback(msg)
+		return m, nil
+
 	case DeckTickMsg:
 		return m.handleDeckTick(msg)
 
@@ -165,6 +221,25 @@ func (m *DashboardModel) handleMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd)
 	}
 
 	switch msg.Action {
+	case tea.MouseActionMotion:
+		// Dragging a deck border resizes the decks beside it.
+		if m.deckDrag != nil {
+			x := msg.X
+			if m.sidebarVisible {
+				x -= sidebarWidth
+			}
+			width, height := m.deckGridSize()
+			m.dragDeckBorder(*m.deckDrag, width, height, x, msg.Y)
+		}
+		return m, nil
+
+	case tea.MouseActionRelease:
+		if m.deckDrag != nil {
+			m.deckDrag = nil
+			m.saveDeckLayouts()
+		}
+		return m, nil
+
 	case tea.MouseActionPress:
 		switch msg.Button {
 		case tea.MouseButtonLeft:
@@ -227,6 +302,10 @@ func (m *DashboardModel) handleMouseClick(x, y int) (tea.Model, tea.Cmd) {
 			m.activeSection = SectionDecks
 			m.activeDeckIdx = idx
 		}
+		// A press on a deck border starts dragging it.
+		if drag, ok := m.deckBorderAt(contentWidth, decksHeight, x, y); ok {
+			m.deckDrag = &drag
+		}
 		return m, nil
 	}
 
@@ -271,6 +350,15 @@ func (m *DashboardModel) activeSeverityLevels() []string {
 	return levels
 }
 
+// logFacets returns a copy of the facets the log list is filtered by, or
+// nil when there are none.
+func (m *DashboardModel) logFacets() map[string]string {
+	if len(m.facets) == 0 {
+		return nil
+	}
+	return maps.Clone(m.facets)
+}
+
 // visibleLogLines returns how many log lines fit on screen given the current
 // terminal dimensions, using the shared layoutHeights calculation.
 // When the List view (ListDeck) is active, the deck area height is used
@@ -287,6 +375,23 @@ func (m *DashboardModel) visibleLogLines() int {
 	return 0
 }
 
+// supports reports whether store serves method; see model.MethodSupporter.
+func supports(store model.LogQuerier, method string) bool {
+	s, ok := store.(model.MethodSupporter)
+	return !ok || s.Supports(method)
+}
+
+// reconnecting reports whether store lost its server and is redialing it.
+func reconnecting(store model.LogQuerier) bool {
+	r, ok := store.(model.Reconnector)
+	return ok && r.Reconnecting()
+}
+
+// fetchTickDataCmd loads the periodic refresh. opts scopes the log list; the
+// total count and the drain3 feed ignore its time range so pattern
+// extraction keeps following the whole stream. A negative logLimit skips
+// the log list, which a live tail feeds instead. A store that loads
+// dashboard snapshots serves the counts, apps, and log list in one call.
 func (m *DashboardModel) fetchTickDataCmd(opts model.QueryOpts, severityLevels []string, messagePattern string, logLimit int, drainFrom int) tea.Cmd {
 	store := m.store
 	if store == nil {
@@ -294,6 +399,7 @@ func (m *DashboardModel) fetchTickDataCmd(opts model.QueryOpts, severityLevels [
 	}
 
 	severityCopy := append([]string(nil), severityLevels...)
+	facets := m.logFacets()
 
 	return func() tea.Msg {
 		msg := tickDataLoadedMsg{}
@@ -305,18 +411,50 @@ func (m *DashboardModel) fetchTickDataCmd(opts model.QueryOpts, severityLevels [
 			}
 		}
 
-		if v, err := store.TotalLogCount(opts); err == nil {
-			msg.totalCount = v
-			msg.hasTotalCount = true
-		} else {
-			collectErr(err)
+		streamOpts := model.QueryOpts{App: opts.App}
+		emptySelection := len(severityCopy) == 0 && severityLevels != nil
+		snapper, batched := store.(model.DashboardSnapshotter)
+		batched = batched && supports(store, "DashboardSnapshot")
+		batchedLogs := batched && logLimit > 0 && !emptySelection
+		if batched {
+			req := model.SnapshotRequest{Opts: streamOpts}
+			if batchedLogs {
+				req.LogOpts = opts
+				req.LogLimit = logLimit
+				req.SeverityLevels = severityCopy
+				req.Facets = facets
+				req.MessagePattern = messagePattern
+			}
+			if snap, err := snapper.DashboardSnapshot(req); err == nil {
+				msg.totalCount = snap.TotalCount
+				msg.hasTotalCount = true
+				msg.appList = snap.Apps
+				msg.hasAppList = true
+				if batchedLogs {
+					msg.logEntries = snap.RecentLogs
+					msg.hasLogEntries = true
+				}
+			} else if !supports(store, "DashboardSnapshot") {
+				// An older server; load the sections one by one instead.
+				batched, batchedLogs = false, false
+			} else {
+				collectErr(err)
+			}
 		}
+		if !batched {
+			if v, err := store.TotalLogCount(streamOpts); err == nil {
+				msg.totalCount = v
+				msg.hasTotalCount = true
+			} else {
+				collectErr(err)
+			}
 
-		if apps, err := store.ListApps(); err == nil {
-			msg.appList = apps
-			msg.hasAppList = true
-		} else {
-			collectErr(err)
+			if apps, err := store.ListApps(); err == nil {
+				msg.appList = apps
+				msg.hasAppList = true
+			} else {
+				collectErr(err)
+			}
 		}
 
 		if msg.hasTotalCount && msg.totalCount > int64(drainFrom) {
@@ -325,7 +463,7 @@ func (m *DashboardModel) fetchTickDataCmd(opts model.QueryOpts, severityLevels [
 				newCount = 5000
 			}
 			if newCount > 0 {
-				if records, err := store.RecentLogsFiltered(newCount, opts.App, nil, ""); err == nil {
+				if records, err := store.RecentLogsFiltered(newCount, streamOpts, nil, nil, ""); err == nil {
 					startIdx := 0
 					if len(records) > newCount {
 						startIdx = len(records) - newCount
@@ -339,10 +477,13 @@ func (m *DashboardModel) fetchTickDataCmd(opts model.QueryOpts, severityLevels [
 			}
 		}
 
-		if len(severityCopy) == 0 && severityLevels != nil {
+		if logLimit < 0 || batchedLogs {
+			return msg
+		}
+		if emptySelection {
 			msg.logEntries = []model.LogRecord{}
 			msg.hasLogEntries = true
-		} else if records, err := store.RecentLogsFiltered(logLimit, opts.App, severityCopy, messagePattern); err == nil {
+		} else if records, err := store.RecentLogsFiltered(logLimit, opts, severityCopy, facets, messagePattern); err == nil {
 			msg.logEntries = records
 			msg.hasLogEntries = true
 		} else {
@@ -381,7 +522,15 @@ func (m *DashboardModel) applyTickData(msg tickDataLoadedMsg) {
 	}
 
 	if msg.hasLogEntries && !m.liveUpdatesPaused() {
-		m.applyLogEntries(msg.logEntries)
+		if m.logTail != nil && !m.logTailSeeded {
+			// The first load since the tail opened: add what it pushed
+			// meanwhile and let it feed the list from now on.
+			m.applyLogEntries(mergeLogEntries(msg.logEntries, m.logTailPending, m.logListLimit()))
+			m.logTailSeeded = true
+			m.logTailPending = nil
+		} else {
+			m.applyLogEntries(msg.logEntries)
+		}
 	}
 }
 
@@ -394,7 +543,7 @@ func (m *DashboardModel) applyDrain3Records(records []model.LogRecord, processed
 		if r.Message == "" {
 			continue
 		}
-		m.drain3Manager.AddLogMessage(r.Message)
+		m.drain3Manager.AddLogRecord(r)
 		if drain3Instance, exists := m.drain3BySeverity[r.Level]; exists && drain3Instance != nil {
 			drain3Instance.AddLogMessage(r.Message)
 		}
@@ -402,7 +551,32 @@ func (m *DashboardModel) applyDrain3Records(records []model.LogRecord, processed
 	m.drain3LastProcessed = processed
 }
 
+// reloadLogEntries refetches the log list synchronously, bypassing the
+// live-update pause. Used when filters change while the log viewer is open.
+func (m *DashboardModel) reloadLogEntries() {
+	if m.store == nil {
+		return
+	}
+	var messagePattern string
+	if m.filterRegex != nil {
+		messagePattern = m.filterRegex.String()
+	}
+	records, err := m.store.RecentLogsFiltered(m.logListLimit(), m.logQueryOpts(), m.activeSeverityLevels(), m.logFacets(), messagePattern)
+	if err != nil {
+		m.lastError = err.Error()
+		m.lastErrorAt = time.Now()
+		return
+	}
+	m.applyLogEntries(records)
+}
+
 func (m *DashboardModel) applyLogEntries(records []model.LogRecord) {
+	records = m.keepScrollback(records)
+	if m.tailMode && !m.logAutoScroll {
+		m.anchorTailSelection(records)
+	} else {
+		m.tailNewLines = 0
+	}
 	m.logEntries = records
 
 	// Clamp selection to bounds; auto-scroll pins to the latest entry.
@@ -23,8 +23,17 @@ type countingStore struct {
 	topServicesBySeverityCall int
 	listAppsCalls             int
 	recentLogsFilteredCalls   int
+	searchLogsCalls           int
 
-	recentLogs []model.LogRecord
+	recentLogs      []model.LogRecord
+	attributeValues []model.DimensionCount
+
+	lastLogOpts         model.QueryOpts
+	lastLogLevels       []string
+	lastLogFacets       map[string]string
+	lastLogPattern      string
+	lastAttributeKey    string
+	lastAttributePrefix string
 }
 
 func (s *countingStore) TotalLogCount(_ model.QueryOpts) (int64, error) {
@@ -52,7 +61,7 @@ func (s *countingStore) TopAttributeKeys(_ int, _ model.QueryOpts) ([]model.Attr
 	return []model.AttributeKeyStat{}, nil
 }
 
-func (s *countingStore) AttributeKeyValues(_ string, _ int) (map[string]int64, error) {
+func (s *countingStore) AttributeKeyValues(_ string, _ int, _ model.QueryOpts) (map[string]int64, error) {
 	s.attributeKeyValuesCalls++
 	return map[string]int64{}, nil
 }
@@ -82,13 +91,31 @@ func (s *countingStore) TopServicesBySeverity(_ string, _ int, _ model.QueryOpts
 	return []model.DimensionCount{}, nil
 }
 
+func (s *countingStore) DistinctAttributeValues(key, prefix string, _ int, _ model.QueryOpts) ([]model.DimensionCount, error) {
+	s.lastAttributeKey, s.lastAttributePrefix = key, prefix
+	return s.attributeValues, nil
+}
+
+func (s *countingStore) RateByDimension(_ string, _, _ time.Duration, _ []string, _ model.QueryOpts) ([]model.DimensionRate, error) {
+	return []model.DimensionRate{}, nil
+}
+
 func (s *countingStore) ListApps() ([]string, error) {
 	s.listAppsCalls++
 	return []string{}, nil
 }
 
-func (s *countingStore) RecentLogsFiltered(_ int, _ string, _ []string, _ string) ([]model.LogRecord, error) {
+func (s *countingStore) RecentLogsFiltered(_ int, opts model.QueryOpts, severityLevels []string, facets map[string]string, messagePattern string) ([]model.LogRecord, error) {
 	s.recentLogsFilteredCalls++
+	s.lastLogOpts = opts
+	s.lastLogLevels = severityLevels
+	s.lastLogFacets = facets
+	s.lastLogPattern = messagePattern
+	return s.recentLogs, nil
+}
+
+func (s *countingStore) SearchLogs(_ string, _ int, _ model.QueryOpts) ([]model.LogRecord, error) {
+	s.searchLogsCalls++
 	return s.recentLogs, nil
 }
 
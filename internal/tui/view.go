@@ -41,7 +41,7 @@ func (m *DashboardModel) layoutHeights() (decksHeight, filterHeight, logsHeight
 func (m *DashboardModel) hasFilterOrSearch() bool {
 	return m.filterActive || m.searchActive ||
 		m.filterRegex != nil || m.filterInput.Value() != "" ||
-		m.searchTerm != "" || m.searchInput.Value() != ""
+		m.searchTerm != "" || m.searchInput.Value() != "" || len(m.highlightTerms) > 0
 }
 
 // View renders the dashboard
@@ -75,7 +75,7 @@ func (m *DashboardModel) renderDashboard() string {
 
 	statusLineHeight := 1
 
-	// No decks: show placeholder (Metrics, Analytics, etc.).
+	// No decks: show placeholder (Analytics, etc.).
 	if len(m.decks) == 0 {
 		placeholderHeight := m.height - statusLineHeight
 		placeholder := renderEmptyPagePlaceholder(m.currentPageTitle(), contentWidth, placeholderHeight)
@@ -76,20 +76,19 @@ func TestPageSwitch_LoadsCorrectViews(t *testing.T) {
 		t.Skip("need at least two pages")
 	}
 
-	// Logs page should have 2 views (Base + List)
+	// Logs page should have 5 views (Base + Patterns + Attributes + List + Custom)
 	logsPage := m.pages[0]
-	if got := len(logsPage.Views); got != 2 {
-		t.Fatalf("logs page views = %d, want 2", got)
+	if got := len(logsPage.Views); got != 5 {
+		t.Fatalf("logs page views = %d, want 5", got)
 	}
 
-	// Switch to Metrics page (placeholder with 1 view, no decks)
+	// Switch to Metrics page (1 view)
 	m.activatePage(1)
 	if got := m.currentPageTitle(); got != "Metrics" {
 		t.Fatalf("page title = %q, want Metrics", got)
 	}
-	// Metrics placeholder has no decks
-	if got := len(m.decks); got != 0 {
-		t.Fatalf("metrics decks = %d, want 0", got)
+	if got := len(m.decks); got != 6 {
+		t.Fatalf("metrics decks = %d, want 6", got)
 	}
 
 	// Switch back to Logs
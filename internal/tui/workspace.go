@@ -0,0 +1,377 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+// WorkspaceVersion is the snapshot file format written by SaveWorkspace.
+const WorkspaceVersion = 1
+
+// workspaceHistoryLimit bounds the query history kept in a workspace.
+const workspaceHistoryLimit = 50
+
+// Query history kinds.
+const (
+	HistoryFilter = "filter"
+	HistorySearch = "search"
+	HistorySQL    = "sql"
+)
+
+// Workspace is a saved investigation state that can be handed to another
+// user and loaded into their TUI.
+type Workspace struct {
+	Version     int                 `json:"version"`
+	Name        string              `json:"name"`
+	SavedAt     time.Time           `json:"saved_at"`
+	SavedBy     string              `json:"saved_by,omitempty"`
+	TimeRange   WorkspaceTimeRange  `json:"time_range,omitzero"`
+	App         string              `json:"app,omitempty"`
+	Page        string              `json:"page,omitempty"`
+	View        string              `json:"view,omitempty"`
+	Filter      string              `json:"filter,omitempty"`
+	Facets      map[string]string   `json:"facets,omitempty"`
+	Search      string              `json:"search,omitempty"`
+	Highlights  []HighlightTerm     `json:"highlights,omitempty"`
+	Severities  map[string]bool     `json:"severities,omitempty"`
+	UseLogTime  bool                `json:"use_log_time,omitempty"`
+	Pinned      []PinnedLog         `json:"pinned,omitempty"`
+	Annotations []Annotation        `json:"annotations,omitempty"`
+	History     []QueryHistoryEntry `json:"history,omitempty"`
+}
+
+// WorkspaceTimeRange is the log-list time window the investigation was
+// looking at, From inclusive and To exclusive.
+type WorkspaceTimeRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// IsZero reports whether no range was recorded.
+func (r WorkspaceTimeRange) IsZero() bool { return r.From.IsZero() && r.To.IsZero() }
+
+// PinnedLog is a log record kept with the workspace so it survives
+// retention on the receiving side.
+type PinnedLog struct {
+	Record   model.LogRecord `json:"record"`
+	PinnedAt time.Time       `json:"pinned_at"`
+}
+
+// Annotation is a free-text note, optionally anchored to a log timestamp.
+type Annotation struct {
+	Created time.Time `json:"created"`
+	Author  string    `json:"author,omitempty"`
+	Anchor  time.Time `json:"anchor,omitzero"`
+	Text    string    `json:"text"`
+}
+
+// QueryHistoryEntry is one applied filter, search, or SQL query.
+type QueryHistoryEntry struct {
+	Kind string    `json:"kind"`
+	Text string    `json:"text"`
+	At   time.Time `json:"at"`
+}
+
+// WorkspaceFile describes a snapshot on disk.
+type WorkspaceFile struct {
+	Name    string
+	Path    string
+	ModTime time.Time
+}
+
+// WorkspaceState holds pins, annotations, and history for the current
+// investigation, and where snapshots and filter presets are saved.
+type WorkspaceState struct {
+	pinned        []PinnedLog
+	annotations   []Annotation
+	queryHistory  []QueryHistoryEntry
+	workspaceName string
+	workspaceDir  string
+
+	filterPresetsPath string // where filter presets are saved; "" disables saving
+}
+
+// workspaceSlug turns a workspace name into a file-system friendly stem.
+func workspaceSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// SaveWorkspace writes ws to dir as <slug>.json and returns the path.
+func SaveWorkspace(dir string, ws *Workspace) (string, error) {
+	slug := workspaceSlug(ws.Name)
+	if slug == "" {
+		return "", errors.New("workspace name is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create workspace dir: %w", err)
+	}
+	ws.Version = WorkspaceVersion
+	data, err := json.MarshalIndent(ws, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode workspace: %w", err)
+	}
+
+	path := filepath.Join(dir, slug+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return "", fmt.Errorf("write workspace: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("write workspace: %w", err)
+	}
+	return path, nil
+}
+
+// LoadWorkspace reads a snapshot file.
+func LoadWorkspace(path string) (*Workspace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var ws Workspace
+	if err := json.Unmarshal(data, &ws); err != nil {
+		return nil, fmt.Errorf("decode workspace %s: %w", path, err)
+	}
+	if ws.Version > WorkspaceVersion {
+		return nil, fmt.Errorf("workspace %s has version %d, this build reads up to %d", path, ws.Version, WorkspaceVersion)
+	}
+	return &ws, nil
+}
+
+// ResolveWorkspacePath maps a -workspace argument to a file. Arguments that
+// are not an existing path are looked up by name in dir.
+func ResolveWorkspacePath(dir, arg string) string {
+	if _, err := os.Stat(arg); err == nil || dir == "" {
+		return arg
+	}
+	return filepath.Join(dir, workspaceSlug(arg)+".json")
+}
+
+// ListWorkspaces returns the snapshots in dir, newest first. A missing
+// directory is not an error.
+func ListWorkspaces(dir string) ([]WorkspaceFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var files []WorkspaceFile
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, WorkspaceFile{
+			Name:    strings.TrimSuffix(e.Name(), ".json"),
+			Path:    filepath.Join(dir, e.Name()),
+			ModTime: info.ModTime(),
+		})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].ModTime.After(files[j].ModTime) })
+	return files, nil
+}
+
+// workspaceAuthor returns the local user name recorded on saves and notes.
+func workspaceAuthor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// SetWorkspaceDir sets where workspace snapshots are saved and listed.
+func (m *DashboardModel) SetWorkspaceDir(dir string) {
+	m.workspaceDir = dir
+}
+
+// sameLogRecord matches records by event ID, falling back to timestamp and message.
+func sameLogRecord(a, b model.LogRecord) bool {
+	if a.EventID != "" && b.EventID != "" {
+		return a.EventID == b.EventID
+	}
+	return a.Timestamp.Equal(b.Timestamp) && a.Message == b.Message
+}
+
+// isPinned reports whether entry is pinned.
+func (m *DashboardModel) isPinned(entry model.LogRecord) bool {
+	for _, p := range m.pinned {
+		if sameLogRecord(p.Record, entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// togglePin pins entry, or unpins it if already pinned. Returns the new state.
+func (m *DashboardModel) togglePin(entry model.LogRecord) bool {
+	for i, p := range m.pinned {
+		if sameLogRecord(p.Record, entry) {
+			m.pinned = append(m.pinned[:i], m.pinned[i+1:]...)
+			return false
+		}
+	}
+	m.pinned = append(m.pinned, PinnedLog{Record: entry, PinnedAt: time.Now()})
+	return true
+}
+
+// toggleSelectedPin pins or unpins the selected log entry.
+func (m *DashboardModel) toggleSelectedPin() {
+	if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+		m.togglePin(m.logEntries[m.selectedLogIndex])
+	}
+}
+
+// addAnnotation records a note anchored to the selected log, if any.
+func (m *DashboardModel) addAnnotation(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	note := Annotation{Created: time.Now(), Author: workspaceAuthor(), Text: text}
+	if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+		note.Anchor = m.getDisplayTimestamp(m.logEntries[m.selectedLogIndex])
+	}
+	m.annotations = append(m.annotations, note)
+}
+
+// recordQuery appends to the query history, skipping immediate repeats.
+func (m *DashboardModel) recordQuery(kind, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	if n := len(m.queryHistory); n > 0 && m.queryHistory[n-1].Kind == kind && m.queryHistory[n-1].Text == text {
+		return
+	}
+	m.queryHistory = append(m.queryHistory, QueryHistoryEntry{Kind: kind, Text: text, At: time.Now()})
+	if over := len(m.queryHistory) - workspaceHistoryLimit; over > 0 {
+		m.queryHistory = append([]QueryHistoryEntry(nil), m.queryHistory[over:]...)
+	}
+}
+
+// captureWorkspace snapshots the current investigation state.
+func (m *DashboardModel) captureWorkspace(name string) *Workspace {
+	ws := &Workspace{
+		Version:     WorkspaceVersion,
+		Name:        name,
+		SavedAt:     time.Now(),
+		SavedBy:     workspaceAuthor(),
+		TimeRange:   m.timeWindow,
+		App:         m.selectedApp,
+		UseLogTime:  m.useLogTime,
+		Pinned:      append([]PinnedLog(nil), m.pinned...),
+		Annotations: append([]Annotation(nil), m.annotations...),
+		History:     append([]QueryHistoryEntry(nil), m.queryHistory...),
+	}
+	if pg := m.activePage(); pg != nil {
+		ws.Page = pg.ID
+	}
+	if vw := m.activeViewInPage(); vw != nil {
+		ws.View = vw.ID
+	}
+	if m.filterRegex != nil {
+		ws.Filter = m.filterInput.Value()
+	}
+	ws.Facets = m.logFacets()
+	ws.Search = m.searchTerm
+	ws.Highlights = slices.Clone(m.highlightTerms)
+	if m.severityFilterActive {
+		ws.Severities = make(map[string]bool, len(m.severityFilter))
+		for level, enabled := range m.severityFilter {
+			ws.Severities[level] = enabled
+		}
+	}
+	return ws
+}
+
+// applyWorkspace restores a snapshot and reloads the log list under its
+// time window and filters. The filter is validated before any state changes
+// so a bad file leaves the dashboard untouched.
+func (m *DashboardModel) applyWorkspace(ws *Workspace) error {
+	filter, err := lintFilterPattern(ws.Filter)
+	if err != nil {
+		return fmt.Errorf("workspace filter: %w", err)
+	}
+
+	m.selectedApp = ws.App
+	for i, pg := range m.pages {
+		if pg.ID != ws.Page {
+			continue
+		}
+		m.activatePage(i)
+		for j, vw := range m.pages[i].Views {
+			if vw.ID == ws.View {
+				m.activateView(j)
+				break
+			}
+		}
+		break
+	}
+
+	m.filterActive = false
+	m.filterInput.Blur()
+	m.filterInput.SetValue(ws.Filter)
+	m.filterRegex = filter
+	m.filterErr = nil
+	m.facets = maps.Clone(ws.Facets)
+	m.searchActive = false
+	m.searchInput.Blur()
+	m.searchInput.SetValue(ws.Search)
+	m.searchTerm = ws.Search
+	m.highlightTerms = slices.Clone(ws.Highlights)
+
+	for level := range m.severityFilter {
+		m.severityFilter[level] = true
+	}
+	for level, enabled := range ws.Severities {
+		m.severityFilter[level] = enabled
+	}
+	m.updateSeverityFilterActiveStatus()
+	m.useLogTime = ws.UseLogTime
+
+	m.pinned = append([]PinnedLog(nil), ws.Pinned...)
+	m.annotations = append([]Annotation(nil), ws.Annotations...)
+	m.queryHistory = append([]QueryHistoryEntry(nil), ws.History...)
+	m.workspaceName = ws.Name
+	m.timeWindow = ws.TimeRange
+	m.reloadLogEntries()
+	return nil
+}
+
+// ApplyWorkspaceFile loads a snapshot from disk into the dashboard.
+func (m *DashboardModel) ApplyWorkspaceFile(path string) error {
+	ws, err := LoadWorkspace(path)
+	if err != nil {
+		return err
+	}
+	return m.applyWorkspace(ws)
+}
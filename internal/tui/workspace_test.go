@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/memstore"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+)
+
+func TestWorkspace_SaveLoadApplyRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	src := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	src.logEntries = []model.LogRecord{
+		{Timestamp: base, Level: "INFO", Message: "started"},
+		{Timestamp: base.Add(time.Minute), Level: "ERROR", Message: "payment failed", Attributes: map[string]string{"order": "42"}},
+	}
+	src.selectedApp = "payments"
+	src.filterInput.SetValue("payment.*failed")
+	src.filterRegex, _ = lintFilterPattern("payment.*failed")
+	src.searchTerm = "failed"
+	src.severityFilter["DEBUG"] = false
+	src.updateSeverityFilterActiveStatus()
+	src.selectedLogIndex = 1
+	src.toggleSelectedPin()
+	src.addAnnotation("card processor timed out")
+	src.recordQuery(HistoryFilter, "payment.*failed")
+	src.timeWindow = WorkspaceTimeRange{From: base, To: base.Add(2 * time.Minute)}
+
+	dir := t.TempDir()
+	path, err := SaveWorkspace(dir, src.captureWorkspace("Checkout Outage #7"))
+	if err != nil {
+		t.Fatalf("SaveWorkspace: %v", err)
+	}
+	if filepath.Base(path) != "checkout-outage-7.json" {
+		t.Fatalf("path = %s, want checkout-outage-7.json", path)
+	}
+
+	// The receiving store holds the failure inside the window and another
+	// one after it; only the first should be listed once the workspace loads.
+	store := memstore.NewStore()
+	if err := store.InsertLogBatch([]*model.LogRecord{
+		{Timestamp: base.Add(time.Minute), Level: "ERROR", App: "payments", Message: "payment failed"},
+		{Timestamp: base.Add(time.Hour), Level: "ERROR", App: "payments", Message: "payment failed again"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	dst := NewDashboardModel(1000, time.Second, false, false, store, "")
+	dst.width, dst.height = 160, 40
+	if err := dst.ApplyWorkspaceFile(ResolveWorkspacePath(dir, "Checkout Outage #7")); err != nil {
+		t.Fatalf("ApplyWorkspaceFile: %v", err)
+	}
+
+	if dst.selectedApp != "payments" {
+		t.Fatalf("selectedApp = %q, want payments", dst.selectedApp)
+	}
+	if dst.filterRegex == nil || dst.filterInput.Value() != "payment.*failed" {
+		t.Fatalf("filter not restored: regex=%v value=%q", dst.filterRegex, dst.filterInput.Value())
+	}
+	if dst.searchTerm != "failed" {
+		t.Fatalf("searchTerm = %q, want failed", dst.searchTerm)
+	}
+	if !dst.severityFilterActive || dst.severityFilter["DEBUG"] {
+		t.Fatal("severity filter not restored")
+	}
+	if len(dst.pinned) != 1 || dst.pinned[0].Record.Attributes["order"] != "42" {
+		t.Fatalf("pinned = %+v, want the ERROR record", dst.pinned)
+	}
+	if len(dst.annotations) != 1 || !dst.annotations[0].Anchor.Equal(base.Add(time.Minute)) {
+		t.Fatalf("annotations = %+v, want one anchored to the selected log", dst.annotations)
+	}
+	if len(dst.queryHistory) != 1 || dst.queryHistory[0].Kind != HistoryFilter {
+		t.Fatalf("queryHistory = %+v", dst.queryHistory)
+	}
+	if !dst.timeWindow.From.Equal(base) || !dst.timeWindow.To.Equal(base.Add(2*time.Minute)) {
+		t.Fatalf("timeWindow = %+v, want the saved query window", dst.timeWindow)
+	}
+	if len(dst.logEntries) != 1 || dst.logEntries[0].Message != "payment failed" {
+		t.Fatalf("logEntries = %+v, want only the failure inside the window", dst.logEntries)
+	}
+	if dst.workspaceName != "Checkout Outage #7" {
+		t.Fatalf("workspaceName = %q", dst.workspaceName)
+	}
+
+	files, err := ListWorkspaces(dir)
+	if err != nil || len(files) != 1 || files[0].Name != "checkout-outage-7" {
+		t.Fatalf("ListWorkspaces = %+v, %v", files, err)
+	}
+}
+
+func TestWorkspace_ApplyRejectsBadFilter(t *testing.T) {
+	t.Parallel()
+
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	m.selectedApp = "keep"
+	if err := m.applyWorkspace(&Workspace{App: "other", Filter: "("}); err == nil {
+		t.Fatal("expected error for invalid filter")
+	}
+	if m.selectedApp != "keep" {
+		t.Fatalf("selectedApp = %q, state should be untouched on error", m.selectedApp)
+	}
+}
+
+func TestWorkspace_LoadRejectsNewerVersion(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "future.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "name": "future"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadWorkspace(path); err == nil {
+		t.Fatal("expected error for newer workspace version")
+	}
+}
+
+func TestWorkspace_TogglePinAndHistory(t *testing.T) {
+	t.Parallel()
+
+	m := NewDashboardModel(1000, time.Second, false, false, nil, "")
+	rec := model.LogRecord{Timestamp: time.Unix(100, 0), Message: "x"}
+	if !m.togglePin(rec) || !m.isPinned(rec) {
+		t.Fatal("first toggle should pin")
+	}
+	if m.togglePin(rec) || m.isPinned(rec) {
+		t.Fatal("second toggle should unpin")
+	}
+
+	for i := 0; i < workspaceHistoryLimit+5; i++ {
+		m.recordQuery(HistorySearch, string(rune('a'+i%26))+"q")
+	}
+	m.recordQuery(HistorySearch, m.queryHistory[len(m.queryHistory)-1].Text)
+	if len(m.queryHistory) != workspaceHistoryLimit {
+		t.Fatalf("history len = %d, want %d", len(m.queryHistory), workspaceHistoryLimit)
+	}
+}
@@ -9,19 +9,42 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
+const (
+	// DefaultCacheTTL is how long a successful check result is reused.
+	DefaultCacheTTL = 24 * time.Hour
+
+	defaultTimeout = 10 * time.Second
+
+	// maxResponseSize bounds the version API response body.
+	maxResponseSize = 1 << 20
+)
+
+// Check states reported by Status.
+const (
+	StateDisabled = "disabled" // check turned off, or no endpoint configured
+	StateOffline  = "offline"  // hard offline mode; no request is ever made
+	StateDev      = "dev"      // development build; nothing to compare against
+	StatePending  = "pending"  // check running or not started yet
+	StateOK       = "ok"       // latest result available (fresh or cached)
+	StateError    = "error"    // last check failed
+)
+
 // UpdateInfo contains information about available updates
 type UpdateInfo struct {
-	UpdateAvailable bool
-	LatestVersion   string
-	CurrentVersion  string
-	ReleaseURL      string
-	Severity        string
+	UpdateAvailable bool   `json:"update_available"`
+	LatestVersion   string `json:"latest_version"`
+	CurrentVersion  string `json:"current_version"`
+	ReleaseURL      string `json:"release_url"`
+	Severity        string `json:"severity"`
 }
 
 // VersionResponse represents the API response from the version check endpoint
@@ -42,21 +65,76 @@ type Latest struct {
 	Assets      map[string]string `json:"assets"`
 }
 
+// Config controls how (and whether) the version check reaches the network.
+type Config struct {
+	// Disabled turns the check off.
+	Disabled bool
+	// Offline is a hard switch: no request is made and no cache is written.
+	Offline bool
+	// Endpoint is the version check API. The check is opt-in: with no
+	// endpoint it never runs.
+	Endpoint string
+	// Proxy is an explicit proxy URL. Empty uses HTTP(S)_PROXY from the environment.
+	Proxy string
+	// CachePath stores the last successful result. Empty disables caching.
+	CachePath string
+	// CacheTTL is how long a cached result is reused; defaults to DefaultCacheTTL.
+	CacheTTL time.Duration
+	// Timeout bounds the request; defaults to 10s.
+	Timeout time.Duration
+	// SendAnonID adds an anonymous machine id to the request. It is only
+	// sent when the user consents to it.
+	SendAnonID bool
+}
+
+// Status is a snapshot of the checker's state, suitable for API responses.
+type Status struct {
+	State     string      `json:"state"`
+	Endpoint  string      `json:"endpoint,omitempty"`
+	CheckedAt time.Time   `json:"checked_at,omitempty"`
+	Cached    bool        `json:"cached"`
+	Error     string      `json:"error,omitempty"`
+	Update    *UpdateInfo `json:"update,omitempty"`
+}
+
 // Checker handles version checking in the background
 type Checker struct {
 	currentVersion string
 	commit         string
-	updateInfo     *UpdateInfo
+	conf           Config
 	checkComplete  chan bool
+
+	mu     sync.Mutex
+	status Status
 }
 
 // NewChecker creates a new version checker
-func NewChecker(currentVersion, commit string) *Checker {
-	return &Checker{
+func NewChecker(currentVersion, commit string, conf ...Config) *Checker {
+	c := &Checker{
 		currentVersion: currentVersion,
 		commit:         commit,
 		checkComplete:  make(chan bool, 1),
 	}
+	if len(conf) > 0 {
+		c.conf = conf[0]
+	}
+	if c.conf.CacheTTL <= 0 {
+		c.conf.CacheTTL = DefaultCacheTTL
+	}
+	if c.conf.Timeout <= 0 {
+		c.conf.Timeout = defaultTimeout
+	}
+
+	c.status = Status{State: StatePending, Endpoint: c.conf.Endpoint}
+	switch {
+	case c.conf.Offline:
+		c.status = Status{State: StateOffline}
+	case c.conf.Disabled || c.conf.Endpoint == "":
+		c.status = Status{State: StateDisabled}
+	case currentVersion == "dev" || currentVersion == "":
+		c.status = Status{State: StateDev}
+	}
+	return c
 }
 
 // CheckInBackground starts a background goroutine to check for updates
@@ -70,69 +148,23 @@ func (c *Checker) CheckInBackground() {
 			}
 		}()
 
-		// Don't check for updates in development builds
-		if c.currentVersion == "dev" || c.currentVersion == "" {
+		if c.Status().State != StatePending {
 			return
 		}
 
-		// Create context with timeout for the HTTP request
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-
-		// Get anonymous ID for caching uniqueness
-		anonID := getOrCreateAnonID()
-
-		// Determine channel (stable for tagged releases, edge for local builds)
-		channel := "stable"
-		if strings.Contains(c.currentVersion, "-dirty") || strings.Contains(c.currentVersion, "-g") {
-			channel = "edge"
-		}
-
-		// Build the API URL with parameters
-		url := fmt.Sprintf(
-			"https://tiny-telemetry-version.example.com/v1/check?app=tiny-telemetry&version=%s&platform=%s&arch=%s&commit=%s&channel=%s&anon_id=%s",
-			c.currentVersion,
-			runtime.GOOS,
-			runtime.GOARCH,
-			c.commit,
-			channel,
-			anonID,
-		)
-
-		// Create HTTP request with context
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return // Silently ignore errors
-		}
-
-		// Make the HTTP request
-		client := &http.Client{Timeout: 10 * time.Second}
-		resp, err := client.Do(req)
-		if err != nil {
-			return // Silently ignore errors
+		if info, checkedAt, ok := c.readCache(); ok {
+			c.setStatus(Status{State: StateOK, Endpoint: c.conf.Endpoint, CheckedAt: checkedAt, Cached: true, Update: info})
+			return
 		}
-		defer resp.Body.Close()
 
-		// Read response body
-		body, err := io.ReadAll(resp.Body)
+		info, err := c.fetch()
 		if err != nil {
-			return // Silently ignore errors
-		}
-
-		// Parse JSON response
-		var versionResp VersionResponse
-		if err := json.Unmarshal(body, &versionResp); err != nil {
-			return // Silently ignore errors
-		}
-
-		// Store update information
-		c.updateInfo = &UpdateInfo{
-			UpdateAvailable: versionResp.UpdateAvailable,
-			LatestVersion:   strings.TrimPrefix(versionResp.Latest.Tag, "v"),
-			CurrentVersion:  c.currentVersion,
-			ReleaseURL:      versionResp.Latest.URL,
-			Severity:        versionResp.Severity,
+			c.setStatus(Status{State: StateError, Endpoint: c.conf.Endpoint, CheckedAt: time.Now(), Error: err.Error()})
+			return
 		}
+		now := time.Now()
+		c.writeCache(info, now)
+		c.setStatus(Status{State: StateOK, Endpoint: c.conf.Endpoint, CheckedAt: now, Update: info})
 	}()
 }
 
@@ -141,17 +173,154 @@ func (c *Checker) CheckInBackground() {
 func (c *Checker) GetUpdateInfo() *UpdateInfo {
 	select {
 	case <-c.checkComplete:
-		// Check completed, return result
-		return c.updateInfo
 	case <-time.After(100 * time.Millisecond):
-		// Don't wait too long, return whatever we have
-		return c.updateInfo
 	}
+	return c.GetUpdateInfoNonBlocking()
 }
 
 // GetUpdateInfoNonBlocking returns the update information without waiting
 func (c *Checker) GetUpdateInfoNonBlocking() *UpdateInfo {
-	return c.updateInfo
+	return c.Status().Update
+}
+
+// Status returns a snapshot of the current check state.
+func (c *Checker) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
+
+func (c *Checker) setStatus(s Status) {
+	c.mu.Lock()
+	c.status = s
+	c.mu.Unlock()
+}
+
+// fetch queries the version endpoint.
+func (c *Checker) fetch() (*UpdateInfo, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.conf.Proxy != "" {
+		proxyURL, err := url.Parse(c.conf.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %q: %w", c.conf.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	client := &http.Client{Timeout: c.conf.Timeout, Transport: transport}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.conf.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.requestURL(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("version endpoint returned HTTP %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	var versionResp VersionResponse
+	if err := json.Unmarshal(body, &versionResp); err != nil {
+		return nil, fmt.Errorf("decode version response: %w", err)
+	}
+	return &UpdateInfo{
+		UpdateAvailable: versionResp.UpdateAvailable,
+		LatestVersion:   strings.TrimPrefix(versionResp.Latest.Tag, "v"),
+		CurrentVersion:  c.currentVersion,
+		ReleaseURL:      versionResp.Latest.URL,
+		Severity:        versionResp.Severity,
+	}, nil
+}
+
+// requestURL builds the check URL with its query parameters.
+func (c *Checker) requestURL() string {
+	// Determine channel (stable for tagged releases, edge for local builds)
+	channel := "stable"
+	if strings.Contains(c.currentVersion, "-dirty") || strings.Contains(c.currentVersion, "-g") {
+		channel = "edge"
+	}
+
+	q := url.Values{}
+	q.Set("app", "tiny-telemetry")
+	q.Set("version", c.currentVersion)
+	q.Set("platform", runtime.GOOS)
+	q.Set("arch", runtime.GOARCH)
+	q.Set("commit", c.commit)
+	q.Set("channel", channel)
+	if c.conf.SendAnonID {
+		q.Set("anon_id", getOrCreateAnonID())
+	}
+
+	sep := "?"
+	if strings.Contains(c.conf.Endpoint, "?") {
+		sep = "&"
+	}
+	return c.conf.Endpoint + sep + q.Encode()
+}
+
+// cacheEntry is the on-disk cache format.
+type cacheEntry struct {
+	Endpoint       string      `json:"endpoint"`
+	CurrentVersion string      `json:"current_version"`
+	CheckedAt      time.Time   `json:"checked_at"`
+	Update         *UpdateInfo `json:"update"`
+}
+
+// readCache returns a cached result for this version and endpoint if it is still fresh.
+func (c *Checker) readCache() (*UpdateInfo, time.Time, bool) {
+	if c.conf.CachePath == "" {
+		return nil, time.Time{}, false
+	}
+	data, err := os.ReadFile(c.conf.CachePath)
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Update == nil {
+		return nil, time.Time{}, false
+	}
+	if entry.Endpoint != c.conf.Endpoint || entry.CurrentVersion != c.currentVersion {
+		return nil, time.Time{}, false
+	}
+	if time.Since(entry.CheckedAt) > c.conf.CacheTTL {
+		return nil, time.Time{}, false
+	}
+	return entry.Update, entry.CheckedAt, true
+}
+
+// writeCache persists a successful result. Failures are ignored; the next
+// start simply checks again.
+func (c *Checker) writeCache(info *UpdateInfo, checkedAt time.Time) {
+	if c.conf.CachePath == "" {
+		return
+	}
+	data, err := json.Marshal(cacheEntry{
+		Endpoint:       c.conf.Endpoint,
+		CurrentVersion: c.currentVersion,
+		CheckedAt:      checkedAt,
+		Update:         info,
+	})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.conf.CachePath), 0o755); err != nil {
+		return
+	}
+	tmp := c.conf.CachePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, c.conf.CachePath)
 }
 
 // getOrCreateAnonID creates a consistent anonymous ID based on machine characteristics
@@ -0,0 +1,141 @@
+package version
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newVersionAPI(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Query().Get("version") != "1.0.0" {
+			http.Error(w, "bad version", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"update_available":true,"severity":"minor","latest":{"tag":"v1.1.0","url":"https://example.com/r/1.1.0"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func waitForCheck(t *testing.T, c *Checker) Status {
+	t.Helper()
+	select {
+	case <-c.checkComplete:
+	case <-time.After(5 * time.Second):
+		t.Fatal("version check did not complete")
+	}
+	return c.Status()
+}
+
+func TestCheckerFetchesAndCaches(t *testing.T) {
+	var hits atomic.Int32
+	api := newVersionAPI(t, &hits)
+	cachePath := filepath.Join(t.TempDir(), "version.json")
+	conf := Config{Endpoint: api.URL, CachePath: cachePath}
+
+	c := NewChecker("1.0.0", "abc", conf)
+	c.CheckInBackground()
+	st := waitForCheck(t, c)
+	if st.State != StateOK || st.Cached {
+		t.Fatalf("status = %+v, want fresh ok", st)
+	}
+	if st.Update == nil || !st.Update.UpdateAvailable || st.Update.LatestVersion != "1.1.0" {
+		t.Fatalf("update = %+v", st.Update)
+	}
+
+	c2 := NewChecker("1.0.0", "abc", conf)
+	c2.CheckInBackground()
+	st = waitForCheck(t, c2)
+	if st.State != StateOK || !st.Cached {
+		t.Fatalf("second status = %+v, want cached ok", st)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("endpoint hits = %d, want 1", got)
+	}
+
+	// A different running version must not reuse the cached answer.
+	c3 := NewChecker("1.0.1", "abc", conf)
+	c3.CheckInBackground()
+	if st := waitForCheck(t, c3); st.State != StateError {
+		t.Fatalf("status for new version = %+v, want error from endpoint", st)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Fatalf("endpoint hits = %d, want 2", got)
+	}
+}
+
+func TestCheckerOfflineMakesNoRequests(t *testing.T) {
+	var hits atomic.Int32
+	api := newVersionAPI(t, &hits)
+
+	for _, conf := range []Config{
+		{Endpoint: api.URL, Offline: true},
+		{Endpoint: api.URL, Disabled: true},
+	} {
+		c := NewChecker("1.0.0", "abc", conf)
+		c.CheckInBackground()
+		st := waitForCheck(t, c)
+		if st.State != StateOffline && st.State != StateDisabled {
+			t.Fatalf("state = %q", st.State)
+		}
+		if c.GetUpdateInfo() != nil {
+			t.Fatal("expected no update info")
+		}
+	}
+	if got := hits.Load(); got != 0 {
+		t.Fatalf("endpoint hits = %d, want 0", got)
+	}
+}
+
+func TestCheckerUsesProxy(t *testing.T) {
+	var hits atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		// Proxied requests carry the absolute target URL.
+		if r.URL.Host != "version.invalid" {
+			http.Error(w, "unexpected host", http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"update_available":false,"latest":{"tag":"v1.0.0"}}`))
+	}))
+	t.Cleanup(proxy.Close)
+
+	c := NewChecker("1.0.0", "abc", Config{Endpoint: "http://version.invalid/v1/check", Proxy: proxy.URL})
+	c.CheckInBackground()
+	st := waitForCheck(t, c)
+	if st.State != StateOK || st.Update == nil || st.Update.UpdateAvailable {
+		t.Fatalf("status = %+v", st)
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("proxy hits = %d, want 1", hits.Load())
+	}
+}
+
+func TestCheckerIsOptIn(t *testing.T) {
+	c := NewChecker("1.0.0", "abc")
+	c.CheckInBackground()
+	if st := waitForCheck(t, c); st.State != StateDisabled {
+		t.Fatalf("state without an endpoint = %q, want %q", st.State, StateDisabled)
+	}
+
+	c = NewChecker("1.0.0", "abc", Config{Endpoint: "http://version.invalid/v1/check"})
+	if u, _ := url.Parse(c.requestURL()); u.Query().Has("anon_id") || u.Query().Get("version") != "1.0.0" {
+		t.Fatalf("request URL %s, want the version and no anon_id without consent", c.requestURL())
+	}
+}
+
+func TestCheckerSkipsDevBuilds(t *testing.T) {
+	c := NewChecker("dev", "", Config{Endpoint: "http://version.invalid/v1/check"})
+	c.CheckInBackground()
+	if st := waitForCheck(t, c); st.State != StateDev {
+		t.Fatalf("state = %q, want %q", st.State, StateDev)
+	}
+}
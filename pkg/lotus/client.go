@@ -0,0 +1,265 @@
+package lotus
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/socketrpc"
+)
+
+// defaultTailInterval is how often Tail polls for new records.
+const defaultTailInterval = time.Second
+
+// Client queries a local server over its Unix socket.
+// It is safe for concurrent use; concurrent calls share one connection and
+// run in parallel on the server.
+type Client struct {
+	rpc *socketrpc.Client
+}
+
+// DefaultSocketPath returns the socket path the server listens on by default.
+func DefaultSocketPath() string {
+	return socketrpc.DefaultSocketPath()
+}
+
+// Dial connects to the server socket at socketPath.
+// An empty path uses DefaultSocketPath.
+func Dial(socketPath string) (*Client, error) {
+	if socketPath == "" {
+		socketPath = DefaultSocketPath()
+	}
+	rpc, err := socketrpc.Dial(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rpc: rpc}, nil
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.rpc.Close()
+}
+
+// TotalLogCount returns the number of stored log records.
+func (c *Client) TotalLogCount(opts QueryOpts) (int64, error) {
+	return c.rpc.TotalLogCount(opts)
+}
+
+// TotalLogBytes returns the total size of stored messages in bytes.
+func (c *Client) TotalLogBytes(opts QueryOpts) (int64, error) {
+	return c.rpc.TotalLogBytes(opts)
+}
+
+// SeverityCounts returns record counts keyed by severity.
+func (c *Client) SeverityCounts(opts QueryOpts) (map[string]int64, error) {
+	return c.rpc.SeverityCounts(opts)
+}
+
+// SeverityCountsByMinute returns per-minute severity counts.
+func (c *Client) SeverityCountsByMinute(opts QueryOpts) ([]MinuteCounts, error) {
+	return c.rpc.SeverityCountsByMinute(opts)
+}
+
+// TopWords returns the most frequent message words.
+func (c *Client) TopWords(limit int, opts QueryOpts) ([]WordCount, error) {
+	return c.rpc.TopWords(limit, opts)
+}
+
+// TopAttributes returns the most frequent attribute key/value pairs.
+func (c *Client) TopAttributes(limit int, opts QueryOpts) ([]AttributeStat, error) {
+	return c.rpc.TopAttributes(limit, opts)
+}
+
+// TopAttributeKeys returns the most frequent attribute keys.
+func (c *Client) TopAttributeKeys(limit int, opts QueryOpts) ([]AttributeKeyStat, error) {
+	return c.rpc.TopAttributeKeys(limit, opts)
+}
+
+// AttributeKeyValues returns value counts for one attribute key.
+func (c *Client) AttributeKeyValues(key string, limit int, opts QueryOpts) (map[string]int64, error) {
+	return c.rpc.AttributeKeyValues(key, limit, opts)
+}
+
+// DistinctAttributeValues returns the most frequent values of one attribute
+// key starting with prefix (case-insensitive), for autocompletion.
+func (c *Client) DistinctAttributeValues(key, prefix string, limit int, opts QueryOpts) ([]DimensionCount, error) {
+	return c.rpc.DistinctAttributeValues(key, prefix, limit, opts)
+}
+
+// TopHosts returns the hosts with the most records.
+func (c *Client) TopHosts(limit int, opts QueryOpts) ([]DimensionCount, error) {
+	return c.rpc.TopHosts(limit, opts)
+}
+
+// TopServices returns the services with the most records.
+func (c *Client) TopServices(limit int, opts QueryOpts) ([]DimensionCount, error) {
+	return c.rpc.TopServices(limit, opts)
+}
+
+// TopServicesBySeverity returns the services with the most records at severity.
+func (c *Client) TopServicesBySeverity(severity string, limit int, opts QueryOpts) ([]DimensionCount, error) {
+	return c.rpc.TopServicesBySeverity(severity, limit, opts)
+}
+
+// RateByDimension returns per-bucket record counts for each value of
+// dimension (service, host, app, or level) over the last window, optionally
+// limited to severity levels.
+func (c *Client) RateByDimension(dimension string, window, step time.Duration, levels []string, opts QueryOpts) ([]DimensionRate, error) {
+	return c.rpc.RateByDimension(dimension, window, step, levels, opts)
+}
+
+// ListApps returns all known app names.
+func (c *Client) ListApps() ([]string, error) {
+	return c.rpc.ListApps()
+}
+
+// RecentLogs returns up to limit of the newest records matching the filter,
+// oldest first. Empty filter fields match everything.
+func (c *Client) RecentLogs(limit int, filter TailFilter) ([]LogRecord, error) {
+	opts := QueryOpts{App: filter.App, From: filter.From, To: filter.To}
+	return c.rpc.RecentLogsFiltered(limit, opts, filter.Levels, filter.Facets, filter.MessagePattern)
+}
+
+// SearchLogs returns records whose message contains term (case-insensitive).
+func (c *Client) SearchLogs(term string, limit int, opts QueryOpts) ([]LogRecord, error) {
+	return c.rpc.SearchLogs(term, limit, opts)
+}
+
+// TailFilter selects which records RecentLogs and Tail return.
+type TailFilter struct {
+	App            string            // empty = all apps
+	Levels         []string          // empty = all severities
+	Facets         map[string]string // service, host, or attribute key = value; empty = all
+	MessagePattern string            // regular expression; empty = all messages
+	From           time.Time         // inclusive lower bound on timestamp; zero = unbounded
+	To             time.Time         // exclusive upper bound on timestamp; zero = unbounded
+}
+
+// TailOptions configures Tail.
+type TailOptions struct {
+	Filter   TailFilter
+	Interval time.Duration // poll interval; defaults to 1s
+	Limit    int           // max records fetched per poll, or buffered when streaming; defaults to 500
+}
+
+// errTailClosed is returned by Tail when the server ends the subscription.
+var errTailClosed = errors.New("lotus: tail closed by the server")
+
+// Tail calls fn for every record stored after Tail starts, in arrival order,
+// until ctx is cancelled or the tail fails. A server that pushes records to
+// subscribers streams them, so every record arrives, repeated lines
+// included. Older servers, and filters with a From or To bound, are polled
+// at opts.Interval instead: bursts larger than opts.Limit per interval are
+// truncated, and identical records sharing a timestamp are emitted once.
+func (c *Client) Tail(ctx context.Context, opts TailOptions, fn func(LogRecord)) error {
+	if opts.Filter.From.IsZero() && opts.Filter.To.IsZero() && c.rpc.Supports("Subscribe") {
+		err := c.streamTail(ctx, opts, fn)
+		if err == nil || c.rpc.Supports("Subscribe") {
+			return err
+		}
+		// The server turned out not to serve Subscribe; poll it instead.
+	}
+	return c.pollTail(ctx, opts, fn)
+}
+
+// streamTail delivers the records the server pushes to a subscription.
+func (c *Client) streamTail(ctx context.Context, opts TailOptions, fn func(LogRecord)) error {
+	f := opts.Filter
+	tail, err := c.rpc.TailLogs(model.TailFilter{
+		App:            f.App,
+		SeverityLevels: f.Levels,
+		Facets:         f.Facets,
+		MessagePattern: f.MessagePattern,
+	}, opts.Limit)
+	if err != nil {
+		return err
+	}
+	defer tail.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case r, ok := <-tail.Records():
+			if !ok {
+				return errTailClosed
+			}
+			fn(r)
+		}
+	}
+}
+
+// pollTail polls RecentLogs for records newer than the last ones emitted.
+func (c *Client) pollTail(ctx context.Context, opts TailOptions, fn func(LogRecord)) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultTailInterval
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 500
+	}
+
+	// Seed the cursor with what is already stored so only new records are emitted.
+	initial, err := c.RecentLogs(limit, opts.Filter)
+	if err != nil {
+		return err
+	}
+	var cur tailCursor
+	cur.advance(initial)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		records, err := c.RecentLogs(limit, opts.Filter)
+		if err != nil {
+			return err
+		}
+		for _, r := range cur.advance(records) {
+			fn(r)
+		}
+	}
+}
+
+// tailCursor tracks the newest timestamp already emitted. Records sharing
+// that timestamp are remembered so they are not emitted twice.
+type tailCursor struct {
+	last   time.Time
+	atLast map[string]struct{}
+}
+
+// advance returns the records in batch (oldest first) not seen before.
+func (t *tailCursor) advance(batch []LogRecord) []LogRecord {
+	var fresh []LogRecord
+	for _, r := range batch {
+		key := tailKey(r)
+		switch {
+		case r.Timestamp.Before(t.last):
+			continue
+		case r.Timestamp.Equal(t.last):
+			if _, seen := t.atLast[key]; seen {
+				continue
+			}
+		default:
+			t.last = r.Timestamp
+			t.atLast = make(map[string]struct{})
+		}
+		if t.atLast == nil {
+			t.atLast = make(map[string]struct{})
+		}
+		t.atLast[key] = struct{}{}
+		fresh = append(fresh, r)
+	}
+	return fresh
+}
+
+func tailKey(r LogRecord) string {
+	return r.App + "\x00" + r.Level + "\x00" + r.Message + "\x00" + r.RawLine
+}
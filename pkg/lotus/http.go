@@ -0,0 +1,306 @@
+package lotus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPClient talks to the server's HTTP API.
+type HTTPClient struct {
+	baseURL string
+	http    *http.Client
+	apiKey  string
+}
+
+// HTTPConfig holds optional HTTPClient settings.
+type HTTPConfig struct {
+	HTTPClient *http.Client // defaults to a client with a 60s timeout
+	APIKey     string       // sent as a bearer token when the server requires keys
+}
+
+// NewHTTPClient creates a client for the HTTP API at baseURL,
+// e.g. "http://127.0.0.1:5000".
+func NewHTTPClient(baseURL string, conf ...HTTPConfig) *HTTPClient {
+	c := &HTTPClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    &http.Client{Timeout: 60 * time.Second},
+	}
+	if len(conf) > 0 {
+		if conf[0].HTTPClient != nil {
+			c.http = conf[0].HTTPClient
+		}
+		c.apiKey = conf[0].APIKey
+	}
+	return c
+}
+
+// APIError is returned when the server answers with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("lotus: HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+// Health is the /api/health response.
+type Health struct {
+	Status   string `json:"status"`
+	Uptime   string `json:"uptime"`
+	LogCount int64  `json:"log_count"`
+}
+
+// Health reports server status.
+func (c *HTTPClient) Health(ctx context.Context) (*Health, error) {
+	var out Health
+	if err := c.do(ctx, http.MethodGet, "/api/health", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SchemaColumn is one column of a table in the /api/schema response.
+type SchemaColumn struct {
+	Column string `json:"column"`
+	Type   string `json:"type"`
+}
+
+// Schema is the /api/schema response.
+type Schema struct {
+	Description string                    `json:"description"`
+	Tables      map[string][]SchemaColumn `json:"tables"`
+	RowCounts   map[string]int64          `json:"row_counts"`
+}
+
+// Schema returns table layouts and row counts.
+func (c *HTTPClient) Schema(ctx context.Context) (*Schema, error) {
+	var out Schema
+	if err := c.do(ctx, http.MethodGet, "/api/schema", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// QueryRequest is an ad-hoc read-only SQL query.
+type QueryRequest struct {
+	SQL string `json:"sql"`
+	// Params are bound to the query's ? placeholders in order: strings,
+	// numbers, booleans, or nil.
+	Params []any `json:"params,omitempty"`
+	// Force skips the server's scan-cost guardrail.
+	Force bool `json:"force,omitempty"`
+	// MaxRows and Timeout lower the server's row cap and query timeout for
+	// this query; zero keeps them.
+	MaxRows int           `json:"max_rows,omitempty"`
+	Timeout time.Duration `json:"-"`
+}
+
+// QueryResult is the /api/query response.
+type QueryResult struct {
+	Columns  []string                 `json:"columns"`
+	Rows     []map[string]interface{} `json:"rows"`
+	RowCount int                      `json:"row_count"`
+	// Truncated is set when the result stopped at MaxRows rows.
+	Truncated bool `json:"truncated"`
+	MaxRows   int  `json:"max_rows"`
+}
+
+// Query runs a read-only SELECT/WITH query.
+func (c *HTTPClient) Query(ctx context.Context, req QueryRequest) (*QueryResult, error) {
+	var out QueryResult
+	if err := c.do(ctx, http.MethodPost, "/api/query", queryBody(req), &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// QueryStream runs a read-only SELECT/WITH query like Query, but has the
+// server stream the rows as it reads them and hands each to fn, its values
+// in columns order, so a large result is never held whole on either side.
+// The result it returns has no Rows. fn returning an error stops the query.
+func (c *HTTPClient) QueryStream(ctx context.Context, req QueryRequest, fn func(columns []string, row []any) error) (*QueryResult, error) {
+	data, err := json.Marshal(queryBody(req))
+	if err != nil {
+		return nil, fmt.Errorf("lotus: marshal request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/query", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("lotus: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/x-ndjson")
+	c.authorize(httpReq)
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("lotus: POST /api/query: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
+	// A {"columns"} line, one array per row, then a summary or an
+	// {"error"} line.
+	dec := json.NewDecoder(resp.Body)
+	var out QueryResult
+	if err := dec.Decode(&out); err != nil {
+		return nil, fmt.Errorf("lotus: decode response: %w", err)
+	}
+	for {
+		var line json.RawMessage
+		if err := dec.Decode(&line); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("lotus: decode response: %w", err)
+		}
+		if line[0] == '[' {
+			var row []any
+			if err := json.Unmarshal(line, &row); err != nil {
+				return nil, fmt.Errorf("lotus: decode response: %w", err)
+			}
+			if err := fn(out.Columns, row); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var summary struct {
+			QueryResult
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(line, &summary); err != nil {
+			return nil, fmt.Errorf("lotus: decode response: %w", err)
+		}
+		if summary.Error != "" {
+			return nil, fmt.Errorf("lotus: query failed: %s", summary.Error)
+		}
+		out.RowCount, out.Truncated, out.MaxRows = summary.RowCount, summary.Truncated, summary.MaxRows
+		return &out, nil
+	}
+}
+
+// queryBody is the /api/query request body of req.
+func queryBody(req QueryRequest) any {
+	body := struct {
+		QueryRequest
+		Timeout string `json:"timeout,omitempty"`
+	}{QueryRequest: req}
+	if req.Timeout > 0 {
+		body.Timeout = req.Timeout.String()
+	}
+	return body
+}
+
+// ExportParquet writes the logs matching opts to w as a Parquet file and
+// returns the number of rows exported. It needs the DuckDB storage backend.
+func (c *HTTPClient) ExportParquet(ctx context.Context, opts QueryOpts, w io.Writer) (int64, error) {
+	return c.Export(ctx, "parquet", opts, w)
+}
+
+// Export writes the logs matching opts to w in format (parquet, csv, or
+// ndjson) and returns the number of rows exported. An export cut short by
+// the server's row or time limit is not an error.
+func (c *HTTPClient) Export(ctx context.Context, format string, opts QueryOpts, w io.Writer) (int64, error) {
+	query := url.Values{"format": {format}}
+	if opts.App != "" {
+		query.Set("app", opts.App)
+	}
+	if !opts.From.IsZero() {
+		query.Set("from", opts.From.Format(time.RFC3339Nano))
+	}
+	if !opts.To.IsZero() {
+		query.Set("to", opts.To.Format(time.RFC3339Nano))
+	}
+	path := "/api/export?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return 0, fmt.Errorf("lotus: build request: %w", err)
+	}
+	c.authorize(req)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("lotus: GET /api/export: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return 0, err
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return 0, fmt.Errorf("lotus: read export: %w", err)
+	}
+	// Streamed formats send the count as a trailer, once the body is read.
+	count := resp.Header.Get("X-Row-Count")
+	if count == "" {
+		count = resp.Trailer.Get("X-Row-Count")
+	}
+	rows, _ := strconv.ParseInt(count, 10, 64)
+	return rows, nil
+}
+
+func (c *HTTPClient) do(ctx context.Context, method, path string, body, dest interface{}) error {
+	var reqBody io.Reader = http.NoBody
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("lotus: marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("lotus: build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.authorize(req)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("lotus: %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("lotus: decode response: %w", err)
+	}
+	return nil
+}
+
+// authorize adds the API key to req, if the client has one.
+func (c *HTTPClient) authorize(req *http.Request) {
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+}
+
+// checkStatus returns an *APIError for a non-2xx response.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+	var errBody struct {
+		Error string `json:"error"`
+	}
+	json.NewDecoder(resp.Body).Decode(&errBody)
+	if errBody.Error == "" {
+		errBody.Error = http.StatusText(resp.StatusCode)
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: errBody.Error}
+}
@@ -0,0 +1,25 @@
+// Package lotus is a Go SDK for shipping logs to and querying a running
+// tiny-telemetry server.
+//
+// It wraps the three public surfaces so callers do not re-implement their
+// framing:
+//
+//   - Client: typed queries and tailing over the local Unix socket JSON-RPC API.
+//   - HTTPClient: schema, health, and ad-hoc SQL over the HTTP API.
+//   - Writer: batched log ingestion over OTLP/gRPC.
+package lotus
+
+import "github.com/tinytelemetry/tiny-telemetry/internal/model"
+
+// Types shared with the server. They are aliases so values returned by the
+// SDK can be passed straight back into it.
+type (
+	LogRecord        = model.LogRecord
+	QueryOpts        = model.QueryOpts
+	WordCount        = model.WordCount
+	AttributeStat    = model.AttributeStat
+	AttributeKeyStat = model.AttributeKeyStat
+	DimensionCount   = model.DimensionCount
+	MinuteCounts     = model.MinuteCounts
+	DimensionRate    = model.DimensionRate
+)
@@ -0,0 +1,275 @@
+package lotus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
+	"github.com/tinytelemetry/tiny-telemetry/internal/httpserver"
+	"github.com/tinytelemetry/tiny-telemetry/internal/ingest"
+	"github.com/tinytelemetry/tiny-telemetry/internal/model"
+	"github.com/tinytelemetry/tiny-telemetry/internal/otlpreceiver"
+	"github.com/tinytelemetry/tiny-telemetry/internal/socketrpc"
+)
+
+func newStore(t *testing.T) *duckdb.Store {
+	t.Helper()
+	store, err := duckdb.NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	now := time.Now()
+	err = store.InsertLogBatch([]*model.LogRecord{
+		{Timestamp: now.Add(-time.Second), Level: "INFO", Message: "checkout started", App: "shop"},
+		{Timestamp: now, Level: "ERROR", Message: "payment failed", App: "shop"},
+	})
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	return store
+}
+
+func TestClient_SocketQueries(t *testing.T) {
+	store := newStore(t)
+	sockPath := filepath.Join(t.TempDir(), "sdk.sock")
+	srv := socketrpc.NewServer(sockPath, store)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("start socket server: %v", err)
+	}
+	defer srv.Stop()
+
+	client, err := Dial(sockPath)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer client.Close()
+
+	count, err := client.TotalLogCount(QueryOpts{App: "shop"})
+	if err != nil {
+		t.Fatalf("TotalLogCount: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("TotalLogCount = %d, want 2", count)
+	}
+
+	logs, err := client.RecentLogs(10, TailFilter{Levels: []string{"ERROR"}})
+	if err != nil {
+		t.Fatalf("RecentLogs: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Message != "payment failed" {
+		t.Fatalf("unexpected RecentLogs result: %+v", logs)
+	}
+}
+
+func TestClient_TailStreamsRepeatedLines(t *testing.T) {
+	store := newStore(t)
+	sockPath := filepath.Join(t.TempDir(), "tail.sock")
+	srv := socketrpc.NewServer(sockPath, store)
+	sink := ingest.NewTailSink(nil)
+	srv.SetLogTailer(sink)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("start socket server: %v", err)
+	}
+	defer srv.Stop()
+
+	client, err := Dial(sockPath)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	got := make(chan LogRecord, 4)
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Tail(ctx, TailOptions{Filter: TailFilter{Levels: []string{"ERROR"}}}, func(r LogRecord) { got <- r })
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for sink.Tails() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Tail did not subscribe")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	at := time.Now()
+	for _, level := range []string{"ERROR", "INFO", "ERROR"} {
+		sink.Add(&LogRecord{Timestamp: at, Level: level, Message: "retrying"})
+	}
+	for i := range 2 {
+		select {
+		case r := <-got:
+			if r.Message != "retrying" || r.Level != "ERROR" {
+				t.Fatalf("record %d = %+v", i, r)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got %d of the 2 repeated records", i)
+		}
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Tail = %v, want context.Canceled", err)
+	}
+}
+
+func TestTailCursor_SkipsSeenRecords(t *testing.T) {
+	t.Parallel()
+
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var cur tailCursor
+	cur.advance([]LogRecord{{Timestamp: t0, Message: "a"}})
+
+	fresh := cur.advance([]LogRecord{
+		{Timestamp: t0, Message: "a"},
+		{Timestamp: t0, Message: "b"},
+		{Timestamp: t0.Add(time.Second), Message: "c"},
+	})
+	if len(fresh) != 2 || fresh[0].Message != "b" || fresh[1].Message != "c" {
+		t.Fatalf("unexpected fresh records: %+v", fresh)
+	}
+	if again := cur.advance([]LogRecord{{Timestamp: t0.Add(time.Second), Message: "c"}}); len(again) != 0 {
+		t.Fatalf("expected no fresh records, got %+v", again)
+	}
+}
+
+func TestHTTPClient_QueryAndGuardrail(t *testing.T) {
+	store := newStore(t)
+	srv := httpserver.NewServer("127.0.0.1:0", store)
+	srv.SetMaxScanRows(1)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("start http server: %v", err)
+	}
+	defer srv.Stop()
+
+	client := NewHTTPClient("http://" + srv.Addr())
+	ctx := context.Background()
+
+	health, err := client.Health(ctx)
+	if err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if health.Status != "ok" || health.LogCount != 2 {
+		t.Fatalf("unexpected health: %+v", health)
+	}
+
+	_, err = client.Query(ctx, QueryRequest{SQL: "SELECT message FROM logs"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected guardrail APIError, got %v", err)
+	}
+
+	result, err := client.Query(ctx, QueryRequest{SQL: "SELECT message FROM logs", Force: true})
+	if err != nil {
+		t.Fatalf("forced Query: %v", err)
+	}
+	if result.RowCount != 2 {
+		t.Fatalf("RowCount = %d, want 2", result.RowCount)
+	}
+
+	result, err = client.Query(ctx, QueryRequest{SQL: "SELECT message FROM logs WHERE app = ? LIMIT ?", Params: []any{"shop", 1}, Force: true})
+	if err != nil {
+		t.Fatalf("Query with params: %v", err)
+	}
+	if result.RowCount != 1 {
+		t.Fatalf("RowCount with params = %d, want 1", result.RowCount)
+	}
+
+	result, err = client.Query(ctx, QueryRequest{SQL: "SELECT message FROM logs", Force: true, MaxRows: 1, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Query with limits: %v", err)
+	}
+	if result.RowCount != 1 || !result.Truncated {
+		t.Fatalf("limited Query = %d rows, truncated %v; want 1 row, truncated", result.RowCount, result.Truncated)
+	}
+
+	var streamed []string
+	result, err = client.QueryStream(ctx, QueryRequest{SQL: "SELECT message, app FROM logs ORDER BY timestamp", Force: true, MaxRows: 1},
+		func(columns []string, row []any) error {
+			if len(columns) != 2 || columns[0] != "message" {
+				t.Errorf("columns = %v", columns)
+			}
+			streamed = append(streamed, row[0].(string))
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("QueryStream: %v", err)
+	}
+	if len(streamed) != 1 || streamed[0] != "checkout started" || result.RowCount != 1 || !result.Truncated {
+		t.Fatalf("QueryStream = %v, %+v; want the first row, truncated", streamed, result)
+	}
+
+	var parquet bytes.Buffer
+	rows, err := client.ExportParquet(ctx, QueryOpts{App: "shop"}, &parquet)
+	if err != nil {
+		t.Fatalf("ExportParquet: %v", err)
+	}
+	if rows != 2 || !bytes.HasPrefix(parquet.Bytes(), []byte("PAR1")) {
+		t.Fatalf("ExportParquet rows = %d, %d bytes", rows, parquet.Len())
+	}
+}
+
+type recordingSink struct {
+	mu      sync.Mutex
+	records []*model.LogRecord
+}
+
+func (s *recordingSink) Add(r *model.LogRecord) {
+	s.mu.Lock()
+	s.records = append(s.records, r)
+	s.mu.Unlock()
+}
+
+func (s *recordingSink) snapshot() []*model.LogRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*model.LogRecord(nil), s.records...)
+}
+
+func TestWriter_ShipsOverOTLP(t *testing.T) {
+	sink := &recordingSink{}
+	receiver := otlpreceiver.NewServer("127.0.0.1:0", sink)
+	if err := receiver.Start(); err != nil {
+		t.Fatalf("start receiver: %v", err)
+	}
+	defer receiver.Stop()
+
+	w, err := NewWriter(receiver.Addr(), WriterConfig{App: "billing", Hostname: "web-1", FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	if err := w.Log("warn", "disk almost full", map[string]string{"disk": "sda"}); err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+	if _, err := w.Write([]byte("ERROR first\nsecond\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	records := sink.snapshot()
+	if len(records) != 3 {
+		t.Fatalf("records = %d, want 3", len(records))
+	}
+	if records[0].Level != "WARN" || records[0].App != "billing" || records[0].Attributes["disk"] != "sda" {
+		t.Fatalf("unexpected first record: %+v", records[0])
+	}
+	if records[1].Level != "ERROR" || records[2].Level != "INFO" {
+		t.Fatalf("levels = %q, %q; want ERROR, INFO", records[1].Level, records[2].Level)
+	}
+	if records[0].Hostname != "web-1" {
+		t.Fatalf("Hostname = %q, want web-1", records[0].Hostname)
+	}
+
+	if err := w.Log("info", "late", nil); !errors.Is(err, ErrWriterClosed) {
+		t.Fatalf("Log after Close = %v, want ErrWriterClosed", err)
+	}
+}
@@ -0,0 +1,263 @@
+package lotus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/tinytelemetry/tiny-telemetry/internal/logparse"
+)
+
+const (
+	defaultWriterBatchSize     = 500
+	defaultWriterFlushInterval = time.Second
+	defaultWriterTimeout       = 10 * time.Second
+)
+
+// ErrWriterClosed is returned when writing to a closed Writer.
+var ErrWriterClosed = errors.New("lotus: writer closed")
+
+// WriterConfig holds optional Writer settings.
+type WriterConfig struct {
+	App           string            // sent as the "app" resource attribute
+	Service       string            // sent as "service.name"
+	Hostname      string            // sent as "host.name"
+	Attributes    map[string]string // extra resource attributes on every record
+	BatchSize     int               // records per export; defaults to 500
+	FlushInterval time.Duration     // max time a record waits; defaults to 1s
+	Timeout       time.Duration     // per-export timeout; defaults to 10s
+}
+
+// Entry is one log record to ship.
+type Entry struct {
+	Time       time.Time // zero = now
+	Level      string    // empty = detected from Message, else INFO
+	Message    string
+	Attributes map[string]string
+}
+
+// Writer ships log entries to the server's OTLP/gRPC receiver in batches.
+// It is safe for concurrent use. Background flush errors are returned by the
+// next call to Flush or Close; entries from a failed export are dropped.
+type Writer struct {
+	conn     *grpc.ClientConn
+	client   collogspb.LogsServiceClient
+	resource *resourcepb.Resource
+	conf     WriterConfig
+
+	mu      sync.Mutex
+	pending []*logspb.LogRecord
+	lastErr error
+	closed  bool
+
+	flushMu sync.Mutex // serializes exports
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// NewWriter connects to the OTLP/gRPC receiver at addr, e.g. "127.0.0.1:4317".
+func NewWriter(addr string, conf ...WriterConfig) (*Writer, error) {
+	var c WriterConfig
+	if len(conf) > 0 {
+		c = conf[0]
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultWriterBatchSize
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = defaultWriterFlushInterval
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultWriterTimeout
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("lotus: dial %s: %w", addr, err)
+	}
+
+	w := &Writer{
+		conn:     conn,
+		client:   collogspb.NewLogsServiceClient(conn),
+		resource: buildResource(c),
+		conf:     c,
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go w.flushLoop()
+	return w, nil
+}
+
+// Log queues a single entry.
+func (w *Writer) Log(level, message string, attributes map[string]string) error {
+	return w.Send(Entry{Level: level, Message: message, Attributes: attributes})
+}
+
+// Send queues entries, exporting immediately once a full batch is pending.
+func (w *Writer) Send(entries ...Entry) error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrWriterClosed
+	}
+	for _, e := range entries {
+		w.pending = append(w.pending, toOTLP(e))
+	}
+	full := len(w.pending) >= w.conf.BatchSize
+	w.mu.Unlock()
+
+	if full {
+		return w.Flush(context.Background())
+	}
+	return nil
+}
+
+// Write implements io.Writer: each non-empty line of p becomes one entry
+// whose severity is detected from its text.
+func (w *Writer) Write(p []byte) (int, error) {
+	var entries []Entry
+	for _, line := range strings.Split(string(p), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+		entries = append(entries, Entry{Message: line})
+	}
+	if err := w.Send(entries...); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush exports all pending entries.
+func (w *Writer) Flush(ctx context.Context) error {
+	w.flushMu.Lock()
+	defer w.flushMu.Unlock()
+
+	w.mu.Lock()
+	batch := w.pending
+	w.pending = nil
+	err := w.lastErr
+	w.lastErr = nil
+	w.mu.Unlock()
+
+	if len(batch) > 0 {
+		if exportErr := w.export(ctx, batch); exportErr != nil {
+			err = exportErr
+		}
+	}
+	return err
+}
+
+// Close flushes pending entries and closes the connection.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	close(w.quit)
+	<-w.done
+
+	err := w.Flush(context.Background())
+	if closeErr := w.conn.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (w *Writer) flushLoop() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.conf.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.quit:
+			return
+		case <-ticker.C:
+			if err := w.Flush(context.Background()); err != nil {
+				w.mu.Lock()
+				w.lastErr = err
+				w.mu.Unlock()
+			}
+		}
+	}
+}
+
+func (w *Writer) export(ctx context.Context, batch []*logspb.LogRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, w.conf.Timeout)
+	defer cancel()
+
+	_, err := w.client.Export(ctx, &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource:  w.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{LogRecords: batch}},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("lotus: export %d records: %w", len(batch), err)
+	}
+	return nil
+}
+
+func buildResource(c WriterConfig) *resourcepb.Resource {
+	attrs := make(map[string]string, len(c.Attributes)+3)
+	for k, v := range c.Attributes {
+		attrs[k] = v
+	}
+	if c.App != "" {
+		attrs["app"] = c.App
+	}
+	if c.Service != "" {
+		attrs["service.name"] = c.Service
+	}
+	if c.Hostname != "" {
+		attrs["host.name"] = c.Hostname
+	}
+	return &resourcepb.Resource{Attributes: keyValues(attrs)}
+}
+
+func toOTLP(e Entry) *logspb.LogRecord {
+	ts := e.Time
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	level := e.Level
+	if level == "" {
+		level = logparse.ExtractSeverityFromText(e.Message)
+	}
+	return &logspb.LogRecord{
+		TimeUnixNano: uint64(ts.UnixNano()),
+		SeverityText: logparse.NormalizeSeverity(level),
+		Body:         stringValue(e.Message),
+		Attributes:   keyValues(e.Attributes),
+	}
+}
+
+func keyValues(attrs map[string]string) []*commonpb.KeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]*commonpb.KeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		kvs = append(kvs, &commonpb.KeyValue{Key: k, Value: stringValue(v)})
+	}
+	return kvs
+}
+
+func stringValue(s string) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: s}}
+}
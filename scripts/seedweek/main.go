@@ -3,7 +3,6 @@
 package main
 
 import (
-	"database/sql"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -11,7 +10,7 @@ import (
 	"path/filepath"
 	"time"
 
-	_ "github.com/duckdb/duckdb-go/v2"
+	"github.com/tinytelemetry/tiny-telemetry/internal/duckdb"
 )
 
 var services = []string{"api-gateway", "user-service", "payment-service", "auth-service", "notification-service"}
@@ -121,12 +120,13 @@ func main() {
 	home, _ := os.UserHomeDir()
 	dbPath := filepath.Join(home, ".local/share/tiny-telemetry/tiny-telemetry.duckdb")
 
-	db, err := sql.Open("duckdb", dbPath)
+	store, err := duckdb.NewStore(dbPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "open db: %v\n", err)
 		os.Exit(1)
 	}
-	defer db.Close()
+	defer store.Close()
+	db := store.DB()
 
 	// Distribution: today = ~3000, yesterday = ~2000, day-2 = ~1500, etc.
 	// Total ~11,500 logs across 7 days.
@@ -223,6 +223,12 @@ func main() {
 		totalInserted += dp.count
 	}
 
+	// Rows inserted directly bypass the dashboard rollups.
+	if err := store.RebuildRollups(); err != nil {
+		fmt.Fprintf(os.Stderr, "rebuild rollups: %v\n", err)
+		os.Exit(1)
+	}
+
 	fmt.Printf("\nDone! Inserted %d logs across 7 days.\n", totalInserted)
 
 	// Quick verification
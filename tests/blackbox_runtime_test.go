@@ -269,8 +269,7 @@ func (s *blackboxServer) waitExited(timeout time.Duration) (error, bool) {
 
 func appCountHTTP(t *testing.T, addr, app string) int64 {
 	t.Helper()
-	escaped := strings.ReplaceAll(app, "'", "''")
-	code, resp, err := postSQL(addr, fmt.Sprintf("SELECT COUNT(*) AS c FROM logs WHERE app = '%s'", escaped))
+	code, resp, err := postSQL(addr, "SELECT COUNT(*) AS c FROM logs WHERE app = ?", app)
 	if err != nil || code != http.StatusOK || len(resp.Rows) != 1 {
 		return -1
 	}
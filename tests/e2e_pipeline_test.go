@@ -271,9 +271,9 @@ type sqlResponse struct {
 	RowCount int                      `json:"row_count"`
 }
 
-func postSQL(addr, sql string) (int, sqlResponse, error) {
+func postSQL(addr, sql string, params ...any) (int, sqlResponse, error) {
 	var out sqlResponse
-	body, err := json.Marshal(map[string]string{"sql": sql})
+	body, err := json.Marshal(map[string]any{"sql": sql, "params": params})
 	if err != nil {
 		return 0, out, err
 	}